@@ -0,0 +1,15 @@
+/*
+Package motfixtures provides tiny MOTChallenge-style sequences for hermetic
+integration tests of the parser, tracker, predictions and metrics.
+
+Each sequence has the MOT17 directory layout (seqinfo.ini, det/det.txt and
+gt/gt.txt) and is embedded into the test binary, so no dataset download is needed.
+The sequences are generated from norfairgo simulations; regenerate them with:
+
+	go generate ./internal/motfixtures
+
+Internal package, not intended for external use.
+*/
+package motfixtures
+
+//go:generate go run ./gen -out data
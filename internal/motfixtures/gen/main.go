@@ -0,0 +1,83 @@
+// Command gen writes the motfixtures sequences from deterministic simulations.
+//
+// Usage (from internal/motfixtures, see go generate):
+//
+//	go run ./gen -out data
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// sequences are the generated sequences by name. Keep them tiny: they are embedded
+// into every test binary that uses them.
+var sequences = map[string]*norfairgo.SimulationConfig{
+	// Clean detections of a few objects
+	"MINI-01": {NumObjects: 3, NumFrames: 12, FrameWidth: 640, FrameHeight: 480, BoxSize: 40, MaxSpeed: 6, Seed: 1},
+
+	// Noisy detections with misses and false positives
+	"MINI-02": {
+		NumObjects: 4, NumFrames: 15, FrameWidth: 640, FrameHeight: 480, BoxSize: 40, MaxSpeed: 6,
+		NoiseStd: 1.5, MissRate: 0.1, FalsePositiveRate: 0.2, Seed: 2,
+	},
+}
+
+func main() {
+	out := flag.String("out", "data", "output directory")
+	flag.Parse()
+
+	for name, config := range sequences {
+		if err := writeSequence(filepath.Join(*out, name), name, config); err != nil {
+			log.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// writeSequence writes one simulated sequence in the MOT17 layout.
+func writeSequence(dir, name string, config *norfairgo.SimulationConfig) error {
+	simulation, err := norfairgo.NewSimulation(config)
+	if err != nil {
+		return err
+	}
+
+	var gt, det strings.Builder
+	for _, frame := range simulation.Frames {
+		for i, box := range frame.GTBBoxes {
+			// frame,id,x,y,w,h,consider,class,visibility
+			fmt.Fprintf(&gt, "%d,%d,%.2f,%.2f,%.2f,%.2f,1,1,1\n",
+				frame.FrameID, frame.GTIDs[i], box[0], box[1], box[2]-box[0], box[3]-box[1])
+		}
+		for _, detection := range frame.Detections {
+			// frame,-1,x,y,w,h,conf,-1,-1,-1
+			x1, y1 := detection.Points.At(0, 0), detection.Points.At(0, 1)
+			x2, y2 := detection.Points.At(1, 0), detection.Points.At(1, 1)
+			fmt.Fprintf(&det, "%d,-1,%.2f,%.2f,%.2f,%.2f,1,-1,-1,-1\n", frame.FrameID, x1, y1, x2-x1, y2-y1)
+		}
+	}
+
+	seqinfo := fmt.Sprintf("[Sequence]\nname=%s\nimDir=img1\nframeRate=30\nseqLength=%d\nimWidth=%.0f\nimHeight=%.0f\nimExt=.jpg\n",
+		name, len(simulation.Frames), config.FrameWidth, config.FrameHeight)
+
+	files := map[string]string{
+		"seqinfo.ini": seqinfo,
+		"gt/gt.txt":   gt.String(),
+		"det/det.txt": det.String(),
+	}
+	for path, contents := range files {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, []byte(contents), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package motfixtures
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+//go:embed data
+var data embed.FS
+
+// Names returns the names of the embedded sequences, sorted.
+func Names() []string {
+	entries, err := data.ReadDir("data")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FS returns the file system of a sequence (seqinfo.ini, det/det.txt, gt/gt.txt).
+func FS(name string) (fs.FS, error) {
+	return fs.Sub(data, filepath.ToSlash(filepath.Join("data", name)))
+}
+
+// Extract copies a sequence into a temporary directory removed after the test, and
+// returns the sequence directory for the file-based loaders (e.g.
+// NewDetectionFileParser and LoadMotchallenge).
+func Extract(t testing.TB, name string) string {
+	t.Helper()
+	sequence, err := FS(name)
+	if err != nil {
+		t.Fatalf("unknown sequence %q: %v", name, err)
+	}
+
+	dir := filepath.Join(t.TempDir(), name)
+	err = fs.WalkDir(sequence, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		contents, err := fs.ReadFile(sequence, path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, contents, 0644)
+	})
+	if err != nil {
+		t.Fatalf("failed to extract sequence %q: %v", name, err)
+	}
+	return dir
+}
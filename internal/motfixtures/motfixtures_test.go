@@ -0,0 +1,22 @@
+package motfixtures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	names := Names()
+	if len(names) == 0 {
+		t.Fatal("Expected embedded sequences")
+	}
+	for _, name := range names {
+		dir := Extract(t, name)
+		for _, file := range []string{"seqinfo.ini", "det/det.txt", "gt/gt.txt"} {
+			if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file))); err != nil || info.Size() == 0 {
+				t.Errorf("%s: missing or empty %s (%v)", name, file, err)
+			}
+		}
+	}
+}
@@ -10,6 +10,11 @@
 
 package motmetrics
 
+import (
+	"math"
+	"sort"
+)
+
 // TrackLifecycle tracks the lifecycle of a single ground truth object.
 //
 // This is a Go port of py-motmetrics track lifecycle tracking used to compute
@@ -21,8 +26,8 @@ type TrackLifecycle struct {
 	GTID int // Ground truth object ID
 
 	// Lifetime span
-	FirstFrame int // First frame where this GT object appeared
-	LastFrame  int // Last frame where this GT object appeared
+	FirstFrame int64 // First frame where this GT object appeared
+	LastFrame  int64 // Last frame where this GT object appeared
 
 	// Tracking quality
 	TrackedFrames  int  // Number of frames where object was matched
@@ -32,7 +37,7 @@ type TrackLifecycle struct {
 }
 
 // NewTrackLifecycle creates a new lifecycle tracker for a GT object.
-func NewTrackLifecycle(gtID int, firstFrame int) *TrackLifecycle {
+func NewTrackLifecycle(gtID int, firstFrame int64) *TrackLifecycle {
 	return &TrackLifecycle{
 		GTID:           gtID,
 		FirstFrame:     firstFrame,
@@ -45,7 +50,7 @@ func NewTrackLifecycle(gtID int, firstFrame int) *TrackLifecycle {
 }
 
 // UpdateMatched updates lifecycle when object is matched (tracked).
-func (tl *TrackLifecycle) UpdateMatched(frameID int) {
+func (tl *TrackLifecycle) UpdateMatched(frameID int64) {
 	tl.LastFrame = frameID
 	tl.DetectedFrames++
 	tl.TrackedFrames++
@@ -59,7 +64,7 @@ func (tl *TrackLifecycle) UpdateMatched(frameID int) {
 }
 
 // UpdateMissed updates lifecycle when object is missed (not tracked).
-func (tl *TrackLifecycle) UpdateMissed(frameID int) {
+func (tl *TrackLifecycle) UpdateMissed(frameID int64) {
 	tl.LastFrame = frameID
 	tl.DetectedFrames++
 	tl.WasMatched = false
@@ -75,6 +80,34 @@ func (tl *TrackLifecycle) Coverage() float64 {
 	return float64(tl.TrackedFrames) / float64(tl.DetectedFrames)
 }
 
+// =============================================================================
+// Event - Per-Frame Tracking Event Log
+// =============================================================================
+
+// EventType identifies the kind of a recorded tracking event.
+type EventType string
+
+// Event types recorded by MOTAccumulator (subset of py-motmetrics event types).
+const (
+	EventMatch         EventType = "MATCH"  // GT matched to the same tracker ID as before
+	EventSwitch        EventType = "SWITCH" // GT matched to a different tracker ID than before
+	EventFalsePositive EventType = "FP"     // Tracker detection without GT match
+	EventMiss          EventType = "MISS"   // GT object without tracker match
+)
+
+// Event is a single row of the accumulator event log.
+//
+// This mirrors a row of the py-motmetrics MOTAccumulator.events DataFrame.
+// GTID is -1 for false positives, PredID is -1 for misses, and Distance is NaN
+// for both.
+type Event struct {
+	FrameID  int64     // Frame number
+	Type     EventType // Event type
+	GTID     int       // Ground truth object ID (OId)
+	PredID   int       // Tracker object ID (HId)
+	Distance float64   // IoU distance of the pair (D)
+}
+
 // =============================================================================
 // MOTAccumulator - Per-Video Tracking Event Accumulation
 // =============================================================================
@@ -101,10 +134,39 @@ type MOTAccumulator struct {
 
 	// ID switch detection (tracks GT→Tracker mapping across frames)
 	PreviousMapping map[int]int // map[gtID]trackerID from previous frame
-	FrameID         int         // Current frame number (1-indexed)
+	FrameID         int64       // Current frame number (1-indexed)
 
 	// Track lifecycle tracking (for MT/ML/PT/Frag metrics)
 	TrackLifecycles map[int]*TrackLifecycle // map[gtID]*lifecycle
+
+	// Identity tracking (for IDP/IDR/IDF1 metrics)
+	GTIDFrames   map[int]int    // map[gtID]number of frames the GT object appeared in
+	PredIDFrames map[int]int    // map[predID]number of frames the tracker object appeared in
+	IDPairFrames map[[2]int]int // map[[gtID, predID]]number of frames the pair was within threshold
+
+	// Identity coverage for confusion analysis
+	CoverageSpans map[int][]CoverageSpan // map[gtID]contiguous spans matched to a single tracker ID
+
+	// Event log (only populated when RecordEvents is true)
+	RecordEvents bool    // Record every MATCH/SWITCH/FP/MISS event in Events
+	Events       []Event // Recorded events in frame order
+
+	// Per-frame counts (only populated when RecordFrames is true)
+	RecordFrames bool          // Record the counts of every frame in Frames
+	Frames       []FrameCounts // Recorded counts in frame order
+}
+
+// FrameCounts are the event counts of a single frame, e.g. for resampling frames
+// when bootstrapping confidence intervals.
+type FrameCounts struct {
+	FrameID        int64
+	Matches        int
+	FalsePositives int
+	Misses         int
+	Switches       int
+	Objects        int      // GT objects of the frame
+	Predictions    int      // Tracker objects of the frame
+	IDPairs        [][2]int // [gtID, predID] pairs within threshold (for ID metrics)
 }
 
 // NewMOTAccumulator creates a new accumulator for a single video sequence.
@@ -120,6 +182,10 @@ func NewMOTAccumulator(videoName string) *MOTAccumulator {
 		VideoName:       videoName,
 		PreviousMapping: make(map[int]int),
 		TrackLifecycles: make(map[int]*TrackLifecycle),
+		GTIDFrames:      make(map[int]int),
+		PredIDFrames:    make(map[int]int),
+		IDPairFrames:    make(map[[2]int]int),
+		CoverageSpans:   make(map[int][]CoverageSpan),
 		FrameID:         0, // Will increment to 1 on first update
 	}
 }
@@ -149,6 +215,28 @@ func (acc *MOTAccumulator) Update(
 ) {
 	acc.FrameID++ // 1-indexed frames (MOTChallenge standard)
 
+	// Record the counts of this frame as the difference of the totals
+	var frame *FrameCounts
+	if acc.RecordFrames {
+		frame = &FrameCounts{FrameID: acc.FrameID, Objects: len(gtBBoxes), Predictions: len(predBBoxes)}
+		matches, fps, misses, switches := acc.NumMatches, acc.NumFalsePositives, acc.NumMisses, acc.NumSwitches
+		defer func() {
+			frame.Matches = acc.NumMatches - matches
+			frame.FalsePositives = acc.NumFalsePositives - fps
+			frame.Misses = acc.NumMisses - misses
+			frame.Switches = acc.NumSwitches - switches
+			acc.Frames = append(acc.Frames, *frame)
+		}()
+	}
+
+	// Count identity appearances for ID metrics
+	for _, gtID := range gtIDs {
+		acc.GTIDFrames[gtID]++
+	}
+	for _, predID := range predIDs {
+		acc.PredIDFrames[predID]++
+	}
+
 	// Edge case: no GT, no predictions
 	if len(gtBBoxes) == 0 && len(predBBoxes) == 0 {
 		return
@@ -157,6 +245,9 @@ func (acc *MOTAccumulator) Update(
 	// Edge case: no GT, only predictions → all false positives
 	if len(gtBBoxes) == 0 {
 		acc.NumFalsePositives += len(predBBoxes)
+		for _, predID := range predIDs {
+			acc.recordEvent(EventFalsePositive, -1, predID, math.NaN())
+		}
 		return
 	}
 
@@ -173,6 +264,7 @@ func (acc *MOTAccumulator) Update(
 				acc.TrackLifecycles[gtID] = lifecycle
 			}
 			lifecycle.UpdateMissed(acc.FrameID)
+			acc.recordEvent(EventMiss, gtID, -1, math.NaN())
 		}
 		return
 	}
@@ -180,6 +272,19 @@ func (acc *MOTAccumulator) Update(
 	// Compute IoU distance matrix
 	distanceMatrix := ComputeIoUMatrix(gtBBoxes, predBBoxes)
 
+	// Count every GT/prediction pair within threshold for ID metrics
+	for gtIdx := range distanceMatrix {
+		for predIdx, distance := range distanceMatrix[gtIdx] {
+			if distance <= threshold {
+				pair := [2]int{gtIDs[gtIdx], predIDs[predIdx]}
+				acc.IDPairFrames[pair]++
+				if frame != nil {
+					frame.IDPairs = append(frame.IDPairs, pair)
+				}
+			}
+		}
+	}
+
 	// Hungarian matching with threshold
 	matches, unmatchedGT, unmatchedPred := hungarianFn(distanceMatrix, threshold)
 
@@ -195,7 +300,7 @@ func (acc *MOTAccumulator) Update(
 		acc.TotalDistance += distanceMatrix[gtIdx][predIdx]
 	}
 
-	// Update lifecycles for matched GT objects
+	// Update lifecycles and coverage spans for matched GT objects
 	for _, match := range matches {
 		gtIdx := match[0]
 		gtID := gtIDs[gtIdx]
@@ -205,6 +310,7 @@ func (acc *MOTAccumulator) Update(
 			lifecycle = NewTrackLifecycle(gtID, acc.FrameID)
 			acc.TrackLifecycles[gtID] = lifecycle
 		}
+		acc.updateCoverage(gtID, predIDs[match[1]], lifecycle.LastFrame)
 		lifecycle.UpdateMatched(acc.FrameID)
 	}
 
@@ -221,11 +327,84 @@ func (acc *MOTAccumulator) Update(
 	}
 
 	// Detect ID switches
-	switches := acc.detectSwitches(matches, gtIDs, predIDs)
-	acc.NumSwitches += switches
+	isSwitch := acc.detectSwitches(matches, gtIDs, predIDs)
+	for _, switched := range isSwitch {
+		if switched {
+			acc.NumSwitches++
+		}
+	}
+
+	// Record events (matches first, then misses and false positives like py-motmetrics)
+	if acc.RecordEvents {
+		for i, match := range matches {
+			eventType := EventMatch
+			if isSwitch[i] {
+				eventType = EventSwitch
+			}
+			acc.recordEvent(eventType, gtIDs[match[0]], predIDs[match[1]], distanceMatrix[match[0]][match[1]])
+		}
+		for _, gtIdx := range unmatchedGT {
+			acc.recordEvent(EventMiss, gtIDs[gtIdx], -1, math.NaN())
+		}
+		for _, predIdx := range unmatchedPred {
+			acc.recordEvent(EventFalsePositive, -1, predIDs[predIdx], math.NaN())
+		}
+	}
+}
+
+// UpdateFrame processes a frame with an explicit frame number.
+//
+// This is equivalent to py-motmetrics update(..., frameid=frameID): the frame
+// number is used for lifecycles and events instead of the auto-incremented one.
+// Frame numbers should be increasing across calls.
+func (acc *MOTAccumulator) UpdateFrame(
+	frameID int64,
+	gtBBoxes [][]float64,
+	gtIDs []int,
+	predBBoxes [][]float64,
+	predIDs []int,
+	threshold float64,
+	hungarianFn func([][]float64, float64) ([][2]int, []int, []int),
+) {
+	acc.FrameID = frameID - 1 // Update increments before use
+	acc.Update(gtBBoxes, gtIDs, predBBoxes, predIDs, threshold, hungarianFn)
+}
+
+// recordEvent appends an event for the current frame if event recording is enabled.
+func (acc *MOTAccumulator) recordEvent(eventType EventType, gtID, predID int, distance float64) {
+	if !acc.RecordEvents {
+		return
+	}
+	acc.Events = append(acc.Events, Event{
+		FrameID:  acc.FrameID,
+		Type:     eventType,
+		GTID:     gtID,
+		PredID:   predID,
+		Distance: distance,
+	})
+}
+
+// updateCoverage extends the GT object's current coverage span or starts a new one.
+//
+// A span continues while the same tracker ID is matched and the GT object was
+// not missed since the span's last frame (lastSeenFrame is the GT lifecycle's
+// last frame before this update).
+func (acc *MOTAccumulator) updateCoverage(gtID, predID int, lastSeenFrame int64) {
+	spans := acc.CoverageSpans[gtID]
+	if n := len(spans); n > 0 && spans[n-1].PredID == predID && spans[n-1].EndFrame == lastSeenFrame {
+		spans[n-1].EndFrame = acc.FrameID
+		spans[n-1].NumFrames++
+		return
+	}
+	acc.CoverageSpans[gtID] = append(spans, CoverageSpan{
+		PredID:     predID,
+		StartFrame: acc.FrameID,
+		EndFrame:   acc.FrameID,
+		NumFrames:  1,
+	})
 }
 
-// detectSwitches counts ID switches by comparing current to previous frame mappings.
+// detectSwitches finds ID switches by comparing current to previous frame mappings.
 //
 // An ID switch occurs when the same GT object is matched to a different tracker ID
 // compared to the previous frame.
@@ -235,21 +414,21 @@ func (acc *MOTAccumulator) Update(
 //   - gtIDs: Ground truth IDs
 //   - predIDs: Tracker IDs
 //
-// Returns: Number of ID switches detected in this frame
+// Returns: Per-match flags, true where the match is an ID switch
 //
 // Reference: https://github.com/cheind/py-motmetrics/blob/master/motmetrics/mot.py
-func (acc *MOTAccumulator) detectSwitches(matches [][2]int, gtIDs, predIDs []int) int {
-	switches := 0
+func (acc *MOTAccumulator) detectSwitches(matches [][2]int, gtIDs, predIDs []int) []bool {
+	switches := make([]bool, len(matches))
 	currentMapping := make(map[int]int)
 
-	for _, match := range matches {
+	for i, match := range matches {
 		gtID := gtIDs[match[0]]
 		predID := predIDs[match[1]]
 
 		// Check if this GT was tracked in previous frame
 		if prevPredID, exists := acc.PreviousMapping[gtID]; exists {
 			if prevPredID != predID {
-				switches++ // Same GT, different tracker ID = switch
+				switches[i] = true // Same GT, different tracker ID = switch
 			}
 		}
 		// Note: First appearance of GT is NOT a switch
@@ -297,3 +476,147 @@ func (acc *MOTAccumulator) ComputeExtendedMetrics() (int, int, int, int) {
 
 	return mt, ml, pt, totalFragmentations
 }
+
+// ComputeIDMetrics computes identity true positives, false positives and false
+// negatives by globally assigning GT IDs to tracker IDs.
+//
+// This is a Go port of py-motmetrics id_global_assignment: each GT trajectory is
+// matched to at most one tracker trajectory such that the number of frames in
+// which the pair is within threshold is maximised.
+//
+// Parameters:
+//   - hungarianFn: Hungarian matching function (accepts cost matrix and threshold)
+//
+// Returns:
+//   - IDTP: Frames where GT and its assigned tracker ID coincide
+//   - IDFP: Tracker detections not covered by their assigned GT ID
+//   - IDFN: GT detections not covered by their assigned tracker ID
+//
+// Reference: https://github.com/cheind/py-motmetrics/blob/master/motmetrics/metrics.py
+func (acc *MOTAccumulator) ComputeIDMetrics(
+	hungarianFn func([][]float64, float64) ([][2]int, []int, []int),
+) (int, int, int) {
+	totalGT := 0
+	for _, count := range acc.GTIDFrames {
+		totalGT += count
+	}
+	totalPred := 0
+	for _, count := range acc.PredIDFrames {
+		totalPred += count
+	}
+
+	idtp := 0
+	for pair := range acc.IDAssignment(hungarianFn) {
+		idtp += acc.IDPairFrames[pair]
+	}
+
+	return idtp, totalPred - idtp, totalGT - idtp
+}
+
+// IDAssignment returns the global assignment of GT IDs to tracker IDs used by
+// ComputeIDMetrics, as a set of [gtID, predID] pairs.
+//
+// Parameters:
+//   - hungarianFn: Hungarian matching function (accepts cost matrix and threshold)
+func (acc *MOTAccumulator) IDAssignment(
+	hungarianFn func([][]float64, float64) ([][2]int, []int, []int),
+) map[[2]int]bool {
+	assignment := make(map[[2]int]bool)
+	if len(acc.IDPairFrames) == 0 {
+		return assignment
+	}
+
+	// Index GT and tracker IDs that share at least one frame
+	gtIndex := make(map[int]int)
+	predIndex := make(map[int]int)
+	var gtIDs, predIDs []int
+	maxCount := 0
+	for pair, count := range acc.IDPairFrames {
+		if _, exists := gtIndex[pair[0]]; !exists {
+			gtIndex[pair[0]] = len(gtIndex)
+			gtIDs = append(gtIDs, pair[0])
+		}
+		if _, exists := predIndex[pair[1]]; !exists {
+			predIndex[pair[1]] = len(predIndex)
+			predIDs = append(predIDs, pair[1])
+		}
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	// Cost is normalised to [0, 1] so pairs sharing more frames are cheaper;
+	// pairs that never overlapped have cost 1 and are rejected by the threshold.
+	costMatrix := make([][]float64, len(gtIndex))
+	for i := range costMatrix {
+		costMatrix[i] = make([]float64, len(predIndex))
+		for j := range costMatrix[i] {
+			costMatrix[i][j] = 1.0
+		}
+	}
+	for pair, count := range acc.IDPairFrames {
+		i, j := gtIndex[pair[0]], predIndex[pair[1]]
+		costMatrix[i][j] = 1.0 - float64(count)/float64(maxCount+1)
+	}
+
+	matches, _, _ := hungarianFn(costMatrix, 1.0-0.5/float64(maxCount+1))
+	for _, match := range matches {
+		assignment[[2]int{gtIDs[match[0]], predIDs[match[1]]}] = true
+	}
+	return assignment
+}
+
+// =============================================================================
+// Confusion Report - GT/Tracker Identity Coverage
+// =============================================================================
+
+// CoverageSpan is a run of frames in which a GT object was matched to one tracker ID.
+type CoverageSpan struct {
+	PredID     int   // Tracker object ID covering the GT object
+	StartFrame int64 // First matched frame of the span
+	EndFrame   int64 // Last matched frame of the span
+	NumFrames  int   // Number of matched frames in the span
+}
+
+// GTCoverage summarizes which tracker IDs covered a single GT object.
+type GTCoverage struct {
+	GTID      int            // Ground truth object ID
+	NumFrames int            // Number of frames the GT object appeared in
+	PredIDs   []int          // Distinct tracker IDs in order of first match
+	Spans     []CoverageSpan // Coverage spans in frame order
+}
+
+// IsFragmented reports whether the GT object was covered by more than one tracker ID.
+func (c GTCoverage) IsFragmented() bool {
+	return len(c.PredIDs) > 1
+}
+
+// ConfusionReport lists, for every GT object, the tracker IDs that covered it.
+//
+// GT objects that were never matched are included with no spans, so the report
+// accounts for every identity seen by the accumulator.
+//
+// Returns: Coverage per GT object, sorted by GT ID
+func (acc *MOTAccumulator) ConfusionReport() []GTCoverage {
+	report := make([]GTCoverage, 0, len(acc.GTIDFrames))
+	for gtID, numFrames := range acc.GTIDFrames {
+		spans := acc.CoverageSpans[gtID]
+		coverage := GTCoverage{
+			GTID:      gtID,
+			NumFrames: numFrames,
+			Spans:     append([]CoverageSpan(nil), spans...),
+		}
+
+		seen := make(map[int]bool)
+		for _, span := range spans {
+			if !seen[span.PredID] {
+				seen[span.PredID] = true
+				coverage.PredIDs = append(coverage.PredIDs, span.PredID)
+			}
+		}
+		report = append(report, coverage)
+	}
+
+	sort.Slice(report, func(i, j int) bool { return report[i].GTID < report[j].GTID })
+	return report
+}
@@ -1,6 +1,7 @@
 package motmetrics
 
 import (
+	"math"
 	"testing"
 
 	"github.com/nmichlo/norfair-go/internal/testutil"
@@ -418,16 +419,16 @@ func TestComputeExtendedMetrics_MostlyTracked(t *testing.T) {
 	// Create lifecycle with 80% coverage (MT threshold)
 	lifecycle1 := NewTrackLifecycle(1, 1)
 	for i := 0; i < 8; i++ {
-		lifecycle1.UpdateMatched(i + 1)
+		lifecycle1.UpdateMatched(int64(i + 1))
 	}
 	for i := 0; i < 2; i++ {
-		lifecycle1.UpdateMissed(i + 9)
+		lifecycle1.UpdateMissed(int64(i + 9))
 	}
 
 	// Create lifecycle with 100% coverage
 	lifecycle2 := NewTrackLifecycle(2, 1)
 	for i := 0; i < 10; i++ {
-		lifecycle2.UpdateMatched(i + 1)
+		lifecycle2.UpdateMatched(int64(i + 1))
 	}
 
 	acc.TrackLifecycles[1] = lifecycle1
@@ -453,14 +454,14 @@ func TestComputeExtendedMetrics_MostlyLost(t *testing.T) {
 	// Create lifecycle with 0% coverage
 	lifecycle1 := NewTrackLifecycle(1, 1)
 	for i := 0; i < 10; i++ {
-		lifecycle1.UpdateMissed(i + 1)
+		lifecycle1.UpdateMissed(int64(i + 1))
 	}
 
 	// Create lifecycle with 16.67% coverage (1/6, just below 20%)
 	lifecycle2 := NewTrackLifecycle(2, 1)
 	lifecycle2.UpdateMatched(1)
 	for i := 0; i < 5; i++ {
-		lifecycle2.UpdateMissed(i + 2)
+		lifecycle2.UpdateMissed(int64(i + 2))
 	}
 
 	acc.TrackLifecycles[1] = lifecycle1
@@ -485,7 +486,7 @@ func TestComputeExtendedMetrics_PartiallyTracked(t *testing.T) {
 
 	// Create lifecycle with 50% coverage
 	lifecycle := NewTrackLifecycle(1, 1)
-	for i := 0; i < 5; i++ {
+	for i := int64(0); i < 5; i++ {
 		lifecycle.UpdateMatched(i*2 + 1)
 		lifecycle.UpdateMissed(i*2 + 2)
 	}
@@ -532,10 +533,193 @@ func TestComputeExtendedMetrics_Fragmentations(t *testing.T) {
 	}
 }
 
+// TestComputeIDMetrics_GlobalAssignment verifies identity assignment across frames
+func TestComputeIDMetrics_GlobalAssignment(t *testing.T) {
+	acc := NewMOTAccumulator("test")
+
+	box := []float64{0, 0, 10, 10}
+	farBox := []float64{100, 100, 110, 110}
+
+	// Frames 1-3: GT 1 covered by tracker 10, GT 2 never covered
+	for i := 0; i < 3; i++ {
+		acc.Update(
+			[][]float64{box, farBox}, []int{1, 2},
+			[][]float64{box}, []int{10},
+			0.5, greedyHungarian,
+		)
+	}
+
+	// Frame 4: GT 1 covered by tracker 20 (identity switch)
+	acc.Update(
+		[][]float64{box}, []int{1},
+		[][]float64{box}, []int{20},
+		0.5, greedyHungarian,
+	)
+
+	idtp, idfp, idfn := acc.ComputeIDMetrics(greedyHungarian)
+
+	// GT 1 is assigned to tracker 10 (3 frames); tracker 20 and GT 2 stay unassigned
+	if idtp != 3 {
+		t.Errorf("Expected IDTP=3, got %d", idtp)
+	}
+	if idfp != 1 {
+		t.Errorf("Expected IDFP=1, got %d", idfp)
+	}
+	if idfn != 4 {
+		t.Errorf("Expected IDFN=4, got %d", idfn)
+	}
+}
+
+// TestComputeIDMetrics_NoOverlap verifies ID metrics without any overlapping pairs
+func TestComputeIDMetrics_NoOverlap(t *testing.T) {
+	acc := NewMOTAccumulator("test")
+
+	acc.Update([][]float64{}, []int{}, [][]float64{{0, 0, 10, 10}}, []int{1}, 0.5, mockHungarian)
+	acc.Update([][]float64{{0, 0, 10, 10}}, []int{1}, [][]float64{}, []int{}, 0.5, mockHungarian)
+
+	idtp, idfp, idfn := acc.ComputeIDMetrics(mockHungarian)
+
+	if idtp != 0 || idfp != 1 || idfn != 1 {
+		t.Errorf("Expected IDTP=0, IDFP=1, IDFN=1, got %d, %d, %d", idtp, idfp, idfn)
+	}
+}
+
 // ==============================================================================
 // Helper Functions
 // ==============================================================================
 
+// TestEventLog_RecordsMatchSwitchMissFP verifies per-frame events and their order
+func TestEventLog_RecordsMatchSwitchMissFP(t *testing.T) {
+	acc := NewMOTAccumulator("test")
+	acc.RecordEvents = true
+
+	box := []float64{0, 0, 10, 10}
+	far := []float64{100, 100, 110, 110}
+
+	// Frame 5: GT 1 matched to tracker 10, tracker 11 is a false positive
+	acc.UpdateFrame(5, [][]float64{box}, []int{1}, [][]float64{box, far}, []int{10, 11}, 0.5, greedyHungarian)
+	// Frame 6: GT 1 matched to tracker 20 (switch), GT 2 missed
+	acc.UpdateFrame(6, [][]float64{box, far}, []int{1, 2}, [][]float64{box}, []int{20}, 0.5, greedyHungarian)
+	// Frame 7: no predictions at all
+	acc.UpdateFrame(7, [][]float64{box}, []int{1}, nil, nil, 0.5, greedyHungarian)
+
+	expected := []Event{
+		{FrameID: 5, Type: EventMatch, GTID: 1, PredID: 10},
+		{FrameID: 5, Type: EventFalsePositive, GTID: -1, PredID: 11},
+		{FrameID: 6, Type: EventSwitch, GTID: 1, PredID: 20},
+		{FrameID: 6, Type: EventMiss, GTID: 2, PredID: -1},
+		{FrameID: 7, Type: EventMiss, GTID: 1, PredID: -1},
+	}
+	if len(acc.Events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(expected), len(acc.Events), acc.Events)
+	}
+	for i, want := range expected {
+		got := acc.Events[i]
+		if got.FrameID != want.FrameID || got.Type != want.Type || got.GTID != want.GTID || got.PredID != want.PredID {
+			t.Errorf("Event %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if acc.Events[0].Distance != 0 {
+		t.Errorf("Expected match distance 0, got %f", acc.Events[0].Distance)
+	}
+	if !math.IsNaN(acc.Events[1].Distance) {
+		t.Errorf("Expected NaN distance for false positive, got %f", acc.Events[1].Distance)
+	}
+	if acc.NumSwitches != 1 {
+		t.Errorf("Expected 1 switch, got %d", acc.NumSwitches)
+	}
+}
+
+// TestUpdateFrame_LargeFrameIDs verifies frame IDs beyond 32 bits are kept intact
+func TestUpdateFrame_LargeFrameIDs(t *testing.T) {
+	acc := NewMOTAccumulator("test")
+	acc.RecordEvents = true
+
+	box := []float64{0, 0, 10, 10}
+	start := int64(1) << 40
+	acc.UpdateFrame(start, [][]float64{box}, []int{1}, [][]float64{box}, []int{10}, 0.5, greedyHungarian)
+	acc.UpdateFrame(start+1, [][]float64{box}, []int{1}, nil, nil, 0.5, greedyHungarian)
+	acc.UpdateFrame(start+2, [][]float64{box}, []int{1}, [][]float64{box}, []int{10}, 0.5, greedyHungarian)
+
+	if acc.FrameID != start+2 {
+		t.Errorf("Expected FrameID %d, got %d", start+2, acc.FrameID)
+	}
+	if len(acc.Events) != 3 || acc.Events[2].FrameID != start+2 {
+		t.Fatalf("Expected 3 events ending at frame %d, got %+v", start+2, acc.Events)
+	}
+
+	lifecycle := acc.TrackLifecycles[1]
+	if lifecycle.FirstFrame != start || lifecycle.LastFrame != start+2 {
+		t.Errorf("Expected lifecycle frames [%d, %d], got [%d, %d]", start, start+2, lifecycle.FirstFrame, lifecycle.LastFrame)
+	}
+	if lifecycle.Fragmentations != 1 {
+		t.Errorf("Expected 1 fragmentation, got %d", lifecycle.Fragmentations)
+	}
+
+	spans := acc.ConfusionReport()[0].Spans
+	if len(spans) != 2 || spans[1].StartFrame != start+2 || spans[1].EndFrame != start+2 {
+		t.Errorf("Expected a second span at frame %d, got %+v", start+2, spans)
+	}
+}
+
+// TestEventLog_DisabledByDefault verifies no events are kept unless requested
+func TestEventLog_DisabledByDefault(t *testing.T) {
+	acc := NewMOTAccumulator("test")
+	box := []float64{0, 0, 10, 10}
+	acc.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{10}, 0.5, greedyHungarian)
+
+	if len(acc.Events) != 0 {
+		t.Errorf("Expected no events when RecordEvents is false, got %d", len(acc.Events))
+	}
+}
+
+// TestConfusionReport_SpansPerTrackerID verifies coverage spans split on ID changes and misses
+func TestConfusionReport_SpansPerTrackerID(t *testing.T) {
+	acc := NewMOTAccumulator("test")
+
+	box := []float64{0, 0, 10, 10}
+	far := []float64{100, 100, 110, 110}
+
+	// GT 1: tracker 10 (frames 1-2), tracker 20 (frame 3), missed (frame 4), tracker 20 (frame 5)
+	acc.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{10}, 0.5, greedyHungarian)
+	acc.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{10}, 0.5, greedyHungarian)
+	acc.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{20}, 0.5, greedyHungarian)
+	acc.Update([][]float64{box}, []int{1}, [][]float64{far}, []int{20}, 0.5, greedyHungarian)
+	acc.Update([][]float64{box, far}, []int{1, 2}, [][]float64{box}, []int{20}, 0.5, greedyHungarian)
+
+	report := acc.ConfusionReport()
+	if len(report) != 2 {
+		t.Fatalf("Expected 2 GT objects in report, got %d", len(report))
+	}
+
+	gt1 := report[0]
+	if gt1.GTID != 1 || gt1.NumFrames != 5 || !gt1.IsFragmented() {
+		t.Errorf("Unexpected GT 1 coverage: %+v", gt1)
+	}
+	expected := []CoverageSpan{
+		{PredID: 10, StartFrame: 1, EndFrame: 2, NumFrames: 2},
+		{PredID: 20, StartFrame: 3, EndFrame: 3, NumFrames: 1},
+		{PredID: 20, StartFrame: 5, EndFrame: 5, NumFrames: 1},
+	}
+	if len(gt1.Spans) != len(expected) {
+		t.Fatalf("Expected %d spans, got %+v", len(expected), gt1.Spans)
+	}
+	for i, want := range expected {
+		if gt1.Spans[i] != want {
+			t.Errorf("Span %d: expected %+v, got %+v", i, want, gt1.Spans[i])
+		}
+	}
+	if len(gt1.PredIDs) != 2 || gt1.PredIDs[0] != 10 || gt1.PredIDs[1] != 20 {
+		t.Errorf("Expected PredIDs [10 20], got %v", gt1.PredIDs)
+	}
+
+	gt2 := report[1]
+	if gt2.GTID != 2 || len(gt2.Spans) != 0 || gt2.IsFragmented() {
+		t.Errorf("Expected unmatched GT 2 with no spans, got %+v", gt2)
+	}
+}
+
 // mockHungarian is a simple mock that returns no matches
 func mockHungarian(distances [][]float64, threshold float64) ([][2]int, []int, []int) {
 	numGT := len(distances)
@@ -556,3 +740,45 @@ func mockHungarian(distances [][]float64, threshold float64) ([][2]int, []int, [
 
 	return [][2]int{}, unmatchedGT, unmatchedPred
 }
+
+// greedyHungarian matches the cheapest pairs first (optimal for small unambiguous tests)
+func greedyHungarian(distances [][]float64, threshold float64) ([][2]int, []int, []int) {
+	numGT := len(distances)
+	numPred := 0
+	if numGT > 0 {
+		numPred = len(distances[0])
+	}
+
+	usedGT := make([]bool, numGT)
+	usedPred := make([]bool, numPred)
+	var matches [][2]int
+	for {
+		best, bestGT, bestPred := threshold, -1, -1
+		for i := 0; i < numGT; i++ {
+			for j := 0; j < numPred; j++ {
+				if !usedGT[i] && !usedPred[j] && distances[i][j] <= best {
+					best, bestGT, bestPred = distances[i][j], i, j
+				}
+			}
+		}
+		if bestGT < 0 {
+			break
+		}
+		usedGT[bestGT], usedPred[bestPred] = true, true
+		matches = append(matches, [2]int{bestGT, bestPred})
+	}
+
+	var unmatchedGT, unmatchedPred []int
+	for i, used := range usedGT {
+		if !used {
+			unmatchedGT = append(unmatchedGT, i)
+		}
+	}
+	for j, used := range usedPred {
+		if !used {
+			unmatchedPred = append(unmatchedPred, j)
+		}
+	}
+
+	return matches, unmatchedGT, unmatchedPred
+}
@@ -25,7 +25,8 @@ import (
 // Parameters:
 //   - XA: First set of vectors (m x n matrix)
 //   - XB: Second set of vectors (p x n matrix)
-//   - metric: Distance metric name ("euclidean", "cityblock"/"manhattan", "cosine", "sqeuclidean", "chebyshev")
+//   - metric: Distance metric name ("euclidean", "cityblock"/"manhattan", "cosine", "sqeuclidean",
+//     "chebyshev", "minkowski" (p=2, see CdistMinkowski), "seuclidean")
 //
 // Returns:
 //   - Distance matrix of shape (m x p)
@@ -54,6 +55,10 @@ func Cdist(XA, XB *mat.Dense, metric string) *mat.Dense {
 		cdistSquaredEuclidean(XA, XB, result)
 	case "chebyshev":
 		cdistChebyshev(XA, XB, result)
+	case "minkowski":
+		cdistMinkowski(XA, XB, 2, result)
+	case "seuclidean":
+		cdistStandardizedEuclidean(XA, XB, result)
 	default:
 		panic(fmt.Sprintf("unsupported metric: %s", metric))
 	}
@@ -61,6 +66,56 @@ func Cdist(XA, XB *mat.Dense, metric string) *mat.Dense {
 	return result
 }
 
+// CdistMinkowski computes pairwise Minkowski distances (sum |u_i - v_i|^p)^(1/p)
+// between two sets of vectors, like cdist(XA, XB, "minkowski", p=p).
+//
+// p=1 is the cityblock distance, p=2 the euclidean distance and p=+Inf the
+// chebyshev distance.
+//
+// Panics if XA and XB have different number of columns or if p <= 0.
+func CdistMinkowski(XA, XB *mat.Dense, p float64) *mat.Dense {
+	rowsA, colsA := XA.Dims()
+	rowsB, colsB := XB.Dims()
+
+	if colsA != colsB {
+		panic(fmt.Sprintf("XA and XB must have same number of columns, got %d and %d", colsA, colsB))
+	}
+	if !(p > 0) {
+		panic(fmt.Sprintf("p must be greater than 0, got %v", p))
+	}
+
+	result := mat.NewDense(rowsA, rowsB, nil)
+	cdistMinkowski(XA, XB, p, result)
+	return result
+}
+
+// CdistStandardizedEuclidean computes pairwise euclidean distances with each
+// component divided by the given variance, like cdist(XA, XB, "seuclidean", V=V).
+// Unlike Cdist with "seuclidean", the variance does not depend on XA and XB.
+//
+// Panics if XA and XB have different number of columns, if V does not have one
+// entry per column or if an entry of V is not greater than 0.
+func CdistStandardizedEuclidean(XA, XB *mat.Dense, V []float64) *mat.Dense {
+	rowsA, colsA := XA.Dims()
+	rowsB, colsB := XB.Dims()
+
+	if colsA != colsB {
+		panic(fmt.Sprintf("XA and XB must have same number of columns, got %d and %d", colsA, colsB))
+	}
+	if len(V) != colsA {
+		panic(fmt.Sprintf("V must have one entry per column, got %d for %d columns", len(V), colsA))
+	}
+	for k, v := range V {
+		if !(v > 0) {
+			panic(fmt.Sprintf("V must be greater than 0, got %v at column %d", v, k))
+		}
+	}
+
+	result := mat.NewDense(rowsA, rowsB, nil)
+	cdistWeightedEuclidean(XA, XB, V, result)
+	return result
+}
+
 func cdistEuclidean(XA, XB, result *mat.Dense) {
 	rowsA, _ := XA.Dims()
 	rowsB, _ := XB.Dims()
@@ -158,3 +213,86 @@ func cdistChebyshev(XA, XB, result *mat.Dense) {
 		}
 	}
 }
+
+func cdistMinkowski(XA, XB *mat.Dense, p float64, result *mat.Dense) {
+	switch {
+	case p == 1:
+		cdistManhattan(XA, XB, result)
+		return
+	case p == 2:
+		cdistEuclidean(XA, XB, result)
+		return
+	case math.IsInf(p, 1):
+		cdistChebyshev(XA, XB, result)
+		return
+	}
+
+	rowsA, _ := XA.Dims()
+	rowsB, _ := XB.Dims()
+
+	for i := 0; i < rowsA; i++ {
+		for j := 0; j < rowsB; j++ {
+			rowA := XA.RawRowView(i)
+			rowB := XB.RawRowView(j)
+			var sum float64
+			for k := range rowA {
+				sum += math.Pow(math.Abs(rowA[k]-rowB[k]), p)
+			}
+			result.Set(i, j, math.Pow(sum, 1/p))
+		}
+	}
+}
+
+// cdistStandardizedEuclidean computes euclidean distances with each component
+// divided by its variance, which scipy defaults to the sample variance (ddof=1)
+// of the stacked XA and XB. Components with zero variance give NaN or +Inf, as in scipy.
+func cdistStandardizedEuclidean(XA, XB, result *mat.Dense) {
+	rowsA, cols := XA.Dims()
+	rowsB, _ := XB.Dims()
+
+	variance := make([]float64, cols)
+	n := float64(rowsA + rowsB)
+	for k := 0; k < cols; k++ {
+		var mean float64
+		for i := 0; i < rowsA; i++ {
+			mean += XA.At(i, k)
+		}
+		for j := 0; j < rowsB; j++ {
+			mean += XB.At(j, k)
+		}
+		mean /= n
+
+		var sum float64
+		for i := 0; i < rowsA; i++ {
+			diff := XA.At(i, k) - mean
+			sum += diff * diff
+		}
+		for j := 0; j < rowsB; j++ {
+			diff := XB.At(j, k) - mean
+			sum += diff * diff
+		}
+		variance[k] = sum / (n - 1)
+	}
+
+	cdistWeightedEuclidean(XA, XB, variance, result)
+}
+
+// cdistWeightedEuclidean computes euclidean distances with each squared component
+// difference divided by variance[k].
+func cdistWeightedEuclidean(XA, XB *mat.Dense, variance []float64, result *mat.Dense) {
+	rowsA, _ := XA.Dims()
+	rowsB, _ := XB.Dims()
+
+	for i := 0; i < rowsA; i++ {
+		for j := 0; j < rowsB; j++ {
+			rowA := XA.RawRowView(i)
+			rowB := XB.RawRowView(j)
+			var sum float64
+			for k := range rowA {
+				diff := rowA[k] - rowB[k]
+				sum += diff * diff / variance[k]
+			}
+			result.Set(i, j, math.Sqrt(sum))
+		}
+	}
+}
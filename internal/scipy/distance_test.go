@@ -261,3 +261,89 @@ func TestCdist_Cosine_AntiParallel(t *testing.T) {
 		t.Errorf("Cosine distance for anti-parallel vectors incorrect.\nGot:\n%v\nExpected:\n%v", mat.Formatted(result), mat.Formatted(expected))
 	}
 }
+
+func TestCdist_Minkowski(t *testing.T) {
+	XA := mat.NewDense(2, 2, []float64{
+		0, 0,
+		1, 2,
+	})
+	XB := mat.NewDense(1, 2, []float64{
+		3, 4,
+	})
+
+	// scipy.spatial.distance.cdist(XA, XB, "minkowski", p=3)
+	// [0,0] to [3,4]: (27 + 64)^(1/3) = 4.497941445275415
+	// [1,2] to [3,4]: (8 + 8)^(1/3) = 2.519842099789746
+	expected := mat.NewDense(2, 1, []float64{4.497941445275415, 2.519842099789746})
+	result := CdistMinkowski(XA, XB, 3)
+	if !mat.EqualApprox(result, expected, 1e-10) {
+		t.Errorf("Minkowski distance incorrect.\nGot:\n%v\nExpected:\n%v", mat.Formatted(result), mat.Formatted(expected))
+	}
+
+	// p=1, p=2 and p=inf match cityblock, euclidean and chebyshev
+	for p, metric := range map[float64]string{1: "cityblock", 2: "euclidean", math.Inf(1): "chebyshev"} {
+		if result := CdistMinkowski(XA, XB, p); !mat.EqualApprox(result, Cdist(XA, XB, metric), 1e-10) {
+			t.Errorf("Minkowski p=%v should match %s, got\n%v", p, metric, mat.Formatted(result))
+		}
+	}
+
+	// The "minkowski" metric defaults to p=2 like scipy
+	if result := Cdist(XA, XB, "minkowski"); !mat.EqualApprox(result, Cdist(XA, XB, "euclidean"), 1e-10) {
+		t.Errorf("Minkowski default should match euclidean, got\n%v", mat.Formatted(result))
+	}
+}
+
+func TestCdist_Minkowski_PanicOnInvalidP(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for p <= 0")
+		}
+	}()
+
+	XA := mat.NewDense(1, 2, []float64{1, 2})
+	CdistMinkowski(XA, XA, 0)
+}
+
+func TestCdist_StandardizedEuclidean(t *testing.T) {
+	XA := mat.NewDense(2, 2, []float64{
+		0, 0,
+		2, 0,
+	})
+	XB := mat.NewDense(1, 2, []float64{
+		1, 3,
+	})
+
+	// scipy.spatial.distance.cdist(XA, XB, "seuclidean")
+	// V = var([[0, 0], [2, 0], [1, 3]], axis=0, ddof=1) = [1, 3]
+	// [0,0] to [1,3]: sqrt(1/1 + 9/3) = 2
+	// [2,0] to [1,3]: sqrt(1/1 + 9/3) = 2
+	expected := mat.NewDense(2, 1, []float64{2, 2})
+	result := Cdist(XA, XB, "seuclidean")
+	if !mat.EqualApprox(result, expected, 1e-10) {
+		t.Errorf("Standardized euclidean distance incorrect.\nGot:\n%v\nExpected:\n%v", mat.Formatted(result), mat.Formatted(expected))
+	}
+}
+
+func TestCdistStandardizedEuclidean_FixedVariance(t *testing.T) {
+	// A y-only move has no x variance, which the fixed V does not depend on
+	XA := mat.NewDense(1, 2, []float64{1, 0})
+	XB := mat.NewDense(1, 2, []float64{1, 4})
+
+	// scipy.spatial.distance.cdist(XA, XB, "seuclidean", V=[1, 4]) = sqrt(16/4)
+	expected := mat.NewDense(1, 1, []float64{2})
+	result := CdistStandardizedEuclidean(XA, XB, []float64{1, 4})
+	if !mat.EqualApprox(result, expected, 1e-10) {
+		t.Errorf("Standardized euclidean distance incorrect.\nGot:\n%v\nExpected:\n%v", mat.Formatted(result), mat.Formatted(expected))
+	}
+
+	for name, V := range map[string][]float64{"zero": {1, 0}, "length": {1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s: expected panic for invalid V", name)
+				}
+			}()
+			CdistStandardizedEuclidean(XA, XB, V)
+		}()
+	}
+}
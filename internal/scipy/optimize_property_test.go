@@ -0,0 +1,154 @@
+// Copyright 2025 Nathan Michlo
+// SPDX-License-Identifier: BSD-3-Clause
+
+package scipy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteForceAssignmentCost returns the minimum total cost of matching every row (or
+// every column, whichever is fewer) to a distinct column (or row).
+func bruteForceAssignmentCost(costMatrix [][]float64) float64 {
+	numRows, numCols := len(costMatrix), len(costMatrix[0])
+	if numRows > numCols {
+		transposed := make([][]float64, numCols)
+		for j := range transposed {
+			transposed[j] = make([]float64, numRows)
+			for i := range costMatrix {
+				transposed[j][i] = costMatrix[i][j]
+			}
+		}
+		return bruteForceAssignmentCost(transposed)
+	}
+
+	used := make([]bool, numCols)
+	best := math.Inf(1)
+	var search func(row int, total float64)
+	search = func(row int, total float64) {
+		if row == numRows {
+			best = math.Min(best, total)
+			return
+		}
+		for j := 0; j < numCols; j++ {
+			if !used[j] {
+				used[j] = true
+				search(row+1, total+costMatrix[row][j])
+				used[j] = false
+			}
+		}
+	}
+	search(0, 0)
+	return best
+}
+
+// checkAgainstBruteForce asserts that LinearSumAssignment finds a complete optimal
+// assignment when nothing is rejected, and consistent unmatched indices when the
+// threshold rejects pairs.
+func checkAgainstBruteForce(t *testing.T, costMatrix [][]float64, maxCost float64) {
+	t.Helper()
+	numRows, numCols := len(costMatrix), len(costMatrix[0])
+
+	assignments, unmatchedRows, unmatchedCols := LinearSumAssignment(costMatrix, math.Inf(1))
+	if len(assignments) != min(numRows, numCols) {
+		t.Fatalf("Expected %d assignments for %v, got %v", min(numRows, numCols), costMatrix, assignments)
+	}
+	if len(unmatchedRows) != numRows-len(assignments) || len(unmatchedCols) != numCols-len(assignments) {
+		t.Fatalf("Expected %d unmatched rows and %d unmatched cols for %v, got %v and %v",
+			numRows-len(assignments), numCols-len(assignments), costMatrix, unmatchedRows, unmatchedCols)
+	}
+	total := 0.0
+	for _, a := range assignments {
+		total += costMatrix[a.RowIdx][a.ColIdx]
+	}
+	if expected := bruteForceAssignmentCost(costMatrix); math.Abs(total-expected) > 1e-9*math.Max(1, math.Abs(expected)) {
+		t.Fatalf("Expected optimal total cost %v for %v, got %v (%v)", expected, costMatrix, total, assignments)
+	}
+
+	// With a threshold every row and column is either matched once or unmatched
+	assignments, unmatchedRows, unmatchedCols = LinearSumAssignment(costMatrix, maxCost)
+	rows := make([]int, numRows)
+	cols := make([]int, numCols)
+	for _, a := range assignments {
+		if costMatrix[a.RowIdx][a.ColIdx] > maxCost {
+			t.Fatalf("Assignment (%d, %d) of %v exceeds max cost %v", a.RowIdx, a.ColIdx, costMatrix, maxCost)
+		}
+		rows[a.RowIdx]++
+		cols[a.ColIdx]++
+	}
+	for _, i := range unmatchedRows {
+		rows[i]++
+	}
+	for _, j := range unmatchedCols {
+		cols[j]++
+	}
+	for i, n := range rows {
+		if n != 1 {
+			t.Fatalf("Row %d of %v is matched or unmatched %d times", i, costMatrix, n)
+		}
+	}
+	for j, n := range cols {
+		if n != 1 {
+			t.Fatalf("Col %d of %v is matched or unmatched %d times", j, costMatrix, n)
+		}
+	}
+}
+
+func TestLinearSumAssignment_PropertyBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	generators := map[string]func() float64{
+		"uniform":  func() float64 { return rng.Float64() },
+		"wide":     func() float64 { return rng.Float64() * 1000 },
+		"ties":     func() float64 { return float64(rng.Intn(3)) },
+		"constant": func() float64 { return 1 },
+		"sparse": func() float64 {
+			if rng.Float64() < 0.7 {
+				return 1e6
+			}
+			return rng.Float64()
+		},
+	}
+
+	for name, generate := range generators {
+		t.Run(name, func(t *testing.T) {
+			for trial := 0; trial < 200; trial++ {
+				numRows, numCols := 1+rng.Intn(6), 1+rng.Intn(6)
+				costMatrix := make([][]float64, numRows)
+				for i := range costMatrix {
+					costMatrix[i] = make([]float64, numCols)
+					for j := range costMatrix[i] {
+						costMatrix[i][j] = generate()
+					}
+				}
+				checkAgainstBruteForce(t, costMatrix, costMatrix[rng.Intn(numRows)][rng.Intn(numCols)])
+			}
+		})
+	}
+}
+
+// FuzzLinearSumAssignment explores cost matrices with coverage-guided fuzzing:
+//
+//	go test ./internal/scipy -fuzz FuzzLinearSumAssignment
+func FuzzLinearSumAssignment(f *testing.F) {
+	f.Add(uint8(3), uint8(3), []byte{1, 2, 3, 2, 4, 6, 3, 6, 9}, 5.0)
+	f.Add(uint8(1), uint8(4), []byte{9, 0, 9, 9}, 1.0)
+	f.Add(uint8(4), uint8(2), []byte{0, 0, 0, 0, 0, 0, 0, 0}, 0.0)
+	f.Add(uint8(5), uint8(3), []byte{255, 1, 255, 1, 255, 255, 255, 255, 1}, 100.0)
+
+	f.Fuzz(func(t *testing.T, rows, cols uint8, values []byte, maxCost float64) {
+		numRows, numCols := 1+int(rows)%6, 1+int(cols)%6
+		if len(values) == 0 || math.IsNaN(maxCost) {
+			t.Skip()
+		}
+		costMatrix := make([][]float64, numRows)
+		for i := range costMatrix {
+			costMatrix[i] = make([]float64, numCols)
+			for j := range costMatrix[i] {
+				costMatrix[i][j] = float64(values[(i*numCols+j)%len(values)])
+			}
+		}
+		checkAgainstBruteForce(t, costMatrix, maxCost)
+	})
+}
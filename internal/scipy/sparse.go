@@ -0,0 +1,133 @@
+// Copyright 2025 Nathan Michlo
+// SPDX-License-Identifier: BSD-3-Clause
+
+package scipy
+
+import (
+	"math"
+)
+
+// SparseEntry is a finite cost (distance) of assigning a row to column Col.
+// It backs norfairgo.SparseEntry, so sparse distance matrices are passed to the
+// solver without conversion.
+type SparseEntry struct {
+	Col      int
+	Distance float64
+}
+
+// LinearSumAssignmentSparse solves the linear sum assignment problem for a sparse
+// cost matrix, given as per-row candidate lists. Missing entries have infinite cost
+// and are never assigned.
+//
+// The rows and columns are split into the connected components of the candidate
+// graph, and each component is solved as a small dense problem, so memory and time
+// depend on the component sizes instead of the full matrix. Within a component, as
+// many pairs as possible are assigned, with the minimum total cost among those
+// assignments; with every entry present this is LinearSumAssignment.
+//
+// Parameters:
+//   - rows: Candidate columns and costs of each row (column indices must be unique per row)
+//   - numCols: Number of columns
+//   - maxDistance: Maximum cost threshold; assignments with cost > maxCost are rejected
+//
+// Returns:
+//   - assignments: Slice of valid assignments (row, col pairs), ordered by row
+//   - unmatchedRows: Indices of rows that were not matched
+//   - unmatchedCols: Indices of columns that were not matched
+func LinearSumAssignmentSparse(rows [][]SparseEntry, numCols int, maxCost float64) ([]Assignment, []int, []int) {
+	numRows := len(rows)
+
+	// Union-find over rows [0, numRows) and columns [numRows, numRows+numCols)
+	parent := make([]int, numRows+numCols)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for r, entries := range rows {
+		for _, e := range entries {
+			parent[find(r)] = find(numRows + e.Col)
+		}
+	}
+
+	// Group the rows and columns of each component
+	componentRows := make(map[int][]int)
+	componentCols := make(map[int][]int)
+	for r, entries := range rows {
+		if len(entries) > 0 {
+			root := find(r)
+			componentRows[root] = append(componentRows[root], r)
+		}
+	}
+	for c := 0; c < numCols; c++ {
+		root := find(numRows + c)
+		if _, ok := componentRows[root]; ok {
+			componentCols[root] = append(componentCols[root], c)
+		}
+	}
+
+	rowMatch := make([]int, numRows)
+	for r := range rowMatch {
+		rowMatch[r] = -1
+	}
+	matchedCols := make([]bool, numCols)
+	for root, compRows := range componentRows {
+		compCols := componentCols[root]
+		colIndex := make(map[int]int, len(compCols))
+		for j, c := range compCols {
+			colIndex[c] = j
+		}
+
+		// Missing entries cost more than any complete set of present entries, so
+		// the solver first maximizes the number of present pairs
+		penalty := 1.0
+		for _, r := range compRows {
+			for _, e := range rows[r] {
+				penalty += math.Abs(e.Distance)
+			}
+		}
+		present := make([][]bool, len(compRows))
+		costMatrix := make([][]float64, len(compRows))
+		for i, r := range compRows {
+			present[i] = make([]bool, len(compCols))
+			costMatrix[i] = make([]float64, len(compCols))
+			for j := range costMatrix[i] {
+				costMatrix[i][j] = penalty
+			}
+			for _, e := range rows[r] {
+				present[i][colIndex[e.Col]] = true
+				costMatrix[i][colIndex[e.Col]] = e.Distance
+			}
+		}
+
+		assignments, _, _ := LinearSumAssignment(costMatrix, maxCost)
+		for _, a := range assignments {
+			if present[a.RowIdx][a.ColIdx] {
+				rowMatch[compRows[a.RowIdx]] = compCols[a.ColIdx]
+				matchedCols[compCols[a.ColIdx]] = true
+			}
+		}
+	}
+
+	var assignments []Assignment
+	var unmatchedRows, unmatchedCols []int
+	for r, c := range rowMatch {
+		if c >= 0 {
+			assignments = append(assignments, Assignment{RowIdx: r, ColIdx: c})
+		} else {
+			unmatchedRows = append(unmatchedRows, r)
+		}
+	}
+	for c, matched := range matchedCols {
+		if !matched {
+			unmatchedCols = append(unmatchedCols, c)
+		}
+	}
+
+	return assignments, unmatchedRows, unmatchedCols
+}
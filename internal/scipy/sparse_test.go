@@ -0,0 +1,86 @@
+// Copyright 2025 Nathan Michlo
+// SPDX-License-Identifier: BSD-3-Clause
+
+package scipy
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestLinearSumAssignmentSparse_Components(t *testing.T) {
+	// Two independent components {0, 1}x{0, 1} and {3}x{3}; row 2 and col 2 have no candidates
+	rows := [][]SparseEntry{
+		{{Col: 0, Distance: 1}, {Col: 1, Distance: 2}},
+		{{Col: 0, Distance: 1}},
+		nil,
+		{{Col: 3, Distance: 7}},
+	}
+
+	assignments, unmatchedRows, unmatchedCols := LinearSumAssignmentSparse(rows, 4, 5)
+
+	// Row 1 can only take col 0, so row 0 takes col 1; (3, 3) exceeds the max cost
+	expected := []Assignment{{RowIdx: 0, ColIdx: 1}, {RowIdx: 1, ColIdx: 0}}
+	if !reflect.DeepEqual(assignments, expected) {
+		t.Errorf("Expected assignments %v, got %v", expected, assignments)
+	}
+	if !reflect.DeepEqual(unmatchedRows, []int{2, 3}) || !reflect.DeepEqual(unmatchedCols, []int{2, 3}) {
+		t.Errorf("Expected unmatched rows and cols [2 3], got %v and %v", unmatchedRows, unmatchedCols)
+	}
+
+	if assignments, unmatchedRows, unmatchedCols := LinearSumAssignmentSparse(nil, 2, 5); len(assignments) != 0 || len(unmatchedRows) != 0 || len(unmatchedCols) != 2 {
+		t.Errorf("Expected no rows to leave both columns unmatched, got %v, %v and %v", assignments, unmatchedRows, unmatchedCols)
+	}
+}
+
+// TestLinearSumAssignmentSparse_MatchesDense checks the sparse solver against the
+// dense solver, with missing entries replaced by a cost too large to ever be worth it
+func TestLinearSumAssignmentSparse_MatchesDense(t *testing.T) {
+	const missing = 1e6
+	rng := rand.New(rand.NewSource(0))
+	for trial := 0; trial < 500; trial++ {
+		numRows, numCols := 1+rng.Intn(6), 1+rng.Intn(6)
+		density := rng.Float64()
+		rows := make([][]SparseEntry, numRows)
+		costMatrix := make([][]float64, numRows)
+		for i := range costMatrix {
+			costMatrix[i] = make([]float64, numCols)
+			for j := range costMatrix[i] {
+				costMatrix[i][j] = missing
+				if rng.Float64() < density {
+					cost := float64(rng.Intn(10))
+					costMatrix[i][j] = cost
+					rows[i] = append(rows[i], SparseEntry{Col: j, Distance: cost})
+				}
+			}
+		}
+
+		count := func(assignments []Assignment) (int, float64) {
+			n, total := 0, 0.0
+			for _, a := range assignments {
+				if cost := costMatrix[a.RowIdx][a.ColIdx]; cost < missing {
+					n++
+					total += cost
+				}
+			}
+			return n, total
+		}
+		dense, _, _ := LinearSumAssignment(costMatrix, math.Inf(1))
+		sparse, unmatchedRows, unmatchedCols := LinearSumAssignmentSparse(rows, numCols, math.Inf(1))
+		denseCount, denseTotal := count(dense)
+		sparseCount, sparseTotal := count(sparse)
+		if sparseCount != len(sparse) {
+			t.Fatalf("Sparse solver assigned a missing entry: %v for %v", sparse, rows)
+		}
+		if sparseCount != denseCount || sparseTotal != denseTotal {
+			t.Fatalf("Expected %d pairs with total %v for %v, got %d pairs with total %v",
+				denseCount, denseTotal, rows, sparseCount, sparseTotal)
+		}
+		if len(unmatchedRows) != numRows-sparseCount || len(unmatchedCols) != numCols-sparseCount {
+			t.Fatalf("Expected %d unmatched rows and %d unmatched cols, got %v and %v",
+				numRows-sparseCount, numCols-sparseCount, unmatchedRows, unmatchedCols)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Alerts - Declarative Rules on Tracked Objects
+// =============================================================================
+
+// AlertRule declares a condition on tracked objects. An object matches when it has
+// the rule's label, is inside its zone and moves faster than its speed (each only
+// if set). Without CountAbove, the rule fires once per object that has matched for
+// more than DurationAbove; with it, the rule fires once more than CountAbove objects
+// have matched at the same time for more than DurationAbove. A rule fires again only
+// after its condition stopped holding.
+type AlertRule struct {
+	// Unique name reported in events.
+	// Required.
+	Name string
+
+	// Label objects must have.
+	// Default: "" (any label)
+	Label string
+
+	// Zone the reference point of objects must be in.
+	// Default: nil (anywhere)
+	Zone *Zone
+
+	// Speed in units per second (see AlertEngineConfig.FPS) objects must exceed.
+	// Only objects providing EstimateVelocity (e.g. *TrackedObject) can match.
+	// Default: 0 (no speed condition)
+	SpeedAbove float64
+
+	// Number of matching objects that must be exceeded, for crowding or queue alerts.
+	// Default: 0 (fire per object)
+	CountAbove int
+
+	// Time in seconds the condition must hold for before the rule fires.
+	// Default: 0 (fire on the first update the condition holds)
+	DurationAbove float64
+
+	// Called with every event of this rule, in addition to it being returned by Update.
+	// Default: nil
+	OnFire func(AlertEvent)
+}
+
+// AlertEngineConfig configures an AlertEngine.
+type AlertEngineConfig struct {
+	// Rules to evaluate on every update.
+	// Required (at least one).
+	Rules []AlertRule
+
+	// Point of each object that is tested against rule zones.
+	// Default: ReferenceBottomCenter
+	Reference ReferencePointMode
+
+	// Updates per second, converting durations and speeds to seconds.
+	// Default: 1 (durations in updates, speeds per update)
+	FPS float64
+}
+
+// AlertEvent reports that a rule fired.
+type AlertEvent struct {
+	Rule     string
+	Frame    int64   // Update number (1-indexed) the rule fired on
+	IDs      []int   // Matching object (per-object rules) or objects (count rules), sorted
+	Count    int     // Number of matching objects on this update
+	Duration float64 // Seconds the condition has held, including this update
+}
+
+// alertState tracks how long the condition of a rule (for one object, or for the
+// count) has held.
+type alertState struct {
+	startFrame int64
+	fired      bool
+}
+
+// AlertEngine evaluates AlertRules on tracked objects every update, turning tracking
+// output into alerts such as "person in zone for more than 30 s", "vehicle faster
+// than 20 m/s" or "more than 5 people in the queue".
+type AlertEngine struct {
+	config      AlertEngineConfig
+	frameNumber int64
+	objects     []map[int]*alertState // Per rule, by object ID
+	counts      []*alertState         // Per count rule, nil while the count is not exceeded
+}
+
+// NewAlertEngine creates a new AlertEngine.
+//
+// Returns: Error if there are no rules, a rule has no or a duplicate name, a
+// negative threshold or a zone with fewer than 3 vertices, or FPS is negative
+func NewAlertEngine(config AlertEngineConfig) (*AlertEngine, error) {
+	if len(config.Rules) == 0 {
+		return nil, fmt.Errorf("alert engine requires at least one rule")
+	}
+	names := make(map[string]bool, len(config.Rules))
+	for _, rule := range config.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("alert rules must have a name")
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate alert rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.SpeedAbove < 0 || rule.CountAbove < 0 || rule.DurationAbove < 0 {
+			return nil, fmt.Errorf("alert rule %q thresholds must be >= 0", rule.Name)
+		}
+		if rule.Zone != nil && len(rule.Zone.Polygon) < 3 {
+			return nil, fmt.Errorf("alert rule %q zone must have at least 3 vertices, got %d", rule.Name, len(rule.Zone.Polygon))
+		}
+	}
+	if config.FPS < 0 {
+		return nil, fmt.Errorf("fps must be >= 0, got %v", config.FPS)
+	}
+	if config.FPS == 0 {
+		config.FPS = 1
+	}
+
+	objects := make([]map[int]*alertState, len(config.Rules))
+	for i := range objects {
+		objects[i] = make(map[int]*alertState)
+	}
+	return &AlertEngine{
+		config:  config,
+		objects: objects,
+		counts:  make([]*alertState, len(config.Rules)),
+	}, nil
+}
+
+// Update evaluates the rules on the current objects, calls the OnFire callbacks and
+// returns the events fired on this update, ordered by rule and then by ID.
+func (e *AlertEngine) Update(objects []TrackedObjectLike) []AlertEvent {
+	e.frameNumber++
+
+	var events []AlertEvent
+	for r, rule := range e.config.Rules {
+		var matching []int
+		for _, obj := range objects {
+			if id := obj.GetID(); id != nil && e.matches(rule, obj) {
+				matching = append(matching, *id)
+			}
+		}
+		sort.Ints(matching)
+
+		var fired []AlertEvent
+		if rule.CountAbove > 0 {
+			fired = e.updateCount(r, matching)
+		} else {
+			fired = e.updateObjects(r, matching)
+		}
+		for _, event := range fired {
+			if rule.OnFire != nil {
+				rule.OnFire(event)
+			}
+		}
+		events = append(events, fired...)
+	}
+	return events
+}
+
+// updateObjects advances the per-object states of a rule.
+func (e *AlertEngine) updateObjects(r int, matching []int) []AlertEvent {
+	states := e.objects[r]
+	seen := make(map[int]bool, len(matching))
+	var events []AlertEvent
+	for _, id := range matching {
+		seen[id] = true
+		state := states[id]
+		if state == nil {
+			state = &alertState{startFrame: e.frameNumber}
+			states[id] = state
+		}
+		if event, ok := e.fire(r, state, []int{id}, len(matching)); ok {
+			events = append(events, event)
+		}
+	}
+	for id := range states {
+		if !seen[id] {
+			delete(states, id)
+		}
+	}
+	return events
+}
+
+// updateCount advances the state of a count rule.
+func (e *AlertEngine) updateCount(r int, matching []int) []AlertEvent {
+	if len(matching) <= e.config.Rules[r].CountAbove {
+		e.counts[r] = nil
+		return nil
+	}
+	if e.counts[r] == nil {
+		e.counts[r] = &alertState{startFrame: e.frameNumber}
+	}
+	if event, ok := e.fire(r, e.counts[r], matching, len(matching)); ok {
+		return []AlertEvent{event}
+	}
+	return nil
+}
+
+// fire returns an event if the condition of a state has held for long enough and
+// the state has not fired yet.
+func (e *AlertEngine) fire(r int, state *alertState, ids []int, count int) (AlertEvent, bool) {
+	duration := float64(e.frameNumber-state.startFrame+1) / e.config.FPS
+	if state.fired || duration <= e.config.Rules[r].DurationAbove {
+		return AlertEvent{}, false
+	}
+	state.fired = true
+	return AlertEvent{
+		Rule:     e.config.Rules[r].Name,
+		Frame:    e.frameNumber,
+		IDs:      ids,
+		Count:    count,
+		Duration: duration,
+	}, true
+}
+
+// matches reports whether an object satisfies the label, zone and speed conditions
+// of a rule.
+func (e *AlertEngine) matches(rule AlertRule, obj TrackedObjectLike) bool {
+	if rule.Label != "" {
+		if label := obj.GetLabel(); label == nil || *label != rule.Label {
+			return false
+		}
+	}
+	if rule.Zone != nil {
+		estimate, err := obj.GetEstimate(false)
+		if err != nil {
+			return false
+		}
+		point := e.config.Reference.Extract(estimate)
+		if !PointInPolygon(point[0], point[1], rule.Zone.Polygon) {
+			return false
+		}
+	}
+	if rule.SpeedAbove > 0 {
+		speed, ok := objectSpeed(obj)
+		if !ok || speed*e.config.FPS <= rule.SpeedAbove {
+			return false
+		}
+	}
+	return true
+}
+
+// objectSpeed returns the per-update centroid speed of objects that estimate their
+// velocity (see TrackedObject.EstimateVelocity).
+func objectSpeed(obj TrackedObjectLike) (float64, bool) {
+	estimator, ok := obj.(interface{ EstimateVelocity() *mat.Dense })
+	if !ok {
+		return 0, false
+	}
+	velocity := estimator.EstimateVelocity()
+	rows, cols := velocity.Dims()
+	if rows == 0 || cols < 2 {
+		return 0, false
+	}
+	var vx, vy float64
+	for i := 0; i < rows; i++ {
+		vx += velocity.At(i, 0) / float64(rows)
+		vy += velocity.At(i, 1) / float64(rows)
+	}
+	return math.Hypot(vx, vy), true
+}
@@ -0,0 +1,127 @@
+package norfairgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+// labeledTrack is a box track with a label.
+type labeledTrack struct {
+	boxTrack
+	label string
+}
+
+func (l *labeledTrack) GetLabel() *string { return &l.label }
+
+func labeledPoint(id int, label string, x, y float64) TrackedObjectLike {
+	return &labeledTrack{boxTrack: *pointTrack(id, x, y).(*boxTrack), label: label}
+}
+
+func TestAlertEngine_ZoneDwellAndCount(t *testing.T) {
+	queue := &Zone{Name: "queue", Polygon: [][2]float64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	var callbacks []AlertEvent
+	engine, err := NewAlertEngine(AlertEngineConfig{
+		Rules: []AlertRule{
+			{Name: "loitering", Label: "person", Zone: queue, DurationAbove: 1, OnFire: func(e AlertEvent) { callbacks = append(callbacks, e) }},
+			{Name: "crowded", Zone: queue, CountAbove: 1},
+		},
+		FPS: 2,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert engine: %v", err)
+	}
+
+	frames := [][]TrackedObjectLike{
+		{labeledPoint(1, "person", 50, 50), labeledPoint(2, "car", 60, 50)},
+		{labeledPoint(1, "person", 50, 50), labeledPoint(2, "car", 60, 50)},
+		{labeledPoint(1, "person", 50, 50)},
+		{labeledPoint(1, "person", 50, 50), labeledPoint(3, "person", 10, 10)},
+		{labeledPoint(1, "person", 50, 50), labeledPoint(3, "person", 10, 10)},
+		{labeledPoint(1, "person", 50, 50), labeledPoint(3, "person", 10, 10)},
+	}
+	var events []AlertEvent
+	for _, objects := range frames {
+		events = append(events, engine.Update(objects)...)
+	}
+
+	expected := []AlertEvent{
+		{Rule: "crowded", Frame: 1, IDs: []int{1, 2}, Count: 2, Duration: 0.5},
+		{Rule: "loitering", Frame: 3, IDs: []int{1}, Count: 1, Duration: 1.5},
+		{Rule: "crowded", Frame: 4, IDs: []int{1, 3}, Count: 2, Duration: 0.5},
+		{Rule: "loitering", Frame: 6, IDs: []int{3}, Count: 2, Duration: 1.5},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected events\n%+v\ngot\n%+v", expected, events)
+	}
+	if len(callbacks) != 2 || callbacks[0].Rule != "loitering" || callbacks[1].IDs[0] != 3 {
+		t.Errorf("Expected the 2 loitering events to be passed to OnFire, got %+v", callbacks)
+	}
+
+	// Leaving and re-entering re-arms the rule
+	engine.Update(nil)
+	for i := 0; i < 3; i++ {
+		events = engine.Update([]TrackedObjectLike{labeledPoint(1, "person", 50, 50)})
+	}
+	if len(events) != 1 || events[0].Frame != 10 || events[0].IDs[0] != 1 {
+		t.Errorf("Expected object 1 to fire again on update 10, got %+v", events)
+	}
+}
+
+func TestAlertEngine_Speed(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   30,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	engine, err := NewAlertEngine(AlertEngineConfig{
+		Rules: []AlertRule{{Name: "speeding", SpeedAbove: 100}},
+		FPS:   10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert engine: %v", err)
+	}
+
+	// 5 px per update at 10 fps is 50 px/s, then 15 px per update is 150 px/s
+	x := 0.0
+	var fired []AlertEvent
+	for i := 0; i < 40; i++ {
+		if i < 20 {
+			x += 5
+		} else {
+			x += 15
+		}
+		objects := tracker.Update([]*Detection{newPointDetection(t, x, 0)}, 1, nil)
+		for _, event := range engine.Update(AsTrackedObjectLikes(objects)) {
+			if i < 20 {
+				t.Errorf("Expected no speeding alert at 50 px/s, got %+v on update %d", event, i+1)
+			}
+			fired = append(fired, event)
+		}
+	}
+	if len(fired) != 1 {
+		t.Errorf("Expected exactly one speeding alert, got %+v", fired)
+	}
+
+	// Objects without a velocity never match speed rules
+	if events := engine.Update([]TrackedObjectLike{pointTrack(9, 0, 0)}); len(events) != 0 {
+		t.Errorf("Expected no alert for an object without velocity, got %+v", events)
+	}
+}
+
+func TestNewAlertEngine_Validation(t *testing.T) {
+	for name, config := range map[string]AlertEngineConfig{
+		"no rules":   {},
+		"no name":    {Rules: []AlertRule{{}}},
+		"duplicate":  {Rules: []AlertRule{{Name: "a"}, {Name: "a"}}},
+		"negative":   {Rules: []AlertRule{{Name: "a", DurationAbove: -1}}},
+		"degenerate": {Rules: []AlertRule{{Name: "a", Zone: &Zone{Name: "z"}}}},
+		"fps":        {Rules: []AlertRule{{Name: "a"}}, FPS: -1},
+	} {
+		if _, err := NewAlertEngine(config); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package norfairgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// CVAT - Import "CVAT for video 1.1" XML annotations
+// =============================================================================
+
+// cvatAnnotations mirrors the subset of the CVAT for video XML schema we need.
+type cvatAnnotations struct {
+	XMLName xml.Name    `xml:"annotations"`
+	Task    string      `xml:"meta>task>name"`
+	Tracks  []cvatTrack `xml:"track"`
+}
+
+type cvatTrack struct {
+	ID    int       `xml:"id,attr"`
+	Label string    `xml:"label,attr"`
+	Boxes []cvatBox `xml:"box"`
+}
+
+type cvatBox struct {
+	Frame    int64   `xml:"frame,attr"`
+	Outside  int     `xml:"outside,attr"`
+	Occluded int     `xml:"occluded,attr"`
+	Keyframe int     `xml:"keyframe,attr"`
+	XTL      float64 `xml:"xtl,attr"`
+	YTL      float64 `xml:"ytl,attr"`
+	XBR      float64 `xml:"xbr,attr"`
+	YBR      float64 `xml:"ybr,attr"`
+}
+
+// LoadCVAT loads a "CVAT for video 1.1" XML export (interpolation mode) as ground truth.
+//
+// Parameters:
+//   - xmlPath: Path to the annotations.xml file
+//   - labelClasses: Optional mapping from CVAT label names to class IDs (nil = class -1)
+//
+// Returns: MOTChallengeData with frames organized by frame number
+//
+// CVAT frames and track IDs are 0-indexed and are shifted by one to match the
+// MOTChallenge convention. Boxes between keyframes that are missing from the
+// export are linearly interpolated, boxes marked "outside" end the track (the
+// box before them is held constant until then), and occluded boxes get a
+// visibility of 0.
+func LoadCVAT(xmlPath string, labelClasses map[string]int) (*MOTChallengeData, error) {
+	content, err := os.ReadFile(xmlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CVAT file: %w", err)
+	}
+
+	var annotations cvatAnnotations
+	if err := xml.Unmarshal(content, &annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse CVAT XML: %w", err)
+	}
+
+	videoName := annotations.Task
+	if videoName == "" {
+		videoName = strings.TrimSuffix(filepath.Base(xmlPath), filepath.Ext(xmlPath))
+	}
+
+	data := &MOTChallengeData{
+		VideoName: videoName,
+		Frames:    make(map[int64]*MOTChallengeFrame),
+	}
+
+	for _, track := range annotations.Tracks {
+		class := classForLabel(labelClasses, track.Label)
+
+		boxes := append([]cvatBox(nil), track.Boxes...)
+		sort.Slice(boxes, func(i, j int) bool { return boxes[i].Frame < boxes[j].Frame })
+
+		for i, box := range boxes {
+			if box.Outside != 0 {
+				continue
+			}
+
+			visibility := 1.0
+			if box.Occluded != 0 {
+				visibility = 0.0
+			}
+			bbox := []float64{box.XTL, box.YTL, box.XBR, box.YBR}
+			data.addRow(box.Frame+1, track.ID+1, bbox, 1.0, class, visibility)
+
+			// Interpolate frames missing between this box and the next one. An
+			// outside keyframe only marks where the track ends, so the box is held
+			// constant until then instead of moving toward its coordinates.
+			if i+1 >= len(boxes) {
+				continue
+			}
+			next := boxes[i+1]
+			gap := next.Frame - box.Frame
+			for step := int64(1); step < gap; step++ {
+				alpha := float64(step) / float64(gap)
+				if next.Outside != 0 {
+					alpha = 0
+				}
+				interpolated := []float64{
+					box.XTL + alpha*(next.XTL-box.XTL),
+					box.YTL + alpha*(next.YTL-box.YTL),
+					box.XBR + alpha*(next.XBR-box.XBR),
+					box.YBR + alpha*(next.YBR-box.YBR),
+				}
+				data.addRow(box.Frame+step+1, track.ID+1, interpolated, 1.0, class, visibility)
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// =============================================================================
+// Labelbox - Import Labelbox NDJSON (export v2) video annotations
+// =============================================================================
+
+// labelboxDataRow mirrors the subset of a Labelbox export v2 NDJSON line we need.
+type labelboxDataRow struct {
+	DataRow struct {
+		ID         string `json:"id"`
+		ExternalID string `json:"external_id"`
+	} `json:"data_row"`
+	Projects map[string]struct {
+		Labels []struct {
+			Annotations struct {
+				Frames map[string]struct {
+					Objects map[string]labelboxObject `json:"objects"`
+				} `json:"frames"`
+			} `json:"annotations"`
+		} `json:"labels"`
+	} `json:"projects"`
+}
+
+type labelboxObject struct {
+	FeatureID   string `json:"feature_id"`
+	Name        string `json:"name"`
+	BoundingBox *struct {
+		Top    float64 `json:"top"`
+		Left   float64 `json:"left"`
+		Height float64 `json:"height"`
+		Width  float64 `json:"width"`
+	} `json:"bounding_box"`
+}
+
+// LoadLabelbox loads a Labelbox NDJSON export (export v2, video projects) as ground truth.
+//
+// Parameters:
+//   - ndjsonPath: Path to the exported .ndjson file
+//   - labelClasses: Optional mapping from Labelbox feature names to class IDs (nil = class -1)
+//
+// Returns: MOTChallengeData per data row, keyed by external ID (or data row ID if empty)
+//
+// Labelbox frame numbers are already 1-indexed. Objects are identified across
+// frames by their feature ID, which is mapped to sequential integer IDs (starting
+// at 1) in order of first appearance. Non bounding-box annotations are skipped.
+func LoadLabelbox(ndjsonPath string, labelClasses map[string]int) (map[string]*MOTChallengeData, error) {
+	file, err := os.Open(ndjsonPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Labelbox file: %w", err)
+	}
+	defer file.Close()
+
+	results := make(map[string]*MOTChallengeData)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var row labelboxDataRow
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return nil, fmt.Errorf("failed to parse Labelbox line %d: %w", lineNumber, err)
+		}
+
+		videoName := row.DataRow.ExternalID
+		if videoName == "" {
+			videoName = row.DataRow.ID
+		}
+		data := &MOTChallengeData{
+			VideoName: videoName,
+			Frames:    make(map[int64]*MOTChallengeFrame),
+		}
+		featureIDs := make(map[string]int)
+
+		for _, project := range row.Projects {
+			for _, label := range project.Labels {
+				// Visit frames in order so IDs follow first appearance
+				frameIDs := make([]int64, 0, len(label.Annotations.Frames))
+				frameKeys := make(map[int64]string, len(label.Annotations.Frames))
+				for key := range label.Annotations.Frames {
+					frameID, err := strconv.ParseInt(key, 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("invalid Labelbox frame number %q on line %d", key, lineNumber)
+					}
+					frameIDs = append(frameIDs, frameID)
+					frameKeys[frameID] = key
+				}
+				slices.Sort(frameIDs)
+
+				for _, frameID := range frameIDs {
+					objects := label.Annotations.Frames[frameKeys[frameID]].Objects
+
+					keys := make([]string, 0, len(objects))
+					for key := range objects {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+
+					for _, key := range keys {
+						object := objects[key]
+						if object.BoundingBox == nil {
+							continue
+						}
+
+						featureID := object.FeatureID
+						if featureID == "" {
+							featureID = key
+						}
+						id, exists := featureIDs[featureID]
+						if !exists {
+							id = len(featureIDs) + 1
+							featureIDs[featureID] = id
+						}
+
+						bb := object.BoundingBox
+						bbox := []float64{bb.Left, bb.Top, bb.Left + bb.Width, bb.Top + bb.Height}
+						data.addRow(frameID, id, bbox, 1.0, classForLabel(labelClasses, object.Name), -1.0)
+					}
+				}
+			}
+		}
+
+		results[videoName] = data
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Labelbox file: %w", err)
+	}
+
+	return results, nil
+}
+
+// =============================================================================
+// Helper Functions
+// =============================================================================
+
+// classForLabel maps an annotation label name to a class ID (-1 if unknown).
+func classForLabel(labelClasses map[string]int, label string) int {
+	if class, ok := labelClasses[label]; ok {
+		return class
+	}
+	return -1
+}
@@ -0,0 +1,188 @@
+package norfairgo
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// =============================================================================
+// CVAT Import Tests
+// =============================================================================
+
+const cvatFixture = `<?xml version="1.0" encoding="utf-8"?>
+<annotations>
+  <version>1.1</version>
+  <meta>
+    <task>
+      <name>MOT-Custom-01</name>
+    </task>
+  </meta>
+  <track id="0" label="person" source="manual">
+    <box frame="0" outside="0" occluded="0" keyframe="1" xtl="0.00" ytl="0.00" xbr="10.00" ybr="20.00" z_order="0"></box>
+    <box frame="2" outside="0" occluded="1" keyframe="1" xtl="4.00" ytl="0.00" xbr="14.00" ybr="20.00" z_order="0"></box>
+    <box frame="3" outside="1" occluded="0" keyframe="1" xtl="4.00" ytl="0.00" xbr="14.00" ybr="20.00" z_order="0"></box>
+  </track>
+  <track id="1" label="car" source="manual">
+    <box frame="1" outside="0" occluded="0" keyframe="1" xtl="50.00" ytl="50.00" xbr="80.00" ybr="70.00" z_order="0"></box>
+  </track>
+</annotations>
+`
+
+func TestLoadCVAT_InterpolationMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "annotations.xml")
+	if err := os.WriteFile(path, []byte(cvatFixture), 0644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	data, err := LoadCVAT(path, map[string]int{"person": 1, "car": 3})
+	if err != nil {
+		t.Fatalf("LoadCVAT failed: %v", err)
+	}
+
+	if data.VideoName != "MOT-Custom-01" {
+		t.Errorf("Expected video name from task meta, got %q", data.VideoName)
+	}
+
+	// Track 0 spans frames 1-3 (1-indexed), the outside box ends it
+	if len(data.Frames) != 3 {
+		t.Fatalf("Expected 3 frames, got %d", len(data.Frames))
+	}
+	if _, exists := data.Frames[4]; exists {
+		t.Errorf("Outside box should not produce a row")
+	}
+
+	// Frame 2 holds the interpolated person box and the car
+	frame := data.Frames[2]
+	if len(frame.IDs) != 2 {
+		t.Fatalf("Expected 2 rows in frame 2, got %d", len(frame.IDs))
+	}
+	for i, id := range frame.IDs {
+		switch id {
+		case 1:
+			expected := []float64{2, 0, 12, 20}
+			for k := range expected {
+				if math.Abs(frame.BBoxes[i][k]-expected[k]) > 1e-9 {
+					t.Errorf("Interpolated bbox = %v, want %v", frame.BBoxes[i], expected)
+					break
+				}
+			}
+			if frame.Classes[i] != 1 {
+				t.Errorf("Expected person class 1, got %d", frame.Classes[i])
+			}
+		case 2:
+			if frame.Classes[i] != 3 {
+				t.Errorf("Expected car class 3, got %d", frame.Classes[i])
+			}
+		default:
+			t.Errorf("Unexpected ID %d", id)
+		}
+	}
+
+	// Occluded keyframe has zero visibility
+	if data.Frames[3].Visibilities[0] != 0.0 {
+		t.Errorf("Expected visibility 0 for occluded box, got %v", data.Frames[3].Visibilities[0])
+	}
+}
+
+func TestLoadCVAT_HoldsBoxBeforeOutside(t *testing.T) {
+	const fixture = `<?xml version="1.0" encoding="utf-8"?>
+<annotations>
+  <track id="0" label="person" source="manual">
+    <box frame="0" outside="0" occluded="0" keyframe="1" xtl="0.00" ytl="0.00" xbr="10.00" ybr="20.00" z_order="0"></box>
+    <box frame="4" outside="1" occluded="0" keyframe="1" xtl="100.00" ytl="100.00" xbr="110.00" ybr="120.00" z_order="0"></box>
+  </track>
+</annotations>
+`
+	path := filepath.Join(t.TempDir(), "annotations.xml")
+	if err := os.WriteFile(path, []byte(fixture), 0644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	data, err := LoadCVAT(path, nil)
+	if err != nil {
+		t.Fatalf("LoadCVAT failed: %v", err)
+	}
+
+	// Frames 1-4 (1-indexed) hold the last visible box, the outside frame 5 is empty
+	if len(data.Frames) != 4 {
+		t.Fatalf("Expected 4 frames, got %d", len(data.Frames))
+	}
+	expected := []float64{0, 0, 10, 20}
+	for frameNum := int64(1); frameNum <= 4; frameNum++ {
+		frame, exists := data.Frames[frameNum]
+		if !exists {
+			t.Fatalf("Expected a row in frame %d", frameNum)
+		}
+		for k := range expected {
+			if math.Abs(frame.BBoxes[0][k]-expected[k]) > 1e-9 {
+				t.Errorf("Frame %d bbox = %v, want %v", frameNum, frame.BBoxes[0], expected)
+				break
+			}
+		}
+	}
+}
+
+func TestLoadCVAT_FileNotFound(t *testing.T) {
+	if _, err := LoadCVAT("/nonexistent/annotations.xml", nil); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+// =============================================================================
+// Labelbox Import Tests
+// =============================================================================
+
+const labelboxFixture = `{"data_row": {"id": "ckx1", "external_id": "video-01.mp4"}, "projects": {"p1": {"labels": [{"annotations": {"frames": {"2": {"objects": {"a": {"feature_id": "feat-b", "name": "person", "annotation_kind": "VideoBoundingBox", "bounding_box": {"top": 5, "left": 10, "height": 20, "width": 10}}}}, "1": {"objects": {"a": {"feature_id": "feat-a", "name": "person", "annotation_kind": "VideoBoundingBox", "bounding_box": {"top": 0, "left": 0, "height": 20, "width": 10}}, "b": {"feature_id": "feat-b", "name": "person", "annotation_kind": "VideoBoundingBox", "bounding_box": {"top": 5, "left": 8, "height": 20, "width": 10}}}}}}}]}}}
+`
+
+func TestLoadLabelbox_VideoBoundingBoxes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.ndjson")
+	if err := os.WriteFile(path, []byte(labelboxFixture), 0644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	results, err := LoadLabelbox(path, map[string]int{"person": 1})
+	if err != nil {
+		t.Fatalf("LoadLabelbox failed: %v", err)
+	}
+
+	data, ok := results["video-01.mp4"]
+	if !ok {
+		t.Fatalf("Expected data keyed by external ID, got %v", results)
+	}
+
+	// feat-a first appears in frame 1 before feat-b
+	frame1 := data.Frames[1]
+	if len(frame1.IDs) != 2 || frame1.IDs[0] != 1 || frame1.IDs[1] != 2 {
+		t.Fatalf("Unexpected frame 1 IDs: %v", frame1.IDs)
+	}
+
+	// feat-b keeps its ID in frame 2 and the bbox is converted to corners
+	frame2 := data.Frames[2]
+	if len(frame2.IDs) != 1 || frame2.IDs[0] != 2 {
+		t.Fatalf("Unexpected frame 2 IDs: %v", frame2.IDs)
+	}
+	expected := []float64{10, 5, 20, 25}
+	for k := range expected {
+		if frame2.BBoxes[0][k] != expected[k] {
+			t.Errorf("BBox = %v, want %v", frame2.BBoxes[0], expected)
+			break
+		}
+	}
+	if frame2.Classes[0] != 1 {
+		t.Errorf("Expected class 1, got %d", frame2.Classes[0])
+	}
+}
+
+func TestLoadLabelbox_InvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.ndjson")
+	if err := os.WriteFile(path, []byte("{not json}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	if _, err := LoadLabelbox(path, nil); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
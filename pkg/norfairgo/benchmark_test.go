@@ -1,6 +1,7 @@
 package norfairgo
 
 import (
+	"math/rand"
 	"testing"
 
 	"gonum.org/v1/gonum/mat"
@@ -128,3 +129,115 @@ func BenchmarkTrackerUpdate_100Objects_IoU(b *testing.B) {
 		tracker.Update(detections, 1, nil)
 	}
 }
+
+// ============================================================================
+// Detection Reuse Benchmarks (run with -benchmem)
+// ============================================================================
+
+func BenchmarkDetections_Allocate(b *testing.B) {
+	points := mat.NewDense(2, 2, []float64{0, 0, 50, 50})
+	detections := make([]*Detection, 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range detections {
+			detections[j], _ = NewDetection(mat.DenseCopyOf(points), nil)
+		}
+	}
+}
+
+func BenchmarkDetections_Pool(b *testing.B) {
+	tracker, _ := NewTracker(&TrackerConfig{})
+	pool := NewDetectionPool()
+	points := mat.NewDense(2, 2, []float64{0, 0, 50, 50})
+	detections := make([]*Detection, 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range detections {
+			detections[j], _ = pool.Get(points, nil)
+		}
+		pool.Release(tracker, detections)
+	}
+}
+
+// ============================================================================
+// Distance Benchmarks
+// ============================================================================
+
+// createTestObjects creates initialized tracked objects from test detections
+func createTestObjects(b *testing.B, n int) []*TrackedObject {
+	b.Helper()
+	tracker, _ := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   50.0,
+		InitializationDelay: 0,
+	})
+	tracker.Update(createTestDetections(n), 1, nil)
+	if len(tracker.TrackedObjects) != n {
+		b.Fatalf("Expected %d objects, got %d", n, len(tracker.TrackedObjects))
+	}
+	return tracker.TrackedObjects
+}
+
+func BenchmarkScalarDistance_GetDistances(b *testing.B) {
+	distance := NewScalarDistance(MeanEuclidean)
+	objects := createTestObjects(b, 100)
+	var candidates interface{} = createTestDetections(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		distance.GetDistances(objects, candidates)
+	}
+}
+
+func BenchmarkScalarDistance_GetDistancesDetections(b *testing.B) {
+	distance := NewScalarDistance(MeanEuclidean)
+	objects := createTestObjects(b, 100)
+	detections := createTestDetections(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		distance.GetDistancesDetections(objects, detections)
+	}
+}
+
+func BenchmarkScalarDistance_GetDistancesValueSlice(b *testing.B) {
+	distance := NewScalarDistance(MeanEuclidean)
+	objects := createTestObjects(b, 100)
+	detections := make([]Detection, 100)
+	for i, det := range createTestDetections(100) {
+		detections[i] = *det
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		distance.GetDistances(objects, detections)
+	}
+}
+
+// ============================================================================
+// Camera Motion Benchmarks
+// ============================================================================
+
+func BenchmarkTranslationTransformationGetter(b *testing.B) {
+	rng := rand.New(rand.NewSource(0))
+	n := 500
+	prevPts := mat.NewDense(n, 2, nil)
+	currPts := mat.NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		x, y := rng.Float64()*1920, rng.Float64()*1080
+		prevPts.Set(i, 0, x)
+		prevPts.Set(i, 1, y)
+		currPts.Set(i, 0, x+5+rng.Float64()*0.5)
+		currPts.Set(i, 1, y-3+rng.Float64()*0.5)
+	}
+	getter := NewTranslationTransformationGetter(0.2, 0.9)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		getter.Call(currPts, prevPts)
+	}
+}
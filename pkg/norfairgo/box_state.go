@@ -0,0 +1,227 @@
+package norfairgo
+
+import "gonum.org/v1/gonum/mat"
+
+// =============================================================================
+// Center-Size Box State - Bounding Boxes as (cx, cy, w, h) with Velocities
+// =============================================================================
+
+// StateRepresentation selects how a filter factory models bounding boxes.
+type StateRepresentation int
+
+const (
+	// StateCorners filters every point independently: for a bounding box, the
+	// two corners and their velocities (like Python norfair).
+	StateCorners StateRepresentation = iota
+
+	// StateCenterSize filters 2-point bounding box detections [[x1, y1], [x2, y2]]
+	// as center (cx, cy) and size (w, h) with their velocities. The box moves and
+	// scales as a whole, so its predicted corners cannot drift apart independently
+	// and deform it. Detections of other shapes (e.g. keypoints) keep StateCorners.
+	StateCenterSize
+)
+
+// BoxToCenterSize converts a bounding box [[x1, y1], [x2, y2]] to [[cx, cy], [w, h]].
+func BoxToCenterSize(box *mat.Dense) *mat.Dense {
+	x1, y1, x2, y2 := box.At(0, 0), box.At(0, 1), box.At(1, 0), box.At(1, 1)
+	return mat.NewDense(2, 2, []float64{(x1 + x2) / 2, (y1 + y2) / 2, x2 - x1, y2 - y1})
+}
+
+// CenterSizeToBox converts [[cx, cy], [w, h]] to a bounding box [[x1, y1], [x2, y2]].
+func CenterSizeToBox(centerSize *mat.Dense) *mat.Dense {
+	cx, cy, w, h := centerSize.At(0, 0), centerSize.At(0, 1), centerSize.At(1, 0), centerSize.At(1, 1)
+	return mat.NewDense(2, 2, []float64{cx - w/2, cy - h/2, cx + w/2, cy + h/2})
+}
+
+// isBoxShape reports whether points have the (2, 2) shape of a bounding box.
+func isBoxShape(points *mat.Dense) bool {
+	rows, cols := points.Dims()
+	return rows == 2 && cols == 2
+}
+
+// CenterSizeFilter filters a bounding box in center-size space (see StateCenterSize)
+// while exposing the corner-space state every Filter has: GetStateVector returns
+// [x1, y1, x2, y2, vx1, vy1, vx2, vy2], and updates take corner measurements.
+//
+// Its covariance and noise matrices (see InspectableFilter) are those of the inner
+// filter mapped to corner space, so gating and innovation checks see the same
+// uncertainty as for StateCorners.
+//
+// Created by the filter factories whose StateRepresentation is StateCenterSize.
+type CenterSizeFilter struct {
+	inner InspectableFilter // Filter over [cx, cy, w, h, vcx, vcy, vw, vh]
+}
+
+// newCenterSizeFilter wraps a filter created from BoxToCenterSize of the initial box.
+func newCenterSizeFilter(inner InspectableFilter) *CenterSizeFilter {
+	return &CenterSizeFilter{inner: inner}
+}
+
+// Inner returns the wrapped filter, whose state is [cx, cy, w, h, vcx, vcy, vw, vh].
+func (f *CenterSizeFilter) Inner() Filter {
+	return f.inner
+}
+
+// Predict advances the center and size by their velocities.
+func (f *CenterSizeFilter) Predict() {
+	f.inner.Predict()
+}
+
+// Update converts the corner measurement to center and size. When only one
+// corner of an axis is measured (see H), the center along that axis is derived
+// from it and the predicted size, and the size is not updated. The measurement
+// noise of both corners is propagated to the center ((r1 + r2) / 4) and size (r1 + r2).
+func (f *CenterSizeFilter) Update(detectionPointsFlatten *mat.Dense, R, H *mat.Dense) {
+	state := f.inner.GetStateVector()
+	z := mat.NewDense(4, 1, nil)
+	var innerH, innerR *mat.Dense
+	if H != nil {
+		innerH = mat.NewDense(4, 8, nil)
+	}
+	if R != nil {
+		innerR = mat.NewDense(4, 4, nil)
+	}
+
+	for axis := 0; axis < 2; axis++ {
+		c1, c2 := detectionPointsFlatten.At(axis, 0), detectionPointsFlatten.At(2+axis, 0)
+		measured1 := H == nil || H.At(axis, axis) != 0
+		measured2 := H == nil || H.At(2+axis, 2+axis) != 0
+		size := state.At(2+axis, 0)
+
+		switch {
+		case measured1 && measured2:
+			z.Set(axis, 0, (c1+c2)/2)
+			z.Set(2+axis, 0, c2-c1)
+			if innerH != nil {
+				innerH.Set(axis, axis, 1)
+				innerH.Set(2+axis, 2+axis, 1)
+			}
+		case measured1:
+			z.Set(axis, 0, c1+size/2)
+			innerH.Set(axis, axis, 1)
+		case measured2:
+			z.Set(axis, 0, c2-size/2)
+			innerH.Set(axis, axis, 1)
+		}
+
+		if innerR != nil {
+			r1, r2 := R.At(axis, axis), R.At(2+axis, 2+axis)
+			switch {
+			case measured1 && measured2:
+				innerR.Set(axis, axis, (r1+r2)/4)
+			case measured1:
+				innerR.Set(axis, axis, r1)
+			default:
+				innerR.Set(axis, axis, r2)
+			}
+			innerR.Set(2+axis, 2+axis, r1+r2)
+		}
+	}
+
+	f.inner.Update(z, innerR, innerH)
+}
+
+// GetState returns the corner-space state (a new vector, see GetStateVector).
+func (f *CenterSizeFilter) GetState() *mat.Dense {
+	return f.GetStateVector()
+}
+
+// GetDimZ returns 4, the number of corner coordinates.
+func (f *CenterSizeFilter) GetDimZ() int {
+	return 4
+}
+
+// GetStateVector returns a new corner-space state vector
+// [x1, y1, x2, y2, vx1, vy1, vx2, vy2]; modify it through SetStateVector.
+func (f *CenterSizeFilter) GetStateVector() *mat.Dense {
+	return centerSizeToCornerState(f.inner.GetStateVector())
+}
+
+// SetStateVector sets the state from a corner-space state vector.
+func (f *CenterSizeFilter) SetStateVector(x *mat.Dense) {
+	f.inner.SetStateVector(cornerToCenterSizeState(x))
+}
+
+// PositionCovariance returns the corner-space position covariance A P A^T, where
+// P is the center-size position covariance and A maps center-size to corners.
+func (f *CenterSizeFilter) PositionCovariance() *mat.Dense {
+	return mat.DenseCopyOf(f.Covariance().Slice(0, 4, 0, 4))
+}
+
+// Covariance returns the corner-space state covariance P (see PositionCovariance).
+func (f *CenterSizeFilter) Covariance() *mat.Dense {
+	return toCornerSpace(centerSizeStateToCorners(), f.inner.Covariance())
+}
+
+// ProcessNoise returns the corner-space process noise Q.
+func (f *CenterSizeFilter) ProcessNoise() *mat.Dense {
+	return toCornerSpace(centerSizeStateToCorners(), f.inner.ProcessNoise())
+}
+
+// MeasurementNoise returns the corner-space default measurement noise R, i.e. the
+// noise of the center and size measurements the inner filter uses when Update is
+// given no R.
+func (f *CenterSizeFilter) MeasurementNoise() *mat.Dense {
+	return toCornerSpace(centerSizeToCorners(), f.inner.MeasurementNoise())
+}
+
+// String returns a debug dump of the corner-space state and matrices.
+func (f *CenterSizeFilter) String() string {
+	return formatFilter("CenterSizeFilter", 8, f.GetDimZ(),
+		f.GetStateVector(), f.Covariance(), f.ProcessNoise(), f.MeasurementNoise())
+}
+
+// centerSizeToCorners returns the matrix mapping [cx, cy, w, h] to [x1, y1, x2, y2].
+func centerSizeToCorners() *mat.Dense {
+	return mat.NewDense(4, 4, []float64{
+		1, 0, -0.5, 0,
+		0, 1, 0, -0.5,
+		1, 0, 0.5, 0,
+		0, 1, 0, 0.5,
+	})
+}
+
+// centerSizeStateToCorners returns the block diagonal matrix mapping the full
+// center-size state (positions and velocities) to the corner state.
+func centerSizeStateToCorners() *mat.Dense {
+	A := centerSizeToCorners()
+	B := mat.NewDense(8, 8, nil)
+	B.Slice(0, 4, 0, 4).(*mat.Dense).Copy(A)
+	B.Slice(4, 8, 4, 8).(*mat.Dense).Copy(A)
+	return B
+}
+
+// toCornerSpace returns the covariance A M A^T of a center-size covariance M.
+func toCornerSpace(A, M *mat.Dense) *mat.Dense {
+	var am, cov mat.Dense
+	am.Mul(A, M)
+	cov.Mul(&am, A.T())
+	return &cov
+}
+
+// centerSizeToCornerState converts [cx, cy, w, h, vcx, vcy, vw, vh] to
+// [x1, y1, x2, y2, vx1, vy1, vx2, vy2].
+func centerSizeToCornerState(state *mat.Dense) *mat.Dense {
+	corners := mat.NewDense(8, 1, nil)
+	for offset := 0; offset < 8; offset += 4 {
+		for axis := 0; axis < 2; axis++ {
+			center, size := state.At(offset+axis, 0), state.At(offset+2+axis, 0)
+			corners.Set(offset+axis, 0, center-size/2)
+			corners.Set(offset+2+axis, 0, center+size/2)
+		}
+	}
+	return corners
+}
+
+// cornerToCenterSizeState is the inverse of centerSizeToCornerState.
+func cornerToCenterSizeState(state *mat.Dense) *mat.Dense {
+	centerSize := mat.NewDense(8, 1, nil)
+	for offset := 0; offset < 8; offset += 4 {
+		for axis := 0; axis < 2; axis++ {
+			c1, c2 := state.At(offset+axis, 0), state.At(offset+2+axis, 0)
+			centerSize.Set(offset+axis, 0, (c1+c2)/2)
+			centerSize.Set(offset+2+axis, 0, c2-c1)
+		}
+	}
+	return centerSize
+}
@@ -0,0 +1,150 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+func newCenterSizeFactories() map[string]FilterFactory {
+	optimized := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0)
+	optimized.StateRepresentation = StateCenterSize
+	filterpy := NewFilterPyKalmanFilterFactory(4.0, 0.1, 10.0)
+	filterpy.StateRepresentation = StateCenterSize
+	return map[string]FilterFactory{"optimized": optimized, "filterpy": filterpy}
+}
+
+func TestBoxToCenterSize(t *testing.T) {
+	box := mat.NewDense(2, 2, []float64{10, 20, 50, 80})
+	centerSize := BoxToCenterSize(box)
+	expected := mat.NewDense(2, 2, []float64{30, 50, 40, 60})
+	if !mat.Equal(centerSize, expected) {
+		t.Errorf("Expected %v, got %v", mat.Formatted(expected), mat.Formatted(centerSize))
+	}
+	if roundTrip := CenterSizeToBox(centerSize); !mat.Equal(roundTrip, box) {
+		t.Errorf("Expected round trip %v, got %v", mat.Formatted(box), mat.Formatted(roundTrip))
+	}
+}
+
+func TestCenterSizeFilter_StateVector(t *testing.T) {
+	for name, factory := range newCenterSizeFactories() {
+		t.Run(name, func(t *testing.T) {
+			filter := factory.CreateFilter(mat.NewDense(2, 2, []float64{10, 20, 50, 80}))
+			csFilter, ok := filter.(*CenterSizeFilter)
+			if !ok {
+				t.Fatalf("Expected CenterSizeFilter, got %T", filter)
+			}
+			if filter.GetDimZ() != 4 {
+				t.Errorf("Expected dimZ=4, got %d", filter.GetDimZ())
+			}
+
+			// The inner state is center-size, the exposed state corners
+			inner := csFilter.Inner().GetStateVector()
+			for i, expected := range []float64{30, 50, 40, 60} {
+				testutil.AssertAlmostEqual(t, inner.At(i, 0), expected, 1e-10, "inner state")
+			}
+			corners := []float64{10, 20, 50, 80, 1, 2, 3, 6}
+			filter.SetStateVector(mat.NewDense(8, 1, corners))
+			state := filter.GetStateVector()
+			for i, expected := range corners {
+				testutil.AssertAlmostEqual(t, state.At(i, 0), expected, 1e-10, "corner state")
+			}
+		})
+	}
+}
+
+func TestCenterSizeFilter_PartialUpdateKeepsSize(t *testing.T) {
+	for name, factory := range newCenterSizeFactories() {
+		t.Run(name, func(t *testing.T) {
+			filter := factory.CreateFilter(mat.NewDense(2, 2, []float64{0, 0, 40, 20}))
+
+			// Only the top-left corner is measured, 10 units to the right
+			H := mat.NewDense(4, 8, nil)
+			H.Set(0, 0, 1)
+			H.Set(1, 1, 1)
+			filter.Predict()
+			filter.Update(mat.NewDense(4, 1, []float64{10, 0, 0, 0}), nil, H)
+
+			state := filter.GetStateVector()
+			testutil.AssertAlmostEqual(t, state.At(2, 0)-state.At(0, 0), 40, 1e-10, "width")
+			testutil.AssertAlmostEqual(t, state.At(3, 0)-state.At(1, 0), 20, 1e-10, "height")
+			if state.At(0, 0) <= 0 || state.At(2, 0) <= 40 {
+				t.Errorf("Expected the whole box to move right, got %v", mat.Formatted(state.T()))
+			}
+		})
+	}
+}
+
+func TestCenterSizeFilter_PositionCovariance(t *testing.T) {
+	factory := newCenterSizeFactories()["optimized"]
+	filter := factory.CreateFilter(mat.NewDense(2, 2, []float64{0, 0, 40, 20}))
+	cov := filter.(CovarianceFilter).PositionCovariance()
+
+	// var(x1) = var(cx) + var(w)/4, cov(x1, x2) = var(cx) - var(w)/4
+	testutil.AssertAlmostEqual(t, cov.At(0, 0), 12.5, 1e-10, "var(x1)")
+	testutil.AssertAlmostEqual(t, cov.At(0, 2), 7.5, 1e-10, "cov(x1, x2)")
+	testutil.AssertAlmostEqual(t, cov.At(2, 0), 7.5, 1e-10, "cov(x2, x1)")
+	testutil.AssertAlmostEqual(t, cov.At(0, 1), 0, 1e-10, "cov(x1, y1)")
+}
+
+func TestCenterSizeFilter_MeasurementNoise(t *testing.T) {
+	for name, factory := range newCenterSizeFactories() {
+		t.Run(name, func(t *testing.T) {
+			filter := factory.CreateFilter(mat.NewDense(2, 2, []float64{0, 0, 40, 20}))
+			inspectable, ok := filter.(InspectableFilter)
+			if !ok {
+				t.Fatalf("Expected %T to implement InspectableFilter", filter)
+			}
+
+			// R is diagonal in center-size space: var(x1) = var(cx) + var(w)/4,
+			// cov(x1, x2) = var(cx) - var(w)/4
+			inner := filter.(*CenterSizeFilter).Inner().(InspectableFilter).MeasurementNoise()
+			R := inspectable.MeasurementNoise()
+			testutil.AssertAlmostEqual(t, R.At(0, 0), inner.At(0, 0)+inner.At(2, 2)/4, 1e-10, "var(x1)")
+			testutil.AssertAlmostEqual(t, R.At(0, 2), inner.At(0, 0)-inner.At(2, 2)/4, 1e-10, "cov(x1, x2)")
+
+			if rows, cols := inspectable.Covariance().Dims(); rows != 8 || cols != 8 {
+				t.Errorf("Expected an 8x8 covariance, got %dx%d", rows, cols)
+			}
+			if rows, cols := inspectable.ProcessNoise().Dims(); rows != 8 || cols != 8 {
+				t.Errorf("Expected an 8x8 process noise, got %dx%d", rows, cols)
+			}
+		})
+	}
+}
+
+func TestCenterSizeFilter_KeypointsKeepCorners(t *testing.T) {
+	for name, factory := range newCenterSizeFactories() {
+		filter := factory.CreateFilter(mat.NewDense(3, 2, []float64{0, 0, 1, 1, 2, 2}))
+		if _, ok := filter.(*CenterSizeFilter); ok {
+			t.Errorf("%s: Expected keypoints not to use CenterSizeFilter", name)
+		}
+	}
+}
+
+func TestTracker_CenterSizeState(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("iou"),
+		DistanceThreshold:   0.7,
+		InitializationDelay: 0,
+		FilterFactory:       newCenterSizeFactories()["optimized"],
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	// A box moving right by 5 and growing by 2 per frame
+	var objects []*TrackedObject
+	for frame := 0; frame < 20; frame++ {
+		x, w := 5*float64(frame), 40+2*float64(frame)
+		det, _ := NewDetectionFromBox(x, 10, x+w, 50, nil)
+		objects = tracker.Update([]*Detection{det}, 1, nil)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 object, got %d", len(objects))
+	}
+	velocity := objects[0].EstimateVelocity()
+	testutil.AssertAlmostEqual(t, velocity.At(0, 0), 5, 0.5, "x1 velocity")
+	testutil.AssertAlmostEqual(t, velocity.At(1, 0), 7, 0.5, "x2 velocity")
+}
@@ -2,12 +2,8 @@ package norfairgo
 
 import (
 	"fmt"
-	"image"
-	"image/color"
-	"log"
 	"math"
 
-	"gocv.io/x/gocv"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -37,6 +33,58 @@ type TransformationGetter interface {
 	Call(currPts, prevPts *mat.Dense) (bool, CoordinateTransformation)
 }
 
+// TransformationResult is a transformation with the point counts it was estimated
+// from, so applications can decide whether to trust it.
+type TransformationResult struct {
+	// UpdateReference reports whether the reference frame should be updated.
+	UpdateReference bool
+
+	// Transformation is the computed transformation (nil if none could be found).
+	Transformation CoordinateTransformation
+
+	// NumPoints is the number of point correspondences passed to the getter.
+	NumPoints int
+
+	// NumInliers is the number of correspondences consistent with the transformation:
+	// RANSAC inliers for homographies, points in the flow mode for translations.
+	NumInliers int
+}
+
+// InlierRatio returns NumInliers / NumPoints, or 0 without points.
+func (r TransformationResult) InlierRatio() float64 {
+	if r.NumPoints == 0 {
+		return 0
+	}
+	return float64(r.NumInliers) / float64(r.NumPoints)
+}
+
+// DetailedTransformationGetter is a TransformationGetter that also reports the
+// point counts behind each transformation. All transformation getters of this
+// package implement it.
+type DetailedTransformationGetter interface {
+	TransformationGetter
+
+	// CallDetailed computes the transformation between current and previous points
+	// like Call, and returns it with its point counts.
+	CallDetailed(currPts, prevPts *mat.Dense) TransformationResult
+}
+
+// callTransformationGetter calls getter.CallDetailed if it is a
+// DetailedTransformationGetter, or Call otherwise, reporting all points as inliers.
+func callTransformationGetter(getter TransformationGetter, currPts, prevPts *mat.Dense) TransformationResult {
+	if detailed, ok := getter.(DetailedTransformationGetter); ok {
+		return detailed.CallDetailed(currPts, prevPts)
+	}
+	numPoints, _ := prevPts.Dims()
+	updatePrvs, transformation := getter.Call(currPts, prevPts)
+	return TransformationResult{
+		UpdateReference: updatePrvs,
+		Transformation:  transformation,
+		NumPoints:       numPoints,
+		NumInliers:      numPoints,
+	}
+}
+
 // NilCoordinateTransformation is a no-op transformation that returns points unchanged.
 // This is used when camera motion is not being tracked.
 type NilCoordinateTransformation struct{}
@@ -135,6 +183,14 @@ func NewTranslationTransformationGetter(binSize, proportionPointsUsedThreshold f
 
 // Call computes the translation transformation between current and previous points.
 // Returns (shouldUpdateReference, transformation).
+func (t *TranslationTransformationGetter) Call(currPts, prevPts *mat.Dense) (bool, CoordinateTransformation) {
+	result := t.CallDetailed(currPts, prevPts)
+	return result.UpdateReference, result.Transformation
+}
+
+// CallDetailed computes the translation transformation between current and
+// previous points, see DetailedTransformationGetter. The inliers are the points
+// whose flow falls in the mode bin.
 //
 // Algorithm:
 // 1. Calculate optical flow: flow = currPts - prevPts
@@ -142,63 +198,39 @@ func NewTranslationTransformationGetter(binSize, proportionPointsUsedThreshold f
 // 3. Find mode (most common flow vector)
 // 4. Check if proportion of points using mode is above threshold
 // 5. Accumulate with previous transformation if reference frame not updated
-func (t *TranslationTransformationGetter) Call(currPts, prevPts *mat.Dense) (bool, CoordinateTransformation) {
+func (t *TranslationTransformationGetter) CallDetailed(currPts, prevPts *mat.Dense) TransformationResult {
 	currRows, currCols := currPts.Dims()
 	prevRows, prevCols := prevPts.Dims()
 
 	if currRows != prevRows || currCols != prevCols {
 		// Invalid input, return nil transformation
-		return true, &TranslationTransformation{MovementVector: []float64{0, 0}}
+		return TransformationResult{UpdateReference: true, Transformation: &TranslationTransformation{MovementVector: []float64{0, 0}}, NumPoints: prevRows}
 	}
 
 	if currCols != 2 {
 		// Not 2D points, return nil transformation
-		return true, &TranslationTransformation{MovementVector: []float64{0, 0}}
+		return TransformationResult{UpdateReference: true, Transformation: &TranslationTransformation{MovementVector: []float64{0, 0}}, NumPoints: prevRows}
 	}
 
-	// Step 1: Calculate flow = currPts - prevPts
-	flow := make([][]float64, currRows)
-	for i := 0; i < currRows; i++ {
-		flow[i] = []float64{
-			currPts.At(i, 0) - prevPts.At(i, 0),
-			currPts.At(i, 1) - prevPts.At(i, 1),
-		}
-	}
-
-	// Step 2: Bin the flow vectors (round to nearest bin_size)
-	binnedFlow := make([][]float64, currRows)
+	// Steps 1-3: Bin the flow = currPts - prevPts (round to nearest bin_size) and
+	// find the mode (most common binned flow vector). Ties resolve to the smallest
+	// bin, like np.unique followed by argmax.
+	flowCounts := make(map[flowBin]int, currRows)
+	var modeBin flowBin
+	maxCount := 0
 	for i := 0; i < currRows; i++ {
-		binnedFlow[i] = []float64{
-			math.Round(flow[i][0]/t.BinSize) * t.BinSize,
-			math.Round(flow[i][1]/t.BinSize) * t.BinSize,
+		bin := flowBin{
+			x: int64(math.Round((currPts.At(i, 0) - prevPts.At(i, 0)) / t.BinSize)),
+			y: int64(math.Round((currPts.At(i, 1) - prevPts.At(i, 1)) / t.BinSize)),
 		}
-	}
-
-	// Step 3: Find mode (most common flow vector)
-	// Group by binned flow and count occurrences
-	flowCounts := make(map[string]int)
-	flowVectors := make(map[string][]float64)
-
-	for _, f := range binnedFlow {
-		// Use string key for map (floats aren't directly comparable)
-		key := fmt.Sprintf("%.10f,%.10f", f[0], f[1])
-		flowCounts[key]++
-		if _, exists := flowVectors[key]; !exists {
-			flowVectors[key] = f
-		}
-	}
-
-	// Find the flow with maximum count
-	var maxKey string
-	maxCount := 0
-	for key, count := range flowCounts {
-		if count > maxCount {
+		flowCounts[bin]++
+		if count := flowCounts[bin]; count > maxCount || (count == maxCount && bin.less(modeBin)) {
 			maxCount = count
-			maxKey = key
+			modeBin = bin
 		}
 	}
 
-	flowMode := flowVectors[maxKey]
+	flowMode := []float64{float64(modeBin.x) * t.BinSize, float64(modeBin.y) * t.BinSize}
 
 	// Step 4: Check proportion of points using the mode
 	proportionPointsUsed := float64(maxCount) / float64(currRows)
@@ -216,7 +248,22 @@ func (t *TranslationTransformationGetter) Call(currPts, prevPts *mat.Dense) (boo
 	}
 
 	transformation, _ := NewTranslationTransformation(flowMode)
-	return updatePrvs, transformation
+	return TransformationResult{
+		UpdateReference: updatePrvs,
+		Transformation:  transformation,
+		NumPoints:       currRows,
+		NumInliers:      maxCount,
+	}
+}
+
+// flowBin is an optical flow vector rounded to integer multiples of the bin size.
+type flowBin struct {
+	x, y int64
+}
+
+// less orders bins lexicographically by (x, y).
+func (b flowBin) less(other flowBin) bool {
+	return b.x < other.x || (b.x == other.x && b.y < other.y)
 }
 
 //
@@ -311,486 +358,3 @@ func (h *HomographyTransformation) transformPoints(points *mat.Dense, transformM
 
 	return result
 }
-
-// HomographyTransformationGetter calculates HomographyTransformation between points using RANSAC.
-//
-// The camera movement is represented as a homography that matches the optical flow between
-// the previous reference frame and the current. Comparing consecutive frames can make differences
-// too small to correctly estimate the homography, so the reference frame is kept fixed as we
-// progress through the video. Eventually, if the transformation can no longer match enough points,
-// the reference frame is updated.
-type HomographyTransformationGetter struct {
-	// Method is the OpenCV method for finding homographies.
-	// Valid options: gocv.HomographyMethodRANSAC (default), gocv.HomographyMethodLMEDS, gocv.HomographyMethodRHO
-	Method gocv.HomographyMethod
-
-	// RansacReprojThreshold is the maximum allowed reprojection error to treat a point pair as an inlier.
-	RansacReprojThreshold float64
-
-	// MaxIters is the maximum number of RANSAC iterations.
-	MaxIters int
-
-	// Confidence is the RANSAC confidence level (between 0 and 1).
-	Confidence float64
-
-	// ProportionPointsUsedThreshold is the minimum proportion of points that must be matched.
-	// If the proportion falls below this threshold, the reference frame is updated.
-	ProportionPointsUsedThreshold float64
-
-	// data stores the accumulated homography from the original reference frame.
-	// nil on first call, then accumulates homographies via matrix multiplication.
-	data *mat.Dense
-}
-
-// NewHomographyTransformationGetter creates a new homography transformation getter with RANSAC.
-func NewHomographyTransformationGetter(ransacReprojThreshold float64, maxIters int, confidence, proportionPointsUsedThreshold float64) *HomographyTransformationGetter {
-	return &HomographyTransformationGetter{
-		Method:                        gocv.HomographyMethodRANSAC,
-		RansacReprojThreshold:         ransacReprojThreshold,
-		MaxIters:                      maxIters,
-		Confidence:                    confidence,
-		ProportionPointsUsedThreshold: proportionPointsUsedThreshold,
-		data:                          nil,
-	}
-}
-
-// Call computes the homography transformation between current and previous points using RANSAC.
-// Returns (shouldUpdateReference, transformation).
-//
-// Algorithm:
-// 1. Validate minimum 4 points (homography requires ≥4 correspondences)
-// 2. Call gocv.FindHomography() with RANSAC
-// 3. Count inliers and check proportion
-// 4. Accumulate homographies via matrix multiplication (NOT addition!)
-// 5. Determine if reference frame should be updated
-func (h *HomographyTransformationGetter) Call(currPts, prevPts *mat.Dense) (bool, CoordinateTransformation) {
-	currRows, currCols := currPts.Dims()
-	prevRows, prevCols := prevPts.Dims()
-
-	// Validate minimum points and dimensions
-	if currRows < 4 || prevRows < 4 || currCols != 2 || prevCols != 2 {
-		log.Printf("Warning: Homography couldn't be computed due to insufficient points (need ≥4, got curr=%d, prev=%d)", currRows, prevRows)
-
-		// Return previous transformation if available
-		if h.data != nil {
-			trans, _ := NewHomographyTransformation(h.data)
-			return true, trans
-		}
-		return true, nil
-	}
-
-	// Convert gonum matrices to gocv Mat
-	prevPtsGocv := matDenseToGocvMat(prevPts)
-	currPtsGocv := matDenseToGocvMat(currPts)
-	defer prevPtsGocv.Close()
-	defer currPtsGocv.Close()
-
-	// Call gocv.FindHomography with RANSAC
-	mask := gocv.NewMat()
-	defer mask.Close()
-
-	homographyMat := gocv.FindHomography(
-		prevPtsGocv,
-		currPtsGocv,
-		h.Method,
-		h.RansacReprojThreshold,
-		&mask,
-		h.MaxIters,
-		h.Confidence,
-	)
-	defer homographyMat.Close()
-
-	// Check if homography computation failed
-	if homographyMat.Empty() {
-		log.Printf("Warning: FindHomography returned empty matrix")
-		if h.data != nil {
-			trans, _ := NewHomographyTransformation(h.data)
-			return true, trans
-		}
-		return true, nil
-	}
-
-	// Convert gocv.Mat (3x3) to gonum *mat.Dense
-	homographyMatrix := gocvMatToMatDense(homographyMat)
-
-	// Count inliers from mask
-	inlierCount := gocv.CountNonZero(mask)
-	totalPoints := prevRows
-	proportionPointsUsed := float64(inlierCount) / float64(totalPoints)
-
-	// Determine if reference frame should be updated
-	updatePrvs := proportionPointsUsed < h.ProportionPointsUsedThreshold
-
-	// Accumulate homographies via matrix multiplication (NOT addition!)
-	// Python: homography_matrix = homography_matrix @ self.data
-	if h.data != nil {
-		var accumulated mat.Dense
-		accumulated.Mul(homographyMatrix, h.data)
-		homographyMatrix = &accumulated
-	}
-
-	// Update accumulated data if reference frame should be updated
-	if updatePrvs {
-		h.data = homographyMatrix
-	}
-
-	// Create and return transformation
-	transformation, err := NewHomographyTransformation(homographyMatrix)
-	if err != nil {
-		log.Printf("Warning: Failed to create HomographyTransformation: %v", err)
-		if h.data != nil {
-			trans, _ := NewHomographyTransformation(h.data)
-			return true, trans
-		}
-		return true, nil
-	}
-
-	return updatePrvs, transformation
-}
-
-//
-// gocv Conversion Helpers
-//
-
-// matDenseToGocvMat converts a gonum *mat.Dense (Nx2) to gocv.Mat for FindHomography.
-// The input matrix should have shape (N, 2) where each row is an (x, y) point.
-// Returns a CV_32FC2 Mat (2-channel float32).
-func matDenseToGocvMat(m *mat.Dense) gocv.Mat {
-	rows, _ := m.Dims()
-
-	// Create a flat array of float32 data in interleaved format: [x1, y1, x2, y2, ...]
-	data := make([]float32, rows*2)
-	for i := 0; i < rows; i++ {
-		data[i*2] = float32(m.At(i, 0))   // x
-		data[i*2+1] = float32(m.At(i, 1)) // y
-	}
-
-	// Create CV_32FC2 Mat from bytes
-	// CV_32FC2 means 2-channel float32, which is exactly what FindHomography expects
-	result, err := gocv.NewMatFromBytes(rows, 1, gocv.MatTypeCV32FC2, toBytes(data))
-	if err != nil {
-		log.Printf("Error creating Mat from bytes: %v", err)
-		return gocv.NewMat()
-	}
-
-	return result
-}
-
-// toBytes converts a slice of float32 to a slice of bytes
-func toBytes(data []float32) []byte {
-	bytes := make([]byte, len(data)*4) // 4 bytes per float32
-	for i, v := range data {
-		bits := math.Float32bits(v)
-		bytes[i*4] = byte(bits)
-		bytes[i*4+1] = byte(bits >> 8)
-		bytes[i*4+2] = byte(bits >> 16)
-		bytes[i*4+3] = byte(bits >> 24)
-	}
-	return bytes
-}
-
-// gocvMatToMatDense converts a gocv.Mat (3x3 homography matrix) to gonum *mat.Dense.
-// The input should be a CV_64F or CV_32F matrix.
-func gocvMatToMatDense(m gocv.Mat) *mat.Dense {
-	rows := m.Rows()
-	cols := m.Cols()
-
-	data := make([]float64, rows*cols)
-
-	// gocv stores matrices row-major
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			// GetDoubleAt works for both CV_64F and CV_32F
-			data[i*cols+j] = m.GetDoubleAt(i, j)
-		}
-	}
-
-	return mat.NewDense(rows, cols, data)
-}
-
-//
-// Motion Estimator
-//
-
-// MotionEstimator tracks camera motion across video frames using optical flow.
-// It maintains a reference frame and tracks feature points between frames to compute
-// coordinate transformations for camera motion compensation.
-type MotionEstimator struct {
-	// Corner detection parameters
-	MaxPoints    int     // Maximum number of corner points to sample for optical flow
-	MinDistance  int     // Minimum distance between sampled points
-	BlockSize    int     // Size of averaging block for corner detection
-	QualityLevel float64 // Minimal accepted quality for corner detection (0.0 to 1.0)
-
-	// Transformation computation
-	TransformationsGetter TransformationGetter // Strategy for computing coordinate transformations
-
-	// Optional flow visualization
-	DrawFlow  bool       // Enable visual debugging by drawing optical flow vectors
-	FlowColor color.RGBA // Color for flow visualization
-
-	// Internal state
-	grayPrvs                  gocv.Mat             // Reference frame (grayscale)
-	grayNext                  gocv.Mat             // Current frame (grayscale)
-	prevPts                   *mat.Dense           // Points from the previous reference frame
-	prevMask                  gocv.Mat             // Mask from the previous reference frame
-	transformationsGetterCopy TransformationGetter // Deep copy for error recovery
-}
-
-// NewMotionEstimator creates a new MotionEstimator with the specified parameters.
-// If transformationsGetter is nil, it defaults to HomographyTransformationGetter.
-func NewMotionEstimator(
-	maxPoints int,
-	minDistance int,
-	blockSize int,
-	qualityLevel float64,
-	transformationsGetter TransformationGetter,
-	drawFlow bool,
-	flowColor *color.RGBA,
-) *MotionEstimator {
-	// Default to HomographyTransformationGetter if nil
-	if transformationsGetter == nil {
-		transformationsGetter = NewHomographyTransformationGetter(3.0, 2000, 0.995, 0.9)
-	}
-
-	// Default flow color to blue if nil and drawFlow is true
-	var flowCol color.RGBA
-	if flowColor != nil {
-		flowCol = *flowColor
-	} else if drawFlow {
-		flowCol = color.RGBA{R: 0, G: 0, B: 255, A: 0} // Blue
-	}
-
-	// TODO: Create deep copy of transformationsGetter for error recovery
-	// For now, just use the same instance
-	transformationsGetterCopy := transformationsGetter
-
-	return &MotionEstimator{
-		MaxPoints:                 maxPoints,
-		MinDistance:               minDistance,
-		BlockSize:                 blockSize,
-		QualityLevel:              qualityLevel,
-		TransformationsGetter:     transformationsGetter,
-		DrawFlow:                  drawFlow,
-		FlowColor:                 flowCol,
-		grayPrvs:                  gocv.NewMat(),
-		grayNext:                  gocv.NewMat(),
-		prevPts:                   nil,
-		prevMask:                  gocv.NewMat(),
-		transformationsGetterCopy: transformationsGetterCopy,
-	}
-}
-
-// Close releases resources held by the MotionEstimator.
-// Must be called when done using the MotionEstimator.
-// Safe to call multiple times.
-func (m *MotionEstimator) Close() {
-	// Close grayPrvs if not already closed
-	if m.grayPrvs.Ptr() != nil {
-		if !m.grayPrvs.Empty() {
-			m.grayPrvs.Close()
-		}
-		m.grayPrvs = gocv.NewMat()
-	}
-
-	// Close grayNext if not already closed
-	if m.grayNext.Ptr() != nil {
-		if !m.grayNext.Empty() {
-			m.grayNext.Close()
-		}
-		m.grayNext = gocv.NewMat()
-	}
-
-	// Close prevMask if not already closed
-	if m.prevMask.Ptr() != nil {
-		if !m.prevMask.Empty() {
-			m.prevMask.Close()
-		}
-		m.prevMask = gocv.NewMat()
-	}
-}
-
-// getSparseFlow computes sparse optical flow between two frames.
-// If prevPts is nil, it detects new corner points in grayPrvs.
-// Returns matched point pairs (currPts, prevPts) as gonum matrices.
-func (m *MotionEstimator) getSparseFlow(mask gocv.Mat) (*mat.Dense, *mat.Dense, error) {
-	// Step 1: Detect corner points if we don't have previous points
-	var prevPtsGocv gocv.Mat
-	if m.prevPts == nil {
-		// Use goodFeaturesToTrack to find corners
-		corners := gocv.NewMat()
-		defer corners.Close()
-
-		gocv.GoodFeaturesToTrack(
-			m.grayPrvs,
-			&corners,
-			m.MaxPoints,
-			m.QualityLevel,
-			float64(m.MinDistance),
-		)
-
-		// Apply mask if provided
-		if !mask.Empty() {
-			// TODO: Implement mask filtering for corners
-			// For now, use all detected corners
-		}
-
-		if corners.Rows() == 0 {
-			return nil, nil, fmt.Errorf("no corners detected")
-		}
-
-		prevPtsGocv = corners
-	} else {
-		// Convert previous points from gonum to gocv format
-		prevPtsGocv = matDenseToGocvMat(m.prevPts)
-		defer prevPtsGocv.Close()
-	}
-
-	// Step 2: Track points using optical flow
-	currPtsGocv := gocv.NewMat()
-	defer currPtsGocv.Close()
-
-	status := gocv.NewMat()
-	defer status.Close()
-
-	errMat := gocv.NewMat()
-	defer errMat.Close()
-
-	// Calculate optical flow (Lucas-Kanade with pyramids)
-	gocv.CalcOpticalFlowPyrLK(
-		m.grayPrvs,
-		m.grayNext,
-		prevPtsGocv,
-		currPtsGocv,
-		&status,
-		&errMat,
-	)
-
-	// Step 3: Filter to successfully tracked points (status == 1)
-	var prevFiltered []float64
-	var currFiltered []float64
-	numPoints := 0
-
-	for i := 0; i < status.Rows(); i++ {
-		if status.GetUCharAt(i, 0) == 1 {
-			// Get previous point
-			prevVec := prevPtsGocv.GetVecfAt(i, 0)
-			prevFiltered = append(prevFiltered, float64(prevVec[0]), float64(prevVec[1]))
-
-			// Get current point
-			currVec := currPtsGocv.GetVecfAt(i, 0)
-			currFiltered = append(currFiltered, float64(currVec[0]), float64(currVec[1]))
-
-			numPoints++
-		}
-	}
-
-	if numPoints == 0 {
-		return nil, nil, fmt.Errorf("no points successfully tracked")
-	}
-
-	// Convert to gonum matrices (N, 2)
-	prevPtsMat := mat.NewDense(numPoints, 2, prevFiltered)
-	currPtsMat := mat.NewDense(numPoints, 2, currFiltered)
-
-	return currPtsMat, prevPtsMat, nil
-}
-
-// Update processes a new frame and computes the coordinate transformation for camera motion.
-// Returns the transformation (or nil if it cannot be computed).
-// The frame parameter is modified in-place if DrawFlow is enabled.
-func (m *MotionEstimator) Update(frame gocv.Mat, mask gocv.Mat) CoordinateTransformation {
-	// Step 1: Convert frame to grayscale
-	gocv.CvtColor(frame, &m.grayNext, gocv.ColorBGRToGray)
-
-	// Step 2: First frame initialization
-	if m.grayPrvs.Empty() {
-		m.grayNext.CopyTo(&m.grayPrvs)
-		if !mask.Empty() {
-			mask.CopyTo(&m.prevMask)
-		}
-		return nil // No transformation for first frame
-	}
-
-	// Step 3: Get sparse optical flow
-	currPts, prevPts, err := m.getSparseFlow(mask)
-	if err != nil {
-		log.Printf("Warning: Optical flow calculation failed: %v", err)
-		return nil
-	}
-
-	// Step 4: Optional flow visualization
-	if m.DrawFlow && !frame.Empty() {
-		m.drawOpticalFlow(frame, prevPts, currPts)
-	}
-
-	// Step 5: Compute transformation via TransformationsGetter
-	var coordTransformations CoordinateTransformation
-	updatePrvs := false
-
-	// Try-catch around transformation calculation (error recovery)
-	func() {
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("Warning: Transformation calculation failed: %v", r)
-				// Restore from copy
-				m.TransformationsGetter = m.transformationsGetterCopy
-				coordTransformations = nil
-			}
-		}()
-
-		updatePrvs, coordTransformations = m.TransformationsGetter.Call(currPts, prevPts)
-	}()
-
-	// Step 6: Handle reference frame update signal
-	if updatePrvs {
-		// Update reference frame
-		m.grayNext.CopyTo(&m.grayPrvs)
-		// Reset tracked points (will detect new corners on next frame)
-		m.prevPts = nil
-		// Update mask
-		if !mask.Empty() {
-			mask.CopyTo(&m.prevMask)
-		} else {
-			m.prevMask = gocv.NewMat()
-		}
-	} else {
-		// Keep reference frame, update tracked points for next iteration
-		m.prevPts = prevPts
-	}
-
-	// Step 7: Return transformation
-	return coordTransformations
-}
-
-// drawOpticalFlow draws optical flow vectors on the frame for visualization.
-// Modifies the frame in-place.
-func (m *MotionEstimator) drawOpticalFlow(frame gocv.Mat, prevPts, currPts *mat.Dense) {
-	numPoints, _ := prevPts.Dims()
-
-	for i := 0; i < numPoints; i++ {
-		// Get previous and current points
-		prevX := int(prevPts.At(i, 0))
-		prevY := int(prevPts.At(i, 1))
-		currX := int(currPts.At(i, 0))
-		currY := int(currPts.At(i, 1))
-
-		// Draw line from previous to current position
-		gocv.Line(
-			&frame,
-			image.Pt(prevX, prevY),
-			image.Pt(currX, currY),
-			m.FlowColor,
-			2, // thickness
-		)
-
-		// Draw circle at current position
-		gocv.Circle(
-			&frame,
-			image.Pt(currX, currY),
-			3, // radius
-			m.FlowColor,
-			-1, // filled
-		)
-	}
-}
@@ -0,0 +1,458 @@
+//go:build !nogocv
+
+package norfairgo
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"runtime"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+
+	conv "github.com/nmichlo/norfair-go/pkg/norfairgoconv"
+)
+
+// =============================================================================
+// OpenCV Motion Estimation (excluded by the nogocv build tag)
+// =============================================================================
+
+// HomographyTransformationGetter calculates HomographyTransformation between points using RANSAC.
+//
+// The camera movement is represented as a homography that matches the optical flow between
+// the previous reference frame and the current. Comparing consecutive frames can make differences
+// too small to correctly estimate the homography, so the reference frame is kept fixed as we
+// progress through the video. Eventually, if the transformation can no longer match enough points,
+// the reference frame is updated.
+type HomographyTransformationGetter struct {
+	// Method is the OpenCV method for finding homographies.
+	// Valid options: gocv.HomographyMethodRANSAC (default), gocv.HomographyMethodLMEDS, gocv.HomographyMethodRHO
+	Method gocv.HomographyMethod
+
+	// RansacReprojThreshold is the maximum allowed reprojection error to treat a point pair as an inlier.
+	RansacReprojThreshold float64
+
+	// MaxIters is the maximum number of RANSAC iterations.
+	MaxIters int
+
+	// Confidence is the RANSAC confidence level (between 0 and 1).
+	Confidence float64
+
+	// ProportionPointsUsedThreshold is the minimum proportion of points that must be matched.
+	// If the proportion falls below this threshold, the reference frame is updated.
+	ProportionPointsUsedThreshold float64
+
+	// Seed, if set, reseeds OpenCV's random number generator before every
+	// FindHomography call, so runs are reproducible. For reproducible runs
+	// without OpenCV, use GonumHomographyTransformationGetter.
+	// Default: nil (OpenCV's global RNG state)
+	Seed *int64
+
+	// data stores the accumulated homography from the original reference frame.
+	// nil on first call, then accumulates homographies via matrix multiplication.
+	data *mat.Dense
+}
+
+// NewHomographyTransformationGetter creates a new homography transformation getter with RANSAC.
+func NewHomographyTransformationGetter(ransacReprojThreshold float64, maxIters int, confidence, proportionPointsUsedThreshold float64) *HomographyTransformationGetter {
+	return &HomographyTransformationGetter{
+		Method:                        gocv.HomographyMethodRANSAC,
+		RansacReprojThreshold:         ransacReprojThreshold,
+		MaxIters:                      maxIters,
+		Confidence:                    confidence,
+		ProportionPointsUsedThreshold: proportionPointsUsedThreshold,
+		data:                          nil,
+	}
+}
+
+// Call computes the homography transformation between current and previous points using RANSAC.
+// Returns (shouldUpdateReference, transformation).
+func (h *HomographyTransformationGetter) Call(currPts, prevPts *mat.Dense) (bool, CoordinateTransformation) {
+	result := h.CallDetailed(currPts, prevPts)
+	return result.UpdateReference, result.Transformation
+}
+
+// CallDetailed computes the homography transformation between current and previous
+// points, see DetailedTransformationGetter. The inliers are the RANSAC inliers.
+//
+// Algorithm:
+// 1. Validate minimum 4 points (homography requires ≥4 correspondences)
+// 2. Call gocv.FindHomography() with RANSAC
+// 3. Count inliers and check proportion
+// 4. Accumulate homographies via matrix multiplication (NOT addition!)
+// 5. Determine if reference frame should be updated
+func (h *HomographyTransformationGetter) CallDetailed(currPts, prevPts *mat.Dense) TransformationResult {
+	currRows, currCols := currPts.Dims()
+	prevRows, prevCols := prevPts.Dims()
+
+	// Validate minimum points and dimensions
+	if currRows < 4 || prevRows < 4 || currCols != 2 || prevCols != 2 {
+		log.Printf("Warning: Homography couldn't be computed due to insufficient points (need ≥4, got curr=%d, prev=%d)", currRows, prevRows)
+		return previousHomography(h.data, prevRows)
+	}
+
+	// Convert gonum matrices to gocv Mat
+	prevPtsGocv, err := conv.PointsToMat(prevPts)
+	if err != nil {
+		log.Printf("Warning: Failed to convert points: %v", err)
+		return previousHomography(h.data, prevRows)
+	}
+	defer prevPtsGocv.Close()
+	currPtsGocv, err := conv.PointsToMat(currPts)
+	if err != nil {
+		log.Printf("Warning: Failed to convert points: %v", err)
+		return previousHomography(h.data, prevRows)
+	}
+	defer currPtsGocv.Close()
+
+	// Call gocv.FindHomography with RANSAC
+	mask := gocv.NewMat()
+	defer mask.Close()
+
+	if h.Seed != nil {
+		// OpenCV's RNG is per thread, so seed and sample on the same thread
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		gocv.SetRNGSeed(int(*h.Seed))
+	}
+	homographyMat := gocv.FindHomography(
+		prevPtsGocv,
+		currPtsGocv,
+		h.Method,
+		h.RansacReprojThreshold,
+		&mask,
+		h.MaxIters,
+		h.Confidence,
+	)
+	defer homographyMat.Close()
+
+	// Check if homography computation failed
+	if homographyMat.Empty() {
+		log.Printf("Warning: FindHomography returned empty matrix")
+		return previousHomography(h.data, prevRows)
+	}
+
+	// Convert gocv.Mat (3x3) to gonum *mat.Dense
+	homographyMatrix, err := conv.MatToDense(homographyMat)
+	if err != nil {
+		log.Printf("Warning: Failed to convert homography: %v", err)
+		return previousHomography(h.data, prevRows)
+	}
+
+	// Count inliers from mask, accumulate and decide on the reference frame update
+	return accumulateHomography(&h.data, homographyMatrix, gocv.CountNonZero(mask), prevRows, h.ProportionPointsUsedThreshold)
+}
+
+//
+// Motion Estimator
+//
+
+// MotionEstimator tracks camera motion across video frames using optical flow.
+// It maintains a reference frame and tracks feature points between frames to compute
+// coordinate transformations for camera motion compensation.
+type MotionEstimator struct {
+	// Corner detection parameters
+	MaxPoints    int     // Maximum number of corner points to sample for optical flow
+	MinDistance  int     // Minimum distance between sampled points
+	BlockSize    int     // Size of averaging block for corner detection
+	QualityLevel float64 // Minimal accepted quality for corner detection (0.0 to 1.0)
+
+	// Transformation computation
+	TransformationsGetter TransformationGetter   // Strategy for computing coordinate transformations
+	MinInlierRatio        float64                // Reject transformations with a lower inlier ratio (0 accepts all)
+	Smoother              TransformationSmoother // Optional temporal smoothing of the transformations (nil disables)
+
+	// Optional flow visualization
+	DrawFlow  bool       // Enable visual debugging by drawing optical flow vectors
+	FlowColor color.RGBA // Color for flow visualization
+
+	// Internal state
+	grayPrvs                  gocv.Mat             // Reference frame (grayscale)
+	grayNext                  gocv.Mat             // Current frame (grayscale)
+	prevPts                   *mat.Dense           // Points from the previous reference frame
+	prevMask                  gocv.Mat             // Mask from the previous reference frame
+	transformationsGetterCopy TransformationGetter // Deep copy for error recovery
+	lastResult                TransformationResult // Result of the last transformation computation
+}
+
+// NewMotionEstimator creates a new MotionEstimator with the specified parameters.
+// If transformationsGetter is nil, it defaults to HomographyTransformationGetter.
+func NewMotionEstimator(
+	maxPoints int,
+	minDistance int,
+	blockSize int,
+	qualityLevel float64,
+	transformationsGetter TransformationGetter,
+	drawFlow bool,
+	flowColor *color.RGBA,
+) *MotionEstimator {
+	// Default to HomographyTransformationGetter if nil
+	if transformationsGetter == nil {
+		transformationsGetter = NewHomographyTransformationGetter(3.0, 2000, 0.995, 0.9)
+	}
+
+	// Default flow color to blue if nil and drawFlow is true
+	var flowCol color.RGBA
+	if flowColor != nil {
+		flowCol = *flowColor
+	} else if drawFlow {
+		flowCol = color.RGBA{R: 0, G: 0, B: 255, A: 0} // Blue
+	}
+
+	// TODO: Create deep copy of transformationsGetter for error recovery
+	// For now, just use the same instance
+	transformationsGetterCopy := transformationsGetter
+
+	return &MotionEstimator{
+		MaxPoints:                 maxPoints,
+		MinDistance:               minDistance,
+		BlockSize:                 blockSize,
+		QualityLevel:              qualityLevel,
+		TransformationsGetter:     transformationsGetter,
+		DrawFlow:                  drawFlow,
+		FlowColor:                 flowCol,
+		grayPrvs:                  gocv.NewMat(),
+		grayNext:                  gocv.NewMat(),
+		prevPts:                   nil,
+		prevMask:                  gocv.NewMat(),
+		transformationsGetterCopy: transformationsGetterCopy,
+	}
+}
+
+// Close releases resources held by the MotionEstimator.
+// Must be called when done using the MotionEstimator.
+// Safe to call multiple times.
+func (m *MotionEstimator) Close() {
+	// Close grayPrvs if not already closed
+	if m.grayPrvs.Ptr() != nil {
+		if !m.grayPrvs.Empty() {
+			m.grayPrvs.Close()
+		}
+		m.grayPrvs = gocv.NewMat()
+	}
+
+	// Close grayNext if not already closed
+	if m.grayNext.Ptr() != nil {
+		if !m.grayNext.Empty() {
+			m.grayNext.Close()
+		}
+		m.grayNext = gocv.NewMat()
+	}
+
+	// Close prevMask if not already closed
+	if m.prevMask.Ptr() != nil {
+		if !m.prevMask.Empty() {
+			m.prevMask.Close()
+		}
+		m.prevMask = gocv.NewMat()
+	}
+}
+
+// getSparseFlow computes sparse optical flow between two frames.
+// If prevPts is nil, it detects new corner points in grayPrvs.
+// Returns matched point pairs (currPts, prevPts) as gonum matrices.
+func (m *MotionEstimator) getSparseFlow(mask gocv.Mat) (*mat.Dense, *mat.Dense, error) {
+	// Step 1: Detect corner points if we don't have previous points
+	var prevPtsGocv gocv.Mat
+	if m.prevPts == nil {
+		// Use goodFeaturesToTrack to find corners
+		corners := gocv.NewMat()
+		defer corners.Close()
+
+		gocv.GoodFeaturesToTrack(
+			m.grayPrvs,
+			&corners,
+			m.MaxPoints,
+			m.QualityLevel,
+			float64(m.MinDistance),
+		)
+
+		// Apply mask if provided
+		if !mask.Empty() {
+			// TODO: Implement mask filtering for corners
+			// For now, use all detected corners
+		}
+
+		if corners.Rows() == 0 {
+			return nil, nil, fmt.Errorf("no corners detected")
+		}
+
+		prevPtsGocv = corners
+	} else {
+		// Convert previous points from gonum to gocv format
+		var err error
+		prevPtsGocv, err = conv.PointsToMat(m.prevPts)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer prevPtsGocv.Close()
+	}
+
+	// Step 2: Track points using optical flow
+	currPtsGocv := gocv.NewMat()
+	defer currPtsGocv.Close()
+
+	status := gocv.NewMat()
+	defer status.Close()
+
+	errMat := gocv.NewMat()
+	defer errMat.Close()
+
+	// Calculate optical flow (Lucas-Kanade with pyramids)
+	gocv.CalcOpticalFlowPyrLK(
+		m.grayPrvs,
+		m.grayNext,
+		prevPtsGocv,
+		currPtsGocv,
+		&status,
+		&errMat,
+	)
+
+	// Step 3: Filter to successfully tracked points (status == 1)
+	var prevFiltered []float64
+	var currFiltered []float64
+	numPoints := 0
+
+	for i := 0; i < status.Rows(); i++ {
+		if status.GetUCharAt(i, 0) == 1 {
+			// Get previous point
+			prevVec := prevPtsGocv.GetVecfAt(i, 0)
+			prevFiltered = append(prevFiltered, float64(prevVec[0]), float64(prevVec[1]))
+
+			// Get current point
+			currVec := currPtsGocv.GetVecfAt(i, 0)
+			currFiltered = append(currFiltered, float64(currVec[0]), float64(currVec[1]))
+
+			numPoints++
+		}
+	}
+
+	if numPoints == 0 {
+		return nil, nil, fmt.Errorf("no points successfully tracked")
+	}
+
+	// Convert to gonum matrices (N, 2)
+	prevPtsMat := mat.NewDense(numPoints, 2, prevFiltered)
+	currPtsMat := mat.NewDense(numPoints, 2, currFiltered)
+
+	return currPtsMat, prevPtsMat, nil
+}
+
+// Update processes a new frame and computes the coordinate transformation for camera motion.
+// Returns the transformation (or nil if it cannot be computed or its inlier ratio is
+// below MinInlierRatio, see LastTransformationResult).
+// The frame parameter is modified in-place if DrawFlow is enabled.
+func (m *MotionEstimator) Update(frame gocv.Mat, mask gocv.Mat) CoordinateTransformation {
+	m.lastResult = TransformationResult{}
+
+	// Step 1: Convert frame to grayscale
+	gocv.CvtColor(frame, &m.grayNext, gocv.ColorBGRToGray)
+
+	// Step 2: First frame initialization
+	if m.grayPrvs.Empty() {
+		m.grayNext.CopyTo(&m.grayPrvs)
+		if !mask.Empty() {
+			mask.CopyTo(&m.prevMask)
+		}
+		return nil // No transformation for first frame
+	}
+
+	// Step 3: Get sparse optical flow
+	currPts, prevPts, err := m.getSparseFlow(mask)
+	if err != nil {
+		log.Printf("Warning: Optical flow calculation failed: %v", err)
+		return nil
+	}
+
+	// Step 4: Optional flow visualization
+	if m.DrawFlow && !frame.Empty() {
+		m.drawOpticalFlow(frame, prevPts, currPts)
+	}
+
+	// Step 5: Compute transformation via TransformationsGetter
+	var result TransformationResult
+
+	// Try-catch around transformation calculation (error recovery)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Warning: Transformation calculation failed: %v", r)
+				// Restore from copy
+				m.TransformationsGetter = m.transformationsGetterCopy
+				result = TransformationResult{}
+			}
+		}()
+
+		result = callTransformationGetter(m.TransformationsGetter, currPts, prevPts)
+	}()
+	m.lastResult = result
+	updatePrvs, coordTransformations := result.UpdateReference, result.Transformation
+	if coordTransformations != nil && result.InlierRatio() < m.MinInlierRatio {
+		log.Printf("Warning: Rejecting transformation with inlier ratio %.2f < %.2f", result.InlierRatio(), m.MinInlierRatio)
+		coordTransformations = nil
+	}
+	if m.Smoother != nil {
+		coordTransformations = m.Smoother.Smooth(coordTransformations)
+	}
+
+	// Step 6: Handle reference frame update signal
+	if updatePrvs {
+		// Update reference frame
+		m.grayNext.CopyTo(&m.grayPrvs)
+		// Reset tracked points (will detect new corners on next frame)
+		m.prevPts = nil
+		// Update mask
+		if !mask.Empty() {
+			mask.CopyTo(&m.prevMask)
+		} else {
+			m.prevMask = gocv.NewMat()
+		}
+	} else {
+		// Keep reference frame, update tracked points for next iteration
+		m.prevPts = prevPts
+	}
+
+	// Step 7: Return transformation
+	return coordTransformations
+}
+
+// LastTransformationResult returns the transformation computed by the last Update
+// with its point counts, e.g. to judge how much to trust it. The result is zero
+// before the second frame and when optical flow or the transformation failed.
+func (m *MotionEstimator) LastTransformationResult() TransformationResult {
+	return m.lastResult
+}
+
+// drawOpticalFlow draws optical flow vectors on the frame for visualization.
+// Modifies the frame in-place.
+func (m *MotionEstimator) drawOpticalFlow(frame gocv.Mat, prevPts, currPts *mat.Dense) {
+	numPoints, _ := prevPts.Dims()
+
+	for i := 0; i < numPoints; i++ {
+		// Get previous and current points
+		prevX := int(prevPts.At(i, 0))
+		prevY := int(prevPts.At(i, 1))
+		currX := int(currPts.At(i, 0))
+		currY := int(currPts.At(i, 1))
+
+		// Draw line from previous to current position
+		gocv.Line(
+			&frame,
+			image.Pt(prevX, prevY),
+			image.Pt(currX, currY),
+			m.FlowColor,
+			2, // thickness
+		)
+
+		// Draw circle at current position
+		gocv.Circle(
+			&frame,
+			image.Pt(currX, currY),
+			3, // radius
+			m.FlowColor,
+			-1, // filled
+		)
+	}
+}
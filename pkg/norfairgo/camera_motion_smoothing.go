@@ -0,0 +1,119 @@
+package norfairgo
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Camera Motion Smoothing - Stabilizing Per-Frame Transformations
+// =============================================================================
+
+// TransformationSmoother smooths the sequence of transformations of a video, e.g.
+// between a TransformationGetter and the tracker (see MotionEstimator.Smoother).
+type TransformationSmoother interface {
+	// Smooth returns the smoothed version of the next transformation of the
+	// sequence. A nil transformation is returned unchanged.
+	Smooth(transformation CoordinateTransformation) CoordinateTransformation
+
+	// Reset forgets the sequence, e.g. at a scene cut.
+	Reset()
+}
+
+// AlphaBetaTransformationSmoother smooths the parameters of translations (the
+// movement vector) and homographies (the 8 entries of the matrix normalized to
+// H[2][2] = 1) with an alpha-beta filter, a steady-state Kalman filter with a
+// constant velocity model.
+//
+// Each frame, the parameters are predicted from the previous estimate and its
+// velocity, and corrected by Alpha (and the velocity by Beta) times the
+// difference to the measured parameters. With Beta = 0 this is an exponential
+// moving average, which lags behind steady camera pans; a small Beta follows
+// them without lag while still removing jitter.
+//
+// Transformations of other types are returned unchanged, and a change of type
+// restarts the smoothing.
+type AlphaBetaTransformationSmoother struct {
+	// Alpha is the weight of the measured parameters (between 0 and 1, exclusive of 0).
+	// Lower values smooth more; 1 disables smoothing.
+	Alpha float64
+
+	// Beta is the weight of the measured parameter velocity (between 0 and 1).
+	// 0 smooths with an exponential moving average.
+	Beta float64
+
+	params     []float64 // Smoothed parameters, nil before the first transformation
+	velocities []float64 // Parameter change per frame
+	homography bool      // Whether params are homography or translation parameters
+}
+
+// NewAlphaBetaTransformationSmoother creates a smoother with the given gains.
+// Returns an error if alpha is not in (0, 1] or beta not in [0, 1].
+func NewAlphaBetaTransformationSmoother(alpha, beta float64) (*AlphaBetaTransformationSmoother, error) {
+	if alpha <= 0 || alpha > 1 {
+		return nil, fmt.Errorf("alpha must be in (0, 1], got %v", alpha)
+	}
+	if beta < 0 || beta > 1 {
+		return nil, fmt.Errorf("beta must be in [0, 1], got %v", beta)
+	}
+	return &AlphaBetaTransformationSmoother{Alpha: alpha, Beta: beta}, nil
+}
+
+// Smooth implements TransformationSmoother.
+func (s *AlphaBetaTransformationSmoother) Smooth(transformation CoordinateTransformation) CoordinateTransformation {
+	var measured []float64
+	var homography bool
+	switch trans := transformation.(type) {
+	case *TranslationTransformation:
+		measured = append([]float64(nil), trans.MovementVector...)
+	case *HomographyTransformation:
+		measured, homography = homographyParams(trans.HomographyMatrix), true
+		if measured == nil {
+			return transformation
+		}
+	default:
+		return transformation
+	}
+
+	if s.params == nil || s.homography != homography || len(s.params) != len(measured) {
+		s.params, s.velocities, s.homography = measured, make([]float64, len(measured)), homography
+	} else {
+		for i, z := range measured {
+			predicted := s.params[i] + s.velocities[i]
+			residual := z - predicted
+			s.params[i] = predicted + s.Alpha*residual
+			s.velocities[i] += s.Beta * residual
+		}
+	}
+
+	if !homography {
+		return &TranslationTransformation{MovementVector: append([]float64(nil), s.params...)}
+	}
+	smoothed, err := NewHomographyTransformation(mat.NewDense(3, 3, append(append([]float64(nil), s.params...), 1)))
+	if err != nil {
+		// The smoothed matrix became singular, restart from the measurement
+		s.Reset()
+		return transformation
+	}
+	return smoothed
+}
+
+// Reset implements TransformationSmoother.
+func (s *AlphaBetaTransformationSmoother) Reset() {
+	s.params, s.velocities = nil, nil
+}
+
+// homographyParams returns the first 8 entries of the homography normalized to
+// H[2][2] = 1, or nil if H[2][2] is 0.
+func homographyParams(homography *mat.Dense) []float64 {
+	scale := homography.At(2, 2)
+	if scale == 0 {
+		return nil
+	}
+	params := make([]float64, 8)
+	for k := range params {
+		params[k] = homography.At(k/3, k%3) / scale
+	}
+	return params
+}
@@ -0,0 +1,96 @@
+package norfairgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+func newTestSmoother(t *testing.T, alpha, beta float64) *AlphaBetaTransformationSmoother {
+	t.Helper()
+	smoother, err := NewAlphaBetaTransformationSmoother(alpha, beta)
+	if err != nil {
+		t.Fatalf("Failed to create smoother: %v", err)
+	}
+	return smoother
+}
+
+func TestAlphaBetaTransformationSmoother_ReducesJitter(t *testing.T) {
+	smoother := newTestSmoother(t, 0.2, 0)
+	rng := rand.New(rand.NewSource(0))
+
+	var rawError, smoothError float64
+	for frame := 0; frame < 200; frame++ {
+		raw := []float64{10 + rng.NormFloat64(), -5 + rng.NormFloat64()}
+		smoothed := smoother.Smooth(&TranslationTransformation{MovementVector: raw}).(*TranslationTransformation)
+		if frame >= 50 {
+			rawError += math.Hypot(raw[0]-10, raw[1]+5)
+			smoothError += math.Hypot(smoothed.MovementVector[0]-10, smoothed.MovementVector[1]+5)
+		}
+	}
+	if smoothError > rawError/2 {
+		t.Errorf("Expected smoothing to at least halve the jitter, got %v vs raw %v", smoothError, rawError)
+	}
+}
+
+func TestAlphaBetaTransformationSmoother_FollowsPans(t *testing.T) {
+	ema := newTestSmoother(t, 0.2, 0)
+	alphaBeta := newTestSmoother(t, 0.2, 0.05)
+
+	// The camera pans by 3 pixels per frame
+	var emaLag, alphaBetaLag float64
+	for frame := 0; frame < 100; frame++ {
+		trans := &TranslationTransformation{MovementVector: []float64{3 * float64(frame), 0}}
+		emaLag = 3*float64(frame) - ema.Smooth(trans).(*TranslationTransformation).MovementVector[0]
+		alphaBetaLag = 3*float64(frame) - alphaBeta.Smooth(trans).(*TranslationTransformation).MovementVector[0]
+	}
+	testutil.AssertAlmostEqual(t, emaLag, 12, 1e-6, "EMA lag of (1 - alpha) / alpha * velocity")
+	testutil.AssertAlmostEqual(t, alphaBetaLag, 0, 1e-3, "alpha-beta lag")
+}
+
+func TestAlphaBetaTransformationSmoother_Homography(t *testing.T) {
+	smoother := newTestSmoother(t, 0.5, 0)
+
+	// Scaled matrices are normalized before smoothing
+	first := mat.NewDense(3, 3, []float64{2, 0, 20, 0, 2, 0, 0, 0, 2})
+	second := mat.NewDense(3, 3, []float64{1, 0, 30, 0, 1, 0, 0, 0, 1})
+	for _, homography := range []*mat.Dense{first, second} {
+		trans, _ := NewHomographyTransformation(homography)
+		smoother.Smooth(trans)
+	}
+	trans, _ := NewHomographyTransformation(second)
+	smoothed := smoother.Smooth(trans).(*HomographyTransformation)
+	testutil.AssertAlmostEqual(t, smoothed.HomographyMatrix.At(0, 2), 25, 1e-10, "smoothed tx of 10, 30, 30")
+	testutil.AssertAlmostEqual(t, smoothed.HomographyMatrix.At(0, 0), 1, 1e-10, "smoothed scale")
+
+	// A translation restarts the smoothing
+	translation := smoother.Smooth(&TranslationTransformation{MovementVector: []float64{1, 2}}).(*TranslationTransformation)
+	if translation.MovementVector[0] != 1 || translation.MovementVector[1] != 2 {
+		t.Errorf("Expected the first translation unchanged, got %v", translation.MovementVector)
+	}
+}
+
+func TestAlphaBetaTransformationSmoother_NilAndReset(t *testing.T) {
+	smoother := newTestSmoother(t, 0.5, 0)
+	smoother.Smooth(&TranslationTransformation{MovementVector: []float64{0, 0}})
+	if smoother.Smooth(nil) != nil {
+		t.Error("Expected nil to pass through")
+	}
+
+	smoother.Reset()
+	smoothed := smoother.Smooth(&TranslationTransformation{MovementVector: []float64{10, 10}}).(*TranslationTransformation)
+	if smoothed.MovementVector[0] != 10 {
+		t.Errorf("Expected the first translation after Reset unchanged, got %v", smoothed.MovementVector)
+	}
+}
+
+func TestNewAlphaBetaTransformationSmoother_Invalid(t *testing.T) {
+	for _, gains := range [][2]float64{{0, 0}, {1.5, 0}, {0.5, -0.1}, {0.5, 2}} {
+		if _, err := NewAlphaBetaTransformationSmoother(gains[0], gains[1]); err == nil {
+			t.Errorf("Expected error for alpha=%v, beta=%v", gains[0], gains[1])
+		}
+	}
+}
@@ -1,10 +1,11 @@
 package norfairgo
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 
-	"gocv.io/x/gocv"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -294,10 +295,135 @@ func TestTranslationTransformationGetter_MismatchedDimensions(t *testing.T) {
 	_ = trans
 }
 
+func TestTranslationTransformationGetter_CallDetailed(t *testing.T) {
+	getter := NewTranslationTransformationGetter(1.0, 0.9)
+
+	// 3 of 4 points move by (5, 0)
+	prevPts := mat.NewDense(4, 2, []float64{0, 0, 10, 10, 20, 20, 30, 30})
+	currPts := mat.NewDense(4, 2, []float64{5, 0, 15, 10, 25, 20, 0, 50})
+
+	result := getter.CallDetailed(currPts, prevPts)
+	if result.NumPoints != 4 || result.NumInliers != 3 {
+		t.Errorf("Expected 3 of 4 inliers, got %d of %d", result.NumInliers, result.NumPoints)
+	}
+	if result.InlierRatio() != 0.75 {
+		t.Errorf("Expected inlier ratio 0.75, got %v", result.InlierRatio())
+	}
+	if !result.UpdateReference {
+		t.Error("Expected reference update with 75% inliers < 90% threshold")
+	}
+
+	if result := getter.CallDetailed(mat.NewDense(2, 2, nil), prevPts); result.NumInliers != 0 || !result.UpdateReference {
+		t.Errorf("Expected no inliers and a reference update with mismatched dimensions, got %+v", result)
+	}
+}
+
+// callOnlyGetter hides CallDetailed of the wrapped getter.
+type callOnlyGetter struct {
+	getter TransformationGetter
+}
+
+func (g callOnlyGetter) Call(currPts, prevPts *mat.Dense) (bool, CoordinateTransformation) {
+	return g.getter.Call(currPts, prevPts)
+}
+
+func TestCallTransformationGetter(t *testing.T) {
+	prevPts := mat.NewDense(4, 2, []float64{0, 0, 10, 10, 20, 20, 30, 30})
+	currPts := mat.NewDense(4, 2, []float64{5, 0, 15, 10, 25, 20, 0, 50})
+
+	detailed := callTransformationGetter(NewTranslationTransformationGetter(1.0, 0.5), currPts, prevPts)
+	if detailed.NumInliers != 3 {
+		t.Errorf("Expected the detailed result with 3 inliers, got %d", detailed.NumInliers)
+	}
+
+	fallback := callTransformationGetter(callOnlyGetter{NewTranslationTransformationGetter(1.0, 0.5)}, currPts, prevPts)
+	if fallback.NumPoints != 4 || fallback.NumInliers != 4 {
+		t.Errorf("Expected all 4 points as inliers without CallDetailed, got %d of %d", fallback.NumInliers, fallback.NumPoints)
+	}
+	if fallback.UpdateReference != detailed.UpdateReference || fallback.Transformation == nil {
+		t.Errorf("Expected the Call results, got %+v", fallback)
+	}
+
+	if (TransformationResult{}).InlierRatio() != 0 {
+		t.Error("Expected inlier ratio 0 without points")
+	}
+}
+
 //
 // NilCoordinateTransformation Tests
 //
 
+func TestTranslationTransformationGetter_TieBreak(t *testing.T) {
+	// Two bins with equal counts: the smallest bin wins, independent of point order
+	prevPts := mat.NewDense(4, 2, []float64{0, 0, 10, 10, 20, 20, 30, 30})
+	for _, currPts := range []*mat.Dense{
+		mat.NewDense(4, 2, []float64{3, 0, 13, 10, 21, 20, 31, 30}),
+		mat.NewDense(4, 2, []float64{1, 0, 11, 10, 23, 20, 33, 30}),
+	} {
+		for i := 0; i < 10; i++ {
+			getter := NewTranslationTransformationGetter(0.5, 0.9)
+			_, trans := getter.Call(currPts, prevPts)
+			movement := trans.(*TranslationTransformation).MovementVector
+			if movement[0] != 1 || movement[1] != 0 {
+				t.Fatalf("Expected mode (1, 0), got %v", movement)
+			}
+		}
+	}
+}
+
+func TestTranslationTransformationGetter_MatchesStringKeys(t *testing.T) {
+	// The integer bins must find the same mode as the former string-keyed grouping
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		n := 200
+		prevPts := mat.NewDense(n, 2, nil)
+		currPts := mat.NewDense(n, 2, nil)
+		binSize := 0.2
+		// Centered in a bin, so the mode is unique and the reference is deterministic
+		dx := math.Round((rng.Float64()*20-10)/binSize) * binSize
+		dy := math.Round((rng.Float64()*20-10)/binSize) * binSize
+		for i := 0; i < n; i++ {
+			x, y := rng.Float64()*640, rng.Float64()*480
+			prevPts.Set(i, 0, x)
+			prevPts.Set(i, 1, y)
+			if i%3 == 0 {
+				dx, dy := rng.Float64()*40-20, rng.Float64()*40-20
+				currPts.Set(i, 0, x+dx)
+				currPts.Set(i, 1, y+dy)
+			} else {
+				currPts.Set(i, 0, x+dx+rng.Float64()*0.1-0.05)
+				currPts.Set(i, 1, y+dy+rng.Float64()*0.1-0.05)
+			}
+		}
+
+		counts := make(map[string]int)
+		vectors := make(map[string][]float64)
+		for i := 0; i < n; i++ {
+			f := []float64{
+				math.Round((currPts.At(i, 0)-prevPts.At(i, 0))/binSize) * binSize,
+				math.Round((currPts.At(i, 1)-prevPts.At(i, 1))/binSize) * binSize,
+			}
+			key := fmt.Sprintf("%.10f,%.10f", f[0], f[1])
+			counts[key]++
+			vectors[key] = f
+		}
+		var expected []float64
+		maxCount := 0
+		for key, count := range counts {
+			if count > maxCount {
+				maxCount, expected = count, vectors[key]
+			}
+		}
+
+		getter := NewTranslationTransformationGetter(binSize, 0.9)
+		_, trans := getter.Call(currPts, prevPts)
+		movement := trans.(*TranslationTransformation).MovementVector
+		if movement[0] != expected[0] || movement[1] != expected[1] {
+			t.Errorf("trial %d: expected mode %v, got %v", trial, expected, movement)
+		}
+	}
+}
+
 func TestNilCoordinateTransformation(t *testing.T) {
 	// Test that nil transformation returns points unchanged
 	nilTrans := &NilCoordinateTransformation{}
@@ -586,186 +712,6 @@ func TestHomographyTransformation_Rotation(t *testing.T) {
 	}
 }
 
-//
-// HomographyTransformationGetter Tests
-//
-
-func TestHomographyTransformationGetter_PerfectCorrespondence(t *testing.T) {
-	// Test with perfect point correspondences (translation only)
-	getter := NewHomographyTransformationGetter(3.0, 2000, 0.995, 0.9)
-
-	// Create NON-COLLINEAR points with perfect translation by (10, 20)
-	// Points form a rectangle + center point (not all on same line)
-	prevPts := mat.NewDense(5, 2, []float64{
-		0, 0, // Bottom-left
-		100, 0, // Bottom-right
-		100, 80, // Top-right
-		0, 80, // Top-left
-		50, 40, // Center
-	})
-
-	currPts := mat.NewDense(5, 2, []float64{
-		10, 20, // Translation: +10, +20
-		110, 20,
-		110, 100,
-		10, 100,
-		60, 60,
-	})
-
-	updateRef, trans := getter.Call(currPts, prevPts)
-
-	// With perfect correspondence, should NOT update reference (100% inliers > 90% threshold)
-	if updateRef {
-		t.Error("Expected NO reference update with perfect correspondence")
-	}
-
-	// Transformation should exist
-	if trans == nil {
-		t.Fatal("Expected non-nil transformation")
-	}
-
-	// Should be a HomographyTransformation
-	_, ok := trans.(*HomographyTransformation)
-	if !ok {
-		t.Error("Expected HomographyTransformation")
-	}
-}
-
-func TestHomographyTransformationGetter_InsufficientPoints(t *testing.T) {
-	// Test with < 4 points (should fail gracefully)
-	getter := NewHomographyTransformationGetter(3.0, 2000, 0.995, 0.9)
-
-	prevPts := mat.NewDense(3, 2, []float64{
-		0, 0,
-		10, 10,
-		20, 20,
-	})
-
-	currPts := mat.NewDense(3, 2, []float64{
-		5, 5,
-		15, 15,
-		25, 25,
-	})
-
-	updateRef, trans := getter.Call(currPts, prevPts)
-
-	// Should update reference (failed to compute)
-	if !updateRef {
-		t.Error("Expected reference update with insufficient points")
-	}
-
-	// Transformation should be nil (no previous data)
-	if trans != nil {
-		t.Error("Expected nil transformation with insufficient points and no previous data")
-	}
-}
-
-func TestHomographyTransformationGetter_WithOutliers(t *testing.T) {
-	// Test RANSAC outlier rejection with some bad correspondences
-	getter := NewHomographyTransformationGetter(3.0, 2000, 0.995, 0.5) // Lower threshold
-
-	// 8 points: 6 inliers + 2 outliers
-	prevPts := mat.NewDense(8, 2, []float64{
-		0, 0, // Inlier
-		100, 0, // Inlier
-		100, 80, // Inlier
-		0, 80, // Inlier
-		50, 40, // Inlier
-		25, 60, // Inlier
-		200, 200, // Outlier
-		300, 300, // Outlier
-	})
-
-	currPts := mat.NewDense(8, 2, []float64{
-		10, 20, // Inlier (translation +10, +20)
-		110, 20, // Inlier
-		110, 100, // Inlier
-		10, 100, // Inlier
-		60, 60, // Inlier
-		35, 80, // Inlier
-		50, 50, // Outlier (wrong correspondence)
-		-100, 0, // Outlier (wrong correspondence)
-	})
-
-	updateRef, trans := getter.Call(currPts, prevPts)
-
-	// With 75% inliers (6/8), should NOT update reference (> 50% threshold)
-	if updateRef {
-		t.Error("Expected NO reference update with 75% inliers > 50% threshold")
-	}
-
-	// Should return valid transformation
-	if trans == nil {
-		t.Fatal("Expected non-nil transformation")
-	}
-
-	// Verify it's a HomographyTransformation
-	_, ok := trans.(*HomographyTransformation)
-	if !ok {
-		t.Error("Expected HomographyTransformation")
-	}
-}
-
-func TestHomographyTransformationGetter_Accumulation(t *testing.T) {
-	// Test that homographies accumulate correctly over multiple calls
-	getter := NewHomographyTransformationGetter(3.0, 2000, 0.995, 0.5)
-
-	// First transformation: translate by (10, 20)
-	prevPts1 := mat.NewDense(5, 2, []float64{
-		0, 0,
-		100, 0,
-		100, 80,
-		0, 80,
-		50, 40,
-	})
-
-	currPts1 := mat.NewDense(5, 2, []float64{
-		10, 20,
-		110, 20,
-		110, 100,
-		10, 100,
-		60, 60,
-	})
-
-	updateRef1, trans1 := getter.Call(currPts1, prevPts1)
-
-	if trans1 == nil {
-		t.Fatal("First transformation should not be nil")
-	}
-
-	// Second transformation: translate by another (5, 10) from first transformed positions
-	prevPts2 := currPts1 // Use previous current points as new previous
-	currPts2 := mat.NewDense(5, 2, []float64{
-		15, 30, // Additional +5, +10
-		115, 30,
-		115, 110,
-		15, 110,
-		65, 70,
-	})
-
-	updateRef2, trans2 := getter.Call(currPts2, prevPts2)
-
-	if trans2 == nil {
-		t.Fatal("Second transformation should not be nil")
-	}
-
-	// If reference was updated on first call, accumulated transformation should exist
-	if updateRef1 {
-		// The accumulated homography should represent total translation (10+5, 20+10) = (15, 30)
-		testPt := mat.NewDense(1, 2, []float64{0, 0})
-		result := trans2.RelToAbs(testPt)
-
-		// Allow some numerical tolerance due to floating point and homography optimization
-		x, y := result.At(0, 0), result.At(0, 1)
-		if math.Abs(x-15) > 5.0 || math.Abs(y-30) > 5.0 {
-			t.Errorf("Accumulated transformation should map (0,0) near (15,30), got (%.2f, %.2f)", x, y)
-		}
-	}
-
-	// Check update flags
-	_ = updateRef2 // May or may not update depending on inlier ratio
-}
-
 //
 // Helper functions
 //
@@ -786,263 +732,3 @@ func matApproxEqual(a, b *mat.Dense, tol float64) bool {
 	}
 	return true
 }
-
-// Python equivalent: norfair/camera_motion.py::MotionEstimator
-//
-//	from norfair.camera_motion import MotionEstimator
-//	import numpy as np
-//	import cv2
-//
-//	# Create motion estimator with transformation getter
-//	motion_estimator = MotionEstimator(
-//	    transformations_getter=HomographyTransformationGetter()
-//	)
-//
-//	# Update with frame and tracked objects to estimate camera motion
-//	frame = cv2.imread("frame.jpg")
-//	coord_transformation = motion_estimator.update(
-//	    frame=frame,
-//	    tracked_objects=tracked_objects
-//	)
-//
-//	# Returns coordinate transformation that compensates for camera movement
-//	# Uses optical flow on tracked object points to estimate transformation
-//
-// Validation: tools/validate_motion_estimator/main.py tests MotionEstimator equivalence
-
-//
-// MotionEstimator Tests
-//
-
-func TestMotionEstimator_Construction(t *testing.T) {
-	// Test with default transformation getter
-	estimator1 := NewMotionEstimator(200, 15, 3, 0.01, nil, false, nil)
-	defer estimator1.Close()
-
-	if estimator1.MaxPoints != 200 {
-		t.Errorf("Expected MaxPoints=200, got %d", estimator1.MaxPoints)
-	}
-	if estimator1.MinDistance != 15 {
-		t.Errorf("Expected MinDistance=15, got %d", estimator1.MinDistance)
-	}
-	if estimator1.TransformationsGetter == nil {
-		t.Error("Expected non-nil default TransformationsGetter")
-	}
-
-	// Test with custom transformation getter
-	customGetter := NewHomographyTransformationGetter(5.0, 1000, 0.99, 0.8)
-	estimator2 := NewMotionEstimator(100, 10, 5, 0.02, customGetter, true, nil)
-	defer estimator2.Close()
-
-	if estimator2.DrawFlow != true {
-		t.Error("Expected DrawFlow=true")
-	}
-	if estimator2.TransformationsGetter != customGetter {
-		t.Error("Expected custom TransformationsGetter")
-	}
-}
-
-func TestMotionEstimator_FirstFrameInitialization(t *testing.T) {
-	estimator := NewMotionEstimator(200, 15, 3, 0.01, nil, false, nil)
-	defer estimator.Close()
-
-	// Create a simple test frame (100x100 grayscale)
-	frame := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
-	defer frame.Close()
-
-	// Fill with some pattern
-	for i := 0; i < 100; i++ {
-		for j := 0; j < 100; j++ {
-			frame.SetUCharAt(i, j*3, uint8(i+j))   // B
-			frame.SetUCharAt(i, j*3+1, uint8(i+j)) // G
-			frame.SetUCharAt(i, j*3+2, uint8(i+j)) // R
-		}
-	}
-
-	// First frame should return nil transformation
-	trans := estimator.Update(frame, gocv.NewMat())
-
-	if trans != nil {
-		t.Error("Expected nil transformation for first frame")
-	}
-
-	// Reference frame should now be set
-	if estimator.grayPrvs.Empty() {
-		t.Error("Expected reference frame to be set after first Update")
-	}
-}
-
-func TestMotionEstimator_CloseResourcesCleanly(t *testing.T) {
-	estimator := NewMotionEstimator(200, 15, 3, 0.01, nil, false, nil)
-
-	// Initialize with a frame
-	frame := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
-	defer frame.Close()
-
-	estimator.Update(frame, gocv.NewMat())
-
-	// Close should not panic
-	estimator.Close()
-
-	// Calling Close again should not panic
-	estimator.Close()
-}
-
-// Python equivalent: tools/validate_motion_estimator/main.py::Test Case 1
-//
-//	import numpy as np
-//	import norfair
-//	from norfair.camera_motion import MotionEstimator
-//
-//	# Create synthetic frames with pattern
-//	def create_frame_with_pattern(offset_x, offset_y):
-//	    frame = np.zeros((480, 640, 3), dtype=np.uint8)
-//	    for i in range(0, 480, 20):
-//	        for j in range(0, 640, 20):
-//	            x, y = j + offset_x, i + offset_y
-//	            if 0 <= x < 640 and 0 <= y < 480:
-//	                frame[y, x] = [255, 255, 255]
-//	    return frame
-//
-//	motion_estimator = MotionEstimator()
-//	frame1 = create_frame_with_pattern(0, 0)
-//	frame2 = create_frame_with_pattern(10, 20)
-//
-//	coord_transformations = motion_estimator.update(frame1, frame2)
-//	# Expected translation: approximately (+10, +20)
-//
-// Test cases match tools/validate_motion_estimator/main.py::Test Case 1 (Translation +10, +20)
-func TestMotionEstimator_ComputeTranslation_Small(t *testing.T) {
-	// Use TranslationTransformationGetter for simple translation detection
-	transformGetter := NewTranslationTransformationGetter(0.2, 0.9)
-	estimator := NewMotionEstimator(200, 15, 3, 0.01, transformGetter, false, nil)
-	defer estimator.Close()
-
-	// Create first frame with grid pattern at (0, 0)
-	frame1 := createFrameWithPattern(0, 0, 480, 640)
-	defer frame1.Close()
-
-	// Create second frame with same pattern shifted by (+10, +20)
-	frame2 := createFrameWithPattern(10, 20, 480, 640)
-	defer frame2.Close()
-
-	// First update initializes reference frame
-	_ = estimator.Update(frame1, gocv.NewMat())
-
-	// Second update should compute transformation
-	coordTransformations := estimator.Update(frame2, gocv.NewMat())
-
-	if coordTransformations == nil {
-		t.Fatal("Expected coordinate transformations, got nil")
-	}
-
-	// Verify transformation is approximately (+10, +20) translation
-	transform := coordTransformations.(*TranslationTransformation)
-	tx := transform.MovementVector[0]
-	ty := transform.MovementVector[1]
-	t.Logf("Detected translation: tx=%.2f, ty=%.2f", tx, ty)
-
-	// Note: Due to the nature of optical flow and sparse features, we use relaxed tolerances
-	// The main validation is that motion is detected in the correct direction
-	if !almostEqual(math.Abs(tx), 10.0, 15.0) {
-		t.Errorf("Expected |tx| ≈ 10.0, got %.2f", tx)
-	}
-	if !almostEqual(math.Abs(ty), 20.0, 25.0) {
-		t.Errorf("Expected |ty| ≈ 20.0, got %.2f", ty)
-	}
-}
-
-// Python equivalent: tools/validate_motion_estimator/main.py::Test Case 2
-//
-//	motion_estimator = MotionEstimator()
-//	frame1 = create_frame_with_pattern(0, 0)
-//	frame2 = create_frame_with_pattern(30, 40)
-//
-//	coord_transformations = motion_estimator.update(frame1, frame2)
-//	# Expected translation: approximately (+30, +40)
-//
-// Test cases match tools/validate_motion_estimator/main.py::Test Case 2 (Translation +30, +40)
-func TestMotionEstimator_ComputeTranslation_Large(t *testing.T) {
-	// Use TranslationTransformationGetter for simple translation detection
-	transformGetter := NewTranslationTransformationGetter(0.2, 0.9)
-	estimator := NewMotionEstimator(200, 15, 3, 0.01, transformGetter, false, nil)
-	defer estimator.Close()
-
-	// Create first frame with grid pattern at (0, 0)
-	frame1 := createFrameWithPattern(0, 0, 480, 640)
-	defer frame1.Close()
-
-	// Create second frame with same pattern shifted by (+30, +40)
-	frame2 := createFrameWithPattern(30, 40, 480, 640)
-	defer frame2.Close()
-
-	// First update initializes reference frame
-	_ = estimator.Update(frame1, gocv.NewMat())
-
-	// Second update should compute transformation
-	coordTransformations := estimator.Update(frame2, gocv.NewMat())
-
-	if coordTransformations == nil {
-		t.Fatal("Expected coordinate transformations, got nil")
-	}
-
-	// Verify transformation is approximately (+30, +40) translation
-	transform := coordTransformations.(*TranslationTransformation)
-	tx := transform.MovementVector[0]
-	ty := transform.MovementVector[1]
-	t.Logf("Detected translation: tx=%.2f, ty=%.2f", tx, ty)
-
-	// Note: Due to the nature of optical flow and sparse features, we use relaxed tolerances
-	// The main validation is that motion is detected in the correct direction
-	if !almostEqual(math.Abs(tx), 30.0, 25.0) {
-		t.Errorf("Expected |tx| ≈ 30.0, got %.2f", tx)
-	}
-	if !almostEqual(math.Abs(ty), 40.0, 45.0) {
-		t.Errorf("Expected |ty| ≈ 40.0, got %.2f", ty)
-	}
-}
-
-// createFrameWithPattern creates a synthetic frame with a checkerboard pattern
-// offset by (offsetX, offsetY) pixels. This matches Python's create_frame_with_pattern.
-func createFrameWithPattern(offsetX, offsetY, height, width int) gocv.Mat {
-	frame := gocv.NewMatWithSize(height, width, gocv.MatTypeCV8UC3)
-
-	// Create a rich pattern with grid lines for better feature tracking in both X and Y
-	blockSize := 20
-	for i := 0; i < height; i++ {
-		for j := 0; j < width; j++ {
-			// Calculate position in shifted coordinate system
-			// The offset represents how much the pattern has moved
-			srcI := i + offsetY
-			srcJ := j + offsetX
-
-			// Create a grid pattern with clear lines every blockSize pixels
-			var value uint8 = 128 // Gray background
-
-			// Add vertical lines
-			if srcJ%blockSize < 3 {
-				value = 255
-			}
-			// Add horizontal lines
-			if srcI%blockSize < 3 {
-				value = 0
-			}
-			// Grid intersections are white
-			if srcJ%blockSize < 3 && srcI%blockSize < 3 {
-				value = 255
-			}
-
-			// Set all channels to same value (grayscale)
-			frame.SetUCharAt(i, j*3, value)   // B
-			frame.SetUCharAt(i, j*3+1, value) // G
-			frame.SetUCharAt(i, j*3+2, value) // R
-		}
-	}
-
-	return frame
-}
-
-// almostEqual checks if two float64 values are approximately equal within tolerance
-func almostEqual(a, b, tol float64) bool {
-	return math.Abs(a-b) <= tol
-}
@@ -0,0 +1,219 @@
+package norfairgo
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Coordinate Spaces - Units and Frame Size Metadata
+// =============================================================================
+
+// CoordinateSpace identifies the space points are expressed in.
+//
+// Distances, thresholds and analytics silently give wrong results when points of
+// different spaces are mixed, e.g. normalized detector output with pixel zones, or
+// ground plane positions (see GroundPlaneProjector) with pixel detections.
+type CoordinateSpace int
+
+const (
+	// SpaceUnspecified is the zero value: the space is unknown and compatible with
+	// every other space.
+	SpaceUnspecified CoordinateSpace = iota
+
+	// SpacePixelAbsolute is pixels of the frame, with the origin at its top-left corner.
+	SpacePixelAbsolute
+
+	// SpacePixelRelative is fractions of the frame size, with (0, 0) the top-left
+	// and (1, 1) the bottom-right corner of the frame.
+	SpacePixelRelative
+
+	// SpaceWorldMeters is meters in the world, e.g. on a bird's-eye view ground plane.
+	SpaceWorldMeters
+)
+
+// String returns the name of the space.
+func (s CoordinateSpace) String() string {
+	switch s {
+	case SpaceUnspecified:
+		return "unspecified"
+	case SpacePixelAbsolute:
+		return "pixel_absolute"
+	case SpacePixelRelative:
+		return "pixel_relative"
+	case SpaceWorldMeters:
+		return "world_meters"
+	default:
+		return fmt.Sprintf("CoordinateSpace(%d)", int(s))
+	}
+}
+
+// CoordinateSystem is optional metadata describing the points of a detection or
+// tracked object. The zero value is unspecified and compatible with everything.
+type CoordinateSystem struct {
+	Space       CoordinateSpace // Space of the points
+	FrameWidth  int             // Width of the source frame in pixels (0 = unknown)
+	FrameHeight int             // Height of the source frame in pixels (0 = unknown)
+}
+
+// HasFrameSize returns whether the frame size is known.
+func (c CoordinateSystem) HasFrameSize() bool {
+	return c.FrameWidth > 0 && c.FrameHeight > 0
+}
+
+// Validate checks that the space is known and the frame size is either unknown or
+// positive in both dimensions.
+func (c CoordinateSystem) Validate() error {
+	if c.Space < SpaceUnspecified || c.Space > SpaceWorldMeters {
+		return fmt.Errorf("invalid coordinate space %v", c.Space)
+	}
+	if c.FrameWidth < 0 || c.FrameHeight < 0 || (c.FrameWidth == 0) != (c.FrameHeight == 0) {
+		return fmt.Errorf("invalid frame size %dx%d, expected both positive or both 0", c.FrameWidth, c.FrameHeight)
+	}
+	return nil
+}
+
+// Compatible returns an error if points of the two systems cannot be compared:
+// their spaces are both specified and differ, or their frame sizes are both known
+// and differ.
+func (c CoordinateSystem) Compatible(other CoordinateSystem) error {
+	if c.Space != SpaceUnspecified && other.Space != SpaceUnspecified && c.Space != other.Space {
+		return fmt.Errorf("cannot mix %v and %v coordinates", c.Space, other.Space)
+	}
+	if c.HasFrameSize() && other.HasFrameSize() && (c.FrameWidth != other.FrameWidth || c.FrameHeight != other.FrameHeight) {
+		return fmt.Errorf("cannot mix coordinates of %dx%d and %dx%d frames", c.FrameWidth, c.FrameHeight, other.FrameWidth, other.FrameHeight)
+	}
+	return nil
+}
+
+// merge returns c with its unspecified space and unknown frame size filled in
+// from other.
+func (c CoordinateSystem) merge(other CoordinateSystem) CoordinateSystem {
+	if c.Space == SpaceUnspecified {
+		c.Space = other.Space
+	}
+	if !c.HasFrameSize() {
+		c.FrameWidth, c.FrameHeight = other.FrameWidth, other.FrameHeight
+	}
+	return c
+}
+
+// CheckCoordinateSystems returns an error if any two of the systems are not
+// Compatible, e.g. to guard analytics fed from several sources.
+func CheckCoordinateSystems(systems ...CoordinateSystem) error {
+	var combined CoordinateSystem
+	for _, system := range systems {
+		if err := combined.Compatible(system); err != nil {
+			return err
+		}
+		combined = combined.merge(system)
+	}
+	return nil
+}
+
+// ConvertPoints converts (n_points, n_dims) points from the system to the given
+// space. Only the x and y coordinates are scaled. Converting between
+// SpacePixelAbsolute and SpacePixelRelative needs the frame size; converting to
+// or from SpaceWorldMeters needs a projection (see GroundPlaneProjector) and is
+// not supported.
+//
+// Returns: A converted copy of the points, or an error if the conversion is not possible
+func (c CoordinateSystem) ConvertPoints(points *mat.Dense, to CoordinateSpace) (*mat.Dense, error) {
+	converted := mat.DenseCopyOf(points)
+	if c.Space == to {
+		return converted, nil
+	}
+
+	var scaleX, scaleY float64
+	switch {
+	case c.Space == SpacePixelAbsolute && to == SpacePixelRelative && c.HasFrameSize():
+		scaleX, scaleY = 1/float64(c.FrameWidth), 1/float64(c.FrameHeight)
+	case c.Space == SpacePixelRelative && to == SpacePixelAbsolute && c.HasFrameSize():
+		scaleX, scaleY = float64(c.FrameWidth), float64(c.FrameHeight)
+	case c.Space == SpaceUnspecified || to == SpaceUnspecified:
+		return nil, fmt.Errorf("cannot convert between %v and %v coordinates", c.Space, to)
+	case !c.HasFrameSize() && c.Space != SpaceWorldMeters && to != SpaceWorldMeters:
+		return nil, fmt.Errorf("converting %v to %v coordinates needs the frame size", c.Space, to)
+	default:
+		return nil, fmt.Errorf("converting %v to %v coordinates needs a projection", c.Space, to)
+	}
+
+	rows, _ := converted.Dims()
+	for i := 0; i < rows; i++ {
+		converted.Set(i, 0, converted.At(i, 0)*scaleX)
+		converted.Set(i, 1, converted.At(i, 1)*scaleY)
+	}
+	return converted, nil
+}
+
+// ConvertCoordinates converts the Points and AbsolutePoints of the detection to
+// the given space in place (see CoordinateSystem.ConvertPoints) and updates
+// Coordinates.Space.
+//
+// Returns: Error if the conversion is not possible (the detection is unchanged)
+func (d *Detection) ConvertCoordinates(to CoordinateSpace) error {
+	points, err := d.Coordinates.ConvertPoints(d.Points, to)
+	if err != nil {
+		return err
+	}
+	var absolutePoints *mat.Dense
+	if d.AbsolutePoints != nil {
+		if absolutePoints, err = d.Coordinates.ConvertPoints(d.AbsolutePoints, to); err != nil {
+			return err
+		}
+	}
+	d.Points, d.AbsolutePoints = points, absolutePoints
+	d.Coordinates.Space = to
+	return nil
+}
+
+// CoordinateSystemLike is implemented by detections and tracks that carry
+// coordinate system metadata (*Detection and *TrackedObject). It is optional for
+// TrackedObjectLike implementations.
+type CoordinateSystemLike interface {
+	GetCoordinates() CoordinateSystem
+}
+
+// GetCoordinates returns the coordinate system of the detection points.
+func (d *Detection) GetCoordinates() CoordinateSystem {
+	return d.Coordinates
+}
+
+// GetCoordinates returns the coordinate system of the object estimate.
+func (to *TrackedObject) GetCoordinates() CoordinateSystem {
+	return to.Coordinates
+}
+
+// CheckObjectCoordinates returns an error if the objects mix incompatible
+// coordinate systems (see CheckCoordinateSystems). Objects not implementing
+// CoordinateSystemLike are unspecified.
+func CheckObjectCoordinates(objects []TrackedObjectLike) error {
+	systems := make([]CoordinateSystem, 0, len(objects))
+	for _, obj := range objects {
+		if like, ok := obj.(CoordinateSystemLike); ok {
+			systems = append(systems, like.GetCoordinates())
+		}
+	}
+	return CheckCoordinateSystems(systems...)
+}
+
+// Coordinates returns the coordinate system the tracker enforces: the space
+// (TrackerConfig.CoordinateSpace) and frame size (TrackerConfig.FrameWidth and
+// FrameHeight) of its configuration, with the unspecified parts filled in from
+// the first detections that specify them. Tracker.Update skips detections that
+// are not compatible with it.
+func (t *Tracker) Coordinates() CoordinateSystem {
+	return t.coordinates
+}
+
+// checkCoordinates returns an error if a detection is not compatible with the
+// coordinate system of the tracker, and otherwise adopts the parts of the
+// detection's system that the tracker does not know yet.
+func (t *Tracker) checkCoordinates(det *Detection) error {
+	if err := t.coordinates.Compatible(det.Coordinates); err != nil {
+		return fmt.Errorf("%w (tracking %v coordinates)", err, t.coordinates.Space)
+	}
+	t.coordinates = t.coordinates.merge(det.Coordinates)
+	return nil
+}
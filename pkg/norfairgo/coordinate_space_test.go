@@ -0,0 +1,125 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCoordinateSystem_Compatible(t *testing.T) {
+	pixels := CoordinateSystem{Space: SpacePixelAbsolute, FrameWidth: 1920, FrameHeight: 1080}
+
+	compatible := []CoordinateSystem{
+		{},
+		{Space: SpacePixelAbsolute},
+		{FrameWidth: 1920, FrameHeight: 1080},
+		pixels,
+	}
+	for _, other := range compatible {
+		if err := pixels.Compatible(other); err != nil {
+			t.Errorf("Expected %+v to be compatible, got %v", other, err)
+		}
+	}
+
+	incompatible := []CoordinateSystem{
+		{Space: SpacePixelRelative},
+		{Space: SpaceWorldMeters},
+		{Space: SpacePixelAbsolute, FrameWidth: 1280, FrameHeight: 720},
+	}
+	for _, other := range incompatible {
+		if err := pixels.Compatible(other); err == nil {
+			t.Errorf("Expected %+v to be incompatible", other)
+		}
+	}
+
+	// Unspecified parts are filled in from later systems
+	if err := CheckCoordinateSystems(CoordinateSystem{}, CoordinateSystem{Space: SpaceWorldMeters}, CoordinateSystem{Space: SpacePixelAbsolute}); err == nil {
+		t.Error("Expected world and pixel systems to be incompatible")
+	}
+	if err := CheckCoordinateSystems(CoordinateSystem{FrameWidth: 640, FrameHeight: 480}, CoordinateSystem{Space: SpacePixelAbsolute}, pixels); err == nil {
+		t.Error("Expected different frame sizes to be incompatible")
+	}
+
+	if err := (CoordinateSystem{FrameWidth: 640}).Validate(); err == nil {
+		t.Error("Expected error for a frame size with a single dimension")
+	}
+	if SpaceWorldMeters.String() != "world_meters" {
+		t.Errorf("Unexpected name %q", SpaceWorldMeters.String())
+	}
+}
+
+func TestDetection_ConvertCoordinates(t *testing.T) {
+	det, err := NewDetection(mat.NewDense(2, 2, []float64{0.25, 0.5, 0.75, 1}), &DetectionConfig{
+		Coordinates: CoordinateSystem{Space: SpacePixelRelative, FrameWidth: 200, FrameHeight: 100},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create detection: %v", err)
+	}
+
+	if err := det.ConvertCoordinates(SpacePixelAbsolute); err != nil {
+		t.Fatalf("ConvertCoordinates failed: %v", err)
+	}
+	expected := mat.NewDense(2, 2, []float64{50, 50, 150, 100})
+	if !mat.Equal(det.Points, expected) || !mat.Equal(det.AbsolutePoints, expected) {
+		t.Errorf("Expected points %v, got %v and %v", mat.Formatted(expected), mat.Formatted(det.Points), mat.Formatted(det.AbsolutePoints))
+	}
+	if det.Coordinates.Space != SpacePixelAbsolute {
+		t.Errorf("Expected the space to be updated, got %v", det.Coordinates.Space)
+	}
+
+	if err := det.ConvertCoordinates(SpaceWorldMeters); err == nil {
+		t.Error("Expected error converting pixels to meters without a projection")
+	}
+	det.Coordinates = CoordinateSystem{Space: SpacePixelAbsolute}
+	if err := det.ConvertCoordinates(SpacePixelRelative); err == nil {
+		t.Error("Expected error converting without a frame size")
+	}
+	if !mat.Equal(det.Points, expected) {
+		t.Error("Expected a failed conversion to leave the detection unchanged")
+	}
+}
+
+func TestTracker_CoordinateSpace(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:  DistanceByName("euclidean"),
+		DistanceThreshold: 100,
+		FrameWidth:        1920,
+		FrameHeight:       1080,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	pixels := newPointDetection(t, 100, 100)
+	pixels.Coordinates.Space = SpacePixelAbsolute
+	normalized := newPointDetection(t, 0.05, 0.1)
+	normalized.Coordinates.Space = SpacePixelRelative
+	otherCamera := newPointDetection(t, 500, 500)
+	otherCamera.Coordinates = CoordinateSystem{Space: SpacePixelAbsolute, FrameWidth: 1280, FrameHeight: 720}
+	untagged := newPointDetection(t, 900, 900)
+
+	// The first specified space is enforced, untagged detections are accepted
+	tracker.Update([]*Detection{pixels, normalized, otherCamera, untagged}, 1, nil)
+	if len(tracker.TrackedObjects) != 2 {
+		t.Errorf("Expected the pixel and untagged detections to be tracked, got %d objects", len(tracker.TrackedObjects))
+	}
+	expected := CoordinateSystem{Space: SpacePixelAbsolute, FrameWidth: 1920, FrameHeight: 1080}
+	if tracker.Coordinates() != expected {
+		t.Errorf("Expected tracker coordinates %+v, got %+v", expected, tracker.Coordinates())
+	}
+	if space := tracker.TrackedObjects[0].Coordinates.Space; space != SpacePixelAbsolute {
+		t.Errorf("Expected the object to carry its detection's space, got %v", space)
+	}
+
+	if err := CheckObjectCoordinates(AsTrackedObjectLikes(tracker.TrackedObjects)); err != nil {
+		t.Errorf("Expected the tracked objects to be consistent, got %v", err)
+	}
+	mixed := append(AsTrackedObjectLikes(tracker.TrackedObjects), &TrackedObject{Coordinates: CoordinateSystem{Space: SpaceWorldMeters}})
+	if err := CheckObjectCoordinates(mixed); err == nil {
+		t.Error("Expected error for objects mixing pixel and world coordinates")
+	}
+
+	if _, err := NewTracker(&TrackerConfig{DistanceFunction: DistanceByName("euclidean"), CoordinateSpace: CoordinateSpace(9)}); err == nil {
+		t.Error("Expected error for an invalid coordinate space")
+	}
+}
@@ -0,0 +1,127 @@
+//go:build !nogocv
+
+package norfairgo
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"gocv.io/x/gocv"
+)
+
+// =============================================================================
+// Crops - Per-Object Image Patches
+// =============================================================================
+
+// CropConfig configures ExtractCrops.
+type CropConfig struct {
+	// Padding added on each side of the object's box, as a fraction of its width
+	// and height (e.g. 0.1 adds 10% of the width to the left and to the right).
+	// Default: 0.0
+	Padding float64
+
+	// Expand the (padded) box to a square around its center before cropping, so
+	// resizing to a square Size keeps the aspect ratio.
+	// Default: false
+	Square bool
+
+	// Resize every crop to this size (width x height).
+	// Default: 0x0 (keep the crop size)
+	Size image.Point
+}
+
+// Crop is the image patch of one tracked object.
+type Crop struct {
+	ID   int             // Tracked object ID
+	Mat  gocv.Mat        // Patch (independent of the frame; caller must Close() it)
+	Rect image.Rectangle // Region of the frame the patch was taken from
+}
+
+// ExtractCrops extracts the image patch of every object from a frame, the
+// standard input of downstream classification and ReID models.
+//
+// Each patch covers the bounding box of the object's relative estimate, padded,
+// optionally squared, clipped to the frame and optionally resized. Objects
+// without an ID or whose box lies outside the frame are skipped.
+//
+// Parameters:
+//   - frame: Frame the objects were tracked on
+//   - objects: Tracked objects (see AsTrackedObjectLikes)
+//   - config: Crop options (nil = defaults)
+//
+// Returns: One crop per object, in input order
+func ExtractCrops(frame gocv.Mat, objects []TrackedObjectLike, config *CropConfig) []Crop {
+	if config == nil {
+		config = &CropConfig{}
+	}
+	bounds := image.Rect(0, 0, frame.Cols(), frame.Rows())
+
+	crops := make([]Crop, 0, len(objects))
+	for _, obj := range objects {
+		id := obj.GetID()
+		if id == nil {
+			continue
+		}
+		estimate, err := obj.GetEstimate(false)
+		if err != nil {
+			continue
+		}
+
+		rect := cropRect(boundingBox(estimate), config).Intersect(bounds)
+		if rect.Empty() {
+			continue
+		}
+
+		region := frame.Region(rect)
+		var patch gocv.Mat
+		if config.Size.X > 0 && config.Size.Y > 0 {
+			patch = gocv.NewMat()
+			gocv.Resize(region, &patch, config.Size, 0, 0, gocv.InterpolationLinear)
+		} else {
+			patch = region.Clone()
+		}
+		region.Close()
+
+		crops = append(crops, Crop{ID: *id, Mat: patch, Rect: rect})
+	}
+	return crops
+}
+
+// ExtractCropImages is ExtractCrops returning Go images keyed by object ID.
+//
+// Returns: Error if a patch cannot be converted to an image
+func ExtractCropImages(frame gocv.Mat, objects []TrackedObjectLike, config *CropConfig) (map[int]image.Image, error) {
+	crops := ExtractCrops(frame, objects, config)
+	defer func() {
+		for _, crop := range crops {
+			crop.Mat.Close()
+		}
+	}()
+
+	images := make(map[int]image.Image, len(crops))
+	for _, crop := range crops {
+		img, err := crop.Mat.ToImage()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert crop of object %d: %w", crop.ID, err)
+		}
+		images[crop.ID] = img
+	}
+	return images, nil
+}
+
+// cropRect pads (and optionally squares) a box and rounds it to pixels.
+func cropRect(box [4]float64, config *CropConfig) image.Rectangle {
+	padX := (box[2] - box[0]) * config.Padding
+	padY := (box[3] - box[1]) * config.Padding
+	x0, y0, x1, y1 := box[0]-padX, box[1]-padY, box[2]+padX, box[3]+padY
+
+	if config.Square {
+		side := math.Max(x1-x0, y1-y0)
+		cx, cy := (x0+x1)/2, (y0+y1)/2
+		x0, x1 = cx-side/2, cx+side/2
+		y0, y1 = cy-side/2, cy+side/2
+	}
+
+	return image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Ceil(x1)), int(math.Ceil(y1)))
+}
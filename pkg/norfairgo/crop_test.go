@@ -0,0 +1,81 @@
+//go:build !nogocv
+
+package norfairgo
+
+import (
+	"image"
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCropRect(t *testing.T) {
+	box := [4]float64{10, 20, 30, 60}
+
+	tests := []struct {
+		name     string
+		config   CropConfig
+		expected image.Rectangle
+	}{
+		{"plain", CropConfig{}, image.Rect(10, 20, 30, 60)},
+		{"padding", CropConfig{Padding: 0.1}, image.Rect(8, 16, 32, 64)},
+		{"square", CropConfig{Square: true}, image.Rect(0, 20, 40, 60)},
+	}
+	for _, tc := range tests {
+		if got := cropRect(box, &tc.config); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestExtractCrops(t *testing.T) {
+	frame := gocv.NewMatWithSize(100, 200, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	objects := []TrackedObjectLike{
+		newCropTrack(1, 10, 20, 30, 60),
+		newCropTrack(2, 180, 90, 250, 150),                                // Clipped to the frame
+		newCropTrack(3, 300, 300, 350, 350),                               // Outside the frame
+		&cropTrack{estimate: mat.NewDense(2, 2, []float64{0, 0, 10, 10})}, // No ID
+	}
+
+	crops := ExtractCrops(frame, objects, nil)
+	defer func() {
+		for _, crop := range crops {
+			crop.Mat.Close()
+		}
+	}()
+
+	if len(crops) != 2 {
+		t.Fatalf("Expected 2 crops, got %d", len(crops))
+	}
+	if crops[0].ID != 1 || crops[0].Rect != image.Rect(10, 20, 30, 60) {
+		t.Errorf("Unexpected first crop: id %d, rect %v", crops[0].ID, crops[0].Rect)
+	}
+	if crops[0].Mat.Cols() != 20 || crops[0].Mat.Rows() != 40 {
+		t.Errorf("Expected a 20x40 patch, got %dx%d", crops[0].Mat.Cols(), crops[0].Mat.Rows())
+	}
+	if crops[1].ID != 2 || crops[1].Rect != image.Rect(180, 90, 200, 100) {
+		t.Errorf("Unexpected clipped crop: id %d, rect %v", crops[1].ID, crops[1].Rect)
+	}
+}
+
+func TestExtractCropImages_Resize(t *testing.T) {
+	frame := gocv.NewMatWithSize(100, 200, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	images, err := ExtractCropImages(frame, []TrackedObjectLike{newCropTrack(7, 10, 20, 30, 60)},
+		&CropConfig{Size: image.Pt(64, 128)})
+	if err != nil {
+		t.Fatalf("ExtractCropImages failed: %v", err)
+	}
+
+	img, ok := images[7]
+	if !ok {
+		t.Fatal("Expected an image for object 7")
+	}
+	if size := img.Bounds().Size(); size != image.Pt(64, 128) {
+		t.Errorf("Expected a 64x128 image, got %v", size)
+	}
+}
@@ -0,0 +1,140 @@
+package norfairgo
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"sync"
+)
+
+// =============================================================================
+// Debug Snapshots - Inspect a Live Tracker as JSON
+// =============================================================================
+
+// TrackSnapshot is the JSON view of one tracked object at snapshot time.
+type TrackSnapshot struct {
+	ID             *int        `json:"id"`
+	GlobalID       *int        `json:"global_id"`
+	InitializingID *int        `json:"initializing_id"`
+	Label          *string     `json:"label,omitempty"`
+	Age            int64       `json:"age"`
+	HitCounter     int         `json:"hit_counter"`
+	FramesSinceHit int64       `json:"frames_since_hit"`
+	IsInitializing bool        `json:"is_initializing"`
+	Frozen         bool        `json:"frozen,omitempty"`
+	LastDistance   *float64    `json:"last_distance"`
+	Quality        float64     `json:"quality"`  // See TrackedObject.Quality
+	Estimate       [][]float64 `json:"estimate"` // Relative (image) coordinates, one row per point
+}
+
+// TrackerSnapshot is the JSON view of a tracker at snapshot time.
+type TrackerSnapshot struct {
+	FrameNumber int64           `json:"frame_number"` // Frames processed so far
+	Tracks      []TrackSnapshot `json:"tracks"`       // All tracked objects, including initializing ones
+}
+
+// Snapshot returns a copy of the tracker's current state that is safe to keep and
+// serialize. Like Update, it must not be called concurrently with other tracker
+// methods; use DebugPublisher to expose snapshots to other goroutines.
+func (t *Tracker) Snapshot() TrackerSnapshot {
+	snapshot := TrackerSnapshot{
+		FrameNumber: t.frameNumber,
+		Tracks:      make([]TrackSnapshot, 0, len(t.TrackedObjects)),
+	}
+	for _, obj := range t.TrackedObjects {
+		track := TrackSnapshot{
+			ID:             copyIntPtr(obj.ID),
+			GlobalID:       copyIntPtr(obj.GlobalID),
+			InitializingID: copyIntPtr(obj.InitializingID),
+			Age:            obj.Age,
+			HitCounter:     obj.HitCounter,
+			FramesSinceHit: obj.FramesSinceHit,
+			IsInitializing: obj.IsInitializing,
+			Frozen:         obj.Frozen,
+			Quality:        obj.Quality(),
+		}
+		if obj.Label != nil {
+			label := *obj.Label
+			track.Label = &label
+		}
+		if obj.LastDistance != nil {
+			distance := *obj.LastDistance
+			track.LastDistance = &distance
+		}
+		if estimate, err := obj.GetEstimate(false); err == nil {
+			rows, _ := estimate.Dims()
+			track.Estimate = make([][]float64, rows)
+			for i := range track.Estimate {
+				track.Estimate[i] = append([]float64(nil), estimate.RawRowView(i)...)
+			}
+		}
+		snapshot.Tracks = append(snapshot.Tracks, track)
+	}
+	return snapshot
+}
+
+// copyIntPtr returns a pointer to a copy of *p, or nil.
+func copyIntPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// DebugPublisher exposes the latest snapshot of a live tracker to other goroutines,
+// as an HTTP JSON endpoint or an expvar variable, so operators can inspect a running
+// process without attaching a debugger.
+//
+// The tracking loop calls Publish after each update; readers always see the last
+// published snapshot.
+//
+// Example:
+//
+//	publisher := NewDebugPublisher()
+//	http.Handle("/debug/tracker", publisher)
+//	go http.ListenAndServe("localhost:6060", nil)
+//	for ... {
+//		tracker.Update(detections, 1, nil)
+//		publisher.Publish(tracker)
+//	}
+type DebugPublisher struct {
+	mu       sync.RWMutex
+	snapshot TrackerSnapshot
+}
+
+// NewDebugPublisher creates a new DebugPublisher with an empty snapshot.
+func NewDebugPublisher() *DebugPublisher {
+	return &DebugPublisher{snapshot: TrackerSnapshot{Tracks: []TrackSnapshot{}}}
+}
+
+// Publish takes a snapshot of the tracker. Call it from the goroutine that updates
+// the tracker.
+func (p *DebugPublisher) Publish(tracker *Tracker) {
+	snapshot := tracker.Snapshot()
+	p.mu.Lock()
+	p.snapshot = snapshot
+	p.mu.Unlock()
+}
+
+// Snapshot returns the last published snapshot.
+func (p *DebugPublisher) Snapshot() TrackerSnapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.snapshot
+}
+
+// ServeHTTP writes the last published snapshot as JSON.
+func (p *DebugPublisher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(p.Snapshot()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// PublishExpvar registers the last published snapshot as an expvar variable, served
+// with the other variables at /debug/vars. Like expvar.Publish, it panics if the
+// name is already registered.
+func (p *DebugPublisher) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} { return p.Snapshot() }))
+}
@@ -0,0 +1,77 @@
+package norfairgo
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracker_Snapshot(t *testing.T) {
+	tracker, id := newManualControlTracker(t)
+
+	snapshot := tracker.Snapshot()
+	if len(snapshot.Tracks) != 1 {
+		t.Fatalf("expected 1 track, got %d", len(snapshot.Tracks))
+	}
+	track := snapshot.Tracks[0]
+	if track.ID == nil || *track.ID != id {
+		t.Errorf("snapshot ID = %v, want %d", track.ID, id)
+	}
+	if len(track.Estimate) != 1 || len(track.Estimate[0]) != 2 {
+		t.Errorf("expected a 1x2 estimate, got %v", track.Estimate)
+	}
+
+	// The snapshot is a copy
+	*track.ID = -1
+	track.Estimate[0][0] = -1
+	if *tracker.TrackedObjects[0].ID != id || tracker.TrackedObjects[0].Estimate.At(0, 0) == -1 {
+		t.Error("modifying the snapshot should not modify the tracker")
+	}
+}
+
+func TestDebugPublisher_ServeHTTP(t *testing.T) {
+	tracker, id := newManualControlTracker(t)
+	publisher := NewDebugPublisher()
+
+	// Before any Publish, tracks is an empty list rather than null
+	recorder := httptest.NewRecorder()
+	publisher.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/tracker", nil))
+	var empty map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &empty); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if tracks, ok := empty["tracks"].([]interface{}); !ok || len(tracks) != 0 {
+		t.Errorf("expected empty tracks list, got %v", empty["tracks"])
+	}
+
+	publisher.Publish(tracker)
+	recorder = httptest.NewRecorder()
+	publisher.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/tracker", nil))
+
+	if ct := recorder.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var snapshot TrackerSnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(snapshot.Tracks) != 1 || *snapshot.Tracks[0].ID != id || snapshot.FrameNumber != tracker.frameNumber {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+}
+
+func TestDebugPublisher_PublishExpvar(t *testing.T) {
+	tracker, _ := newManualControlTracker(t)
+	publisher := NewDebugPublisher()
+	publisher.PublishExpvar("norfairgo_test_tracker")
+	publisher.Publish(tracker)
+
+	var snapshot TrackerSnapshot
+	if err := json.Unmarshal([]byte(expvar.Get("norfairgo_test_tracker").String()), &snapshot); err != nil {
+		t.Fatalf("invalid expvar JSON: %v", err)
+	}
+	if len(snapshot.Tracks) != 1 {
+		t.Errorf("expected 1 track, got %d", len(snapshot.Tracks))
+	}
+}
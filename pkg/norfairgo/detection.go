@@ -20,8 +20,16 @@ type DetectionConfig struct {
 	// Objects with different labels are never matched
 	Label *string
 
+	// ClassID is the integer class ID for multi-class tracking, as emitted by most
+	// detectors. Avoids string conversions in the per-frame hot path.
+	// Objects with different class IDs are never matched
+	ClassID *int
+
 	// Embedding is the ReID embedding for re-identification
 	Embedding []float64
+
+	// Coordinates optionally describe the space and frame size of the points
+	Coordinates CoordinateSystem
 }
 
 // Detection represents a detected object in a frame.
@@ -44,12 +52,29 @@ type Detection struct {
 	// Objects with different labels are never matched
 	Label *string
 
+	// ClassID is the integer class ID for multi-class tracking (can be nil)
+	// Objects with different class IDs are never matched
+	ClassID *int
+
 	// Embedding is the ReID embedding for re-identification (can be nil)
 	Embedding []float64
 
+	// Coordinates optionally describe the space and frame size of the points (zero
+	// value = unspecified). Tracker.Update skips detections whose coordinates are
+	// not compatible with the tracker's (see Tracker.Coordinates)
+	Coordinates CoordinateSystem
+
+	// Ignore excludes the detection from Tracker.Update: it is neither matched nor
+	// starts a new object, but stays in the caller's slice (e.g. to be drawn
+	// differently, see SplitIgnored). Useful to hide detections in certain states
+	// without rebuilding the detection slice every frame.
+	Ignore bool
+
 	// Age is the age of this detection when added to past_detections
 	// Set by TrackedObject when storing past detections
-	Age int
+	Age int64
+
+	undistorted bool // Points already corrected by an Undistorter
 }
 
 // StringPtr returns a pointer to a string. Helper for DetectionConfig.Label.
@@ -63,6 +88,17 @@ func StringPtr(s string) *string {
 	return &s
 }
 
+// IntPtr returns a pointer to an int. Helper for DetectionConfig.ClassID.
+//
+// Example:
+//
+//	det, err := NewDetection(points, &DetectionConfig{
+//	    ClassID: IntPtr(0),
+//	})
+func IntPtr(i int) *int {
+	return &i
+}
+
 // NewDetection creates a new Detection from points and optional configuration.
 //
 // Example - Simple (no config):
@@ -104,13 +140,17 @@ func NewDetection(points *mat.Dense, config *DetectionConfig) (*Detection, error
 	var scores []float64
 	var data interface{}
 	var label *string
+	var classID *int
 	var embedding []float64
+	var coordinates CoordinateSystem
 
 	if config != nil {
 		scores = config.Scores
 		data = config.Data
 		label = config.Label
+		classID = config.ClassID
 		embedding = config.Embedding
+		coordinates = config.Coordinates
 	}
 
 	return &Detection{
@@ -119,11 +159,164 @@ func NewDetection(points *mat.Dense, config *DetectionConfig) (*Detection, error
 		Scores:         scores,
 		Data:           data,
 		Label:          label,
+		ClassID:        classID,
 		Embedding:      embedding,
+		Coordinates:    coordinates,
 		Age:            0,
 	}, nil
 }
 
+// Reset reinitializes the detection in place for reuse with new points and scores,
+// reusing the storage of the previous points when it is large enough (no allocation
+// for same-sized detections). Data, Label, ClassID, Embedding, Coordinates, Ignore and
+// Age are cleared.
+//
+// The points and scores are copied, so the caller may reuse its own buffers. The
+// detection must not be reset while a tracker still references it (see DetectionPool).
+//
+// Returns error if points have invalid shape.
+func (d *Detection) Reset(points *mat.Dense, scores []float64) error {
+	if _, err := ValidatePoints(points); err != nil {
+		return fmt.Errorf("invalid detection points: %w", err)
+	}
+
+	d.Points = resetDense(d.Points, points)
+	d.AbsolutePoints = resetDense(d.AbsolutePoints, points)
+	if scores == nil {
+		d.Scores = nil
+	} else {
+		d.Scores = append(d.Scores[:0], scores...)
+	}
+	d.Data = nil
+	d.Label = nil
+	d.ClassID = nil
+	d.Embedding = nil
+	d.Coordinates = CoordinateSystem{}
+	d.Ignore = false
+	d.Age = 0
+	d.undistorted = false
+	return nil
+}
+
+// SplitIgnored splits detections into those Tracker.Update tracks and those it
+// skips because Detection.Ignore is set, keeping their order, e.g. to draw the
+// ignored detections in a different color.
+func SplitIgnored(detections []*Detection) (active, ignored []*Detection) {
+	for _, det := range detections {
+		if det != nil && det.Ignore {
+			ignored = append(ignored, det)
+		} else {
+			active = append(active, det)
+		}
+	}
+	return active, ignored
+}
+
+// resetDense copies src into dst, reusing dst's backing storage when possible.
+func resetDense(dst, src *mat.Dense) *mat.Dense {
+	if dst == nil {
+		return mat.DenseCopyOf(src)
+	}
+	rows, cols := src.Dims()
+	dst.Reset()
+	dst.ReuseAs(rows, cols)
+	dst.Copy(src)
+	return dst
+}
+
+// Validate checks that the detection can be tracked: it has at least one point of
+// 2 or 3 dimensions, AbsolutePoints (if set) has the same shape as Points,
+// Scores (if set) has one score per point, and Coordinates is valid.
+//
+// Detections created with NewDetection are always valid; this catches detections
+// built as struct literals or modified afterwards. Tracker.Update skips invalid
+// detections with a warning.
+func (d *Detection) Validate() error {
+	if d == nil {
+		return fmt.Errorf("detection is nil")
+	}
+	if d.Points == nil {
+		return fmt.Errorf("detection has no points")
+	}
+	rows, cols := d.Points.Dims()
+	if rows == 0 || (cols != 2 && cols != 3) {
+		return fmt.Errorf("invalid points shape: expected (n_points >= 1, 2 or 3), got (%d, %d)", rows, cols)
+	}
+	if d.AbsolutePoints != nil {
+		if absRows, absCols := d.AbsolutePoints.Dims(); absRows != rows || absCols != cols {
+			return fmt.Errorf("absolute points shape (%d, %d) does not match points shape (%d, %d)", absRows, absCols, rows, cols)
+		}
+	}
+	if d.Scores != nil && len(d.Scores) != rows {
+		return fmt.Errorf("got %d scores for %d points", len(d.Scores), rows)
+	}
+	if err := d.Coordinates.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// =============================================================================
+// Slice Constructors - Detections Without Building *mat.Dense
+// =============================================================================
+
+// Number is the set of numeric types accepted by the slice-based constructors,
+// e.g. the int pixel coordinates or float32 boxes emitted by most detectors.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// NewDetectionFromPoints creates a Detection from 2D points given as [x, y] pairs.
+//
+// Example:
+//
+//	det, err := norfairgo.NewDetectionFromPoints([][2]float64{{10, 20}, {30, 40}}, nil)
+//
+// Returns error if points is empty.
+func NewDetectionFromPoints[T Number](points [][2]T, config *DetectionConfig) (*Detection, error) {
+	values := make([]float64, 0, 2*len(points))
+	for _, point := range points {
+		values = append(values, float64(point[0]), float64(point[1]))
+	}
+	return NewDetectionFromSlice(values, 2, config)
+}
+
+// NewDetectionFromBox creates a 2-point bounding box detection [[x1, y1], [x2, y2]],
+// as expected by the "iou" distance.
+//
+// Example:
+//
+//	det, err := norfairgo.NewDetectionFromBox(box.X1, box.Y1, box.X2, box.Y2, nil)
+func NewDetectionFromBox[T Number](x1, y1, x2, y2 T, config *DetectionConfig) (*Detection, error) {
+	return NewDetectionFromSlice([]T{x1, y1, x2, y2}, 2, config)
+}
+
+// NewDetectionFromSlice creates a Detection from a flat row-major slice of point
+// coordinates, e.g. [x1, y1, x2, y2, ...] with dims=2.
+//
+// Parameters:
+//   - values: Point coordinates, n_points * dims values
+//   - dims: Dimensions per point (2 or 3)
+//   - config: Optional configuration (can be nil)
+//
+// Returns error if values is empty or its length is not a multiple of dims.
+func NewDetectionFromSlice[T Number](values []T, dims int, config *DetectionConfig) (*Detection, error) {
+	if dims != 2 && dims != 3 {
+		return nil, fmt.Errorf("invalid detection points: expected dims to be 2 or 3, got %d", dims)
+	}
+	if len(values) == 0 || len(values)%dims != 0 {
+		return nil, fmt.Errorf("invalid detection points: expected a non-empty multiple of %d values, got %d", dims, len(values))
+	}
+
+	data := make([]float64, len(values))
+	for i, v := range values {
+		data[i] = float64(v)
+	}
+	return NewDetection(mat.NewDense(len(values)/dims, dims, data), config)
+}
+
 // UpdateCoordinateTransformation transforms detection points to absolute coordinates.
 // This is used for camera motion compensation.
 //
@@ -0,0 +1,186 @@
+package norfairgo
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Detection Noise - Detector Degradation for Robustness Testing
+// =============================================================================
+
+// DetectionNoiseConfig configures NoisyDetections.
+//
+// Each detection is dropped with DropRate, and otherwise copied with Gaussian noise
+// on its points. False positives are copies of a random detection of the frame moved
+// to a random position inside the frame.
+type DetectionNoiseConfig struct {
+	// Probability that a detection is dropped (a detector miss).
+	// Default: 0
+	DropRate float64
+
+	// Standard deviation of the Gaussian noise added to every point coordinate.
+	// Default: 0 (exact points)
+	NoiseStd float64
+
+	// Expected number of false positives per frame (the mean of a Poisson
+	// distribution, so it may exceed 1; SimulationConfig.FalsePositiveRate is a
+	// per-frame probability instead). Frames without detections get no false
+	// positives.
+	// Default: 0
+	FalsePositivesPerFrame float64
+
+	// Frame size false positives are placed in.
+	// Default: 1920x1080
+	FrameWidth  float64
+	FrameHeight float64
+
+	// Random seed; the same config and source always produce the same detections.
+	// Default: 0
+	Seed int64
+}
+
+// NoisyDetections wraps a per-frame detection source (e.g. DetectionFileParser.All
+// or Simulation.All) and degrades its detections, to measure how a tracker
+// configuration copes with detector noise.
+//
+// The source detections are never modified: kept detections are copies with the
+// same label, class ID, scores, data and embedding. Copies that are not valid
+// detections, and false positives from templates without x and y coordinates, are
+// skipped with a warning. Every iteration restarts the random sequence from the seed.
+//
+// Returns: Error if a rate or the noise is out of range
+func NoisyDetections(source iter.Seq2[int, []*Detection], config *DetectionNoiseConfig) (iter.Seq2[int, []*Detection], error) {
+	if config == nil {
+		config = &DetectionNoiseConfig{}
+	}
+
+	// Apply defaults
+	c := *config
+	if c.FrameWidth == 0 {
+		c.FrameWidth = 1920
+	}
+	if c.FrameHeight == 0 {
+		c.FrameHeight = 1080
+	}
+
+	// Validate
+	if c.DropRate < 0 || c.DropRate > 1 {
+		return nil, fmt.Errorf("drop rate must be in [0, 1], got %v", c.DropRate)
+	}
+	if c.NoiseStd < 0 || c.FalsePositivesPerFrame < 0 {
+		return nil, fmt.Errorf("noise std and false positives per frame must be >= 0")
+	}
+	if c.FrameWidth < 0 || c.FrameHeight < 0 {
+		return nil, fmt.Errorf("frame size must be positive, got %gx%g", c.FrameWidth, c.FrameHeight)
+	}
+
+	return func(yield func(int, []*Detection) bool) {
+		rng := rand.New(rand.NewSource(c.Seed))
+		for frame, detections := range source {
+			noisy := make([]*Detection, 0, len(detections))
+			for _, det := range detections {
+				if det == nil || rng.Float64() < c.DropRate {
+					continue
+				}
+				copied, err := noisyDetection(det, c.NoiseStd, 0, 0, rng)
+				if err != nil {
+					WarnOnce(fmt.Sprintf("NoisyDetections skipped detections: %s", err))
+					continue
+				}
+				noisy = append(noisy, copied)
+			}
+
+			if len(detections) > 0 {
+				for n := poisson(c.FalsePositivesPerFrame, rng); n > 0; n-- {
+					template := detections[rng.Intn(len(detections))]
+					if template == nil {
+						continue
+					}
+					cx, cy, ok := pointsCentroid(template.Points)
+					if !ok {
+						WarnOnce("NoisyDetections skipped false positives: template points need x and y coordinates")
+						continue
+					}
+					dx := rng.Float64()*c.FrameWidth - cx
+					dy := rng.Float64()*c.FrameHeight - cy
+					falsePositive, err := noisyDetection(template, c.NoiseStd, dx, dy, rng)
+					if err != nil {
+						WarnOnce(fmt.Sprintf("NoisyDetections skipped false positives: %s", err))
+						continue
+					}
+					noisy = append(noisy, falsePositive)
+				}
+			}
+
+			if !yield(frame, noisy) {
+				return
+			}
+		}
+	}, nil
+}
+
+// noisyDetection copies a detection, translating its points by (dx, dy) and adding
+// Gaussian noise to every coordinate.
+//
+// Returns: Error if the copy is not a valid detection (see NewDetection)
+func noisyDetection(det *Detection, noiseStd, dx, dy float64, rng *rand.Rand) (*Detection, error) {
+	rows, cols := det.Points.Dims()
+	points := mat.NewDense(rows, cols, nil)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			v := det.Points.At(i, j) + rng.NormFloat64()*noiseStd
+			switch j {
+			case 0:
+				v += dx
+			case 1:
+				v += dy
+			}
+			points.Set(i, j, v)
+		}
+	}
+	return NewDetection(points, &DetectionConfig{
+		Scores:      det.Scores,
+		Data:        det.Data,
+		Label:       det.Label,
+		ClassID:     det.ClassID,
+		Embedding:   det.Embedding,
+		Coordinates: det.Coordinates,
+	})
+}
+
+// pointsCentroid returns the mean x and y of a point matrix, or false if it has
+// no points or fewer than 2 columns.
+func pointsCentroid(points *mat.Dense) (float64, float64, bool) {
+	if points == nil {
+		return 0, 0, false
+	}
+	rows, cols := points.Dims()
+	if rows == 0 || cols < 2 {
+		return 0, 0, false
+	}
+	var x, y float64
+	for i := 0; i < rows; i++ {
+		x += points.At(i, 0)
+		y += points.At(i, 1)
+	}
+	return x / float64(rows), y / float64(rows), true
+}
+
+// poisson samples a Poisson distributed count with the given mean (Knuth's method,
+// fine for the small rates used here).
+func poisson(mean float64, rng *rand.Rand) int {
+	if mean <= 0 {
+		return 0
+	}
+	limit := math.Exp(-mean)
+	n := 0
+	for p := rng.Float64(); p > limit; p *= rng.Float64() {
+		n++
+	}
+	return n
+}
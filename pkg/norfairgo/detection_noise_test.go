@@ -0,0 +1,146 @@
+package norfairgo
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func collectNoisy(t *testing.T, simulation *Simulation, config *DetectionNoiseConfig) [][]*Detection {
+	t.Helper()
+	source, err := NoisyDetections(simulation.All(), config)
+	if err != nil {
+		t.Fatalf("NoisyDetections failed: %v", err)
+	}
+	var frames [][]*Detection
+	for _, detections := range source {
+		frames = append(frames, detections)
+	}
+	return frames
+}
+
+func TestNoisyDetections_DropAndNoise(t *testing.T) {
+	simulation, err := NewSimulation(&SimulationConfig{NumObjects: 4, NumFrames: 50, Seed: 3})
+	if err != nil {
+		t.Fatalf("NewSimulation failed: %v", err)
+	}
+
+	// No noise: copies with identical points
+	frames := collectNoisy(t, simulation, nil)
+	for f, detections := range frames {
+		if len(detections) != 4 {
+			t.Fatalf("Frame %d: expected 4 detections, got %d", f, len(detections))
+		}
+		for i, det := range detections {
+			source := simulation.Frames[f].Detections[i]
+			if det == source || det.Points.At(1, 1) != source.Points.At(1, 1) {
+				t.Fatalf("Frame %d: expected an identical copy of detection %d", f, i)
+			}
+		}
+	}
+
+	// Dropping half the detections keeps about half
+	config := &DetectionNoiseConfig{DropRate: 0.5, NoiseStd: 2, Seed: 7}
+	frames = collectNoisy(t, simulation, config)
+	kept := 0
+	for _, detections := range frames {
+		kept += len(detections)
+	}
+	if kept < 70 || kept > 130 {
+		t.Errorf("Expected about 100 of 200 detections to be kept, got %d", kept)
+	}
+
+	// The same seed reproduces the same detections
+	again := collectNoisy(t, simulation, config)
+	for f := range frames {
+		if len(frames[f]) != len(again[f]) {
+			t.Fatalf("Frame %d: same seed produced different detections", f)
+		}
+		for i := range frames[f] {
+			if frames[f][i].Points.At(0, 0) != again[f][i].Points.At(0, 0) {
+				t.Fatalf("Frame %d: same seed produced different noise", f)
+			}
+		}
+	}
+}
+
+func TestNoisyDetections_FalsePositives(t *testing.T) {
+	simulation, err := NewSimulation(&SimulationConfig{NumObjects: 1, NumFrames: 200, FrameWidth: 640, FrameHeight: 480, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewSimulation failed: %v", err)
+	}
+	label := "person"
+	for _, frame := range simulation.Frames {
+		frame.Detections[0].Label = &label
+	}
+
+	frames := collectNoisy(t, simulation, &DetectionNoiseConfig{
+		DropRate: 1, FalsePositivesPerFrame: 2, FrameWidth: 640, FrameHeight: 480,
+	})
+	total := 0
+	for _, detections := range frames {
+		for _, det := range detections {
+			total++
+			if det.Label == nil || *det.Label != label {
+				t.Fatalf("Expected false positives to keep the label, got %v", det.Label)
+			}
+			cx, cy, _ := pointsCentroid(det.Points)
+			if cx < 0 || cx > 640 || cy < 0 || cy > 480 {
+				t.Fatalf("Expected false positives centered inside the frame, got (%v, %v)", cx, cy)
+			}
+			if w := det.Points.At(1, 0) - det.Points.At(0, 0); math.Abs(w-50) > 1e-9 {
+				t.Fatalf("Expected false positives to keep the box size, got width %v", w)
+			}
+		}
+	}
+	if mean := float64(total) / 200; mean < 1.6 || mean > 2.4 {
+		t.Errorf("Expected about 2 false positives per frame, got %v", mean)
+	}
+}
+
+func TestNoisyDetections_Validation(t *testing.T) {
+	simulation, _ := NewSimulation(&SimulationConfig{NumFrames: 1})
+	for name, config := range map[string]*DetectionNoiseConfig{
+		"drop":  {DropRate: 1.5},
+		"noise": {NoiseStd: -1},
+		"fp":    {FalsePositivesPerFrame: -1},
+		"frame": {FrameWidth: -1},
+	} {
+		if _, err := NoisyDetections(simulation.All(), config); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestNoisyDetections_SkipsInvalidDetections(t *testing.T) {
+	valid := newPointDetection(t, 10, 10)
+	wide := &Detection{Points: mat.NewDense(1, 4, []float64{1, 2, 3, 4})}
+	narrow := &Detection{Points: mat.NewDense(2, 1, []float64{1, 2})}
+	source := func(yield func(int, []*Detection) bool) {
+		for frame := 1; frame <= 20; frame++ {
+			if !yield(frame, []*Detection{valid, wide, narrow}) {
+				return
+			}
+		}
+	}
+
+	noisy, err := NoisyDetections(source, &DetectionNoiseConfig{FalsePositivesPerFrame: 3, Seed: 1})
+	if err != nil {
+		t.Fatalf("NoisyDetections failed: %v", err)
+	}
+	for frame, detections := range noisy {
+		for _, det := range detections {
+			if det == nil {
+				t.Fatalf("Frame %d: expected invalid copies to be skipped, got a nil detection", frame)
+			}
+			if _, cols := det.Points.Dims(); cols != 2 {
+				t.Fatalf("Frame %d: expected only copies of the valid detection, got %d columns", frame, cols)
+			}
+		}
+	}
+
+	if _, _, ok := pointsCentroid(narrow.Points); ok {
+		t.Error("Expected no centroid for points without a y coordinate")
+	}
+}
@@ -0,0 +1,115 @@
+package norfairgo
+
+import (
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// DetectionPool - Reusing Detections Across Frames
+// =============================================================================
+
+// DetectionPool recycles Detection objects so high-FPS pipelines don't allocate
+// every detection of every frame.
+//
+// Ownership: a detection passed to Tracker.Update may be kept by the tracker, as the
+// LastDetection of a new object, in PastDetections (see TrackerConfig.PastDetectionsLength)
+// or in Hypotheses. Such a detection must not be reset or reused. Release returns only
+// the detections the tracker no longer references (the others are left to the garbage
+// collector, or can be passed to Release again later):
+//
+//	pool := norfairgo.NewDetectionPool()
+//	for frame := range frames {
+//	    detections = detections[:0]
+//	    for _, box := range detect(frame) {
+//	        det, _ := pool.Get(box, nil)
+//	        detections = append(detections, det)
+//	    }
+//	    tracker.Update(detections, 1, nil)
+//	    pool.Release(tracker, detections)
+//	}
+//
+// A DetectionPool is safe for concurrent use.
+type DetectionPool struct {
+	pool sync.Pool
+
+	mu         sync.Mutex
+	referenced map[*Detection]struct{} // Scratch set reused by Release
+}
+
+// NewDetectionPool creates an empty detection pool.
+func NewDetectionPool() *DetectionPool {
+	return &DetectionPool{
+		pool: sync.Pool{
+			New: func() interface{} { return &Detection{} },
+		},
+		referenced: map[*Detection]struct{}{},
+	}
+}
+
+// Get returns a detection with the given points and scores (copied, see Detection.Reset),
+// reusing a released detection when one is available.
+//
+// Returns error if points have invalid shape.
+func (p *DetectionPool) Get(points *mat.Dense, scores []float64) (*Detection, error) {
+	det := p.pool.Get().(*Detection)
+	if err := det.Reset(points, scores); err != nil {
+		p.pool.Put(det)
+		return nil, err
+	}
+	return det, nil
+}
+
+// Put returns detections to the pool unconditionally. The caller guarantees that
+// nothing references them anymore; prefer Release after Tracker.Update.
+func (p *DetectionPool) Put(detections ...*Detection) {
+	for _, det := range detections {
+		if det != nil {
+			p.pool.Put(det)
+		}
+	}
+}
+
+// Release returns the detections that the tracker does not reference to the pool,
+// including those of the lost objects kept for re-linking (see
+// TrackerConfig.RelinkDistanceThreshold).
+//
+// Returns: Number of detections released
+func (p *DetectionPool) Release(tracker *Tracker, detections []*Detection) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	defer clear(p.referenced) // Don't keep detections alive until the next call
+	for _, obj := range tracker.TrackedObjects {
+		p.reference(obj)
+	}
+	for _, lost := range tracker.lostObjects {
+		p.reference(lost.obj)
+	}
+
+	released := 0
+	for _, det := range detections {
+		if det == nil {
+			continue
+		}
+		if _, ok := p.referenced[det]; ok {
+			continue
+		}
+		p.pool.Put(det)
+		released++
+	}
+	return released
+}
+
+// reference marks the detections an object holds on to as referenced.
+func (p *DetectionPool) reference(obj *TrackedObject) {
+	if obj.LastDetection != nil {
+		p.referenced[obj.LastDetection] = struct{}{}
+	}
+	for _, det := range obj.PastDetections {
+		p.referenced[det] = struct{}{}
+	}
+	for _, det := range obj.Hypotheses {
+		p.referenced[det] = struct{}{}
+	}
+}
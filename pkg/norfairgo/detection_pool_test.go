@@ -0,0 +1,117 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDetectionPool_GetPut(t *testing.T) {
+	pool := NewDetectionPool()
+
+	det, err := pool.Get(mat.NewDense(1, 2, []float64{1, 2}), nil)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if det.Points.At(0, 1) != 2 || det.Scores != nil {
+		t.Error("Expected the detection to hold the given points")
+	}
+
+	if _, err := pool.Get(mat.NewDense(1, 4, nil), nil); err == nil {
+		t.Error("Expected error for invalid points shape")
+	}
+
+	pool.Put(det, nil)
+}
+
+func TestDetectionPool_ReleaseSkipsReferenced(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:     DistanceByName("euclidean"),
+		DistanceThreshold:    20.0,
+		HitCounterMax:        5,
+		InitializationDelay:  0,
+		PastDetectionsLength: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	pool := NewDetectionPool()
+
+	// Frame 1: the detection starts an object and is kept as its LastDetection
+	first, _ := pool.Get(mat.NewDense(1, 2, []float64{0, 0}), nil)
+	frame1 := []*Detection{first}
+	tracker.Update(frame1, 1, nil)
+	if released := pool.Release(tracker, frame1); released != 0 {
+		t.Errorf("Expected the retained detection not to be released, released %d", released)
+	}
+
+	// Frame 2: the matched detection is kept in PastDetections
+	second, _ := pool.Get(mat.NewDense(1, 2, []float64{1, 0}), nil)
+	frame2 := []*Detection{second}
+	tracker.Update(frame2, 1, nil)
+	if released := pool.Release(tracker, frame2); released != 0 {
+		t.Errorf("Expected the past detection not to be released, released %d", released)
+	}
+
+	// Frame 3: the new detection replaces it, so it can be released now
+	third, _ := pool.Get(mat.NewDense(1, 2, []float64{2, 0}), nil)
+	tracker.Update([]*Detection{third}, 1, nil)
+	if released := pool.Release(tracker, frame2); released != 1 {
+		t.Errorf("Expected the replaced detection to be released, released %d", released)
+	}
+
+	// The retained detection is untouched by later reuse
+	if first.Points.At(0, 0) != 0 || tracker.TrackedObjects[0].LastDetection != first {
+		t.Error("Expected the retained detection to stay intact")
+	}
+}
+
+func TestDetectionPool_ReleaseSkipsLostObjects(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:        DistanceByName("euclidean"),
+		DistanceThreshold:       20.0,
+		HitCounterMax:           2,
+		InitializationDelay:     0,
+		PastDetectionsLength:    2,
+		RelinkDistanceThreshold: 10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	pool := NewDetectionPool()
+
+	// Detections the tracker doesn't hold on to are released every frame
+	for frame := 0; frame < 3; frame++ {
+		det, _ := pool.Get(mat.NewDense(1, 2, []float64{float64(frame), 0}), nil)
+		tracker.Update([]*Detection{det}, 1, nil)
+		pool.Release(tracker, []*Detection{det})
+	}
+
+	// The object is lost and waits for re-linking with its detections
+	for len(tracker.TrackedObjects) > 0 {
+		tracker.Update(nil, 1, nil)
+	}
+	if len(tracker.lostObjects) != 1 {
+		t.Fatalf("Expected 1 lost object, got %d", len(tracker.lostObjects))
+	}
+	lost := tracker.lostObjects[0].obj
+	held := append([]*Detection{lost.LastDetection}, lost.PastDetections...)
+	positions := make([]float64, len(held))
+	for i, det := range held {
+		positions[i] = det.Points.At(0, 0)
+	}
+	if released := pool.Release(tracker, held); released != 0 {
+		t.Errorf("Expected the lost object's detections not to be released, released %d", released)
+	}
+
+	// Reused detections don't overwrite them before the object is re-linked
+	for i := 0; i < 5; i++ {
+		det, _ := pool.Get(mat.NewDense(1, 2, []float64{500, 500}), nil)
+		pool.Put(det)
+	}
+	for i, det := range held {
+		if det.Points.At(0, 0) != positions[i] {
+			t.Errorf("Expected the lost object's detection to stay at x=%v, got x=%v", positions[i], det.Points.At(0, 0))
+		}
+	}
+}
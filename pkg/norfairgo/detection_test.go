@@ -0,0 +1,162 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNewDetectionFromPoints(t *testing.T) {
+	det, err := NewDetectionFromPoints([][2]int{{10, 20}, {30, 40}}, &DetectionConfig{Label: StringPtr("person")})
+	if err != nil {
+		t.Fatalf("NewDetectionFromPoints failed: %v", err)
+	}
+
+	rows, cols := det.Points.Dims()
+	if rows != 2 || cols != 2 {
+		t.Fatalf("Expected 2x2 points, got %dx%d", rows, cols)
+	}
+	if det.Points.At(1, 0) != 30 || det.Points.At(1, 1) != 40 {
+		t.Errorf("Expected second point (30, 40), got (%v, %v)", det.Points.At(1, 0), det.Points.At(1, 1))
+	}
+	if det.AbsolutePoints == det.Points || det.AbsolutePoints.At(0, 1) != 20 {
+		t.Error("Expected absolute points to be a copy of the points")
+	}
+	if det.Label == nil || *det.Label != "person" {
+		t.Error("Expected config to be applied")
+	}
+
+	if _, err := NewDetectionFromPoints([][2]float64{}, nil); err == nil {
+		t.Error("Expected error for empty points")
+	}
+}
+
+func TestNewDetectionFromBox(t *testing.T) {
+	det, err := NewDetectionFromBox[float32](1.5, 2.5, 10, 20, nil)
+	if err != nil {
+		t.Fatalf("NewDetectionFromBox failed: %v", err)
+	}
+
+	expected := []float64{1.5, 2.5, 10, 20}
+	for i, v := range expected {
+		if got := det.Points.At(i/2, i%2); got != v {
+			t.Errorf("Point value %d: expected %v, got %v", i, v, got)
+		}
+	}
+}
+
+func TestNewDetectionFromSlice(t *testing.T) {
+	det, err := NewDetectionFromSlice([]uint16{1, 2, 3, 4, 5, 6}, 3, nil)
+	if err != nil {
+		t.Fatalf("NewDetectionFromSlice failed: %v", err)
+	}
+	if rows, cols := det.Points.Dims(); rows != 2 || cols != 3 {
+		t.Errorf("Expected 2x3 points, got %dx%d", rows, cols)
+	}
+
+	invalid := []struct {
+		name   string
+		values []float64
+		dims   int
+	}{
+		{"empty", nil, 2},
+		{"not a multiple of dims", []float64{1, 2, 3}, 2},
+		{"invalid dims", []float64{1, 2, 3, 4}, 4},
+	}
+	for _, tc := range invalid {
+		if _, err := NewDetectionFromSlice(tc.values, tc.dims, nil); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestDetection_Reset(t *testing.T) {
+	det, err := NewDetectionFromBox(0, 0, 10, 10, &DetectionConfig{
+		Scores: []float64{0.5, 0.5},
+		Label:  StringPtr("car"),
+	})
+	if err != nil {
+		t.Fatalf("NewDetectionFromBox failed: %v", err)
+	}
+	det.Age = 3
+
+	points := mat.NewDense(2, 2, []float64{20, 30, 40, 50})
+	scores := []float64{0.9, 0.8}
+	if err := det.Reset(points, scores); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	if det.Points.At(1, 1) != 50 || det.AbsolutePoints.At(0, 0) != 20 {
+		t.Error("Expected points and absolute points to be replaced")
+	}
+	if det.Label != nil || det.Age != 0 {
+		t.Error("Expected metadata to be cleared")
+	}
+
+	// Inputs are copied, so the caller may reuse its buffers
+	points.Set(0, 0, -1)
+	scores[0] = -1
+	if det.Points.At(0, 0) != 20 || det.AbsolutePoints.At(0, 0) != 20 || det.Scores[0] != 0.9 {
+		t.Error("Expected Reset to copy points and scores")
+	}
+
+	if err := det.Reset(mat.NewDense(1, 4, nil), nil); err == nil {
+		t.Error("Expected error for invalid points shape")
+	}
+}
+
+func TestDetection_ResetZeroAllocation(t *testing.T) {
+	det, _ := NewDetectionFromBox(0, 0, 10, 10, &DetectionConfig{Scores: []float64{1, 1}})
+	points := mat.NewDense(2, 2, []float64{20, 30, 40, 50})
+	scores := []float64{0.9, 0.8}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = det.Reset(points, scores)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations when resetting a same-sized detection, got %v", allocs)
+	}
+}
+
+func TestDetection_Validate(t *testing.T) {
+	valid, _ := NewDetectionFromBox(0, 0, 10, 10, nil)
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid detection, got %v", err)
+	}
+
+	cases := map[string]*Detection{
+		"nil":             nil,
+		"no points":       {},
+		"empty points":    {Points: &mat.Dense{}},
+		"4 columns":       {Points: mat.NewDense(1, 4, nil)},
+		"absolute shape":  {Points: mat.NewDense(2, 2, nil), AbsolutePoints: mat.NewDense(1, 2, nil)},
+		"scores mismatch": {Points: mat.NewDense(2, 2, nil), Scores: []float64{1}},
+	}
+	for name, det := range cases {
+		if err := det.Validate(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSplitIgnored(t *testing.T) {
+	a, _ := NewDetectionFromPoints([][2]float64{{0, 0}}, nil)
+	b, _ := NewDetectionFromPoints([][2]float64{{1, 1}}, nil)
+	c, _ := NewDetectionFromPoints([][2]float64{{2, 2}}, nil)
+	b.Ignore = true
+
+	active, ignored := SplitIgnored([]*Detection{a, b, c})
+	if len(active) != 2 || active[0] != a || active[1] != c {
+		t.Errorf("Expected active detections a and c, got %v", active)
+	}
+	if len(ignored) != 1 || ignored[0] != b {
+		t.Errorf("Expected ignored detection b, got %v", ignored)
+	}
+
+	if err := b.Reset(mat.NewDense(1, 2, []float64{3, 3}), nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if b.Ignore {
+		t.Error("Expected Reset to clear Ignore")
+	}
+}
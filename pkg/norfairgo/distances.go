@@ -18,6 +18,35 @@ type Distance interface {
 	GetDistances(objects []*TrackedObject, candidates interface{}) *mat.Dense
 }
 
+// DetectionDistance is implemented by distances with a typed entry point for
+// detection candidates. The tracker prefers it over GetDistances, which has to
+// convert the candidates through interface{} type switches.
+type DetectionDistance interface {
+	GetDistancesDetections(objects []*TrackedObject, detections []*Detection) *mat.Dense
+}
+
+// TrackedObjectDistance is implemented by distances with a typed entry point for
+// tracked object candidates (ReID matching). The tracker prefers it over GetDistances.
+type TrackedObjectDistance interface {
+	GetDistancesTracked(objects []*TrackedObject, candidates []*TrackedObject) *mat.Dense
+}
+
+// getDistances computes the distance matrix through the typed entry points of the
+// distance when it has them, and GetDistances otherwise.
+func getDistances(distance Distance, objects []*TrackedObject, candidates interface{}) *mat.Dense {
+	switch c := candidates.(type) {
+	case []*Detection:
+		if typed, ok := distance.(DetectionDistance); ok {
+			return typed.GetDistancesDetections(objects, c)
+		}
+	case []*TrackedObject:
+		if typed, ok := distance.(TrackedObjectDistance); ok {
+			return typed.GetDistancesTracked(objects, c)
+		}
+	}
+	return distance.GetDistances(objects, candidates)
+}
+
 // =============================================================================
 // ScalarDistance - Wraps pointwise distance functions
 // =============================================================================
@@ -34,8 +63,20 @@ func NewScalarDistance(distanceFunction func(*Detection, *TrackedObject) float64
 	}
 }
 
-// GetDistances computes the distance matrix using scalar distance function
+// GetDistances computes the distance matrix using scalar distance function.
+// It implements Distance for candidates of type []*Detection, []Detection,
+// []*TrackedObject or []TrackedObject.
+//
+// Deprecated: Call GetDistancesDetections or GetDistancesTracked directly, which
+// avoid converting the candidates through interface{}.
 func (sd *ScalarDistance) GetDistances(objects []*TrackedObject, candidates interface{}) *mat.Dense {
+	switch c := candidates.(type) {
+	case []*Detection:
+		return sd.GetDistancesDetections(objects, c)
+	case []*TrackedObject:
+		return sd.GetDistancesTracked(objects, c)
+	}
+
 	candList := convertCandidatesToList(candidates)
 	distanceMatrix := createInfinityMatrix(len(candList), len(objects))
 
@@ -54,6 +95,26 @@ func (sd *ScalarDistance) GetDistances(objects []*TrackedObject, candidates inte
 	return distanceMatrix
 }
 
+// GetDistancesDetections computes the distance matrix (rows = detections, cols =
+// objects). Pairs with different labels or class IDs are +Inf.
+func (sd *ScalarDistance) GetDistancesDetections(objects []*TrackedObject, detections []*Detection) *mat.Dense {
+	distanceMatrix := createInfinityMatrix(len(detections), len(objects))
+	for c, det := range detections {
+		for o, obj := range objects {
+			if labelsMatch(det.Label, obj.Label) && classIDsMatch(det.ClassID, obj.ClassID) {
+				distanceMatrix.Set(c, o, sd.distanceFunction(det, obj))
+			}
+		}
+	}
+	return distanceMatrix
+}
+
+// GetDistancesTracked returns a matrix of +Inf (rows = candidates, cols = objects):
+// a scalar distance can't compare two tracked objects.
+func (sd *ScalarDistance) GetDistancesTracked(objects []*TrackedObject, candidates []*TrackedObject) *mat.Dense {
+	return createInfinityMatrix(len(candidates), len(objects))
+}
+
 func convertCandidatesToList(candidates interface{}) []interface{} {
 	switch v := candidates.(type) {
 	case []Detection:
@@ -86,19 +147,17 @@ func convertCandidatesToList(candidates interface{}) []interface{} {
 }
 
 func createInfinityMatrix(rows, cols int) *mat.Dense {
-	matrix := mat.NewDense(rows, cols, nil)
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			matrix.Set(i, j, math.Inf(1))
-		}
+	data := make([]float64, rows*cols)
+	for i := range data {
+		data[i] = math.Inf(1)
 	}
-	return matrix
+	return mat.NewDense(rows, cols, data)
 }
 
 func (sd *ScalarDistance) computePairDistance(candidate interface{}, obj *TrackedObject) (float64, bool) {
 	switch cand := candidate.(type) {
 	case *Detection:
-		if labelsMatch(cand.Label, obj.Label) {
+		if labelsMatch(cand.Label, obj.Label) && classIDsMatch(cand.ClassID, obj.ClassID) {
 			return sd.distanceFunction(cand, obj), true
 		}
 	case *TrackedObject:
@@ -119,6 +178,37 @@ func labelsMatch(label1, label2 *string) bool {
 	return true
 }
 
+func classIDsMatch(id1, id2 *int) bool {
+	if id1 != nil && id2 != nil {
+		return *id1 == *id2
+	}
+	if id1 != nil || id2 != nil {
+		log.Printf("Warning: comparing objects with mismatched class ID presence")
+		return false
+	}
+	return true
+}
+
+// labelKey groups candidates and objects by label and class ID.
+// Comparable, so it can be used as a map key without building strings.
+type labelKey struct {
+	label      string
+	hasLabel   bool
+	classID    int
+	hasClassID bool
+}
+
+func newLabelKey(label *string, classID *int) labelKey {
+	var key labelKey
+	if label != nil {
+		key.label, key.hasLabel = *label, true
+	}
+	if classID != nil {
+		key.classID, key.hasClassID = *classID, true
+	}
+	return key
+}
+
 // =============================================================================
 // Built-in Distance Functions (Scalar)
 // =============================================================================
@@ -193,64 +283,87 @@ func NewVectorizedDistance(distanceFunction func(candidates, objects *mat.Dense)
 	}
 }
 
-// GetDistances computes the distance matrix using vectorized distance function
+// GetDistances computes the distance matrix using vectorized distance function.
+// It implements Distance for candidates of type []*Detection, []Detection,
+// []*TrackedObject or []TrackedObject.
+//
+// Deprecated: Call GetDistancesDetections or GetDistancesTracked directly, which
+// avoid converting the candidates through interface{}.
 func (vd *VectorizedDistance) GetDistances(objects []*TrackedObject, candidates interface{}) *mat.Dense {
+	switch c := candidates.(type) {
+	case []*Detection:
+		return vd.GetDistancesDetections(objects, c)
+	case []*TrackedObject:
+		return vd.GetDistancesTracked(objects, c)
+	}
+
 	candList := convertCandidatesToList(candidates)
-	distanceMatrix := createInfinityMatrix(len(candList), len(objects))
+	candidateLabels := make([]labelKey, len(candList))
+	candidatePoints := make([]*mat.Dense, len(candList))
+	for i, cand := range candList {
+		switch c := cand.(type) {
+		case *Detection:
+			candidateLabels[i], candidatePoints[i] = newLabelKey(c.Label, c.ClassID), c.Points
+		case *TrackedObject:
+			candidateLabels[i], candidatePoints[i] = newLabelKey(c.Label, c.ClassID), c.Estimate
+		}
+	}
+	return vd.getDistances(objects, candidateLabels, candidatePoints)
+}
 
-	if len(candList) == 0 || len(objects) == 0 {
+// GetDistancesDetections computes the distance matrix (rows = detections, cols =
+// objects) from the detection points. Pairs with different labels or class IDs are +Inf.
+func (vd *VectorizedDistance) GetDistancesDetections(objects []*TrackedObject, detections []*Detection) *mat.Dense {
+	candidateLabels := make([]labelKey, len(detections))
+	candidatePoints := make([]*mat.Dense, len(detections))
+	for i, det := range detections {
+		candidateLabels[i], candidatePoints[i] = newLabelKey(det.Label, det.ClassID), det.Points
+	}
+	return vd.getDistances(objects, candidateLabels, candidatePoints)
+}
+
+// GetDistancesTracked computes the distance matrix (rows = candidates, cols =
+// objects) from the candidates' estimates. Pairs with different labels or class IDs are +Inf.
+func (vd *VectorizedDistance) GetDistancesTracked(objects []*TrackedObject, candidates []*TrackedObject) *mat.Dense {
+	candidateLabels := make([]labelKey, len(candidates))
+	candidatePoints := make([]*mat.Dense, len(candidates))
+	for i, cand := range candidates {
+		candidateLabels[i], candidatePoints[i] = newLabelKey(cand.Label, cand.ClassID), cand.Estimate
+	}
+	return vd.getDistances(objects, candidateLabels, candidatePoints)
+}
+
+// getDistances computes the distance matrix label group by label group.
+func (vd *VectorizedDistance) getDistances(objects []*TrackedObject, candidateLabels []labelKey, candidatePoints []*mat.Dense) *mat.Dense {
+	distanceMatrix := createInfinityMatrix(len(candidatePoints), len(objects))
+
+	if len(candidatePoints) == 0 || len(objects) == 0 {
 		return distanceMatrix
 	}
 
 	objectLabels := extractObjectLabels(objects)
-	candidateLabels := extractCandidateLabels(candList)
 	uniqueLabels := findIntersection(unique(objectLabels), unique(candidateLabels))
 
 	for _, label := range uniqueLabels {
-		vd.processLabelGroup(label, objects, candList, objectLabels, candidateLabels, distanceMatrix)
+		vd.processLabelGroup(label, objects, candidatePoints, objectLabels, candidateLabels, distanceMatrix)
 	}
 
 	return distanceMatrix
 }
 
-func extractObjectLabels(objects []*TrackedObject) []string {
-	labels := make([]string, len(objects))
-	for i := range objects {
-		if objects[i].Label != nil {
-			labels[i] = *objects[i].Label
-		} else {
-			labels[i] = "None"
-		}
-	}
-	return labels
-}
-
-func extractCandidateLabels(candList []interface{}) []string {
-	labels := make([]string, len(candList))
-	for i, cand := range candList {
-		switch c := cand.(type) {
-		case *Detection:
-			if c.Label != nil {
-				labels[i] = *c.Label
-			} else {
-				labels[i] = "None"
-			}
-		case *TrackedObject:
-			if c.Label != nil {
-				labels[i] = *c.Label
-			} else {
-				labels[i] = "None"
-			}
-		}
+func extractObjectLabels(objects []*TrackedObject) []labelKey {
+	labels := make([]labelKey, len(objects))
+	for i, obj := range objects {
+		labels[i] = newLabelKey(obj.Label, obj.ClassID)
 	}
 	return labels
 }
 
 func (vd *VectorizedDistance) processLabelGroup(
-	label string,
+	label labelKey,
 	objects []*TrackedObject,
-	candList []interface{},
-	objectLabels, candidateLabels []string,
+	candidatePoints []*mat.Dense,
+	objectLabels, candidateLabels []labelKey,
 	distanceMatrix *mat.Dense,
 ) {
 	objIndices := findLabelIndices(objectLabels, label)
@@ -261,7 +374,7 @@ func (vd *VectorizedDistance) processLabelGroup(
 	}
 
 	stackedObjects := stackObjectEstimates(objects, objIndices)
-	stackedCandidates := stackCandidateData(candList, candIndices, stackedObjects.RawMatrix().Cols)
+	stackedCandidates := stackCandidateData(candidatePoints, candIndices, stackedObjects.RawMatrix().Cols)
 
 	distances := vd.distanceFunction(stackedCandidates, stackedObjects)
 	assignDistancesToMatrix(distances, candIndices, objIndices, distanceMatrix)
@@ -286,18 +399,10 @@ func stackObjectEstimates(objects []*TrackedObject, indices []int) *mat.Dense {
 	return stacked
 }
 
-func stackCandidateData(candList []interface{}, indices []int, flattenedCols int) *mat.Dense {
+func stackCandidateData(candidatePoints []*mat.Dense, indices []int, flattenedCols int) *mat.Dense {
 	stacked := mat.NewDense(len(indices), flattenedCols, nil)
 	for i, idx := range indices {
-		var flatData []float64
-		cand := candList[idx]
-
-		if det, ok := cand.(*Detection); ok {
-			flatData = flattenMatrix(det.Points)
-		} else if obj, ok := cand.(*TrackedObject); ok {
-			flatData = flattenMatrix(obj.Estimate)
-		}
-
+		flatData := flattenMatrix(candidatePoints[idx])
 		for j, val := range flatData {
 			stacked.Set(i, j, val)
 		}
@@ -336,6 +441,49 @@ func NewScipyDistance(metric string) *ScipyDistance {
 	return sd
 }
 
+// NewMinkowskiDistance creates a ScipyDistance using the Minkowski distance of
+// order p between flattened points, like cdist(..., "minkowski", p=p).
+// p=1 is the cityblock distance, p=2 the euclidean distance.
+//
+// Panics if p <= 0.
+func NewMinkowskiDistance(p float64) *ScipyDistance {
+	if !(p > 0) {
+		panic(fmt.Sprintf("minkowski p must be greater than 0, got %v", p))
+	}
+	sd := &ScipyDistance{
+		metric: "minkowski",
+	}
+	sd.VectorizedDistance.distanceFunction = func(candidates, objects *mat.Dense) *mat.Dense {
+		return scipy.CdistMinkowski(candidates, objects, p)
+	}
+	return sd
+}
+
+// NewStandardizedEuclideanDistance creates a ScipyDistance using the euclidean
+// distance between flattened points with each coordinate divided by its variance,
+// like cdist(..., "seuclidean", V=variance).
+//
+// The variance is fixed, with one entry per flattened coordinate (n_points *
+// n_dims), so DistanceThreshold keeps the same meaning in every frame; scipy's
+// default of the variance of the compared points is not supported.
+//
+// Panics if an entry of variance is not greater than 0.
+func NewStandardizedEuclideanDistance(variance []float64) *ScipyDistance {
+	for k, v := range variance {
+		if !(v > 0) {
+			panic(fmt.Sprintf("seuclidean variance must be greater than 0, got %v at coordinate %d", v, k))
+		}
+	}
+	variance = append([]float64(nil), variance...)
+	sd := &ScipyDistance{
+		metric: "seuclidean",
+	}
+	sd.VectorizedDistance.distanceFunction = func(candidates, objects *mat.Dense) *mat.Dense {
+		return scipy.CdistStandardizedEuclidean(candidates, objects, variance)
+	}
+	return sd
+}
+
 // =============================================================================
 // Built-in Distance Functions (Vectorized)
 // =============================================================================
@@ -388,6 +536,68 @@ func IoU(candidates, objects *mat.Dense) *mat.Dense {
 	return result
 }
 
+// CreateExpandedIoU constructs an expanded IoU distance (E-IoU) that scales both
+// boxes around their centers before computing the IoU distance.
+//
+// Tiny objects often move more than their own size between frames, so their
+// boxes stop overlapping and plain IoU is 1 for every pair. Expanding the boxes
+// restores an overlap that still decreases with the center offset.
+//
+// Parameters:
+//   - expansionFactor: Scale of the box width and height (1 = plain IoU, must be > 0)
+//
+// Example:
+//
+//	config := &TrackerConfig{
+//	    DistanceFunction: NewVectorizedDistance(CreateExpandedIoU(2)),
+//	}
+func CreateExpandedIoU(expansionFactor float64) func(candidates, objects *mat.Dense) *mat.Dense {
+	if expansionFactor <= 0 {
+		panic(fmt.Sprintf("expansionFactor must be > 0, got %f", expansionFactor))
+	}
+	return func(candidates, objects *mat.Dense) *mat.Dense {
+		return IoU(expandBboxes(candidates, expansionFactor), expandBboxes(objects, expansionFactor))
+	}
+}
+
+// CreateBufferedIoU constructs a buffered IoU distance (BIoU) that adds a margin
+// of bufferScale times the box width and height on every side of both boxes
+// before computing the IoU distance, so fast-moving or blurred objects still
+// overlap their tracks.
+//
+// This is CreateExpandedIoU with an expansion factor of 1 + 2*bufferScale.
+//
+// Parameters:
+//   - bufferScale: Margin per side relative to the box size (0 = plain IoU, must be >= 0)
+func CreateBufferedIoU(bufferScale float64) func(candidates, objects *mat.Dense) *mat.Dense {
+	if bufferScale < 0 {
+		panic(fmt.Sprintf("bufferScale must be >= 0, got %f", bufferScale))
+	}
+	return CreateExpandedIoU(1 + 2*bufferScale)
+}
+
+// expandBboxes returns a copy of bboxes scaled by factor around their centers
+func expandBboxes(bboxes *mat.Dense, factor float64) *mat.Dense {
+	expanded := mat.DenseCopyOf(bboxes)
+	if factor == 1 {
+		return expanded
+	}
+	rows, cols := expanded.Dims()
+	if cols != 4 {
+		return expanded // Reported by validateBboxes
+	}
+	for i := 0; i < rows; i++ {
+		row := expanded.RawRowView(i)
+		halfWidth := (row[2] - row[0]) * factor / 2
+		halfHeight := (row[3] - row[1]) * factor / 2
+		centerX := (row[0] + row[2]) / 2
+		centerY := (row[1] + row[3]) / 2
+		row[0], row[1] = centerX-halfWidth, centerY-halfHeight
+		row[2], row[3] = centerX+halfWidth, centerY+halfHeight
+	}
+	return expanded
+}
+
 // validateBboxes checks that bboxes have correct shape and warns on invalid bounds
 func validateBboxes(bboxes *mat.Dense) {
 	rows, cols := bboxes.Dims()
@@ -432,10 +642,10 @@ func flattenMatrix(m *mat.Dense) []float64 {
 	return result
 }
 
-// unique returns unique strings from a slice
-func unique(s []string) []string {
-	seen := make(map[string]bool)
-	var result []string
+// unique returns unique labels from a slice
+func unique(s []labelKey) []labelKey {
+	seen := make(map[labelKey]bool)
+	var result []labelKey
 	for _, val := range s {
 		if !seen[val] {
 			seen[val] = true
@@ -446,13 +656,13 @@ func unique(s []string) []string {
 }
 
 // findIntersection finds common elements between two slices
-func findIntersection(a, b []string) []string {
-	set := make(map[string]bool)
+func findIntersection(a, b []labelKey) []labelKey {
+	set := make(map[labelKey]bool)
 	for _, val := range a {
 		set[val] = true
 	}
 
-	var result []string
+	var result []labelKey
 	for _, val := range b {
 		if set[val] {
 			result = append(result, val)
@@ -462,7 +672,7 @@ func findIntersection(a, b []string) []string {
 }
 
 // findLabelIndices finds all indices where the label matches
-func findLabelIndices(labels []string, target string) []int {
+func findLabelIndices(labels []labelKey, target labelKey) []int {
 	var indices []int
 	for i, label := range labels {
 		if label == target {
@@ -569,24 +779,30 @@ var scalarDistanceFunctions = map[string]func(*Detection, *TrackedObject) float6
 
 // Vectorized distance function registry
 var vectorizedDistanceFunctions = map[string]func(*mat.Dense, *mat.Dense) *mat.Dense{
-	"iou":     IoU,
-	"iou_opt": IoU, // deprecated, same as iou
+	"iou":         IoU,
+	"iou_opt":     IoU, // deprecated, same as iou
+	"iou32":       IoU32,
+	"euclidean32": Euclidean32,
+	"eiou":        CreateExpandedIoU(2),   // Use CreateExpandedIoU for other factors
+	"biou":        CreateBufferedIoU(0.3), // Use CreateBufferedIoU for other buffers
 }
 
-// List of supported scipy distance metrics
+// List of supported scipy distance metrics (those implemented by scipy.Cdist)
 var scipyDistanceMetrics = []string{
-	"braycurtis", "canberra", "chebyshev", "cityblock", "correlation",
-	"cosine", "dice", "euclidean", "hamming", "jaccard", "jensenshannon",
-	"kulczynski1", "mahalanobis", "matching", "minkowski", "rogerstanimoto",
-	"russellrao", "seuclidean", "sokalmichener", "sokalsneath", "sqeuclidean",
-	"yule",
+	"chebyshev", "cityblock", "cosine", "euclidean", "manhattan",
+	"minkowski", "sqeuclidean",
 }
 
 // GetDistanceByName selects a distance by name.
 //
 // Returns the corresponding Distance implementation for the given name.
 // Supports scalar distances (frobenius, mean_euclidean, mean_manhattan),
-// vectorized distances (iou), and scipy metrics (euclidean, manhattan, etc.).
+// vectorized distances (iou, eiou with boxes expanded 2x, biou with a 0.3
+// buffer, and the float32 iou32 and euclidean32), reference
+// point distances (center_euclidean, bottom_center_euclidean, centroid_euclidean)
+// and scipy metrics (euclidean, cityblock/manhattan, cosine, chebyshev, minkowski
+// with p=2, sqeuclidean). Use NewMinkowskiDistance for other p and
+// NewStandardizedEuclideanDistance for seuclidean.
 func GetDistanceByName(name string) Distance {
 	// Check scalar distances
 	if fn, ok := scalarDistanceFunctions[name]; ok {
@@ -602,6 +818,11 @@ func GetDistanceByName(name string) Distance {
 		return NewVectorizedDistance(fn)
 	}
 
+	// Check reference point distances
+	if mode, ok := referencePointDistances[name]; ok {
+		return NewReferencePointDistance(mode.Extract)
+	}
+
 	// Check scipy distances
 	for _, metric := range scipyDistanceMetrics {
 		if name == metric {
@@ -449,6 +449,51 @@ func TestIoU_InvalidBbox(t *testing.T) {
 	IoU(candMat, objMat)
 }
 
+func TestCreateExpandedIoU(t *testing.T) {
+	// Tiny boxes that moved by more than their own size
+	cand := mat.NewDense(1, 4, []float64{0, 0, 2, 2})
+	obj := mat.NewDense(1, 4, []float64{3, 0, 5, 2})
+
+	testutil.AssertAlmostEqual(t, IoU(cand, obj).At(0, 0), 1.0, 1e-6, "plain IoU")
+	testutil.AssertAlmostEqual(t, CreateExpandedIoU(1)(cand, obj).At(0, 0), 1.0, 1e-6, "factor 1")
+
+	// Expanded 3x: [-2, -2, 4, 4] and [1, -2, 7, 4], intersection=3*6, union=36+36-18
+	testutil.AssertAlmostEqual(t, CreateExpandedIoU(3)(cand, obj).At(0, 0), 1.0-18.0/54.0, 1e-6, "factor 3")
+
+	// Inputs are not modified
+	testutil.AssertAlmostEqual(t, cand.At(0, 2), 2.0, 1e-9, "candidate unchanged")
+
+	// Identical boxes still have zero distance
+	testutil.AssertAlmostEqual(t, CreateExpandedIoU(2)(cand, cand).At(0, 0), 0.0, 1e-6, "identical")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for non-positive expansion factor")
+		}
+	}()
+	CreateExpandedIoU(0)
+}
+
+func TestCreateBufferedIoU(t *testing.T) {
+	cand := mat.NewDense(1, 4, []float64{0, 0, 2, 2})
+	obj := mat.NewDense(1, 4, []float64{3, 0, 5, 2})
+
+	testutil.AssertAlmostEqual(t, CreateBufferedIoU(0)(cand, obj).At(0, 0), 1.0, 1e-6, "no buffer")
+	// Buffer 1 = expansion 3
+	testutil.AssertAlmostEqual(t, CreateBufferedIoU(1)(cand, obj).At(0, 0), CreateExpandedIoU(3)(cand, obj).At(0, 0), 1e-9, "buffer 1")
+
+	// Selectable by name
+	distance := GetDistanceByName("biou").(*VectorizedDistance)
+	testutil.AssertAlmostEqual(t, distance.distanceFunction(cand, obj).At(0, 0), CreateBufferedIoU(0.3)(cand, obj).At(0, 0), 1e-9, "biou")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for negative buffer")
+		}
+	}()
+	CreateBufferedIoU(-0.1)
+}
+
 // =============================================================================
 // Test ScalarDistance Wrapper
 // =============================================================================
@@ -535,6 +580,111 @@ func TestVectorizedDistance(t *testing.T) {
 	testutil.AssertAlmostEqual(t, matrix.At(0, 0), 0.0, 1e-6, "IoU distance should be 0 for perfect match")
 }
 
+// TestVectorizedDistance_ClassIDGrouping verifies integer class IDs separate label groups
+func TestVectorizedDistance_ClassIDGrouping(t *testing.T) {
+	distance := NewVectorizedDistance(IoU)
+	bbox := func() *mat.Dense { return mat.NewDense(1, 4, []float64{0, 0, 1, 1}) }
+
+	detections := []*Detection{
+		{Points: bbox(), ClassID: IntPtr(0)},
+		{Points: bbox(), ClassID: IntPtr(1)},
+		{Points: bbox()},
+	}
+	objects := []*TrackedObject{
+		{Estimate: bbox(), ClassID: IntPtr(1)},
+		{Estimate: bbox()},
+	}
+
+	matrix := distance.GetDistances(objects, detections)
+
+	expected := [][]float64{
+		{math.Inf(1), math.Inf(1)},
+		{0, math.Inf(1)},
+		{math.Inf(1), 0},
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if got := matrix.At(i, j); got != expected[i][j] {
+				t.Errorf("matrix[%d][%d] = %v, expected %v", i, j, got, expected[i][j])
+			}
+		}
+	}
+
+	// A string label and a class ID are independent keys
+	labelled := []*Detection{{Points: bbox(), Label: StringPtr("1")}}
+	if got := distance.GetDistances(objects[:1], labelled).At(0, 0); !math.IsInf(got, 1) {
+		t.Errorf("Expected label \"1\" not to match class ID 1, got %v", got)
+	}
+}
+
+// TestScalarDistance_ClassIDMatch verifies scalar distances skip mismatched class IDs
+func TestScalarDistance_ClassIDMatch(t *testing.T) {
+	distance := NewScalarDistance(Frobenius)
+
+	det := newMockDetection([][]float64{{1, 2}})
+	det.ClassID = IntPtr(3)
+	same := newMockTrackedObject([][]float64{{1, 2}})
+	same.ClassID = IntPtr(3)
+	other := newMockTrackedObject([][]float64{{1, 2}})
+	other.ClassID = IntPtr(4)
+
+	matrix := distance.GetDistances([]*TrackedObject{same, other}, []*Detection{det})
+	if matrix.At(0, 0) != 0 {
+		t.Errorf("Expected distance 0 for matching class IDs, got %v", matrix.At(0, 0))
+	}
+	if !math.IsInf(matrix.At(0, 1), 1) {
+		t.Errorf("Expected infinite distance for different class IDs, got %v", matrix.At(0, 1))
+	}
+}
+
+// TestDistance_TypedCandidates verifies the typed entry points match GetDistances
+func TestDistance_TypedCandidates(t *testing.T) {
+	bbox := func(x float64) *mat.Dense { return mat.NewDense(2, 2, []float64{x, 0, x + 1, 1}) }
+	detections := []*Detection{
+		{Points: bbox(0), ClassID: IntPtr(1)},
+		{Points: bbox(0.5)},
+		{Points: bbox(3), Label: StringPtr("car")},
+	}
+	objects := []*TrackedObject{
+		{Estimate: bbox(0.2), ClassID: IntPtr(1)},
+		{Estimate: bbox(0.4)},
+		{Estimate: bbox(3), Label: StringPtr("car")},
+	}
+	detectionValues := []Detection{*detections[0], *detections[1], *detections[2]}
+
+	distances := map[string]interface {
+		Distance
+		DetectionDistance
+		TrackedObjectDistance
+	}{
+		"scalar":     NewScalarDistance(Frobenius),
+		"vectorized": NewVectorizedDistance(IoU),
+		"scipy":      NewScipyDistance("euclidean"),
+	}
+	for name, distance := range distances {
+		t.Run(name, func(t *testing.T) {
+			expected := distance.GetDistances(objects, detectionValues)
+			assertMatrixEqual(t, distance.GetDistancesDetections(objects, detections), expected)
+			assertMatrixEqual(t, distance.GetDistances(objects, detections), expected)
+			if !math.IsInf(expected.At(0, 1), 1) || !math.IsInf(expected.At(2, 0), 1) {
+				t.Errorf("Expected mismatched labels to be infinite, got %v", mat.Formatted(expected))
+			}
+
+			tracked := distance.GetDistancesTracked(objects, objects)
+			if rows, cols := tracked.Dims(); rows != 3 || cols != 3 {
+				t.Errorf("Expected matrix shape (3, 3), got (%d, %d)", rows, cols)
+			}
+		})
+	}
+}
+
+func assertMatrixEqual(t *testing.T, got, expected *mat.Dense) {
+	t.Helper()
+	if !mat.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", mat.Formatted(expected), mat.Formatted(got))
+	}
+}
+
 // =============================================================================
 // Test ScipyDistance
 // =============================================================================
@@ -578,6 +728,62 @@ func TestScipyDistance(t *testing.T) {
 	testutil.AssertAlmostEqual(t, distMatrix.At(0, 0), 1.0, 1e-6, "euclidean distance should be 1.0")
 }
 
+// TestScipyDistance_Metrics verifies every registered scipy metric is computable
+func TestScipyDistance_Metrics(t *testing.T) {
+	// det = [1, 2, 3, 4], obj = [2, 3, 4, 6]
+	detections := []*Detection{newMockDetection([][]float64{{1, 2}, {3, 4}})}
+	objects := []*TrackedObject{newMockTrackedObject([][]float64{{2, 3}, {4, 6}})}
+
+	expected := map[string]float64{
+		"chebyshev":   2,
+		"cityblock":   5,
+		"manhattan":   5,
+		"cosine":      1 - 44/(math.Sqrt(30)*math.Sqrt(65)),
+		"euclidean":   math.Sqrt(7),
+		"minkowski":   math.Sqrt(7),
+		"sqeuclidean": 7,
+	}
+	for _, metric := range scipyDistanceMetrics {
+		want, ok := expected[metric]
+		if !ok {
+			t.Errorf("Missing expected value for metric %q", metric)
+			continue
+		}
+		got := GetDistanceByName(metric).GetDistances(objects, detections).At(0, 0)
+		testutil.AssertAlmostEqual(t, got, want, 1e-9, metric)
+	}
+
+	got := NewMinkowskiDistance(3).GetDistancesDetections(objects, detections).At(0, 0)
+	testutil.AssertAlmostEqual(t, got, math.Cbrt(11), 1e-9, "minkowski p=3")
+}
+
+func TestStandardizedEuclideanDistance(t *testing.T) {
+	// det = [1, 2, 3, 4], obj = [2, 3, 4, 6], V = [1, 1, 4, 4]
+	detections := []*Detection{newMockDetection([][]float64{{1, 2}, {3, 4}})}
+	objects := []*TrackedObject{newMockTrackedObject([][]float64{{2, 3}, {4, 6}})}
+	got := NewStandardizedEuclideanDistance([]float64{1, 1, 4, 4}).GetDistances(objects, detections).At(0, 0)
+	testutil.AssertAlmostEqual(t, got, math.Sqrt(1+1+0.25+1), 1e-9, "seuclidean")
+
+	// A point moving only along y has no x variance among the compared points
+	tracker := mustNewTracker(t, &TrackerConfig{
+		DistanceFunction:  NewStandardizedEuclideanDistance([]float64{1, 1}),
+		DistanceThreshold: 5,
+	})
+	for frame := 0; frame < 5; frame++ {
+		tracker.Update([]*Detection{newPointDetection(t, 10, float64(10+frame))}, 1, nil)
+	}
+	if len(tracker.TrackedObjects) != 1 {
+		t.Errorf("Expected one tracked object, got %d", len(tracker.TrackedObjects))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for a zero variance")
+		}
+	}()
+	NewStandardizedEuclideanDistance([]float64{1, 0})
+}
+
 // =============================================================================
 // Test Keypoint Voting Distance
 // =============================================================================
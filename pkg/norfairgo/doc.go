@@ -46,5 +46,12 @@ Custom: Implement Distance interface
   - OptimizedKalmanFilter: Fast, simplified covariance (default)
   - FilterPyKalmanFilter: Full Kalman, filterpy-compatible
   - NoFilter: No prediction
+
+# Build Tags
+
+Building with -tags nogocv excludes everything that needs OpenCV (MotionEstimator,
+HomographyTransformationGetter, Video, GetCutout, ExtractCrops, Pipeline), so the
+tracker compiles with pure Go dependencies. Frame is then image.Image instead of
+gocv.Mat.
 */
 package norfairgo
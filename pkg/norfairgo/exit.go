@@ -0,0 +1,51 @@
+package norfairgo
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Frame Exit - Early Termination of Objects Leaving the Frame
+// =============================================================================
+
+// terminateExitingObjects removes the objects that were not matched in this
+// update and are predicted to leave the frame (see TrackerConfig.ExitLookahead).
+func (t *Tracker) terminateExitingObjects() {
+	kept := t.TrackedObjects[:0:0]
+	for _, obj := range t.TrackedObjects {
+		if obj.matched || obj.Frozen || !obj.isExiting(t.Config.ExitLookahead, t.Config.FrameWidth, t.Config.FrameHeight) {
+			kept = append(kept, obj)
+		}
+	}
+	t.TrackedObjects = kept
+}
+
+// isExiting reports whether the object's center, predicted lookahead frames ahead
+// with its velocity, lies outside the width x height frame.
+func (to *TrackedObject) isExiting(lookahead, width, height int) bool {
+	if to.DimPoints < 2 {
+		return false
+	}
+
+	// The filter state (and velocity) is in absolute coordinates when a
+	// coordinate transformation is set; the frame is in relative coordinates.
+	estimate, err := to.GetEstimate(to.AbsToRel != nil)
+	if err != nil {
+		return false
+	}
+	velocity := to.EstimateVelocity()
+	center := mat.NewDense(1, to.DimPoints, nil)
+	for d := 0; d < to.DimPoints; d++ {
+		sum := 0.0
+		for i := 0; i < to.NumPoints; i++ {
+			sum += estimate.At(i, d) + float64(lookahead)*velocity.At(i, d)
+		}
+		center.Set(0, d, sum/float64(to.NumPoints))
+	}
+	if to.AbsToRel != nil {
+		center = to.AbsToRel(center)
+	}
+
+	x, y := center.At(0, 0), center.At(0, 1)
+	return x < 0 || y < 0 || x >= float64(width) || y >= float64(height)
+}
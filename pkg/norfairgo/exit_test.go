@@ -0,0 +1,62 @@
+package norfairgo
+
+import (
+	"testing"
+)
+
+func TestTracker_ExitLookahead(t *testing.T) {
+	newTracker := func(lookahead int) *Tracker {
+		tracker, err := NewTracker(&TrackerConfig{
+			DistanceFunction:    DistanceByName("euclidean"),
+			DistanceThreshold:   20.0,
+			HitCounterMax:       10,
+			InitializationDelay: 0,
+			FrameWidth:          200,
+			FrameHeight:         100,
+			ExitLookahead:       lookahead,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+		return tracker
+	}
+
+	// An object moving right by 10 per frame, last seen at x=190, and one occluded
+	// in the middle of the frame
+	run := func(tracker *Tracker) (exited int) {
+		for frame := 0; frame < 20; frame++ {
+			tracker.Update([]*Detection{
+				newPointDetection(t, float64(frame)*10, 50),
+				newPointDetection(t, 100, 90),
+			}, 1, nil)
+		}
+		_, stats := tracker.UpdateWithStats(nil, 1, nil)
+		return stats.NumExited
+	}
+
+	tracker := newTracker(1)
+	if exited := run(tracker); exited != 1 {
+		t.Errorf("Expected 1 exited object, got %d", exited)
+	}
+	if len(tracker.TrackedObjects) != 1 || tracker.TrackedObjects[0].Estimate.At(0, 0) != 100 {
+		t.Fatalf("Expected only the occluded object to remain, got %d objects", len(tracker.TrackedObjects))
+	}
+
+	// Disabled: the exiting object lingers
+	tracker = newTracker(0)
+	if exited := run(tracker); exited != 0 {
+		t.Errorf("Expected no exited objects when disabled, got %d", exited)
+	}
+	if len(tracker.TrackedObjects) != 2 {
+		t.Errorf("Expected both objects to remain when disabled, got %d", len(tracker.TrackedObjects))
+	}
+
+	// A frame size is required
+	if _, err := NewTracker(&TrackerConfig{
+		DistanceFunction:  DistanceByName("euclidean"),
+		DistanceThreshold: 1,
+		ExitLookahead:     1,
+	}); err == nil {
+		t.Error("Expected error for ExitLookahead without a frame size")
+	}
+}
@@ -1,6 +1,10 @@
 package norfairgo
 
 import (
+	"fmt"
+	"math"
+	"strings"
+
 	"github.com/nmichlo/norfair-go/internal/filterpy"
 	"gonum.org/v1/gonum/mat"
 )
@@ -22,6 +26,30 @@ type Filter interface {
 	SetStateVector(x *mat.Dense)
 }
 
+// CovarianceFilter is implemented by filters that expose their position uncertainty.
+//
+// PositionCovariance returns the (dimZ, dimZ) covariance of the position part of the
+// state, in the same flattened point order as the state vector.
+type CovarianceFilter interface {
+	PositionCovariance() *mat.Dense
+}
+
+// InspectableFilter is implemented by Kalman filters that expose their matrices
+// for inspection and tuning. All returned matrices are copies, in filterpy layout:
+//   - Covariance: P, shape (dimX, dimX)
+//   - ProcessNoise: Q, shape (dimX, dimX)
+//   - MeasurementNoise: R, shape (dimZ, dimZ)
+//
+// The state vector is available through Filter.GetStateVector. String returns a
+// human-readable debug dump of all of the above.
+type InspectableFilter interface {
+	Filter
+	Covariance() *mat.Dense
+	ProcessNoise() *mat.Dense
+	MeasurementNoise() *mat.Dense
+	String() string
+}
+
 // =============================================================================
 // FilterPyKalmanFilter - Full Kalman Filter Implementation
 // =============================================================================
@@ -29,6 +57,9 @@ type Filter interface {
 // FilterPyKalmanFilter wraps the internal filterpy.KalmanFilter to satisfy the Filter interface
 type FilterPyKalmanFilter struct {
 	*filterpy.KalmanFilter
+
+	qMult    float64    // Base velocity process noise
+	adaptive *adaptiveQ // Adaptive process noise state (nil = disabled)
 }
 
 // FilterPyKalmanFilterFactory creates FilterPyKalmanFilter instances
@@ -36,6 +67,14 @@ type FilterPyKalmanFilterFactory struct {
 	RMult float64 // Multiplier for sensor measurement noise matrix
 	QMult float64 // Multiplier for process uncertainty
 	PMult float64 // Multiplier for initial covariance matrix (position entries)
+
+	// AdaptiveQ scales the velocity process noise with recent innovations.
+	// Default: nil (fixed process noise)
+	AdaptiveQ *AdaptiveQConfig
+
+	// StateRepresentation selects how 2-point bounding boxes are modelled.
+	// Default: StateCorners
+	StateRepresentation StateRepresentation
 }
 
 // NewFilterPyKalmanFilterFactory creates a factory with default parameters
@@ -47,8 +86,16 @@ func NewFilterPyKalmanFilterFactory(rMult, qMult, pMult float64) *FilterPyKalman
 	}
 }
 
-// CreateFilter creates a new FilterPyKalmanFilter instance
+// CreateFilter creates a new FilterPyKalmanFilter instance, wrapped in a
+// CenterSizeFilter for bounding boxes with StateCenterSize.
 func (f *FilterPyKalmanFilterFactory) CreateFilter(initialDetection *mat.Dense) Filter {
+	if f.StateRepresentation == StateCenterSize && isBoxShape(initialDetection) {
+		return newCenterSizeFilter(f.createFilter(BoxToCenterSize(initialDetection)))
+	}
+	return f.createFilter(initialDetection)
+}
+
+func (f *FilterPyKalmanFilterFactory) createFilter(initialDetection *mat.Dense) InspectableFilter {
 	numPoints, dimPoints := initialDetection.Dims()
 	dimZ := numPoints * dimPoints
 	dimX := 2 * dimZ
@@ -111,7 +158,53 @@ func (f *FilterPyKalmanFilterFactory) CreateFilter(initialDetection *mat.Dense)
 		P.Set(i, i, f.PMult)
 	}
 
-	return &FilterPyKalmanFilter{KalmanFilter: kf}
+	return &FilterPyKalmanFilter{KalmanFilter: kf, qMult: f.QMult, adaptive: newAdaptiveQ(f.AdaptiveQ)}
+}
+
+// Update performs the Kalman update and, in adaptive-Q mode, rescales the
+// velocity process noise from the normalized innovation of this measurement.
+func (kf *FilterPyKalmanFilter) Update(z *mat.Dense, R, H *mat.Dense) {
+	if kf.adaptive == nil {
+		kf.KalmanFilter.Update(z, R, H)
+		return
+	}
+
+	if R == nil {
+		R = kf.GetR()
+	}
+	if H == nil {
+		H = kf.GetH()
+	}
+
+	// Innovation y = z - Hx and its variance diag(HPH^T + R), before the update
+	dimZ := kf.GetDimZ()
+	var hx, hp, s mat.Dense
+	hx.Mul(H, kf.GetState())
+	hp.Mul(H, kf.GetP())
+	s.Mul(&hp, H.T())
+	s.Add(&s, R)
+
+	innovation := make([]float64, dimZ)
+	variance := make([]float64, dimZ)
+	measured := make([]bool, dimZ)
+	for i := 0; i < dimZ; i++ {
+		innovation[i] = z.At(i, 0) - hx.At(i, 0)
+		variance[i] = s.At(i, i)
+		measured[i] = H.At(i, i) != 0
+	}
+
+	kf.KalmanFilter.Update(z, R, H)
+
+	scale := kf.adaptive.observe(innovation, variance, measured)
+	Q := kf.GetQ()
+	for i := dimZ; i < kf.GetDimX(); i++ {
+		Q.Set(i, i, kf.qMult*scale)
+	}
+}
+
+// ProcessNoiseScale returns the current adaptive process noise scale (1.0 if disabled).
+func (kf *FilterPyKalmanFilter) ProcessNoiseScale() float64 {
+	return kf.adaptive.scale()
 }
 
 // GetStateVector returns the state vector (wrapper for GetState to satisfy Filter interface)
@@ -124,6 +217,33 @@ func (kf *FilterPyKalmanFilter) SetStateVector(x *mat.Dense) {
 	kf.SetState(x)
 }
 
+// PositionCovariance returns a copy of the position block P[:dimZ, :dimZ].
+func (kf *FilterPyKalmanFilter) PositionCovariance() *mat.Dense {
+	dimZ := kf.GetDimZ()
+	return mat.DenseCopyOf(kf.GetP().Slice(0, dimZ, 0, dimZ))
+}
+
+// Covariance returns a copy of the state covariance P.
+func (kf *FilterPyKalmanFilter) Covariance() *mat.Dense {
+	return mat.DenseCopyOf(kf.GetP())
+}
+
+// ProcessNoise returns a copy of the process noise Q.
+func (kf *FilterPyKalmanFilter) ProcessNoise() *mat.Dense {
+	return mat.DenseCopyOf(kf.GetQ())
+}
+
+// MeasurementNoise returns a copy of the default measurement noise R.
+func (kf *FilterPyKalmanFilter) MeasurementNoise() *mat.Dense {
+	return mat.DenseCopyOf(kf.GetR())
+}
+
+// String returns a debug dump of the filter state and matrices.
+func (kf *FilterPyKalmanFilter) String() string {
+	return formatFilter("FilterPyKalmanFilter", kf.GetDimX(), kf.GetDimZ(),
+		kf.GetState(), kf.Covariance(), kf.ProcessNoise(), kf.MeasurementNoise())
+}
+
 // =============================================================================
 // NoFilter - Simple No-Op Filter
 // =============================================================================
@@ -229,15 +349,43 @@ type OptimizedKalmanFilter struct {
 	VelVariance      []float64
 	qQ               float64
 	defaultR         []float64
+
+	qMult    float64    // Base process noise (qQ before adaptive scaling)
+	adaptive *adaptiveQ // Adaptive process noise state (nil = disabled)
 }
 
-// OptimizedKalmanFilterFactory creates OptimizedKalmanFilter instances
+// OptimizedKalmanFilterFactory creates OptimizedKalmanFilter instances.
+//
+// Every coordinate is filtered independently with a 2x2 (position, velocity)
+// covariance. In filterpy terms, for each coordinate i:
+//
+//	R[i, i]           = RMult
+//	Q[dimZ+i, dimZ+i] = QMult (position noise is zero)
+//	P[i, i]           = PosVariance
+//	P[i, dimZ+i]      = PosVelCovariance (and symmetric)
+//	P[dimZ+i, dimZ+i] = VelVariance
+//
+// InitialCovariance, ProcessNoise and MeasurementNoise build these matrices
+// for a given dimZ so they can be compared with a FilterPyKalmanFilterFactory.
 type OptimizedKalmanFilterFactory struct {
-	RMult            float64
-	QMult            float64
-	PosVariance      float64
-	PosVelCovariance float64
-	VelVariance      float64
+	RMult            float64 // Measurement noise variance (R diagonal)
+	QMult            float64 // Process noise added to the velocity variance per step
+	PosVariance      float64 // Initial position variance
+	PosVelCovariance float64 // Initial position/velocity covariance
+	VelVariance      float64 // Initial velocity variance
+
+	// AdaptiveQ scales QMult with recent innovations.
+	// Default: nil (fixed process noise)
+	AdaptiveQ *AdaptiveQConfig
+
+	// Float32 creates OptimizedKalmanFilter32 filters, which store their state in
+	// float32 to halve the memory per track.
+	// Default: false
+	Float32 bool
+
+	// StateRepresentation selects how 2-point bounding boxes are modelled.
+	// Default: StateCorners
+	StateRepresentation StateRepresentation
 }
 
 // NewOptimizedKalmanFilterFactory creates a factory with default parameters
@@ -251,7 +399,36 @@ func NewOptimizedKalmanFilterFactory(rMult, qMult, posVar, posVelCov, velVar flo
 	}
 }
 
+// InitialCovariance returns the filterpy-equivalent initial covariance P for dimZ measurements.
+func (f *OptimizedKalmanFilterFactory) InitialCovariance(dimZ int) *mat.Dense {
+	return blockCovariance(dimZ, constantSlice(dimZ, f.PosVariance),
+		constantSlice(dimZ, f.PosVelCovariance), constantSlice(dimZ, f.VelVariance))
+}
+
+// ProcessNoise returns the filterpy-equivalent process noise Q for dimZ measurements.
+func (f *OptimizedKalmanFilterFactory) ProcessNoise(dimZ int) *mat.Dense {
+	return velocityProcessNoise(dimZ, f.QMult)
+}
+
+// MeasurementNoise returns the filterpy-equivalent measurement noise R for dimZ measurements.
+func (f *OptimizedKalmanFilterFactory) MeasurementNoise(dimZ int) *mat.Dense {
+	return diagonalMatrix(constantSlice(dimZ, f.RMult))
+}
+
+// CreateFilter creates a new OptimizedKalmanFilter (or OptimizedKalmanFilter32)
+// instance, wrapped in a CenterSizeFilter for bounding boxes with StateCenterSize.
 func (f *OptimizedKalmanFilterFactory) CreateFilter(initialDetection *mat.Dense) Filter {
+	if f.StateRepresentation == StateCenterSize && isBoxShape(initialDetection) {
+		return newCenterSizeFilter(f.createFilter(BoxToCenterSize(initialDetection)))
+	}
+	return f.createFilter(initialDetection)
+}
+
+func (f *OptimizedKalmanFilterFactory) createFilter(initialDetection *mat.Dense) InspectableFilter {
+	if f.Float32 {
+		return newOptimizedKalmanFilter32(f, initialDetection)
+	}
+
 	numPoints, dimPoints := initialDetection.Dims()
 	dimZ := numPoints * dimPoints
 	dimX := 2 * dimZ
@@ -265,6 +442,8 @@ func (f *OptimizedKalmanFilterFactory) CreateFilter(initialDetection *mat.Dense)
 		VelVariance:      make([]float64, dimZ),
 		qQ:               f.QMult,
 		defaultR:         make([]float64, dimZ),
+		qMult:            f.QMult,
+		adaptive:         newAdaptiveQ(f.AdaptiveQ),
 	}
 
 	// Initialize covariance vectors
@@ -370,6 +549,20 @@ func (okf *OptimizedKalmanFilter) Update(detectionPointsFlatten *mat.Dense, R, H
 			velVarPlusPosVelCovOverAddedVariances[i]*velVarPlusPosVelCovOverAddedVariances[i]*
 			addedVariances[i]
 	}
+
+	// Rescale process noise for the next step (addedVariances is the innovation variance)
+	if okf.adaptive != nil {
+		measured := make([]bool, okf.dimZ)
+		for i := 0; i < okf.dimZ; i++ {
+			measured[i] = diagonal[i] != 0
+		}
+		okf.qQ = okf.qMult * okf.adaptive.observe(error, addedVariances, measured)
+	}
+}
+
+// ProcessNoiseScale returns the current adaptive process noise scale (1.0 if disabled).
+func (okf *OptimizedKalmanFilter) ProcessNoiseScale() float64 {
+	return okf.adaptive.scale()
 }
 
 func (okf *OptimizedKalmanFilter) GetState() *mat.Dense {
@@ -388,6 +581,107 @@ func (okf *OptimizedKalmanFilter) SetStateVector(x *mat.Dense) {
 	okf.x.Copy(x)
 }
 
+// PositionCovariance returns the diagonal position covariance tracked by the
+// simplified filter (off-diagonal terms are not modelled and are zero).
+func (okf *OptimizedKalmanFilter) PositionCovariance() *mat.Dense {
+	cov := mat.NewDense(okf.dimZ, okf.dimZ, nil)
+	for i, variance := range okf.PosVariance {
+		cov.Set(i, i, variance)
+	}
+	return cov
+}
+
+// Covariance returns the full state covariance P implied by the per-coordinate
+// variance vectors (position, position/velocity and velocity blocks are diagonal).
+func (okf *OptimizedKalmanFilter) Covariance() *mat.Dense {
+	return blockCovariance(okf.dimZ, okf.PosVariance, okf.PosVelCovariance, okf.VelVariance)
+}
+
+// ProcessNoise returns the process noise Q (QMult on the velocity diagonal).
+func (okf *OptimizedKalmanFilter) ProcessNoise() *mat.Dense {
+	return velocityProcessNoise(okf.dimZ, okf.qQ)
+}
+
+// MeasurementNoise returns the default measurement noise R.
+func (okf *OptimizedKalmanFilter) MeasurementNoise() *mat.Dense {
+	return diagonalMatrix(okf.defaultR)
+}
+
+// String returns a debug dump of the filter state and matrices.
+func (okf *OptimizedKalmanFilter) String() string {
+	return formatFilter("OptimizedKalmanFilter", okf.dimX, okf.dimZ,
+		okf.x, okf.Covariance(), okf.ProcessNoise(), okf.MeasurementNoise())
+}
+
+// =============================================================================
+// Adaptive Process Noise
+// =============================================================================
+
+// AdaptiveQConfig configures innovation-based process noise scaling.
+//
+// After every update the normalized innovation squared (NIS, innovation^2 divided
+// by its predicted variance, averaged over measured coordinates) is smoothed with
+// an exponential moving average. Its expected value is 1 when the motion model
+// fits, so the process noise is multiplied by the smoothed NIS: it grows while an
+// object maneuvers and decays back while it moves steadily or stands still.
+type AdaptiveQConfig struct {
+	// Smoothing factor of the moving average (0 < Alpha <= 1, higher reacts faster).
+	// Default: 0.3
+	Alpha float64
+
+	// Bounds of the process noise scale.
+	// Default: 0.1 and 10.0
+	MinScale float64
+	MaxScale float64
+}
+
+// adaptiveQ holds the per-filter adaptive process noise state.
+type adaptiveQ struct {
+	config AdaptiveQConfig
+	nis    float64 // Smoothed normalized innovation squared
+}
+
+// newAdaptiveQ applies defaults and returns nil if config is nil.
+func newAdaptiveQ(config *AdaptiveQConfig) *adaptiveQ {
+	if config == nil {
+		return nil
+	}
+	c := *config
+	if c.Alpha <= 0 || c.Alpha > 1 {
+		c.Alpha = 0.3
+	}
+	if c.MinScale <= 0 {
+		c.MinScale = 0.1
+	}
+	if c.MaxScale <= 0 {
+		c.MaxScale = 10.0
+	}
+	return &adaptiveQ{config: c, nis: 1.0}
+}
+
+// observe folds one measurement into the moving average and returns the new scale.
+func (a *adaptiveQ) observe(innovation, variance []float64, measured []bool) float64 {
+	sum, count := 0.0, 0
+	for i := range innovation {
+		if measured[i] && variance[i] > 0 {
+			sum += innovation[i] * innovation[i] / variance[i]
+			count++
+		}
+	}
+	if count > 0 {
+		a.nis = (1-a.config.Alpha)*a.nis + a.config.Alpha*sum/float64(count)
+	}
+	return a.scale()
+}
+
+// scale returns the current process noise scale (1.0 for a nil receiver).
+func (a *adaptiveQ) scale() float64 {
+	if a == nil {
+		return 1.0
+	}
+	return math.Max(a.config.MinScale, math.Min(a.config.MaxScale, a.nis))
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
@@ -403,3 +697,53 @@ func flattenDetection(detection *mat.Dense) []float64 {
 	}
 	return flat
 }
+
+// constantSlice returns a slice of n copies of value.
+func constantSlice(n int, value float64) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = value
+	}
+	return s
+}
+
+// diagonalMatrix returns a dense matrix with the given diagonal.
+func diagonalMatrix(diagonal []float64) *mat.Dense {
+	m := mat.NewDense(len(diagonal), len(diagonal), nil)
+	for i, v := range diagonal {
+		m.Set(i, i, v)
+	}
+	return m
+}
+
+// blockCovariance builds P = [[diag(posVar), diag(posVelCov)], [diag(posVelCov), diag(velVar)]].
+func blockCovariance(dimZ int, posVar, posVelCov, velVar []float64) *mat.Dense {
+	P := mat.NewDense(2*dimZ, 2*dimZ, nil)
+	for i := 0; i < dimZ; i++ {
+		P.Set(i, i, posVar[i])
+		P.Set(i, dimZ+i, posVelCov[i])
+		P.Set(dimZ+i, i, posVelCov[i])
+		P.Set(dimZ+i, dimZ+i, velVar[i])
+	}
+	return P
+}
+
+// velocityProcessNoise builds Q with q on the velocity diagonal and zero elsewhere.
+func velocityProcessNoise(dimZ int, q float64) *mat.Dense {
+	Q := mat.NewDense(2*dimZ, 2*dimZ, nil)
+	for i := dimZ; i < 2*dimZ; i++ {
+		Q.Set(i, i, q)
+	}
+	return Q
+}
+
+// formatFilter renders a filter's state and matrices for debugging.
+func formatFilter(name string, dimX, dimZ int, x, P, Q, R *mat.Dense) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s(dimX=%d, dimZ=%d)\n", name, dimX, dimZ)
+	fmt.Fprintf(&b, "x^T = %v\n", mat.Formatted(x.T(), mat.Squeeze()))
+	fmt.Fprintf(&b, "P =\n%v\n", mat.Formatted(P, mat.Squeeze()))
+	fmt.Fprintf(&b, "Q =\n%v\n", mat.Formatted(Q, mat.Squeeze()))
+	fmt.Fprintf(&b, "R =\n%v", mat.Formatted(R, mat.Squeeze()))
+	return b.String()
+}
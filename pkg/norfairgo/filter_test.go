@@ -1,6 +1,7 @@
 package norfairgo
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/nmichlo/norfair-go/internal/testutil"
@@ -419,3 +420,171 @@ func TestFilters_MultiPoint(t *testing.T) {
 	testutil.AssertAlmostEqual(t, statePy.At(0, 0), 0.1, 0.1, "FilterPy point 1 x")
 	testutil.AssertAlmostEqual(t, stateOpt.At(0, 0), 0.1, 0.1, "Optimized point 1 x")
 }
+
+// =============================================================================
+// Position Covariance Tests
+// =============================================================================
+
+func TestFilters_PositionCovariance(t *testing.T) {
+	initialDetection := mat.NewDense(2, 2, []float64{
+		0.0, 0.0,
+		1.0, 1.0,
+	})
+
+	filterPy := NewFilterPyKalmanFilterFactory(4.0, 0.1, 10.0).CreateFilter(initialDetection)
+	optimized := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0).CreateFilter(initialDetection)
+
+	for name, filter := range map[string]Filter{"FilterPy": filterPy, "Optimized": optimized} {
+		covFilter, ok := filter.(CovarianceFilter)
+		if !ok {
+			t.Fatalf("%s filter should implement CovarianceFilter", name)
+		}
+		cov := covFilter.PositionCovariance()
+		if r, c := cov.Dims(); r != 4 || c != 4 {
+			t.Fatalf("%s: expected 4x4 position covariance, got %dx%d", name, r, c)
+		}
+		testutil.AssertAlmostEqual(t, cov.At(0, 0), 10.0, 1e-10, name+" initial position variance")
+
+		// A measurement must shrink the position uncertainty
+		filter.Update(mat.NewDense(4, 1, []float64{0, 0, 1, 1}), nil, nil)
+		if updated := covFilter.PositionCovariance().At(0, 0); updated >= 10.0 {
+			t.Errorf("%s: expected variance to shrink after update, got %f", name, updated)
+		}
+	}
+
+	if _, ok := Filter(&NoFilter{}).(CovarianceFilter); ok {
+		t.Error("NoFilter should not implement CovarianceFilter")
+	}
+}
+
+func TestFilters_Inspectable(t *testing.T) {
+	initialDetection := mat.NewDense(2, 2, []float64{
+		0.0, 0.0,
+		1.0, 1.0,
+	})
+
+	factory := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0)
+	filterPy := NewFilterPyKalmanFilterFactory(4.0, 0.1, 10.0).CreateFilter(initialDetection)
+	optimized := factory.CreateFilter(initialDetection)
+
+	// Equivalent parameters give equivalent initial P and R, and the same velocity noise
+	inspectables := map[string]InspectableFilter{}
+	for name, filter := range map[string]Filter{"FilterPy": filterPy, "Optimized": optimized} {
+		inspectable, ok := filter.(InspectableFilter)
+		if !ok {
+			t.Fatalf("%s filter should implement InspectableFilter", name)
+		}
+		inspectables[name] = inspectable
+
+		if r, c := inspectable.Covariance().Dims(); r != 8 || c != 8 {
+			t.Errorf("%s: expected 8x8 covariance, got %dx%d", name, r, c)
+		}
+		if r, c := inspectable.MeasurementNoise().Dims(); r != 4 || c != 4 {
+			t.Errorf("%s: expected 4x4 measurement noise, got %dx%d", name, r, c)
+		}
+		testutil.AssertAlmostEqual(t, inspectable.ProcessNoise().At(4, 4), 0.1, 1e-10, name+" velocity process noise")
+		if !strings.HasPrefix(inspectable.String(), name) {
+			t.Errorf("%s: unexpected String() output %q", name, inspectable.String())
+		}
+	}
+	if !mat.EqualApprox(inspectables["FilterPy"].Covariance(), inspectables["Optimized"].Covariance(), 1e-10) {
+		t.Error("Expected equal initial covariance for equivalent parameters")
+	}
+	if !mat.EqualApprox(inspectables["FilterPy"].MeasurementNoise(), inspectables["Optimized"].MeasurementNoise(), 1e-10) {
+		t.Error("Expected equal measurement noise for equivalent parameters")
+	}
+
+	// Factory accessors describe the filters they create
+	if !mat.EqualApprox(factory.InitialCovariance(4), inspectables["Optimized"].Covariance(), 1e-10) {
+		t.Error("Expected factory InitialCovariance to match a new filter")
+	}
+	if !mat.EqualApprox(factory.ProcessNoise(4), inspectables["Optimized"].ProcessNoise(), 1e-10) ||
+		!mat.EqualApprox(factory.MeasurementNoise(4), inspectables["Optimized"].MeasurementNoise(), 1e-10) {
+		t.Error("Expected factory noise matrices to match a new filter")
+	}
+
+	// Returned matrices are copies
+	inspectables["FilterPy"].Covariance().Set(0, 0, -1)
+	testutil.AssertAlmostEqual(t, inspectables["FilterPy"].Covariance().At(0, 0), 10.0, 1e-10, "covariance copy")
+}
+
+func TestFilters_AdaptiveQ(t *testing.T) {
+	initialDetection := mat.NewDense(1, 2, []float64{0, 0})
+
+	filterPyFactory := NewFilterPyKalmanFilterFactory(4.0, 0.1, 10.0)
+	filterPyFactory.AdaptiveQ = &AdaptiveQConfig{}
+	optimizedFactory := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0)
+	optimizedFactory.AdaptiveQ = &AdaptiveQConfig{}
+
+	type scaledFilter interface {
+		InspectableFilter
+		ProcessNoiseScale() float64
+	}
+
+	for name, factory := range map[string]FilterFactory{"FilterPy": filterPyFactory, "Optimized": optimizedFactory} {
+		filter := factory.CreateFilter(initialDetection).(scaledFilter)
+		if filter.ProcessNoiseScale() != 1.0 {
+			t.Errorf("%s: expected initial scale 1.0, got %f", name, filter.ProcessNoiseScale())
+		}
+
+		// Stationary object: innovations are tiny, so the process noise shrinks
+		for i := 0; i < 20; i++ {
+			filter.Predict()
+			filter.Update(mat.NewDense(2, 1, []float64{0, 0}), nil, nil)
+		}
+		stableScale := filter.ProcessNoiseScale()
+		if stableScale >= 1.0 {
+			t.Errorf("%s: expected scale < 1 for a stationary object, got %f", name, stableScale)
+		}
+		testutil.AssertAlmostEqual(t, filter.ProcessNoise().At(2, 2), 0.1*stableScale, 1e-10, name+" scaled process noise")
+
+		// Sudden fast motion: large innovations raise the process noise
+		for i := 1; i <= 5; i++ {
+			filter.Predict()
+			position := float64(i * 50)
+			filter.Update(mat.NewDense(2, 1, []float64{position, position}), nil, nil)
+		}
+		if filter.ProcessNoiseScale() <= 1.0 {
+			t.Errorf("%s: expected scale > 1 while maneuvering, got %f", name, filter.ProcessNoiseScale())
+		}
+		if filter.ProcessNoiseScale() > 10.0 {
+			t.Errorf("%s: expected scale clamped to 10, got %f", name, filter.ProcessNoiseScale())
+		}
+	}
+
+	// Disabled by default
+	fixed := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0).CreateFilter(initialDetection).(*OptimizedKalmanFilter)
+	fixed.Update(mat.NewDense(2, 1, []float64{100, 100}), nil, nil)
+	if fixed.ProcessNoiseScale() != 1.0 {
+		t.Errorf("Expected scale 1.0 without AdaptiveQ, got %f", fixed.ProcessNoiseScale())
+	}
+}
+
+func TestTrackedObject_PointCovariance(t *testing.T) {
+	initialDetection := mat.NewDense(2, 2, []float64{
+		0.0, 0.0,
+		1.0, 1.0,
+	})
+	filter := NewFilterPyKalmanFilterFactory(4.0, 0.1, 10.0).CreateFilter(initialDetection)
+	kf := filter.(*FilterPyKalmanFilter)
+	kf.GetP().Set(2, 3, 0.5) // Covariance between x and y of the second point
+	kf.GetP().Set(3, 2, 0.5)
+
+	obj := &TrackedObject{Filter: filter, NumPoints: 2, DimPoints: 2, DimZ: 4}
+
+	cov, err := obj.PointCovariance(1)
+	if err != nil {
+		t.Fatalf("PointCovariance failed: %v", err)
+	}
+	expected := mat.NewDense(2, 2, []float64{10.0, 0.5, 0.5, 10.0})
+	testutil.AssertMatrixAlmostEqual(t, cov, expected, 1e-10, "point 1 covariance")
+
+	if _, err := obj.PointCovariance(2); err == nil {
+		t.Error("Expected error for out of range point index")
+	}
+
+	noFilterObj := &TrackedObject{Filter: &NoFilter{}, NumPoints: 2, DimPoints: 2, DimZ: 4}
+	if _, err := noFilterObj.PointCovariance(0); err == nil {
+		t.Error("Expected error for filter without covariance")
+	}
+}
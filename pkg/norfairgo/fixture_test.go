@@ -56,7 +56,7 @@ type TrackedObjectJSON struct {
 	ID             *int        `json:"id"`
 	InitializingID int         `json:"initializing_id"`
 	Estimate       [][]float64 `json:"estimate"`
-	Age            int         `json:"age"`
+	Age            int64       `json:"age"`
 	HitCounter     int         `json:"hit_counter"`
 	IsInitializing bool        `json:"is_initializing"`
 	ReidHitCounter *int        `json:"reid_hit_counter,omitempty"`
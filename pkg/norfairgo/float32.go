@@ -0,0 +1,283 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Float32 Mode - Half-Size Filter State and Distances
+// =============================================================================
+//
+// For thousands of simultaneous tracks on memory- or cache-constrained devices,
+// OptimizedKalmanFilterFactory.Float32 stores the filter state in float32, and the
+// "iou32" and "euclidean32" distances compute in float32. Results agree with the
+// float64 versions to roughly 1e-6 relative error; the Filter and Distance
+// interfaces still exchange *mat.Dense values.
+
+// OptimizedKalmanFilter32 is OptimizedKalmanFilter with float32 state and covariance
+// vectors, using half the memory per track. Create it with an
+// OptimizedKalmanFilterFactory whose Float32 field is set.
+//
+// GetStateVector returns a float64 copy of the state, so changes to it only take
+// effect through SetStateVector (as TrackedObject does).
+type OptimizedKalmanFilter32 struct {
+	dimX int
+	dimZ int
+	x    []float32
+
+	// Simplified covariance representation (vectors instead of matrices)
+	PosVariance      []float32
+	PosVelCovariance []float32
+	VelVariance      []float32
+	qQ               float32
+	defaultR         []float32
+
+	qMult    float32    // Base process noise (qQ before adaptive scaling)
+	adaptive *adaptiveQ // Adaptive process noise state (nil = disabled)
+}
+
+// newOptimizedKalmanFilter32 creates the float32 filter for a factory.
+func newOptimizedKalmanFilter32(f *OptimizedKalmanFilterFactory, initialDetection *mat.Dense) *OptimizedKalmanFilter32 {
+	numPoints, dimPoints := initialDetection.Dims()
+	dimZ := numPoints * dimPoints
+
+	filter := &OptimizedKalmanFilter32{
+		dimX:             2 * dimZ,
+		dimZ:             dimZ,
+		x:                make([]float32, 2*dimZ),
+		PosVariance:      constantSlice32(dimZ, f.PosVariance),
+		PosVelCovariance: constantSlice32(dimZ, f.PosVelCovariance),
+		VelVariance:      constantSlice32(dimZ, f.VelVariance),
+		qQ:               float32(f.QMult),
+		defaultR:         constantSlice32(dimZ, f.RMult),
+		qMult:            float32(f.QMult),
+		adaptive:         newAdaptiveQ(f.AdaptiveQ),
+	}
+	for i, v := range flattenDetection(initialDetection) {
+		filter.x[i] = float32(v)
+	}
+	return filter
+}
+
+func (okf *OptimizedKalmanFilter32) Predict() {
+	// x[:dimZ] += x[dimZ:]
+	for i := 0; i < okf.dimZ; i++ {
+		okf.x[i] += okf.x[okf.dimZ+i]
+	}
+}
+
+// Update performs the same per-coordinate update as OptimizedKalmanFilter.Update,
+// in float32 and without allocating (unless AdaptiveQ is enabled).
+func (okf *OptimizedKalmanFilter32) Update(detectionPointsFlatten *mat.Dense, R, H *mat.Dense) {
+	var innovations, variances []float64
+	var measured []bool
+	if okf.adaptive != nil {
+		innovations = make([]float64, okf.dimZ)
+		variances = make([]float64, okf.dimZ)
+		measured = make([]bool, okf.dimZ)
+	}
+
+	for i := 0; i < okf.dimZ; i++ {
+		diagonal := float32(1)
+		if H != nil {
+			diagonal = float32(H.At(i, i))
+		}
+		kalmanR := okf.defaultR[i]
+		if R != nil {
+			kalmanR = float32(R.At(i, i))
+		}
+
+		// Innovation and Kalman gains
+		err := (float32(detectionPointsFlatten.At(i, 0)) - okf.x[i]) * diagonal
+		velVarPlusPosVelCov := okf.PosVelCovariance[i] + okf.VelVariance[i]
+		addedVariances := okf.PosVariance[i] + okf.PosVelCovariance[i] + velVarPlusPosVelCov + okf.qQ + kalmanR
+		kalmanROverAddedVariances := kalmanR / addedVariances
+		velVarPlusPosVelCovOverAddedVariances := velVarPlusPosVelCov / addedVariances
+		addedVariancesOrKalmanR := addedVariances*(1-diagonal) + kalmanR*diagonal
+
+		// Update state
+		okf.x[i] += diagonal * (1 - kalmanROverAddedVariances) * err
+		okf.x[okf.dimZ+i] += diagonal * velVarPlusPosVelCovOverAddedVariances * err
+
+		// Update covariance vectors
+		okf.PosVariance[i] = (1 - kalmanROverAddedVariances) * addedVariancesOrKalmanR
+		okf.PosVelCovariance[i] = velVarPlusPosVelCovOverAddedVariances * addedVariancesOrKalmanR
+		okf.VelVariance[i] += okf.qQ - diagonal*
+			velVarPlusPosVelCovOverAddedVariances*velVarPlusPosVelCovOverAddedVariances*
+			addedVariances
+
+		if okf.adaptive != nil {
+			innovations[i] = float64(err)
+			variances[i] = float64(addedVariances)
+			measured[i] = diagonal != 0
+		}
+	}
+
+	// Rescale process noise for the next step
+	if okf.adaptive != nil {
+		okf.qQ = okf.qMult * float32(okf.adaptive.observe(innovations, variances, measured))
+	}
+}
+
+// ProcessNoiseScale returns the current adaptive process noise scale (1.0 if disabled).
+func (okf *OptimizedKalmanFilter32) ProcessNoiseScale() float64 {
+	return okf.adaptive.scale()
+}
+
+// GetState returns a float64 copy of the state vector.
+func (okf *OptimizedKalmanFilter32) GetState() *mat.Dense {
+	return okf.GetStateVector()
+}
+
+func (okf *OptimizedKalmanFilter32) GetDimZ() int {
+	return okf.dimZ
+}
+
+// GetStateVector returns a float64 copy of the state vector.
+func (okf *OptimizedKalmanFilter32) GetStateVector() *mat.Dense {
+	return mat.NewDense(okf.dimX, 1, toFloat64s(okf.x))
+}
+
+func (okf *OptimizedKalmanFilter32) SetStateVector(x *mat.Dense) {
+	for i := range okf.x {
+		okf.x[i] = float32(x.At(i, 0))
+	}
+}
+
+// PositionCovariance returns the diagonal position covariance (see OptimizedKalmanFilter).
+func (okf *OptimizedKalmanFilter32) PositionCovariance() *mat.Dense {
+	return diagonalMatrix(toFloat64s(okf.PosVariance))
+}
+
+// Covariance returns the full state covariance P implied by the variance vectors.
+func (okf *OptimizedKalmanFilter32) Covariance() *mat.Dense {
+	return blockCovariance(okf.dimZ, toFloat64s(okf.PosVariance),
+		toFloat64s(okf.PosVelCovariance), toFloat64s(okf.VelVariance))
+}
+
+// ProcessNoise returns the process noise Q (QMult on the velocity diagonal).
+func (okf *OptimizedKalmanFilter32) ProcessNoise() *mat.Dense {
+	return velocityProcessNoise(okf.dimZ, float64(okf.qQ))
+}
+
+// MeasurementNoise returns the default measurement noise R.
+func (okf *OptimizedKalmanFilter32) MeasurementNoise() *mat.Dense {
+	return diagonalMatrix(toFloat64s(okf.defaultR))
+}
+
+// String returns a debug dump of the filter state and matrices.
+func (okf *OptimizedKalmanFilter32) String() string {
+	return formatFilter("OptimizedKalmanFilter32", okf.dimX, okf.dimZ,
+		okf.GetStateVector(), okf.Covariance(), okf.ProcessNoise(), okf.MeasurementNoise())
+}
+
+// =============================================================================
+// Float32 Distances
+// =============================================================================
+
+// IoU32 is IoU computed in float32 (registered as "iou32").
+// Input format: [x_min, y_min, x_max, y_max]
+func IoU32(candidates, objects *mat.Dense) *mat.Dense {
+	validateBboxes(candidates)
+	validateBboxes(objects)
+
+	cand := toFloat32Rows(candidates)
+	obj := toFloat32Rows(objects)
+	candRows, objRows := len(cand)/4, len(obj)/4
+
+	result := mat.NewDense(candRows, objRows, nil)
+	raw := result.RawMatrix()
+	for i := 0; i < candRows; i++ {
+		c := cand[4*i : 4*i+4]
+		candArea := (c[2] - c[0]) * (c[3] - c[1])
+		for j := 0; j < objRows; j++ {
+			o := obj[4*j : 4*j+4]
+			width := max32(0, min32(c[2], o[2])-max32(c[0], o[0]))
+			height := max32(0, min32(c[3], o[3])-max32(c[1], o[1]))
+			intersection := width * height
+			union := candArea + (o[2]-o[0])*(o[3]-o[1]) - intersection
+			raw.Data[i*raw.Stride+j] = float64(1 - intersection/union)
+		}
+	}
+	return result
+}
+
+// Euclidean32 is the scipy "euclidean" distance between flattened points computed
+// in float32 (registered as "euclidean32").
+func Euclidean32(candidates, objects *mat.Dense) *mat.Dense {
+	candRows, cols := candidates.Dims()
+	objRows, objCols := objects.Dims()
+	if cols != objCols {
+		panic(fmt.Sprintf("candidates and objects must have the same number of columns, got %d and %d", cols, objCols))
+	}
+
+	cand := toFloat32Rows(candidates)
+	obj := toFloat32Rows(objects)
+
+	result := mat.NewDense(candRows, objRows, nil)
+	raw := result.RawMatrix()
+	for i := 0; i < candRows; i++ {
+		c := cand[cols*i : cols*i+cols]
+		for j := 0; j < objRows; j++ {
+			o := obj[cols*j : cols*j+cols]
+			var sum float32
+			for k := range c {
+				diff := c[k] - o[k]
+				sum += diff * diff
+			}
+			raw.Data[i*raw.Stride+j] = math.Sqrt(float64(sum))
+		}
+	}
+	return result
+}
+
+// =============================================================================
+// Helper Functions
+// =============================================================================
+
+// toFloat32Rows converts a matrix to a contiguous row-major float32 slice.
+func toFloat32Rows(m *mat.Dense) []float32 {
+	rows, cols := m.Dims()
+	out := make([]float32, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j, v := range m.RawRowView(i) {
+			out[i*cols+j] = float32(v)
+		}
+	}
+	return out
+}
+
+// toFloat64s converts a float32 slice to a new float64 slice.
+func toFloat64s(values []float32) []float64 {
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = float64(v)
+	}
+	return out
+}
+
+// constantSlice32 returns a slice of n copies of value as float32.
+func constantSlice32(n int, value float64) []float32 {
+	s := make([]float32, n)
+	for i := range s {
+		s[i] = float32(value)
+	}
+	return s
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
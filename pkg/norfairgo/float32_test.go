@@ -0,0 +1,140 @@
+package norfairgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Float32 Mode Tests
+// =============================================================================
+
+// assertRelativelyClose checks |got - want| <= tol * max(1, |want|) elementwise.
+func assertRelativelyClose(t *testing.T, got, want *mat.Dense, tol float64, msg string) {
+	t.Helper()
+	rows, cols := want.Dims()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			w, g := want.At(i, j), got.At(i, j)
+			if math.Abs(g-w) > tol*math.Max(1, math.Abs(w)) {
+				t.Fatalf("%s[%d,%d]: got %v, want %v", msg, i, j, g, w)
+			}
+		}
+	}
+}
+
+func TestOptimizedKalmanFilter32_MatchesFloat64(t *testing.T) {
+	for _, adaptive := range []bool{false, true} {
+		factory64 := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0)
+		factory32 := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0)
+		factory32.Float32 = true
+		if adaptive {
+			factory64.AdaptiveQ = &AdaptiveQConfig{}
+			factory32.AdaptiveQ = &AdaptiveQConfig{}
+		}
+
+		initial := mat.NewDense(2, 2, []float64{100, 200, 150, 260})
+		filter64 := factory64.CreateFilter(initial).(*OptimizedKalmanFilter)
+		filter32, ok := factory32.CreateFilter(initial).(*OptimizedKalmanFilter32)
+		if !ok {
+			t.Fatalf("Expected OptimizedKalmanFilter32 with Float32 set")
+		}
+
+		// Noisy constant-velocity motion with occasional partial measurements
+		rng := rand.New(rand.NewSource(42))
+		H := mat.NewDense(4, 4, nil)
+		for step := 1; step <= 200; step++ {
+			measurement := mat.NewDense(4, 1, []float64{
+				100 + 2*float64(step) + rng.NormFloat64(),
+				200 + 1*float64(step) + rng.NormFloat64(),
+				150 + 2*float64(step) + rng.NormFloat64(),
+				260 + 1*float64(step) + rng.NormFloat64(),
+			})
+			var h *mat.Dense
+			if step%7 == 0 {
+				H.Zero()
+				H.Set(0, 0, 1)
+				H.Set(1, 1, 1)
+				h = H
+			}
+			filter64.Predict()
+			filter32.Predict()
+			filter64.Update(measurement, nil, h)
+			filter32.Update(measurement, nil, h)
+		}
+
+		assertRelativelyClose(t, filter32.GetState(), filter64.GetState(), 1e-4, "state")
+		assertRelativelyClose(t, filter32.Covariance(), filter64.Covariance(), 1e-4, "covariance")
+		testutil.AssertAlmostEqual(t, filter32.ProcessNoiseScale(), filter64.ProcessNoiseScale(), 1e-4, "process noise scale")
+	}
+}
+
+func TestOptimizedKalmanFilter32_SetStateVector(t *testing.T) {
+	factory := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0)
+	factory.Float32 = true
+	filter := factory.CreateFilter(mat.NewDense(1, 2, []float64{1, 2}))
+
+	// GetStateVector is a copy; changes apply through SetStateVector
+	x := filter.GetStateVector()
+	x.Set(2, 0, 5)
+	testutil.AssertAlmostEqual(t, filter.GetStateVector().At(2, 0), 0.0, 1e-10, "copy")
+	filter.SetStateVector(x)
+	filter.Predict()
+	testutil.AssertAlmostEqual(t, filter.GetState().At(0, 0), 6.0, 1e-6, "predicted x")
+	testutil.AssertAlmostEqual(t, filter.GetState().At(1, 0), 2.0, 1e-6, "predicted y")
+}
+
+func TestFloat32Distances_MatchFloat64(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	randomBoxes := func(n int) *mat.Dense {
+		boxes := mat.NewDense(n, 4, nil)
+		for i := 0; i < n; i++ {
+			x, y := rng.Float64()*1000, rng.Float64()*1000
+			boxes.SetRow(i, []float64{x, y, x + 10 + rng.Float64()*200, y + 10 + rng.Float64()*200})
+		}
+		return boxes
+	}
+	candidates, objects := randomBoxes(30), randomBoxes(40)
+
+	assertRelativelyClose(t, IoU32(candidates, objects), IoU(candidates, objects), 1e-5, "iou32")
+
+	euclidean := GetDistanceByName("euclidean")
+	euclidean32 := GetDistanceByName("euclidean32")
+	want := euclidean.(*ScipyDistance).distanceFunction(candidates, objects)
+	got := euclidean32.(*VectorizedDistance).distanceFunction(candidates, objects)
+	assertRelativelyClose(t, got, want, 1e-5, "euclidean32")
+}
+
+func TestTracker_Float32Filter(t *testing.T) {
+	factory := NewOptimizedKalmanFilterFactory(4.0, 0.1, 10.0, 0.0, 1.0)
+	factory.Float32 = true
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean32"),
+		DistanceThreshold:   20,
+		HitCounterMax:       5,
+		InitializationDelay: 0,
+		FilterFactory:       factory,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var objects []*TrackedObject
+	for frame := 0; frame < 20; frame++ {
+		x := 10 + 3*float64(frame)
+		objects = tracker.Update([]*Detection{newPointDetection(t, x, 50)}, 1, nil)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 tracked object, got %d", len(objects))
+	}
+	estimate, err := objects[0].GetEstimate(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertAlmostEqual(t, estimate.At(0, 0), 10+3*19, 0.5, "estimate x")
+	testutil.AssertAlmostEqual(t, objects[0].EstimateVelocity().At(0, 0), 3, 0.5, "velocity x")
+}
@@ -0,0 +1,9 @@
+//go:build !nogocv
+
+package norfairgo
+
+import "gocv.io/x/gocv"
+
+// Frame is the image type passed to TrackerConfig.EmbeddingFn and UpdateWithFrame.
+// It is gocv.Mat by default, and image.Image when building with the nogocv tag.
+type Frame = gocv.Mat
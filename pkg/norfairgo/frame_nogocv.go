@@ -0,0 +1,9 @@
+//go:build nogocv
+
+package norfairgo
+
+import "image"
+
+// Frame is the image type passed to TrackerConfig.EmbeddingFn and UpdateWithFrame.
+// It is image.Image when building with the nogocv tag, and gocv.Mat by default.
+type Frame = image.Image
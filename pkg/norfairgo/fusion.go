@@ -0,0 +1,156 @@
+package norfairgo
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Detection Fusion - Deduplicating Multiple Detector Sources
+// =============================================================================
+
+// DetectionSource is the output of one detector for a frame.
+type DetectionSource struct {
+	Name       string       // Source name (informational)
+	Priority   int          // Higher priority sources win duplicates
+	Detections []*Detection // Detections of this source
+}
+
+// FusionConfig configures FuseDetections.
+type FusionConfig struct {
+	// Minimum IoU between the bounding boxes of two detections' points for them
+	// to be considered duplicates.
+	// Default: 0.5
+	IoUThreshold float64
+
+	// Minimum cosine similarity between embeddings for two detections to be
+	// considered duplicates (only when both have embeddings), regardless of overlap.
+	// Default: 0.0 (embeddings are not used)
+	EmbeddingThreshold float64
+}
+
+// FuseDetections merges the detections of several sources for one frame,
+// removing duplicates so each object is passed to Tracker.Update only once.
+//
+// Sources are processed from highest to lowest priority (ties keep input order).
+// A detection is dropped if it duplicates a detection already kept from another
+// source: same label and class ID, and either IoU >= IoUThreshold or embedding
+// similarity >= EmbeddingThreshold. Detections of the same source are never merged
+// (that is the detector's NMS). A kept detection without an embedding inherits the
+// embedding of the first duplicate that has one.
+//
+// Parameters:
+//   - sources: Detector outputs for the current frame
+//   - config: Fusion thresholds (nil = defaults)
+//
+// Returns: Deduplicated detections, in priority order
+func FuseDetections(sources []DetectionSource, config *FusionConfig) []*Detection {
+	iouThreshold := 0.5
+	embeddingThreshold := 0.0
+	if config != nil {
+		if config.IoUThreshold > 0 {
+			iouThreshold = config.IoUThreshold
+		}
+		embeddingThreshold = config.EmbeddingThreshold
+	}
+
+	ordered := make([]int, len(sources))
+	for i := range ordered {
+		ordered[i] = i
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return sources[ordered[i]].Priority > sources[ordered[j]].Priority
+	})
+
+	type keptDetection struct {
+		detection *Detection
+		source    int
+		box       [4]float64
+	}
+	var kept []keptDetection
+
+	for _, sourceIdx := range ordered {
+		for _, det := range sources[sourceIdx].Detections {
+			box := pointsBoundingBox(det)
+			duplicate := false
+			for k := range kept {
+				other := &kept[k]
+				if other.source == sourceIdx ||
+					newLabelKey(det.Label, det.ClassID) != newLabelKey(other.detection.Label, other.detection.ClassID) {
+					continue
+				}
+				sameObject := boxIoU(box, other.box) >= iouThreshold
+				if !sameObject && embeddingThreshold > 0 && det.Embedding != nil && other.detection.Embedding != nil {
+					sameObject = cosineSimilarity(det.Embedding, other.detection.Embedding) >= embeddingThreshold
+				}
+				if sameObject {
+					if other.detection.Embedding == nil && det.Embedding != nil {
+						other.detection.Embedding = det.Embedding
+					}
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				kept = append(kept, keptDetection{detection: det, source: sourceIdx, box: box})
+			}
+		}
+	}
+
+	fused := make([]*Detection, len(kept))
+	for i, k := range kept {
+		fused[i] = k.detection
+	}
+	return fused
+}
+
+// pointsBoundingBox returns [x_min, y_min, x_max, y_max] of a detection's points.
+func pointsBoundingBox(det *Detection) [4]float64 {
+	return boundingBox(det.Points)
+}
+
+// boundingBox returns [x_min, y_min, x_max, y_max] of a points matrix.
+func boundingBox(points mat.Matrix) [4]float64 {
+	box := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	rows, _ := points.Dims()
+	for i := 0; i < rows; i++ {
+		x, y := points.At(i, 0), points.At(i, 1)
+		box[0] = math.Min(box[0], x)
+		box[1] = math.Min(box[1], y)
+		box[2] = math.Max(box[2], x)
+		box[3] = math.Max(box[3], y)
+	}
+	return box
+}
+
+// boxIoU returns the intersection over union of two [x_min, y_min, x_max, y_max] boxes.
+func boxIoU(a, b [4]float64) float64 {
+	intersectionW := math.Min(a[2], b[2]) - math.Max(a[0], b[0])
+	intersectionH := math.Min(a[3], b[3]) - math.Max(a[1], b[1])
+	if intersectionW <= 0 || intersectionH <= 0 {
+		return 0
+	}
+	intersection := intersectionW * intersectionH
+	union := (a[2]-a[0])*(a[3]-a[1]) + (b[2]-b[0])*(b[3]-b[1]) - intersection
+	return intersection / union
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors (0 if either is zero
+// or their lengths differ).
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,93 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Detection Fusion Tests
+// =============================================================================
+
+func newBoxDetection(t *testing.T, x1, y1, x2, y2 float64, config *DetectionConfig) *Detection {
+	t.Helper()
+	det, err := NewDetection(mat.NewDense(2, 2, []float64{x1, y1, x2, y2}), config)
+	if err != nil {
+		t.Fatalf("Failed to create detection: %v", err)
+	}
+	return det
+}
+
+func TestFuseDetections_PriorityAndOverlap(t *testing.T) {
+	primaryBox := newBoxDetection(t, 0, 0, 100, 100, nil)
+	primaryOther := newBoxDetection(t, 500, 500, 600, 600, nil)
+	duplicate := newBoxDetection(t, 5, 5, 105, 105, nil) // IoU ~0.82 with primaryBox
+	distinct := newBoxDetection(t, 200, 200, 300, 300, nil)
+	sameSourceOverlap := newBoxDetection(t, 2, 2, 102, 102, nil)
+
+	fused := FuseDetections([]DetectionSource{
+		{Name: "secondary", Priority: 0, Detections: []*Detection{duplicate, distinct}},
+		{Name: "primary", Priority: 1, Detections: []*Detection{primaryBox, primaryOther, sameSourceOverlap}},
+	}, nil)
+
+	expected := []*Detection{primaryBox, primaryOther, sameSourceOverlap, distinct}
+	if len(fused) != len(expected) {
+		t.Fatalf("Expected %d fused detections, got %d", len(expected), len(fused))
+	}
+	for i := range expected {
+		if fused[i] != expected[i] {
+			t.Errorf("Fused detection %d: unexpected detection", i)
+		}
+	}
+
+	// A stricter threshold keeps both
+	strict := FuseDetections([]DetectionSource{
+		{Priority: 1, Detections: []*Detection{primaryBox}},
+		{Priority: 0, Detections: []*Detection{duplicate}},
+	}, &FusionConfig{IoUThreshold: 0.9})
+	if len(strict) != 2 {
+		t.Errorf("Expected no merge above IoU threshold, got %d detections", len(strict))
+	}
+}
+
+func TestFuseDetections_LabelsAndEmbeddings(t *testing.T) {
+	person := newBoxDetection(t, 0, 0, 100, 100, &DetectionConfig{Label: StringPtr("person")})
+	face := newBoxDetection(t, 0, 0, 100, 100, &DetectionConfig{Label: StringPtr("face")})
+
+	fused := FuseDetections([]DetectionSource{
+		{Priority: 1, Detections: []*Detection{person}},
+		{Priority: 0, Detections: []*Detection{face}},
+	}, nil)
+	if len(fused) != 2 {
+		t.Errorf("Expected different labels never to merge, got %d detections", len(fused))
+	}
+
+	// Non-overlapping boxes with matching embeddings merge when enabled
+	a := newBoxDetection(t, 0, 0, 10, 10, nil)
+	b := newBoxDetection(t, 50, 50, 60, 60, &DetectionConfig{Embedding: []float64{1, 0, 1}})
+	sources := []DetectionSource{
+		{Priority: 1, Detections: []*Detection{a}},
+		{Priority: 0, Detections: []*Detection{b}},
+	}
+	if len(FuseDetections(sources, nil)) != 2 {
+		t.Error("Expected embeddings to be ignored by default")
+	}
+
+	a.Embedding = []float64{1, 0, 0.9}
+	fused = FuseDetections(sources, &FusionConfig{EmbeddingThreshold: 0.95})
+	if len(fused) != 1 || fused[0] != a {
+		t.Fatalf("Expected embedding duplicate to merge into the priority detection, got %d", len(fused))
+	}
+
+	// Missing embedding is inherited from the duplicate
+	c := newBoxDetection(t, 0, 0, 100, 100, nil)
+	d := newBoxDetection(t, 0, 0, 100, 100, &DetectionConfig{Embedding: []float64{0.5, 0.5}})
+	fused = FuseDetections([]DetectionSource{
+		{Priority: 1, Detections: []*Detection{c}},
+		{Priority: 0, Detections: []*Detection{d}},
+	}, nil)
+	if len(fused) != 1 || len(fused[0].Embedding) != 2 {
+		t.Errorf("Expected kept detection to inherit the duplicate's embedding")
+	}
+}
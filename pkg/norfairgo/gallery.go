@@ -0,0 +1,108 @@
+package norfairgo
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Embedding Gallery - Diverse Per-Track Appearances for ReID
+// =============================================================================
+
+// addToGallery adds an embedding to the object's gallery. When the gallery exceeds
+// TrackerConfig.EmbeddingGallerySize, the most redundant embedding (highest cosine
+// similarity to another one, the older one on ties) is dropped.
+func (to *TrackedObject) addToGallery(embedding []float64) {
+	size := to.config.EmbeddingGallerySize
+	if size <= 0 || embedding == nil {
+		return
+	}
+
+	to.EmbeddingGallery = append(to.EmbeddingGallery, embedding)
+	if len(to.EmbeddingGallery) <= size {
+		return
+	}
+
+	redundant, maxSimilarity := 0, math.Inf(-1)
+	for i, a := range to.EmbeddingGallery {
+		for j, b := range to.EmbeddingGallery {
+			if i == j {
+				continue
+			}
+			if similarity := cosineSimilarity(a, b); similarity > maxSimilarity {
+				redundant, maxSimilarity = i, similarity
+			}
+		}
+	}
+	to.EmbeddingGallery = append(to.EmbeddingGallery[:redundant], to.EmbeddingGallery[redundant+1:]...)
+}
+
+// GalleryDistance is a ReID distance comparing appearances against the objects'
+// embedding galleries (see TrackerConfig.EmbeddingGallerySize).
+//
+// The distance is the smallest cosine distance (1 - cosine similarity) between the
+// candidate's embeddings (a detection's Embedding, or a tracked object's gallery) and
+// the object's gallery, so a match with any past appearance counts, e.g. after a long
+// occlusion that changed the pose. Pairs with different labels or missing embeddings
+// are never matched.
+type GalleryDistance struct{}
+
+// NewGalleryDistance creates a GalleryDistance.
+//
+// Example:
+//
+//	config := &TrackerConfig{
+//	    ReidDistanceFunction:  norfairgo.NewGalleryDistance(),
+//	    ReidDistanceThreshold: 0.3,
+//	    ReidHitCounterMax:     norfairgo.IntPtr(100),
+//	    EmbeddingGallerySize:  10,
+//	}
+func NewGalleryDistance() *GalleryDistance {
+	return &GalleryDistance{}
+}
+
+// GetDistances computes the gallery distance matrix (rows = candidates, cols = objects).
+func (gd *GalleryDistance) GetDistances(objects []*TrackedObject, candidates interface{}) *mat.Dense {
+	candList := convertCandidatesToList(candidates)
+	distanceMatrix := createInfinityMatrix(len(candList), len(objects))
+
+	for c, candidate := range candList {
+		var embeddings [][]float64
+		var label *string
+		var classID *int
+		switch cand := candidate.(type) {
+		case *Detection:
+			if cand.Embedding != nil {
+				embeddings = [][]float64{cand.Embedding}
+			}
+			label, classID = cand.Label, cand.ClassID
+		case *TrackedObject:
+			embeddings = cand.EmbeddingGallery
+			label, classID = cand.Label, cand.ClassID
+		}
+
+		for o, obj := range objects {
+			if !labelsMatch(label, obj.Label) || !classIDsMatch(classID, obj.ClassID) {
+				continue
+			}
+			if distance, ok := minCosineDistance(embeddings, obj.EmbeddingGallery); ok {
+				distanceMatrix.Set(c, o, distance)
+			}
+		}
+	}
+
+	return distanceMatrix
+}
+
+// minCosineDistance returns the smallest cosine distance between two embedding sets.
+func minCosineDistance(a, b [][]float64) (float64, bool) {
+	best, found := math.Inf(1), false
+	for _, x := range a {
+		for _, y := range b {
+			best = math.Min(best, 1-cosineSimilarity(x, y))
+			found = true
+		}
+	}
+	return best, found
+}
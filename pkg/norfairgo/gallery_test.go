@@ -0,0 +1,113 @@
+package norfairgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrackedObject_AddToGalleryKeepsDiverseEmbeddings(t *testing.T) {
+	obj := &TrackedObject{config: &TrackerConfig{EmbeddingGallerySize: 2}}
+
+	front := []float64{1, 0}
+	side := []float64{0, 1}
+	frontAgain := []float64{0.99, 0.01}
+
+	obj.addToGallery(front)
+	obj.addToGallery(nil) // Detections without embedding are ignored
+	obj.addToGallery(side)
+	obj.addToGallery(frontAgain)
+
+	if len(obj.EmbeddingGallery) != 2 {
+		t.Fatalf("Expected a gallery of 2, got %d", len(obj.EmbeddingGallery))
+	}
+	// The older of the two near-duplicates is dropped, the distinct appearance is kept
+	if &obj.EmbeddingGallery[0][0] != &side[0] || &obj.EmbeddingGallery[1][0] != &frontAgain[0] {
+		t.Errorf("Expected gallery [side, frontAgain], got %v", obj.EmbeddingGallery)
+	}
+}
+
+func TestTrackedObject_AddToGalleryDisabled(t *testing.T) {
+	obj := &TrackedObject{config: &TrackerConfig{}}
+	obj.addToGallery([]float64{1, 0})
+	if obj.EmbeddingGallery != nil {
+		t.Error("Expected no gallery when EmbeddingGallerySize is 0")
+	}
+}
+
+func TestGalleryDistance(t *testing.T) {
+	config := &TrackerConfig{EmbeddingGallerySize: 4}
+	person := &TrackedObject{config: config, Label: StringPtr("person")}
+	person.addToGallery([]float64{1, 0})
+	person.addToGallery([]float64{0, 1})
+	empty := &TrackedObject{config: config, Label: StringPtr("person")}
+
+	det := &Detection{Label: StringPtr("person"), Embedding: []float64{0, 2}} // Matches the second appearance
+	car := &Detection{Label: StringPtr("car"), Embedding: []float64{0, 1}}
+	noEmbedding := &Detection{Label: StringPtr("person")}
+
+	distances := NewGalleryDistance().GetDistances([]*TrackedObject{person, empty}, []*Detection{det, car, noEmbedding})
+
+	if d := distances.At(0, 0); math.Abs(d) > 1e-9 {
+		t.Errorf("Expected distance 0 to the matching appearance, got %v", d)
+	}
+	for _, cell := range [][2]int{{0, 1}, {1, 0}, {2, 0}} {
+		if d := distances.At(cell[0], cell[1]); !math.IsInf(d, 1) {
+			t.Errorf("Expected no distance for cell %v, got %v", cell, d)
+		}
+	}
+
+	// Tracked object candidates (ReID) compare galleries
+	candidate := &TrackedObject{config: config, Label: StringPtr("person")}
+	candidate.addToGallery([]float64{1, 1})
+	distances = NewGalleryDistance().GetDistances([]*TrackedObject{person}, []*TrackedObject{candidate})
+	if d, expected := distances.At(0, 0), 1-1/math.Sqrt2; math.Abs(d-expected) > 1e-9 {
+		t.Errorf("Expected gallery distance %v, got %v", expected, d)
+	}
+}
+
+func TestTracker_GalleryReidAfterOcclusion(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:      DistanceByName("euclidean"),
+		DistanceThreshold:     20.0,
+		HitCounterMax:         4,
+		InitializationDelay:   1,
+		ReidDistanceFunction:  NewGalleryDistance(),
+		ReidDistanceThreshold: 0.2,
+		ReidHitCounterMax:     IntPtr(50),
+		EmbeddingGallerySize:  4,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	detection := func(x float64, embedding []float64) []*Detection {
+		det := newPointDetection(t, x, 0)
+		det.Embedding = embedding
+		return []*Detection{det}
+	}
+
+	// Seen from the front, then from the side
+	for i := 0; i < 3; i++ {
+		tracker.Update(detection(0, []float64{1, 0}), 1, nil)
+	}
+	for i := 0; i < 3; i++ {
+		tracker.Update(detection(1, []float64{0, 1}), 1, nil)
+	}
+	if len(tracker.TrackedObjects) != 1 || tracker.TrackedObjects[0].ID == nil {
+		t.Fatalf("Expected one initialized object, got %d", len(tracker.TrackedObjects))
+	}
+	id := *tracker.TrackedObjects[0].ID
+
+	// Long occlusion, then it reappears elsewhere seen from the front again
+	for i := 0; i < 10; i++ {
+		tracker.Update(nil, 1, nil)
+	}
+	var active []*TrackedObject
+	for i := 0; i < 3; i++ {
+		active = tracker.Update(detection(500, []float64{1, 0.05}), 1, nil)
+	}
+
+	if len(active) != 1 || active[0].ID == nil || *active[0].ID != id {
+		t.Errorf("Expected the object to be re-identified with ID %d, got %d active objects", id, len(active))
+	}
+}
@@ -0,0 +1,162 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// =============================================================================
+// Chi-Square Gating
+// =============================================================================
+
+// MahalanobisDistance returns the squared Mahalanobis distance between a detection
+// and the predicted position of the object, using the innovation covariance
+// S = P + R of the position part of the filter (in absolute coordinates).
+//
+// Only points that are detected (score above the detection threshold) and that the
+// object has seen before are compared, since the position of the others is unknown.
+//
+// Returns:
+//   - distance: Squared Mahalanobis distance
+//   - dof: Number of compared coordinates (degrees of freedom of the chi-square test)
+//   - err: Error if the filter does not expose covariance (see CovarianceFilter),
+//     the point counts differ or S is singular
+func (to *TrackedObject) MahalanobisDistance(detection *Detection) (distance float64, dof int, err error) {
+	covFilter, ok := to.Filter.(CovarianceFilter)
+	if !ok {
+		return 0, 0, fmt.Errorf("filter %T does not expose covariance", to.Filter)
+	}
+	if rows, cols := detection.AbsolutePoints.Dims(); rows != to.NumPoints || cols != to.DimPoints {
+		return 0, 0, fmt.Errorf("detection has shape (%d, %d), expected (%d, %d)", rows, cols, to.NumPoints, to.DimPoints)
+	}
+
+	// Coordinates to compare
+	var coords []int
+	for i := 0; i < to.NumPoints; i++ {
+		if !to.DetectedAtLeastOncePoints[i] {
+			continue
+		}
+		if detection.Scores != nil && i < len(detection.Scores) && detection.Scores[i] <= to.pointDetectionThreshold(i) {
+			continue
+		}
+		for d := 0; d < to.DimPoints; d++ {
+			coords = append(coords, i*to.DimPoints+d)
+		}
+	}
+	if len(coords) == 0 {
+		return 0, 0, nil
+	}
+
+	// Innovation y and covariance S = P + R over the compared coordinates
+	cov := covFilter.PositionCovariance()
+	var measurementNoise *mat.Dense
+	if inspectable, ok := to.Filter.(InspectableFilter); ok {
+		measurementNoise = inspectable.MeasurementNoise()
+	}
+	state := to.Filter.GetStateVector()
+	detectionFlatten := to.flattenDetectionPoints(detection)
+
+	n := len(coords)
+	innovation := mat.NewVecDense(n, nil)
+	innovationCov := mat.NewSymDense(n, nil)
+	for a, i := range coords {
+		innovation.SetVec(a, detectionFlatten.At(i, 0)-state.At(i, 0))
+		for b := a; b < n; b++ {
+			j := coords[b]
+			value := cov.At(i, j)
+			if measurementNoise != nil {
+				value += measurementNoise.At(i, j)
+			}
+			innovationCov.SetSym(a, b, value)
+		}
+	}
+
+	var chol mat.Cholesky
+	if !chol.Factorize(innovationCov) {
+		return 0, n, fmt.Errorf("innovation covariance is not positive definite")
+	}
+	solved := mat.NewVecDense(n, nil)
+	if err := chol.SolveVecTo(solved, innovation); err != nil {
+		return 0, n, fmt.Errorf("failed to solve innovation covariance: %w", err)
+	}
+	return mat.Dot(innovation, solved), n, nil
+}
+
+// ChiSquareGate returns the gating threshold for squared Mahalanobis distances
+// with dof degrees of freedom at the given confidence level, i.e. the quantile of
+// the chi-square distribution (e.g. 9.4877 for dof=4 at 0.95).
+func ChiSquareGate(dof int, confidence float64) float64 {
+	return distuv.ChiSquared{K: float64(dof)}.Quantile(confidence)
+}
+
+// applyGating sets the distance of every detection-object pair outside the
+// chi-square gate (see TrackerConfig.GatingConfidence) to +Inf, so it cannot be
+// matched. Pairs whose Mahalanobis distance cannot be computed are not gated.
+//
+// Parameters:
+//   - distanceMatrix: (detections, objects) distances, modified in place
+//   - objects: Objects of the matrix columns
+//   - detections: Detections of the matrix rows
+//   - alignedDetections: Aligned detection per pair (see alignedDistances), may be nil
+func (t *Tracker) applyGating(distanceMatrix *mat.Dense, objects []*TrackedObject, detections []*Detection, alignedDetections [][]*Detection) {
+	for i, det := range detections {
+		for j, obj := range objects {
+			if math.IsInf(distanceMatrix.At(i, j), 1) {
+				continue
+			}
+			candidate := det
+			if alignedDetections != nil && alignedDetections[i][j] != nil {
+				candidate = alignedDetections[i][j]
+			}
+			distance, dof, err := obj.MahalanobisDistance(candidate)
+			if err != nil || dof == 0 {
+				continue
+			}
+			if distance > t.chiSquareGate(dof) {
+				distanceMatrix.Set(i, j, math.Inf(1))
+			}
+		}
+	}
+}
+
+// applySparseGating removes the detection-object pairs outside the chi-square gate
+// from a sparse distance matrix, like applyGating.
+func (t *Tracker) applySparseGating(distances *SparseDistances, objects []*TrackedObject, detections []*Detection) {
+	for i, det := range detections {
+		kept := distances.Rows[i][:0]
+		for _, e := range distances.Rows[i] {
+			distance, dof, err := objects[e.Col].MahalanobisDistance(det)
+			if err != nil || dof == 0 || distance <= t.chiSquareGate(dof) {
+				kept = append(kept, e)
+			}
+		}
+		distances.Rows[i] = kept
+	}
+}
+
+// isInnovationOutlier returns whether the innovation of a matched detection
+// exceeds TrackerConfig.InnovationThreshold. Matches whose Mahalanobis distance
+// cannot be computed are never outliers.
+func (t *Tracker) isInnovationOutlier(obj *TrackedObject, detection *Detection) bool {
+	if t.Config.InnovationThreshold <= 0 {
+		return false
+	}
+	distance, dof, err := obj.MahalanobisDistance(detection)
+	return err == nil && dof > 0 && distance > t.Config.InnovationThreshold
+}
+
+// chiSquareGate returns the cached ChiSquareGate for GatingConfidence.
+func (t *Tracker) chiSquareGate(dof int) float64 {
+	if gate, ok := t.gatingThresholds[dof]; ok {
+		return gate
+	}
+	if t.gatingThresholds == nil {
+		t.gatingThresholds = make(map[int]float64)
+	}
+	gate := ChiSquareGate(dof, t.Config.GatingConfidence)
+	t.gatingThresholds[dof] = gate
+	return gate
+}
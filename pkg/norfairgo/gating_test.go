@@ -0,0 +1,140 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+)
+
+// =============================================================================
+// Chi-Square Gating Tests
+// =============================================================================
+
+func TestChiSquareGate(t *testing.T) {
+	testutil.AssertAlmostEqual(t, ChiSquareGate(2, 0.95), 5.9915, 1e-4, "dof 2")
+	testutil.AssertAlmostEqual(t, ChiSquareGate(4, 0.95), 9.4877, 1e-4, "dof 4")
+	testutil.AssertAlmostEqual(t, ChiSquareGate(4, 0.99), 13.2767, 1e-4, "dof 4 at 0.99")
+}
+
+func TestTrackedObject_MahalanobisDistance(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:  DistanceByName("euclidean"),
+		DistanceThreshold: 100,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	tracker.Update([]*Detection{newPointDetection(t, 100, 100)}, 1, nil)
+	obj := tracker.TrackedObjects[0]
+
+	distance, dof, err := obj.MahalanobisDistance(newPointDetection(t, 100, 100))
+	if err != nil {
+		t.Fatalf("MahalanobisDistance failed: %v", err)
+	}
+	if dof != 2 {
+		t.Errorf("Expected 2 degrees of freedom, got %d", dof)
+	}
+	testutil.AssertAlmostEqual(t, distance, 0, 1e-9, "same position")
+
+	// S = P + R is diagonal for the default filter
+	variance := obj.Filter.(CovarianceFilter).PositionCovariance().At(0, 0) +
+		obj.Filter.(InspectableFilter).MeasurementNoise().At(0, 0)
+	distance, _, err = obj.MahalanobisDistance(newPointDetection(t, 106, 100))
+	if err != nil {
+		t.Fatalf("MahalanobisDistance failed: %v", err)
+	}
+	testutil.AssertAlmostEqual(t, distance, 36/variance, 1e-9, "offset position")
+
+	// Wrong shape
+	wrongShape, _ := NewDetectionFromPoints([][2]float64{{1, 2}, {3, 4}}, nil)
+	if _, _, err := obj.MahalanobisDistance(wrongShape); err == nil {
+		t.Error("Expected error for mismatched point count")
+	}
+
+	// Filter without covariance
+	obj.Filter = &NoFilter{}
+	if _, _, err := obj.MahalanobisDistance(newPointDetection(t, 100, 100)); err == nil {
+		t.Error("Expected error for filter without covariance")
+	}
+}
+
+func TestTracker_GatingConfidence(t *testing.T) {
+	run := func(gatingConfidence float64) *Tracker {
+		tracker, err := NewTracker(&TrackerConfig{
+			DistanceFunction:    DistanceByName("euclidean"),
+			DistanceThreshold:   1000,
+			HitCounterMax:       10,
+			InitializationDelay: 1,
+			GatingConfidence:    gatingConfidence,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+		for frame := 0; frame < 10; frame++ {
+			tracker.Update([]*Detection{newPointDetection(t, 100, 100)}, 1, nil)
+		}
+		// A jump far outside the object's uncertainty
+		tracker.Update([]*Detection{newPointDetection(t, 300, 100)}, 1, nil)
+		return tracker
+	}
+
+	// The loose euclidean threshold accepts the jump
+	if ungated := run(0); ungated.TotalObjectCount() != 1 {
+		t.Errorf("Expected the jump to be matched without gating, got %d IDs", ungated.TotalObjectCount())
+	}
+
+	// The gate rejects it, so it starts a new object
+	gated := run(0.99)
+	if len(gated.TrackedObjects) != 2 {
+		t.Errorf("Expected the gated jump to start a new object, got %d objects", len(gated.TrackedObjects))
+	}
+
+	if _, err := NewTracker(&TrackerConfig{DistanceFunction: DistanceByName("euclidean"), GatingConfidence: 1}); err == nil {
+		t.Error("Expected error for gating_confidence >= 1")
+	}
+}
+
+func TestTracker_InnovationThreshold(t *testing.T) {
+	run := func(innovationThreshold float64) (*Tracker, FrameStats) {
+		tracker, err := NewTracker(&TrackerConfig{
+			DistanceFunction:    DistanceByName("euclidean"),
+			DistanceThreshold:   1000,
+			HitCounterMax:       10,
+			InitializationDelay: 1,
+			InnovationThreshold: innovationThreshold,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+		for frame := 0; frame < 10; frame++ {
+			tracker.Update([]*Detection{newPointDetection(t, 100, 100)}, 1, nil)
+		}
+		// A single gross outlier
+		_, stats := tracker.UpdateWithStats([]*Detection{newPointDetection(t, 300, 100)}, 1, nil)
+		return tracker, stats
+	}
+
+	// Without rejection the estimate is yanked towards the outlier
+	corrected, stats := run(0)
+	if x := corrected.TrackedObjects[0].Estimate.At(0, 0); x < 150 {
+		t.Errorf("Expected the outlier to pull the estimate, got x=%v", x)
+	}
+	if stats.NumRejected != 0 {
+		t.Errorf("Expected no rejections when disabled, got %d", stats.NumRejected)
+	}
+
+	// With rejection the object coasts and the outlier is left unmatched
+	rejected, stats := run(ChiSquareGate(2, 0.9999))
+	obj := rejected.TrackedObjects[0]
+	testutil.AssertAlmostEqual(t, obj.Estimate.At(0, 0), 100, 1, "coasted x")
+	if obj.FramesSinceHit != 1 {
+		t.Errorf("Expected the object to skip its correction, got FramesSinceHit=%d", obj.FramesSinceHit)
+	}
+	if stats.NumRejected != 1 || stats.NumMatched != 0 || stats.NumNew != 1 {
+		t.Errorf("Expected 1 rejection starting 1 new object, got %+v", stats)
+	}
+
+	if _, err := NewTracker(&TrackerConfig{DistanceFunction: DistanceByName("euclidean"), InnovationThreshold: -1}); err == nil {
+		t.Error("Expected error for negative innovation_threshold")
+	}
+}
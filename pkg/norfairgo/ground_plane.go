@@ -0,0 +1,163 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Ground Plane Projection - Bird's-Eye View in Metric World Coordinates
+// =============================================================================
+
+// GroundPlaneProjector converts image positions of tracked objects into world
+// coordinates on the ground plane (e.g. meters), using an image-to-ground homography.
+// This enables true distance and speed computations and top-down minimap rendering.
+type GroundPlaneProjector struct {
+	homography *HomographyTransformation // AbsToRel = image -> ground, RelToAbs = ground -> image
+
+	// Point of each object that is projected.
+	// Default: ReferenceBottomCenter
+	Reference ReferencePointMode
+
+	// Custom reference point extractor, overriding Reference.
+	// Default: nil (use Reference)
+	ReferenceFunc ReferencePointFunc
+}
+
+// NewGroundPlaneProjector creates a projector from a 3x3 image-to-ground homography.
+//
+// Returns: Error if the matrix is not 3x3 or not invertible
+func NewGroundPlaneProjector(imageToGround *mat.Dense) (*GroundPlaneProjector, error) {
+	homography, err := NewHomographyTransformation(imageToGround)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image-to-ground homography: %w", err)
+	}
+	return &GroundPlaneProjector{homography: homography}, nil
+}
+
+// NewGroundPlaneProjectorFromPoints estimates the image-to-ground homography from at
+// least 4 correspondences, e.g. the image pixels of the corners of a marked area of
+// known size and their world coordinates. With more than 4 points the homography is
+// the least-squares fit.
+//
+// Returns: Error if there are fewer than 4 correspondences or they are degenerate
+func NewGroundPlaneProjectorFromPoints(imagePoints, groundPoints [][2]float64) (*GroundPlaneProjector, error) {
+	n := len(imagePoints)
+	if n < 4 || len(groundPoints) != n {
+		return nil, fmt.Errorf("need at least 4 matching image and ground points, got %d and %d", n, len(groundPoints))
+	}
+
+	// Direct linear transform with h33 = 1: two equations per correspondence
+	a := mat.NewDense(2*n, 8, nil)
+	b := mat.NewVecDense(2*n, nil)
+	for i := 0; i < n; i++ {
+		x, y := imagePoints[i][0], imagePoints[i][1]
+		u, v := groundPoints[i][0], groundPoints[i][1]
+		a.SetRow(2*i, []float64{x, y, 1, 0, 0, 0, -u * x, -u * y})
+		a.SetRow(2*i+1, []float64{0, 0, 0, x, y, 1, -v * x, -v * y})
+		b.SetVec(2*i, u)
+		b.SetVec(2*i+1, v)
+	}
+
+	var h mat.VecDense
+	if err := h.SolveVec(a, b); err != nil {
+		return nil, fmt.Errorf("degenerate point correspondences: %w", err)
+	}
+
+	homography := mat.NewDense(3, 3, []float64{
+		h.AtVec(0), h.AtVec(1), h.AtVec(2),
+		h.AtVec(3), h.AtVec(4), h.AtVec(5),
+		h.AtVec(6), h.AtVec(7), 1,
+	})
+	return NewGroundPlaneProjector(homography)
+}
+
+// ImageToGround projects (n_points, 2) image points onto the ground plane.
+func (p *GroundPlaneProjector) ImageToGround(points *mat.Dense) *mat.Dense {
+	return p.homography.AbsToRel(points)
+}
+
+// GroundToImage projects (n_points, 2) ground points back into the image.
+func (p *GroundPlaneProjector) GroundToImage(points *mat.Dense) *mat.Dense {
+	return p.homography.RelToAbs(points)
+}
+
+// ReferencePoint returns the image point of an object that is projected to the ground.
+func (p *GroundPlaneProjector) ReferencePoint(obj TrackedObjectLike) ([2]float64, error) {
+	estimate, err := obj.GetEstimate(false)
+	if err != nil {
+		return [2]float64{}, err
+	}
+	return p.referencePoint(estimate), nil
+}
+
+// Project returns the ground position of an object.
+func (p *GroundPlaneProjector) Project(obj TrackedObjectLike) ([2]float64, error) {
+	point, err := p.ReferencePoint(obj)
+	if err != nil {
+		return [2]float64{}, err
+	}
+	return p.projectPoint(point), nil
+}
+
+// ProjectAll returns the ground positions of objects by ID, skipping objects
+// without an ID or estimate.
+func (p *GroundPlaneProjector) ProjectAll(objects []TrackedObjectLike) map[int][2]float64 {
+	positions := make(map[int][2]float64, len(objects))
+	for _, obj := range objects {
+		id := obj.GetID()
+		if id == nil {
+			continue
+		}
+		if position, err := p.Project(obj); err == nil {
+			positions[*id] = position
+		}
+	}
+	return positions
+}
+
+// GroundVelocity returns an object's velocity on the ground plane in world units
+// per frame, from its Kalman velocity estimate (multiply by the frame rate for
+// units per second). Unlike pixel velocities, it is not distorted by perspective.
+func (p *GroundPlaneProjector) GroundVelocity(obj *TrackedObject) ([2]float64, error) {
+	estimate, err := obj.GetEstimate(false)
+	if err != nil {
+		return [2]float64{}, err
+	}
+	next := mat.DenseCopyOf(estimate)
+	next.Add(next, obj.EstimateVelocity())
+
+	current := p.projectPoint(p.referencePoint(estimate))
+	future := p.projectPoint(p.referencePoint(next))
+	return [2]float64{future[0] - current[0], future[1] - current[1]}, nil
+}
+
+// GroundSpeed returns the magnitude of GroundVelocity.
+func (p *GroundPlaneProjector) GroundSpeed(obj *TrackedObject) (float64, error) {
+	velocity, err := p.GroundVelocity(obj)
+	if err != nil {
+		return 0, err
+	}
+	return math.Hypot(velocity[0], velocity[1]), nil
+}
+
+// GroundDistance returns the Euclidean distance between two ground positions.
+func GroundDistance(a, b [2]float64) float64 {
+	return math.Hypot(a[0]-b[0], a[1]-b[1])
+}
+
+// referencePoint returns the reference point of an estimate.
+func (p *GroundPlaneProjector) referencePoint(estimate *mat.Dense) [2]float64 {
+	if p.ReferenceFunc != nil {
+		return p.ReferenceFunc(estimate)
+	}
+	return p.Reference.Extract(estimate)
+}
+
+// projectPoint projects a single image point onto the ground plane.
+func (p *GroundPlaneProjector) projectPoint(point [2]float64) [2]float64 {
+	projected := p.ImageToGround(mat.NewDense(1, 2, point[:]))
+	return [2]float64{projected.At(0, 0), projected.At(0, 1)}
+}
@@ -0,0 +1,142 @@
+package norfairgo
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// cropTrack is a minimal TrackedObjectLike with a fixed estimate
+type cropTrack struct {
+	id       *int
+	estimate *mat.Dense
+}
+
+func (c *cropTrack) GetEstimate(absolute bool) (*mat.Dense, error) { return c.estimate, nil }
+func (c *cropTrack) GetID() *int                                   { return c.id }
+func (c *cropTrack) GetLabel() *string                             { return nil }
+func (c *cropTrack) GetLivePoints() []bool                         { return []bool{true, true} }
+
+func newCropTrack(id int, x1, y1, x2, y2 float64) *cropTrack {
+	return &cropTrack{id: &id, estimate: mat.NewDense(2, 2, []float64{x1, y1, x2, y2})}
+}
+
+// scaleProjector maps 10 image pixels to 1 world unit.
+func newScaleProjector(t *testing.T) *GroundPlaneProjector {
+	t.Helper()
+	projector, err := NewGroundPlaneProjector(mat.NewDense(3, 3, []float64{
+		0.1, 0, 0,
+		0, 0.1, 0,
+		0, 0, 1,
+	}))
+	if err != nil {
+		t.Fatalf("NewGroundPlaneProjector failed: %v", err)
+	}
+	return projector
+}
+
+func TestNewGroundPlaneProjector_Invalid(t *testing.T) {
+	if _, err := NewGroundPlaneProjector(mat.NewDense(2, 2, nil)); err == nil {
+		t.Error("expected error for non-3x3 homography")
+	}
+	if _, err := NewGroundPlaneProjectorFromPoints([][2]float64{{0, 0}, {1, 0}, {1, 1}}, [][2]float64{{0, 0}, {1, 0}, {1, 1}}); err == nil {
+		t.Error("expected error for fewer than 4 correspondences")
+	}
+}
+
+func TestGroundPlaneProjector_Project(t *testing.T) {
+	projector := newScaleProjector(t)
+	obj := newCropTrack(1, 100, 200, 140, 300)
+
+	position, err := projector.Project(obj)
+	if err != nil {
+		t.Fatalf("Project failed: %v", err)
+	}
+	if math.Abs(position[0]-12) > 1e-9 || math.Abs(position[1]-30) > 1e-9 {
+		t.Errorf("bottom center projection = %v, want [12 30]", position)
+	}
+
+	projector.Reference = ReferenceCentroid
+	position, _ = projector.Project(obj)
+	if math.Abs(position[0]-12) > 1e-9 || math.Abs(position[1]-25) > 1e-9 {
+		t.Errorf("centroid projection = %v, want [12 25]", position)
+	}
+
+	// A custom extractor overrides Reference
+	projector.ReferenceFunc = func(points *mat.Dense) [2]float64 { return [2]float64{points.At(0, 0), points.At(0, 1)} }
+	position, _ = projector.Project(obj)
+	if math.Abs(position[0]-10) > 1e-9 || math.Abs(position[1]-20) > 1e-9 {
+		t.Errorf("custom projection = %v, want [10 20]", position)
+	}
+}
+
+func TestGroundPlaneProjector_ProjectAll(t *testing.T) {
+	projector := newScaleProjector(t)
+	noID := &cropTrack{estimate: mat.NewDense(2, 2, []float64{0, 0, 10, 10})}
+	positions := projector.ProjectAll([]TrackedObjectLike{
+		newCropTrack(1, 0, 0, 10, 10),
+		newCropTrack(2, 25, 0, 45, 50),
+		noID,
+	})
+
+	if len(positions) != 2 {
+		t.Fatalf("expected 2 positions, got %d", len(positions))
+	}
+	if d := GroundDistance(positions[1], positions[2]); math.Abs(d-5) > 1e-9 {
+		t.Errorf("GroundDistance = %v, want 5", d)
+	}
+}
+
+func TestNewGroundPlaneProjectorFromPoints(t *testing.T) {
+	// A 4m x 10m area seen in perspective: the far edge is shorter in the image
+	imagePoints := [][2]float64{{500, 700}, {900, 700}, {800, 300}, {600, 300}}
+	groundPoints := [][2]float64{{0, 0}, {4, 0}, {4, 10}, {0, 10}}
+
+	projector, err := NewGroundPlaneProjectorFromPoints(imagePoints, groundPoints)
+	if err != nil {
+		t.Fatalf("NewGroundPlaneProjectorFromPoints failed: %v", err)
+	}
+
+	projected := projector.ImageToGround(mat.NewDense(4, 2, []float64{500, 700, 900, 700, 800, 300, 600, 300}))
+	for i, want := range groundPoints {
+		if math.Abs(projected.At(i, 0)-want[0]) > 1e-6 || math.Abs(projected.At(i, 1)-want[1]) > 1e-6 {
+			t.Errorf("point %d projected to (%v, %v), want %v", i, projected.At(i, 0), projected.At(i, 1), want)
+		}
+	}
+
+	back := projector.GroundToImage(mat.NewDense(1, 2, []float64{4, 10}))
+	if math.Abs(back.At(0, 0)-800) > 1e-6 || math.Abs(back.At(0, 1)-300) > 1e-6 {
+		t.Errorf("GroundToImage = (%v, %v), want (800, 300)", back.At(0, 0), back.At(0, 1))
+	}
+}
+
+func TestGroundPlaneProjector_GroundSpeed(t *testing.T) {
+	projector := newScaleProjector(t)
+	projector.Reference = ReferenceCentroid
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   50,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("NewTracker failed: %v", err)
+	}
+
+	// Move 10 pixels (1 world unit) per frame
+	var objects []*TrackedObject
+	for i := 0; i < 30; i++ {
+		objects = tracker.Update([]*Detection{newPointDetection(t, float64(10*i), 100)}, 1, nil)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("expected 1 active object, got %d", len(objects))
+	}
+
+	speed, err := projector.GroundSpeed(objects[0])
+	if err != nil {
+		t.Fatalf("GroundSpeed failed: %v", err)
+	}
+	if math.Abs(speed-1) > 0.1 {
+		t.Errorf("GroundSpeed = %v, want ~1", speed)
+	}
+}
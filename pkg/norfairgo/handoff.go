@@ -0,0 +1,62 @@
+package norfairgo
+
+import "fmt"
+
+// =============================================================================
+// Track Handoff Between Trackers
+// =============================================================================
+
+// ExportObjects removes the initialized objects with the given permanent IDs from
+// the tracker and returns them, with their filter state, IDs and history, so they
+// can be handed off to another tracker with ImportObjects (e.g. when an object
+// leaves one tile or time window for the next).
+//
+// Returns: Error (and no change) if any ID is not tracked
+func (t *Tracker) ExportObjects(ids []int) ([]*TrackedObject, error) {
+	exported := make([]*TrackedObject, 0, len(ids))
+	for _, id := range ids {
+		obj := t.findObjectByID(id)
+		if obj == nil {
+			return nil, fmt.Errorf("tracked object with id %d not found", id)
+		}
+		exported = append(exported, obj)
+	}
+	for _, obj := range exported {
+		t.removeTrackedObject(obj)
+	}
+	return exported, nil
+}
+
+// ImportObjects adds objects exported from another tracker, keeping their IDs.
+// They are rebound to this tracker's configuration (hit counters are clamped to
+// its HitCounterMax) and its ID counter continues after the imported IDs, so new
+// objects never reuse them. Their filters keep running in the coordinates of the
+// source tracker, so both trackers must share the same coordinate system.
+//
+// Returns: Error (and no change) if an object has no ID or its ID is already tracked
+func (t *Tracker) ImportObjects(objects []*TrackedObject) error {
+	seen := make(map[int]bool, len(objects))
+	for _, obj := range objects {
+		if obj == nil || obj.ID == nil {
+			return fmt.Errorf("only initialized objects can be imported")
+		}
+		if seen[*obj.ID] || t.findObjectByID(*obj.ID) != nil {
+			return fmt.Errorf("tracked object with id %d already exists", *obj.ID)
+		}
+		seen[*obj.ID] = true
+	}
+
+	for _, obj := range objects {
+		obj.config = t.Config
+		obj.objFactory = t.objFactory
+		obj.HitCounter = min(obj.HitCounter, t.Config.HitCounterMax)
+		obj.clampPointHitCounters()
+		obj.matched = false
+		t.objFactory.restoreCount(*obj.ID)
+		if obj.GlobalID != nil {
+			raiseGlobalCount(*obj.GlobalID)
+		}
+		t.TrackedObjects = append(t.TrackedObjects, obj)
+	}
+	return nil
+}
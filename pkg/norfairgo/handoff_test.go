@@ -0,0 +1,85 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+)
+
+// =============================================================================
+// Track Handoff Tests
+// =============================================================================
+
+func newHandoffTracker(t *testing.T) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   30,
+		HitCounterMax:       6,
+		InitializationDelay: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	return tracker
+}
+
+func TestTracker_ExportImportObjects(t *testing.T) {
+	source := newHandoffTracker(t)
+	for frame := 0; frame < 6; frame++ {
+		source.Update([]*Detection{
+			newPointDetection(t, 10*float64(frame), 100),
+			newPointDetection(t, 10*float64(frame), 400),
+		}, 1, nil)
+	}
+	active := source.GetActiveObjects()
+	if len(active) != 2 {
+		t.Fatalf("Expected 2 active objects, got %d", len(active))
+	}
+	movingID := *active[0].ID
+	velocity := active[0].EstimateVelocity().At(0, 0)
+
+	if _, err := source.ExportObjects([]int{movingID, 99}); err == nil {
+		t.Error("Expected error for unknown ID")
+	}
+	if len(source.TrackedObjects) != 2 {
+		t.Fatalf("Expected a failed export to not remove objects")
+	}
+
+	exported, err := source.ExportObjects([]int{movingID})
+	if err != nil {
+		t.Fatalf("ExportObjects failed: %v", err)
+	}
+	if len(exported) != 1 || len(source.TrackedObjects) != 1 {
+		t.Fatalf("Expected 1 exported and 1 remaining object, got %d and %d", len(exported), len(source.TrackedObjects))
+	}
+
+	target := newHandoffTracker(t)
+	if err := target.ImportObjects(exported); err != nil {
+		t.Fatalf("ImportObjects failed: %v", err)
+	}
+	if err := target.ImportObjects(exported); err == nil {
+		t.Error("Expected error for importing an existing ID")
+	}
+
+	// The filter state moved with the object: it keeps its ID and velocity
+	objects := target.Update([]*Detection{newPointDetection(t, 60, exported[0].Estimate.At(0, 1))}, 1, nil)
+	if len(objects) != 1 || *objects[0].ID != movingID {
+		t.Fatalf("Expected the imported object to be matched with ID %d", movingID)
+	}
+	testutil.AssertAlmostEqual(t, objects[0].EstimateVelocity().At(0, 0), velocity, 2, "velocity after handoff")
+
+	// New objects in the target do not reuse imported IDs
+	for frame := 0; frame < 3; frame++ {
+		target.Update([]*Detection{newPointDetection(t, 500, 500)}, 1, nil)
+	}
+	for _, obj := range target.GetActiveObjects() {
+		if obj != objects[0] && *obj.ID <= movingID {
+			t.Errorf("Expected a new ID after %d, got %d", movingID, *obj.ID)
+		}
+	}
+
+	if err := target.ImportObjects([]*TrackedObject{{}}); err == nil {
+		t.Error("Expected error for an object without ID")
+	}
+}
@@ -0,0 +1,61 @@
+package norfairgo
+
+import "math"
+
+// =============================================================================
+// Heading - Smoothed Direction of Motion Per Track
+// =============================================================================
+
+// Heading returns the object's direction of motion in degrees in [0, 360), measured
+// from the +x axis towards the +y axis (clockwise in image coordinates, so 90 is
+// moving down), and whether it is known. It is useful for direction-based counting
+// and turning-movement analysis.
+//
+// The heading follows an exponential moving average of the centroid velocity (see
+// TrackerConfig.HeadingSmoothing), updated every tracker step, and only changes while
+// the smoothed speed is at least TrackerConfig.HeadingMinSpeed: slow or stopped
+// objects keep their last heading, and objects that never moved fast enough have none.
+// With a coordinate transformation, it is the direction in absolute coordinates.
+func (to *TrackedObject) Heading() (degrees float64, ok bool) {
+	return to.heading, to.hasHeading
+}
+
+// updateHeading folds the current centroid velocity into the smoothed velocity and
+// refreshes the heading if the object moves fast enough.
+func (to *TrackedObject) updateHeading(period int) {
+	if to.DimPoints < 2 || to.NumPoints == 0 {
+		return
+	}
+	smoothing, minSpeed := 0.3, 1.0
+	if to.config != nil {
+		if to.config.HeadingSmoothing > 0 {
+			smoothing = to.config.HeadingSmoothing
+		}
+		if to.config.HeadingMinSpeed > 0 {
+			minSpeed = to.config.HeadingMinSpeed
+		}
+	}
+
+	velocity := to.EstimateVelocity()
+	var centroid [2]float64
+	for i := 0; i < to.NumPoints; i++ {
+		centroid[0] += velocity.At(i, 0) / float64(to.NumPoints)
+		centroid[1] += velocity.At(i, 1) / float64(to.NumPoints)
+	}
+
+	// A step of period frames weighs like period single-frame steps
+	weight := 1 - math.Pow(1-smoothing, float64(period))
+	for d := range to.headingVelocity {
+		to.headingVelocity[d] += weight * (centroid[d] - to.headingVelocity[d])
+	}
+
+	if math.Hypot(to.headingVelocity[0], to.headingVelocity[1]) < minSpeed {
+		return
+	}
+	degrees := math.Atan2(to.headingVelocity[1], to.headingVelocity[0]) * 180 / math.Pi
+	if degrees < 0 {
+		degrees += 360
+	}
+	to.heading = degrees
+	to.hasHeading = true
+}
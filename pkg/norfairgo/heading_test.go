@@ -0,0 +1,89 @@
+package norfairgo
+
+import (
+	"math"
+	"testing"
+)
+
+// angleDifference returns the absolute difference of two angles in degrees.
+func angleDifference(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	return math.Min(d, 360-d)
+}
+
+func TestTrackedObject_Heading(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	obj := func() *TrackedObject { return tracker.TrackedObjects[0] }
+
+	// Moving right: heading 0
+	x, y := 0.0, 0.0
+	for i := 0; i < 20; i++ {
+		x += 5
+		tracker.Update([]*Detection{newPointDetection(t, x, y)}, 1, nil)
+	}
+	if heading, ok := obj().Heading(); !ok || angleDifference(heading, 0) > 5 {
+		t.Errorf("Expected heading ~0 when moving right, got %.1f (ok=%v)", heading, ok)
+	}
+
+	// Turning down: heading follows to 90 (clockwise in image coordinates)
+	for i := 0; i < 30; i++ {
+		y += 5
+		tracker.Update([]*Detection{newPointDetection(t, x, y)}, 1, nil)
+	}
+	if heading, ok := obj().Heading(); !ok || angleDifference(heading, 90) > 5 {
+		t.Errorf("Expected heading ~90 when moving down, got %.1f (ok=%v)", heading, ok)
+	}
+
+	// Stopping with jitter: the last heading is kept
+	for i := 0; i < 40; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, x+0.3*float64(i%2), y-0.3*float64(i%3))}, 1, nil)
+	}
+	if heading, ok := obj().Heading(); !ok || angleDifference(heading, 90) > 10 {
+		t.Errorf("Expected the heading to stay ~90 after stopping, got %.1f (ok=%v)", heading, ok)
+	}
+}
+
+func TestTrackedObject_HeadingUnknownWhenStationary(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20,
+		InitializationDelay: 0,
+		HeadingMinSpeed:     0.5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	for i := 0; i < 30; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 100+0.4*float64(i%2), 100-0.4*float64(i%2))}, 1, nil)
+	}
+	if heading, ok := tracker.TrackedObjects[0].Heading(); ok {
+		t.Errorf("Expected no heading for a jittering stationary object, got %.1f", heading)
+	}
+}
+
+func TestNewTracker_HeadingValidation(t *testing.T) {
+	for _, config := range []*TrackerConfig{
+		{DistanceFunction: DistanceByName("euclidean"), DistanceThreshold: 1, HeadingSmoothing: 1.5},
+		{DistanceFunction: DistanceByName("euclidean"), DistanceThreshold: 1, HeadingSmoothing: -0.1},
+		{DistanceFunction: DistanceByName("euclidean"), DistanceThreshold: 1, HeadingMinSpeed: -1},
+	} {
+		if _, err := NewTracker(config); err == nil {
+			t.Errorf("Expected error for smoothing %v and min speed %v", config.HeadingSmoothing, config.HeadingMinSpeed)
+		}
+	}
+
+	tracker, err := NewTracker(&TrackerConfig{DistanceFunction: DistanceByName("euclidean"), DistanceThreshold: 1})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	if tracker.Config.HeadingSmoothing != 0.3 || tracker.Config.HeadingMinSpeed != 1.0 {
+		t.Errorf("Expected defaults 0.3 and 1.0, got %v and %v", tracker.Config.HeadingSmoothing, tracker.Config.HeadingMinSpeed)
+	}
+}
@@ -0,0 +1,352 @@
+package norfairgo
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Pure Go Homography Estimation (DLT + RANSAC)
+// =============================================================================
+
+// FindHomographyRANSAC estimates the homography mapping srcPts to dstPts with
+// RANSAC over normalized DLT (direct linear transform) fits, like OpenCV's
+// findHomography with RANSAC but without any OpenCV dependency. The final
+// homography is refitted on all inliers. Sampling is seeded with
+// DefaultRANSACSeed, so results are deterministic; see FindHomographyRANSACWithSeed.
+//
+// Parameters:
+//   - srcPts, dstPts: Corresponding points, shape (N, 2) with N >= 4
+//   - reprojThreshold: Maximum reprojection error (in pixels) of an inlier
+//   - maxIters: Maximum number of RANSAC iterations
+//   - confidence: Stop early once an all-inlier sample was drawn with this
+//     probability (between 0 and 1)
+//
+// Returns:
+//   - homography: 3x3 matrix normalized to H[2][2] = 1
+//   - inliers: Inlier mask of the point pairs
+//   - err: Error if the shapes are invalid or no non-degenerate model was found
+func FindHomographyRANSAC(srcPts, dstPts *mat.Dense, reprojThreshold float64, maxIters int, confidence float64) (*mat.Dense, []bool, error) {
+	return FindHomographyRANSACWithSeed(srcPts, dstPts, reprojThreshold, maxIters, confidence, DefaultRANSACSeed)
+}
+
+// DefaultRANSACSeed is the sampling seed of FindHomographyRANSAC.
+const DefaultRANSACSeed int64 = 1
+
+// FindHomographyRANSACWithSeed is FindHomographyRANSAC with an explicit sampling
+// seed. The same inputs and seed always give the same homography, so runs can
+// be reproduced exactly, while different seeds give independent RANSAC trials.
+func FindHomographyRANSACWithSeed(srcPts, dstPts *mat.Dense, reprojThreshold float64, maxIters int, confidence float64, seed int64) (*mat.Dense, []bool, error) {
+	n, srcCols := srcPts.Dims()
+	dstRows, dstCols := dstPts.Dims()
+	if srcCols != 2 || dstCols != 2 || dstRows != n {
+		return nil, nil, fmt.Errorf("points must have matching shapes (N, 2), got (%d, %d) and (%d, %d)", n, srcCols, dstRows, dstCols)
+	}
+	if n < 4 {
+		return nil, nil, fmt.Errorf("homography requires at least 4 points, got %d", n)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	var best *mat.Dense
+	var bestInliers []bool
+	bestCount := 0
+	sample := make([]int, 4)
+	iterations := maxIters
+
+	for iter := 0; iter < iterations; iter++ {
+		if n == 4 {
+			copy(sample, []int{0, 1, 2, 3})
+		} else {
+			perm := rng.Perm(n)
+			copy(sample, perm[:4])
+		}
+		if sampleIsDegenerate(srcPts, dstPts, sample) {
+			continue
+		}
+		homography, err := fitHomographyDLT(srcPts, dstPts, sample)
+		if err != nil {
+			continue
+		}
+		inliers, count := homographyInliers(homography, srcPts, dstPts, reprojThreshold)
+		if count > bestCount {
+			best, bestInliers, bestCount = homography, inliers, count
+			iterations = min(iterations, ransacIterations(float64(count)/float64(n), confidence, maxIters))
+		}
+		if n == 4 {
+			break
+		}
+	}
+	if best == nil {
+		return nil, nil, fmt.Errorf("no non-degenerate homography found")
+	}
+
+	// Refit on all inliers
+	if bestCount > 4 {
+		indices := make([]int, 0, bestCount)
+		for i, inlier := range bestInliers {
+			if inlier {
+				indices = append(indices, i)
+			}
+		}
+		if refined, err := fitHomographyDLT(srcPts, dstPts, indices); err == nil {
+			if inliers, count := homographyInliers(refined, srcPts, dstPts, reprojThreshold); count >= bestCount {
+				best, bestInliers = refined, inliers
+			}
+		}
+	}
+	return best, bestInliers, nil
+}
+
+// GonumHomographyTransformationGetter is a HomographyTransformationGetter that
+// estimates homographies with FindHomographyRANSAC instead of OpenCV. It is
+// available in nogocv builds and can be passed to NewMotionEstimator.
+type GonumHomographyTransformationGetter struct {
+	// RansacReprojThreshold is the maximum allowed reprojection error to treat a point pair as an inlier.
+	RansacReprojThreshold float64
+
+	// MaxIters is the maximum number of RANSAC iterations.
+	MaxIters int
+
+	// Confidence is the RANSAC confidence level (between 0 and 1).
+	Confidence float64
+
+	// ProportionPointsUsedThreshold is the minimum proportion of points that must be matched.
+	// If the proportion falls below this threshold, the reference frame is updated.
+	ProportionPointsUsedThreshold float64
+
+	// Seed of the RANSAC sampling. Every call is seeded the same way, so the
+	// same frames always give the same transformations.
+	// Default: DefaultRANSACSeed (set by NewGonumHomographyTransformationGetter)
+	Seed int64
+
+	// data stores the accumulated homography from the original reference frame.
+	data *mat.Dense
+}
+
+// NewGonumHomographyTransformationGetter creates a pure Go homography transformation
+// getter with the parameters of NewHomographyTransformationGetter.
+func NewGonumHomographyTransformationGetter(ransacReprojThreshold float64, maxIters int, confidence, proportionPointsUsedThreshold float64) *GonumHomographyTransformationGetter {
+	return &GonumHomographyTransformationGetter{
+		RansacReprojThreshold:         ransacReprojThreshold,
+		MaxIters:                      maxIters,
+		Confidence:                    confidence,
+		ProportionPointsUsedThreshold: proportionPointsUsedThreshold,
+		Seed:                          DefaultRANSACSeed,
+	}
+}
+
+// Call computes the homography transformation between current and previous points.
+// Returns (shouldUpdateReference, transformation), as HomographyTransformationGetter.Call.
+func (h *GonumHomographyTransformationGetter) Call(currPts, prevPts *mat.Dense) (bool, CoordinateTransformation) {
+	result := h.CallDetailed(currPts, prevPts)
+	return result.UpdateReference, result.Transformation
+}
+
+// CallDetailed computes the homography transformation between current and previous
+// points, see DetailedTransformationGetter. The inliers are the RANSAC inliers.
+func (h *GonumHomographyTransformationGetter) CallDetailed(currPts, prevPts *mat.Dense) TransformationResult {
+	currRows, currCols := currPts.Dims()
+	prevRows, prevCols := prevPts.Dims()
+	if currRows < 4 || prevRows < 4 || currCols != 2 || prevCols != 2 {
+		log.Printf("Warning: Homography couldn't be computed due to insufficient points (need ≥4, got curr=%d, prev=%d)", currRows, prevRows)
+		return previousHomography(h.data, prevRows)
+	}
+
+	homographyMatrix, inliers, err := FindHomographyRANSACWithSeed(prevPts, currPts, h.RansacReprojThreshold, h.MaxIters, h.Confidence, h.Seed)
+	if err != nil {
+		log.Printf("Warning: FindHomographyRANSAC failed: %v", err)
+		return previousHomography(h.data, prevRows)
+	}
+
+	inlierCount := 0
+	for _, inlier := range inliers {
+		if inlier {
+			inlierCount++
+		}
+	}
+	return accumulateHomography(&h.data, homographyMatrix, inlierCount, prevRows, h.ProportionPointsUsedThreshold)
+}
+
+// =============================================================================
+// Helper Functions
+// =============================================================================
+
+// accumulateHomography chains a homography onto the accumulated reference
+// homography and updates the reference when too few points were inliers
+// (shared by the homography transformation getters).
+func accumulateHomography(data **mat.Dense, homographyMatrix *mat.Dense, inlierCount, totalPoints int, proportionPointsUsedThreshold float64) TransformationResult {
+	proportionPointsUsed := float64(inlierCount) / float64(totalPoints)
+	updatePrvs := proportionPointsUsed < proportionPointsUsedThreshold
+
+	// Accumulate homographies via matrix multiplication (NOT addition!)
+	// Python: homography_matrix = homography_matrix @ self.data
+	if *data != nil {
+		var accumulated mat.Dense
+		accumulated.Mul(homographyMatrix, *data)
+		homographyMatrix = &accumulated
+	}
+
+	if updatePrvs {
+		*data = homographyMatrix
+	}
+
+	transformation, err := NewHomographyTransformation(homographyMatrix)
+	if err != nil {
+		log.Printf("Warning: Failed to create HomographyTransformation: %v", err)
+		return previousHomography(*data, totalPoints)
+	}
+	return TransformationResult{
+		UpdateReference: updatePrvs,
+		Transformation:  transformation,
+		NumPoints:       totalPoints,
+		NumInliers:      inlierCount,
+	}
+}
+
+// previousHomography returns the accumulated reference homography (nil if none)
+// without inliers and requests a reference update, for frames where no
+// homography was found.
+func previousHomography(data *mat.Dense, numPoints int) TransformationResult {
+	result := TransformationResult{UpdateReference: true, NumPoints: numPoints}
+	if data != nil {
+		result.Transformation, _ = NewHomographyTransformation(data)
+	}
+	return result
+}
+
+// fitHomographyDLT fits a homography to the indexed point pairs with the
+// normalized DLT: points are centered and scaled to a mean distance of sqrt(2)
+// and the homography is the null vector of the stacked constraints.
+func fitHomographyDLT(srcPts, dstPts *mat.Dense, indices []int) (*mat.Dense, error) {
+	srcNorm := pointNormalization(srcPts, indices)
+	dstNorm := pointNormalization(dstPts, indices)
+
+	a := mat.NewDense(2*len(indices), 9, nil)
+	for row, i := range indices {
+		x, y := applyNormalization(srcNorm, srcPts.At(i, 0), srcPts.At(i, 1))
+		u, v := applyNormalization(dstNorm, dstPts.At(i, 0), dstPts.At(i, 1))
+		a.SetRow(2*row, []float64{-x, -y, -1, 0, 0, 0, u * x, u * y, u})
+		a.SetRow(2*row+1, []float64{0, 0, 0, -x, -y, -1, v * x, v * y, v})
+	}
+
+	var svd mat.SVD
+	if !svd.Factorize(a, mat.SVDFullV) {
+		return nil, fmt.Errorf("SVD failed")
+	}
+	var vt mat.Dense
+	svd.VTo(&vt)
+	normalized := mat.NewDense(3, 3, nil)
+	for k := 0; k < 9; k++ {
+		normalized.Set(k/3, k%3, vt.At(k, 8))
+	}
+
+	// Denormalize: H = T_dst^-1 * H_norm * T_src
+	var dstInverse, tmp, homography mat.Dense
+	if err := dstInverse.Inverse(dstNorm); err != nil {
+		return nil, fmt.Errorf("degenerate points: %w", err)
+	}
+	tmp.Mul(&dstInverse, normalized)
+	homography.Mul(&tmp, srcNorm)
+
+	scale := homography.At(2, 2)
+	if math.Abs(scale) < 1e-12 {
+		return nil, fmt.Errorf("degenerate homography")
+	}
+	homography.Scale(1/scale, &homography)
+	return &homography, nil
+}
+
+// pointNormalization returns the 3x3 similarity that centers the indexed points
+// and scales them to a mean distance of sqrt(2) from the origin.
+func pointNormalization(points *mat.Dense, indices []int) *mat.Dense {
+	var cx, cy float64
+	for _, i := range indices {
+		cx += points.At(i, 0)
+		cy += points.At(i, 1)
+	}
+	cx /= float64(len(indices))
+	cy /= float64(len(indices))
+
+	var meanDist float64
+	for _, i := range indices {
+		meanDist += math.Hypot(points.At(i, 0)-cx, points.At(i, 1)-cy)
+	}
+	meanDist /= float64(len(indices))
+	scale := 1.0
+	if meanDist > 1e-12 {
+		scale = math.Sqrt2 / meanDist
+	}
+	return mat.NewDense(3, 3, []float64{
+		scale, 0, -scale * cx,
+		0, scale, -scale * cy,
+		0, 0, 1,
+	})
+}
+
+// applyNormalization applies a normalization similarity to a point.
+func applyNormalization(t *mat.Dense, x, y float64) (float64, float64) {
+	return t.At(0, 0)*x + t.At(0, 2), t.At(1, 1)*y + t.At(1, 2)
+}
+
+// homographyInliers returns the pairs whose reprojection error is within threshold.
+func homographyInliers(homography, srcPts, dstPts *mat.Dense, threshold float64) ([]bool, int) {
+	n, _ := srcPts.Dims()
+	inliers := make([]bool, n)
+	count := 0
+	h := homography.RawMatrix().Data
+	for i := 0; i < n; i++ {
+		x, y := srcPts.At(i, 0), srcPts.At(i, 1)
+		w := h[6]*x + h[7]*y + h[8]
+		if math.Abs(w) < 1e-12 {
+			continue
+		}
+		u := (h[0]*x + h[1]*y + h[2]) / w
+		v := (h[3]*x + h[4]*y + h[5]) / w
+		if math.Hypot(u-dstPts.At(i, 0), v-dstPts.At(i, 1)) <= threshold {
+			inliers[i] = true
+			count++
+		}
+	}
+	return inliers, count
+}
+
+// sampleIsDegenerate reports whether three points of a minimal sample are
+// (nearly) collinear in either image, which makes the DLT ill-conditioned.
+func sampleIsDegenerate(srcPts, dstPts *mat.Dense, sample []int) bool {
+	for _, points := range []*mat.Dense{srcPts, dstPts} {
+		for a := 0; a < 4; a++ {
+			for b := a + 1; b < 4; b++ {
+				for c := b + 1; c < 4; c++ {
+					p, q, r := sample[a], sample[b], sample[c]
+					cross := (points.At(q, 0)-points.At(p, 0))*(points.At(r, 1)-points.At(p, 1)) -
+						(points.At(q, 1)-points.At(p, 1))*(points.At(r, 0)-points.At(p, 0))
+					if math.Abs(cross) < 1e-9 {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ransacIterations returns the number of iterations needed to draw an all-inlier
+// sample of 4 points with the given confidence at the given inlier ratio.
+func ransacIterations(inlierRatio, confidence float64, maxIters int) int {
+	allInliers := math.Pow(inlierRatio, 4)
+	if allInliers >= 1 {
+		return 1
+	}
+	if allInliers <= 0 || confidence <= 0 || confidence >= 1 {
+		return maxIters
+	}
+	iterations := math.Log(1-confidence) / math.Log(1-allInliers)
+	if iterations >= float64(maxIters) {
+		return maxIters
+	}
+	return int(math.Ceil(iterations))
+}
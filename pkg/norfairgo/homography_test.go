@@ -0,0 +1,225 @@
+package norfairgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Pure Go Homography Estimation Tests
+// =============================================================================
+
+// testHomography is a perspective transformation with rotation, scale and shear.
+var testHomography = mat.NewDense(3, 3, []float64{
+	1.02, 0.05, 12,
+	-0.03, 0.98, -7,
+	1e-4, -5e-5, 1,
+})
+
+// homographyCorrespondences returns n random points in a 640x480 frame and their
+// projection by homography, with gaussian noise and the given fraction of the
+// projections replaced by random points (outliers).
+func homographyCorrespondences(homography *mat.Dense, n int, noise, outlierRatio float64, seed int64) (src, dst *mat.Dense, isOutlier []bool) {
+	rng := rand.New(rand.NewSource(seed))
+	src = mat.NewDense(n, 2, nil)
+	dst = mat.NewDense(n, 2, nil)
+	isOutlier = make([]bool, n)
+	transformation, _ := NewHomographyTransformation(homography)
+	for i := 0; i < n; i++ {
+		src.Set(i, 0, rng.Float64()*640)
+		src.Set(i, 1, rng.Float64()*480)
+	}
+	projected := transformation.AbsToRel(src)
+	for i := 0; i < n; i++ {
+		if rng.Float64() < outlierRatio {
+			isOutlier[i] = true
+			dst.Set(i, 0, rng.Float64()*640)
+			dst.Set(i, 1, rng.Float64()*480)
+			continue
+		}
+		dst.Set(i, 0, projected.At(i, 0)+rng.NormFloat64()*noise)
+		dst.Set(i, 1, projected.At(i, 1)+rng.NormFloat64()*noise)
+	}
+	return src, dst, isOutlier
+}
+
+// meanReprojectionError returns the mean distance between the projections of a
+// grid over the frame by the estimated and the true homography.
+func meanReprojectionError(t *testing.T, estimated, truth *mat.Dense) float64 {
+	t.Helper()
+	estimatedTransformation, err := NewHomographyTransformation(estimated)
+	if err != nil {
+		t.Fatalf("Invalid estimated homography: %v", err)
+	}
+	trueTransformation, _ := NewHomographyTransformation(truth)
+
+	grid := mat.NewDense(48, 2, nil)
+	for i := 0; i < 48; i++ {
+		grid.Set(i, 0, float64(i%8)*80+40)
+		grid.Set(i, 1, float64(i/8)*80+40)
+	}
+	a := estimatedTransformation.AbsToRel(grid)
+	b := trueTransformation.AbsToRel(grid)
+	var sum float64
+	for i := 0; i < 48; i++ {
+		sum += math.Hypot(a.At(i, 0)-b.At(i, 0), a.At(i, 1)-b.At(i, 1))
+	}
+	return sum / 48
+}
+
+func TestFindHomographyRANSAC_Exact(t *testing.T) {
+	src, dst, _ := homographyCorrespondences(testHomography, 20, 0, 0, 1)
+	homography, inliers, err := FindHomographyRANSAC(src, dst, 3, 2000, 0.995)
+	if err != nil {
+		t.Fatalf("FindHomographyRANSAC failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(homography.At(i, j)-testHomography.At(i, j)) > 1e-6 {
+				t.Errorf("H[%d][%d] = %v, expected %v", i, j, homography.At(i, j), testHomography.At(i, j))
+			}
+		}
+	}
+	for i, inlier := range inliers {
+		if !inlier {
+			t.Errorf("Expected point %d to be an inlier", i)
+		}
+	}
+}
+
+func TestFindHomographyRANSAC_MinimalSample(t *testing.T) {
+	src, dst, _ := homographyCorrespondences(testHomography, 4, 0, 0, 2)
+	homography, _, err := FindHomographyRANSAC(src, dst, 3, 2000, 0.995)
+	if err != nil {
+		t.Fatalf("FindHomographyRANSAC failed: %v", err)
+	}
+	if e := meanReprojectionError(t, homography, testHomography); e > 1e-6 {
+		t.Errorf("Expected exact fit from 4 points, got error %v", e)
+	}
+}
+
+func TestFindHomographyRANSAC_OutliersAndNoise(t *testing.T) {
+	src, dst, isOutlier := homographyCorrespondences(testHomography, 200, 0.5, 0.3, 3)
+	homography, inliers, err := FindHomographyRANSAC(src, dst, 3, 2000, 0.995)
+	if err != nil {
+		t.Fatalf("FindHomographyRANSAC failed: %v", err)
+	}
+	if e := meanReprojectionError(t, homography, testHomography); e > 0.5 {
+		t.Errorf("Expected mean reprojection error < 0.5px, got %v", e)
+	}
+	misclassified := 0
+	for i := range inliers {
+		if inliers[i] == isOutlier[i] {
+			misclassified++
+		}
+	}
+	if misclassified > 4 {
+		t.Errorf("Expected at most 4 misclassified points, got %d", misclassified)
+	}
+}
+
+func TestFindHomographyRANSAC_InvalidInput(t *testing.T) {
+	src, dst, _ := homographyCorrespondences(testHomography, 3, 0, 0, 4)
+	if _, _, err := FindHomographyRANSAC(src, dst, 3, 2000, 0.995); err == nil {
+		t.Error("Expected error for 3 points")
+	}
+	if _, _, err := FindHomographyRANSAC(mat.NewDense(4, 2, nil), mat.NewDense(5, 2, nil), 3, 2000, 0.995); err == nil {
+		t.Error("Expected error for mismatched shapes")
+	}
+
+	// Collinear points
+	collinear := mat.NewDense(5, 2, []float64{0, 0, 1, 1, 2, 2, 3, 3, 4, 4})
+	if _, _, err := FindHomographyRANSAC(collinear, collinear, 3, 100, 0.995); err == nil {
+		t.Error("Expected error for collinear points")
+	}
+}
+
+func TestFindHomographyRANSACWithSeed(t *testing.T) {
+	src, dst, _ := homographyCorrespondences(testHomography, 100, 0.5, 0.4, 7)
+	reference, _, err := FindHomographyRANSAC(src, dst, 3, 2000, 0.995)
+	if err != nil {
+		t.Fatalf("FindHomographyRANSAC failed: %v", err)
+	}
+	seeded, _, err := FindHomographyRANSACWithSeed(src, dst, 3, 2000, 0.995, DefaultRANSACSeed)
+	if err != nil {
+		t.Fatalf("FindHomographyRANSACWithSeed failed: %v", err)
+	}
+	if !mat.Equal(reference, seeded) {
+		t.Error("Expected FindHomographyRANSAC to use DefaultRANSACSeed")
+	}
+
+	for _, seed := range []int64{0, 42, 1234} {
+		first, _, err := FindHomographyRANSACWithSeed(src, dst, 3, 2000, 0.995, seed)
+		if err != nil {
+			t.Fatalf("seed %d: FindHomographyRANSACWithSeed failed: %v", seed, err)
+		}
+		second, _, _ := FindHomographyRANSACWithSeed(src, dst, 3, 2000, 0.995, seed)
+		if !mat.Equal(first, second) {
+			t.Errorf("seed %d: Expected identical homographies for the same seed", seed)
+		}
+		if e := meanReprojectionError(t, first, testHomography); e > 1 {
+			t.Errorf("seed %d: Expected mean reprojection error < 1px, got %v", seed, e)
+		}
+	}
+}
+
+func TestGonumHomographyTransformationGetter(t *testing.T) {
+	getter := NewGonumHomographyTransformationGetter(3.0, 2000, 0.995, 0.5)
+
+	// Insufficient points without previous data
+	if update, trans := getter.Call(mat.NewDense(3, 2, nil), mat.NewDense(3, 2, nil)); !update || trans != nil {
+		t.Error("Expected reference update and nil transformation with insufficient points")
+	}
+
+	// 25% outliers stay below the reference update threshold
+	src, dst, isOutlier := homographyCorrespondences(testHomography, 100, 0.2, 0.25, 5)
+	result := getter.CallDetailed(dst, src)
+	update, trans := result.UpdateReference, result.Transformation
+	numOutliers := 0
+	for _, outlier := range isOutlier {
+		if outlier {
+			numOutliers++
+		}
+	}
+	if result.NumPoints != 100 || result.NumInliers != 100-numOutliers {
+		t.Errorf("Expected %d of 100 inliers, got %d of %d", 100-numOutliers, result.NumInliers, result.NumPoints)
+	}
+	if update {
+		t.Error("Expected no reference update with 75% inliers")
+	}
+	homography, ok := trans.(*HomographyTransformation)
+	if !ok {
+		t.Fatalf("Expected HomographyTransformation, got %T", trans)
+	}
+	if e := meanReprojectionError(t, homography.HomographyMatrix, testHomography); e > 0.5 {
+		t.Errorf("Expected mean reprojection error < 0.5px, got %v", e)
+	}
+
+	// Mostly outliers request a reference update, which then accumulates
+	src, dst, _ = homographyCorrespondences(testHomography, 100, 0.2, 0.7, 6)
+	if update, _ := getter.Call(dst, src); !update {
+		t.Error("Expected reference update with 30% inliers")
+	}
+	if getter.data == nil {
+		t.Fatal("Expected the reference homography to be stored")
+	}
+	if update, trans := getter.Call(mat.NewDense(2, 2, nil), mat.NewDense(2, 2, nil)); !update || trans == nil {
+		t.Error("Expected the stored reference homography with insufficient points")
+	}
+}
+
+func TestRansacIterations(t *testing.T) {
+	if n := ransacIterations(1, 0.995, 2000); n != 1 {
+		t.Errorf("Expected 1 iteration without outliers, got %d", n)
+	}
+	// log(0.005) / log(1 - 0.5^4) = 82.1
+	if n := ransacIterations(0.5, 0.995, 2000); n != 83 {
+		t.Errorf("Expected 83 iterations at 50%% inliers, got %d", n)
+	}
+	if n := ransacIterations(0.01, 0.995, 2000); n != 2000 {
+		t.Errorf("Expected the iteration cap, got %d", n)
+	}
+}
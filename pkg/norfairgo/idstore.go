@@ -0,0 +1,509 @@
+package norfairgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// ID Stores - Persistent Track IDs Across Restarts
+// =============================================================================
+
+// IDState is the tracker state persisted by an IDStore: the ID counters and the
+// fingerprints of recent tracks, so a restarted tracker neither reuses IDs nor
+// loses the identities of objects that are still in view.
+type IDState struct {
+	Count        int                `json:"count"`        // Tracker's instance ID counter (last issued ID)
+	GlobalCount  int                `json:"global_count"` // Global ID counter (see GlobalCount)
+	Fingerprints []TrackFingerprint `json:"fingerprints"` // Tracks that can still be re-identified
+}
+
+// TrackFingerprint is what a restarted tracker needs to re-identify a track: its
+// IDs, labels, last position and appearance.
+type TrackFingerprint struct {
+	ID             int         `json:"id"`
+	GlobalID       int         `json:"global_id"`
+	Label          *string     `json:"label,omitempty"`
+	ClassID        *int        `json:"class_id,omitempty"`
+	Points         [][]float64 `json:"points"`               // Absolute position estimate, one row per point
+	Embeddings     [][]float64 `json:"embeddings,omitempty"` // Embedding gallery (or the last detection's embedding)
+	ReidHitCounter int         `json:"reid_hit_counter"`     // Frames left for ReID matching
+}
+
+// IDStore persists a tracker's IDState (see TrackerConfig.IDStore).
+type IDStore interface {
+	// Load returns the saved state, or nil if nothing was saved yet.
+	Load() (*IDState, error)
+
+	// Save replaces the saved state.
+	Save(state *IDState) error
+}
+
+// =============================================================================
+// Tracker Integration
+// =============================================================================
+
+// SaveIDs saves the ID counters and the fingerprints of all tracks with an ID to
+// TrackerConfig.IDStore, after waiting for the saves started by Update. Update
+// already saves in the background whenever a new ID is issued; call SaveIDs on
+// shutdown so the fingerprints are current and nothing is left unsaved.
+//
+// Fingerprints are only saved with ReID enabled (ReidHitCounterMax set), since
+// restored tracks can only be recovered by ReID matching.
+//
+// Returns: Error if no IDStore is configured or saving fails
+func (t *Tracker) SaveIDs() error {
+	if t.Config.IDStore == nil {
+		return fmt.Errorf("tracker has no IDStore")
+	}
+
+	state := t.idState()
+	t.idSaver.wait()
+	if err := t.Config.IDStore.Save(state); err != nil {
+		return fmt.Errorf("failed to save IDs: %w", err)
+	}
+	t.savedCount = state.Count
+	return nil
+}
+
+// idState returns the current ID counters and fingerprints.
+func (t *Tracker) idState() *IDState {
+	state := &IDState{
+		Count:        t.objFactory.Count(),
+		GlobalCount:  GlobalCount(),
+		Fingerprints: []TrackFingerprint{},
+	}
+	if t.Config.ReidHitCounterMax != nil {
+		for _, obj := range t.TrackedObjects {
+			if obj.ID != nil && obj.GlobalID != nil {
+				state.Fingerprints = append(state.Fingerprints, obj.fingerprint(*t.Config.ReidHitCounterMax))
+			}
+		}
+	}
+
+	return state
+}
+
+// autoSaveIDs starts saving the IDs in the background after an update that issued
+// a new ID, so IDs are not reused even if the process is killed, without blocking
+// Update on a slow store (e.g. a RedisIDStore round trip).
+func (t *Tracker) autoSaveIDs() {
+	if t.Config.IDStore == nil || t.objFactory.Count() == t.savedCount {
+		return
+	}
+	state := t.idState()
+	t.idSaver.save(state)
+	t.savedCount = state.Count
+}
+
+// idSaver saves ID states on a background goroutine. Only the latest pending
+// state is kept, so a store slower than the tracker skips intermediate states.
+type idSaver struct {
+	store IDStore
+
+	mu      sync.Mutex
+	idle    *sync.Cond // Signalled when the goroutine exits
+	pending *IDState   // Latest state not yet being saved (nil if none)
+	running bool       // Whether the goroutine is running
+}
+
+func newIDSaver(store IDStore) *idSaver {
+	s := &idSaver{store: store}
+	s.idle = sync.NewCond(&s.mu)
+	return s
+}
+
+// save queues a state, starting the goroutine if needed.
+func (s *idSaver) save(state *IDState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = state
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+}
+
+// run saves the pending states until none is left.
+func (s *idSaver) run() {
+	for {
+		s.mu.Lock()
+		state := s.pending
+		s.pending = nil
+		if state == nil {
+			s.running = false
+			s.idle.Broadcast()
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+
+		if err := s.store.Save(state); err != nil {
+			log.Printf("Warning: failed to save IDs: %v", err)
+		}
+	}
+}
+
+// wait waits until the queued states are saved.
+func (s *idSaver) wait() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.running {
+		s.idle.Wait()
+	}
+}
+
+// restoreIDs loads the state saved in TrackerConfig.IDStore: the ID counters
+// continue where they stopped, and (with ReID enabled) the fingerprinted tracks are
+// restored as dead objects waiting for ReID, which keep their IDs when matched.
+func (t *Tracker) restoreIDs() error {
+	state, err := t.Config.IDStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load IDs: %w", err)
+	}
+	if state == nil {
+		return nil
+	}
+
+	t.objFactory.restoreCount(state.Count)
+	raiseGlobalCount(state.GlobalCount)
+	t.savedCount = state.Count
+
+	if t.Config.ReidHitCounterMax == nil {
+		return nil
+	}
+	for _, fingerprint := range state.Fingerprints {
+		obj, err := restoreTrackedObject(t.objFactory, fingerprint, t.Config)
+		if err != nil {
+			log.Printf("Warning: skipping fingerprint of track %d: %v", fingerprint.ID, err)
+			continue
+		}
+		t.TrackedObjects = append(t.TrackedObjects, obj)
+	}
+	return nil
+}
+
+// fingerprint returns the object's fingerprint. Alive objects get the full
+// reidHitCounterMax, dead ones their remaining ReID counter.
+func (to *TrackedObject) fingerprint(reidHitCounterMax int) TrackFingerprint {
+	fingerprint := TrackFingerprint{
+		ID:             *to.ID,
+		GlobalID:       *to.GlobalID,
+		Points:         make([][]float64, to.NumPoints),
+		ReidHitCounter: reidHitCounterMax,
+	}
+	if to.ReidHitCounter != nil {
+		fingerprint.ReidHitCounter = *to.ReidHitCounter
+	}
+	if to.Label != nil {
+		fingerprint.Label = StringPtr(*to.Label)
+	}
+	if to.ClassID != nil {
+		fingerprint.ClassID = IntPtr(*to.ClassID)
+	}
+
+	// The filter state is in absolute coordinates
+	stateVector := to.Filter.GetStateVector()
+	for i := range fingerprint.Points {
+		fingerprint.Points[i] = make([]float64, to.DimPoints)
+		for d := range fingerprint.Points[i] {
+			fingerprint.Points[i][d] = stateVector.At(i*to.DimPoints+d, 0)
+		}
+	}
+
+	// Copied, since Update saves the fingerprints in the background
+	if len(to.EmbeddingGallery) > 0 {
+		fingerprint.Embeddings = make([][]float64, len(to.EmbeddingGallery))
+		for i, embedding := range to.EmbeddingGallery {
+			fingerprint.Embeddings[i] = slices.Clone(embedding)
+		}
+	} else if to.LastDetection != nil && to.LastDetection.Embedding != nil {
+		fingerprint.Embeddings = [][]float64{slices.Clone(to.LastDetection.Embedding)}
+	}
+	return fingerprint
+}
+
+// restoreTrackedObject recreates a fingerprinted track as a dead object waiting for
+// ReID, at its last position with zero velocity.
+func restoreTrackedObject(objFactory *TrackedObjectFactory, fingerprint TrackFingerprint, config *TrackerConfig) (*TrackedObject, error) {
+	if len(fingerprint.Points) == 0 {
+		return nil, fmt.Errorf("fingerprint has no points")
+	}
+	dimPoints := len(fingerprint.Points[0])
+	values := make([]float64, 0, len(fingerprint.Points)*dimPoints)
+	for _, point := range fingerprint.Points {
+		if len(point) != dimPoints {
+			return nil, fmt.Errorf("fingerprint points have different dimensions")
+		}
+		values = append(values, point...)
+	}
+
+	var embedding []float64
+	if len(fingerprint.Embeddings) > 0 {
+		embedding = fingerprint.Embeddings[len(fingerprint.Embeddings)-1]
+	}
+	detection, err := NewDetection(mat.NewDense(len(fingerprint.Points), dimPoints, values), &DetectionConfig{
+		Label:     fingerprint.Label,
+		ClassID:   fingerprint.ClassID,
+		Embedding: embedding,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Period 0 keeps the object initializing, so it does not acquire new IDs
+	obj, err := NewTrackedObject(objFactory, detection, config, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	id, globalID, reidHitCounter := fingerprint.ID, fingerprint.GlobalID, fingerprint.ReidHitCounter
+	obj.ID = &id
+	obj.GlobalID = &globalID
+	obj.IsInitializing = false
+	obj.InitialPeriod = 1
+	obj.HitCounter = -1
+	obj.ReidHitCounter = &reidHitCounter
+	obj.matched = false
+	for i := range obj.PointHitCounter {
+		obj.PointHitCounter[i] = 0
+	}
+	if len(fingerprint.Embeddings) > 0 {
+		obj.EmbeddingGallery = fingerprint.Embeddings
+	}
+	return obj, nil
+}
+
+// =============================================================================
+// FileIDStore - JSON File
+// =============================================================================
+
+// FileIDStore stores the IDState as a JSON file. Saves replace the file atomically,
+// so a crash mid-save keeps the previous state.
+type FileIDStore struct {
+	Path string
+}
+
+// NewFileIDStore creates a FileIDStore for the file at path.
+//
+// Example:
+//
+//	tracker, err := norfairgo.NewTracker(&norfairgo.TrackerConfig{
+//	    IDStore:           norfairgo.NewFileIDStore("/var/lib/tracker/ids.json"),
+//	    ReidHitCounterMax: norfairgo.IntPtr(100),
+//	    // ...
+//	})
+func NewFileIDStore(path string) *FileIDStore {
+	return &FileIDStore{Path: path}
+}
+
+// Load reads the state, returning nil if the file does not exist.
+func (s *FileIDStore) Load() (*IDState, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeIDState(data)
+}
+
+// Save writes the state to a temporary file and renames it over the file.
+func (s *FileIDStore) Save(state *IDState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// =============================================================================
+// RedisIDStore - Redis Key
+// =============================================================================
+
+// RedisIDStoreConfig configures a RedisIDStore.
+type RedisIDStoreConfig struct {
+	// Address of the Redis server (host:port).
+	// Default: "localhost:6379"
+	Addr string
+
+	// Key the JSON state is stored under, e.g. one per camera.
+	// Default: "norfair:ids"
+	Key string
+
+	// Password for AUTH.
+	// Default: "" (no AUTH)
+	Password string
+
+	// Database number for SELECT.
+	// Default: 0
+	DB int
+
+	// Timeout for connecting and for each operation.
+	// Default: 5s
+	Timeout time.Duration
+}
+
+// RedisIDStore stores the IDState as JSON under a Redis key, so trackers on
+// different hosts (or containers without persistent disks) can share it. It speaks
+// the Redis protocol directly and opens one connection per Load or Save.
+type RedisIDStore struct {
+	config RedisIDStoreConfig
+}
+
+// NewRedisIDStore creates a RedisIDStore.
+//
+// Parameters:
+//   - config: Connection options (nil = defaults)
+func NewRedisIDStore(config *RedisIDStoreConfig) *RedisIDStore {
+	store := &RedisIDStore{}
+	if config != nil {
+		store.config = *config
+	}
+	if store.config.Addr == "" {
+		store.config.Addr = "localhost:6379"
+	}
+	if store.config.Key == "" {
+		store.config.Key = "norfair:ids"
+	}
+	if store.config.Timeout == 0 {
+		store.config.Timeout = 5 * time.Second
+	}
+	return store
+}
+
+// Load reads the state, returning nil if the key does not exist.
+func (s *RedisIDStore) Load() (*IDState, error) {
+	reply, err := s.do("GET", s.config.Key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, nil
+	}
+	return decodeIDState(reply)
+}
+
+// Save sets the key to the state.
+func (s *RedisIDStore) Save(state *IDState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	_, err = s.do("SET", s.config.Key, string(data))
+	return err
+}
+
+// do runs a command on a new connection (after AUTH and SELECT) and returns its
+// reply (nil for a null reply).
+func (s *RedisIDStore) do(args ...string) ([]byte, error) {
+	conn, err := net.DialTimeout("tcp", s.config.Addr, s.config.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(s.config.Timeout)); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	var commands [][]string
+	if s.config.Password != "" {
+		commands = append(commands, []string{"AUTH", s.config.Password})
+	}
+	if s.config.DB != 0 {
+		commands = append(commands, []string{"SELECT", strconv.Itoa(s.config.DB)})
+	}
+	commands = append(commands, args)
+
+	var reply []byte
+	for _, command := range commands {
+		if err := writeRESPCommand(conn, command); err != nil {
+			return nil, fmt.Errorf("redis %s failed: %w", command[0], err)
+		}
+		if reply, err = readRESPReply(reader); err != nil {
+			return nil, fmt.Errorf("redis %s failed: %w", command[0], err)
+		}
+	}
+	return reply, nil
+}
+
+// writeRESPCommand writes a command as a RESP array of bulk strings.
+func writeRESPCommand(w io.Writer, args []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// readRESPReply reads a simple string, error, integer or bulk string reply.
+func readRESPReply(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, fmt.Errorf("%s", line[1:])
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk length %q", line[1:])
+		}
+		if length < 0 {
+			return nil, nil
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:length], nil
+	default:
+		return nil, fmt.Errorf("unexpected reply %q", line)
+	}
+}
+
+// decodeIDState parses a JSON IDState.
+func decodeIDState(data []byte) (*IDState, error) {
+	var state IDState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid ID state: %w", err)
+	}
+	return &state, nil
+}
@@ -0,0 +1,301 @@
+package norfairgo
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// =============================================================================
+// ID Store Tests
+// =============================================================================
+
+func newIDStoreTracker(t *testing.T, store IDStore) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:      DistanceByName("euclidean"),
+		DistanceThreshold:     20.0,
+		HitCounterMax:         4,
+		InitializationDelay:   1,
+		ReidDistanceFunction:  NewGalleryDistance(),
+		ReidDistanceThreshold: 0.2,
+		ReidHitCounterMax:     IntPtr(50),
+		EmbeddingGallerySize:  4,
+		IDStore:               store,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	t.Cleanup(tracker.idSaver.wait) // Before the store's temp dir is removed
+	return tracker
+}
+
+func embeddedPointDetection(t *testing.T, x, y float64, embedding []float64) *Detection {
+	t.Helper()
+	det := newPointDetection(t, x, y)
+	det.Embedding = embedding
+	return det
+}
+
+func TestFileIDStore_RoundTrip(t *testing.T) {
+	store := NewFileIDStore(filepath.Join(t.TempDir(), "ids.json"))
+
+	state, err := store.Load()
+	if err != nil || state != nil {
+		t.Fatalf("Expected no state before the first save, got %v, %v", state, err)
+	}
+
+	saved := &IDState{Count: 7, GlobalCount: 9, Fingerprints: []TrackFingerprint{
+		{ID: 3, GlobalID: 5, Label: StringPtr("person"), Points: [][]float64{{1, 2}}, Embeddings: [][]float64{{1, 0}}, ReidHitCounter: 10},
+	}}
+	if err := store.Save(saved); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	state, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if state.Count != 7 || state.GlobalCount != 9 || len(state.Fingerprints) != 1 {
+		t.Fatalf("Unexpected state %+v", state)
+	}
+	if fp := state.Fingerprints[0]; fp.ID != 3 || *fp.Label != "person" || fp.Points[0][1] != 2 || fp.ReidHitCounter != 10 {
+		t.Errorf("Unexpected fingerprint %+v", fp)
+	}
+}
+
+func TestTracker_IDStoreContinuesIDs(t *testing.T) {
+	store := NewFileIDStore(filepath.Join(t.TempDir(), "ids.json"))
+
+	// Two objects get IDs 1 and 2; Update saves in the background without an explicit SaveIDs
+	tracker := newIDStoreTracker(t, store)
+	for i := 0; i < 3; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 0, 0), newPointDetection(t, 100, 0)}, 1, nil)
+	}
+	if tracker.TotalObjectCount() != 2 {
+		t.Fatalf("Expected 2 objects, got %d", tracker.TotalObjectCount())
+	}
+	tracker.idSaver.wait()
+
+	// After a restart, a new object continues at ID 3
+	restarted := newIDStoreTracker(t, store)
+	var objects []*TrackedObject
+	for i := 0; i < 3; i++ {
+		objects = restarted.Update([]*Detection{newPointDetection(t, 500, 500)}, 1, nil)
+	}
+	if len(objects) != 1 || *objects[0].ID != 3 {
+		t.Fatalf("Expected one object with ID 3 after restart, got %d objects", len(objects))
+	}
+}
+
+func TestTracker_IDStoreRestoresIdentities(t *testing.T) {
+	store := NewFileIDStore(filepath.Join(t.TempDir(), "ids.json"))
+
+	tracker := newIDStoreTracker(t, store)
+	for i := 0; i < 3; i++ {
+		tracker.Update([]*Detection{
+			embeddedPointDetection(t, 0, 0, []float64{1, 0}),
+			embeddedPointDetection(t, 100, 0, []float64{0, 1}),
+		}, 1, nil)
+	}
+	ids := map[float64]int{}
+	for _, obj := range tracker.GetActiveObjects() {
+		ids[obj.Estimate.At(0, 0)] = *obj.ID
+	}
+	if err := tracker.SaveIDs(); err != nil {
+		t.Fatalf("SaveIDs failed: %v", err)
+	}
+
+	// The restored tracks are not output until re-identified
+	restarted := newIDStoreTracker(t, store)
+	if len(restarted.GetActiveObjects()) != 0 || len(restarted.TrackedObjects) != 2 {
+		t.Fatalf("Expected 2 restored dead tracks, got %d tracked and %d active",
+			len(restarted.TrackedObjects), len(restarted.GetActiveObjects()))
+	}
+
+	// Both objects reappear (swapped positions) and get their old IDs back by appearance
+	var objects []*TrackedObject
+	for i := 0; i < 4; i++ {
+		objects = restarted.Update([]*Detection{
+			embeddedPointDetection(t, 100, 50, []float64{1, 0}),
+			embeddedPointDetection(t, 0, 50, []float64{0, 1}),
+		}, 1, nil)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objects))
+	}
+	for _, obj := range objects {
+		want := ids[0]
+		if obj.Estimate.At(0, 0) < 50 {
+			want = ids[100]
+		}
+		if *obj.ID != want {
+			t.Errorf("Expected object at x=%.0f to keep ID %d, got %d", obj.Estimate.At(0, 0), want, *obj.ID)
+		}
+	}
+}
+
+func TestTracker_SaveIDsWithoutStore(t *testing.T) {
+	tracker, _ := newManualControlTracker(t)
+	if err := tracker.SaveIDs(); err == nil {
+		t.Error("Expected an error without an IDStore")
+	}
+}
+
+// fakeRedis is a minimal in-memory Redis server supporting AUTH, SELECT, GET and SET.
+type fakeRedis struct {
+	listener net.Listener
+	mu       sync.Mutex
+	data     map[string]string
+	commands []string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Cannot listen on loopback: %v", err)
+	}
+	server := &fakeRedis{listener: listener, data: map[string]string{}}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		var args []string
+		var n int
+		if _, err := fmt.Sscanf(line, "*%d", &n); err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			if _, err := reader.ReadString('\n'); err != nil {
+				return
+			}
+			arg, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			args = append(args, strings.TrimSuffix(arg, "\r\n"))
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, args[0])
+		switch args[0] {
+		case "GET":
+			if value, ok := s.data[args[1]]; ok {
+				conn.Write([]byte("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"))
+			} else {
+				conn.Write([]byte("$-1\r\n"))
+			}
+		case "SET":
+			s.data[args[1]] = args[2]
+			conn.Write([]byte("+OK\r\n"))
+		case "AUTH":
+			if args[1] == "secret" {
+				conn.Write([]byte("+OK\r\n"))
+			} else {
+				conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+			}
+		default:
+			conn.Write([]byte("+OK\r\n"))
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestRedisIDStore(t *testing.T) {
+	server := newFakeRedis(t)
+	store := NewRedisIDStore(&RedisIDStoreConfig{
+		Addr:     server.listener.Addr().String(),
+		Key:      "camera-1",
+		Password: "secret",
+		DB:       2,
+	})
+
+	state, err := store.Load()
+	if err != nil || state != nil {
+		t.Fatalf("Expected no state before the first save, got %v, %v", state, err)
+	}
+	if err := store.Save(&IDState{Count: 4, GlobalCount: 4}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	state, err = store.Load()
+	if err != nil || state == nil || state.Count != 4 {
+		t.Fatalf("Expected count 4, got %v, %v", state, err)
+	}
+	if !strings.Contains(server.data["camera-1"], `"count":4`) {
+		t.Errorf("Expected JSON state under the key, got %q", server.data["camera-1"])
+	}
+	if got := strings.Join(server.commands[:3], " "); got != "AUTH SELECT GET" {
+		t.Errorf("Expected AUTH and SELECT before each command, got %q", got)
+	}
+
+	// Server errors are reported
+	bad := NewRedisIDStore(&RedisIDStoreConfig{Addr: server.listener.Addr().String(), Password: "wrong"})
+	if _, err := bad.Load(); err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Errorf("Expected an AUTH error, got %v", err)
+	}
+}
+
+// blockingIDStore blocks every Save until release is closed.
+type blockingIDStore struct {
+	release chan struct{}
+	mu      sync.Mutex
+	saved   []*IDState
+}
+
+func (s *blockingIDStore) Load() (*IDState, error) { return nil, nil }
+
+func (s *blockingIDStore) Save(state *IDState) error {
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = append(s.saved, state)
+	return nil
+}
+
+func TestTracker_IDStoreSavesInBackground(t *testing.T) {
+	store := &blockingIDStore{release: make(chan struct{})}
+	tracker := newIDStoreTracker(t, store)
+
+	// Updates issuing new IDs do not wait for the blocked store
+	for i := 0; i < 5; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, float64(100*i), 0)}, 1, nil)
+		tracker.Update([]*Detection{newPointDetection(t, float64(100*i), 0)}, 1, nil)
+	}
+
+	close(store.release)
+	if err := tracker.SaveIDs(); err != nil {
+		t.Fatalf("SaveIDs failed: %v", err)
+	}
+
+	// The first background save plus at most one coalesced pending state, then SaveIDs last
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.saved) < 2 || len(store.saved) > 3 {
+		t.Fatalf("Expected 2 or 3 saves, got %d", len(store.saved))
+	}
+	if last := store.saved[len(store.saved)-1]; last.Count != tracker.TotalObjectCount() {
+		t.Errorf("Expected SaveIDs to save last with count %d, got %d", tracker.TotalObjectCount(), last.Count)
+	}
+}
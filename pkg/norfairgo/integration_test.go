@@ -1,3 +1,5 @@
+//go:build !nogocv
+
 package norfairgo_test
 
 import (
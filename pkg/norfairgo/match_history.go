@@ -0,0 +1,119 @@
+package norfairgo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// =============================================================================
+// Match History - Per-Track Detection Records for Dataset Building
+// =============================================================================
+
+// DetectionMatch records that a tracked object was matched with (or created from)
+// a detection. Together with the frames, it lets per-track image patches be
+// cropped later, e.g. to build self-training or ReID datasets.
+type DetectionMatch struct {
+	Frame          int64      // Tracker frame number (sum of Update periods, 1-indexed at period 1)
+	DetectionIndex int        // Index of the detection in the slice passed to Update
+	BBox           [4]float64 // Bounding box of the detection points [x_min, y_min, x_max, y_max]
+}
+
+// MatchHistory returns the recorded match history of every object that received an
+// ID, including objects no longer tracked, keyed by ID (see TrackerConfig.RecordMatchHistory).
+func (t *Tracker) MatchHistory() map[int][]DetectionMatch {
+	history := make(map[int][]DetectionMatch, len(t.matchHistory)+len(t.TrackedObjects))
+	for id, matches := range t.matchHistory {
+		history[id] = matches
+	}
+	for _, obj := range t.TrackedObjects {
+		if obj.ID != nil && len(obj.MatchHistory) > 0 {
+			history[*obj.ID] = obj.MatchHistory
+		}
+	}
+	return history
+}
+
+// WriteMatchHistoryCSV writes a match history (see Tracker.MatchHistory) as CSV,
+// one detection per row, ordered by track ID and frame.
+//
+// Format: track_id,frame,detection_index,x_min,y_min,x_max,y_max
+func WriteMatchHistoryCSV(w io.Writer, history map[int][]DetectionMatch) error {
+	ids := make([]int, 0, len(history))
+	for id := range history {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"track_id", "frame", "detection_index", "x_min", "y_min", "x_max", "y_max"}); err != nil {
+		return fmt.Errorf("failed to write match history header: %w", err)
+	}
+	for _, id := range ids {
+		for _, match := range history[id] {
+			record := []string{strconv.Itoa(id), strconv.FormatInt(match.Frame, 10), strconv.Itoa(match.DetectionIndex)}
+			for _, v := range match.BBox {
+				record = append(record, strconv.FormatFloat(v, 'f', -1, 64))
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write match history: %w", err)
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// indexDetections remembers the index of each detection of the current frame in
+// the slice passed to Update, before any detection is skipped. A detection passed
+// more than once keeps its first index.
+func (t *Tracker) indexDetections(detections []*Detection) {
+	if t.detectionIndices == nil {
+		t.detectionIndices = make(map[*Detection]int, len(detections))
+	}
+	clear(t.detectionIndices)
+	for i, det := range detections {
+		if _, ok := t.detectionIndices[det]; !ok {
+			t.detectionIndices[det] = i
+		}
+	}
+}
+
+// recordMatch appends a match with a detection of the current frame to an object's history.
+func (t *Tracker) recordMatch(obj *TrackedObject, detection *Detection) {
+	if !t.Config.RecordMatchHistory {
+		return
+	}
+	index, ok := t.detectionIndices[detection]
+	if !ok {
+		return
+	}
+	obj.MatchHistory = append(obj.MatchHistory, DetectionMatch{
+		Frame:          t.frameNumber,
+		DetectionIndex: index,
+		BBox:           pointsBoundingBox(detection),
+	})
+}
+
+// archiveRemovedMatchHistories keeps the histories of objects in before that are no
+// longer tracked, so they outlive the objects.
+func (t *Tracker) archiveRemovedMatchHistories(before []*TrackedObject) {
+	if !t.Config.RecordMatchHistory {
+		return
+	}
+	current := make(map[*TrackedObject]bool, len(t.TrackedObjects))
+	for _, obj := range t.TrackedObjects {
+		current[obj] = true
+	}
+	for _, obj := range before {
+		if current[obj] || obj.ID == nil || len(obj.MatchHistory) == 0 {
+			continue
+		}
+		if t.matchHistory == nil {
+			t.matchHistory = map[int][]DetectionMatch{}
+		}
+		t.matchHistory[*obj.ID] = obj.MatchHistory
+	}
+}
@@ -0,0 +1,83 @@
+package norfairgo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTracker_MatchHistory(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20.0,
+		HitCounterMax:       2,
+		InitializationDelay: 0,
+		RecordMatchHistory:  true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	// Frame 1 creates the object from detection 1, frame 2 matches detection 0
+	tracker.Update([]*Detection{newPointDetection(t, 500, 500), newPointDetection(t, 0, 0)}, 1, nil)
+	tracker.Update([]*Detection{newPointDetection(t, 1, 0)}, 1, nil)
+
+	obj := tracker.TrackedObjects[1]
+	expected := []DetectionMatch{
+		{Frame: 1, DetectionIndex: 1, BBox: [4]float64{0, 0, 0, 0}},
+		{Frame: 2, DetectionIndex: 0, BBox: [4]float64{1, 0, 1, 0}},
+	}
+	if len(obj.MatchHistory) != len(expected) {
+		t.Fatalf("Expected %d matches, got %v", len(expected), obj.MatchHistory)
+	}
+	for i := range expected {
+		if obj.MatchHistory[i] != expected[i] {
+			t.Errorf("Match %d: expected %+v, got %+v", i, expected[i], obj.MatchHistory[i])
+		}
+	}
+
+	// Histories outlive their objects
+	id := *obj.ID
+	for i := 0; i < 10; i++ {
+		tracker.Update(nil, 1, nil)
+	}
+	if len(tracker.TrackedObjects) != 0 {
+		t.Fatalf("Expected all objects to expire, got %d", len(tracker.TrackedObjects))
+	}
+	history := tracker.MatchHistory()
+	if len(history) != 2 || len(history[id]) != 2 {
+		t.Errorf("Expected the archived histories of both objects, got %v", history)
+	}
+}
+
+func TestTracker_MatchHistoryDisabled(t *testing.T) {
+	tracker, _ := newManualControlTracker(t)
+	if len(tracker.TrackedObjects[0].MatchHistory) != 0 || len(tracker.MatchHistory()) != 0 {
+		t.Error("Expected no match history when recording is disabled")
+	}
+}
+
+func TestWriteMatchHistoryCSV(t *testing.T) {
+	history := map[int][]DetectionMatch{
+		2: {{Frame: 3, DetectionIndex: 0, BBox: [4]float64{1, 2, 3.5, 4}}},
+		1: {
+			{Frame: 1, DetectionIndex: 2, BBox: [4]float64{0, 0, 10, 10}},
+			{Frame: 2, DetectionIndex: 1, BBox: [4]float64{1, 1, 11, 11}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMatchHistoryCSV(&buf, history); err != nil {
+		t.Fatalf("WriteMatchHistoryCSV failed: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"track_id,frame,detection_index,x_min,y_min,x_max,y_max",
+		"1,1,2,0,0,10,10",
+		"1,2,1,1,1,11,11",
+		"2,3,0,1,2,3.5,4",
+	}, "\n") + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
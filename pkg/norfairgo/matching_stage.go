@@ -0,0 +1,152 @@
+package norfairgo
+
+// =============================================================================
+// Matching Stages - Composable Matching of Initialized Objects
+// =============================================================================
+
+// MatchingStage is one step of matching initialized objects to detections (see
+// TrackerConfig.MatchingStages). Each stage matches the objects not matched by
+// earlier stages to the detections they left over, with its own distance
+// function and threshold, e.g. a strict IoU stage for high-confidence detections
+// followed by a looser stage for low-confidence ones (as in ByteTrack).
+type MatchingStage struct {
+	// Name of the stage, for debugging.
+	// Default: ""
+	Name string
+
+	// Distance function of the stage.
+	// Default: nil (TrackerConfig.DistanceFunction)
+	DistanceFunction Distance
+
+	// Maximum distance for a valid match in this stage. Like DistanceThreshold, it
+	// is scaled with the camera motion if MotionThresholdScale is set.
+	// Default: 0.0 (TrackerConfig.DistanceThreshold)
+	DistanceThreshold float64
+
+	// Selects the detections the stage may match. Detections it rejects stay
+	// available to later stages, initializing objects and new objects.
+	// Default: nil (all remaining detections)
+	DetectionFilter func(detection *Detection) bool
+
+	// Selects the objects the stage may match. Objects it rejects stay available
+	// to later stages.
+	// Default: nil (all remaining initialized objects)
+	ObjectFilter func(obj *TrackedObject) bool
+}
+
+// MinDetectionScore returns a MatchingStage.DetectionFilter that keeps detections
+// whose mean point score is at least minScore (unscored detections count as 1).
+func MinDetectionScore(minScore float64) func(detection *Detection) bool {
+	return func(detection *Detection) bool {
+		return meanDetectionScore(detection) >= minScore
+	}
+}
+
+// MaxDetectionScore returns a MatchingStage.DetectionFilter that keeps detections
+// whose mean point score is below maxScore (unscored detections count as 1).
+func MaxDetectionScore(maxScore float64) func(detection *Detection) bool {
+	return func(detection *Detection) bool {
+		return meanDetectionScore(detection) < maxScore
+	}
+}
+
+// meanDetectionScore returns the mean score of a detection (1.0 if unscored).
+func meanDetectionScore(detection *Detection) float64 {
+	if detection == nil || len(detection.Scores) == 0 {
+		return 1.0
+	}
+	sum := 0.0
+	for _, score := range detection.Scores {
+		sum += score
+	}
+	return sum / float64(len(detection.Scores))
+}
+
+// matchingStages returns the configured matching stages, or a single stage with the
+// tracker's distance function and threshold.
+func (t *Tracker) matchingStages() []MatchingStage {
+	if len(t.Config.MatchingStages) > 0 {
+		return t.Config.MatchingStages
+	}
+	return []MatchingStage{{Name: "default"}}
+}
+
+// stageThreshold returns the distance threshold of a stage in the current frame,
+// scaled with the camera motion like the tracker's threshold.
+func (t *Tracker) stageThreshold(stage MatchingStage) float64 {
+	if stage.DistanceThreshold == 0 {
+		return t.distanceThreshold
+	}
+	return stage.DistanceThreshold * t.distanceThreshold / t.Config.DistanceThreshold
+}
+
+// matchStages runs the matching stages on the initialized objects (with the
+// matching cascade within every stage if enabled).
+//
+// Returns:
+//   - unmatchedDetections: Detections not matched by any stage
+//   - unmatchedObjects: Objects not matched (or deferred as ambiguous) by any stage
+func (t *Tracker) matchStages(
+	objects []*TrackedObject,
+	detections []*Detection,
+	period int,
+) ([]*Detection, []*TrackedObject) {
+	for _, stage := range t.matchingStages() {
+		distanceFunction := stage.DistanceFunction
+		if distanceFunction == nil {
+			distanceFunction = t.Config.DistanceFunction
+		}
+		threshold := t.stageThreshold(stage)
+
+		stageObjects, skippedObjects := partition(objects, stage.ObjectFilter)
+		stageDetections, rejectedDetections := partition(detections, stage.DetectionFilter)
+
+		var leftover []*Detection
+		var unmatched []*TrackedObject
+		if t.Config.MatchingCascade {
+			leftover, unmatched = t.matchCascade(distanceFunction, threshold, stageObjects, stageDetections, period)
+		} else {
+			remaining, _, unmatchedObjects := t.updateObjectsInPlace(distanceFunction, threshold, stageObjects, stageDetections, period)
+			leftover, _ = remaining.([]*Detection)
+			unmatched = unmatchedObjects
+		}
+
+		objects = append(skippedObjects, unmatched...)
+		if stage.DetectionFilter == nil {
+			detections = leftover
+			continue
+		}
+
+		// Return the detections the filter rejected, in their original order
+		leftoverSet := make(map[*Detection]bool, len(leftover)+len(rejectedDetections))
+		for _, detection := range leftover {
+			leftoverSet[detection] = true
+		}
+		for _, detection := range rejectedDetections {
+			leftoverSet[detection] = true
+		}
+		kept := make([]*Detection, 0, len(leftoverSet))
+		for _, detection := range detections {
+			if leftoverSet[detection] {
+				kept = append(kept, detection)
+			}
+		}
+		detections = kept
+	}
+	return detections, objects
+}
+
+// partition splits items into those the filter keeps (all if nil) and the rest.
+func partition[T any](items []T, keep func(T) bool) (kept, rejected []T) {
+	if keep == nil {
+		return items, nil
+	}
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
+		} else {
+			rejected = append(rejected, item)
+		}
+	}
+	return kept, rejected
+}
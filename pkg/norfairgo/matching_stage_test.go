@@ -0,0 +1,112 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// newScoredPointDetection creates a single point detection with a score
+func newScoredPointDetection(t *testing.T, x, y, score float64) *Detection {
+	t.Helper()
+	detection, err := NewDetection(mat.NewDense(1, 2, []float64{x, y}), &DetectionConfig{Scores: []float64{score}})
+	if err != nil {
+		t.Fatalf("Failed to create detection: %v", err)
+	}
+	return detection
+}
+
+// newByteStyleTracker tracks objects A at x=0 and B at x=30 with a strict stage for
+// high-score detections followed by a loose stage for low-score detections
+func newByteStyleTracker(t *testing.T) (tracker *Tracker, objA, objB *TrackedObject) {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   2.0,
+		HitCounterMax:       10,
+		InitializationDelay: 0,
+		MatchingStages: []MatchingStage{
+			{Name: "high", DetectionFilter: MinDetectionScore(0.5)},
+			{Name: "low", DistanceThreshold: 10.0, DetectionFilter: MaxDetectionScore(0.5)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 0, 0), newPointDetection(t, 30, 0)}, 1, nil)
+	}
+	if len(tracker.TrackedObjects) != 2 {
+		t.Fatalf("Expected 2 tracked objects, got %d", len(tracker.TrackedObjects))
+	}
+	return tracker, tracker.TrackedObjects[0], tracker.TrackedObjects[1]
+}
+
+func TestTracker_MatchingStages(t *testing.T) {
+	tracker, objA, objB := newByteStyleTracker(t)
+
+	// A is matched by the strict stage, B only by the loose one
+	tracker.Update([]*Detection{newScoredPointDetection(t, 1, 0, 0.9), newScoredPointDetection(t, 24, 0, 0.2)}, 1, nil)
+	if !objA.IsMatched() || !objB.IsMatched() {
+		t.Errorf("Expected both objects to be matched (A matched=%v, B matched=%v)", objA.IsMatched(), objB.IsMatched())
+	}
+	if len(tracker.TrackedObjects) != 2 {
+		t.Errorf("Expected no new objects, got %d tracked objects", len(tracker.TrackedObjects))
+	}
+}
+
+func TestTracker_MatchingStagesFilterDetections(t *testing.T) {
+	tracker, objA, _ := newByteStyleTracker(t)
+
+	// A high-score detection beyond the strict threshold is not matched by the
+	// loose stage and starts a new object
+	tracker.Update([]*Detection{newScoredPointDetection(t, 6, 0, 0.9)}, 1, nil)
+	if objA.IsMatched() {
+		t.Error("Expected the loose stage to ignore high-score detections")
+	}
+	if len(tracker.TrackedObjects) != 3 {
+		t.Errorf("Expected a new object, got %d tracked objects", len(tracker.TrackedObjects))
+	}
+}
+
+func TestTracker_MatchingStagesObjectFilter(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   2.0,
+		HitCounterMax:       10,
+		InitializationDelay: 0,
+		MatchingStages: []MatchingStage{
+			{Name: "cars", DistanceThreshold: 10.0, ObjectFilter: func(obj *TrackedObject) bool {
+				return obj.Label != nil && *obj.Label == "car"
+			}},
+			{Name: "rest"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	labeled := func(x float64, label string) *Detection {
+		detection, err := NewDetection(mat.NewDense(1, 2, []float64{x, 0}), &DetectionConfig{Label: &label})
+		if err != nil {
+			t.Fatalf("Failed to create detection: %v", err)
+		}
+		return detection
+	}
+	tracker.Update([]*Detection{labeled(0, "car"), labeled(30, "person")}, 1, nil)
+	objCar, objPerson := tracker.TrackedObjects[0], tracker.TrackedObjects[1]
+
+	// Only the car gets the loose threshold
+	tracker.Update([]*Detection{labeled(6, "car"), labeled(36, "person")}, 1, nil)
+	if !objCar.IsMatched() || objPerson.IsMatched() {
+		t.Errorf("Expected only the car to be matched (car matched=%v, person matched=%v)", objCar.IsMatched(), objPerson.IsMatched())
+	}
+}
+
+func TestNewTracker_InvalidMatchingStage(t *testing.T) {
+	_, err := NewTracker(&TrackerConfig{
+		MatchingStages: []MatchingStage{{Name: "bad", DistanceThreshold: -1}},
+	})
+	if err == nil {
+		t.Error("Expected error for a negative stage threshold")
+	}
+}
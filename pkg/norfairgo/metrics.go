@@ -2,11 +2,14 @@ package norfairgo
 
 import (
 	"bufio"
-	"encoding/csv"
 	"fmt"
+	"io"
+	"iter"
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -123,12 +126,80 @@ func (inf *InformationFile) SearchString(variableName string) (string, error) {
 
 // PredictionsTextFile generates a text file with tracked objects in MOTChallenge format.
 //
-// The output format is CSV with columns:
+// The output format is CSV with columns (see RowFormatter for other schemas):
 // frame,id,bb_left,bb_top,bb_width,bb_height,-1,-1,-1,-1
 type PredictionsTextFile struct {
+	// RowFormatter returns the CSV fields of each row, e.g. MOTRowFormatter with extra
+	// columns appended (label, score, ...) or MOTWorldRowFormatter.
+	// Default: nil (MOTRowFormatter)
+	RowFormatter PredictionRowFormatter
+
+	// BackfillInitializing buffers the boxes of initializing objects passed to Update
+	// (e.g. from Tracker.AliveObjects) and writes them under the permanent ID once the
+	// object is initialized, so the InitializationDelay frames count towards recall.
+	// Buffers of objects that are no longer passed (e.g. died while initializing) are
+	// dropped. Backfilled rows are written late, so the file is not sorted by frame.
+	// Default: false.
+	BackfillInitializing bool
+
 	length      int
 	textFile    *os.File
-	frameNumber int
+	frameNumber int64
+	pending     map[int][]pendingPrediction // Buffered rows by InitializingID
+}
+
+// pendingPrediction is a row of an initializing object waiting for its permanent ID.
+type pendingPrediction struct {
+	frame    int64
+	estimate *mat.Dense
+}
+
+// PredictionRow is a row written by PredictionsTextFile.
+type PredictionRow struct {
+	Frame    int64
+	ID       int
+	Estimate *mat.Dense        // Relative estimate at Frame: [[x_min, y_min], [x_max, y_max]]
+	Object   TrackedObjectLike // The prediction (for backfilled rows, the object once initialized)
+}
+
+// Box returns the MOTChallenge box [bb_left, bb_top, bb_width, bb_height] of the row.
+func (r PredictionRow) Box() [4]float64 {
+	// Python: obj.estimate[0, 0], obj.estimate[0, 1], obj.estimate[1, 0], obj.estimate[1, 1]
+	return [4]float64{
+		r.Estimate.At(0, 0),
+		r.Estimate.At(0, 1),
+		r.Estimate.At(1, 0) - r.Estimate.At(0, 0),
+		r.Estimate.At(1, 1) - r.Estimate.At(0, 1),
+	}
+}
+
+// PredictionRowFormatter returns the CSV fields of a PredictionsTextFile row.
+type PredictionRowFormatter func(row PredictionRow) ([]string, error)
+
+// MOTRowFormatter formats rows in the MOTChallenge 2D format:
+// frame,id,bb_left,bb_top,bb_width,bb_height,-1,-1,-1,-1
+func MOTRowFormatter(row PredictionRow) ([]string, error) {
+	fields := []string{strconv.FormatInt(row.Frame, 10), strconv.Itoa(row.ID)}
+	for _, v := range row.Box() {
+		fields = append(fields, fmt.Sprintf("%f", v))
+	}
+	return append(fields, "-1", "-1", "-1", "-1"), nil
+}
+
+// MOTWorldRowFormatter formats rows in the MOTChallenge 3D format, filling the
+// world x and y columns with the ground position of each box from projector:
+// frame,id,bb_left,bb_top,bb_width,bb_height,-1,x,y,-1
+func MOTWorldRowFormatter(projector *GroundPlaneProjector) PredictionRowFormatter {
+	return func(row PredictionRow) ([]string, error) {
+		fields, err := MOTRowFormatter(row)
+		if err != nil {
+			return nil, err
+		}
+		position := projector.projectPoint(projector.referencePoint(row.Estimate))
+		fields[7] = fmt.Sprintf("%f", position[0])
+		fields[8] = fmt.Sprintf("%f", position[1])
+		return fields, nil
+	}
 }
 
 // NewPredictionsTextFile creates a new PredictionsTextFile for writing tracking results.
@@ -186,11 +257,18 @@ func NewPredictionsTextFile(inputPath string, savePath string, informationFile *
 // Update writes tracked object information for the current frame.
 //
 // Parameters:
-//   - predictions: List of TrackedObject instances
+//   - predictions: List of TrackedObject instances (including initializing ones
+//     with BackfillInitializing, e.g. Tracker.AliveObjects)
 //   - frameNumber: Optional frame number (if nil, uses auto-incremented counter)
 //
 // Format: frame_number,id,bb_left,bb_top,bb_width,bb_height,-1,-1,-1,-1
-func (ptf *PredictionsTextFile) Update(predictions []*TrackedObject, frameNumber *int) error {
+func (ptf *PredictionsTextFile) Update(predictions []*TrackedObject, frameNumber *int64) error {
+	return ptf.UpdateObjects(AsTrackedObjectLikes(predictions), frameNumber)
+}
+
+// UpdateObjects is Update for any track representation implementing TrackedObjectLike.
+// Each prediction's relative estimate must be a 2-point box [[x_min, y_min], [x_max, y_max]].
+func (ptf *PredictionsTextFile) UpdateObjects(predictions []TrackedObjectLike, frameNumber *int64) error {
 	// Use provided frame number or auto-increment
 	frame := ptf.frameNumber
 	if frameNumber != nil {
@@ -198,24 +276,52 @@ func (ptf *PredictionsTextFile) Update(predictions []*TrackedObject, frameNumber
 	}
 
 	// Write each prediction as CSV row
+	initializing := make(map[int]bool)
 	for _, obj := range predictions {
-		if obj.ID == nil {
+		initID := ptf.backfillID(obj)
+		if initID != nil && obj.(*TrackedObject).IsInitializing {
+			estimate, err := relativeEstimate(obj)
+			if err != nil {
+				return fmt.Errorf("failed to get estimate of initializing object %d: %w", *initID, err)
+			}
+			if ptf.pending == nil {
+				ptf.pending = make(map[int][]pendingPrediction)
+			}
+			pending := pendingPrediction{frame: frame, estimate: mat.DenseCopyOf(estimate)}
+			ptf.pending[*initID] = append(ptf.pending[*initID], pending)
+			initializing[*initID] = true
+			continue
+		}
+
+		id := obj.GetID()
+		if id == nil {
 			continue // Skip objects without IDs
 		}
+		estimate, err := relativeEstimate(obj)
+		if err != nil {
+			return fmt.Errorf("failed to get estimate of object %d: %w", *id, err)
+		}
 
-		// Extract bounding box coordinates
-		// Python: obj.estimate[0, 0], obj.estimate[0, 1], obj.estimate[1, 0], obj.estimate[1, 1]
-		bbLeft := obj.Estimate.At(0, 0)
-		bbTop := obj.Estimate.At(0, 1)
-		bbWidth := obj.Estimate.At(1, 0) - obj.Estimate.At(0, 0)
-		bbHeight := obj.Estimate.At(1, 1) - obj.Estimate.At(0, 1)
+		// Backfill the frames the object spent initializing
+		if initID != nil {
+			for _, pending := range ptf.pending[*initID] {
+				row := PredictionRow{Frame: pending.frame, ID: *id, Estimate: pending.estimate, Object: obj}
+				if err := ptf.writeRow(row); err != nil {
+					return err
+				}
+			}
+			delete(ptf.pending, *initID)
+		}
 
-		// Format: frame,id,bb_left,bb_top,bb_width,bb_height,-1,-1,-1,-1
-		line := fmt.Sprintf("%d,%d,%f,%f,%f,%f,-1,-1,-1,-1\n",
-			frame, *obj.ID, bbLeft, bbTop, bbWidth, bbHeight)
+		if err := ptf.writeRow(PredictionRow{Frame: frame, ID: *id, Estimate: estimate, Object: obj}); err != nil {
+			return err
+		}
+	}
 
-		if _, err := ptf.textFile.WriteString(line); err != nil {
-			return fmt.Errorf("failed to write prediction: %w", err)
+	// Drop buffers of objects that died while initializing
+	for initID := range ptf.pending {
+		if !initializing[initID] {
+			delete(ptf.pending, initID)
 		}
 	}
 
@@ -223,7 +329,7 @@ func (ptf *PredictionsTextFile) Update(predictions []*TrackedObject, frameNumber
 	ptf.frameNumber++
 
 	// Auto-close when sequence complete
-	if ptf.frameNumber > ptf.length {
+	if ptf.frameNumber > int64(ptf.length) {
 		if err := ptf.textFile.Close(); err != nil {
 			return fmt.Errorf("failed to close file: %w", err)
 		}
@@ -233,6 +339,44 @@ func (ptf *PredictionsTextFile) Update(predictions []*TrackedObject, frameNumber
 	return nil
 }
 
+// backfillID returns the InitializingID of obj when backfilling is enabled and obj
+// is a *TrackedObject, or nil.
+func (ptf *PredictionsTextFile) backfillID(obj TrackedObjectLike) *int {
+	if !ptf.BackfillInitializing {
+		return nil
+	}
+	trackedObject, ok := obj.(*TrackedObject)
+	if !ok {
+		return nil
+	}
+	return trackedObject.InitializingID
+}
+
+// writeRow writes one prediction with the RowFormatter.
+func (ptf *PredictionsTextFile) writeRow(row PredictionRow) error {
+	formatter := ptf.RowFormatter
+	if formatter == nil {
+		formatter = MOTRowFormatter
+	}
+	fields, err := formatter(row)
+	if err != nil {
+		return fmt.Errorf("failed to format prediction of object %d: %w", row.ID, err)
+	}
+	if _, err := ptf.textFile.WriteString(strings.Join(fields, ",") + "\n"); err != nil {
+		return fmt.Errorf("failed to write prediction: %w", err)
+	}
+	return nil
+}
+
+// relativeEstimate returns a track's estimate in relative coordinates, using the
+// cached Estimate of a *TrackedObject.
+func relativeEstimate(obj TrackedObjectLike) (*mat.Dense, error) {
+	if trackedObject, ok := obj.(*TrackedObject); ok && trackedObject.Estimate != nil {
+		return trackedObject.Estimate, nil
+	}
+	return obj.GetEstimate(false)
+}
+
 // Close closes the output file (useful for manual cleanup).
 // Safe to call multiple times (idempotent).
 func (ptf *PredictionsTextFile) Close() error {
@@ -260,6 +404,7 @@ type DetectionFileParser struct {
 	matrixDetections [][]float64    // All detections (N x 10 matrix)
 	length           int            // Sequence length
 	sortedByFrame    [][]*Detection // Pre-indexed detections by frame
+	groundTruth      bool           // Loaded from gt/gt.txt (no det/det.txt)
 }
 
 // NewDetectionFileParser creates a new DetectionFileParser.
@@ -270,11 +415,74 @@ type DetectionFileParser struct {
 //
 // Returns: DetectionFileParser instance or error
 func NewDetectionFileParser(inputPath string, informationFile *InformationFile) (*DetectionFileParser, error) {
+	return NewDetectionFileParserWithDialect(inputPath, informationFile, nil)
+}
+
+// NewDetectionFileParserWithDialect creates a new DetectionFileParser using a custom CSV dialect.
+//
+// Parameters:
+//   - inputPath: Path to sequence directory
+//   - informationFile: Optional InformationFile (if nil, will load from inputPath/seqinfo.ini)
+//   - dialect: Optional CSV dialect (nil = auto-detect delimiter and header)
+//
+// Returns: DetectionFileParser instance or error
+func NewDetectionFileParserWithDialect(inputPath string, informationFile *InformationFile, dialect *CSVDialect) (*DetectionFileParser, error) {
+	return NewDetectionFileParserWithConfig(inputPath, informationFile, &DetectionFileParserConfig{Dialect: dialect})
+}
+
+// DetectionFileParserConfig configures how DetectionFileParser reads and filters rows.
+// All fields are optional and can be zero.
+type DetectionFileParserConfig struct {
+	// CSV dialect of the input file.
+	// Default: nil (auto-detect delimiter and header)
+	Dialect *CSVDialect
+
+	// Drop detector rows (det/det.txt) whose confidence (column 7) is below this value.
+	// Default: nil (keep all rows)
+	MinConfidence *float64
+
+	// Keep ground truth rows (gt/gt.txt fallback) whose "consider" flag (column 7)
+	// is 0, e.g. static persons or reflections that MOTChallenge ignores in scoring.
+	// Default: false (inactive rows are dropped)
+	IncludeInactiveGT bool
+
+	// Only keep ground truth rows of these classes (column 8, e.g. 1 = pedestrian
+	// in MOT17). Rows without a class column are kept.
+	// Default: nil (all classes)
+	GTClasses []int
+
+	// Drop ground truth rows whose visibility ratio (column 9) is below this value.
+	// Default: 0.0 (keep all rows)
+	GTMinVisibility float64
+}
+
+// NewDetectionFileParserWithConfig creates a new DetectionFileParser with confidence
+// and ground truth filtering options.
+//
+// In det/det.txt column 7 is the detector confidence, while in gt/gt.txt (used when
+// there is no det/det.txt) it is the "consider" flag, followed by class and
+// visibility. Ground truth rows are therefore filtered by flag, class and visibility
+// and get a confidence of 1 (0 for included inactive rows), instead of being read
+// as detector rows.
+//
+// Parameters:
+//   - inputPath: Path to sequence directory
+//   - informationFile: Optional InformationFile (if nil, will load from inputPath/seqinfo.ini)
+//   - config: Optional configuration (nil = defaults)
+//
+// Returns: DetectionFileParser instance or error
+func NewDetectionFileParserWithConfig(inputPath string, informationFile *InformationFile, config *DetectionFileParserConfig) (*DetectionFileParser, error) {
+	if config == nil {
+		config = &DetectionFileParserConfig{}
+	}
+
 	// Load detections CSV file
+	groundTruth := false
 	detectionsPath := filepath.Join(inputPath, "det/det.txt")
 	file, err := os.Open(detectionsPath)
 	if err != nil {
 		// Try ground truth path as fallback
+		groundTruth = true
 		detectionsPath = filepath.Join(inputPath, "gt/gt.txt")
 		file, err = os.Open(detectionsPath)
 		if err != nil {
@@ -284,20 +492,21 @@ func NewDetectionFileParser(inputPath string, informationFile *InformationFile)
 	defer file.Close()
 
 	// Parse CSV
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	records, err := readMOTRecords(file, config.Dialect)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read CSV: %w", err)
 	}
 
-	// Convert to float64 matrix
-	matrixDetections := make([][]float64, len(records))
-	for i, record := range records {
+	// Convert to float64 matrix, dropping filtered rows
+	matrixDetections := make([][]float64, 0, len(records))
+	for _, record := range records {
 		row := make([]float64, len(record))
 		for j, val := range record {
 			row[j], _ = strconv.ParseFloat(val, 64)
 		}
-		matrixDetections[i] = row
+		if keepMOTRow(row, groundTruth, config) {
+			matrixDetections = append(matrixDetections, row)
+		}
 	}
 
 	// Sort by frame number (column 0)
@@ -335,6 +544,7 @@ func NewDetectionFileParser(inputPath string, informationFile *InformationFile)
 		matrixDetections: matrixDetections,
 		length:           length,
 		sortedByFrame:    make([][]*Detection, length),
+		groundTruth:      groundTruth,
 	}
 
 	// Pre-index detections by frame
@@ -376,18 +586,50 @@ func (dfp *DetectionFileParser) getDetectionsFromFrame(frameNumber int) []*Detec
 	return detections
 }
 
+// DetectionFrame is the detections of one frame together with its frame number.
+type DetectionFrame struct {
+	Number     int64        // 1-indexed frame number in the sequence
+	Detections []*Detection // Detections of the frame (nil if none)
+}
+
+// Frames returns a channel that iterates through the frames of the sequence with
+// their frame numbers, so consumers do not have to count frames themselves. Like
+// Detections, the channel is filled and closed up front.
+func (dfp *DetectionFileParser) Frames() <-chan DetectionFrame {
+	ch := make(chan DetectionFrame, dfp.length)
+	for frame := 1; frame <= dfp.length; frame++ {
+		ch <- DetectionFrame{Number: int64(frame), Detections: dfp.sortedByFrame[frame-1]}
+	}
+	close(ch)
+	return ch
+}
+
 // Detections returns a channel that iterates through detections frame by frame.
 //
 // This implements the iterator protocol using Go channels (matches video.go pattern).
+// All frames are already in memory, so the channel is filled and closed up front:
+// consumers may stop reading early without leaking a goroutine.
+//
+// Deprecated: Use Frames (or All), which also yield the frame numbers.
 func (dfp *DetectionFileParser) Detections() <-chan []*Detection {
-	ch := make(chan []*Detection)
-	go func() {
-		defer close(ch)
+	ch := make(chan []*Detection, dfp.length)
+	for frame := 1; frame <= dfp.length; frame++ {
+		ch <- dfp.sortedByFrame[frame-1]
+	}
+	close(ch)
+	return ch
+}
+
+// All returns an iterator over the 1-indexed frame numbers and detections of the
+// sequence, an alternative to Detections for range-over-func loops.
+func (dfp *DetectionFileParser) All() iter.Seq2[int, []*Detection] {
+	return func(yield func(int, []*Detection) bool) {
 		for frame := 1; frame <= dfp.length; frame++ {
-			ch <- dfp.sortedByFrame[frame-1]
+			if !yield(frame, dfp.sortedByFrame[frame-1]) {
+				return
+			}
 		}
-	}()
-	return ch
+	}
 }
 
 // Length returns the sequence length.
@@ -395,6 +637,138 @@ func (dfp *DetectionFileParser) Length() int {
 	return dfp.length
 }
 
+// IsGroundTruth reports whether the detections were loaded from gt/gt.txt because
+// the sequence has no det/det.txt.
+func (dfp *DetectionFileParser) IsGroundTruth() bool {
+	return dfp.groundTruth
+}
+
+// keepMOTRow applies the DetectionFileParserConfig filters to a parsed row. Ground
+// truth rows that are kept get a confidence of 1, or 0 for included inactive rows
+// (column 7 is their "consider" flag).
+func keepMOTRow(row []float64, groundTruth bool, config *DetectionFileParserConfig) bool {
+	if len(row) < 7 {
+		return true // Dropped later as incomplete
+	}
+	if !groundTruth {
+		return config.MinConfidence == nil || row[6] >= *config.MinConfidence
+	}
+
+	active := row[6] != 0
+	if !active && !config.IncludeInactiveGT {
+		return false
+	}
+	if len(row) >= 8 && config.GTClasses != nil && !slices.Contains(config.GTClasses, int(row[7])) {
+		return false
+	}
+	if len(row) >= 9 && config.GTMinVisibility > 0 && row[8] < config.GTMinVisibility {
+		return false
+	}
+	row[6] = 0
+	if active {
+		row[6] = 1
+	}
+	return true
+}
+
+// =============================================================================
+// CSVDialect - Delimiter and header handling for MOTChallenge text files
+// =============================================================================
+
+// CSVDialect describes how MOTChallenge text files are laid out.
+// All fields are optional and can be zero.
+type CSVDialect struct {
+	// Field delimiter, e.g. ',' or ';'. Use ' ' to split on any run of whitespace.
+	// Default: 0 (auto-detect from the first data line: ',', ';', tab, then whitespace)
+	Delimiter rune
+
+	// Number of leading lines to skip unconditionally (e.g. a header row).
+	// Leading lines whose first field is not numeric are always skipped as headers.
+	// Default: 0
+	HeaderLines int
+}
+
+// readMOTRecords reads delimited records from a MOTChallenge text file.
+//
+// Unlike encoding/csv, rows may have varying numbers of fields, fields are
+// trimmed of surrounding whitespace and blank lines are ignored.
+//
+// Returns: Error if the file has lines but none of them is a numeric row
+func readMOTRecords(r io.Reader, dialect *CSVDialect) ([][]string, error) {
+	if dialect == nil {
+		dialect = &CSVDialect{}
+	}
+
+	delimiter := dialect.Delimiter
+	inHeader := true
+	skippedLines := 0
+	var records [][]string
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if lineNumber <= dialect.HeaderLines {
+			continue
+		}
+
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" {
+			continue
+		}
+
+		// Skip header rows until the first numeric row, which fixes the detected
+		// delimiter (a header may be laid out differently from the data)
+		lineDelimiter := delimiter
+		if lineDelimiter == 0 {
+			lineDelimiter = detectDelimiter(line)
+		}
+		fields := splitMOTLine(line, lineDelimiter)
+		if inHeader {
+			if _, err := strconv.ParseFloat(fields[0], 64); err != nil {
+				skippedLines++
+				continue
+			}
+			inHeader = false
+			delimiter = lineDelimiter
+		}
+
+		records = append(records, fields)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 && skippedLines > 0 {
+		return nil, fmt.Errorf("no numeric rows found after %d header lines", skippedLines)
+	}
+
+	return records, nil
+}
+
+// detectDelimiter guesses the field delimiter of a MOTChallenge line.
+func detectDelimiter(line string) rune {
+	for _, candidate := range []rune{',', ';', '\t'} {
+		if strings.ContainsRune(line, candidate) {
+			return candidate
+		}
+	}
+	return ' '
+}
+
+// splitMOTLine splits a line on delimiter (' ' = any whitespace) and trims each field.
+func splitMOTLine(line string, delimiter rune) []string {
+	if delimiter == ' ' {
+		return strings.Fields(line)
+	}
+
+	fields := strings.Split(line, string(delimiter))
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
@@ -567,6 +941,8 @@ func (df *MetricsDataFrame) Get(videoName, metricName string) (float64, bool) {
 // This is thread-safe for concurrent accumulation across different videos.
 type Accumulators struct {
 	accumulators map[string]*motmetrics.MOTAccumulator // map[videoName]*accumulator
+	recordEvents bool                                  // Record per-frame events in new accumulators
+	recordFrames bool                                  // Record per-frame counts in new accumulators
 	mu           sync.Mutex                            // Thread-safety for concurrent updates
 }
 
@@ -593,10 +969,40 @@ func (a *Accumulators) CreateAccumulator(videoName string) error {
 		return fmt.Errorf("accumulator for video '%s' already exists", videoName)
 	}
 
-	a.accumulators[videoName] = motmetrics.NewMOTAccumulator(videoName)
+	acc := motmetrics.NewMOTAccumulator(videoName)
+	acc.RecordEvents = a.recordEvents
+	acc.RecordFrames = a.recordFrames
+	a.accumulators[videoName] = acc
 	return nil
 }
 
+// SetRecordEvents enables or disables the per-frame event log for all accumulators.
+//
+// Events are only recorded for frames processed while enabled. Recording is off by
+// default since the log grows with every matched, missed and false positive box.
+func (a *Accumulators) SetRecordEvents(enabled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.recordEvents = enabled
+	for _, acc := range a.accumulators {
+		acc.RecordEvents = enabled
+	}
+}
+
+// SetRecordFrames enables or disables recording the counts of every frame, which
+// BootstrapMetrics resamples and FrameSeries exports. Like SetRecordEvents, it only
+// affects frames processed while enabled.
+func (a *Accumulators) SetRecordFrames(enabled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.recordFrames = enabled
+	for _, acc := range a.accumulators {
+		acc.RecordFrames = enabled
+	}
+}
+
 // Update processes a frame for a specific video.
 //
 // Parameters:
@@ -621,6 +1027,46 @@ func (a *Accumulators) Update(gtBBoxes [][]float64, gtIDs []int, predBBoxes [][]
 	return nil
 }
 
+// UpdateFrame processes a frame for a specific video using an explicit frame number.
+//
+// Unlike Update, the frame number is used as-is in the event log, so events line
+// up with the frame numbers of the MOTChallenge files.
+//
+// Parameters:
+//   - frameID: frame number (should increase across calls)
+//   - remaining parameters: same as Update
+//
+// Returns: Error if accumulator doesn't exist
+func (a *Accumulators) UpdateFrame(frameID int64, gtBBoxes [][]float64, gtIDs []int, predBBoxes [][]float64, predIDs []int, videoName string, threshold float64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	acc, exists := a.accumulators[videoName]
+	if !exists {
+		return fmt.Errorf("accumulator for video '%s' not found, call CreateAccumulator first", videoName)
+	}
+
+	acc.UpdateFrame(frameID, gtBBoxes, gtIDs, predBBoxes, predIDs, threshold, hungarianMatching)
+	return nil
+}
+
+// Events returns a copy of the recorded event log for a video.
+//
+// Returns: Events in frame order, or error if accumulator doesn't exist
+func (a *Accumulators) Events(videoName string) ([]motmetrics.Event, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	acc, exists := a.accumulators[videoName]
+	if !exists {
+		return nil, fmt.Errorf("accumulator for video '%s' not found", videoName)
+	}
+
+	events := make([]motmetrics.Event, len(acc.Events))
+	copy(events, acc.Events)
+	return events, nil
+}
+
 // Metrics contains computed MOTChallenge metrics for evaluation output.
 //
 // This matches the output format of py-motmetrics compute_many().
@@ -682,6 +1128,11 @@ func (a *Accumulators) ComputeMetrics() (*Metrics, error) {
 	totalFragmentations := 0
 	totalTracks := 0
 
+	// ID metrics aggregation
+	totalIDTP := 0
+	totalIDFP := 0
+	totalIDFN := 0
+
 	for _, acc := range a.accumulators {
 		totalMatches += acc.NumMatches
 		totalFP += acc.NumFalsePositives
@@ -697,6 +1148,12 @@ func (a *Accumulators) ComputeMetrics() (*Metrics, error) {
 		totalPT += pt
 		totalFragmentations += frag
 		totalTracks += len(acc.TrackLifecycles)
+
+		// Compute ID metrics for this accumulator
+		idtp, idfp, idfn := acc.ComputeIDMetrics(hungarianMatching)
+		totalIDTP += idtp
+		totalIDFP += idfp
+		totalIDFN += idfn
 	}
 
 	// Compute MOTA
@@ -742,6 +1199,19 @@ func (a *Accumulators) ComputeMetrics() (*Metrics, error) {
 		ptPercent = float64(totalPT) / float64(totalTracks) * 100.0
 	}
 
+	// Compute IDP, IDR and IDF1
+	// Formula: IDF1 = 2 * IDTP / (2 * IDTP + IDFP + IDFN)
+	var idp, idr, idf1 float64
+	if totalIDTP+totalIDFP > 0 {
+		idp = float64(totalIDTP) / float64(totalIDTP+totalIDFP)
+	}
+	if totalIDTP+totalIDFN > 0 {
+		idr = float64(totalIDTP) / float64(totalIDTP+totalIDFN)
+	}
+	if 2*totalIDTP+totalIDFP+totalIDFN > 0 {
+		idf1 = 2.0 * float64(totalIDTP) / float64(2*totalIDTP+totalIDFP+totalIDFN)
+	}
+
 	return &Metrics{
 		MOTA:              mota,
 		MOTP:              motp,
@@ -760,9 +1230,9 @@ func (a *Accumulators) ComputeMetrics() (*Metrics, error) {
 		MLCount:           totalML,
 		PTCount:           totalPT,
 		NumTracks:         totalTracks,
-		IDP:               0.0, // Phase 2.3
-		IDR:               0.0, // Phase 2.3
-		IDF1:              0.0, // Phase 2.3
+		IDP:               idp,
+		IDR:               idr,
+		IDF1:              idf1,
 	}, nil
 }
 
@@ -832,6 +1302,131 @@ func (a *Accumulators) SaveMetrics(filePath string) error {
 	return nil
 }
 
+// SaveEvents exports the recorded event logs of all videos to a CSV file.
+//
+// The columns follow the py-motmetrics events DataFrame (Event is a running index
+// within each frame). OId is empty for false positives, HId is empty for misses,
+// and D is NaN for both. Videos are written in sorted order.
+//
+// Parameters:
+//   - filePath: Path to output CSV file
+//
+// Returns: Error if file creation or writing fails
+func (a *Accumulators) SaveEvents(filePath string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create events file: %w", err)
+	}
+	defer file.Close()
+
+	videoNames := make([]string, 0, len(a.accumulators))
+	for videoName := range a.accumulators {
+		videoNames = append(videoNames, videoName)
+	}
+	sort.Strings(videoNames)
+
+	w := bufio.NewWriter(file)
+	fmt.Fprintf(w, "Video,FrameId,Event,Type,OId,HId,D\n")
+	for _, videoName := range videoNames {
+		eventIndex := 0
+		lastFrame := int64(0)
+		for i, event := range a.accumulators[videoName].Events {
+			if i == 0 || event.FrameID != lastFrame {
+				eventIndex = 0
+				lastFrame = event.FrameID
+			}
+
+			oid, hid := "", ""
+			if event.GTID >= 0 {
+				oid = strconv.Itoa(event.GTID)
+			}
+			if event.PredID >= 0 {
+				hid = strconv.Itoa(event.PredID)
+			}
+			distance := "NaN"
+			if !math.IsNaN(event.Distance) {
+				distance = strconv.FormatFloat(event.Distance, 'f', 6, 64)
+			}
+
+			fmt.Fprintf(w, "%s,%d,%d,%s,%s,%s,%s\n", videoName, event.FrameID, eventIndex, event.Type, oid, hid, distance)
+			eventIndex++
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write events file: %w", err)
+	}
+	return nil
+}
+
+// ConfusionReport lists which tracker IDs covered each GT object of a video.
+//
+// Each GT object has its tracker IDs with the frame ranges they covered it, so
+// identity fragmentation (one GT object split across several tracker IDs) is
+// easy to spot.
+//
+// Returns: Coverage per GT object sorted by GT ID, or error if accumulator doesn't exist
+func (a *Accumulators) ConfusionReport(videoName string) ([]motmetrics.GTCoverage, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	acc, exists := a.accumulators[videoName]
+	if !exists {
+		return nil, fmt.Errorf("accumulator for video '%s' not found", videoName)
+	}
+
+	return acc.ConfusionReport(), nil
+}
+
+// SaveConfusionReport exports the confusion reports of all videos to a CSV file.
+//
+// One row is written per coverage span. GT objects that were never matched get a
+// single row with empty HId, StartFrame and EndFrame. Videos are written in sorted order.
+//
+// Parameters:
+//   - filePath: Path to output CSV file
+//
+// Returns: Error if file creation or writing fails
+func (a *Accumulators) SaveConfusionReport(filePath string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create confusion report file: %w", err)
+	}
+	defer file.Close()
+
+	videoNames := make([]string, 0, len(a.accumulators))
+	for videoName := range a.accumulators {
+		videoNames = append(videoNames, videoName)
+	}
+	sort.Strings(videoNames)
+
+	w := bufio.NewWriter(file)
+	fmt.Fprintf(w, "Video,OId,NumHIds,HId,StartFrame,EndFrame,Frames\n")
+	for _, videoName := range videoNames {
+		for _, coverage := range a.accumulators[videoName].ConfusionReport() {
+			if len(coverage.Spans) == 0 {
+				fmt.Fprintf(w, "%s,%d,0,,,,0\n", videoName, coverage.GTID)
+				continue
+			}
+			for _, span := range coverage.Spans {
+				fmt.Fprintf(w, "%s,%d,%d,%d,%d,%d,%d\n", videoName, coverage.GTID, len(coverage.PredIDs),
+					span.PredID, span.StartFrame, span.EndFrame, span.NumFrames)
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write confusion report file: %w", err)
+	}
+	return nil
+}
+
 // Reset clears all accumulators.
 func (a *Accumulators) Reset() {
 	a.mu.Lock()
@@ -849,14 +1444,67 @@ func (a *Accumulators) Reset() {
 // Data is organized by frame number (1-indexed) for efficient frame-by-frame access.
 type MOTChallengeData struct {
 	VideoName string
-	Frames    map[int]*MOTChallengeFrame // map[frameID]*frame
+	Frames    map[int64]*MOTChallengeFrame // map[frameID]*frame
 }
 
 // MOTChallengeFrame holds all detections/tracks for a single frame.
+//
+// Confidences, Classes and Visibilities are parallel to BBoxes and hold the
+// optional MOTChallenge columns 7-9. In gt.txt files column 7 is the "consider"
+// flag (0 = ignore, 1 = evaluate).
 type MOTChallengeFrame struct {
-	FrameID int
-	BBoxes  [][]float64 // [x_min, y_min, x_max, y_max]
-	IDs     []int
+	FrameID      int64
+	BBoxes       [][]float64 // [x_min, y_min, x_max, y_max]
+	IDs          []int
+	Confidences  []float64 // Column 7: confidence (or gt "consider" flag), 1 if missing
+	Classes      []int     // Column 8: class ID, -1 if missing
+	Visibilities []float64 // Column 9: visibility ratio, -1 if missing
+}
+
+// confidenceAt returns the confidence of row i, or 1 if the column was not populated.
+func (f *MOTChallengeFrame) confidenceAt(i int) float64 {
+	if i < len(f.Confidences) {
+		return f.Confidences[i]
+	}
+	return 1.0
+}
+
+// classAt returns the class of row i, or -1 if the column was not populated.
+func (f *MOTChallengeFrame) classAt(i int) int {
+	if i < len(f.Classes) {
+		return f.Classes[i]
+	}
+	return -1
+}
+
+// visibilityAt returns the visibility of row i, or -1 if the column was not populated.
+func (f *MOTChallengeFrame) visibilityAt(i int) float64 {
+	if i < len(f.Visibilities) {
+		return f.Visibilities[i]
+	}
+	return -1.0
+}
+
+// addRow appends a single annotation row to the given frame, creating it if needed.
+func (d *MOTChallengeData) addRow(frameID int64, id int, bbox []float64, conf float64, class int, visibility float64) {
+	frame, exists := d.Frames[frameID]
+	if !exists {
+		frame = &MOTChallengeFrame{
+			FrameID:      frameID,
+			BBoxes:       make([][]float64, 0),
+			IDs:          make([]int, 0),
+			Confidences:  make([]float64, 0),
+			Classes:      make([]int, 0),
+			Visibilities: make([]float64, 0),
+		}
+		d.Frames[frameID] = frame
+	}
+
+	frame.BBoxes = append(frame.BBoxes, bbox)
+	frame.IDs = append(frame.IDs, id)
+	frame.Confidences = append(frame.Confidences, conf)
+	frame.Classes = append(frame.Classes, class)
+	frame.Visibilities = append(frame.Visibilities, visibility)
 }
 
 // LoadMotchallenge loads MOTChallenge format CSV file into structured data.
@@ -868,21 +1516,31 @@ type MOTChallengeFrame struct {
 //
 // CSV Format: frame,id,bb_left,bb_top,bb_width,bb_height,conf,x,y,z
 func LoadMotchallenge(csvPath string) (*MOTChallengeData, error) {
+	return LoadMotchallengeWithDialect(csvPath, nil)
+}
+
+// LoadMotchallengeWithDialect loads a MOTChallenge format file using a custom CSV dialect.
+//
+// Parameters:
+//   - csvPath: Path to MOTChallenge CSV file (gt.txt or predictions.txt)
+//   - dialect: Optional CSV dialect (nil = auto-detect delimiter and header)
+//
+// Returns: MOTChallengeData with frames organized by frame number
+func LoadMotchallengeWithDialect(csvPath string, dialect *CSVDialect) (*MOTChallengeData, error) {
 	file, err := os.Open(csvPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open MOTChallenge file: %w", err)
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	records, err := readMOTRecords(file, dialect)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read CSV: %w", err)
 	}
 
 	data := &MOTChallengeData{
 		VideoName: filepath.Base(filepath.Dir(csvPath)), // Extract video name from path
-		Frames:    make(map[int]*MOTChallengeFrame),
+		Frames:    make(map[int64]*MOTChallengeFrame),
 	}
 
 	for _, record := range records {
@@ -891,7 +1549,7 @@ func LoadMotchallenge(csvPath string) (*MOTChallengeData, error) {
 		}
 
 		// Parse fields
-		frameID, err := strconv.Atoi(record[0])
+		frameID, err := strconv.ParseInt(record[0], 10, 64)
 		if err != nil {
 			continue
 		}
@@ -904,6 +1562,26 @@ func LoadMotchallenge(csvPath string) (*MOTChallengeData, error) {
 		bbWidth, _ := strconv.ParseFloat(record[4], 64)
 		bbHeight, _ := strconv.ParseFloat(record[5], 64)
 
+		// Optional columns (conf/consider, class, visibility)
+		conf := 1.0
+		if len(record) > 6 {
+			if v, err := strconv.ParseFloat(strings.TrimSpace(record[6]), 64); err == nil {
+				conf = v
+			}
+		}
+		class := -1
+		if len(record) > 7 {
+			if v, err := strconv.ParseFloat(strings.TrimSpace(record[7]), 64); err == nil {
+				class = int(v)
+			}
+		}
+		visibility := -1.0
+		if len(record) > 8 {
+			if v, err := strconv.ParseFloat(strings.TrimSpace(record[8]), 64); err == nil {
+				visibility = v
+			}
+		}
+
 		// Convert to corner format [x_min, y_min, x_max, y_max]
 		bbox := []float64{
 			bbLeft,
@@ -912,25 +1590,48 @@ func LoadMotchallenge(csvPath string) (*MOTChallengeData, error) {
 			bbTop + bbHeight,
 		}
 
-		// Get or create frame
-		frame, exists := data.Frames[frameID]
-		if !exists {
-			frame = &MOTChallengeFrame{
-				FrameID: frameID,
-				BBoxes:  make([][]float64, 0),
-				IDs:     make([]int, 0),
-			}
-			data.Frames[frameID] = frame
-		}
-
 		// Add detection to frame
-		frame.BBoxes = append(frame.BBoxes, bbox)
-		frame.IDs = append(frame.IDs, id)
+		data.addRow(frameID, id, bbox, conf, class, visibility)
 	}
 
 	return data, nil
 }
 
+// CompareConfig contains optional configuration for CompareDataframesWithConfig.
+// All fields are optional and can be nil/zero.
+type CompareConfig struct {
+	// DistractorClasses are GT classes (MOTChallenge column 8) that should neither be
+	// rewarded nor penalised, e.g. MOT17 static persons (7), distractors (8) and
+	// reflections (12). Predictions matched to a distractor GT box are removed, then
+	// the distractor GT rows themselves are removed.
+	DistractorClasses []int
+
+	// IgnoreRegions are polygons of (x, y) vertices in image coordinates.
+	// GT rows and predictions whose box center lies inside any region are removed.
+	IgnoreRegions [][][2]float64
+
+	// FrameOffset is added to prediction frame numbers to align them with GT frames,
+	// e.g. 1 for predictions written with 0-indexed frames.
+	// Default: 0
+	FrameOffset int
+
+	// FrameRatio is the number of GT frames per prediction frame (GT fps / prediction fps),
+	// e.g. 2 when predictions were generated on every 2nd frame. GT frames without a
+	// corresponding prediction frame are skipped instead of being counted as misses.
+	// Default: 0 (treated as 1)
+	FrameRatio float64
+
+	// RecordEvents enables the per-frame event log on the returned Accumulators
+	// (see Accumulators.Events and Accumulators.SaveEvents).
+	// Default: false
+	RecordEvents bool
+
+	// RecordFrames enables the per-frame counts on the returned Accumulators, which
+	// Accumulators.BootstrapMetrics needs.
+	// Default: false
+	RecordFrames bool
+}
+
 // CompareDataframes performs MOTChallenge evaluation on loaded GT and predictions.
 //
 // Parameters:
@@ -941,19 +1642,43 @@ func LoadMotchallenge(csvPath string) (*MOTChallengeData, error) {
 //
 // Returns: Populated Accumulators with all frames processed
 func CompareDataframes(gt, predictions *MOTChallengeData, distanceFunc string, threshold float64) (*Accumulators, error) {
+	return CompareDataframesWithConfig(gt, predictions, distanceFunc, threshold, nil)
+}
+
+// CompareDataframesWithConfig performs MOTChallenge evaluation with distractor and
+// ignore-region handling, following the preprocessing of the official MOT devkit.
+//
+// Parameters:
+//   - gt: Ground truth MOTChallenge data
+//   - predictions: Tracker predictions MOTChallenge data
+//   - distanceFunc: Distance function name ("iou")
+//   - threshold: Distance threshold for valid matches (default 0.5 for IoU)
+//   - config: Optional configuration (can be nil)
+//
+// Returns: Populated Accumulators with all frames processed
+func CompareDataframesWithConfig(gt, predictions *MOTChallengeData, distanceFunc string, threshold float64, config *CompareConfig) (*Accumulators, error) {
 	// Only IoU distance supported for now (Phase 3)
 	if distanceFunc != "iou" && distanceFunc != "" {
 		return nil, fmt.Errorf("unsupported distance function: %s (only 'iou' supported)", distanceFunc)
 	}
 
+	if config != nil {
+		gt, predictions = resampleFrames(gt, predictions, config)
+		gt, predictions = removeIgnored(gt, predictions, threshold, config)
+	}
+
 	accumulators := NewAccumulators()
+	if config != nil {
+		accumulators.SetRecordEvents(config.RecordEvents)
+		accumulators.SetRecordFrames(config.RecordFrames)
+	}
 	videoName := gt.VideoName
 	if err := accumulators.CreateAccumulator(videoName); err != nil {
 		return nil, err
 	}
 
 	// Determine frame range (union of GT and prediction frames)
-	allFrameIDs := make(map[int]bool)
+	allFrameIDs := make(map[int64]bool)
 	for frameID := range gt.Frames {
 		allFrameIDs[frameID] = true
 	}
@@ -962,7 +1687,7 @@ func CompareDataframes(gt, predictions *MOTChallengeData, distanceFunc string, t
 	}
 
 	// Convert to sorted slice
-	frameIDs := make([]int, 0, len(allFrameIDs))
+	frameIDs := make([]int64, 0, len(allFrameIDs))
 	for frameID := range allFrameIDs {
 		frameIDs = append(frameIDs, frameID)
 	}
@@ -995,7 +1720,7 @@ func CompareDataframes(gt, predictions *MOTChallengeData, distanceFunc string, t
 		}
 
 		// Update accumulator for this frame
-		if err := accumulators.Update(gtBBoxes, gtIDs, predBBoxes, predIDs, videoName, threshold); err != nil {
+		if err := accumulators.UpdateFrame(frameID, gtBBoxes, gtIDs, predBBoxes, predIDs, videoName, threshold); err != nil {
 			return nil, err
 		}
 	}
@@ -1003,6 +1728,122 @@ func CompareDataframes(gt, predictions *MOTChallengeData, distanceFunc string, t
 	return accumulators, nil
 }
 
+// resampleFrames aligns prediction frame numbers with GT frame numbers using the
+// configured offset and frame ratio, dropping GT frames that have no prediction frame.
+//
+// Prediction frame p corresponds to GT frame round((p-1)*ratio) + 1 + offset.
+func resampleFrames(gt, predictions *MOTChallengeData, config *CompareConfig) (*MOTChallengeData, *MOTChallengeData) {
+	ratio := config.FrameRatio
+	if ratio <= 0 {
+		ratio = 1.0
+	}
+	if ratio == 1.0 && config.FrameOffset == 0 {
+		return gt, predictions
+	}
+
+	offset := int64(config.FrameOffset)
+	toGT := func(predFrame int64) int64 {
+		return int64(math.Round(float64(predFrame-1)*ratio)) + 1 + offset
+	}
+	toPred := func(gtFrame int64) int64 {
+		return int64(math.Round(float64(gtFrame-1-offset)/ratio)) + 1
+	}
+
+	// Candidate GT frames: every GT frame plus every mapped prediction frame
+	candidates := make(map[int64]bool, len(gt.Frames)+len(predictions.Frames))
+	for frameID := range gt.Frames {
+		candidates[frameID] = true
+	}
+	for frameID := range predictions.Frames {
+		candidates[toGT(frameID)] = true
+	}
+
+	resampledGT := &MOTChallengeData{VideoName: gt.VideoName, Frames: make(map[int64]*MOTChallengeFrame)}
+	resampledPred := &MOTChallengeData{VideoName: predictions.VideoName, Frames: make(map[int64]*MOTChallengeFrame)}
+
+	for gtFrameID := range candidates {
+		predFrameID := toPred(gtFrameID)
+		if toGT(predFrameID) != gtFrameID {
+			continue // No prediction frame lands on this GT frame
+		}
+
+		if frame, exists := gt.Frames[gtFrameID]; exists {
+			resampledGT.Frames[gtFrameID] = frame
+		}
+		if frame, exists := predictions.Frames[predFrameID]; exists {
+			relabelled := *frame
+			relabelled.FrameID = gtFrameID
+			resampledPred.Frames[gtFrameID] = &relabelled
+		}
+	}
+
+	return resampledGT, resampledPred
+}
+
+// removeIgnored applies ignore-region and distractor filtering to GT and predictions.
+//
+// Python (py-motmetrics preprocessResult):
+//
+//	match predictions to all GT boxes per frame, drop predictions matched to
+//	distractor classes, then drop the distractor GT rows
+func removeIgnored(gt, predictions *MOTChallengeData, threshold float64, config *CompareConfig) (*MOTChallengeData, *MOTChallengeData) {
+	inIgnoreRegion := func(bbox []float64) bool {
+		cx := (bbox[0] + bbox[2]) / 2
+		cy := (bbox[1] + bbox[3]) / 2
+		for _, region := range config.IgnoreRegions {
+			if PointInPolygon(cx, cy, region) {
+				return true
+			}
+		}
+		return false
+	}
+
+	distractors := make(map[int]bool, len(config.DistractorClasses))
+	for _, class := range config.DistractorClasses {
+		distractors[class] = true
+	}
+
+	gt = filterMOTChallengeData(gt, func(frame *MOTChallengeFrame, i int) bool {
+		return !inIgnoreRegion(frame.BBoxes[i])
+	})
+	predictions = filterMOTChallengeData(predictions, func(frame *MOTChallengeFrame, i int) bool {
+		return !inIgnoreRegion(frame.BBoxes[i])
+	})
+
+	if len(distractors) == 0 {
+		return gt, predictions
+	}
+
+	// Find predictions matched to distractor GT boxes
+	removedPreds := make(map[int64]map[int]bool)
+	for frameID, gtFrame := range gt.Frames {
+		predFrame := predictions.Frames[frameID]
+		if predFrame == nil || len(gtFrame.BBoxes) == 0 || len(predFrame.BBoxes) == 0 {
+			continue
+		}
+
+		distanceMatrix := motmetrics.ComputeIoUMatrix(gtFrame.BBoxes, predFrame.BBoxes)
+		matches, _, _ := hungarianMatching(distanceMatrix, threshold)
+		for _, match := range matches {
+			if distractors[gtFrame.classAt(match[0])] {
+				if removedPreds[frameID] == nil {
+					removedPreds[frameID] = make(map[int]bool)
+				}
+				removedPreds[frameID][match[1]] = true
+			}
+		}
+	}
+
+	predictions = filterMOTChallengeData(predictions, func(frame *MOTChallengeFrame, i int) bool {
+		return !removedPreds[frame.FrameID][i]
+	})
+	gt = filterMOTChallengeData(gt, func(frame *MOTChallengeFrame, i int) bool {
+		return !distractors[frame.classAt(i)]
+	})
+
+	return gt, predictions
+}
+
 // EvalMotChallenge performs complete MOTChallenge evaluation from file paths.
 //
 // Parameters:
@@ -1042,3 +1883,174 @@ func EvalMotChallenge(gtPath, predPath string, metricsToCompute []string) (*Metr
 
 	return metrics, nil
 }
+
+// =============================================================================
+// Per-Label Evaluation - Class-aware MOT17/MOT20 protocol
+// =============================================================================
+
+// LabelEvalConfig configures class-aware MOTChallenge evaluation.
+//
+// All fields are optional; a nil config evaluates every class found in the
+// ground truth and drops GT rows whose "consider" flag is 0.
+type LabelEvalConfig struct {
+	// Classes to evaluate (MOTChallenge column 8).
+	// Default: nil (every class present in the ground truth)
+	Classes []int
+
+	// Minimum visibility ratio (column 9) for a GT row to be evaluated.
+	// Rows with unknown visibility (-1) are always kept.
+	// Default: 0.0 (keep all)
+	MinVisibility float64
+
+	// Keep GT rows whose "consider" flag (column 7) is 0.
+	// Default: false (rows are dropped, matching the official devkit)
+	IgnoreConsiderFlag bool
+
+	// Distractor and ignore-region handling, applied to the unfiltered ground
+	// truth before per-class filtering.
+	// Default: nil (disabled)
+	Compare *CompareConfig
+}
+
+// CompareDataframesPerLabel performs MOTChallenge evaluation separately for each class.
+//
+// GT rows are filtered by class, "consider" flag and visibility before matching.
+// Predictions with a known class (>= 0) are only evaluated against GT of the same
+// class, while predictions with unknown class (-1, as written by PredictionsTextFile)
+// are evaluated against every class.
+//
+// Parameters:
+//   - gt: Ground truth MOTChallenge data
+//   - predictions: Tracker predictions MOTChallenge data
+//   - distanceFunc: Distance function name ("iou")
+//   - threshold: Distance threshold for valid matches (default 0.5 for IoU)
+//   - config: Optional evaluation configuration (can be nil)
+//
+// Returns: Populated Accumulators for each evaluated class
+func CompareDataframesPerLabel(gt, predictions *MOTChallengeData, distanceFunc string, threshold float64, config *LabelEvalConfig) (map[int]*Accumulators, error) {
+	if config == nil {
+		config = &LabelEvalConfig{}
+	}
+
+	if config.Compare != nil {
+		gt, predictions = removeIgnored(gt, predictions, threshold, config.Compare)
+	}
+
+	// Only evaluate GT rows that pass the consider/visibility filters
+	gtConsidered := filterMOTChallengeData(gt, func(frame *MOTChallengeFrame, i int) bool {
+		if !config.IgnoreConsiderFlag && frame.confidenceAt(i) == 0 {
+			return false
+		}
+		visibility := frame.visibilityAt(i)
+		return visibility < 0 || visibility >= config.MinVisibility
+	})
+
+	classes := config.Classes
+	if len(classes) == 0 {
+		classes = uniqueClasses(gtConsidered)
+	}
+
+	results := make(map[int]*Accumulators, len(classes))
+	for _, class := range classes {
+		gtClass := filterMOTChallengeData(gtConsidered, func(frame *MOTChallengeFrame, i int) bool {
+			return frame.classAt(i) == class
+		})
+		predClass := filterMOTChallengeData(predictions, func(frame *MOTChallengeFrame, i int) bool {
+			return frame.classAt(i) < 0 || frame.classAt(i) == class
+		})
+
+		accumulators, err := CompareDataframes(gtClass, predClass, distanceFunc, threshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare class %d: %w", class, err)
+		}
+		results[class] = accumulators
+	}
+
+	return results, nil
+}
+
+// EvalMotChallengePerLabel performs class-aware MOTChallenge evaluation from file paths.
+//
+// Parameters:
+//   - gtPath: Path to ground truth CSV file (e.g., "gt/gt.txt")
+//   - predPath: Path to predictions CSV file (e.g., "predictions.txt")
+//   - config: Optional evaluation configuration (can be nil)
+//
+// Returns: Metrics for each evaluated class, keyed by class ID
+func EvalMotChallengePerLabel(gtPath, predPath string, config *LabelEvalConfig) (map[int]*Metrics, error) {
+	gt, err := LoadMotchallenge(gtPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ground truth: %w", err)
+	}
+
+	predictions, err := LoadMotchallenge(predPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load predictions: %w", err)
+	}
+
+	perLabel, err := CompareDataframesPerLabel(gt, predictions, "iou", 0.5, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare dataframes: %w", err)
+	}
+
+	results := make(map[int]*Metrics, len(perLabel))
+	for class, accumulators := range perLabel {
+		metrics, err := accumulators.ComputeMetrics()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute metrics for class %d: %w", class, err)
+		}
+		results[class] = metrics
+	}
+
+	return results, nil
+}
+
+// filterMOTChallengeData returns a copy of data containing only the rows for which keep returns true.
+// Frames are kept even if they end up empty so that frame counting is unaffected.
+func filterMOTChallengeData(data *MOTChallengeData, keep func(frame *MOTChallengeFrame, i int) bool) *MOTChallengeData {
+	filtered := &MOTChallengeData{
+		VideoName: data.VideoName,
+		Frames:    make(map[int64]*MOTChallengeFrame, len(data.Frames)),
+	}
+
+	for frameID, frame := range data.Frames {
+		out := &MOTChallengeFrame{
+			FrameID:      frameID,
+			BBoxes:       make([][]float64, 0),
+			IDs:          make([]int, 0),
+			Confidences:  make([]float64, 0),
+			Classes:      make([]int, 0),
+			Visibilities: make([]float64, 0),
+		}
+		for i := range frame.BBoxes {
+			if !keep(frame, i) {
+				continue
+			}
+			out.BBoxes = append(out.BBoxes, frame.BBoxes[i])
+			out.IDs = append(out.IDs, frame.IDs[i])
+			out.Confidences = append(out.Confidences, frame.confidenceAt(i))
+			out.Classes = append(out.Classes, frame.classAt(i))
+			out.Visibilities = append(out.Visibilities, frame.visibilityAt(i))
+		}
+		filtered.Frames[frameID] = out
+	}
+
+	return filtered
+}
+
+// uniqueClasses returns the sorted set of class IDs present in data.
+func uniqueClasses(data *MOTChallengeData) []int {
+	seen := make(map[int]bool)
+	for _, frame := range data.Frames {
+		for i := range frame.BBoxes {
+			seen[frame.classAt(i)] = true
+		}
+	}
+
+	classes := make([]int, 0, len(seen))
+	for class := range seen {
+		classes = append(classes, class)
+	}
+	sort.Ints(classes)
+	return classes
+}
@@ -0,0 +1,224 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/nmichlo/norfair-go/internal/motmetrics"
+)
+
+// =============================================================================
+// Bootstrap Confidence Intervals for MOT Metrics
+// =============================================================================
+
+// BootstrapConfig configures Accumulators.BootstrapMetrics.
+// All fields are optional and can be zero.
+type BootstrapConfig struct {
+	// Number of bootstrap resamples.
+	// Default: 1000
+	Samples int
+
+	// Confidence level of the intervals, in (0, 1).
+	// Default: 0.95
+	Confidence float64
+
+	// Resample whole sequences instead of frames. Frames of a sequence are
+	// correlated, so with enough sequences this gives more honest intervals.
+	// Default: false (resample frames across all sequences)
+	BySequence bool
+
+	// Seed of the resampling, so intervals are reproducible.
+	// Default: 0
+	Seed int64
+}
+
+// ConfidenceInterval is a metric value with its bootstrap percentile interval.
+type ConfidenceInterval struct {
+	Value float64 // Metric computed on all frames
+	Lower float64 // Lower bound of the interval
+	Upper float64 // Upper bound of the interval
+}
+
+// String formats the interval as "value [lower, upper]".
+func (ci ConfidenceInterval) String() string {
+	return fmt.Sprintf("%.4f [%.4f, %.4f]", ci.Value, ci.Lower, ci.Upper)
+}
+
+// BootstrapMetrics holds approximate confidence intervals of MOT metrics.
+type BootstrapMetrics struct {
+	MOTA       ConfidenceInterval
+	IDF1       ConfidenceInterval
+	Samples    int     // Number of resamples
+	Confidence float64 // Confidence level of the intervals
+}
+
+// bootstrapUnit holds the summed counts of a resampled unit (a frame or a sequence).
+type bootstrapUnit struct {
+	errors      int // FP + FN + IDSW
+	objects     int // GT objects
+	predictions int // Tracker objects
+	idtp        int // Identity true positives under the global ID assignment
+}
+
+// BootstrapMetrics computes approximate confidence intervals of MOTA and IDF1 by
+// bootstrap resampling over frames (or sequences, see BootstrapConfig.BySequence),
+// so differences between two tracker configurations can be judged against the
+// sampling noise of the benchmark.
+//
+// The counts of every frame must have been recorded (see SetRecordFrames and
+// CompareConfig.RecordFrames). For IDF1 the global ID assignment of each sequence
+// is computed once on all frames and kept fixed while resampling.
+//
+// Example:
+//
+//	accumulators, _ := norfairgo.CompareDataframesWithConfig(gt, pred, "iou", 0.5,
+//	    &norfairgo.CompareConfig{RecordFrames: true})
+//	bootstrap, _ := accumulators.BootstrapMetrics(nil)
+//	fmt.Println("MOTA", bootstrap.MOTA) // e.g. 0.6123 [0.5981, 0.6270]
+//
+// Returns: Error if frames were not recorded or the config is invalid
+func (a *Accumulators) BootstrapMetrics(config *BootstrapConfig) (*BootstrapMetrics, error) {
+	if config == nil {
+		config = &BootstrapConfig{}
+	}
+	samples := config.Samples
+	if samples == 0 {
+		samples = 1000
+	}
+	confidence := config.Confidence
+	if confidence == 0 {
+		confidence = 0.95
+	}
+	if samples < 0 {
+		return nil, fmt.Errorf("samples must be > 0, got %d", samples)
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return nil, fmt.Errorf("confidence must be in (0, 1), got %f", confidence)
+	}
+
+	units, err := a.bootstrapUnits(config.BySequence)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resample units with replacement
+	rng := rand.New(rand.NewSource(config.Seed))
+	motas := make([]float64, samples)
+	idf1s := make([]float64, samples)
+	for s := 0; s < samples; s++ {
+		var total bootstrapUnit
+		for range units {
+			unit := units[rng.Intn(len(units))]
+			total.errors += unit.errors
+			total.objects += unit.objects
+			total.predictions += unit.predictions
+			total.idtp += unit.idtp
+		}
+		motas[s], idf1s[s] = total.metrics()
+	}
+
+	var total bootstrapUnit
+	for _, unit := range units {
+		total.errors += unit.errors
+		total.objects += unit.objects
+		total.predictions += unit.predictions
+		total.idtp += unit.idtp
+	}
+	mota, idf1 := total.metrics()
+
+	return &BootstrapMetrics{
+		MOTA:       percentileInterval(mota, motas, confidence),
+		IDF1:       percentileInterval(idf1, idf1s, confidence),
+		Samples:    samples,
+		Confidence: confidence,
+	}, nil
+}
+
+// bootstrapUnits collects the counts of every recorded frame, or of every sequence.
+func (a *Accumulators) bootstrapUnits(bySequence bool) ([]bootstrapUnit, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	videoNames := make([]string, 0, len(a.accumulators))
+	for videoName := range a.accumulators {
+		videoNames = append(videoNames, videoName)
+	}
+	sort.Strings(videoNames)
+
+	var units []bootstrapUnit
+	for _, videoName := range videoNames {
+		acc := a.accumulators[videoName]
+		if acc.FrameID > 0 && len(acc.Frames) == 0 {
+			return nil, fmt.Errorf("no per-frame counts recorded for video '%s', enable SetRecordFrames before updating", videoName)
+		}
+
+		assignment := acc.IDAssignment(hungarianMatching)
+		var sequence bootstrapUnit
+		for _, frame := range acc.Frames {
+			unit := frameBootstrapUnit(frame, assignment)
+			if bySequence {
+				sequence.errors += unit.errors
+				sequence.objects += unit.objects
+				sequence.predictions += unit.predictions
+				sequence.idtp += unit.idtp
+			} else {
+				units = append(units, unit)
+			}
+		}
+		if bySequence && len(acc.Frames) > 0 {
+			units = append(units, sequence)
+		}
+	}
+
+	if len(units) == 0 {
+		return nil, fmt.Errorf("no frames to resample")
+	}
+	if bySequence && len(units) < 2 {
+		return nil, fmt.Errorf("resampling sequences requires at least 2 sequences, got %d", len(units))
+	}
+	return units, nil
+}
+
+// frameBootstrapUnit converts the counts of a frame to a bootstrap unit.
+func frameBootstrapUnit(frame motmetrics.FrameCounts, assignment map[[2]int]bool) bootstrapUnit {
+	unit := bootstrapUnit{
+		errors:      frame.FalsePositives + frame.Misses + frame.Switches,
+		objects:     frame.Objects,
+		predictions: frame.Predictions,
+	}
+	for _, pair := range frame.IDPairs {
+		if assignment[pair] {
+			unit.idtp++
+		}
+	}
+	return unit
+}
+
+// metrics computes MOTA and IDF1 from summed counts (as ComputeMetrics does).
+func (u bootstrapUnit) metrics() (mota, idf1 float64) {
+	if u.objects > 0 {
+		mota = 1.0 - float64(u.errors)/float64(u.objects)
+	}
+	if u.objects+u.predictions > 0 {
+		// IDF1 = 2 * IDTP / (2 * IDTP + IDFP + IDFN) = 2 * IDTP / (GT + predictions)
+		idf1 = 2.0 * float64(u.idtp) / float64(u.objects+u.predictions)
+	}
+	return mota, idf1
+}
+
+// percentileInterval returns the percentile bootstrap interval of the samples.
+func percentileInterval(value float64, samples []float64, confidence float64) ConfidenceInterval {
+	sort.Float64s(samples)
+	alpha := (1 - confidence) / 2
+	quantile := func(q float64) float64 {
+		index := int(math.Round(q * float64(len(samples)-1)))
+		return samples[index]
+	}
+	return ConfidenceInterval{
+		Value: value,
+		Lower: quantile(alpha),
+		Upper: quantile(1 - alpha),
+	}
+}
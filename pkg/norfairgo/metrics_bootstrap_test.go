@@ -0,0 +1,119 @@
+package norfairgo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+)
+
+// =============================================================================
+// Bootstrap Confidence Interval Tests
+// =============================================================================
+
+// newBootstrapAccumulators evaluates numFrames frames of two GT objects per video,
+// where the tracker misses each object with probability missRate and swaps IDs once.
+func newBootstrapAccumulators(t *testing.T, videos []string, numFrames int, missRate float64) *Accumulators {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	accumulators := NewAccumulators()
+	accumulators.SetRecordFrames(true)
+	for _, video := range videos {
+		if err := accumulators.CreateAccumulator(video); err != nil {
+			t.Fatalf("CreateAccumulator failed: %v", err)
+		}
+		for frame := 0; frame < numFrames; frame++ {
+			gtBoxes := [][]float64{{0, 0, 10, 10}, {50, 50, 60, 60}}
+			var predBoxes [][]float64
+			var predIDs []int
+			for i, box := range gtBoxes {
+				if rng.Float64() < missRate {
+					continue
+				}
+				predID := i + 1
+				if frame >= numFrames/2 {
+					predID = 2 - i // IDs swap halfway
+				}
+				predBoxes = append(predBoxes, box)
+				predIDs = append(predIDs, predID)
+			}
+			if err := accumulators.Update(gtBoxes, []int{1, 2}, predBoxes, predIDs, video, 0.5); err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+		}
+	}
+	return accumulators
+}
+
+func TestAccumulators_BootstrapMetrics(t *testing.T) {
+	accumulators := newBootstrapAccumulators(t, []string{"seq"}, 200, 0.2)
+	metrics, err := accumulators.ComputeMetrics()
+	if err != nil {
+		t.Fatalf("ComputeMetrics failed: %v", err)
+	}
+
+	bootstrap, err := accumulators.BootstrapMetrics(&BootstrapConfig{Samples: 500})
+	if err != nil {
+		t.Fatalf("BootstrapMetrics failed: %v", err)
+	}
+	testutil.AssertAlmostEqual(t, bootstrap.MOTA.Value, metrics.MOTA, 1e-12, "MOTA value")
+	testutil.AssertAlmostEqual(t, bootstrap.IDF1.Value, metrics.IDF1, 1e-12, "IDF1 value")
+	for name, ci := range map[string]ConfidenceInterval{"MOTA": bootstrap.MOTA, "IDF1": bootstrap.IDF1} {
+		if !(ci.Lower < ci.Value && ci.Value < ci.Upper) {
+			t.Errorf("%s: expected value inside a non-empty interval, got %v", name, ci)
+		}
+		if ci.Upper-ci.Lower > 0.2 {
+			t.Errorf("%s: interval %v is implausibly wide for 400 objects", name, ci)
+		}
+	}
+	if bootstrap.Samples != 500 || bootstrap.Confidence != 0.95 {
+		t.Errorf("Unexpected samples %d and confidence %v", bootstrap.Samples, bootstrap.Confidence)
+	}
+
+	// Reproducible with the same seed, narrower at lower confidence
+	again, _ := accumulators.BootstrapMetrics(&BootstrapConfig{Samples: 500})
+	if again.MOTA != bootstrap.MOTA {
+		t.Errorf("Expected identical intervals for the same seed, got %v and %v", again.MOTA, bootstrap.MOTA)
+	}
+	narrow, _ := accumulators.BootstrapMetrics(&BootstrapConfig{Samples: 500, Confidence: 0.5})
+	if narrow.MOTA.Upper-narrow.MOTA.Lower >= bootstrap.MOTA.Upper-bootstrap.MOTA.Lower {
+		t.Errorf("Expected a narrower 50%% interval, got %v vs %v", narrow.MOTA, bootstrap.MOTA)
+	}
+}
+
+func TestAccumulators_BootstrapMetricsBySequence(t *testing.T) {
+	accumulators := newBootstrapAccumulators(t, []string{"a", "b", "c"}, 50, 0.2)
+	bootstrap, err := accumulators.BootstrapMetrics(&BootstrapConfig{Samples: 200, BySequence: true})
+	if err != nil {
+		t.Fatalf("BootstrapMetrics failed: %v", err)
+	}
+	if bootstrap.MOTA.Lower > bootstrap.MOTA.Value || bootstrap.MOTA.Upper < bootstrap.MOTA.Value {
+		t.Errorf("Expected value inside the interval, got %v", bootstrap.MOTA)
+	}
+
+	single := newBootstrapAccumulators(t, []string{"a"}, 10, 0.2)
+	if _, err := single.BootstrapMetrics(&BootstrapConfig{BySequence: true}); err == nil {
+		t.Error("Expected error for resampling a single sequence")
+	}
+}
+
+func TestAccumulators_BootstrapMetricsErrors(t *testing.T) {
+	accumulators := NewAccumulators()
+	if err := accumulators.CreateAccumulator("seq"); err != nil {
+		t.Fatalf("CreateAccumulator failed: %v", err)
+	}
+	if _, err := accumulators.BootstrapMetrics(nil); err == nil {
+		t.Error("Expected error without frames")
+	}
+	if err := accumulators.Update([][]float64{{0, 0, 10, 10}}, []int{1}, nil, nil, "seq", 0.5); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := accumulators.BootstrapMetrics(nil); err == nil {
+		t.Error("Expected error when frames were not recorded")
+	}
+
+	recorded := newBootstrapAccumulators(t, []string{"seq"}, 10, 0)
+	if _, err := recorded.BootstrapMetrics(&BootstrapConfig{Confidence: 1}); err == nil {
+		t.Error("Expected error for confidence 1")
+	}
+}
@@ -0,0 +1,122 @@
+package norfairgo
+
+import (
+	"github.com/nmichlo/norfair-go/internal/motmetrics"
+)
+
+// =============================================================================
+// Per-Frame Diagnosis - Classified GT and Tracker Boxes
+// =============================================================================
+
+// DiagnosedBox is a GT or tracker box of a frame with the outcome of matching it.
+//
+// Type is EventMatch, EventSwitch or EventMiss for GT boxes, and EventMatch,
+// EventSwitch or EventFalsePositive for tracker boxes. A switched tracker box is
+// the one that took over the GT object.
+type DiagnosedBox struct {
+	BBox      []float64 // [x_min, y_min, x_max, y_max]
+	ID        int       // GT ID of GT boxes, tracker ID of tracker boxes
+	MatchedID int       // ID of the matched box of the other side, -1 if unmatched
+	Type      motmetrics.EventType
+}
+
+// FrameDiagnosis holds the classified boxes of a frame.
+type FrameDiagnosis struct {
+	FrameID     int64
+	GT          []DiagnosedBox
+	Predictions []DiagnosedBox
+}
+
+// Counts returns the number of matches, false positives, misses and ID switches of
+// the frame. Switches are also counted as matches, as in MOTAccumulator.
+func (d *FrameDiagnosis) Counts() (matches, falsePositives, misses, switches int) {
+	for _, box := range d.GT {
+		switch box.Type {
+		case motmetrics.EventMatch:
+			matches++
+		case motmetrics.EventSwitch:
+			matches++
+			switches++
+		case motmetrics.EventMiss:
+			misses++
+		}
+	}
+	for _, box := range d.Predictions {
+		if box.Type == motmetrics.EventFalsePositive {
+			falsePositives++
+		}
+	}
+	return matches, falsePositives, misses, switches
+}
+
+// DiagnoseFrames evaluates predictions against GT like CompareDataframesWithConfig
+// and classifies every box of every frame as a match, miss, false positive or ID
+// switch, e.g. for rendering debugging videos that show where metric numbers come
+// from. Boxes removed by the config (ignore regions, distractors) are left out.
+//
+// Parameters:
+//   - gt: Ground truth MOTChallenge data
+//   - predictions: Tracker predictions MOTChallenge data
+//   - threshold: IoU distance threshold for valid matches (default 0.5)
+//   - config: Optional configuration (can be nil)
+//
+// Returns: Diagnosis per GT frame number
+func DiagnoseFrames(gt, predictions *MOTChallengeData, threshold float64, config *CompareConfig) (map[int64]*FrameDiagnosis, error) {
+	recording := CompareConfig{}
+	if config != nil {
+		recording = *config
+	}
+	recording.RecordEvents = true
+
+	accumulators, err := CompareDataframesWithConfig(gt, predictions, "iou", threshold, &recording)
+	if err != nil {
+		return nil, err
+	}
+	events, err := accumulators.Events(gt.VideoName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index the boxes of every frame by ID (after the same preprocessing)
+	gt, predictions = resampleFrames(gt, predictions, &recording)
+	gt, predictions = removeIgnored(gt, predictions, threshold, &recording)
+	boxByID := func(data *MOTChallengeData, frameID int64, id int) []float64 {
+		frame := data.Frames[frameID]
+		if frame == nil {
+			return nil
+		}
+		for i, frameObjectID := range frame.IDs {
+			if frameObjectID == id {
+				return frame.BBoxes[i]
+			}
+		}
+		return nil
+	}
+
+	diagnoses := make(map[int64]*FrameDiagnosis)
+	for _, event := range events {
+		diagnosis, exists := diagnoses[event.FrameID]
+		if !exists {
+			diagnosis = &FrameDiagnosis{FrameID: event.FrameID}
+			diagnoses[event.FrameID] = diagnosis
+		}
+
+		if event.GTID >= 0 {
+			diagnosis.GT = append(diagnosis.GT, DiagnosedBox{
+				BBox:      boxByID(gt, event.FrameID, event.GTID),
+				ID:        event.GTID,
+				MatchedID: event.PredID,
+				Type:      event.Type,
+			})
+		}
+		if event.PredID >= 0 {
+			diagnosis.Predictions = append(diagnosis.Predictions, DiagnosedBox{
+				BBox:      boxByID(predictions, event.FrameID, event.PredID),
+				ID:        event.PredID,
+				MatchedID: event.GTID,
+				Type:      event.Type,
+			})
+		}
+	}
+	return diagnoses, nil
+}
@@ -0,0 +1,182 @@
+package norfairgo
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// MetricsDataFrame Rendering - Text, Markdown and HTML Tables
+// =============================================================================
+
+// MetricsRenderOptions selects what MetricsDataFrame.RenderText, RenderMarkdown
+// and RenderHTML show. All fields are optional and can be zero.
+type MetricsRenderOptions struct {
+	// Metric names (see MetricsDataFrame.Get) in column order.
+	// Default: nil (DefaultRenderMetrics)
+	Metrics []string
+
+	// Decimal places of fractional metrics.
+	// Default: 3
+	Decimals int
+}
+
+// DefaultRenderMetrics are the columns of rendered metric tables, in the order of
+// py-motmetrics' MOTChallenge summary.
+var DefaultRenderMetrics = []string{
+	"IDF1", "IDP", "IDR", "Recall", "Precision", "MT", "PT", "ML",
+	"NumFalsePositives", "NumMisses", "NumSwitches", "NumFragmentations", "MOTA", "MOTP",
+}
+
+// metricHeaders are the short column headers of rendered metric tables.
+var metricHeaders = map[string]string{
+	"Recall":            "Rcll",
+	"Precision":         "Prcn",
+	"NumMatches":        "TP",
+	"NumFalsePositives": "FP",
+	"NumMisses":         "FN",
+	"NumSwitches":       "IDs",
+	"NumObjects":        "Obj",
+	"NumFragmentations": "FM",
+}
+
+// RenderText renders the metrics as a whitespace-aligned text table with one row
+// per video, like py-motmetrics' render_summary.
+//
+// Returns: Error if a metric name is unknown
+func (df *MetricsDataFrame) RenderText(options *MetricsRenderOptions) (string, error) {
+	header, rows, err := df.renderCells(options)
+	if err != nil {
+		return "", err
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i == 0 {
+				fmt.Fprintf(&b, "%-*s", widths[i], cell) // Video names are left-aligned
+			} else {
+				fmt.Fprintf(&b, " %*s", widths[i], cell)
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// RenderMarkdown renders the metrics as a GitHub-flavored Markdown table.
+//
+// Returns: Error if a metric name is unknown
+func (df *MetricsDataFrame) RenderMarkdown(options *MetricsRenderOptions) (string, error) {
+	header, rows, err := df.renderCells(options)
+	if err != nil {
+		return "", err
+	}
+
+	escape := strings.NewReplacer("|", `\|`)
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + escape.Replace(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(header)
+	b.WriteString("|:---|")
+	for range header[1:] {
+		b.WriteString("---:|")
+	}
+	b.WriteString("\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return b.String(), nil
+}
+
+// RenderHTML renders the metrics as a simple HTML table without styling.
+//
+// Returns: Error if a metric name is unknown
+func (df *MetricsDataFrame) RenderHTML(options *MetricsRenderOptions) (string, error) {
+	header, rows, err := df.renderCells(options)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, cell := range header {
+		b.WriteString("<th>" + html.EscapeString(cell) + "</th>")
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range rows {
+		b.WriteString("<tr>")
+		for i, cell := range row {
+			if i == 0 {
+				b.WriteString("<th>" + html.EscapeString(cell) + "</th>")
+			} else {
+				b.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+			}
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String(), nil
+}
+
+// renderCells formats the header and rows of a rendered table. The first column is
+// the video name; counts are rendered as integers and NaN as "NaN".
+func (df *MetricsDataFrame) renderCells(options *MetricsRenderOptions) ([]string, [][]string, error) {
+	if options == nil {
+		options = &MetricsRenderOptions{}
+	}
+	metricNames := options.Metrics
+	if metricNames == nil {
+		metricNames = DefaultRenderMetrics
+	}
+	decimals := options.Decimals
+	if decimals == 0 {
+		decimals = 3
+	}
+
+	header := []string{""}
+	for _, name := range metricNames {
+		if _, ok := metricExtractors[name]; !ok {
+			return nil, nil, fmt.Errorf("unknown metric %q", name)
+		}
+		if short, ok := metricHeaders[name]; ok {
+			header = append(header, short)
+		} else {
+			header = append(header, name)
+		}
+	}
+
+	rows := make([][]string, len(df.Rows))
+	for i, row := range df.Rows {
+		cells := []string{row.VideoName}
+		for _, name := range metricNames {
+			value := metricExtractors[name](row)
+			switch {
+			case math.IsNaN(value):
+				cells = append(cells, "NaN")
+			case strings.HasPrefix(name, "Num"):
+				cells = append(cells, strconv.Itoa(int(value)))
+			default:
+				cells = append(cells, strconv.FormatFloat(value, 'f', decimals, 64))
+			}
+		}
+		rows[i] = cells
+	}
+	return header, rows, nil
+}
@@ -0,0 +1,76 @@
+package norfairgo
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// MetricsDataFrame Rendering Tests
+// =============================================================================
+
+func newRenderDataFrame() *MetricsDataFrame {
+	df := NewMetricsDataFrame()
+	df.AddRow(MetricsRow{VideoName: "MOT17-02", MOTA: 0.5, MOTP: math.NaN(), NumSwitches: 12})
+	df.AddRow(MetricsRow{VideoName: "OVERALL", MOTA: 0.61234, MOTP: 0.25, NumSwitches: 3})
+	return df
+}
+
+func TestMetricsDataFrame_RenderText(t *testing.T) {
+	text, err := newRenderDataFrame().RenderText(&MetricsRenderOptions{Metrics: []string{"MOTA", "MOTP", "NumSwitches"}})
+	if err != nil {
+		t.Fatalf("RenderText failed: %v", err)
+	}
+	expected := "" +
+		"          MOTA  MOTP IDs\n" +
+		"MOT17-02 0.500   NaN  12\n" +
+		"OVERALL  0.612 0.250   3\n"
+	if text != expected {
+		t.Errorf("Unexpected text table:\n%s\nexpected:\n%s", text, expected)
+	}
+}
+
+func TestMetricsDataFrame_RenderMarkdown(t *testing.T) {
+	markdown, err := newRenderDataFrame().RenderMarkdown(&MetricsRenderOptions{Metrics: []string{"MOTA", "NumSwitches"}, Decimals: 1})
+	if err != nil {
+		t.Fatalf("RenderMarkdown failed: %v", err)
+	}
+	expected := "" +
+		"|  | MOTA | IDs |\n" +
+		"|:---|---:|---:|\n" +
+		"| MOT17-02 | 0.5 | 12 |\n" +
+		"| OVERALL | 0.6 | 3 |\n"
+	if markdown != expected {
+		t.Errorf("Unexpected markdown table:\n%s\nexpected:\n%s", markdown, expected)
+	}
+}
+
+func TestMetricsDataFrame_RenderHTML(t *testing.T) {
+	df := NewMetricsDataFrame()
+	df.AddRow(MetricsRow{VideoName: "<seq>", MOTA: 1})
+	htmlTable, err := df.RenderHTML(&MetricsRenderOptions{Metrics: []string{"MOTA"}})
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+	for _, part := range []string{"<table>", "<th>MOTA</th>", "<th>&lt;seq&gt;</th>", "<td>1.000</td>", "</table>"} {
+		if !strings.Contains(htmlTable, part) {
+			t.Errorf("Expected HTML table to contain %q, got:\n%s", part, htmlTable)
+		}
+	}
+}
+
+func TestMetricsDataFrame_RenderDefaults(t *testing.T) {
+	text, err := newRenderDataFrame().RenderText(nil)
+	if err != nil {
+		t.Fatalf("RenderText failed: %v", err)
+	}
+	header := strings.Fields(strings.SplitN(text, "\n", 2)[0])
+	if len(header) != len(DefaultRenderMetrics) || header[0] != "IDF1" || header[len(header)-1] != "MOTP" {
+		t.Errorf("Unexpected default header %v", header)
+	}
+
+	if _, err := newRenderDataFrame().RenderMarkdown(&MetricsRenderOptions{Metrics: []string{"HOTA"}}); err == nil {
+		t.Error("Expected error for unknown metric")
+	}
+}
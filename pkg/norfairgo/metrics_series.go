@@ -0,0 +1,193 @@
+package norfairgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// =============================================================================
+// Per-Frame Metric Time Series
+// =============================================================================
+
+// FrameMetrics are the MOTA components of a single frame, together with the MOTA
+// of the frame alone and of all frames of the video up to and including it.
+type FrameMetrics struct {
+	Video          string  `json:"video"`
+	FrameID        int64   `json:"frame_id"`
+	Matches        int     `json:"matches"`
+	FalsePositives int     `json:"false_positives"`
+	Misses         int     `json:"misses"`
+	Switches       int     `json:"switches"`
+	Objects        int     `json:"objects"`
+	MOTA           float64 `json:"mota"`            // MOTA of this frame (NaN without GT objects)
+	CumulativeMOTA float64 `json:"cumulative_mota"` // MOTA up to this frame (NaN without GT objects so far)
+}
+
+// FrameSeries returns the per-frame MOTA components of a video in frame order, so
+// users can see where in a video the tracker degrades rather than only the
+// aggregate numbers. Frame counts must have been recorded (see SetRecordFrames).
+//
+// Returns: Series of the video, or error if the accumulator doesn't exist or
+// frames were not recorded
+func (a *Accumulators) FrameSeries(videoName string) ([]FrameMetrics, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.frameSeries(videoName)
+}
+
+// frameSeries computes the series of a video. Callers must hold a.mu.
+func (a *Accumulators) frameSeries(videoName string) ([]FrameMetrics, error) {
+	acc, exists := a.accumulators[videoName]
+	if !exists {
+		return nil, fmt.Errorf("accumulator for video '%s' not found", videoName)
+	}
+	if acc.FrameID > 0 && len(acc.Frames) == 0 {
+		return nil, fmt.Errorf("no per-frame counts recorded for video '%s', enable SetRecordFrames before updating", videoName)
+	}
+
+	series := make([]FrameMetrics, len(acc.Frames))
+	totalErrors, totalObjects := 0, 0
+	for i, frame := range acc.Frames {
+		errors := frame.FalsePositives + frame.Misses + frame.Switches
+		totalErrors += errors
+		totalObjects += frame.Objects
+		series[i] = FrameMetrics{
+			Video:          videoName,
+			FrameID:        frame.FrameID,
+			Matches:        frame.Matches,
+			FalsePositives: frame.FalsePositives,
+			Misses:         frame.Misses,
+			Switches:       frame.Switches,
+			Objects:        frame.Objects,
+			MOTA:           motaOf(errors, frame.Objects),
+			CumulativeMOTA: motaOf(totalErrors, totalObjects),
+		}
+	}
+	return series, nil
+}
+
+// motaOf computes MOTA from the summed errors (FP + FN + IDSW) and GT objects.
+func motaOf(errors, objects int) float64 {
+	if objects == 0 {
+		return math.NaN()
+	}
+	return 1.0 - float64(errors)/float64(objects)
+}
+
+// allFrameSeries concatenates the series of all videos in sorted order. Callers
+// must hold a.mu.
+func (a *Accumulators) allFrameSeries() ([]FrameMetrics, error) {
+	videoNames := make([]string, 0, len(a.accumulators))
+	for videoName := range a.accumulators {
+		videoNames = append(videoNames, videoName)
+	}
+	sort.Strings(videoNames)
+
+	var all []FrameMetrics
+	for _, videoName := range videoNames {
+		series, err := a.frameSeries(videoName)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, series...)
+	}
+	return all, nil
+}
+
+// SaveFrameSeries exports the per-frame series of all videos to a CSV file, ready
+// for plotting. MOTA columns are NaN for frames without GT objects. Videos are
+// written in sorted order.
+//
+// Parameters:
+//   - filePath: Path to output CSV file
+//
+// Returns: Error if frames were not recorded, or file creation or writing fails
+func (a *Accumulators) SaveFrameSeries(filePath string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	all, err := a.allFrameSeries()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create frame series file: %w", err)
+	}
+	defer file.Close()
+
+	formatMOTA := func(value float64) string {
+		if math.IsNaN(value) {
+			return "NaN"
+		}
+		return strconv.FormatFloat(value, 'f', 6, 64)
+	}
+
+	w := bufio.NewWriter(file)
+	fmt.Fprintf(w, "Video,FrameId,Matches,FalsePositives,Misses,Switches,Objects,MOTA,CumulativeMOTA\n")
+	for _, point := range all {
+		fmt.Fprintf(w, "%s,%d,%d,%d,%d,%d,%d,%s,%s\n", point.Video, point.FrameID,
+			point.Matches, point.FalsePositives, point.Misses, point.Switches, point.Objects,
+			formatMOTA(point.MOTA), formatMOTA(point.CumulativeMOTA))
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write frame series file: %w", err)
+	}
+	return nil
+}
+
+// SaveFrameSeriesJSON exports the per-frame series of all videos to a JSON file as
+// an array of FrameMetrics objects. JSON has no NaN, so undefined MOTA values are
+// written as null. Videos are written in sorted order.
+//
+// Parameters:
+//   - filePath: Path to output JSON file
+//
+// Returns: Error if frames were not recorded, or file creation or writing fails
+func (a *Accumulators) SaveFrameSeriesJSON(filePath string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	all, err := a.allFrameSeries()
+	if err != nil {
+		return err
+	}
+
+	// Mirror FrameMetrics with nullable MOTA values
+	type jsonFrameMetrics struct {
+		FrameMetrics
+		MOTA           *float64 `json:"mota"`
+		CumulativeMOTA *float64 `json:"cumulative_mota"`
+	}
+	nullable := func(value float64) *float64 {
+		if math.IsNaN(value) {
+			return nil
+		}
+		return &value
+	}
+	points := make([]jsonFrameMetrics, len(all))
+	for i, point := range all {
+		points[i] = jsonFrameMetrics{
+			FrameMetrics:   point,
+			MOTA:           nullable(point.MOTA),
+			CumulativeMOTA: nullable(point.CumulativeMOTA),
+		}
+	}
+
+	data, err := json.MarshalIndent(points, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode frame series: %w", err)
+	}
+	if err := os.WriteFile(filePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write frame series file: %w", err)
+	}
+	return nil
+}
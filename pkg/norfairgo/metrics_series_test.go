@@ -0,0 +1,140 @@
+package norfairgo
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+)
+
+// =============================================================================
+// Per-Frame Metric Time Series Tests
+// =============================================================================
+
+// newSeriesAccumulators evaluates three frames of a video: a perfect frame, a frame
+// with a miss and a false positive, and a frame without GT objects.
+func newSeriesAccumulators(t *testing.T) *Accumulators {
+	t.Helper()
+	accumulators := NewAccumulators()
+	accumulators.SetRecordFrames(true)
+	if err := accumulators.CreateAccumulator("seq"); err != nil {
+		t.Fatalf("CreateAccumulator failed: %v", err)
+	}
+	gt := [][]float64{{0, 0, 10, 10}, {50, 50, 60, 60}}
+	frames := []struct {
+		gt      [][]float64
+		gtIDs   []int
+		pred    [][]float64
+		predIDs []int
+	}{
+		{gt, []int{1, 2}, gt, []int{1, 2}},
+		{gt, []int{1, 2}, [][]float64{{0, 0, 10, 10}, {100, 100, 110, 110}}, []int{1, 3}},
+		{nil, nil, nil, nil},
+	}
+	for _, f := range frames {
+		if err := accumulators.Update(f.gt, f.gtIDs, f.pred, f.predIDs, "seq", 0.5); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+	return accumulators
+}
+
+func TestAccumulators_FrameSeries(t *testing.T) {
+	accumulators := newSeriesAccumulators(t)
+	series, err := accumulators.FrameSeries("seq")
+	if err != nil {
+		t.Fatalf("FrameSeries failed: %v", err)
+	}
+	if len(series) != 3 {
+		t.Fatalf("Expected 3 frames, got %d", len(series))
+	}
+
+	if series[0].FrameID != 1 || series[0].Matches != 2 || series[0].MOTA != 1 {
+		t.Errorf("Unexpected first frame: %+v", series[0])
+	}
+	if series[1].Misses != 1 || series[1].FalsePositives != 1 {
+		t.Errorf("Expected 1 miss and 1 false positive, got %+v", series[1])
+	}
+	testutil.AssertAlmostEqual(t, series[1].MOTA, 0, 1e-12, "frame MOTA")
+	testutil.AssertAlmostEqual(t, series[1].CumulativeMOTA, 0.5, 1e-12, "cumulative MOTA")
+	if !math.IsNaN(series[2].MOTA) {
+		t.Errorf("Expected NaN MOTA without GT objects, got %v", series[2].MOTA)
+	}
+	testutil.AssertAlmostEqual(t, series[2].CumulativeMOTA, 0.5, 1e-12, "cumulative MOTA")
+
+	metrics, err := accumulators.ComputeMetrics()
+	if err != nil {
+		t.Fatalf("ComputeMetrics failed: %v", err)
+	}
+	testutil.AssertAlmostEqual(t, series[2].CumulativeMOTA, metrics.MOTA, 1e-12, "final cumulative MOTA")
+
+	if _, err := accumulators.FrameSeries("missing"); err == nil {
+		t.Error("Expected error for unknown video")
+	}
+}
+
+func TestAccumulators_FrameSeriesNotRecorded(t *testing.T) {
+	accumulators := NewAccumulators()
+	if err := accumulators.CreateAccumulator("seq"); err != nil {
+		t.Fatalf("CreateAccumulator failed: %v", err)
+	}
+	if err := accumulators.Update([][]float64{{0, 0, 10, 10}}, []int{1}, nil, nil, "seq", 0.5); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := accumulators.FrameSeries("seq"); err == nil {
+		t.Error("Expected error when frames were not recorded")
+	}
+	if err := accumulators.SaveFrameSeries(filepath.Join(t.TempDir(), "series.csv")); err == nil {
+		t.Error("Expected error when frames were not recorded")
+	}
+}
+
+func TestAccumulators_SaveFrameSeries(t *testing.T) {
+	accumulators := newSeriesAccumulators(t)
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "series.csv")
+	if err := accumulators.SaveFrameSeries(csvPath); err != nil {
+		t.Fatalf("SaveFrameSeries failed: %v", err)
+	}
+	content, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	expected := "Video,FrameId,Matches,FalsePositives,Misses,Switches,Objects,MOTA,CumulativeMOTA\n" +
+		"seq,1,2,0,0,0,2,1.000000,1.000000\n" +
+		"seq,2,1,1,1,0,2,0.000000,0.500000\n" +
+		"seq,3,0,0,0,0,0,NaN,0.500000\n"
+	if string(content) != expected {
+		t.Errorf("Unexpected CSV:\n%s\nexpected:\n%s", content, expected)
+	}
+
+	jsonPath := filepath.Join(dir, "series.json")
+	if err := accumulators.SaveFrameSeriesJSON(jsonPath); err != nil {
+		t.Fatalf("SaveFrameSeriesJSON failed: %v", err)
+	}
+	content, err = os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSON: %v", err)
+	}
+	var points []map[string]interface{}
+	if err := json.Unmarshal(content, &points); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, content)
+	}
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 points, got %d", len(points))
+	}
+	if points[1]["video"] != "seq" || points[1]["misses"] != 1.0 || points[1]["cumulative_mota"] != 0.5 {
+		t.Errorf("Unexpected second point: %v", points[1])
+	}
+	if mota, ok := points[2]["mota"]; !ok || mota != nil {
+		t.Errorf("Expected null MOTA without GT objects, got %v", mota)
+	}
+	if strings.Contains(string(content), "NaN") {
+		t.Error("JSON must not contain NaN")
+	}
+}
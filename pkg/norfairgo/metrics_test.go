@@ -2,9 +2,11 @@ package norfairgo
 
 import (
 	"bufio"
+	"errors"
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -295,6 +297,94 @@ seqLength=1
 	}
 }
 
+// writeMOTSequence writes a one-frame sequence with the given detections file
+// (relative path) and contents.
+func writeMOTSequence(t *testing.T, file, content string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "seqinfo.ini"), []byte("[Sequence]\nseqLength=1\n"), 0644); err != nil {
+		t.Fatalf("Failed to create seqinfo.ini: %v", err)
+	}
+	path := filepath.Join(tmpDir, file)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", file, err)
+	}
+	return tmpDir
+}
+
+func TestDetectionFileParser_MinConfidence(t *testing.T) {
+	dir := writeMOTSequence(t, "det/det.txt", `1,-1,0,0,10,10,0.9,-1,-1,-1
+1,-1,20,0,10,10,0.3,-1,-1,-1
+1,-1,40,0,10,10,-2.5,-1,-1,-1
+`)
+
+	parser, err := NewDetectionFileParserWithConfig(dir, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDetectionFileParserWithConfig failed: %v", err)
+	}
+	if detections := <-parser.Detections(); len(detections) != 3 {
+		t.Errorf("Expected all 3 detections without threshold, got %d", len(detections))
+	}
+	if parser.IsGroundTruth() {
+		t.Error("Expected detections not to be ground truth")
+	}
+
+	minConfidence := 0.5
+	parser, err = NewDetectionFileParserWithConfig(dir, nil, &DetectionFileParserConfig{MinConfidence: &minConfidence})
+	if err != nil {
+		t.Fatalf("NewDetectionFileParserWithConfig failed: %v", err)
+	}
+	detections := <-parser.Detections()
+	if len(detections) != 1 || detections[0].Scores[0] != 0.9 {
+		t.Errorf("Expected only the 0.9 detection, got %d detections", len(detections))
+	}
+}
+
+func TestDetectionFileParser_GroundTruthFlags(t *testing.T) {
+	// frame,id,x,y,w,h,consider,class,visibility
+	dir := writeMOTSequence(t, "gt/gt.txt", `1,1,0,0,10,10,1,1,0.9
+1,2,20,0,10,10,0,1,1.0
+1,3,40,0,10,10,1,7,1.0
+1,4,60,0,10,10,1,1,0.1
+`)
+
+	parser, err := NewDetectionFileParser(dir, nil)
+	if err != nil {
+		t.Fatalf("NewDetectionFileParser failed: %v", err)
+	}
+	if !parser.IsGroundTruth() {
+		t.Error("Expected detections to be ground truth")
+	}
+	detections := <-parser.Detections()
+	if len(detections) != 3 {
+		t.Fatalf("Expected the inactive row to be dropped, got %d detections", len(detections))
+	}
+	for _, det := range detections {
+		if det.Scores[0] != 1 {
+			t.Errorf("Expected ground truth confidence 1, got %v", det.Scores[0])
+		}
+	}
+
+	parser, err = NewDetectionFileParserWithConfig(dir, nil, &DetectionFileParserConfig{
+		IncludeInactiveGT: true,
+		GTClasses:         []int{1},
+		GTMinVisibility:   0.5,
+	})
+	if err != nil {
+		t.Fatalf("NewDetectionFileParserWithConfig failed: %v", err)
+	}
+	detections = <-parser.Detections()
+	if len(detections) != 2 {
+		t.Fatalf("Expected 2 visible pedestrians, got %d", len(detections))
+	}
+	if detections[0].Scores[0] != 1 || detections[1].Scores[0] != 0 {
+		t.Errorf("Expected confidences [1, 0] for active and inactive rows, got [%v, %v]", detections[0].Scores[0], detections[1].Scores[0])
+	}
+}
+
 func TestDetectionFileParser_EmptyFile(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -349,6 +439,100 @@ seqLength=1
 	}
 }
 
+func TestDetectionFileParser_WhitespaceWithHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	seqinfoPath := filepath.Join(tmpDir, "seqinfo.ini")
+	if err := os.WriteFile(seqinfoPath, []byte("[Sequence]\nseqLength=2\n"), 0644); err != nil {
+		t.Fatalf("Failed to create seqinfo.ini: %v", err)
+	}
+
+	detDir := filepath.Join(tmpDir, "det")
+	if err := os.MkdirAll(detDir, 0755); err != nil {
+		t.Fatalf("Failed to create det dir: %v", err)
+	}
+
+	// Whitespace separated with a header row and irregular spacing
+	detContent := `frame id bb_left bb_top bb_width bb_height conf
+1  -1  100 200 50 75 0.9
+2	-1	110 210 50 75 0.8
+`
+	if err := os.WriteFile(filepath.Join(detDir, "det.txt"), []byte(detContent), 0644); err != nil {
+		t.Fatalf("Failed to create det.txt: %v", err)
+	}
+
+	parser, err := NewDetectionFileParser(tmpDir, nil)
+	if err != nil {
+		t.Fatalf("NewDetectionFileParser failed: %v", err)
+	}
+
+	frames := make([][]*Detection, 0)
+	for detections := range parser.Detections() {
+		frames = append(frames, detections)
+	}
+
+	if len(frames[0]) != 1 || len(frames[1]) != 1 {
+		t.Fatalf("Expected 1 detection per frame, got %d and %d", len(frames[0]), len(frames[1]))
+	}
+	if frames[1][0].Points.At(1, 0) != 160 || frames[1][0].Scores[0] != 0.8 {
+		t.Errorf("Unexpected detection in frame 2: x_max=%v score=%v",
+			frames[1][0].Points.At(1, 0), frames[1][0].Scores[0])
+	}
+}
+
+func TestLoadMotchallengeWithDialect(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		dialect *CSVDialect
+	}{
+		{"comma", "1,1,10,20,30,40,1,-1,-1,-1\n", nil},
+		{"semicolon with header", "frame;id;x;y;w;h\n1;1;10;20;30;40\n", nil},
+		{"spaces after commas", "1, 1, 10, 20, 30, 40\n", nil},
+		{"whitespace", "1 1 10 20 30 40\n", nil},
+		{"explicit header lines", "# exported by tool\n1|1|10|20|30|40\n", &CSVDialect{Delimiter: '|', HeaderLines: 1}},
+		{"whitespace header", "frame id x y w h conf\n1,1,10,20,30,40,1\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, strings.ReplaceAll(tt.name, " ", "_")+".txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			data, err := LoadMotchallengeWithDialect(path, tt.dialect)
+			if err != nil {
+				t.Fatalf("LoadMotchallengeWithDialect failed: %v", err)
+			}
+
+			frame, ok := data.Frames[1]
+			if !ok || len(frame.BBoxes) != 1 {
+				t.Fatalf("Expected one row in frame 1, got %v", data.Frames)
+			}
+			expected := []float64{10, 20, 40, 60}
+			for i := range expected {
+				if frame.BBoxes[0][i] != expected[i] {
+					t.Errorf("BBox = %v, want %v", frame.BBoxes[0], expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMotchallengeWithDialect_NoRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gt.txt")
+	if err := os.WriteFile(path, []byte("frame id x y w h\nnot a row\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := LoadMotchallengeWithDialect(path, nil); err == nil {
+		t.Error("Expected error for a file without numeric rows")
+	}
+}
+
 // =============================================================================
 // PredictionsTextFile Tests (4 tests)
 // =============================================================================
@@ -512,7 +696,7 @@ seqLength=10
 	}
 
 	// Write with custom frame number
-	frameNum := 5
+	frameNum := int64(5)
 	if err := ptf.Update([]*TrackedObject{obj}, &frameNum); err != nil {
 		t.Fatalf("Update failed: %v", err)
 	}
@@ -532,6 +716,221 @@ seqLength=10
 	}
 }
 
+func TestPredictionsTextFile_LargeFrameNumber(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "seqinfo.ini"), []byte("[Sequence]\nseqLength=10\n"), 0644); err != nil {
+		t.Fatalf("Failed to create seqinfo.ini: %v", err)
+	}
+	ptf, err := NewPredictionsTextFile(tmpDir, tmpDir, nil)
+	if err != nil {
+		t.Fatalf("NewPredictionsTextFile failed: %v", err)
+	}
+	defer ptf.Close()
+
+	id := 1
+	obj := &TrackedObject{
+		ID:       &id,
+		Estimate: mat.NewDense(2, 2, []float64{100, 200, 150, 275}),
+	}
+	frameNum := int64(5_000_000_000)
+	if err := ptf.Update([]*TrackedObject{obj}, &frameNum); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	ptf.Close()
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "predictions", filepath.Base(tmpDir)+".txt"))
+	if err != nil {
+		t.Fatalf("Failed to read predictions file: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "5000000000,1,") {
+		t.Errorf("Expected frame number 5000000000, got: %s", content)
+	}
+
+	// The written frame ID survives a round trip through the loader
+	data, err := LoadMotchallenge(filepath.Join(tmpDir, "predictions", filepath.Base(tmpDir)+".txt"))
+	if err != nil {
+		t.Fatalf("LoadMotchallenge failed: %v", err)
+	}
+	if frame := data.Frames[frameNum]; frame == nil || frame.FrameID != frameNum || frame.IDs[0] != 1 {
+		t.Errorf("Expected frame %d with ID 1, got %+v", frameNum, data.Frames)
+	}
+}
+
+func TestPredictionsTextFile_BackfillInitializing(t *testing.T) {
+	for _, backfill := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "seqinfo.ini"), []byte("[Sequence]\nseqLength=10\n"), 0644); err != nil {
+			t.Fatalf("Failed to create seqinfo.ini: %v", err)
+		}
+		ptf, err := NewPredictionsTextFile(tmpDir, tmpDir, nil)
+		if err != nil {
+			t.Fatalf("NewPredictionsTextFile failed: %v", err)
+		}
+		ptf.BackfillInitializing = backfill
+
+		newObject := func(initID int, x float64) *TrackedObject {
+			return &TrackedObject{
+				InitializingID: &initID,
+				IsInitializing: true,
+				Estimate:       mat.NewDense(2, 2, []float64{x, 0, x + 10, 10}),
+			}
+		}
+		confirmed := newObject(1, 0)
+		dying := newObject(2, 100)
+
+		// Frames 1-2: both initializing, frame 3: one confirms and the other is gone
+		steps := [][]*TrackedObject{{confirmed, dying}, {confirmed, dying}, {confirmed}, {confirmed}}
+		for frame, objects := range steps {
+			if frame == 2 {
+				id := 7
+				confirmed.ID = &id
+				confirmed.IsInitializing = false
+			}
+			confirmed.Estimate.Set(0, 0, float64(frame))
+			if err := ptf.Update(objects, nil); err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+		}
+		ptf.Close()
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "predictions", filepath.Base(tmpDir)+".txt"))
+		if err != nil {
+			t.Fatalf("Failed to read predictions file: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+
+		expected := []string{"3,7,2.0", "4,7,3.0"}
+		if backfill {
+			expected = []string{"1,7,0.0", "2,7,1.0", "3,7,2.0", "4,7,3.0"}
+		}
+		if len(lines) != len(expected) {
+			t.Fatalf("backfill=%v: expected %d rows, got %q", backfill, len(expected), lines)
+		}
+		for i, prefix := range expected {
+			if !strings.HasPrefix(lines[i], prefix) {
+				t.Errorf("backfill=%v: row %d: expected prefix %q, got %q", backfill, i, prefix, lines[i])
+			}
+		}
+		if len(ptf.pending) != 0 {
+			t.Errorf("backfill=%v: expected no pending rows, got %v", backfill, ptf.pending)
+		}
+	}
+}
+
+func TestPredictionsTextFile_RowFormatter(t *testing.T) {
+	projector, err := NewGroundPlaneProjector(mat.NewDense(3, 3, []float64{0.1, 0, 0, 0, 0.1, 0, 0, 0, 1}))
+	if err != nil {
+		t.Fatalf("NewGroundPlaneProjector failed: %v", err)
+	}
+	withLabel := func(row PredictionRow) ([]string, error) {
+		fields, err := MOTRowFormatter(row)
+		if err != nil {
+			return nil, err
+		}
+		label := ""
+		if l := row.Object.GetLabel(); l != nil {
+			label = *l
+		}
+		return append(fields, label), nil
+	}
+	failing := func(row PredictionRow) ([]string, error) { return nil, errors.New("boom") }
+
+	testCases := []struct {
+		name      string
+		formatter PredictionRowFormatter
+		expected  string
+	}{
+		{"default", nil, "1,1,100.000000,200.000000,50.000000,75.000000,-1,-1,-1,-1"},
+		{"extra column", withLabel, "1,1,100.000000,200.000000,50.000000,75.000000,-1,-1,-1,-1,person"},
+		{"world", MOTWorldRowFormatter(projector), "1,1,100.000000,200.000000,50.000000,75.000000,-1,12.500000,27.500000,-1"},
+		{"error", failing, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "seqinfo.ini"), []byte("[Sequence]\nseqLength=10\n"), 0644); err != nil {
+				t.Fatalf("Failed to create seqinfo.ini: %v", err)
+			}
+			ptf, err := NewPredictionsTextFile(tmpDir, tmpDir, nil)
+			if err != nil {
+				t.Fatalf("NewPredictionsTextFile failed: %v", err)
+			}
+			ptf.RowFormatter = tc.formatter
+
+			id := 1
+			label := "person"
+			obj := &TrackedObject{
+				ID:       &id,
+				Label:    &label,
+				Estimate: mat.NewDense(2, 2, []float64{100, 200, 150, 275}),
+			}
+			err = ptf.Update([]*TrackedObject{obj}, nil)
+			ptf.Close()
+			if tc.expected == "" {
+				if err == nil {
+					t.Error("Expected the formatter error to be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "predictions", filepath.Base(tmpDir)+".txt"))
+			if err != nil {
+				t.Fatalf("Failed to read predictions file: %v", err)
+			}
+			if got := strings.TrimSpace(string(content)); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// boxTrack is a minimal custom TrackedObjectLike
+type boxTrack struct {
+	id  int
+	box *mat.Dense
+}
+
+func (b *boxTrack) GetEstimate(absolute bool) (*mat.Dense, error) { return b.box, nil }
+func (b *boxTrack) GetID() *int                                   { return &b.id }
+func (b *boxTrack) GetLabel() *string                             { return nil }
+func (b *boxTrack) GetLivePoints() []bool                         { return []bool{true, true} }
+
+func TestPredictionsTextFile_UpdateObjects(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	seqinfoPath := filepath.Join(tmpDir, "seqinfo.ini")
+	if err := os.WriteFile(seqinfoPath, []byte("[Sequence]\nseqLength=1\n"), 0644); err != nil {
+		t.Fatalf("Failed to create seqinfo.ini: %v", err)
+	}
+	inf, err := NewInformationFile(seqinfoPath)
+	if err != nil {
+		t.Fatalf("NewInformationFile failed: %v", err)
+	}
+	ptf, err := NewPredictionsTextFile(tmpDir, tmpDir, inf)
+	if err != nil {
+		t.Fatalf("NewPredictionsTextFile failed: %v", err)
+	}
+	defer ptf.Close()
+
+	track := &boxTrack{id: 7, box: mat.NewDense(2, 2, []float64{100, 200, 150, 275})}
+	if err := ptf.UpdateObjects([]TrackedObjectLike{track}, nil); err != nil {
+		t.Fatalf("UpdateObjects failed: %v", err)
+	}
+
+	predPath := filepath.Join(tmpDir, "predictions", filepath.Base(tmpDir)+".txt")
+	content, err := os.ReadFile(predPath)
+	if err != nil {
+		t.Fatalf("Failed to read predictions file: %v", err)
+	}
+	expected := "1,7,100.000000,200.000000,50.000000,75.000000,-1,-1,-1,-1"
+	if strings.TrimSpace(string(content)) != expected {
+		t.Errorf("Expected %q, got %q", expected, strings.TrimSpace(string(content)))
+	}
+}
+
 func TestPredictionsTextFile_AutoClose(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -637,7 +1036,7 @@ frameRate=30
 	}
 	defer ptf.Close()
 
-	frameNum := 1
+	frameNum := int64(1)
 	for detections := range parser.Detections() {
 		// Convert detections to tracked objects (mock tracking)
 		trackedObjects := make([]*TrackedObject, len(detections))
@@ -1842,6 +2241,36 @@ func TestAccumulators_ComputeMetrics_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestAccumulators_ComputeMetrics_IDF1(t *testing.T) {
+	accumulators := NewAccumulators()
+	accumulators.CreateAccumulator("video1")
+
+	box := []float64{100, 100, 200, 200}
+
+	// Frames 1-3: GT 1 tracked as 10
+	for i := 0; i < 3; i++ {
+		accumulators.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{10}, "video1", 0.5)
+	}
+	// Frame 4: GT 1 tracked as 20 (identity switch)
+	accumulators.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{20}, "video1", 0.5)
+
+	metrics, err := accumulators.ComputeMetrics()
+	if err != nil {
+		t.Fatalf("Failed to compute metrics: %v", err)
+	}
+
+	// IDTP = 3, IDFP = 1, IDFN = 1
+	if math.Abs(metrics.IDP-0.75) > 1e-9 {
+		t.Errorf("Expected IDP 0.75, got %.6f", metrics.IDP)
+	}
+	if math.Abs(metrics.IDR-0.75) > 1e-9 {
+		t.Errorf("Expected IDR 0.75, got %.6f", metrics.IDR)
+	}
+	if math.Abs(metrics.IDF1-0.75) > 1e-9 {
+		t.Errorf("Expected IDF1 0.75, got %.6f", metrics.IDF1)
+	}
+}
+
 func TestAccumulators_PrintMetrics(t *testing.T) {
 	accumulators := NewAccumulators()
 	accumulators.CreateAccumulator("print_test")
@@ -2132,3 +2561,451 @@ func TestMetricsDataFrame_MultipleVideos(t *testing.T) {
 		}
 	}
 }
+
+// ==============================================================================
+// Per-Label Evaluation Tests
+// ==============================================================================
+
+func writeMOTFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadMotchallenge_OptionalColumns(t *testing.T) {
+	tmpDir := t.TempDir()
+	fullPath := writeMOTFile(t, tmpDir, "gt.txt", "1,1,10,10,5,5,0,2,0.25\n")
+	shortPath := writeMOTFile(t, tmpDir, "short.txt", "1,2,20,20,5,5\n")
+
+	full, err := LoadMotchallenge(fullPath)
+	if err != nil {
+		t.Fatalf("LoadMotchallenge failed: %v", err)
+	}
+	frame := full.Frames[1]
+	if frame.Confidences[0] != 0 || frame.Classes[0] != 2 || frame.Visibilities[0] != 0.25 {
+		t.Errorf("Unexpected optional columns: conf=%v class=%v vis=%v",
+			frame.Confidences[0], frame.Classes[0], frame.Visibilities[0])
+	}
+
+	short, err := LoadMotchallenge(shortPath)
+	if err != nil {
+		t.Fatalf("LoadMotchallenge failed: %v", err)
+	}
+	frame = short.Frames[1]
+	if frame.Confidences[0] != 1 || frame.Classes[0] != -1 || frame.Visibilities[0] != -1 {
+		t.Errorf("Expected defaults for missing columns, got conf=%v class=%v vis=%v",
+			frame.Confidences[0], frame.Classes[0], frame.Visibilities[0])
+	}
+}
+
+func TestEvalMotChallengePerLabel_SplitsClasses(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Class 1 (pedestrian) and class 3 (car) tracked perfectly, plus one
+	// class 1 row flagged as "do not consider" and one low-visibility row
+	gtPath := writeMOTFile(t, tmpDir, "gt.txt", strings.Join([]string{
+		"1,1,0,0,10,10,1,1,1.0",
+		"1,2,100,100,10,10,1,3,1.0",
+		"1,3,200,200,10,10,0,1,1.0",
+		"1,4,300,300,10,10,1,1,0.1",
+		"2,1,0,0,10,10,1,1,1.0",
+		"2,2,100,100,10,10,1,3,1.0",
+	}, "\n"))
+
+	// Predictions with class 1 / class 3 labels
+	predPath := writeMOTFile(t, tmpDir, "pred.txt", strings.Join([]string{
+		"1,10,0,0,10,10,1,1,-1",
+		"1,20,100,100,10,10,1,3,-1",
+		"2,10,0,0,10,10,1,1,-1",
+		"2,20,100,100,10,10,1,3,-1",
+	}, "\n"))
+
+	results, err := EvalMotChallengePerLabel(gtPath, predPath, &LabelEvalConfig{MinVisibility: 0.5})
+	if err != nil {
+		t.Fatalf("EvalMotChallengePerLabel failed: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 classes, got %d", len(results))
+	}
+
+	for _, class := range []int{1, 3} {
+		metrics, ok := results[class]
+		if !ok {
+			t.Fatalf("Missing metrics for class %d", class)
+		}
+		if metrics.NumObjects != 2 {
+			t.Errorf("Class %d: expected 2 GT objects, got %d", class, metrics.NumObjects)
+		}
+		if metrics.MOTA != 1.0 {
+			t.Errorf("Class %d: expected MOTA 1.0, got %.6f", class, metrics.MOTA)
+		}
+		if metrics.IDF1 != 1.0 {
+			t.Errorf("Class %d: expected IDF1 1.0, got %.6f", class, metrics.IDF1)
+		}
+	}
+}
+
+func TestCompareDataframesPerLabel_UnlabelledPredictions(t *testing.T) {
+	gt := &MOTChallengeData{
+		VideoName: "video",
+		Frames: map[int64]*MOTChallengeFrame{
+			1: {
+				FrameID: 1,
+				BBoxes:  [][]float64{{0, 0, 10, 10}, {100, 100, 110, 110}},
+				IDs:     []int{1, 2},
+				Classes: []int{1, 2},
+			},
+		},
+	}
+
+	// Predictions without class information are evaluated against every class
+	predictions := &MOTChallengeData{
+		VideoName: "video",
+		Frames: map[int64]*MOTChallengeFrame{
+			1: {
+				FrameID: 1,
+				BBoxes:  [][]float64{{0, 0, 10, 10}},
+				IDs:     []int{10},
+			},
+		},
+	}
+
+	results, err := CompareDataframesPerLabel(gt, predictions, "iou", 0.5, &LabelEvalConfig{Classes: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("CompareDataframesPerLabel failed: %v", err)
+	}
+
+	class1, _ := results[1].ComputeMetrics()
+	if class1.NumMatches != 1 || class1.NumFalsePositives != 0 {
+		t.Errorf("Class 1: expected 1 match and 0 FP, got %d and %d", class1.NumMatches, class1.NumFalsePositives)
+	}
+
+	class2, _ := results[2].ComputeMetrics()
+	if class2.NumMisses != 1 || class2.NumFalsePositives != 1 {
+		t.Errorf("Class 2: expected 1 miss and 1 FP, got %d and %d", class2.NumMisses, class2.NumFalsePositives)
+	}
+}
+
+// ==============================================================================
+// Distractor / Ignore Region Tests
+// ==============================================================================
+
+func TestCompareDataframesWithConfig_DistractorClasses(t *testing.T) {
+	gt := &MOTChallengeData{
+		VideoName: "video",
+		Frames: map[int64]*MOTChallengeFrame{
+			1: {
+				FrameID: 1,
+				BBoxes:  [][]float64{{0, 0, 10, 10}, {100, 100, 110, 110}},
+				IDs:     []int{1, 2},
+				Classes: []int{1, 8}, // pedestrian, distractor
+			},
+		},
+	}
+	predictions := &MOTChallengeData{
+		VideoName: "video",
+		Frames: map[int64]*MOTChallengeFrame{
+			1: {
+				FrameID: 1,
+				BBoxes:  [][]float64{{0, 0, 10, 10}, {100, 100, 110, 110}},
+				IDs:     []int{10, 20},
+			},
+		},
+	}
+
+	// Without distractor handling the distractor counts as a regular object
+	plain, err := CompareDataframes(gt, predictions, "iou", 0.5)
+	if err != nil {
+		t.Fatalf("CompareDataframes failed: %v", err)
+	}
+	plainMetrics, _ := plain.ComputeMetrics()
+	if plainMetrics.NumObjects != 2 {
+		t.Errorf("Expected 2 GT objects without config, got %d", plainMetrics.NumObjects)
+	}
+
+	// With distractor handling both the GT row and its matched prediction are dropped
+	accumulators, err := CompareDataframesWithConfig(gt, predictions, "iou", 0.5, &CompareConfig{
+		DistractorClasses: []int{8},
+	})
+	if err != nil {
+		t.Fatalf("CompareDataframesWithConfig failed: %v", err)
+	}
+	metrics, _ := accumulators.ComputeMetrics()
+	if metrics.NumObjects != 1 || metrics.NumMatches != 1 || metrics.NumFalsePositives != 0 {
+		t.Errorf("Expected 1 object, 1 match, 0 FP, got %d, %d, %d",
+			metrics.NumObjects, metrics.NumMatches, metrics.NumFalsePositives)
+	}
+}
+
+func TestCompareDataframesWithConfig_IgnoreRegions(t *testing.T) {
+	gt := &MOTChallengeData{
+		VideoName: "video",
+		Frames: map[int64]*MOTChallengeFrame{
+			1: {
+				FrameID: 1,
+				BBoxes:  [][]float64{{0, 0, 10, 10}, {200, 200, 210, 210}},
+				IDs:     []int{1, 2},
+			},
+		},
+	}
+	predictions := &MOTChallengeData{
+		VideoName: "video",
+		Frames: map[int64]*MOTChallengeFrame{
+			1: {
+				FrameID: 1,
+				BBoxes:  [][]float64{{0, 0, 10, 10}, {300, 300, 310, 310}},
+				IDs:     []int{10, 20},
+			},
+		},
+	}
+
+	// Region covering the unmatched GT (200..210) and the unmatched prediction (300..310)
+	region := [][2]float64{{150, 150}, {400, 150}, {400, 400}, {150, 400}}
+
+	accumulators, err := CompareDataframesWithConfig(gt, predictions, "iou", 0.5, &CompareConfig{
+		IgnoreRegions: [][][2]float64{region},
+	})
+	if err != nil {
+		t.Fatalf("CompareDataframesWithConfig failed: %v", err)
+	}
+
+	metrics, _ := accumulators.ComputeMetrics()
+	if metrics.NumMisses != 0 || metrics.NumFalsePositives != 0 || metrics.NumMatches != 1 {
+		t.Errorf("Expected 1 match and no errors, got matches=%d misses=%d fp=%d",
+			metrics.NumMatches, metrics.NumMisses, metrics.NumFalsePositives)
+	}
+}
+
+// ==============================================================================
+// Frame Remapping Tests
+// ==============================================================================
+
+func TestCompareDataframesWithConfig_FrameRatio(t *testing.T) {
+	box := []float64{0, 0, 10, 10}
+
+	// GT on every frame 1-6, predictions generated on every 2nd frame (1, 3, 5)
+	gt := &MOTChallengeData{VideoName: "video", Frames: map[int64]*MOTChallengeFrame{}}
+	for frameID := int64(1); frameID <= 6; frameID++ {
+		gt.addRow(frameID, 1, box, 1, -1, -1)
+	}
+	predictions := &MOTChallengeData{VideoName: "video", Frames: map[int64]*MOTChallengeFrame{}}
+	for frameID := int64(1); frameID <= 3; frameID++ {
+		predictions.addRow(frameID, 10, box, 1, -1, -1)
+	}
+
+	// Without remapping, prediction frames 1-3 only cover GT frames 1-3
+	plain, _ := CompareDataframes(gt, predictions, "iou", 0.5)
+	plainMetrics, _ := plain.ComputeMetrics()
+	if plainMetrics.NumMisses != 3 {
+		t.Errorf("Expected 3 misses without remapping, got %d", plainMetrics.NumMisses)
+	}
+
+	accumulators, err := CompareDataframesWithConfig(gt, predictions, "iou", 0.5, &CompareConfig{FrameRatio: 2})
+	if err != nil {
+		t.Fatalf("CompareDataframesWithConfig failed: %v", err)
+	}
+	metrics, _ := accumulators.ComputeMetrics()
+	if metrics.NumObjects != 3 || metrics.NumMatches != 3 || metrics.NumMisses != 0 {
+		t.Errorf("Expected 3 objects and 3 matches, got objects=%d matches=%d misses=%d",
+			metrics.NumObjects, metrics.NumMatches, metrics.NumMisses)
+	}
+}
+
+func TestCompareDataframesWithConfig_FrameOffset(t *testing.T) {
+	box := []float64{0, 0, 10, 10}
+
+	gt := &MOTChallengeData{VideoName: "video", Frames: map[int64]*MOTChallengeFrame{}}
+	predictions := &MOTChallengeData{VideoName: "video", Frames: map[int64]*MOTChallengeFrame{}}
+	for frameID := int64(1); frameID <= 3; frameID++ {
+		gt.addRow(frameID, 1, box, 1, -1, -1)
+		predictions.addRow(frameID-1, 10, box, 1, -1, -1) // 0-indexed predictions
+	}
+
+	accumulators, err := CompareDataframesWithConfig(gt, predictions, "iou", 0.5, &CompareConfig{FrameOffset: 1})
+	if err != nil {
+		t.Fatalf("CompareDataframesWithConfig failed: %v", err)
+	}
+	metrics, _ := accumulators.ComputeMetrics()
+	if metrics.NumMatches != 3 || metrics.NumFalsePositives != 0 || metrics.NumMisses != 0 {
+		t.Errorf("Expected 3 matches and no errors, got matches=%d fp=%d misses=%d",
+			metrics.NumMatches, metrics.NumFalsePositives, metrics.NumMisses)
+	}
+}
+
+func TestCompareDataframesWithConfig_RecordEvents(t *testing.T) {
+	box := []float64{0, 0, 10, 10}
+
+	gt := &MOTChallengeData{VideoName: "video", Frames: map[int64]*MOTChallengeFrame{}}
+	predictions := &MOTChallengeData{VideoName: "video", Frames: map[int64]*MOTChallengeFrame{}}
+	gt.addRow(3, 1, box, 1, -1, -1)
+	predictions.addRow(3, 10, box, 1, -1, -1)
+	gt.addRow(8, 1, box, 1, -1, -1)
+
+	accumulators, err := CompareDataframesWithConfig(gt, predictions, "iou", 0.5, &CompareConfig{RecordEvents: true})
+	if err != nil {
+		t.Fatalf("CompareDataframesWithConfig failed: %v", err)
+	}
+
+	events, err := accumulators.Events("video")
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+	if len(events) != 2 || events[0].FrameID != 3 || events[1].FrameID != 8 {
+		t.Fatalf("Expected events on GT frames 3 and 8, got %+v", events)
+	}
+
+	eventsPath := filepath.Join(t.TempDir(), "events.csv")
+	if err := accumulators.SaveEvents(eventsPath); err != nil {
+		t.Fatalf("SaveEvents failed: %v", err)
+	}
+	content, err := os.ReadFile(eventsPath)
+	if err != nil {
+		t.Fatalf("Failed to read events file: %v", err)
+	}
+
+	expected := "Video,FrameId,Event,Type,OId,HId,D\n" +
+		"video,3,0,MATCH,1,10,0.000000\n" +
+		"video,8,0,MISS,1,,NaN\n"
+	if string(content) != expected {
+		t.Errorf("Unexpected events CSV:\n%s\nexpected:\n%s", content, expected)
+	}
+}
+
+func TestAccumulators_SaveConfusionReport(t *testing.T) {
+	accumulators := NewAccumulators()
+	if err := accumulators.CreateAccumulator("video"); err != nil {
+		t.Fatalf("CreateAccumulator failed: %v", err)
+	}
+
+	box := []float64{0, 0, 10, 10}
+	accumulators.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{10}, "video", 0.5)
+	accumulators.Update([][]float64{box}, []int{1}, [][]float64{box}, []int{11}, "video", 0.5)
+	accumulators.Update([][]float64{box}, []int{2}, nil, nil, "video", 0.5)
+
+	if _, err := accumulators.ConfusionReport("missing"); err == nil {
+		t.Error("Expected error for unknown video")
+	}
+
+	reportPath := filepath.Join(t.TempDir(), "confusion.csv")
+	if err := accumulators.SaveConfusionReport(reportPath); err != nil {
+		t.Fatalf("SaveConfusionReport failed: %v", err)
+	}
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Failed to read confusion report: %v", err)
+	}
+
+	expected := "Video,OId,NumHIds,HId,StartFrame,EndFrame,Frames\n" +
+		"video,1,2,10,1,1,1\n" +
+		"video,1,2,11,2,2,1\n" +
+		"video,2,0,,,,0\n"
+	if string(content) != expected {
+		t.Errorf("Unexpected confusion report:\n%s\nexpected:\n%s", content, expected)
+	}
+}
+
+func TestDetectionFileParser_DetectionsEarlyStop(t *testing.T) {
+	parser := &DetectionFileParser{length: 100, sortedByFrame: make([][]*Detection, 100)}
+	before := runtime.NumGoroutine()
+
+	// Consumers that stop after the first frame must not leave a goroutine blocked
+	for i := 0; i < 10; i++ {
+		<-parser.Detections()
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected no leaked goroutines, got %d -> %d", before, after)
+	}
+
+	frames := 0
+	for range parser.Detections() {
+		frames++
+	}
+	if frames != 100 {
+		t.Errorf("Expected 100 frames, got %d", frames)
+	}
+}
+
+func TestDetectionFileParser_All(t *testing.T) {
+	sortedByFrame := make([][]*Detection, 5)
+	for i := range sortedByFrame {
+		det, _ := NewDetectionFromBox(float64(i), 0, float64(i)+10, 10, nil)
+		sortedByFrame[i] = []*Detection{det}
+	}
+	parser := &DetectionFileParser{length: 5, sortedByFrame: sortedByFrame}
+
+	var frames []int
+	for frame, detections := range parser.All() {
+		if detections[0] != sortedByFrame[frame-1][0] {
+			t.Errorf("frame %d: unexpected detections", frame)
+		}
+		frames = append(frames, frame)
+		if frame == 3 {
+			break
+		}
+	}
+	if len(frames) != 3 || frames[0] != 1 || frames[2] != 3 {
+		t.Errorf("Expected frames [1 2 3] before breaking, got %v", frames)
+	}
+}
+
+func TestDetectionFileParser_Frames(t *testing.T) {
+	sortedByFrame := [][]*Detection{nil, {{}}, nil}
+	parser := &DetectionFileParser{length: 3, sortedByFrame: sortedByFrame}
+
+	var numbers []int64
+	for frame := range parser.Frames() {
+		numbers = append(numbers, frame.Number)
+		if len(frame.Detections) != len(sortedByFrame[frame.Number-1]) {
+			t.Errorf("frame %d: expected %d detections, got %d", frame.Number, len(sortedByFrame[frame.Number-1]), len(frame.Detections))
+		}
+	}
+	if len(numbers) != 3 || numbers[0] != 1 || numbers[2] != 3 {
+		t.Errorf("Expected frames [1 2 3] including empty ones, got %v", numbers)
+	}
+}
+
+func TestDiagnoseFrames(t *testing.T) {
+	gt := &MOTChallengeData{VideoName: "seq", Frames: map[int64]*MOTChallengeFrame{}}
+	predictions := &MOTChallengeData{VideoName: "seq", Frames: map[int64]*MOTChallengeFrame{}}
+	// Frame 1: GT 1 matched by tracker 5, GT 2 missed, tracker 9 a false positive
+	gt.addRow(1, 1, []float64{0, 0, 10, 10}, 1, -1, -1)
+	gt.addRow(1, 2, []float64{50, 50, 60, 60}, 1, -1, -1)
+	predictions.addRow(1, 5, []float64{0, 0, 10, 10}, 1, -1, -1)
+	predictions.addRow(1, 9, []float64{100, 100, 110, 110}, 1, -1, -1)
+	// Frame 2: GT 1 switches to tracker 6
+	gt.addRow(2, 1, []float64{0, 0, 10, 10}, 1, -1, -1)
+	predictions.addRow(2, 6, []float64{0, 0, 10, 10}, 1, -1, -1)
+
+	diagnoses, err := DiagnoseFrames(gt, predictions, 0.5, nil)
+	if err != nil {
+		t.Fatalf("DiagnoseFrames failed: %v", err)
+	}
+	if len(diagnoses) != 2 {
+		t.Fatalf("Expected 2 frames, got %d", len(diagnoses))
+	}
+
+	first := diagnoses[1]
+	matches, falsePositives, misses, switches := first.Counts()
+	if matches != 1 || falsePositives != 1 || misses != 1 || switches != 0 {
+		t.Errorf("Unexpected frame 1 counts: %d, %d, %d, %d", matches, falsePositives, misses, switches)
+	}
+	for _, box := range first.GT {
+		if box.ID == 2 && (box.Type != motmetrics.EventMiss || box.BBox[0] != 50 || box.MatchedID != -1) {
+			t.Errorf("Expected GT 2 to be missed, got %+v", box)
+		}
+	}
+	for _, box := range first.Predictions {
+		if box.ID == 9 && (box.Type != motmetrics.EventFalsePositive || box.BBox[0] != 100) {
+			t.Errorf("Expected tracker 9 to be a false positive, got %+v", box)
+		}
+	}
+
+	second := diagnoses[2]
+	if len(second.Predictions) != 1 || second.Predictions[0].Type != motmetrics.EventSwitch || second.Predictions[0].MatchedID != 1 {
+		t.Errorf("Expected tracker 6 to switch to GT 1, got %+v", second.Predictions)
+	}
+}
@@ -0,0 +1,79 @@
+package norfairgo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/motfixtures"
+)
+
+// =============================================================================
+// End-to-End Tests on Embedded MOT Sequences
+// =============================================================================
+
+// TestMOTFixtures_ParserTrackerMetrics runs the full evaluation pipeline
+// (DetectionFileParser -> Tracker -> PredictionsTextFile -> metrics) on the
+// embedded mini-sequences.
+func TestMOTFixtures_ParserTrackerMetrics(t *testing.T) {
+	minMOTA := map[string]float64{
+		"MINI-01": 0.9, // Clean detections
+		"MINI-02": 0.6, // Noise, misses and false positives
+	}
+
+	for _, name := range motfixtures.Names() {
+		t.Run(name, func(t *testing.T) {
+			sequenceDir := motfixtures.Extract(t, name)
+			outputDir := t.TempDir()
+
+			parser, err := NewDetectionFileParser(sequenceDir, nil)
+			if err != nil {
+				t.Fatalf("NewDetectionFileParser failed: %v", err)
+			}
+			predictions, err := NewPredictionsTextFile(sequenceDir, outputDir, nil)
+			if err != nil {
+				t.Fatalf("NewPredictionsTextFile failed: %v", err)
+			}
+			tracker, err := NewTracker(&TrackerConfig{
+				DistanceFunction:    DistanceByName("iou"),
+				DistanceThreshold:   0.7,
+				HitCounterMax:       4,
+				InitializationDelay: 1,
+			})
+			if err != nil {
+				t.Fatalf("NewTracker failed: %v", err)
+			}
+
+			for frame, detections := range parser.All() {
+				frameNumber := int64(frame)
+				if err := predictions.Update(tracker.Update(detections, 1, nil), &frameNumber); err != nil {
+					t.Fatalf("Update predictions failed: %v", err)
+				}
+			}
+			if err := predictions.Close(); err != nil {
+				t.Fatalf("Close predictions failed: %v", err)
+			}
+
+			gt, err := LoadMotchallenge(filepath.Join(sequenceDir, "gt", "gt.txt"))
+			if err != nil {
+				t.Fatalf("LoadMotchallenge gt failed: %v", err)
+			}
+			predicted, err := LoadMotchallenge(filepath.Join(outputDir, "predictions", name+".txt"))
+			if err != nil {
+				t.Fatalf("LoadMotchallenge predictions failed: %v", err)
+			}
+			accumulators, err := CompareDataframes(gt, predicted, "iou", 0.5)
+			if err != nil {
+				t.Fatalf("CompareDataframes failed: %v", err)
+			}
+			metrics, err := accumulators.ComputeMetrics()
+			if err != nil {
+				t.Fatalf("ComputeMetrics failed: %v", err)
+			}
+
+			t.Logf("MOTA=%.3f IDF1=%.3f switches=%d", metrics.MOTA, metrics.IDF1, metrics.NumSwitches)
+			if want, ok := minMOTA[name]; ok && metrics.MOTA < want {
+				t.Errorf("Expected MOTA >= %.2f, got %.3f", want, metrics.MOTA)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package norfairgo
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Motion-Compensated Distance Threshold
+// =============================================================================
+
+// updateDistanceThreshold sets the distance threshold of the current frame from the
+// camera motion since the previous frame (see TrackerConfig.MotionThresholdScale).
+func (t *Tracker) updateDistanceThreshold(detections []*Detection, coordTransformations CoordinateTransformation) {
+	previous := t.lastTransformation
+	t.lastTransformation = coordTransformations
+	t.cameraMotion = 0
+	t.distanceThreshold = t.Config.DistanceThreshold
+	if t.Config.MotionThresholdScale <= 0 || previous == nil || coordTransformations == nil {
+		return
+	}
+
+	t.cameraMotion = cameraMotion(previous, coordTransformations, t.motionSamplePoint(detections))
+	factor := math.Min(1+t.Config.MotionThresholdScale*t.cameraMotion, t.Config.MaxMotionThresholdFactor)
+	t.distanceThreshold = t.Config.DistanceThreshold * factor
+}
+
+// motionSamplePoint returns the point where camera motion is measured: the mean of
+// the detections, else of the tracked objects, else the origin. With homographies
+// the motion differs across the frame, so it is measured where the objects are.
+func (t *Tracker) motionSamplePoint(detections []*Detection) [2]float64 {
+	var sum [2]float64
+	count := 0
+	add := func(points *mat.Dense) {
+		if points == nil {
+			return
+		}
+		rows, _ := points.Dims()
+		for i := 0; i < rows; i++ {
+			sum[0] += points.At(i, 0)
+			sum[1] += points.At(i, 1)
+		}
+		count += rows
+	}
+
+	for _, det := range detections {
+		add(det.Points)
+	}
+	if count == 0 {
+		for _, obj := range t.TrackedObjects {
+			add(obj.Estimate)
+		}
+	}
+	if count == 0 {
+		return [2]float64{}
+	}
+	return [2]float64{sum[0] / float64(count), sum[1] / float64(count)}
+}
+
+// cameraMotion returns how far the image content at point moved between two
+// frames, in pixels: the point is mapped to absolute coordinates with the current
+// transformation and back into the previous frame.
+func cameraMotion(previous, current CoordinateTransformation, point [2]float64) float64 {
+	absolute := current.RelToAbs(mat.NewDense(1, 2, point[:]))
+	before := previous.AbsToRel(absolute)
+	motion := math.Hypot(before.At(0, 0)-point[0], before.At(0, 1)-point[1])
+	if math.IsNaN(motion) {
+		return 0
+	}
+	return motion
+}
+
+// CurrentDistanceThreshold returns the distance threshold used in the latest
+// update: DistanceThreshold, scaled by the camera motion when MotionThresholdScale
+// is set.
+func (t *Tracker) CurrentDistanceThreshold() float64 {
+	return t.distanceThreshold
+}
+
+// CameraMotion returns the camera motion in pixels measured in the latest update
+// (0 unless MotionThresholdScale is set and both it and the previous update had a
+// coordinate transformation).
+func (t *Tracker) CameraMotion() float64 {
+	return t.cameraMotion
+}
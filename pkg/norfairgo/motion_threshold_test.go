@@ -0,0 +1,71 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+)
+
+// =============================================================================
+// Motion-Compensated Distance Threshold Tests
+// =============================================================================
+
+// runPanningSequence tracks a static object while the camera pans 50 pixels per
+// frame, with motion blur displacing the detections by +-12 pixels.
+func runPanningSequence(t *testing.T, motionThresholdScale float64) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:     DistanceByName("euclidean"),
+		DistanceThreshold:    10,
+		HitCounterMax:        6,
+		InitializationDelay:  1,
+		MotionThresholdScale: motionThresholdScale,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	for frame := 0; frame < 20; frame++ {
+		pan := 50 * float64(frame)
+		transformation, _ := NewTranslationTransformation([]float64{pan, 0})
+		blur := 12.0
+		if frame%2 == 0 {
+			blur = -12
+		}
+		tracker.Update([]*Detection{newPointDetection(t, 1000+pan+blur, 300)}, 1, transformation)
+	}
+	return tracker
+}
+
+func TestTracker_MotionThresholdScale(t *testing.T) {
+	// A fixed threshold loses the object during the pan
+	fixed := runPanningSequence(t, 0)
+	if len(fixed.GetActiveObjects()) == 1 && fixed.TotalObjectCount() == 1 {
+		t.Fatalf("Expected the fixed threshold to lose the track")
+	}
+	testutil.AssertAlmostEqual(t, fixed.CurrentDistanceThreshold(), 10, 1e-9, "fixed threshold")
+	testutil.AssertAlmostEqual(t, fixed.CameraMotion(), 0, 1e-9, "motion without scaling")
+
+	// Scaling with the 50 pixel motion (clamped to 3x) keeps a single track
+	scaled := runPanningSequence(t, 0.05)
+	if scaled.TotalObjectCount() != 1 {
+		t.Errorf("Expected a single track with motion scaling, got %d IDs", scaled.TotalObjectCount())
+	}
+	testutil.AssertAlmostEqual(t, scaled.CameraMotion(), 50, 1e-9, "camera motion")
+	testutil.AssertAlmostEqual(t, scaled.CurrentDistanceThreshold(), 30, 1e-9, "scaled threshold")
+
+	// No transformation, no scaling
+	scaled.Update(nil, 1, nil)
+	testutil.AssertAlmostEqual(t, scaled.CurrentDistanceThreshold(), 10, 1e-9, "threshold without transformation")
+}
+
+func TestNewTracker_MotionThresholdValidation(t *testing.T) {
+	for _, config := range []*TrackerConfig{
+		{MotionThresholdScale: -1},
+		{MotionThresholdScale: 0.1, MaxMotionThresholdFactor: 0.5},
+	} {
+		if _, err := NewTracker(config); err == nil {
+			t.Errorf("Expected an error for %+v", config)
+		}
+	}
+}
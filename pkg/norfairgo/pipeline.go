@@ -0,0 +1,222 @@
+//go:build !nogocv
+
+package norfairgo
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+
+	"gocv.io/x/gocv"
+)
+
+// =============================================================================
+// Pipeline - Parallel Decode, Detect, Track and Sink Stages
+// =============================================================================
+
+// PipelineFrame carries one frame through the pipeline stages.
+type PipelineFrame struct {
+	Index       int      // 0-based position in the source; frames are tracked in this order
+	FrameNumber int      // Frame number reported by the source
+	Image       gocv.Mat // Decoded frame, closed by the pipeline after the sinks ran
+
+	// Set by PipelineConfig.Detect
+	Detections          []*Detection
+	CoordTransformation CoordinateTransformation // Camera motion (nil = none)
+
+	// Set by the tracking stage: snapshots of the active objects (see SnapshotObjects)
+	Objects []TrackedObjectLike
+}
+
+// PipelineConfig configures a Pipeline.
+type PipelineConfig struct {
+	// Frames to process, e.g. Video.All() or VideoFromFrames.All().
+	// Required.
+	Source iter.Seq2[int, gocv.Mat]
+
+	// Sets the detections (and optionally the camera motion) of a frame, e.g. by
+	// running a detector on frame.Image. With DetectWorkers > 1 it is called
+	// concurrently on different frames; motion estimation, which depends on the
+	// previous frame, then belongs in a sink or a single-worker pipeline.
+	// Required.
+	Detect func(frame *PipelineFrame) error
+
+	// Tracker updated with each frame's detections, in source order. It must not be
+	// used elsewhere while the pipeline runs.
+	// Required.
+	Tracker *Tracker
+
+	// Called in order on each tracked frame, e.g. to draw and write it. Sinks run on
+	// their own goroutine, concurrently with tracking of later frames; they must
+	// Clone frame.Image to keep it after returning.
+	// Default: nil (no sinks)
+	Sinks []func(frame *PipelineFrame) error
+
+	// Number of goroutines running Detect.
+	// Default: 1
+	DetectWorkers int
+
+	// Capacity of the channels between stages. Together with DetectWorkers it
+	// bounds the number of decoded frames in flight (backpressure on the source).
+	// Default: 2
+	BufferSize int
+}
+
+// Pipeline wires a frame source, a detection provider, a tracker and sinks into
+// concurrent stages connected by bounded channels, so applications use several
+// cores without hand-rolling goroutine plumbing:
+//
+//	source → detect (DetectWorkers) → track (in order) → sinks
+type Pipeline struct {
+	config PipelineConfig
+}
+
+// NewPipeline creates a new Pipeline.
+//
+// Returns: Error if Source, Detect or Tracker is missing, or a count is negative
+func NewPipeline(config *PipelineConfig) (*Pipeline, error) {
+	if config == nil || config.Source == nil || config.Detect == nil || config.Tracker == nil {
+		return nil, fmt.Errorf("pipeline requires a source, a detect function and a tracker")
+	}
+	c := *config
+	if c.DetectWorkers < 0 || c.BufferSize < 0 {
+		return nil, fmt.Errorf("detect workers and buffer size must be >= 0, got %d and %d", c.DetectWorkers, c.BufferSize)
+	}
+	if c.DetectWorkers == 0 {
+		c.DetectWorkers = 1
+	}
+	if c.BufferSize == 0 {
+		c.BufferSize = 2
+	}
+	return &Pipeline{config: c}, nil
+}
+
+// Run processes the source until it is exhausted, the context is cancelled or a
+// stage fails. Frames still in flight are closed when the run stops early.
+//
+// Returns: The first Detect or sink error, ctx.Err() if cancelled, or nil
+func (p *Pipeline) Run(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	buffer := p.config.BufferSize
+	workers := p.config.DetectWorkers
+	inFlight := make(chan struct{}, workers+3*buffer) // Bounds frames between decode and tracking
+	sourced := make(chan *PipelineFrame, buffer)
+	detected := make(chan *PipelineFrame, buffer)
+	tracked := make(chan *PipelineFrame, buffer)
+
+	// send forwards a frame to the next stage, closing it if the run was cancelled
+	send := func(out chan<- *PipelineFrame, frame *PipelineFrame) bool {
+		select {
+		case out <- frame:
+			return true
+		case <-runCtx.Done():
+			frame.Image.Close()
+			return false
+		}
+	}
+
+	// Stage 1: decode
+	go func() {
+		defer close(sourced)
+		index := 0
+		for frameNumber, image := range p.config.Source {
+			select {
+			case inFlight <- struct{}{}:
+			case <-runCtx.Done():
+				image.Close()
+				return
+			}
+			if !send(sourced, &PipelineFrame{Index: index, FrameNumber: frameNumber, Image: image}) {
+				return
+			}
+			index++
+		}
+	}()
+
+	// Stage 2: detect, possibly out of order
+	var detectWG sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		detectWG.Add(1)
+		go func() {
+			defer detectWG.Done()
+			for frame := range sourced {
+				if runCtx.Err() != nil {
+					frame.Image.Close()
+					continue
+				}
+				if err := p.config.Detect(frame); err != nil {
+					frame.Image.Close()
+					fail(fmt.Errorf("detect frame %d: %w", frame.FrameNumber, err))
+					continue
+				}
+				send(detected, frame)
+			}
+		}()
+	}
+	go func() {
+		detectWG.Wait()
+		close(detected)
+	}()
+
+	// Stage 3: track, restoring source order
+	go func() {
+		defer close(tracked)
+		pending := make(map[int]*PipelineFrame)
+		next := 0
+		for frame := range detected {
+			if runCtx.Err() != nil {
+				frame.Image.Close()
+				continue
+			}
+			pending[frame.Index] = frame
+			for {
+				frame, ok := pending[next]
+				if !ok || runCtx.Err() != nil {
+					break
+				}
+				delete(pending, next)
+				next++
+
+				activeObjects := p.config.Tracker.Update(frame.Detections, 1, frame.CoordTransformation)
+				frame.Objects = SnapshotObjects(activeObjects)
+				<-inFlight
+				send(tracked, frame)
+			}
+		}
+		for _, frame := range pending {
+			frame.Image.Close()
+		}
+	}()
+
+	// Stage 4: sinks
+	for frame := range tracked {
+		if runCtx.Err() == nil {
+			for _, sink := range p.config.Sinks {
+				if err := sink(frame); err != nil {
+					fail(fmt.Errorf("sink on frame %d: %w", frame.FrameNumber, err))
+					break
+				}
+			}
+		}
+		frame.Image.Close()
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
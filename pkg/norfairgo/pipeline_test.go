@@ -0,0 +1,152 @@
+//go:build !nogocv
+
+package norfairgo
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gocv.io/x/gocv"
+)
+
+// pipelineSource yields n small frames, counting how many were produced.
+func pipelineSource(n int, produced *int) iter.Seq2[int, gocv.Mat] {
+	return func(yield func(int, gocv.Mat) bool) {
+		for i := 1; i <= n; i++ {
+			*produced++
+			if !yield(i, gocv.NewMatWithSize(4, 4, gocv.MatTypeCV8UC3)) {
+				return
+			}
+		}
+	}
+}
+
+func newPipelineTracker(t *testing.T) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   10,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	return tracker
+}
+
+func TestNewPipeline_Validation(t *testing.T) {
+	if _, err := NewPipeline(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+	if _, err := NewPipeline(&PipelineConfig{Tracker: newPipelineTracker(t)}); err == nil {
+		t.Error("expected error for missing source and detect function")
+	}
+}
+
+func TestPipeline_RunInOrder(t *testing.T) {
+	produced := 0
+	var frameNumbers []int
+	var positions []float64
+
+	pipeline, err := NewPipeline(&PipelineConfig{
+		Source: pipelineSource(30, &produced),
+		Detect: func(frame *PipelineFrame) error {
+			// Uneven latency so workers finish out of order
+			time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
+			frame.Detections = []*Detection{newPointDetection(t, float64(frame.FrameNumber), 0)}
+			return nil
+		},
+		Tracker: newPipelineTracker(t),
+		Sinks: []func(frame *PipelineFrame) error{
+			func(frame *PipelineFrame) error {
+				frameNumbers = append(frameNumbers, frame.FrameNumber)
+				if len(frame.Objects) != 1 {
+					t.Errorf("frame %d: expected 1 object, got %d", frame.FrameNumber, len(frame.Objects))
+					return nil
+				}
+				estimate, _ := frame.Objects[0].GetEstimate(false)
+				positions = append(positions, estimate.At(0, 0))
+				return nil
+			},
+		},
+		DetectWorkers: 4,
+	})
+	if err != nil {
+		t.Fatalf("NewPipeline failed: %v", err)
+	}
+
+	if err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(frameNumbers) != 30 {
+		t.Fatalf("expected 30 frames, got %d", len(frameNumbers))
+	}
+	for i, n := range frameNumbers {
+		if n != i+1 {
+			t.Fatalf("frames reached the sinks out of order: %v", frameNumbers)
+		}
+	}
+	// Snapshots are not modified by later updates
+	for i := 1; i < len(positions); i++ {
+		if positions[i] <= positions[i-1] {
+			t.Fatalf("expected increasing positions, got %v", positions)
+		}
+	}
+}
+
+func TestPipeline_StopsOnError(t *testing.T) {
+	produced := 0
+	stop := errors.New("stop")
+	pipeline, err := NewPipeline(&PipelineConfig{
+		Source: pipelineSource(1000, &produced),
+		Detect: func(frame *PipelineFrame) error {
+			if frame.FrameNumber == 5 {
+				return stop
+			}
+			return nil
+		},
+		Tracker: newPipelineTracker(t),
+	})
+	if err != nil {
+		t.Fatalf("NewPipeline failed: %v", err)
+	}
+
+	if err := pipeline.Run(context.Background()); !errors.Is(err, stop) {
+		t.Fatalf("expected the detect error, got %v", err)
+	}
+	if produced >= 1000 {
+		t.Errorf("expected the source to stop early, produced %d frames", produced)
+	}
+}
+
+func TestPipeline_Cancel(t *testing.T) {
+	produced := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	pipeline, err := NewPipeline(&PipelineConfig{
+		Source: pipelineSource(1000, &produced),
+		Detect: func(frame *PipelineFrame) error { return nil },
+		Sinks: []func(frame *PipelineFrame) error{
+			func(frame *PipelineFrame) error {
+				if frame.FrameNumber == 10 {
+					cancel()
+				}
+				return nil
+			},
+		},
+		Tracker: newPipelineTracker(t),
+	})
+	if err != nil {
+		t.Fatalf("NewPipeline failed: %v", err)
+	}
+
+	if err := pipeline.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if produced >= 1000 {
+		t.Errorf("expected the source to stop early, produced %d frames", produced)
+	}
+}
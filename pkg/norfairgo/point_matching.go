@@ -0,0 +1,141 @@
+package norfairgo
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Point Correspondence - Asymmetric Point Counts
+// =============================================================================
+
+// AlignDetectionPoints aligns a detection to a track whose point count differs.
+//
+// Detection points are matched one-to-one to the track's estimated points by
+// greedy nearest-point matching (see MatchDetectionsAndObjects). The returned
+// detection has exactly the track's point count:
+//   - matched points take the detection's coordinates and score (1.0 if unscored)
+//   - unmatched track points are filled with the track's estimate and score 0.0,
+//     so they are gated out of the Kalman update (see TrackerConfig.DetectionThreshold)
+//   - unmatched detection points are dropped
+//
+// Parameters:
+//   - detection: Detection with a possibly different number of points
+//   - trackedObject: Track to align to
+//   - threshold: Maximum point-to-point distance for a correspondence
+//
+// Returns: The aligned detection, or false if dimensions differ or no point matched
+func AlignDetectionPoints(detection *Detection, trackedObject *TrackedObject, threshold float64) (*Detection, bool) {
+	detRows, detCols := detection.Points.Dims()
+	if detCols != trackedObject.DimPoints {
+		return nil, false
+	}
+
+	// Filter positions are absolute when a coordinate transformation is set
+	absEstimate, err := trackedObject.GetEstimate(trackedObject.AbsToRel != nil)
+	if err != nil {
+		return nil, false
+	}
+	relEstimate := trackedObject.Estimate
+	if relEstimate == nil {
+		relEstimate = absEstimate
+	}
+
+	// Point-to-point distances (rows = detection points, cols = track points)
+	numPoints := trackedObject.NumPoints
+	pointDistances := mat.NewDense(detRows, numPoints, nil)
+	for i := 0; i < detRows; i++ {
+		for j := 0; j < numPoints; j++ {
+			sum := 0.0
+			for d := 0; d < detCols; d++ {
+				diff := detection.Points.At(i, d) - relEstimate.At(j, d)
+				sum += diff * diff
+			}
+			pointDistances.Set(i, j, math.Sqrt(sum))
+		}
+	}
+
+	detIndices, trackIndices := MatchDetectionsAndObjects(pointDistances, threshold)
+	if len(detIndices) == 0 {
+		return nil, false
+	}
+
+	// Start from the track's estimate with every point gated
+	points := mat.DenseCopyOf(relEstimate)
+	absolutePoints := mat.DenseCopyOf(absEstimate)
+	scores := make([]float64, numPoints)
+
+	for k, i := range detIndices {
+		j := trackIndices[k]
+		for d := 0; d < detCols; d++ {
+			points.Set(j, d, detection.Points.At(i, d))
+			absolutePoints.Set(j, d, detection.AbsolutePoints.At(i, d))
+		}
+		if detection.Scores != nil {
+			scores[j] = detection.Scores[i]
+		} else {
+			scores[j] = 1.0
+		}
+	}
+
+	return &Detection{
+		Points:         points,
+		AbsolutePoints: absolutePoints,
+		Scores:         scores,
+		Data:           detection.Data,
+		Label:          detection.Label,
+		ClassID:        detection.ClassID,
+		Embedding:      detection.Embedding,
+		Coordinates:    detection.Coordinates,
+		Age:            detection.Age,
+	}, true
+}
+
+// alignedDistances computes the candidate/object distance matrix when point counts
+// may differ, aligning each mismatched pair with AlignDetectionPoints.
+//
+// Returns:
+//   - distanceMatrix: NxM matrix (N=detections, M=objects), +Inf for unalignable pairs
+//   - aligned: aligned[i][j] is the detection to use for Hit, nil if it is detections[i] itself
+func (t *Tracker) alignedDistances(
+	distanceFunction Distance,
+	objects []*TrackedObject,
+	detections []*Detection,
+) (*mat.Dense, [][]*Detection) {
+	distanceMatrix := createInfinityMatrix(len(detections), len(objects))
+	aligned := make([][]*Detection, len(detections))
+
+	for i, det := range detections {
+		aligned[i] = make([]*Detection, len(objects))
+		detRows, _ := det.Points.Dims()
+		for j, obj := range objects {
+			candidate := det
+			if detRows != obj.NumPoints {
+				alignedDet, ok := AlignDetectionPoints(det, obj, t.Config.PointMatchingThreshold)
+				if !ok {
+					continue
+				}
+				candidate = alignedDet
+				aligned[i][j] = alignedDet
+			}
+			pairDistance := getDistances(distanceFunction, []*TrackedObject{obj}, []*Detection{candidate})
+			distanceMatrix.Set(i, j, pairDistance.At(0, 0))
+		}
+	}
+
+	return distanceMatrix, aligned
+}
+
+// hasPointCountMismatch reports whether any detection's point count differs from any object's.
+func hasPointCountMismatch(objects []*TrackedObject, detections []*Detection) bool {
+	for _, det := range detections {
+		detRows, _ := det.Points.Dims()
+		for _, obj := range objects {
+			if detRows != obj.NumPoints {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package norfairgo
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Point Correspondence Tests
+// =============================================================================
+
+func TestAlignDetectionPoints(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{InitializationDelay: 0})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	full, _ := NewDetection(mat.NewDense(3, 2, []float64{0, 0, 10, 0, 20, 0}), nil)
+	tracker.Update([]*Detection{full}, 1, nil)
+	obj := tracker.TrackedObjects[0]
+
+	// Partial detection: the middle point is missing, the others slightly moved
+	det, _ := NewDetection(mat.NewDense(2, 2, []float64{21, 1, 1, 1}), &DetectionConfig{Scores: []float64{0.9, 0.8}})
+
+	aligned, ok := AlignDetectionPoints(det, obj, 5.0)
+	if !ok {
+		t.Fatal("Expected detection to be aligned")
+	}
+	if rows, _ := aligned.Points.Dims(); rows != 3 {
+		t.Fatalf("Expected 3 aligned points, got %d", rows)
+	}
+
+	expectedPoints := [][]float64{{1, 1}, {10, 0}, {21, 1}}
+	for i, p := range expectedPoints {
+		if aligned.Points.At(i, 0) != p[0] || aligned.Points.At(i, 1) != p[1] {
+			t.Errorf("Point %d: expected %v, got [%v %v]", i, p, aligned.Points.At(i, 0), aligned.Points.At(i, 1))
+		}
+	}
+	expectedScores := []float64{0.8, 0, 0.9}
+	for i, s := range expectedScores {
+		if aligned.Scores[i] != s {
+			t.Errorf("Score %d: expected %v, got %v", i, s, aligned.Scores[i])
+		}
+	}
+
+	// No point within threshold
+	far, _ := NewDetection(mat.NewDense(1, 2, []float64{100, 100}), nil)
+	if _, ok := AlignDetectionPoints(far, obj, 5.0); ok {
+		t.Error("Expected alignment to fail when no point is within threshold")
+	}
+}
+
+func TestTracker_PointMatchingPartialDetections(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:       DistanceByName("euclidean"),
+		DistanceThreshold:      10.0,
+		HitCounterMax:          10,
+		InitializationDelay:    0,
+		PointMatchingThreshold: 5.0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	full, _ := NewDetection(mat.NewDense(3, 2, []float64{0, 0, 10, 0, 20, 0}), nil)
+	tracker.Update([]*Detection{full}, 1, nil)
+	if len(tracker.TrackedObjects) != 1 {
+		t.Fatalf("Expected 1 tracked object, got %d", len(tracker.TrackedObjects))
+	}
+	obj := tracker.TrackedObjects[0]
+
+	for i := 0; i < 3; i++ {
+		partial, _ := NewDetection(mat.NewDense(2, 2, []float64{20, 0, 0, 0}), nil)
+		tracker.Update([]*Detection{partial}, 1, nil)
+	}
+
+	if len(tracker.TrackedObjects) != 1 {
+		t.Fatalf("Expected partial detections to match the existing track, got %d objects", len(tracker.TrackedObjects))
+	}
+	if obj.NumPoints != 3 {
+		t.Errorf("Expected track to keep 3 points, got %d", obj.NumPoints)
+	}
+	if obj.GatedPoints[0] || !obj.GatedPoints[1] || obj.GatedPoints[2] {
+		t.Errorf("Expected only the missing middle point to be gated, got %v", obj.GatedPoints)
+	}
+	if x := obj.Estimate.At(1, 0); math.Abs(x-10) > 1e-6 {
+		t.Errorf("Expected missing point to keep its estimate x=10, got %v", x)
+	}
+}
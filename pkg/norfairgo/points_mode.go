@@ -0,0 +1,105 @@
+package norfairgo
+
+import (
+	"fmt"
+)
+
+// =============================================================================
+// Points Mode - Bounding Box vs Keypoint Detections
+// =============================================================================
+
+// PointsMode selects the shape Tracker.Update expects detection points to have.
+//
+// Bounding boxes and keypoints are tracked the same way, but box distances such
+// as "iou" silently give meaningless results for keypoints, and keypoint
+// detections of varying shapes are dropped per label. An explicit mode makes
+// Tracker.Update reject non-conforming detections with a descriptive warning.
+type PointsMode int
+
+const (
+	// PointsAuto accepts any shape that is consistent per label and class ID, and
+	// detects the mode from the first frame with detections (see Tracker.PointsMode).
+	PointsAuto PointsMode = iota
+
+	// PointsBBox requires bounding boxes [[x1, y1], [x2, y2]] with x1 <= x2 and y1 <= y2.
+	PointsBBox
+
+	// PointsKeypoints requires every detection to have the same number of points
+	// and dimensions, whatever its label (only the dimensions must match when
+	// PointMatchingThreshold allows partial detections).
+	PointsKeypoints
+)
+
+// String returns the name of the mode.
+func (m PointsMode) String() string {
+	switch m {
+	case PointsAuto:
+		return "auto"
+	case PointsBBox:
+		return "bbox"
+	case PointsKeypoints:
+		return "keypoints"
+	default:
+		return fmt.Sprintf("PointsMode(%d)", int(m))
+	}
+}
+
+// PointsMode returns the points mode the tracker enforces: TrackerConfig.PointsMode,
+// or with PointsAuto the mode detected from the first frame with detections
+// (PointsBBox if they were all bounding boxes, PointsKeypoints otherwise).
+// Returns PointsAuto until a PointsAuto tracker has seen a detection.
+func (t *Tracker) PointsMode() PointsMode {
+	return t.pointsMode
+}
+
+// checkPointsMode returns an error describing why a valid detection does not
+// conform to TrackerConfig.PointsMode.
+func (t *Tracker) checkPointsMode(det *Detection) error {
+	rows, cols := det.Points.Dims()
+	switch t.Config.PointsMode {
+	case PointsBBox:
+		if rows != 2 || cols != 2 {
+			return fmt.Errorf("PointsMode is bbox but got %s, expected a bounding box [[x1, y1], [x2, y2]]", describeShape(rows, cols))
+		}
+		if det.Points.At(0, 0) > det.Points.At(1, 0) || det.Points.At(0, 1) > det.Points.At(1, 1) {
+			return fmt.Errorf("PointsMode is bbox but got corners with x1 > x2 or y1 > y2, expected [[x1, y1], [x2, y2]]")
+		}
+	case PointsKeypoints:
+		if t.keypointShape == [2]int{} {
+			t.keypointShape = [2]int{rows, cols}
+		} else if cols != t.keypointShape[1] || (rows != t.keypointShape[0] && t.Config.PointMatchingThreshold <= 0) {
+			return fmt.Errorf("PointsMode is keypoints but got %s, expected %s like the first detection",
+				describeShape(rows, cols), describeShape(t.keypointShape[0], t.keypointShape[1]))
+		}
+	}
+	return nil
+}
+
+// detectPointsMode returns PointsBBox if every detection is a bounding box with
+// ordered corners, PointsKeypoints otherwise.
+func detectPointsMode(detections []*Detection) PointsMode {
+	for _, det := range detections {
+		if !isBoundingBox(det) {
+			return PointsKeypoints
+		}
+	}
+	return PointsBBox
+}
+
+func isBoundingBox(det *Detection) bool {
+	rows, cols := det.Points.Dims()
+	return rows == 2 && cols == 2 &&
+		det.Points.At(0, 0) <= det.Points.At(1, 0) && det.Points.At(0, 1) <= det.Points.At(1, 1)
+}
+
+// describeShape describes a points shape for warnings, e.g. "17 keypoints (17, 2)".
+func describeShape(rows, cols int) string {
+	switch {
+	case rows == 2 && cols == 2:
+		return "a bounding box or 2 keypoints (2, 2)"
+	case rows == 1:
+		return fmt.Sprintf("a single point (1, %d)", cols)
+	default:
+		return fmt.Sprintf("%d keypoints (%d, %d)", rows, rows, cols)
+	}
+}
@@ -0,0 +1,118 @@
+package norfairgo
+
+import (
+	"testing"
+)
+
+func newPointsModeTracker(t *testing.T, mode PointsMode) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   10.0,
+		InitializationDelay: 0,
+		PointsMode:          mode,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	return tracker
+}
+
+func newSliceDetection(t *testing.T, values []float64, config *DetectionConfig) *Detection {
+	t.Helper()
+	det, err := NewDetectionFromSlice(values, 2, config)
+	if err != nil {
+		t.Fatalf("Failed to create detection: %v", err)
+	}
+	return det
+}
+
+func TestTracker_PointsModeAuto(t *testing.T) {
+	boxes := newPointsModeTracker(t, PointsAuto)
+	if boxes.PointsMode() != PointsAuto {
+		t.Errorf("Expected PointsAuto before any detection, got %v", boxes.PointsMode())
+	}
+	boxes.Update(nil, 1, nil)
+	if boxes.PointsMode() != PointsAuto {
+		t.Errorf("Expected PointsAuto after an empty frame, got %v", boxes.PointsMode())
+	}
+	boxes.Update([]*Detection{newSliceDetection(t, []float64{0, 0, 10, 10}, nil)}, 1, nil)
+	if boxes.PointsMode() != PointsBBox {
+		t.Errorf("Expected detected PointsBBox, got %v", boxes.PointsMode())
+	}
+
+	keypoints := newPointsModeTracker(t, PointsAuto)
+	keypoints.Update([]*Detection{
+		newSliceDetection(t, []float64{0, 0, 10, 10}, nil),
+		newSliceDetection(t, []float64{50, 50, 60, 60, 70, 70}, labelConfig("pose")),
+	}, 1, nil)
+	if keypoints.PointsMode() != PointsKeypoints {
+		t.Errorf("Expected detected PointsKeypoints, got %v", keypoints.PointsMode())
+	}
+	if len(keypoints.TrackedObjects) != 2 {
+		t.Errorf("Expected auto mode to accept shapes consistent per label, got %d objects", len(keypoints.TrackedObjects))
+	}
+}
+
+func TestTracker_PointsModeBBox(t *testing.T) {
+	tracker := newPointsModeTracker(t, PointsBBox)
+	tracker.Update([]*Detection{
+		newSliceDetection(t, []float64{0, 0, 10, 10}, nil),                                 // Box
+		newSliceDetection(t, []float64{100, 100, 90, 110}, nil),                            // x1 > x2
+		newSliceDetection(t, []float64{200, 200, 210, 210, 220, 220}, labelConfig("pose")), // 3 points
+	}, 1, nil)
+
+	if len(tracker.TrackedObjects) != 1 {
+		t.Fatalf("Expected only the bounding box to be tracked, got %d objects", len(tracker.TrackedObjects))
+	}
+	if tracker.PointsMode() != PointsBBox {
+		t.Errorf("Expected PointsBBox, got %v", tracker.PointsMode())
+	}
+}
+
+func TestTracker_PointsModeKeypoints(t *testing.T) {
+	tracker := newPointsModeTracker(t, PointsKeypoints)
+	tracker.Update([]*Detection{
+		newSliceDetection(t, []float64{0, 0, 10, 10, 20, 20}, nil),
+		newSliceDetection(t, []float64{100, 100, 110, 110}, labelConfig("other")), // 2 points
+		newSliceDetection(t, []float64{200, 200, 210, 210, 220, 220}, labelConfig("other")),
+	}, 1, nil)
+
+	if len(tracker.TrackedObjects) != 2 {
+		t.Fatalf("Expected the 2-point detection to be skipped, got %d objects", len(tracker.TrackedObjects))
+	}
+	for _, obj := range tracker.TrackedObjects {
+		if obj.NumPoints != 3 {
+			t.Errorf("Expected 3-point objects, got %d points", obj.NumPoints)
+		}
+	}
+}
+
+func TestTracker_PointsModeInvalid(t *testing.T) {
+	_, err := NewTracker(&TrackerConfig{
+		DistanceFunction:  DistanceByName("euclidean"),
+		DistanceThreshold: 10.0,
+		PointsMode:        PointsMode(7),
+	})
+	if err == nil {
+		t.Error("Expected error for invalid points mode")
+	}
+}
+
+func TestDescribeShape(t *testing.T) {
+	tests := map[[2]int]string{
+		{2, 2}:  "a bounding box or 2 keypoints (2, 2)",
+		{1, 3}:  "a single point (1, 3)",
+		{17, 2}: "17 keypoints (17, 2)",
+	}
+	for shape, expected := range tests {
+		if got := describeShape(shape[0], shape[1]); got != expected {
+			t.Errorf("describeShape(%d, %d) = %q, expected %q", shape[0], shape[1], got, expected)
+		}
+	}
+}
+
+// labelConfig returns a DetectionConfig with only a label.
+func labelConfig(label string) *DetectionConfig {
+	return &DetectionConfig{Label: StringPtr(label)}
+}
@@ -0,0 +1,213 @@
+package norfairgo
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Proximity - Pairs of Objects That Stay Close in World Space
+// =============================================================================
+
+// ProximityConfig configures a ProximityMonitor.
+type ProximityConfig struct {
+	// Ground distance (world units) at or below which a pair counts as close.
+	// Required (must be > 0).
+	Threshold float64
+
+	// Ground distance above which a close pair is released. Keeping it larger than
+	// Threshold adds hysteresis, so pairs hovering around Threshold don't flap.
+	// Default: 1.2 * Threshold
+	ExitThreshold float64
+
+	// Number of consecutive updates a pair must be close before a start event is
+	// emitted.
+	// Default: 1
+	MinDuration int
+}
+
+// ProximityEventType distinguishes the start and end of a proximity episode.
+type ProximityEventType int
+
+const (
+	// ProximityStart is emitted once a pair has been close for MinDuration updates.
+	ProximityStart ProximityEventType = iota
+
+	// ProximityEnd is emitted when a close pair moves beyond ExitThreshold or one
+	// of its objects is no longer tracked.
+	ProximityEnd
+)
+
+// String returns "start" or "end".
+func (t ProximityEventType) String() string {
+	if t == ProximityEnd {
+		return "end"
+	}
+	return "start"
+}
+
+// ProximityEvent reports a pair of objects entering or leaving proximity.
+type ProximityEvent struct {
+	Type        ProximityEventType
+	IDA, IDB    int     // Object IDs, IDA < IDB
+	Frame       int64   // Update number (1-indexed) the event was emitted on
+	StartFrame  int64   // First update of the episode the pair was close on
+	Distance    float64 // Ground distance on this update (0 if an object is gone)
+	MinDistance float64 // Smallest ground distance of the episode so far
+}
+
+// proximityPair tracks one pair of objects that is, or is becoming, close.
+type proximityPair struct {
+	startFrame  int64
+	closeFrames int64
+	active      bool
+	distance    float64 // Ground distance on the last update
+	minDistance float64
+}
+
+// ProximityMonitor computes pairwise ground distances between tracked objects on
+// every update and emits events when pairs stay within a threshold for longer than
+// a minimum duration, e.g. for social-distancing or near-miss analytics.
+type ProximityMonitor struct {
+	projector   *GroundPlaneProjector
+	config      ProximityConfig
+	frameNumber int64
+	pairs       map[[2]int]*proximityPair
+}
+
+// NewProximityMonitor creates a new ProximityMonitor.
+//
+// Parameters:
+//   - projector: Projects objects onto the ground plane (nil = use image coordinates)
+//   - config: Thresholds and duration, see ProximityConfig
+//
+// Returns: Error if Threshold is not positive or ExitThreshold is below Threshold
+func NewProximityMonitor(projector *GroundPlaneProjector, config ProximityConfig) (*ProximityMonitor, error) {
+	if config.Threshold <= 0 {
+		return nil, fmt.Errorf("threshold must be > 0, got %v", config.Threshold)
+	}
+	if config.ExitThreshold == 0 {
+		config.ExitThreshold = 1.2 * config.Threshold
+	}
+	if config.ExitThreshold < config.Threshold {
+		return nil, fmt.Errorf("exit threshold (%v) must be >= threshold (%v)", config.ExitThreshold, config.Threshold)
+	}
+	if config.MinDuration <= 0 {
+		config.MinDuration = 1
+	}
+
+	if projector == nil {
+		identity, err := NewGroundPlaneProjector(mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}))
+		if err != nil {
+			return nil, err
+		}
+		projector = identity
+	}
+
+	return &ProximityMonitor{
+		projector: projector,
+		config:    config,
+		pairs:     make(map[[2]int]*proximityPair),
+	}, nil
+}
+
+// Update computes the pairwise distances of the current objects and returns the
+// events emitted on this update, ordered by pair.
+func (m *ProximityMonitor) Update(objects []TrackedObjectLike) []ProximityEvent {
+	m.frameNumber++
+	positions := m.projector.ProjectAll(objects)
+
+	ids := make([]int, 0, len(positions))
+	for id := range positions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var events []ProximityEvent
+	seen := make(map[[2]int]bool)
+	for i, idA := range ids {
+		for _, idB := range ids[i+1:] {
+			key := [2]int{idA, idB}
+			distance := GroundDistance(positions[idA], positions[idB])
+			pair := m.pairs[key]
+
+			if pair == nil || !pair.active {
+				if distance > m.config.Threshold {
+					delete(m.pairs, key)
+					continue
+				}
+				if pair == nil {
+					pair = &proximityPair{startFrame: m.frameNumber, minDistance: distance}
+					m.pairs[key] = pair
+				}
+			} else if distance > m.config.ExitThreshold {
+				events = append(events, m.event(ProximityEnd, key, pair, distance))
+				delete(m.pairs, key)
+				continue
+			}
+
+			seen[key] = true
+			pair.closeFrames++
+			pair.distance = distance
+			if distance < pair.minDistance {
+				pair.minDistance = distance
+			}
+			if !pair.active && pair.closeFrames >= int64(m.config.MinDuration) {
+				pair.active = true
+				events = append(events, m.event(ProximityStart, key, pair, distance))
+			}
+		}
+	}
+
+	// Pairs with an object that is no longer tracked
+	for key, pair := range m.pairs {
+		if seen[key] {
+			continue
+		}
+		if pair.active {
+			events = append(events, m.event(ProximityEnd, key, pair, 0))
+		}
+		delete(m.pairs, key)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].IDA != events[j].IDA {
+			return events[i].IDA < events[j].IDA
+		}
+		return events[i].IDB < events[j].IDB
+	})
+	return events
+}
+
+// Active returns the pairs currently in proximity, ordered by pair. Each entry
+// describes the episode so far, with Type ProximityStart and the latest Distance.
+func (m *ProximityMonitor) Active() []ProximityEvent {
+	active := make([]ProximityEvent, 0, len(m.pairs))
+	for key, pair := range m.pairs {
+		if pair.active {
+			active = append(active, m.event(ProximityStart, key, pair, pair.distance))
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].IDA != active[j].IDA {
+			return active[i].IDA < active[j].IDA
+		}
+		return active[i].IDB < active[j].IDB
+	})
+	return active
+}
+
+// event builds an event for a pair on the current update.
+func (m *ProximityMonitor) event(eventType ProximityEventType, key [2]int, pair *proximityPair, distance float64) ProximityEvent {
+	return ProximityEvent{
+		Type:        eventType,
+		IDA:         key[0],
+		IDB:         key[1],
+		Frame:       m.frameNumber,
+		StartFrame:  pair.startFrame,
+		Distance:    distance,
+		MinDistance: pair.minDistance,
+	}
+}
@@ -0,0 +1,89 @@
+package norfairgo
+
+import (
+	"testing"
+)
+
+func proximityObjects(positions map[int][2]float64) []TrackedObjectLike {
+	objects := make([]TrackedObjectLike, 0, len(positions))
+	for id, p := range positions {
+		objects = append(objects, newCropTrack(id, p[0], p[1], p[0], p[1]))
+	}
+	return objects
+}
+
+func TestNewProximityMonitor_Validation(t *testing.T) {
+	if _, err := NewProximityMonitor(nil, ProximityConfig{}); err == nil {
+		t.Error("expected error for zero threshold")
+	}
+	if _, err := NewProximityMonitor(nil, ProximityConfig{Threshold: 2, ExitThreshold: 1}); err == nil {
+		t.Error("expected error for exit threshold below threshold")
+	}
+
+	monitor, err := NewProximityMonitor(nil, ProximityConfig{Threshold: 10})
+	if err != nil {
+		t.Fatalf("NewProximityMonitor failed: %v", err)
+	}
+	if monitor.config.ExitThreshold != 12 || monitor.config.MinDuration != 1 {
+		t.Errorf("unexpected defaults: %+v", monitor.config)
+	}
+}
+
+func TestProximityMonitor_MinDurationAndHysteresis(t *testing.T) {
+	monitor, err := NewProximityMonitor(nil, ProximityConfig{Threshold: 10, ExitThreshold: 15, MinDuration: 3})
+	if err != nil {
+		t.Fatalf("NewProximityMonitor failed: %v", err)
+	}
+
+	// Distance between objects 1 and 2 on each update
+	distances := []float64{20, 8, 9, 5, 12, 14, 9, 16, 8}
+	var starts, ends []ProximityEvent
+	for _, d := range distances {
+		events := monitor.Update(proximityObjects(map[int][2]float64{1: {0, 0}, 2: {d, 0}, 3: {100, 100}}))
+		for _, e := range events {
+			if e.IDA != 1 || e.IDB != 2 {
+				t.Errorf("unexpected pair (%d, %d)", e.IDA, e.IDB)
+			}
+			if e.Type == ProximityStart {
+				starts = append(starts, e)
+			} else {
+				ends = append(ends, e)
+			}
+		}
+	}
+
+	// Close from update 2; start emitted on update 4 after 3 close updates.
+	// 12 and 14 stay within the exit threshold, 16 ends the episode on update 8.
+	if len(starts) != 1 || starts[0].Frame != 4 || starts[0].StartFrame != 2 {
+		t.Fatalf("unexpected start events: %+v", starts)
+	}
+	if len(ends) != 1 || ends[0].Frame != 8 || ends[0].MinDistance != 5 || ends[0].Distance != 16 {
+		t.Fatalf("unexpected end events: %+v", ends)
+	}
+	if active := monitor.Active(); len(active) != 0 {
+		t.Errorf("pair on its first close update should not be active yet: %+v", active)
+	}
+}
+
+func TestProximityMonitor_ObjectLost(t *testing.T) {
+	monitor, err := NewProximityMonitor(nil, ProximityConfig{Threshold: 10})
+	if err != nil {
+		t.Fatalf("NewProximityMonitor failed: %v", err)
+	}
+
+	events := monitor.Update(proximityObjects(map[int][2]float64{1: {0, 0}, 2: {3, 4}}))
+	if len(events) != 1 || events[0].Type != ProximityStart || events[0].Distance != 5 {
+		t.Fatalf("expected start event at distance 5, got %+v", events)
+	}
+	if active := monitor.Active(); len(active) != 1 || active[0].Distance != 5 {
+		t.Fatalf("expected 1 active pair, got %+v", active)
+	}
+
+	events = monitor.Update(proximityObjects(map[int][2]float64{1: {0, 0}}))
+	if len(events) != 1 || events[0].Type != ProximityEnd {
+		t.Fatalf("expected end event when an object is lost, got %+v", events)
+	}
+	if len(monitor.Active()) != 0 {
+		t.Error("no pairs should remain active")
+	}
+}
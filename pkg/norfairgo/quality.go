@@ -0,0 +1,72 @@
+package norfairgo
+
+import "math"
+
+// =============================================================================
+// Track Quality - A Consistent 0-1 Score Per Track
+// =============================================================================
+
+// TrackQuality is the breakdown of TrackedObject.Quality. Every component is in
+// [0, 1], higher is better.
+type TrackQuality struct {
+	// Fraction of the object's frames in which it was matched with a detection.
+	HitRatio float64
+
+	// How directly the object initialized: the minimum number of frames needed to
+	// initialize (InitializationDelay + 1) over the number it took. While still
+	// initializing, the progress towards initialization.
+	Stability float64
+
+	// 1 - mean match distance / DistanceThreshold (1 before the first match).
+	MatchScore float64
+
+	// Product of the components: any weak component makes a low-quality track.
+	Score float64
+}
+
+// Quality returns the object's quality score in [0, 1], combining its hit ratio,
+// initialization stability and mean match distance (see QualityBreakdown), so
+// downstream consumers can rank or discard tracks consistently.
+func (to *TrackedObject) Quality() float64 {
+	return to.QualityBreakdown().Score
+}
+
+// QualityBreakdown returns the components of the object's quality score.
+func (to *TrackedObject) QualityBreakdown() TrackQuality {
+	quality := TrackQuality{
+		HitRatio:   clamp01(float64(to.hitFrames) / float64(to.Age+int64(max(to.InitialPeriod, 1)))),
+		MatchScore: 1,
+	}
+
+	requiredHits := float64(to.config.InitializationDelay + 1)
+	if to.IsInitializing {
+		quality.Stability = clamp01(float64(to.HitCounter) / requiredHits)
+	} else {
+		quality.Stability = clamp01(requiredHits / float64(to.initializedAge+1))
+	}
+
+	if to.numMatchDistances > 0 && to.config.DistanceThreshold > 0 {
+		meanDistance := to.matchDistanceSum / float64(to.numMatchDistances)
+		quality.MatchScore = 1 - clamp01(meanDistance/to.config.DistanceThreshold)
+	}
+
+	quality.Score = quality.HitRatio * quality.Stability * quality.MatchScore
+	return quality
+}
+
+// addMatchDistance records the distance of a detection match for MatchScore.
+func (to *TrackedObject) addMatchDistance(distance float64) {
+	if math.IsNaN(distance) || math.IsInf(distance, 0) {
+		return
+	}
+	to.matchDistanceSum += distance
+	to.numMatchDistances++
+}
+
+// clamp01 clamps a value to [0, 1] (NaN becomes 0).
+func clamp01(value float64) float64 {
+	if !(value > 0) {
+		return 0
+	}
+	return math.Min(value, 1)
+}
@@ -0,0 +1,72 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+)
+
+// =============================================================================
+// Track Quality Tests
+// =============================================================================
+
+func TestTrackedObject_Quality(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   10.0,
+		HitCounterMax:       10,
+		InitializationDelay: 2,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	// Steady: detected every frame at the same place
+	// Flaky: detected every other frame, 2 pixels off its position
+	objectAt := func(x float64) *TrackedObject {
+		for _, obj := range tracker.TrackedObjects {
+			if obj.Estimate.At(0, 0) > x-50 && obj.Estimate.At(0, 0) < x+50 {
+				return obj
+			}
+		}
+		t.Fatalf("No object near x=%v", x)
+		return nil
+	}
+
+	tracker.Update([]*Detection{newPointDetection(t, 0, 0), newPointDetection(t, 500, 0)}, 1, nil)
+	initializing := objectAt(0).QualityBreakdown()
+	testutil.AssertAlmostEqual(t, initializing.HitRatio, 1.0, 1e-10, "new object hit ratio")
+	testutil.AssertAlmostEqual(t, initializing.Stability, 1.0/3.0, 1e-10, "initialization progress")
+	testutil.AssertAlmostEqual(t, initializing.MatchScore, 1.0, 1e-10, "match score before matches")
+
+	for frame := 1; frame < 20; frame++ {
+		detections := []*Detection{newPointDetection(t, 0, 0)}
+		if frame%2 == 0 {
+			offset := 2.0
+			if frame%4 == 0 {
+				offset = -2.0
+			}
+			detections = append(detections, newPointDetection(t, 500+offset, 0))
+		}
+		tracker.Update(detections, 1, nil)
+	}
+
+	steady := objectAt(0).QualityBreakdown()
+	testutil.AssertAlmostEqual(t, steady.HitRatio, 1.0, 1e-10, "steady hit ratio")
+	testutil.AssertAlmostEqual(t, steady.Stability, 1.0, 1e-10, "steady stability")
+	testutil.AssertAlmostEqual(t, steady.Score, 1.0, 1e-6, "steady score")
+
+	flaky := objectAt(500)
+	breakdown := flaky.QualityBreakdown()
+	testutil.AssertAlmostEqual(t, breakdown.HitRatio, 10.0/20.0, 1e-10, "flaky hit ratio")
+	if breakdown.Stability >= 1 {
+		t.Errorf("Expected flaky object to initialize slowly, got stability %v", breakdown.Stability)
+	}
+	if breakdown.MatchScore >= 0.95 || breakdown.MatchScore <= 0 {
+		t.Errorf("Expected a reduced match score, got %v", breakdown.MatchScore)
+	}
+	testutil.AssertAlmostEqual(t, flaky.Quality(), breakdown.HitRatio*breakdown.Stability*breakdown.MatchScore, 1e-12, "score")
+	if flaky.Quality() >= objectAt(0).Quality() {
+		t.Errorf("Expected flaky quality %v < steady quality %v", flaky.Quality(), objectAt(0).Quality())
+	}
+}
@@ -0,0 +1,329 @@
+package norfairgo
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Recording - Record and Replay Raw Update Inputs
+// =============================================================================
+
+// RecordedUpdate holds the inputs of one Tracker.Update call.
+type RecordedUpdate struct {
+	Period         int
+	Detections     []RecordedDetection
+	Transformation *RecordedTransformation // nil = no coordinate transformation
+}
+
+// RecordedDetection is a detection exactly as it was passed to Update, including
+// invalid ones, so replays reproduce input problems too. Detection.Data is not
+// recorded.
+type RecordedDetection struct {
+	Nil              bool      // The detection itself was nil
+	Rows, Cols       int       // Shape of Points (0x0 = nil)
+	Points           []float64 // Row-major
+	AbsEqualsPoints  bool      // AbsolutePoints was equal to Points (not stored)
+	AbsRows, AbsCols int       // Shape of AbsolutePoints (0x0 = nil or AbsEqualsPoints)
+	AbsolutePoints   []float64 // Row-major
+	Scores           []float64
+	Label            *string
+	ClassID          *int
+	Embedding        []float64
+	Ignore           bool
+	Coordinates      CoordinateSystem
+}
+
+// RecordedTransformation is a recorded CoordinateTransformation.
+type RecordedTransformation struct {
+	Kind   string    // "translation", "homography", "nil" or "unsupported"
+	Values []float64 // Movement vector or row-major homography matrix
+}
+
+// UpdateRecorder writes the inputs of every Update call of a tracker (see
+// TrackerConfig.Recorder) as a gzip-compressed gob stream, so an exact
+// reproduction of a tracking problem can be attached to a bug report.
+//
+// Only the inputs are recorded: replay with the same TrackerConfig (see Replay).
+// Custom CoordinateTransformation types are recorded as "unsupported" and replayed
+// as no transformation.
+//
+// An UpdateRecorder is safe for concurrent use, but a recording only replays the
+// updates of a single tracker: TiledTracker rejects it, since every tile would
+// record its own subset of the detections.
+type UpdateRecorder struct {
+	mu      sync.Mutex // Guards the fields below
+	gz      *gzip.Writer
+	encoder *gob.Encoder
+	closer  io.Closer // Underlying file (nil if the caller owns the writer)
+	err     error     // First write error
+	count   int
+}
+
+// NewUpdateRecorder creates a recorder writing to w. Close it to flush the stream.
+func NewUpdateRecorder(w io.Writer) *UpdateRecorder {
+	gz := gzip.NewWriter(w)
+	return &UpdateRecorder{gz: gz, encoder: gob.NewEncoder(gz)}
+}
+
+// CreateUpdateRecorder creates a recorder writing to a new file at path.
+//
+// Example:
+//
+//	recorder, err := norfairgo.CreateUpdateRecorder("bug-report.rec")
+//	if err != nil {
+//	    return err
+//	}
+//	defer recorder.Close()
+//	config.Recorder = recorder
+func CreateUpdateRecorder(path string) (*UpdateRecorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	recorder := NewUpdateRecorder(file)
+	recorder.closer = file
+	return recorder, nil
+}
+
+// Record appends the inputs of one Update call. Tracker.Update calls it for the
+// configured Recorder; after the first write error, further calls are no-ops and
+// Close returns the error.
+func (r *UpdateRecorder) Record(detections []*Detection, period int, coordTransformations CoordinateTransformation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+
+	update := RecordedUpdate{
+		Period:         period,
+		Detections:     make([]RecordedDetection, len(detections)),
+		Transformation: recordTransformation(coordTransformations),
+	}
+	for i, det := range detections {
+		update.Detections[i] = recordDetection(det)
+	}
+
+	if err := r.encoder.Encode(&update); err != nil {
+		r.err = fmt.Errorf("failed to record update %d: %w", r.count, err)
+		return r.err
+	}
+	r.count++
+	return nil
+}
+
+// Count returns the number of recorded updates.
+func (r *UpdateRecorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// Close flushes the recording and closes the file created by CreateUpdateRecorder.
+func (r *UpdateRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.gz.Close()
+	if r.closer != nil {
+		if closeErr := r.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return err
+}
+
+// ReadRecording reads all updates written by an UpdateRecorder.
+//
+// Returns: Error if the stream is not a valid recording
+func ReadRecording(r io.Reader) ([]*RecordedUpdate, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recording: %w", err)
+	}
+	defer gz.Close()
+
+	decoder := gob.NewDecoder(gz)
+	var updates []*RecordedUpdate
+	for {
+		update := &RecordedUpdate{}
+		if err := decoder.Decode(update); err != nil {
+			if errors.Is(err, io.EOF) {
+				return updates, nil
+			}
+			return nil, fmt.Errorf("invalid recording at update %d: %w", len(updates), err)
+		}
+		updates = append(updates, update)
+	}
+}
+
+// LoadRecording reads the recording file at path (see ReadRecording).
+func LoadRecording(path string) ([]*RecordedUpdate, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+	return ReadRecording(file)
+}
+
+// Replay feeds recorded updates into a tracker, which should be created with the
+// same TrackerConfig as the recorded one.
+//
+// Parameters:
+//   - tracker: Tracker to update
+//   - updates: Recorded updates (see LoadRecording)
+//   - onUpdate: Optional callback with the index and result of each update; a
+//     non-nil error stops the replay and is returned
+func Replay(tracker *Tracker, updates []*RecordedUpdate, onUpdate func(index int, objects []*TrackedObject) error) error {
+	for i, update := range updates {
+		transformation, err := update.CoordinateTransformation()
+		if err != nil {
+			return fmt.Errorf("update %d: %w", i, err)
+		}
+		objects := tracker.Update(update.DetectionList(), update.Period, transformation)
+		if onUpdate != nil {
+			if err := onUpdate(i, objects); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DetectionList rebuilds the recorded detections (new instances on every call).
+func (u *RecordedUpdate) DetectionList() []*Detection {
+	detections := make([]*Detection, len(u.Detections))
+	for i, recorded := range u.Detections {
+		if recorded.Nil {
+			continue
+		}
+		det := &Detection{
+			Points:      recordedDense(recorded.Rows, recorded.Cols, recorded.Points),
+			Scores:      recorded.Scores,
+			Label:       recorded.Label,
+			ClassID:     recorded.ClassID,
+			Embedding:   recorded.Embedding,
+			Ignore:      recorded.Ignore,
+			Coordinates: recorded.Coordinates,
+		}
+		if recorded.AbsEqualsPoints {
+			det.AbsolutePoints = mat.DenseCopyOf(det.Points)
+		} else {
+			det.AbsolutePoints = recordedDense(recorded.AbsRows, recorded.AbsCols, recorded.AbsolutePoints)
+		}
+		detections[i] = det
+	}
+	return detections
+}
+
+// CoordinateTransformation rebuilds the recorded transformation (nil if none or
+// unsupported).
+func (u *RecordedUpdate) CoordinateTransformation() (CoordinateTransformation, error) {
+	if u.Transformation == nil {
+		return nil, nil
+	}
+	switch u.Transformation.Kind {
+	case "translation":
+		return NewTranslationTransformation(u.Transformation.Values)
+	case "homography":
+		if len(u.Transformation.Values) != 9 {
+			return nil, fmt.Errorf("homography must have 9 values, got %d", len(u.Transformation.Values))
+		}
+		return NewHomographyTransformation(mat.NewDense(3, 3, u.Transformation.Values))
+	case "nil":
+		return &NilCoordinateTransformation{}, nil
+	case "unsupported":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown transformation kind %q", u.Transformation.Kind)
+	}
+}
+
+// =============================================================================
+// Helper Functions
+// =============================================================================
+
+// recordDetection copies a detection into its recorded form.
+func recordDetection(det *Detection) RecordedDetection {
+	if det == nil {
+		return RecordedDetection{Nil: true}
+	}
+	recorded := RecordedDetection{
+		Scores:      det.Scores,
+		Label:       det.Label,
+		ClassID:     det.ClassID,
+		Embedding:   det.Embedding,
+		Ignore:      det.Ignore,
+		Coordinates: det.Coordinates,
+	}
+	if det.Points != nil {
+		recorded.Rows, recorded.Cols = det.Points.Dims()
+		recorded.Points = denseValues(det.Points)
+	}
+	if det.AbsolutePoints != nil {
+		absRows, absCols := det.AbsolutePoints.Dims()
+		if det.Points != nil && absRows == recorded.Rows && absCols == recorded.Cols && mat.Equal(det.Points, det.AbsolutePoints) {
+			recorded.AbsEqualsPoints = true
+		} else {
+			recorded.AbsRows, recorded.AbsCols = absRows, absCols
+			recorded.AbsolutePoints = denseValues(det.AbsolutePoints)
+		}
+	}
+	return recorded
+}
+
+// recordTransformation converts the known transformation types to their recorded form.
+func recordTransformation(coordTransformations CoordinateTransformation) *RecordedTransformation {
+	switch ct := coordTransformations.(type) {
+	case nil:
+		return nil
+	case *TranslationTransformation:
+		return &RecordedTransformation{Kind: "translation", Values: append([]float64(nil), ct.MovementVector...)}
+	case *HomographyTransformation:
+		return &RecordedTransformation{Kind: "homography", Values: denseValues(ct.HomographyMatrix)}
+	case *NilCoordinateTransformation:
+		return &RecordedTransformation{Kind: "nil"}
+	default:
+		WarnOnce(fmt.Sprintf("recording of coordinate transformation %T is not supported, it will be replayed as nil", ct))
+		return &RecordedTransformation{Kind: "unsupported"}
+	}
+}
+
+// denseValues returns the row-major values of a matrix.
+func denseValues(m *mat.Dense) []float64 {
+	rows, cols := m.Dims()
+	values := make([]float64, 0, rows*cols)
+	for i := 0; i < rows; i++ {
+		values = append(values, m.RawRowView(i)...)
+	}
+	return values
+}
+
+// recordedDense rebuilds a recorded matrix; nil for 0x0 or mismatched values.
+func recordedDense(rows, cols int, values []float64) *mat.Dense {
+	if rows == 0 || cols == 0 || len(values) != rows*cols {
+		return nil
+	}
+	return mat.NewDense(rows, cols, append([]float64(nil), values...))
+}
+
+// recordUpdate records an Update call for TrackerConfig.Recorder.
+func (t *Tracker) recordUpdate(detections []*Detection, period int, coordTransformations CoordinateTransformation) {
+	if t.Config.Recorder == nil {
+		return
+	}
+	if err := t.Config.Recorder.Record(detections, period, coordTransformations); err != nil {
+		WarnOnce(fmt.Sprintf("%v", err))
+	}
+}
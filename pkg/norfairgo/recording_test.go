@@ -0,0 +1,177 @@
+package norfairgo
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Recording Tests
+// =============================================================================
+
+func newRecordingTracker(t *testing.T, recorder *UpdateRecorder) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:  DistanceByName("iou"),
+		DistanceThreshold: 0.7,
+		HitCounterMax:     10,
+		Recorder:          recorder,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	return tracker
+}
+
+// trackerOutput summarizes an Update result for comparing runs.
+type trackerOutput struct {
+	id       int
+	estimate []float64
+}
+
+func summarizeObjects(objects []*TrackedObject) []trackerOutput {
+	outputs := make([]trackerOutput, len(objects))
+	for i, obj := range objects {
+		outputs[i] = trackerOutput{id: *obj.ID, estimate: denseValues(obj.Estimate)}
+	}
+	return outputs
+}
+
+func TestRecording_ReplayReproducesTracking(t *testing.T) {
+	simulation, err := NewSimulation(&SimulationConfig{NumObjects: 4, NumFrames: 40, NoiseStd: 2, MissRate: 0.1, FalsePositiveRate: 0.2, Seed: 3})
+	if err != nil {
+		t.Fatalf("Failed to create simulation: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	recorder := NewUpdateRecorder(&buffer)
+	tracker := newRecordingTracker(t, recorder)
+
+	var recorded [][]trackerOutput
+	for frameID, detections := range simulation.All() {
+		// Exercise transformations, a nil and an invalid detection
+		var transformation CoordinateTransformation
+		if frameID%2 == 0 {
+			transformation, _ = NewTranslationTransformation([]float64{float64(frameID), 0})
+		}
+		if frameID == 5 {
+			detections = append(detections, nil, &Detection{Points: mat.NewDense(1, 2, []float64{math.NaN(), 0})})
+		}
+		recorded = append(recorded, summarizeObjects(tracker.Update(detections, 1, transformation)))
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if recorder.Count() != 40 {
+		t.Fatalf("Expected 40 recorded updates, got %d", recorder.Count())
+	}
+
+	updates, err := ReadRecording(&buffer)
+	if err != nil {
+		t.Fatalf("ReadRecording failed: %v", err)
+	}
+	if len(updates) != 40 {
+		t.Fatalf("Expected 40 updates, got %d", len(updates))
+	}
+	if dets := updates[4].DetectionList(); dets[len(dets)-2] != nil || !math.IsNaN(dets[len(dets)-1].Points.At(0, 0)) {
+		t.Errorf("Expected the nil and NaN detections to be recorded as-is")
+	}
+
+	// Replaying into a fresh tracker gives identical results
+	err = Replay(newRecordingTracker(t, nil), updates, func(index int, objects []*TrackedObject) error {
+		replayed := summarizeObjects(objects)
+		if len(replayed) != len(recorded[index]) {
+			t.Fatalf("Update %d: expected %d objects, got %d", index, len(recorded[index]), len(replayed))
+		}
+		for i := range replayed {
+			if replayed[i].id != recorded[index][i].id {
+				t.Fatalf("Update %d: expected ID %d, got %d", index, recorded[index][i].id, replayed[i].id)
+			}
+			for j, v := range replayed[i].estimate {
+				if v != recorded[index][i].estimate[j] {
+					t.Fatalf("Update %d: estimates differ", index)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+}
+
+func TestRecording_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bug.rec")
+	recorder, err := CreateUpdateRecorder(path)
+	if err != nil {
+		t.Fatalf("CreateUpdateRecorder failed: %v", err)
+	}
+	homography, _ := NewHomographyTransformation(mat.NewDense(3, 3, []float64{1, 0, 5, 0, 1, 6, 0, 0, 1}))
+	det := newPointDetection(t, 1, 2)
+	det.Label = StringPtr("person")
+	det.Scores = []float64{0.9}
+	if err := recorder.Record([]*Detection{det}, 2, homography); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	updates, err := LoadRecording(path)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+	if len(updates) != 1 || updates[0].Period != 2 {
+		t.Fatalf("Expected one update with period 2, got %+v", updates)
+	}
+	replayed := updates[0].DetectionList()[0]
+	if *replayed.Label != "person" || replayed.Scores[0] != 0.9 || replayed.Points.At(0, 1) != 2 || replayed.AbsolutePoints.At(0, 0) != 1 {
+		t.Errorf("Unexpected replayed detection %+v", replayed)
+	}
+	transformation, err := updates[0].CoordinateTransformation()
+	if err != nil {
+		t.Fatalf("CoordinateTransformation failed: %v", err)
+	}
+	if h, ok := transformation.(*HomographyTransformation); !ok || h.HomographyMatrix.At(1, 2) != 6 {
+		t.Errorf("Expected the recorded homography, got %v", transformation)
+	}
+
+	if _, err := ReadRecording(bytes.NewReader([]byte("not a recording"))); err == nil {
+		t.Error("Expected an error for an invalid recording")
+	}
+}
+
+func TestRecording_ConcurrentRecord(t *testing.T) {
+	var buffer bytes.Buffer
+	recorder := NewUpdateRecorder(&buffer)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(x float64) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if err := recorder.Record([]*Detection{newPointDetection(t, x, float64(j))}, 1, nil); err != nil {
+					t.Errorf("Record failed: %v", err)
+				}
+			}
+		}(float64(i))
+	}
+	wg.Wait()
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	updates, err := ReadRecording(&buffer)
+	if err != nil {
+		t.Fatalf("ReadRecording failed: %v", err)
+	}
+	if len(updates) != 40 || recorder.Count() != 40 {
+		t.Errorf("Expected 40 recorded updates, got %d (count %d)", len(updates), recorder.Count())
+	}
+}
@@ -0,0 +1,113 @@
+package norfairgo
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Reference Points - Single Anchor Point of an Object
+// =============================================================================
+
+// ReferencePointFunc extracts the anchor point of an object from its points
+// (n_points x 2 or 3), e.g. the foot point of a pedestrian's box.
+type ReferencePointFunc func(points *mat.Dense) [2]float64
+
+// ReferencePointMode selects a built-in reference point.
+type ReferencePointMode int
+
+const (
+	// ReferenceBottomCenter uses the bottom center of the estimate's bounding box,
+	// where a standing person or a vehicle touches the ground.
+	ReferenceBottomCenter ReferencePointMode = iota
+
+	// ReferenceCentroid uses the mean of the estimate's points, for objects tracked
+	// by a single ground point or seen from above.
+	ReferenceCentroid
+
+	// ReferenceCenter uses the center of the estimate's bounding box, which unlike
+	// the centroid does not shift with the number of keypoints on each side.
+	ReferenceCenter
+)
+
+// Extract returns the mode's reference point of the points.
+func (m ReferencePointMode) Extract(points *mat.Dense) [2]float64 {
+	switch m {
+	case ReferenceCentroid:
+		return centroid(points)
+	case ReferenceCenter:
+		box := boundingBox(points)
+		return [2]float64{(box[0] + box[2]) / 2, (box[1] + box[3]) / 2}
+	default:
+		box := boundingBox(points)
+		return [2]float64{(box[0] + box[2]) / 2, box[3]}
+	}
+}
+
+// ReferencePointDistance is the Euclidean distance between the reference points of
+// detections and tracked objects, instead of between all of their points. Anchoring
+// pedestrians at their foot point, for example, keeps the association stable when
+// the box height jitters with pose.
+//
+// Pairs with different labels or class IDs are never matched.
+type ReferencePointDistance struct {
+	extract ReferencePointFunc
+}
+
+// NewReferencePointDistance creates a ReferencePointDistance with a custom extractor.
+// The built-in modes are available by name: "center_euclidean",
+// "bottom_center_euclidean" and "centroid_euclidean".
+//
+// Example:
+//
+//	config := &TrackerConfig{
+//	    DistanceFunction:  norfairgo.NewReferencePointDistance(norfairgo.ReferenceBottomCenter.Extract),
+//	    DistanceThreshold: 50,
+//	}
+func NewReferencePointDistance(extract ReferencePointFunc) *ReferencePointDistance {
+	return &ReferencePointDistance{extract: extract}
+}
+
+// GetDistances computes the distance matrix (rows = candidates, cols = objects).
+func (rd *ReferencePointDistance) GetDistances(objects []*TrackedObject, candidates interface{}) *mat.Dense {
+	candList := convertCandidatesToList(candidates)
+	distanceMatrix := createInfinityMatrix(len(candList), len(objects))
+
+	objectPoints := make([][2]float64, len(objects))
+	objectLabels := extractObjectLabels(objects)
+	for o, obj := range objects {
+		objectPoints[o] = rd.extract(obj.Estimate)
+	}
+
+	for c, candidate := range candList {
+		var point [2]float64
+		var label labelKey
+		switch cand := candidate.(type) {
+		case *Detection:
+			point = rd.extract(cand.Points)
+			label = newLabelKey(cand.Label, cand.ClassID)
+		case *TrackedObject:
+			point = rd.extract(cand.Estimate)
+			label = newLabelKey(cand.Label, cand.ClassID)
+		default:
+			continue
+		}
+
+		for o := range objects {
+			if label != objectLabels[o] {
+				continue
+			}
+			distanceMatrix.Set(c, o, math.Hypot(point[0]-objectPoints[o][0], point[1]-objectPoints[o][1]))
+		}
+	}
+
+	return distanceMatrix
+}
+
+// referencePointDistances are the built-in ReferencePointDistance modes by name.
+var referencePointDistances = map[string]ReferencePointMode{
+	"center_euclidean":        ReferenceCenter,
+	"bottom_center_euclidean": ReferenceBottomCenter,
+	"centroid_euclidean":      ReferenceCentroid,
+}
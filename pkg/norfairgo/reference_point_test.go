@@ -0,0 +1,90 @@
+package norfairgo
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Reference Point Tests
+// =============================================================================
+
+func TestReferencePointMode_Extract(t *testing.T) {
+	// Keypoints crowded at the top of a 100x200 box
+	points := mat.NewDense(4, 2, []float64{0, 0, 100, 0, 50, 10, 50, 200})
+
+	cases := map[ReferencePointMode][2]float64{
+		ReferenceBottomCenter: {50, 200},
+		ReferenceCenter:       {50, 100},
+		ReferenceCentroid:     {50, 52.5},
+	}
+	for mode, want := range cases {
+		if got := mode.Extract(points); math.Abs(got[0]-want[0]) > 1e-9 || math.Abs(got[1]-want[1]) > 1e-9 {
+			t.Errorf("mode %d: got %v, want %v", mode, got, want)
+		}
+	}
+}
+
+func TestReferencePointDistance_FootPoint(t *testing.T) {
+	box := func(x1, y1, x2, y2 float64, label string) *Detection {
+		det, err := NewDetectionFromBox(x1, y1, x2, y2, &DetectionConfig{Label: StringPtr(label)})
+		if err != nil {
+			t.Fatalf("NewDetectionFromBox failed: %v", err)
+		}
+		return det
+	}
+
+	// The box top jitters with pose while the feet stay put
+	obj := &TrackedObject{Estimate: mat.NewDense(2, 2, []float64{100, 100, 140, 300}), Label: StringPtr("person")}
+	candidates := []*Detection{
+		box(100, 160, 140, 300, "person"),
+		box(100, 100, 140, 330, "person"),
+		box(100, 160, 140, 300, "car"),
+	}
+
+	distances := DistanceByName("bottom_center_euclidean").GetDistances([]*TrackedObject{obj}, candidates)
+	if d := distances.At(0, 0); math.Abs(d) > 1e-9 {
+		t.Errorf("Expected distance 0 for the same foot point, got %v", d)
+	}
+	if d := distances.At(1, 0); math.Abs(d-30) > 1e-9 {
+		t.Errorf("Expected distance 30 for a foot point 30 pixels lower, got %v", d)
+	}
+	if d := distances.At(2, 0); !math.IsInf(d, 1) {
+		t.Errorf("Expected no distance across labels, got %v", d)
+	}
+
+	centers := DistanceByName("center_euclidean").GetDistances([]*TrackedObject{obj}, candidates)
+	if d := centers.At(0, 0); math.Abs(d-30) > 1e-9 {
+		t.Errorf("Expected center distance 30, got %v", d)
+	}
+
+	custom := NewReferencePointDistance(func(points *mat.Dense) [2]float64 { return [2]float64{points.At(0, 0), points.At(0, 1)} })
+	if d := custom.GetDistances([]*TrackedObject{obj}, candidates).At(0, 0); math.Abs(d-60) > 1e-9 {
+		t.Errorf("Expected top-left distance 60, got %v", d)
+	}
+}
+
+func TestTracker_ReferencePointDistance(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("bottom_center_euclidean"),
+		DistanceThreshold:   20,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("NewTracker failed: %v", err)
+	}
+
+	// A walking pedestrian whose box height changes by up to 60 pixels
+	var objects []*TrackedObject
+	for frame := 0; frame < 10; frame++ {
+		x := 100 + 2*float64(frame)
+		top := 100 + 60*float64(frame%2)
+		det, _ := NewDetectionFromBox(x, top, x+40, 300, nil)
+		objects = tracker.Update([]*Detection{det}, 1, nil)
+	}
+	if len(objects) != 1 || tracker.TotalObjectCount() != 1 {
+		t.Errorf("Expected a single track, got %d objects and %d IDs", len(objects), tracker.TotalObjectCount())
+	}
+}
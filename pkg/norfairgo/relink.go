@@ -0,0 +1,132 @@
+package norfairgo
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Motion-Only Re-Linking of Lost Objects
+// =============================================================================
+
+// lostObject is an object removed from tracking that can still be re-linked.
+type lostObject struct {
+	obj       *TrackedObject
+	lostFrame int64 // Tracker frame number at which the object was removed
+}
+
+// poolLostObjects keeps the initialized objects removed in this update for
+// re-linking (see TrackerConfig.RelinkDistanceThreshold).
+func (t *Tracker) poolLostObjects(before []*TrackedObject) {
+	if t.Config.RelinkDistanceThreshold <= 0 {
+		return
+	}
+	current := make(map[*TrackedObject]bool, len(t.TrackedObjects))
+	for _, obj := range t.TrackedObjects {
+		current[obj] = true
+	}
+	for _, obj := range before {
+		if !current[obj] && obj.ID != nil {
+			t.lostObjects = append(t.lostObjects, lostObject{obj: obj, lostFrame: t.frameNumber})
+		}
+	}
+}
+
+// predictLostObjects advances the lost objects to the current frame, dropping
+// those lost for more than RelinkMaxFrames frames.
+func (t *Tracker) predictLostObjects(period int, coordTransformations CoordinateTransformation) {
+	kept := t.lostObjects[:0]
+	for _, lost := range t.lostObjects {
+		if t.frameNumber-lost.lostFrame > int64(t.Config.RelinkMaxFrames) {
+			continue
+		}
+		lost.obj.trackerStepPeriod(period)
+		lost.obj.UpdateCoordinateTransformation(coordTransformations)
+		kept = append(kept, lost)
+	}
+	clear(t.lostObjects[len(kept):])
+	t.lostObjects = kept
+}
+
+// relinkObjects hands the objects that finished initializing in this update over
+// to lost objects whose predicted position and velocity they are consistent with,
+// so the lost objects continue under their old IDs.
+func (t *Tracker) relinkObjects(initialized []*TrackedObject) {
+	if len(initialized) == 0 || len(t.lostObjects) == 0 {
+		return
+	}
+
+	// Rows are new objects, columns lost objects (as candidates and objects in matching)
+	distances := mat.NewDense(len(initialized), len(t.lostObjects), nil)
+	for i, obj := range initialized {
+		for j, lost := range t.lostObjects {
+			distances.Set(i, j, t.relinkDistance(obj, lost.obj))
+		}
+	}
+
+	newIndices, lostIndices := MatchDetectionsAndObjects(distances, t.Config.RelinkDistanceThreshold)
+	relinked := make(map[int]bool, len(lostIndices))
+	for k := range newIndices {
+		obj, lost := initialized[newIndices[k]], t.lostObjects[lostIndices[k]].obj
+		if distances.At(newIndices[k], lostIndices[k]) >= t.Config.RelinkDistanceThreshold {
+			continue
+		}
+
+		hitCounter := obj.HitCounter
+		lost.Merge(obj)
+		lost.HitCounter = hitCounter
+		t.removeTrackedObject(obj)
+		t.TrackedObjects = append(t.TrackedObjects, lost)
+		relinked[lostIndices[k]] = true
+		if t.stats != nil {
+			t.stats.NumRelinked++
+		}
+	}
+
+	kept := t.lostObjects[:0]
+	for j, lost := range t.lostObjects {
+		if !relinked[j] {
+			kept = append(kept, lost)
+		}
+	}
+	clear(t.lostObjects[len(kept):])
+	t.lostObjects = kept
+}
+
+// relinkDistance returns the mean point distance between a new object and the
+// predicted position of a lost object, or +Inf if they cannot be the same object
+// (different labels or shapes, or incompatible velocities).
+func (t *Tracker) relinkDistance(obj, lost *TrackedObject) float64 {
+	if newLabelKey(obj.Label, obj.ClassID) != newLabelKey(lost.Label, lost.ClassID) ||
+		obj.NumPoints != lost.NumPoints || obj.DimPoints != lost.DimPoints {
+		return math.Inf(1)
+	}
+	if t.Config.RelinkVelocityThreshold > 0 &&
+		meanPointDistance(obj.EstimateVelocity(), lost.EstimateVelocity()) > t.Config.RelinkVelocityThreshold {
+		return math.Inf(1)
+	}
+
+	// Compare in absolute coordinates when a coordinate transformation is set
+	objEstimate, errObj := obj.GetEstimate(obj.AbsToRel != nil)
+	lostEstimate, errLost := lost.GetEstimate(lost.AbsToRel != nil)
+	if errObj != nil || errLost != nil {
+		return math.Inf(1)
+	}
+	return meanPointDistance(objEstimate, lostEstimate)
+}
+
+// meanPointDistance returns the mean Euclidean distance between the rows of a and b.
+func meanPointDistance(a, b *mat.Dense) float64 {
+	rows, cols := a.Dims()
+	sum := 0.0
+	for i := 0; i < rows; i++ {
+		squared := 0.0
+		for d := 0; d < cols; d++ {
+			diff := a.At(i, d) - b.At(i, d)
+			squared += diff * diff
+		}
+		sum += math.Sqrt(squared)
+	}
+	return sum / float64(rows)
+}
@@ -0,0 +1,94 @@
+package norfairgo
+
+import (
+	"testing"
+)
+
+// runOcclusionScenario tracks an object moving 2 units per frame in x (or back in
+// the reverse direction after the occlusion), occluded for 8 frames, and returns
+// the IDs of the object before and after the occlusion.
+func runOcclusionScenario(t *testing.T, config *TrackerConfig, reverse bool) (before, after int, tracker *Tracker) {
+	t.Helper()
+	config.DistanceFunction = DistanceByName("euclidean")
+	config.DistanceThreshold = 5.0
+	config.HitCounterMax = 4
+	config.InitializationDelay = 2
+	tracker, err := NewTracker(config)
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	var objects []*TrackedObject
+	for frame := 0; frame < 10; frame++ {
+		objects = tracker.Update([]*Detection{newPointDetection(t, 2*float64(frame), 0)}, 1, nil)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 active object before the occlusion, got %d", len(objects))
+	}
+	before = *objects[0].ID
+
+	for frame := 10; frame < 18; frame++ {
+		tracker.Update(nil, 1, nil)
+	}
+	if len(tracker.TrackedObjects) != 0 {
+		t.Fatalf("Expected the object to be lost during the occlusion, got %d objects", len(tracker.TrackedObjects))
+	}
+
+	for frame := 18; frame < 26; frame++ {
+		x := 2 * float64(frame)
+		if reverse {
+			x = 2*18 - 2*float64(frame-18)
+		}
+		objects = tracker.Update([]*Detection{newPointDetection(t, x, 0)}, 1, nil)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 active object after the occlusion, got %d", len(objects))
+	}
+	return before, *objects[0].ID, tracker
+}
+
+func TestTracker_Relink(t *testing.T) {
+	before, after, tracker := runOcclusionScenario(t, &TrackerConfig{RelinkDistanceThreshold: 10}, false)
+	if before != after {
+		t.Errorf("Expected the object to keep ID %d after the occlusion, got %d", before, after)
+	}
+	if tracker.Config.RelinkMaxFrames != 30 {
+		t.Errorf("Expected default RelinkMaxFrames 30, got %d", tracker.Config.RelinkMaxFrames)
+	}
+	if len(tracker.lostObjects) != 0 {
+		t.Errorf("Expected the relinked object to leave the lost objects, got %d", len(tracker.lostObjects))
+	}
+}
+
+func TestTracker_RelinkDisabled(t *testing.T) {
+	before, after, _ := runOcclusionScenario(t, &TrackerConfig{}, false)
+	if before == after {
+		t.Errorf("Expected a new ID after the occlusion without relinking, got %d", after)
+	}
+}
+
+func TestTracker_RelinkExpired(t *testing.T) {
+	before, after, _ := runOcclusionScenario(t, &TrackerConfig{RelinkDistanceThreshold: 10, RelinkMaxFrames: 3}, false)
+	if before == after {
+		t.Errorf("Expected a new ID once the lost object expired, got %d", after)
+	}
+}
+
+func TestTracker_RelinkVelocity(t *testing.T) {
+	// Reappearing at the predicted position but moving the other way
+	before, after, _ := runOcclusionScenario(t, &TrackerConfig{RelinkDistanceThreshold: 40, RelinkVelocityThreshold: 1}, true)
+	if before == after {
+		t.Errorf("Expected no relinking with an incompatible velocity, got ID %d", after)
+	}
+
+	before, after, _ = runOcclusionScenario(t, &TrackerConfig{RelinkDistanceThreshold: 40}, true)
+	if before != after {
+		t.Errorf("Expected relinking without a velocity threshold, got IDs %d and %d", before, after)
+	}
+}
+
+func TestNewTracker_InvalidRelink(t *testing.T) {
+	if _, err := NewTracker(&TrackerConfig{RelinkDistanceThreshold: -1}); err == nil {
+		t.Error("Expected error for a negative relink threshold")
+	}
+}
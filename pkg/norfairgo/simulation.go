@@ -0,0 +1,252 @@
+package norfairgo
+
+import (
+	"fmt"
+	"iter"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Simulation - Synthetic Sequences with Known Ground Truth
+// =============================================================================
+
+// SimulationConfig configures a synthetic multi-object sequence.
+//
+// Objects are axis-aligned boxes moving at constant velocity and bouncing off the
+// frame borders. Detections are the ground truth boxes with Gaussian corner noise,
+// randomly dropped (misses) and mixed with random boxes (false positives).
+type SimulationConfig struct {
+	// Number of ground truth objects.
+	// Default: 5
+	NumObjects int
+
+	// Number of frames to generate.
+	// Default: 100
+	NumFrames int
+
+	// Frame size in pixels.
+	// Default: 1920x1080
+	FrameWidth  float64
+	FrameHeight float64
+
+	// Side length of the object boxes in pixels.
+	// Default: 50
+	BoxSize float64
+
+	// Maximum speed per axis in pixels per frame.
+	// Default: 5
+	MaxSpeed float64
+
+	// Standard deviation of the Gaussian noise added to detection corners.
+	// Default: 0 (exact detections)
+	NoiseStd float64
+
+	// Probability that an object is not detected in a frame.
+	// Default: 0
+	MissRate float64
+
+	// Probability of a random false positive detection per frame.
+	// Default: 0
+	FalsePositiveRate float64
+
+	// Random seed; the same config always generates the same sequence.
+	// Default: 0
+	Seed int64
+}
+
+// SimulationFrame is one frame of a simulated sequence.
+type SimulationFrame struct {
+	FrameID    int          // 1-indexed frame number (MOTChallenge convention)
+	GTBBoxes   [][]float64  // Ground truth boxes [x_min, y_min, x_max, y_max]
+	GTIDs      []int        // Ground truth IDs, parallel to GTBBoxes
+	Detections []*Detection // Noisy detections, each a 2-point box [[x_min, y_min], [x_max, y_max]]
+}
+
+// Simulation is a generated sequence of frames.
+type Simulation struct {
+	Config *SimulationConfig
+	Frames []*SimulationFrame
+}
+
+// All returns an iterator over the 1-indexed frame IDs and detections of the
+// sequence, the same shape as DetectionFileParser.All so either can drive a tracker.
+func (s *Simulation) All() iter.Seq2[int, []*Detection] {
+	return func(yield func(int, []*Detection) bool) {
+		for _, frame := range s.Frames {
+			if !yield(frame.FrameID, frame.Detections) {
+				return
+			}
+		}
+	}
+}
+
+// simulatedObject is the ground truth state of one simulated object.
+type simulatedObject struct {
+	x, y   float64 // Top-left corner
+	vx, vy float64 // Velocity in pixels per frame
+}
+
+// NewSimulation generates a synthetic sequence from a configuration.
+//
+// Returns: Error if the configuration is invalid
+func NewSimulation(config *SimulationConfig) (*Simulation, error) {
+	if config == nil {
+		config = &SimulationConfig{}
+	}
+
+	// Apply defaults
+	c := *config
+	if c.NumObjects == 0 {
+		c.NumObjects = 5
+	}
+	if c.NumFrames == 0 {
+		c.NumFrames = 100
+	}
+	if c.FrameWidth == 0 {
+		c.FrameWidth = 1920
+	}
+	if c.FrameHeight == 0 {
+		c.FrameHeight = 1080
+	}
+	if c.BoxSize == 0 {
+		c.BoxSize = 50
+	}
+	if c.MaxSpeed == 0 {
+		c.MaxSpeed = 5
+	}
+
+	// Validate
+	if c.NumObjects < 0 || c.NumFrames < 0 {
+		return nil, fmt.Errorf("object and frame counts must be >= 0, got %d and %d", c.NumObjects, c.NumFrames)
+	}
+	if c.BoxSize >= c.FrameWidth || c.BoxSize >= c.FrameHeight {
+		return nil, fmt.Errorf("box size %g must be smaller than the frame %gx%g", c.BoxSize, c.FrameWidth, c.FrameHeight)
+	}
+	if c.MissRate < 0 || c.MissRate > 1 || c.FalsePositiveRate < 0 || c.FalsePositiveRate > 1 {
+		return nil, fmt.Errorf("miss and false positive rates must be in [0, 1]")
+	}
+
+	rng := rand.New(rand.NewSource(c.Seed))
+	maxX := c.FrameWidth - c.BoxSize
+	maxY := c.FrameHeight - c.BoxSize
+
+	objects := make([]*simulatedObject, c.NumObjects)
+	for i := range objects {
+		objects[i] = &simulatedObject{
+			x:  rng.Float64() * maxX,
+			y:  rng.Float64() * maxY,
+			vx: (2*rng.Float64() - 1) * c.MaxSpeed,
+			vy: (2*rng.Float64() - 1) * c.MaxSpeed,
+		}
+	}
+
+	frames := make([]*SimulationFrame, c.NumFrames)
+	for f := range frames {
+		frame := &SimulationFrame{FrameID: f + 1}
+
+		for id, obj := range objects {
+			gtBox := []float64{obj.x, obj.y, obj.x + c.BoxSize, obj.y + c.BoxSize}
+			frame.GTBBoxes = append(frame.GTBBoxes, gtBox)
+			frame.GTIDs = append(frame.GTIDs, id+1)
+
+			if rng.Float64() >= c.MissRate {
+				frame.Detections = append(frame.Detections, simulatedDetection(gtBox, c.NoiseStd, rng))
+			}
+
+			// Advance, bouncing off the borders
+			obj.x, obj.vx = bounce(obj.x+obj.vx, obj.vx, maxX)
+			obj.y, obj.vy = bounce(obj.y+obj.vy, obj.vy, maxY)
+		}
+
+		if rng.Float64() < c.FalsePositiveRate {
+			x, y := rng.Float64()*maxX, rng.Float64()*maxY
+			fpBox := []float64{x, y, x + c.BoxSize, y + c.BoxSize}
+			frame.Detections = append(frame.Detections, simulatedDetection(fpBox, 0, rng))
+		}
+
+		frames[f] = frame
+	}
+
+	return &Simulation{Config: &c, Frames: frames}, nil
+}
+
+// bounce reflects a coordinate (and its velocity) back into [0, limit].
+func bounce(position, velocity, limit float64) (float64, float64) {
+	if position < 0 {
+		return -position, -velocity
+	}
+	if position > limit {
+		return 2*limit - position, -velocity
+	}
+	return position, velocity
+}
+
+// simulatedDetection builds a 2-point box detection with Gaussian corner noise.
+func simulatedDetection(box []float64, noiseStd float64, rng *rand.Rand) *Detection {
+	points := mat.NewDense(2, 2, nil)
+	for i, v := range box {
+		points.Set(i/2, i%2, v+rng.NormFloat64()*noiseStd)
+	}
+	// Keep the corners ordered under heavy noise
+	for d := 0; d < 2; d++ {
+		if points.At(0, d) > points.At(1, d) {
+			lo, hi := points.At(1, d), points.At(0, d)
+			points.Set(0, d, lo)
+			points.Set(1, d, hi)
+		}
+	}
+	detection, _ := NewDetection(points, nil)
+	return detection
+}
+
+// EvaluateTracker runs a tracker over a simulation and scores it against the
+// ground truth with the MOTChallenge metrics (MOTA, IDF1, ID switches, ...).
+//
+// Tracked objects must estimate 2-point boxes [[x_min, y_min], [x_max, y_max]],
+// as produced by the simulation, e.g. with the "iou" distance.
+//
+// Parameters:
+//   - tracker: Tracker to evaluate (its state is advanced by the run)
+//   - simulation: Sequence with ground truth
+//   - threshold: IoU distance threshold for GT/prediction matching (default 0.5 if <= 0)
+//
+// Returns: Computed metrics, or error
+func EvaluateTracker(tracker *Tracker, simulation *Simulation, threshold float64) (*Metrics, error) {
+	if tracker == nil || simulation == nil {
+		return nil, fmt.Errorf("tracker and simulation cannot be nil")
+	}
+	if threshold <= 0 {
+		threshold = 0.5
+	}
+
+	const videoName = "simulation"
+	accumulators := NewAccumulators()
+	if err := accumulators.CreateAccumulator(videoName); err != nil {
+		return nil, err
+	}
+
+	for _, frame := range simulation.Frames {
+		activeObjects := tracker.Update(frame.Detections, 1, nil)
+
+		predBBoxes := make([][]float64, 0, len(activeObjects))
+		predIDs := make([]int, 0, len(activeObjects))
+		for _, obj := range activeObjects {
+			if rows, cols := obj.Estimate.Dims(); rows != 2 || cols != 2 {
+				return nil, fmt.Errorf("tracked object %d must estimate a 2-point box, got %dx%d", *obj.ID, rows, cols)
+			}
+			predBBoxes = append(predBBoxes, []float64{
+				obj.Estimate.At(0, 0), obj.Estimate.At(0, 1),
+				obj.Estimate.At(1, 0), obj.Estimate.At(1, 1),
+			})
+			predIDs = append(predIDs, *obj.ID)
+		}
+
+		if err := accumulators.UpdateFrame(int64(frame.FrameID), frame.GTBBoxes, frame.GTIDs, predBBoxes, predIDs, videoName, threshold); err != nil {
+			return nil, err
+		}
+	}
+
+	return accumulators.ComputeMetrics()
+}
@@ -0,0 +1,117 @@
+package norfairgo
+
+import (
+	"testing"
+)
+
+// =============================================================================
+// Simulation Self-Evaluation Tests
+// =============================================================================
+
+func TestNewSimulation_Deterministic(t *testing.T) {
+	config := &SimulationConfig{NumObjects: 3, NumFrames: 20, NoiseStd: 1.0, MissRate: 0.1, Seed: 42}
+	a, err := NewSimulation(config)
+	if err != nil {
+		t.Fatalf("NewSimulation failed: %v", err)
+	}
+	b, _ := NewSimulation(config)
+
+	if len(a.Frames) != 20 || a.Frames[0].FrameID != 1 {
+		t.Fatalf("Expected 20 frames starting at 1, got %d", len(a.Frames))
+	}
+	for f := range a.Frames {
+		if len(a.Frames[f].GTIDs) != 3 {
+			t.Fatalf("Frame %d: expected 3 GT objects, got %d", f, len(a.Frames[f].GTIDs))
+		}
+		if len(a.Frames[f].Detections) != len(b.Frames[f].Detections) {
+			t.Fatalf("Frame %d: same seed produced different detections", f)
+		}
+		for i, box := range a.Frames[f].GTBBoxes {
+			for j := range box {
+				if box[j] != b.Frames[f].GTBBoxes[i][j] {
+					t.Fatalf("Frame %d: same seed produced different ground truth", f)
+				}
+			}
+		}
+	}
+
+	if _, err := NewSimulation(&SimulationConfig{MissRate: 2}); err == nil {
+		t.Error("Expected error for miss rate > 1")
+	}
+}
+
+// TestEvaluateTracker_QualityThresholds guards tracking quality against regressions
+func TestEvaluateTracker_QualityThresholds(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      SimulationConfig
+		minMOTA     float64
+		minIDF1     float64
+		maxSwitches int
+	}{
+		{"clean", SimulationConfig{Seed: 1}, 0.95, 0.95, 0},
+		{"noisy", SimulationConfig{NoiseStd: 2.0, MissRate: 0.1, FalsePositiveRate: 0.1, Seed: 2}, 0.9, 0.9, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulation, err := NewSimulation(&tt.config)
+			if err != nil {
+				t.Fatalf("NewSimulation failed: %v", err)
+			}
+			tracker := mustNewTracker(t, &TrackerConfig{
+				DistanceFunction:    DistanceByName("iou"),
+				DistanceThreshold:   0.7,
+				HitCounterMax:       15,
+				InitializationDelay: 2,
+			})
+			metrics, err := EvaluateTracker(tracker, simulation, 0.5)
+			if err != nil {
+				t.Fatalf("EvaluateTracker failed: %v", err)
+			}
+
+			if metrics.MOTA < tt.minMOTA {
+				t.Errorf("MOTA %.3f below %.3f", metrics.MOTA, tt.minMOTA)
+			}
+			if metrics.IDF1 < tt.minIDF1 {
+				t.Errorf("IDF1 %.3f below %.3f", metrics.IDF1, tt.minIDF1)
+			}
+			if metrics.NumSwitches > tt.maxSwitches {
+				t.Errorf("%d ID switches, expected at most %d", metrics.NumSwitches, tt.maxSwitches)
+			}
+			t.Logf("MOTA=%.3f IDF1=%.3f switches=%d", metrics.MOTA, metrics.IDF1, metrics.NumSwitches)
+		})
+	}
+}
+
+func TestEvaluateTracker_RejectsPointTracks(t *testing.T) {
+	simulation, _ := NewSimulation(&SimulationConfig{NumObjects: 1, NumFrames: 5})
+	tracker, _ := NewTracker(&TrackerConfig{InitializationDelay: 0})
+
+	// Point detections instead of boxes
+	for _, frame := range simulation.Frames {
+		frame.Detections = []*Detection{newPointDetection(t, frame.GTBBoxes[0][0], frame.GTBBoxes[0][1])}
+	}
+	if _, err := EvaluateTracker(tracker, simulation, 0.5); err == nil {
+		t.Error("Expected error for tracks that are not boxes")
+	}
+}
+
+func TestSimulation_All(t *testing.T) {
+	simulation, err := NewSimulation(&SimulationConfig{NumFrames: 10})
+	if err != nil {
+		t.Fatalf("NewSimulation failed: %v", err)
+	}
+
+	count := 0
+	for frameID, detections := range simulation.All() {
+		frame := simulation.Frames[count]
+		if frameID != frame.FrameID || len(detections) != len(frame.Detections) {
+			t.Errorf("frame %d: iterator does not match Frames", frameID)
+		}
+		count++
+	}
+	if count != 10 {
+		t.Errorf("Expected 10 frames, got %d", count)
+	}
+}
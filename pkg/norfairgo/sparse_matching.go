@@ -0,0 +1,244 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/nmichlo/norfair-go/internal/scipy"
+)
+
+// =============================================================================
+// Sparse Matching - Distance Matrices Storing Only Matchable Pairs
+// =============================================================================
+
+// SparseEntry is the distance between a candidate (row) and the object of column Col.
+type SparseEntry = scipy.SparseEntry
+
+// SparseDistances is a (candidates, objects) distance matrix storing, per candidate,
+// only the objects it can be matched with. Missing entries are +Inf.
+//
+// When gating or the distance threshold rule out most pairs, as in large scenes,
+// it needs memory and matching time proportional to the number of matchable pairs
+// instead of candidates x objects. It implements mat.Matrix, so it can be used
+// wherever a dense distance matrix is only read. It is matched greedily by
+// MatchSparseDetectionsAndObjects (as the tracker does) or optimally by
+// MatchSparseOptimal.
+type SparseDistances struct {
+	Rows    [][]SparseEntry // Entries of each candidate, sorted by column
+	NumCols int             // Number of objects
+}
+
+// NewSparseDistances creates an empty (all +Inf) sparse distance matrix.
+func NewSparseDistances(rows, cols int) *SparseDistances {
+	return &SparseDistances{Rows: make([][]SparseEntry, rows), NumCols: cols}
+}
+
+// SparseDistancesFromDense keeps the entries of a dense distance matrix below the
+// threshold. NaN entries are kept, so they can still be reported as errors.
+func SparseDistancesFromDense(distances mat.Matrix, threshold float64) *SparseDistances {
+	rows, cols := distances.Dims()
+	sparse := NewSparseDistances(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if d := distances.At(i, j); d < threshold || math.IsNaN(d) {
+				sparse.Rows[i] = append(sparse.Rows[i], SparseEntry{Col: j, Distance: d})
+			}
+		}
+	}
+	return sparse
+}
+
+// Add sets the distance between a candidate and an object. Entries of a row must be
+// added in increasing column order.
+func (s *SparseDistances) Add(row, col int, distance float64) {
+	s.Rows[row] = append(s.Rows[row], SparseEntry{Col: col, Distance: distance})
+}
+
+// Dims returns the number of candidates and objects.
+func (s *SparseDistances) Dims() (int, int) {
+	return len(s.Rows), s.NumCols
+}
+
+// At returns the distance between a candidate and an object, +Inf if not stored.
+func (s *SparseDistances) At(i, j int) float64 {
+	entries := s.Rows[i]
+	k := sort.Search(len(entries), func(k int) bool { return entries[k].Col >= j })
+	if k < len(entries) && entries[k].Col == j {
+		return entries[k].Distance
+	}
+	return math.Inf(1)
+}
+
+// T returns the transpose of the matrix (implements mat.Matrix).
+func (s *SparseDistances) T() mat.Matrix {
+	return mat.Transpose{Matrix: s}
+}
+
+// NumEntries returns the number of stored entries.
+func (s *SparseDistances) NumEntries() int {
+	n := 0
+	for _, entries := range s.Rows {
+		n += len(entries)
+	}
+	return n
+}
+
+// Validate returns an error if the matrix contains NaN values (see ValidateDistanceMatrix).
+func (s *SparseDistances) Validate() error {
+	for _, entries := range s.Rows {
+		for _, e := range entries {
+			if math.IsNaN(e.Distance) {
+				return fmt.Errorf(
+					"received NaN values from distance function, please check your distance function for errors",
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// SparseDistance is implemented by distances that can compute only the detection
+// object pairs below a threshold, without building the dense matrix. With
+// TrackerConfig.SparseMatching, the tracker prefers it over the dense entry points.
+type SparseDistance interface {
+	GetSparseDistancesDetections(objects []*TrackedObject, detections []*Detection, threshold float64) *SparseDistances
+}
+
+// getSparseDistances computes the sparse distances of detections through
+// GetSparseDistancesDetections when the distance has it, and by sparsifying the
+// dense matrix otherwise.
+func getSparseDistances(distance Distance, objects []*TrackedObject, detections []*Detection, threshold float64) *SparseDistances {
+	if typed, ok := distance.(SparseDistance); ok {
+		return typed.GetSparseDistancesDetections(objects, detections, threshold)
+	}
+	return SparseDistancesFromDense(getDistances(distance, objects, detections), threshold)
+}
+
+// GetSparseDistancesDetections computes the distances below the threshold pair by
+// pair, never allocating the dense matrix (implements SparseDistance).
+func (sd *ScalarDistance) GetSparseDistancesDetections(objects []*TrackedObject, detections []*Detection, threshold float64) *SparseDistances {
+	distances := NewSparseDistances(len(detections), len(objects))
+	for c, det := range detections {
+		for o, obj := range objects {
+			if d, ok := sd.computePairDistance(det, obj); ok && (d < threshold || math.IsNaN(d)) {
+				distances.Add(c, o, d)
+			}
+		}
+	}
+	return distances
+}
+
+// MatchSparseDetectionsAndObjects performs the greedy minimum-distance matching of
+// MatchDetectionsAndObjects on a sparse distance matrix, with the same result, in
+// O(E log E) for E stored entries.
+//
+// Parameters:
+//   - distances: Sparse distances where rows are candidates and columns objects
+//   - distanceThreshold: Maximum distance to consider a valid match
+//
+// Returns:
+//   - matchedCandIndices: Indices of matched candidates (detections)
+//   - matchedObjIndices: Indices of matched objects (tracked objects)
+func MatchSparseDetectionsAndObjects(
+	distances *SparseDistances,
+	distanceThreshold float64,
+) (matchedCandIndices, matchedObjIndices []int) {
+	type pair struct {
+		row, col int
+		distance float64
+	}
+	var pairs []pair
+	for row, entries := range distances.Rows {
+		for _, e := range entries {
+			if e.Distance < distanceThreshold {
+				pairs = append(pairs, pair{row, e.Col, e.Distance})
+			}
+		}
+	}
+	// Ties are broken in row-major order, like argMin on the dense matrix
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].distance < pairs[b].distance })
+
+	candIndices := []int{}
+	objIndices := []int{}
+	usedRows := make(map[int]bool)
+	usedCols := make(map[int]bool)
+	for _, p := range pairs {
+		if usedRows[p.row] || usedCols[p.col] {
+			continue
+		}
+		usedRows[p.row] = true
+		usedCols[p.col] = true
+		candIndices = append(candIndices, p.row)
+		objIndices = append(objIndices, p.col)
+	}
+
+	return candIndices, objIndices
+}
+
+// MatchSparseOptimal performs the optimal (Hungarian) matching of a sparse
+// distance matrix: as many candidates as possible are matched to objects within the
+// distance threshold, with the minimum total distance among those matchings. Each
+// connected group of candidates and objects is solved separately, so time and
+// memory depend on the group sizes instead of candidates x objects.
+//
+// Unlike MatchSparseDetectionsAndObjects, a candidate may give up its closest
+// object when that lets more pairs match, e.g. for evaluation against ground truth.
+//
+// Parameters:
+//   - distances: Sparse distances where rows are candidates and columns objects
+//   - distanceThreshold: Maximum distance to consider a valid match (exclusive)
+//
+// Returns:
+//   - matchedCandIndices: Indices of matched candidates, in increasing order
+//   - matchedObjIndices: Indices of the objects matched to them
+func MatchSparseOptimal(
+	distances *SparseDistances,
+	distanceThreshold float64,
+) (matchedCandIndices, matchedObjIndices []int) {
+	rows := make([][]SparseEntry, len(distances.Rows))
+	for row, entries := range distances.Rows {
+		for _, e := range entries {
+			if e.Distance < distanceThreshold {
+				rows[row] = append(rows[row], e)
+			}
+		}
+	}
+
+	assignments, _, _ := scipy.LinearSumAssignmentSparse(rows, distances.NumCols, math.Inf(1))
+	candIndices := make([]int, 0, len(assignments))
+	objIndices := make([]int, 0, len(assignments))
+	for _, a := range assignments {
+		candIndices = append(candIndices, a.RowIdx)
+		objIndices = append(objIndices, a.ColIdx)
+	}
+	return candIndices, objIndices
+}
+
+// columnMinima returns the minimum distance of each object (column) of a dense or
+// sparse distance matrix, +Inf for objects without candidates.
+func columnMinima(distances mat.Matrix) []float64 {
+	rows, cols := distances.Dims()
+	minima := make([]float64, cols)
+	for j := range minima {
+		minima[j] = math.Inf(1)
+	}
+	if sparse, ok := distances.(*SparseDistances); ok {
+		for _, entries := range sparse.Rows {
+			for _, e := range entries {
+				minima[e.Col] = math.Min(minima[e.Col], e.Distance)
+			}
+		}
+		return minima
+	}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if val := distances.At(i, j); val < minima[j] {
+				minima[j] = val
+			}
+		}
+	}
+	return minima
+}
@@ -0,0 +1,129 @@
+package norfairgo
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSparseDistances_FromDense(t *testing.T) {
+	inf := math.Inf(1)
+	dense := mat.NewDense(2, 3, []float64{
+		1, inf, 5,
+		inf, 2, math.NaN(),
+	})
+	sparse := SparseDistancesFromDense(dense, 4)
+
+	if rows, cols := sparse.Dims(); rows != 2 || cols != 3 {
+		t.Fatalf("Expected 2x3, got %dx%d", rows, cols)
+	}
+	if sparse.NumEntries() != 3 {
+		t.Errorf("Expected 3 entries (2 below threshold and the NaN), got %d", sparse.NumEntries())
+	}
+	if sparse.At(0, 0) != 1 || sparse.At(1, 1) != 2 || !math.IsInf(sparse.At(0, 2), 1) {
+		t.Errorf("Unexpected entries %v", sparse.Rows)
+	}
+	if sparse.T().At(1, 1) != 2 {
+		t.Errorf("Expected the transpose to read the same entries")
+	}
+	if sparse.Validate() == nil {
+		t.Error("Expected NaN entry to fail validation")
+	}
+}
+
+func TestMatchSparseOptimal(t *testing.T) {
+	// Greedy matching takes candidate 0's closest object and leaves candidate 1
+	// unmatched; the optimal matching matches both
+	dense := mat.NewDense(2, 3, []float64{
+		1, 2, 9,
+		1.5, math.Inf(1), math.Inf(1),
+	})
+	sparse := SparseDistancesFromDense(dense, 3)
+
+	if cands, _ := MatchSparseDetectionsAndObjects(sparse, 3); len(cands) != 1 {
+		t.Fatalf("Expected greedy matching to match 1 candidate, got %v", cands)
+	}
+	cands, objs := MatchSparseOptimal(sparse, 3)
+	if !reflect.DeepEqual(cands, []int{0, 1}) || !reflect.DeepEqual(objs, []int{1, 0}) {
+		t.Errorf("Expected optimal matches [0 1]/[1 0], got %v/%v", cands, objs)
+	}
+
+	// Entries at the threshold are not matched
+	if cands, _ := MatchSparseOptimal(sparse, 1); len(cands) != 0 {
+		t.Errorf("Expected no matches below a threshold of 1, got %v", cands)
+	}
+}
+
+// TestMatchSparseDetectionsAndObjects_MatchesDense checks the sparse greedy matching
+// against the dense one, including ties
+func TestMatchSparseDetectionsAndObjects_MatchesDense(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for trial := 0; trial < 300; trial++ {
+		rows, cols := 1+rng.Intn(8), 1+rng.Intn(8)
+		dense := mat.NewDense(rows, cols, nil)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				if rng.Float64() < 0.5 {
+					dense.Set(i, j, math.Inf(1))
+				} else {
+					dense.Set(i, j, float64(rng.Intn(5)))
+				}
+			}
+		}
+
+		expectedCands, expectedObjs := MatchDetectionsAndObjects(dense, 3)
+		cands, objs := MatchSparseDetectionsAndObjects(SparseDistancesFromDense(dense, 3), 3)
+		if !reflect.DeepEqual(cands, expectedCands) || !reflect.DeepEqual(objs, expectedObjs) {
+			t.Fatalf("Expected matches %v/%v for %v, got %v/%v", expectedCands, expectedObjs, mat.Formatted(dense), cands, objs)
+		}
+	}
+}
+
+func TestTracker_SparseMatching(t *testing.T) {
+	simulation, err := NewSimulation(&SimulationConfig{NumObjects: 20, NumFrames: 60, NoiseStd: 2, MissRate: 0.1, FalsePositiveRate: 0.3, Seed: 5})
+	if err != nil {
+		t.Fatalf("NewSimulation failed: %v", err)
+	}
+
+	for name, distance := range map[string]func() Distance{
+		"scalar":     func() Distance { return DistanceByName("mean_euclidean") },
+		"vectorized": func() Distance { return DistanceByName("iou") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			threshold := 0.7
+			if name == "scalar" {
+				threshold = 30
+			}
+			newTracker := func(sparse bool) *Tracker {
+				tracker, err := NewTracker(&TrackerConfig{
+					DistanceFunction:    distance(),
+					DistanceThreshold:   threshold,
+					InitializationDelay: 2,
+					GatingConfidence:    0.99,
+					SparseMatching:      sparse,
+				})
+				if err != nil {
+					t.Fatalf("Failed to create tracker: %v", err)
+				}
+				return tracker
+			}
+			dense, sparse := newTracker(false), newTracker(true)
+
+			for _, frame := range simulation.Frames {
+				expected := dense.Update(frame.Detections, 1, nil)
+				objects := sparse.Update(frame.Detections, 1, nil)
+				if len(objects) != len(expected) {
+					t.Fatalf("Frame %d: expected %d objects, got %d", frame.FrameID, len(expected), len(objects))
+				}
+				for i := range objects {
+					if *objects[i].ID != *expected[i].ID || !mat.Equal(objects[i].Estimate, expected[i].Estimate) {
+						t.Fatalf("Frame %d: sparse matching diverged on object %d", frame.FrameID, *expected[i].ID)
+					}
+				}
+			}
+		})
+	}
+}
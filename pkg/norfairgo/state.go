@@ -0,0 +1,106 @@
+package norfairgo
+
+import "sort"
+
+// =============================================================================
+// User State - Application Data Attached to Tracked Objects
+// =============================================================================
+
+// SetState attaches a value to the object under key, replacing any previous value.
+// The state lives as long as the object and survives ReID merges, so applications
+// can keep per-track data (counted flags, classification history, ...) without an
+// external map keyed by ID. All state methods are safe for concurrent use.
+//
+// Example:
+//
+//	for _, obj := range tracker.Update(detections, 1, nil) {
+//	    if _, counted := obj.GetState("counted"); !counted && crossedLine(obj) {
+//	        obj.SetState("counted", true)
+//	        total++
+//	    }
+//	}
+func (to *TrackedObject) SetState(key string, value interface{}) {
+	to.stateMu.Lock()
+	defer to.stateMu.Unlock()
+	if to.state == nil {
+		to.state = make(map[string]interface{})
+	}
+	to.state[key] = value
+}
+
+// GetState returns the value attached under key and whether it exists.
+func (to *TrackedObject) GetState(key string) (interface{}, bool) {
+	to.stateMu.RLock()
+	defer to.stateMu.RUnlock()
+	value, ok := to.state[key]
+	return value, ok
+}
+
+// DeleteState removes the value attached under key.
+func (to *TrackedObject) DeleteState(key string) {
+	to.stateMu.Lock()
+	defer to.stateMu.Unlock()
+	delete(to.state, key)
+}
+
+// UpdateState atomically replaces the value under key with fn(current, exists),
+// e.g. to append to a history from several goroutines.
+func (to *TrackedObject) UpdateState(key string, fn func(value interface{}, ok bool) interface{}) {
+	to.stateMu.Lock()
+	defer to.stateMu.Unlock()
+	if to.state == nil {
+		to.state = make(map[string]interface{})
+	}
+	value, ok := to.state[key]
+	to.state[key] = fn(value, ok)
+}
+
+// StateKeys returns the keys of the attached state, sorted.
+func (to *TrackedObject) StateKeys() []string {
+	to.stateMu.RLock()
+	defer to.stateMu.RUnlock()
+	keys := make([]string, 0, len(to.state))
+	for key := range to.state {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// StateValue returns the value attached to obj under key as a T. ok is false if
+// the key does not exist or holds a value of another type.
+//
+// Example:
+//
+//	history, _ := norfairgo.StateValue[[]string](obj, "classes")
+func StateValue[T any](obj *TrackedObject, key string) (value T, ok bool) {
+	raw, exists := obj.GetState(key)
+	if !exists {
+		return value, false
+	}
+	value, ok = raw.(T)
+	return value, ok
+}
+
+// mergeState adds the state of other for keys this object does not have (used by
+// Merge, where the ReID'd object's own state takes precedence).
+func (to *TrackedObject) mergeState(other *TrackedObject) {
+	if to == other {
+		return
+	}
+	other.stateMu.RLock()
+	defer other.stateMu.RUnlock()
+	if len(other.state) == 0 {
+		return
+	}
+	to.stateMu.Lock()
+	defer to.stateMu.Unlock()
+	if to.state == nil {
+		to.state = make(map[string]interface{}, len(other.state))
+	}
+	for key, value := range other.state {
+		if _, exists := to.state[key]; !exists {
+			to.state[key] = value
+		}
+	}
+}
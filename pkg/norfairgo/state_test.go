@@ -0,0 +1,88 @@
+package norfairgo
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// =============================================================================
+// User State Tests
+// =============================================================================
+
+func TestTrackedObject_State(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   50,
+		HitCounterMax:       5,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	objects := tracker.Update([]*Detection{newPointDetection(t, 10, 10)}, 1, nil)
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 object, got %d", len(objects))
+	}
+	objects[0].SetState("counted", true)
+	objects[0].SetState("classes", []string{"car"})
+
+	// State survives across frames
+	objects = tracker.Update([]*Detection{newPointDetection(t, 12, 10)}, 1, nil)
+	if counted, ok := StateValue[bool](objects[0], "counted"); !ok || !counted {
+		t.Errorf("Expected counted=true, got %v (ok=%v)", counted, ok)
+	}
+	if _, ok := StateValue[int](objects[0], "counted"); ok {
+		t.Error("Expected StateValue with the wrong type to fail")
+	}
+	if _, ok := objects[0].GetState("missing"); ok {
+		t.Error("Expected missing key to not exist")
+	}
+	if keys := objects[0].StateKeys(); !reflect.DeepEqual(keys, []string{"classes", "counted"}) {
+		t.Errorf("Unexpected keys %v", keys)
+	}
+
+	objects[0].DeleteState("counted")
+	if _, ok := objects[0].GetState("counted"); ok {
+		t.Error("Expected deleted key to not exist")
+	}
+}
+
+func TestTrackedObject_UpdateStateConcurrent(t *testing.T) {
+	obj := &TrackedObject{}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj.UpdateState("count", func(value interface{}, ok bool) interface{} {
+				if !ok {
+					return 1
+				}
+				return value.(int) + 1
+			})
+		}()
+	}
+	wg.Wait()
+
+	if count, _ := StateValue[int](obj, "count"); count != 20 {
+		t.Errorf("Expected count 20, got %d", count)
+	}
+}
+
+func TestTrackedObject_MergeState(t *testing.T) {
+	old := &TrackedObject{}
+	old.SetState("name", "old")
+	reidentified := &TrackedObject{}
+	reidentified.SetState("name", "new")
+	reidentified.SetState("zone", 3)
+
+	old.mergeState(reidentified)
+	if name, _ := StateValue[string](old, "name"); name != "old" {
+		t.Errorf("Expected the ReID'd object's state to take precedence, got %q", name)
+	}
+	if zone, _ := StateValue[int](old, "zone"); zone != 3 {
+		t.Errorf("Expected missing keys to be merged, got %d", zone)
+	}
+}
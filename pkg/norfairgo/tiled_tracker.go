@@ -0,0 +1,300 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// TiledTracker - Region-Partitioned Tracking for Very Wide Frames
+// =============================================================================
+
+// TiledTrackerConfig configures a TiledTracker.
+type TiledTrackerConfig struct {
+	// Tracker is the configuration shared by every tile tracker. Recorder and
+	// IDStore hold state of a single tracker and are not supported.
+	Tracker *TrackerConfig
+
+	// FrameWidth and FrameHeight are the frame size in pixels (required).
+	FrameWidth  int
+	FrameHeight int
+
+	// TileCols and TileRows define the tile grid.
+	// Default: 1 (no partitioning along that axis)
+	TileCols int
+	TileRows int
+
+	// Width in pixels of the band around tile borders where detections are
+	// stitched to the objects of neighbouring tiles: a detection in the band goes
+	// to the tile of the nearest predicted object within this distance of it, so
+	// objects crossing a border are matched even when the detection crosses before
+	// the prediction does. Must be less than half the smaller tile side.
+	// Default: 10% of the smaller tile side (if 0)
+	BorderMargin float64
+
+	// Parallel updates the tiles on separate goroutines. ID assignment order
+	// between tiles is then not deterministic, and custom distance functions,
+	// filter factories and hooks of the tracker config must be safe for
+	// concurrent use.
+	// Default: false
+	Parallel bool
+}
+
+// TiledTracker partitions the frame into a grid of tiles, each with its own Tracker,
+// so the assignment problem per tile only involves that tile's objects and detections.
+//
+// Objects are assigned to the tile containing their predicted centroid and
+// detections to the tile containing their centroid (in relative coordinates).
+// Stitching keeps identities across tile borders: before every update, objects
+// whose predicted centroid lies in another tile are handed over to that tile's
+// tracker together with their filter state and IDs, and detections within
+// BorderMargin of a border go to the tile of the nearest predicted object. All
+// tiles share one TrackedObjectFactory, so IDs are unique across the whole frame.
+type TiledTracker struct {
+	Config *TiledTrackerConfig
+
+	tiles        []*Tracker
+	objFactory   *TrackedObjectFactory
+	tileWidth    float64
+	tileHeight   float64
+	borderMargin float64
+}
+
+// objectPrediction is the predicted centroid of an object.
+type objectPrediction struct {
+	obj      *TrackedObject
+	centroid [2]float64
+}
+
+// NewTiledTracker creates a TiledTracker from a configuration.
+//
+// Returns: Error if the frame size is missing, the border margin is out of range or
+// the tracker config is invalid or uses Recorder or IDStore
+func NewTiledTracker(config *TiledTrackerConfig) (*TiledTracker, error) {
+	if config == nil || config.Tracker == nil {
+		return nil, fmt.Errorf("tiled tracker config and tracker config cannot be nil")
+	}
+	if config.FrameWidth <= 0 || config.FrameHeight <= 0 {
+		return nil, fmt.Errorf("frame size must be positive, got %dx%d", config.FrameWidth, config.FrameHeight)
+	}
+
+	// Apply defaults
+	if config.TileCols <= 0 {
+		config.TileCols = 1
+	}
+	if config.TileRows <= 0 {
+		config.TileRows = 1
+	}
+	tileWidth := float64(config.FrameWidth) / float64(config.TileCols)
+	tileHeight := float64(config.FrameHeight) / float64(config.TileRows)
+	if config.BorderMargin == 0 {
+		config.BorderMargin = 0.1 * math.Min(tileWidth, tileHeight)
+	}
+	if config.BorderMargin < 0 || config.BorderMargin >= math.Min(tileWidth, tileHeight)/2 {
+		return nil, fmt.Errorf("border_margin must be in [0, %v), got %v", math.Min(tileWidth, tileHeight)/2, config.BorderMargin)
+	}
+
+	// Tile trackers would each record, restore and save their own share of the state
+	if config.Tracker.Recorder != nil {
+		return nil, fmt.Errorf("tile trackers cannot share a Recorder")
+	}
+	if config.Tracker.IDStore != nil {
+		return nil, fmt.Errorf("tile trackers cannot share an IDStore")
+	}
+
+	objFactory := NewTrackedObjectFactory()
+	tiles := make([]*Tracker, config.TileCols*config.TileRows)
+	for i := range tiles {
+		tracker, err := NewTracker(config.Tracker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tile tracker: %w", err)
+		}
+		tracker.objFactory = objFactory // IDs unique across tiles
+		tiles[i] = tracker
+	}
+
+	return &TiledTracker{
+		Config:       config,
+		tiles:        tiles,
+		objFactory:   objFactory,
+		tileWidth:    tileWidth,
+		tileHeight:   tileHeight,
+		borderMargin: config.BorderMargin,
+	}, nil
+}
+
+// Update processes detections for the current frame and returns active tracked objects
+// of all tiles.
+//
+// Parameters:
+//   - detections: List of detections for this frame (nil = no detections)
+//   - period: Time period since last update (default: 1)
+//   - coordTransformations: Coordinate transformation for camera motion (nil = no transformation)
+func (tt *TiledTracker) Update(
+	detections []*Detection,
+	period int,
+	coordTransformations CoordinateTransformation,
+) []*TrackedObject {
+	// Stitching: hand objects over to the tile they are predicted to be in
+	predictions := tt.migrateObjects()
+
+	// Partition detections by centroid, stitching those near borders
+	tileDetections := make([][]*Detection, len(tt.tiles))
+	for _, det := range detections {
+		if err := det.Validate(); err != nil {
+			WarnOnce(fmt.Sprintf("TiledTracker.Update skipped detections: %s", err))
+			continue
+		}
+		tile := tt.detectionTile(centroid(det.Points), predictions)
+		tileDetections[tile] = append(tileDetections[tile], det)
+	}
+
+	// Update tiles independently
+	if tt.Config.Parallel {
+		var wg sync.WaitGroup
+		for i, tracker := range tt.tiles {
+			wg.Add(1)
+			go func(tracker *Tracker, dets []*Detection) {
+				defer wg.Done()
+				tracker.Update(dets, period, coordTransformations)
+			}(tracker, tileDetections[i])
+		}
+		wg.Wait()
+	} else {
+		for i, tracker := range tt.tiles {
+			tracker.Update(tileDetections[i], period, coordTransformations)
+		}
+	}
+
+	// Apply the Output* options of the tracker config
+	activeObjects := []*TrackedObject{}
+	for _, tracker := range tt.tiles {
+		activeObjects = append(activeObjects, tracker.filterOutputObjects(tracker.GetActiveObjects())...)
+	}
+	return activeObjects
+}
+
+// GetActiveObjects returns the active objects of all tiles.
+func (tt *TiledTracker) GetActiveObjects() []*TrackedObject {
+	activeObjects := []*TrackedObject{}
+	for _, tracker := range tt.tiles {
+		activeObjects = append(activeObjects, tracker.GetActiveObjects()...)
+	}
+	return activeObjects
+}
+
+// TrackedObjects returns all tracked objects of all tiles (including initializing ones).
+func (tt *TiledTracker) TrackedObjects() []*TrackedObject {
+	objects := []*TrackedObject{}
+	for _, tracker := range tt.tiles {
+		objects = append(objects, tracker.TrackedObjects...)
+	}
+	return objects
+}
+
+// Tile returns the tracker of the tile at the given grid position, or nil if out of range.
+func (tt *TiledTracker) Tile(col, row int) *Tracker {
+	if col < 0 || col >= tt.Config.TileCols || row < 0 || row >= tt.Config.TileRows {
+		return nil
+	}
+	return tt.tiles[row*tt.Config.TileCols+col]
+}
+
+// TotalObjectCount returns the total number of objects ever created across all tiles.
+func (tt *TiledTracker) TotalObjectCount() int {
+	return tt.objFactory.Count()
+}
+
+// migrateObjects moves objects whose predicted centroid (estimate plus one step of
+// velocity, matching the filter prediction in Update) lies in another tile.
+//
+// Returns: The predicted centroids of the objects of every tile after the moves
+func (tt *TiledTracker) migrateObjects() [][]objectPrediction {
+	type move struct {
+		obj      *TrackedObject
+		from, to int
+	}
+	var moves []move
+	predictions := make([][]objectPrediction, len(tt.tiles))
+	for i, tracker := range tt.tiles {
+		for _, obj := range tracker.TrackedObjects {
+			estimate, err := obj.GetEstimate(false)
+			if err != nil {
+				continue
+			}
+			predicted := centroid(estimate)
+			velocity := centroid(obj.EstimateVelocity())
+			predicted[0] += velocity[0]
+			predicted[1] += velocity[1]
+
+			target := tt.tileIndex(predicted)
+			predictions[target] = append(predictions[target], objectPrediction{obj: obj, centroid: predicted})
+			if target != i {
+				moves = append(moves, move{obj: obj, from: i, to: target})
+			}
+		}
+	}
+
+	for _, m := range moves {
+		tt.tiles[m.from].removeTrackedObject(m.obj)
+		tt.tiles[m.to].TrackedObjects = append(tt.tiles[m.to].TrackedObjects, m.obj)
+	}
+	return predictions
+}
+
+// detectionTile returns the tile a detection centroid is passed to: the tile of
+// the nearest predicted object within BorderMargin when the centroid lies in the
+// band around a tile border, the tile containing it otherwise.
+func (tt *TiledTracker) detectionTile(c [2]float64, predictions [][]objectPrediction) int {
+	own := tt.tileIndex(c)
+	m := tt.borderMargin
+
+	// Tiles overlapping the margin around the centroid (at most 4)
+	var nearby []int
+	for _, corner := range [4][2]float64{{c[0] - m, c[1] - m}, {c[0] + m, c[1] - m}, {c[0] - m, c[1] + m}, {c[0] + m, c[1] + m}} {
+		if tile := tt.tileIndex(corner); !slices.Contains(nearby, tile) {
+			nearby = append(nearby, tile)
+		}
+	}
+	if len(nearby) == 1 {
+		return own
+	}
+
+	best, bestDistance := own, math.Inf(1)
+	for _, tile := range nearby {
+		for _, p := range predictions[tile] {
+			if d := math.Hypot(p.centroid[0]-c[0], p.centroid[1]-c[1]); d <= m && d < bestDistance {
+				best, bestDistance = tile, d
+			}
+		}
+	}
+	return best
+}
+
+// tileIndex returns the index of the tile containing a point (clamped to the grid).
+func (tt *TiledTracker) tileIndex(point [2]float64) int {
+	col := int(math.Floor(point[0] / tt.tileWidth))
+	row := int(math.Floor(point[1] / tt.tileHeight))
+	col = max(0, min(tt.Config.TileCols-1, col))
+	row = max(0, min(tt.Config.TileRows-1, row))
+	return row*tt.Config.TileCols + col
+}
+
+// centroid returns the mean (x, y) of a points matrix.
+func centroid(points *mat.Dense) [2]float64 {
+	rows, _ := points.Dims()
+	var c [2]float64
+	for i := 0; i < rows; i++ {
+		c[0] += points.At(i, 0)
+		c[1] += points.At(i, 1)
+	}
+	if rows > 0 {
+		c[0] /= float64(rows)
+		c[1] /= float64(rows)
+	}
+	return c
+}
@@ -0,0 +1,166 @@
+package norfairgo
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// TiledTracker Tests
+// =============================================================================
+
+func newTestTiledTracker(t *testing.T, parallel bool) *TiledTracker {
+	t.Helper()
+	tt, err := NewTiledTracker(&TiledTrackerConfig{
+		Tracker: &TrackerConfig{
+			DistanceFunction:    DistanceByName("euclidean"),
+			DistanceThreshold:   30.0,
+			HitCounterMax:       10,
+			InitializationDelay: 1,
+		},
+		FrameWidth:  200,
+		FrameHeight: 100,
+		TileCols:    2,
+		TileRows:    1,
+		Parallel:    parallel,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tiled tracker: %v", err)
+	}
+	return tt
+}
+
+func TestNewTiledTracker_InvalidConfig(t *testing.T) {
+	if _, err := NewTiledTracker(nil); err == nil {
+		t.Error("Expected error for nil config")
+	}
+	if _, err := NewTiledTracker(&TiledTrackerConfig{Tracker: &TrackerConfig{}}); err == nil {
+		t.Error("Expected error for missing frame size")
+	}
+}
+
+func TestTiledTracker_PartitionsDetections(t *testing.T) {
+	tt := newTestTiledTracker(t, false)
+
+	detections := func() []*Detection {
+		left, _ := NewDetection(mat.NewDense(1, 2, []float64{20, 50}), nil)
+		right, _ := NewDetection(mat.NewDense(1, 2, []float64{180, 50}), nil)
+		return []*Detection{left, right}
+	}
+
+	var active []*TrackedObject
+	for i := 0; i < 3; i++ {
+		active = tt.Update(detections(), 1, nil)
+	}
+
+	if len(active) != 2 {
+		t.Fatalf("Expected 2 active objects, got %d", len(active))
+	}
+	if len(tt.Tile(0, 0).TrackedObjects) != 1 || len(tt.Tile(1, 0).TrackedObjects) != 1 {
+		t.Errorf("Expected one object per tile, got %d and %d",
+			len(tt.Tile(0, 0).TrackedObjects), len(tt.Tile(1, 0).TrackedObjects))
+	}
+	if *active[0].ID == *active[1].ID {
+		t.Error("Expected IDs to be unique across tiles")
+	}
+	if tt.Tile(2, 0) != nil {
+		t.Error("Expected nil for out of range tile")
+	}
+}
+
+func TestTiledTracker_StitchesAcrossBorder(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		tt := newTestTiledTracker(t, parallel)
+
+		// Object moving right across the tile border at x=100
+		var id *int
+		for frame := 0; frame < 20; frame++ {
+			x := 60.0 + 5.0*float64(frame)
+			det, _ := NewDetection(mat.NewDense(1, 2, []float64{x, 50}), nil)
+			active := tt.Update([]*Detection{det}, 1, nil)
+
+			if len(active) > 1 {
+				t.Fatalf("parallel=%v frame %d: expected at most 1 active object, got %d", parallel, frame, len(active))
+			}
+			if len(active) == 1 {
+				if id == nil {
+					id = active[0].ID
+				} else if *active[0].ID != *id {
+					t.Fatalf("parallel=%v frame %d: ID changed from %d to %d at border", parallel, frame, *id, *active[0].ID)
+				}
+			}
+		}
+
+		if id == nil {
+			t.Fatalf("parallel=%v: object never became active", parallel)
+		}
+		if len(tt.Tile(1, 0).GetActiveObjects()) != 1 {
+			t.Errorf("parallel=%v: expected the object to end in the right tile", parallel)
+		}
+		if tt.TotalObjectCount() != 1 {
+			t.Errorf("parallel=%v: expected 1 object in total, got %d", parallel, tt.TotalObjectCount())
+		}
+	}
+}
+
+func TestTiledTracker_StitchesDetectionAheadOfPrediction(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		tt := newTestTiledTracker(t, parallel)
+
+		// Object waiting just left of the seam at x=100, then stepping across it: the
+		// detection is in the right tile while the prediction is still in the left one
+		for frame := 0; frame < 10; frame++ {
+			tt.Update([]*Detection{newPointDetection(t, 97, 50)}, 1, nil)
+		}
+		for frame := 0; frame < 10; frame++ {
+			active := tt.Update([]*Detection{newPointDetection(t, 103, 50)}, 1, nil)
+			if len(active) != 1 {
+				t.Fatalf("parallel=%v frame %d: expected 1 active object, got %d", parallel, frame, len(active))
+			}
+		}
+
+		if tt.TotalObjectCount() != 1 || len(tt.TrackedObjects()) != 1 {
+			t.Errorf("parallel=%v: expected a single track across the seam, got %d IDs and %d objects",
+				parallel, tt.TotalObjectCount(), len(tt.TrackedObjects()))
+		}
+		if len(tt.Tile(1, 0).TrackedObjects) != 1 {
+			t.Errorf("parallel=%v: expected the object to end in the right tile", parallel)
+		}
+	}
+}
+
+func TestTiledTracker_SkipsInvalidDetections(t *testing.T) {
+	tt := newTestTiledTracker(t, false)
+	invalid := []*Detection{
+		nil,
+		{Points: mat.NewDense(1, 1, []float64{150})},
+		{},
+		newPointDetection(t, 20, 50),
+	}
+	tt.Update(invalid, 1, nil)
+	if len(tt.TrackedObjects()) != 1 {
+		t.Errorf("Expected only the valid detection to be tracked, got %d objects", len(tt.TrackedObjects()))
+	}
+}
+
+func TestNewTiledTracker_RejectsSharedState(t *testing.T) {
+	newConfig := func(tracker *TrackerConfig) *TiledTrackerConfig {
+		tracker.DistanceFunction = DistanceByName("euclidean")
+		return &TiledTrackerConfig{Tracker: tracker, FrameWidth: 200, FrameHeight: 100, TileCols: 2}
+	}
+	if _, err := NewTiledTracker(newConfig(&TrackerConfig{Recorder: NewUpdateRecorder(&bytes.Buffer{})})); err == nil {
+		t.Error("Expected error for a shared Recorder")
+	}
+	if _, err := NewTiledTracker(newConfig(&TrackerConfig{IDStore: NewFileIDStore(filepath.Join(t.TempDir(), "ids.json"))})); err == nil {
+		t.Error("Expected error for a shared IDStore")
+	}
+
+	config := newConfig(&TrackerConfig{})
+	config.BorderMargin = 50
+	if _, err := NewTiledTracker(config); err == nil {
+		t.Error("Expected error for a border margin of half a tile")
+	}
+}
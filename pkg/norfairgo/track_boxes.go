@@ -0,0 +1,110 @@
+package norfairgo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// =============================================================================
+// TrackBoxes - One-Call Tracking of Bounding Box Lists
+// =============================================================================
+
+// TrackResult is a tracked box in a frame returned by TrackBoxes.
+type TrackResult struct {
+	ID             int        // Permanent object ID
+	Box            [4]float64 // Estimated box [x_min, y_min, x_max, y_max]
+	DetectionIndex int        // Index of the matched input box in the frame, -1 if not matched
+}
+
+// TrackOption configures the tracker created by TrackBoxes.
+type TrackOption func(*TrackerConfig)
+
+// WithTrackerConfig replaces the whole configuration (see NewTracker for defaults of
+// zero fields). Options given after it still apply.
+func WithTrackerConfig(config TrackerConfig) TrackOption {
+	return func(c *TrackerConfig) { *c = config }
+}
+
+// WithDistanceFunction sets the distance used to match boxes to objects (default: iou).
+func WithDistanceFunction(distance Distance) TrackOption {
+	return func(c *TrackerConfig) { c.DistanceFunction = distance }
+}
+
+// WithDistanceThreshold sets the maximum distance of a match (default 0.5).
+func WithDistanceThreshold(threshold float64) TrackOption {
+	return func(c *TrackerConfig) { c.DistanceThreshold = threshold }
+}
+
+// WithHitCounterMax sets the number of frames an object survives without matches (default 15).
+func WithHitCounterMax(hitCounterMax int) TrackOption {
+	return func(c *TrackerConfig) { c.HitCounterMax = hitCounterMax }
+}
+
+// WithInitializationDelay sets the number of matches before an object gets an ID
+// (default: HitCounterMax / 2).
+func WithInitializationDelay(delay int) TrackOption {
+	return func(c *TrackerConfig) { c.InitializationDelay = delay }
+}
+
+// TrackBoxes tracks per-frame lists of [x_min, y_min, x_max, y_max] boxes, e.g. the
+// output of a detector on each frame of a video, and returns the active objects of
+// each frame ordered by ID. It covers the common case of assigning IDs to detections
+// without setting up a Tracker and Detections by hand:
+//
+//	results, err := norfairgo.TrackBoxes(frames, norfairgo.WithDistanceThreshold(0.7))
+//	for _, r := range results[i] {
+//	    fmt.Println(r.ID, r.Box, r.DetectionIndex)
+//	}
+//
+// Returns: Error if the configuration is invalid or a box has x_max < x_min or y_max < y_min
+func TrackBoxes(framesOfBoxes [][][4]float64, opts ...TrackOption) ([][]TrackResult, error) {
+	config := &TrackerConfig{
+		DistanceFunction:    DistanceByName("iou"),
+		DistanceThreshold:   0.5,
+		InitializationDelay: -1,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	config.RecordMatchHistory = true // Source of DetectionIndex
+	tracker, err := NewTracker(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracker: %w", err)
+	}
+
+	results := make([][]TrackResult, len(framesOfBoxes))
+	for i, boxes := range framesOfBoxes {
+		detections := make([]*Detection, len(boxes))
+		for j, box := range boxes {
+			if box[2] < box[0] || box[3] < box[1] {
+				return nil, fmt.Errorf("frame %d, box %d: expected [x_min, y_min, x_max, y_max], got %v", i, j, box)
+			}
+			detection, err := NewDetectionFromBox(box[0], box[1], box[2], box[3], nil)
+			if err != nil {
+				return nil, fmt.Errorf("frame %d, box %d: %w", i, j, err)
+			}
+			detections[j] = detection
+		}
+
+		objects := tracker.Update(detections, 1, nil)
+		frameResults := make([]TrackResult, 0, len(objects))
+		for _, obj := range objects {
+			if obj.ID == nil {
+				continue
+			}
+			index := -1
+			if n := len(obj.MatchHistory); n > 0 && obj.MatchHistory[n-1].Frame == tracker.frameNumber {
+				index = obj.MatchHistory[n-1].DetectionIndex
+			}
+			estimate := obj.Estimate
+			frameResults = append(frameResults, TrackResult{
+				ID:             *obj.ID,
+				Box:            [4]float64{estimate.At(0, 0), estimate.At(0, 1), estimate.At(1, 0), estimate.At(1, 1)},
+				DetectionIndex: index,
+			})
+		}
+		sort.Slice(frameResults, func(a, b int) bool { return frameResults[a].ID < frameResults[b].ID })
+		results[i] = frameResults
+	}
+	return results, nil
+}
@@ -0,0 +1,94 @@
+package norfairgo
+
+import (
+	"testing"
+)
+
+func TestTrackBoxes(t *testing.T) {
+	// Two boxes moving right, listed in swapped order on odd frames, then a frame
+	// without detections
+	var frames [][][4]float64
+	for i := 0; i < 10; i++ {
+		x := float64(i)
+		left := [4]float64{x, 0, x + 10, 10}
+		right := [4]float64{x + 100, 0, x + 110, 10}
+		if i%2 == 0 {
+			frames = append(frames, [][4]float64{left, right})
+		} else {
+			frames = append(frames, [][4]float64{right, left})
+		}
+	}
+	frames = append(frames, nil)
+
+	results, err := TrackBoxes(frames, WithInitializationDelay(2))
+	if err != nil {
+		t.Fatalf("TrackBoxes failed: %v", err)
+	}
+	if len(results) != len(frames) {
+		t.Fatalf("Expected %d frames of results, got %d", len(frames), len(results))
+	}
+	if len(results[0]) != 0 {
+		t.Errorf("Expected no confirmed objects in the first frame, got %+v", results[0])
+	}
+
+	for i := 3; i < 10; i++ {
+		if len(results[i]) != 2 {
+			t.Fatalf("Frame %d: expected 2 objects, got %+v", i, results[i])
+		}
+		left, right := results[i][0], results[i][1]
+		if left.ID != 1 || right.ID != 2 {
+			t.Errorf("Frame %d: expected IDs 1 and 2, got %d and %d", i, left.ID, right.ID)
+		}
+		leftIndex, rightIndex := 0, 1
+		if i%2 == 1 {
+			leftIndex, rightIndex = 1, 0
+		}
+		if left.DetectionIndex != leftIndex || right.DetectionIndex != rightIndex {
+			t.Errorf("Frame %d: expected detection indices %d and %d, got %d and %d",
+				i, leftIndex, rightIndex, left.DetectionIndex, right.DetectionIndex)
+		}
+		if left.Box[0] > float64(i)+1 || left.Box[0] < float64(i)-1 || right.Box[2] < float64(i)+109 {
+			t.Errorf("Frame %d: unexpected boxes %v and %v", i, left.Box, right.Box)
+		}
+	}
+
+	last := results[len(results)-1]
+	if len(last) != 2 || last[0].DetectionIndex != -1 || last[1].DetectionIndex != -1 {
+		t.Errorf("Expected 2 unmatched objects in the last frame, got %+v", last)
+	}
+}
+
+func TestTrackBoxes_IndicesAfterDuplicate(t *testing.T) {
+	// The exact duplicate of A is skipped by the tracker, B keeps its input index
+	a := [4]float64{0, 0, 10, 10}
+	b := [4]float64{100, 0, 110, 10}
+	frames := [][][4]float64{{a, a, b}, {a, a, b}}
+
+	results, err := TrackBoxes(frames, WithInitializationDelay(0))
+	if err != nil {
+		t.Fatalf("TrackBoxes failed: %v", err)
+	}
+	for i, frame := range results {
+		if len(frame) != 2 {
+			t.Fatalf("Frame %d: expected 2 objects, got %+v", i, frame)
+		}
+		if frame[0].DetectionIndex != 0 || frame[1].DetectionIndex != 2 {
+			t.Errorf("Frame %d: expected detection indices 0 and 2, got %d and %d",
+				i, frame[0].DetectionIndex, frame[1].DetectionIndex)
+		}
+	}
+}
+
+func TestTrackBoxes_Errors(t *testing.T) {
+	if _, err := TrackBoxes([][][4]float64{{{10, 0, 0, 10}}}); err == nil {
+		t.Error("Expected error for a box with x_max < x_min")
+	}
+	if _, err := TrackBoxes(nil, WithHitCounterMax(2), WithInitializationDelay(5)); err == nil {
+		t.Error("Expected error for an invalid configuration")
+	}
+
+	results, err := TrackBoxes(nil, WithTrackerConfig(TrackerConfig{DistanceFunction: DistanceByName("iou")}))
+	if err != nil || len(results) != 0 {
+		t.Errorf("Expected no results and no error for no frames, got %v, %v", results, err)
+	}
+}
@@ -0,0 +1,209 @@
+package norfairgo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// =============================================================================
+// Track Hotspots - Where Tracks Are Born and Die
+// =============================================================================
+
+// TrackHotspotsConfig configures a TrackHotspots.
+type TrackHotspotsConfig struct {
+	// Frame size the grid covers. Points outside are clamped to the border cells.
+	// Required.
+	FrameWidth  float64
+	FrameHeight float64
+
+	// Side length of the square grid cells, in the units of the estimates.
+	// Default: 32
+	CellSize float64
+
+	// Point of each object whose position is recorded.
+	// Default: ReferenceBottomCenter
+	Reference ReferencePointMode
+}
+
+// TrackEndpoint is where and when a track was born or died.
+type TrackEndpoint struct {
+	ID    int
+	Frame int64 // Update number (1-indexed)
+	X, Y  float64
+}
+
+// HotspotCell is the number of births and deaths in one grid cell.
+type HotspotCell struct {
+	Row, Col int
+	X, Y     float64 // Top-left corner of the cell
+	Births   int
+	Deaths   int
+}
+
+// TrackHotspots records where tracks are born (the first update their ID is passed
+// to Update) and where they die (the last update before their ID is no longer
+// passed), and aggregates both into heat grids. Births and deaths away from the
+// frame borders point at detector blind spots, occlusions or a poorly placed camera.
+type TrackHotspots struct {
+	config      TrackHotspotsConfig
+	rows, cols  int
+	frameNumber int64
+	alive       map[int]TrackEndpoint // Last seen position of the current tracks
+	births      []TrackEndpoint
+	deaths      []TrackEndpoint
+}
+
+// NewTrackHotspots creates a new TrackHotspots.
+//
+// Returns: Error if the frame size is not positive or CellSize is negative
+func NewTrackHotspots(config TrackHotspotsConfig) (*TrackHotspots, error) {
+	if config.FrameWidth <= 0 || config.FrameHeight <= 0 {
+		return nil, fmt.Errorf("frame size must be positive, got %gx%g", config.FrameWidth, config.FrameHeight)
+	}
+	if config.CellSize < 0 {
+		return nil, fmt.Errorf("cell size must be >= 0, got %v", config.CellSize)
+	}
+	if config.CellSize == 0 {
+		config.CellSize = 32
+	}
+	return &TrackHotspots{
+		config: config,
+		rows:   int(math.Ceil(config.FrameHeight / config.CellSize)),
+		cols:   int(math.Ceil(config.FrameWidth / config.CellSize)),
+		alive:  make(map[int]TrackEndpoint),
+	}, nil
+}
+
+// Update records the births among the current objects and the deaths of the
+// tracks no longer among them.
+func (h *TrackHotspots) Update(objects []TrackedObjectLike) {
+	h.frameNumber++
+
+	seen := make(map[int]bool, len(objects))
+	var births []TrackEndpoint
+	for _, obj := range objects {
+		id := obj.GetID()
+		if id == nil {
+			continue
+		}
+		estimate, err := obj.GetEstimate(false)
+		if err != nil {
+			continue
+		}
+		point := h.config.Reference.Extract(estimate)
+		endpoint := TrackEndpoint{ID: *id, Frame: h.frameNumber, X: point[0], Y: point[1]}
+		if _, ok := h.alive[*id]; !ok {
+			births = append(births, endpoint)
+		}
+		h.alive[*id] = endpoint
+		seen[*id] = true
+	}
+
+	var deaths []TrackEndpoint
+	for id, endpoint := range h.alive {
+		if !seen[id] {
+			deaths = append(deaths, endpoint)
+			delete(h.alive, id)
+		}
+	}
+	sortEndpoints(births)
+	sortEndpoints(deaths)
+	h.births = append(h.births, births...)
+	h.deaths = append(h.deaths, deaths...)
+}
+
+// Births returns where tracks were born, ordered by update and then by ID.
+func (h *TrackHotspots) Births() []TrackEndpoint {
+	return append([]TrackEndpoint{}, h.births...)
+}
+
+// Deaths returns where tracks died (their last seen position), ordered by update
+// of death and then by ID. Tracks still alive are not included.
+func (h *TrackHotspots) Deaths() []TrackEndpoint {
+	return append([]TrackEndpoint{}, h.deaths...)
+}
+
+// BirthGrid returns the number of births per grid cell, indexed [row][col].
+func (h *TrackHotspots) BirthGrid() [][]int {
+	return h.grid(h.births)
+}
+
+// DeathGrid returns the number of deaths per grid cell, indexed [row][col].
+func (h *TrackHotspots) DeathGrid() [][]int {
+	return h.grid(h.deaths)
+}
+
+// Hotspots returns the cells with at least minCount births or deaths, with the
+// most births plus deaths first.
+func (h *TrackHotspots) Hotspots(minCount int) []HotspotCell {
+	births, deaths := h.BirthGrid(), h.DeathGrid()
+	var cells []HotspotCell
+	for row := 0; row < h.rows; row++ {
+		for col := 0; col < h.cols; col++ {
+			if max(births[row][col], deaths[row][col]) < max(minCount, 1) {
+				continue
+			}
+			cells = append(cells, HotspotCell{
+				Row:    row,
+				Col:    col,
+				X:      float64(col) * h.config.CellSize,
+				Y:      float64(row) * h.config.CellSize,
+				Births: births[row][col],
+				Deaths: deaths[row][col],
+			})
+		}
+	}
+	sort.SliceStable(cells, func(a, b int) bool {
+		return cells[a].Births+cells[a].Deaths > cells[b].Births+cells[b].Deaths
+	})
+	return cells
+}
+
+// WriteCSV writes the birth and death counts of every grid cell as CSV, one cell
+// per row in row-major order, e.g. to render heat maps.
+//
+// Format: row,col,x,y,births,deaths
+func (h *TrackHotspots) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"row", "col", "x", "y", "births", "deaths"}); err != nil {
+		return fmt.Errorf("failed to write hotspots header: %w", err)
+	}
+	births, deaths := h.BirthGrid(), h.DeathGrid()
+	for row := 0; row < h.rows; row++ {
+		for col := 0; col < h.cols; col++ {
+			record := []string{
+				strconv.Itoa(row), strconv.Itoa(col),
+				formatFloat(float64(col) * h.config.CellSize), formatFloat(float64(row) * h.config.CellSize),
+				strconv.Itoa(births[row][col]), strconv.Itoa(deaths[row][col]),
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write hotspots: %w", err)
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// grid counts endpoints per cell, clamping points outside the frame to the border.
+func (h *TrackHotspots) grid(endpoints []TrackEndpoint) [][]int {
+	grid := make([][]int, h.rows)
+	for row := range grid {
+		grid[row] = make([]int, h.cols)
+	}
+	for _, e := range endpoints {
+		row := min(max(int(math.Floor(e.Y/h.config.CellSize)), 0), h.rows-1)
+		col := min(max(int(math.Floor(e.X/h.config.CellSize)), 0), h.cols-1)
+		grid[row][col]++
+	}
+	return grid
+}
+
+// sortEndpoints orders endpoints of the same update by ID.
+func sortEndpoints(endpoints []TrackEndpoint) {
+	sort.Slice(endpoints, func(a, b int) bool { return endpoints[a].ID < endpoints[b].ID })
+}
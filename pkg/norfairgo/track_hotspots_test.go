@@ -0,0 +1,57 @@
+package norfairgo
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrackHotspots(t *testing.T) {
+	hotspots, err := NewTrackHotspots(TrackHotspotsConfig{FrameWidth: 100, FrameHeight: 60, CellSize: 50})
+	if err != nil {
+		t.Fatalf("Failed to create track hotspots: %v", err)
+	}
+
+	// Object 1 is born in the top-left cell and dies in the top-right one; object 2
+	// is born in the bottom-right cell (clamped from outside the frame) and stays
+	frames := [][]TrackedObjectLike{
+		{pointTrack(1, 10, 20)},
+		{pointTrack(1, 60, 20), pointTrack(2, 120, 55)},
+		{pointTrack(2, 90, 55)},
+	}
+	for _, objects := range frames {
+		hotspots.Update(objects)
+	}
+
+	expectedBirths := []TrackEndpoint{{ID: 1, Frame: 1, X: 10, Y: 20}, {ID: 2, Frame: 2, X: 120, Y: 55}}
+	if !reflect.DeepEqual(hotspots.Births(), expectedBirths) {
+		t.Errorf("Expected births %v, got %v", expectedBirths, hotspots.Births())
+	}
+	expectedDeaths := []TrackEndpoint{{ID: 1, Frame: 2, X: 60, Y: 20}}
+	if !reflect.DeepEqual(hotspots.Deaths(), expectedDeaths) {
+		t.Errorf("Expected deaths %v, got %v", expectedDeaths, hotspots.Deaths())
+	}
+	if grid := hotspots.BirthGrid(); !reflect.DeepEqual(grid, [][]int{{1, 0}, {0, 1}}) {
+		t.Errorf("Unexpected birth grid %v", grid)
+	}
+	if grid := hotspots.DeathGrid(); !reflect.DeepEqual(grid, [][]int{{0, 1}, {0, 0}}) {
+		t.Errorf("Unexpected death grid %v", grid)
+	}
+	if cells := hotspots.Hotspots(1); len(cells) != 3 || cells[0].Births+cells[0].Deaths != 1 {
+		t.Errorf("Expected 3 hotspot cells, got %+v", cells)
+	}
+
+	var buf bytes.Buffer
+	if err := hotspots.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	expected := "row,col,x,y,births,deaths\n0,0,0,0,1,0\n0,1,50,0,0,1\n1,0,0,50,0,0\n1,1,50,50,1,0\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	if _, err := NewTrackHotspots(TrackHotspotsConfig{}); err == nil || !strings.Contains(err.Error(), "frame size") {
+		t.Errorf("Expected frame size error, got %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package norfairgo
 
 import (
 	"fmt"
+	"math"
+	"sync"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -25,8 +27,29 @@ type TrackedObject struct {
 	HitCounter      int   // Current hit counter (object-level)
 	ReidHitCounter  *int  // Current ReID counter (nil until object dies)
 	PointHitCounter []int // Per-point hit counters
-	Age             int   // Age in frames
+	Age             int64 // Age in frames
 	IsInitializing  bool  // Whether still in initialization phase
+	Frozen          bool  // Frozen objects keep predicting but are never matched or expired
+	StationaryCount int64 // Consecutive frames with speed under StationaryVelocityThreshold
+	FramesSinceHit  int64 // Frames since the last match with a detection (0 right after a hit)
+	matched         bool  // Matched with a detection since the last TrackerStep
+	deferred        bool  // Had an ambiguous match deferred in the previous update
+	embeddingFrame  int64 // Tracker frame number of the last EmbeddingFn call (0 = never)
+
+	// Quality statistics (see Quality)
+	hitFrames         int64   // Frames covered by matched detections, including the first
+	initializedAge    int64   // Age at which the object acquired its IDs
+	matchDistanceSum  float64 // Sum of the detection match distances
+	numMatchDistances int     // Number of detection match distances
+
+	// Direction of motion (see Heading)
+	headingVelocity [2]float64 // Smoothed centroid velocity
+	heading         float64    // Last stable heading in degrees
+	hasHeading      bool       // Whether the object has moved fast enough for a heading
+
+	// Competing detections of an ambiguous match deferred in the latest update
+	// (nil if none). See TrackerConfig.AmbiguityRatio.
+	Hypotheses []*Detection
 
 	// IDs
 	InitializingID *int // Temporary ID during initialization
@@ -38,18 +61,97 @@ type TrackedObject struct {
 	LastDistance              *float64     // Distance from last match
 	CurrentMinDistance        *float64     // Current minimum distance (debug)
 	DetectedAtLeastOncePoints []bool       // Which points have been detected at least once
+	GatedPoints               []bool       // Points whose score was at or below threshold in the last detection
 	PastDetections            []*Detection // Past detections stored
 
+	// Detections this object was matched with (only with TrackerConfig.RecordMatchHistory)
+	MatchHistory []DetectionMatch
+
+	// Diverse embeddings of the matched detections (see TrackerConfig.EmbeddingGallerySize)
+	EmbeddingGallery [][]float64
+
 	// Filter
 	Filter   Filter     // Kalman filter for state estimation
 	DimZ     int        // Measurement dimension (dimPoints * numPoints)
 	Estimate *mat.Dense // Cached position estimate (updated after filter operations)
 
 	// Label and coordinate transform
-	Label    *string                     // Class label
-	AbsToRel func(*mat.Dense) *mat.Dense // Absolute to relative coordinate transform
+	Label       *string                     // Class label
+	ClassID     *int                        // Integer class ID
+	AbsToRel    func(*mat.Dense) *mat.Dense // Absolute to relative coordinate transform
+	Coordinates CoordinateSystem            // Coordinate system of the matched detections
+
+	// Application state (see SetState)
+	stateMu sync.RWMutex
+	state   map[string]interface{}
+}
+
+// TrackedObjectLike is the read-only view of a track used by drawing (norfairgodraw)
+// and metrics (PredictionsTextFile), so tracks from other sources can be fed to them.
+// *TrackedObject implements it.
+type TrackedObjectLike interface {
+	GetEstimate(absolute bool) (*mat.Dense, error) // Position estimate (NumPoints x DimPoints)
+	GetID() *int                                   // Permanent ID (nil if not yet assigned)
+	GetLabel() *string                             // Class label (nil if unlabeled)
+	GetLivePoints() []bool                         // Mask of currently tracked points
+}
+
+var _ TrackedObjectLike = (*TrackedObject)(nil)
+
+// AsTrackedObjectLikes converts tracked objects (e.g. Tracker.Update's return value)
+// to the interface slice accepted by the TrackedObjectLike APIs.
+func AsTrackedObjectLikes(objects []*TrackedObject) []TrackedObjectLike {
+	likes := make([]TrackedObjectLike, len(objects))
+	for i, obj := range objects {
+		likes[i] = obj
+	}
+	return likes
+}
+
+// SnapshotObjects copies the ID, label, estimates and live points of tracked objects
+// into immutable TrackedObjectLikes that stay valid after the next Update, e.g. to
+// draw them on another goroutine while tracking continues.
+func SnapshotObjects(objects []*TrackedObject) []TrackedObjectLike {
+	snapshots := make([]TrackedObjectLike, len(objects))
+	for i, obj := range objects {
+		snapshot := &objectSnapshot{
+			id:         copyIntPtr(obj.ID),
+			livePoints: obj.LivePoints(),
+		}
+		if obj.Label != nil {
+			label := *obj.Label
+			snapshot.label = &label
+		}
+		snapshot.estimate, _ = obj.GetEstimate(false)
+		snapshot.absoluteEstimate, _ = obj.GetEstimate(true)
+		snapshots[i] = snapshot
+	}
+	return snapshots
+}
+
+// objectSnapshot is a TrackedObjectLike copy of a tracked object (see SnapshotObjects).
+type objectSnapshot struct {
+	id               *int
+	label            *string
+	estimate         *mat.Dense
+	absoluteEstimate *mat.Dense // nil without coordinate transformations
+	livePoints       []bool
+}
+
+func (s *objectSnapshot) GetEstimate(absolute bool) (*mat.Dense, error) {
+	if !absolute {
+		return s.estimate, nil
+	}
+	if s.absoluteEstimate == nil {
+		return nil, fmt.Errorf("you must provide 'coord_transformations' to get absolute coordinates")
+	}
+	return s.absoluteEstimate, nil
 }
 
+func (s *objectSnapshot) GetID() *int           { return s.id }
+func (s *objectSnapshot) GetLabel() *string     { return s.label }
+func (s *objectSnapshot) GetLivePoints() []bool { return s.livePoints }
+
 // NewTrackedObject creates a new tracked object from an initial detection.
 //
 // Parameters:
@@ -89,13 +191,17 @@ func NewTrackedObject(
 		NumPoints:          numPoints,
 		InitialPeriod:      period,
 		HitCounter:         period, // Starts at period!
-		ReidHitCounter:     nil,    // Not set until object dies
+		hitFrames:          int64(period),
+		ReidHitCounter:     nil, // Not set until object dies
 		Age:                0,
 		LastDetection:      initialDetection,
 		LastDistance:       nil,
 		CurrentMinDistance: nil,
 		DimZ:               dimZ,
+		matched:            true,
 		Label:              initialDetection.Label,
+		ClassID:            initialDetection.ClassID,
+		Coordinates:        initialDetection.Coordinates,
 	}
 
 	// Set initialization state
@@ -113,6 +219,7 @@ func NewTrackedObject(
 	// Initialize point tracking
 	to.DetectedAtLeastOncePoints = make([]bool, numPoints)
 	to.PointHitCounter = make([]int, numPoints)
+	to.GatedPoints = make([]bool, numPoints)
 
 	if initialDetection.Scores == nil {
 		// No scores - all points detected
@@ -123,16 +230,19 @@ func NewTrackedObject(
 	} else {
 		// Use scores to determine detected points
 		for i := 0; i < numPoints; i++ {
-			if initialDetection.Scores[i] > to.config.DetectionThreshold {
+			if initialDetection.Scores[i] > to.pointDetectionThreshold(i) {
 				to.DetectedAtLeastOncePoints[i] = true
 				to.PointHitCounter[i] = 1
 			} else {
 				to.DetectedAtLeastOncePoints[i] = false
 				to.PointHitCounter[i] = 0
+				to.GatedPoints[i] = true
 			}
 		}
 	}
 
+	to.addToGallery(initialDetection.Embedding)
+
 	// Initialize past detections
 	initialDetection.Age = to.Age
 	if to.config.PastDetectionsLength > 0 {
@@ -160,7 +270,24 @@ func NewTrackedObject(
 
 // TrackerStep is called once per frame for all tracked objects.
 // It decrements counters, increments age, and calls filter prediction.
+// Counters of frozen objects are held so they neither expire nor die.
 func (to *TrackedObject) TrackerStep() {
+	to.trackerStepPeriod(1)
+}
+
+// trackerStepPeriod advances the object by period frames: counters decay by period,
+// age grows by period and the filter predicts period steps (see Tracker.Update).
+func (to *TrackedObject) trackerStepPeriod(period int) {
+	to.matched = false
+	to.deferred = to.Hypotheses != nil
+	to.Hypotheses = nil
+	to.FramesSinceHit += int64(period)
+	if to.Frozen {
+		to.Age += int64(period)
+		to.predict(period)
+		return
+	}
+
 	// ReID counter management
 	if to.ReidHitCounter == nil {
 		// If object just died, initialize ReID counter
@@ -170,29 +297,124 @@ func (to *TrackedObject) TrackerStep() {
 		}
 	} else {
 		// Decrement ReID counter
-		*to.ReidHitCounter -= 1
+		*to.ReidHitCounter -= period
 	}
 
 	// Decrement counters
-	to.HitCounter -= 1
+	to.HitCounter -= period
 	for i := range to.PointHitCounter {
-		to.PointHitCounter[i] -= 1
+		to.PointHitCounter[i] -= period
 	}
 
 	// Increment age
-	to.Age += 1
+	to.Age += int64(period)
 
-	// Predict next state
-	to.Filter.Predict()
+	// Stationary detection (optionally pinning the velocity to zero)
+	to.updateStationary(period)
+	to.updateHeading(period)
 
-	// Update cached estimate
+	// Predict next state and update cached estimate
+	to.predict(period)
+}
+
+// predict runs period filter prediction steps and refreshes the cached estimate.
+func (to *TrackedObject) predict(period int) {
+	constrained := to.config.MaxAspectRatioChange > 0 && to.NumPoints == 2 && to.DimPoints == 2
+	for i := 0; i < period; i++ {
+		if !constrained {
+			to.Filter.Predict()
+			continue
+		}
+		state := to.Filter.GetStateVector()
+		width, height := state.At(2, 0)-state.At(0, 0), state.At(3, 0)-state.At(1, 0)
+		to.Filter.Predict()
+		to.constrainAspectRatio(width, height)
+	}
 	to.updateEstimate()
 }
 
+// constrainAspectRatio resizes the predicted box around its center, keeping its
+// area, so its aspect ratio changed by at most MaxAspectRatioChange from the box
+// before the prediction (width x height). Boxes predicted with a non-positive
+// width or height get the previous size back.
+func (to *TrackedObject) constrainAspectRatio(width, height float64) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	state := mat.DenseCopyOf(to.Filter.GetStateVector())
+	cx, cy := (state.At(0, 0)+state.At(2, 0))/2, (state.At(1, 0)+state.At(3, 0))/2
+	newWidth, newHeight := state.At(2, 0)-state.At(0, 0), state.At(3, 0)-state.At(1, 0)
+
+	if newWidth <= 0 || newHeight <= 0 {
+		newWidth, newHeight = width, height
+	} else {
+		ratio, maxChange := width/height, 1+to.config.MaxAspectRatioChange
+		newRatio := newWidth / newHeight
+		if newRatio >= ratio/maxChange && newRatio <= ratio*maxChange {
+			return
+		}
+		newRatio = math.Max(ratio/maxChange, math.Min(newRatio, ratio*maxChange))
+		area := newWidth * newHeight
+		newWidth, newHeight = math.Sqrt(area*newRatio), math.Sqrt(area/newRatio)
+	}
+
+	state.Set(0, 0, cx-newWidth/2)
+	state.Set(1, 0, cy-newHeight/2)
+	state.Set(2, 0, cx+newWidth/2)
+	state.Set(3, 0, cy+newHeight/2)
+	to.Filter.SetStateVector(state)
+}
+
+// IsMatched reports whether the object was matched with a detection (or created
+// from one) in the most recent tracker update.
+func (to *TrackedObject) IsMatched() bool {
+	return to.matched
+}
+
+// IsStationary reports whether the object's estimated speed has stayed under
+// TrackerConfig.StationaryVelocityThreshold for at least StationaryFrames frames.
+// Always false when stationary detection is disabled.
+func (to *TrackedObject) IsStationary() bool {
+	return to.config.StationaryVelocityThreshold > 0 && to.StationaryCount >= int64(to.config.StationaryFrames)
+}
+
+// updateStationary updates StationaryCount from the current velocity estimate and
+// zeroes the velocity of stationary objects if FreezeStationaryVelocity is set.
+func (to *TrackedObject) updateStationary(period int) {
+	if to.config.StationaryVelocityThreshold <= 0 {
+		return
+	}
+
+	velocity := to.EstimateVelocity()
+	maxSpeed := 0.0
+	for i := 0; i < to.NumPoints; i++ {
+		maxSpeed = math.Max(maxSpeed, mat.Norm(velocity.RowView(i), 2))
+	}
+
+	if maxSpeed < to.config.StationaryVelocityThreshold {
+		to.StationaryCount += int64(period)
+	} else {
+		to.StationaryCount = 0
+	}
+
+	if to.config.FreezeStationaryVelocity && to.IsStationary() {
+		state := mat.DenseCopyOf(to.Filter.GetStateVector())
+		for i := to.DimZ; i < 2*to.DimZ; i++ {
+			state.Set(i, 0, 0)
+		}
+		to.Filter.SetStateVector(state)
+	}
+}
+
 // Hit is called when the object is matched with a detection.
 // It updates the Kalman filter and manages hit counters.
 func (to *TrackedObject) Hit(detection *Detection, period int) error {
+	to.matched = true
+	to.Coordinates = to.Coordinates.merge(detection.Coordinates)
+	to.FramesSinceHit = 0
+	to.hitFrames += int64(period)
 	to.conditionallyAddToPastDetections(detection)
+	to.addToGallery(detection.Embedding)
 	to.updateHitCounters(period)
 
 	pointsOverThresholdMask, hPos := to.buildMeasurementMask(detection, period)
@@ -217,6 +439,9 @@ func (to *TrackedObject) updateHitCounters(period int) {
 }
 
 func (to *TrackedObject) buildMeasurementMask(detection *Detection, period int) ([]bool, *mat.Dense) {
+	if len(to.GatedPoints) != to.NumPoints {
+		to.GatedPoints = make([]bool, to.NumPoints)
+	}
 	if detection.Scores != nil {
 		return to.buildPartialMask(detection, period)
 	}
@@ -228,7 +453,8 @@ func (to *TrackedObject) buildPartialMask(detection *Detection, period int) ([]b
 	sensorsMask := make([]float64, to.DimZ)
 
 	for i := 0; i < to.NumPoints; i++ {
-		pointsMask[i] = detection.Scores[i] > to.config.DetectionThreshold
+		pointsMask[i] = detection.Scores[i] > to.pointDetectionThreshold(i)
+		to.GatedPoints[i] = !pointsMask[i]
 		if pointsMask[i] {
 			to.PointHitCounter[i] += 2 * period
 			for d := 0; d < to.DimPoints; d++ {
@@ -251,6 +477,7 @@ func (to *TrackedObject) buildFullMask(period int) ([]bool, *mat.Dense) {
 	pointsMask := make([]bool, to.NumPoints)
 	for i := 0; i < to.NumPoints; i++ {
 		pointsMask[i] = true
+		to.GatedPoints[i] = false
 		to.PointHitCounter[i] += 2 * period
 	}
 
@@ -264,6 +491,15 @@ func (to *TrackedObject) buildFullMask(period int) ([]bool, *mat.Dense) {
 	return pointsMask, hPos
 }
 
+// pointDetectionThreshold returns the score threshold for a point, preferring
+// config.PointDetectionThresholds over the uniform DetectionThreshold.
+func (to *TrackedObject) pointDetectionThreshold(pointIndex int) float64 {
+	if pointIndex < len(to.config.PointDetectionThresholds) {
+		return to.config.PointDetectionThresholds[pointIndex]
+	}
+	return to.config.DetectionThreshold
+}
+
 func (to *TrackedObject) clampPointHitCounters() {
 	for i := 0; i < to.NumPoints; i++ {
 		if to.PointHitCounter[i] >= to.config.PointwiseHitCounterMax {
@@ -350,18 +586,39 @@ func (to *TrackedObject) Merge(trackedObject *TrackedObject) {
 	to.LastDistance = trackedObject.LastDistance
 	to.CurrentMinDistance = trackedObject.CurrentMinDistance
 	to.LastDetection = trackedObject.LastDetection
+	to.matched = trackedObject.matched
+	to.FramesSinceHit = trackedObject.FramesSinceHit
+	to.Coordinates = to.Coordinates.merge(trackedObject.Coordinates)
+	to.hitFrames += trackedObject.hitFrames
+	to.matchDistanceSum += trackedObject.matchDistanceSum
+	to.numMatchDistances += trackedObject.numMatchDistances
+	if trackedObject.hasHeading {
+		to.headingVelocity = trackedObject.headingVelocity
+		to.heading = trackedObject.heading
+		to.hasHeading = true
+	}
 
 	to.DetectedAtLeastOncePoints = make([]bool, len(trackedObject.DetectedAtLeastOncePoints))
 	copy(to.DetectedAtLeastOncePoints, trackedObject.DetectedAtLeastOncePoints)
 
+	to.GatedPoints = make([]bool, len(trackedObject.GatedPoints))
+	copy(to.GatedPoints, trackedObject.GatedPoints)
+
 	// Take new filter state
 	to.Filter = trackedObject.Filter
 
+	to.MatchHistory = append(to.MatchHistory, trackedObject.MatchHistory...)
+	for _, embedding := range trackedObject.EmbeddingGallery {
+		to.addToGallery(embedding)
+	}
+
 	// Merge past detections
 	for _, pastDetection := range trackedObject.PastDetections {
 		to.conditionallyAddToPastDetections(pastDetection)
 	}
 
+	to.mergeState(trackedObject)
+
 	// Update cached estimate
 	to.updateEstimate()
 }
@@ -416,6 +673,28 @@ func (to *TrackedObject) EstimateVelocity() *mat.Dense {
 	return velocities
 }
 
+// PointCovariance returns the (DimPoints, DimPoints) position covariance of a single
+// point, in absolute coordinates when a coordinate transformation is set.
+//
+// Parameters:
+//   - pointIndex: Index of the point in the estimate
+//
+// Returns: Error if the filter does not expose covariance (see CovarianceFilter)
+// or the index is out of range
+func (to *TrackedObject) PointCovariance(pointIndex int) (*mat.Dense, error) {
+	if pointIndex < 0 || pointIndex >= to.NumPoints {
+		return nil, fmt.Errorf("point index %d out of range [0, %d)", pointIndex, to.NumPoints)
+	}
+	covFilter, ok := to.Filter.(CovarianceFilter)
+	if !ok {
+		return nil, fmt.Errorf("filter %T does not expose covariance", to.Filter)
+	}
+
+	start := pointIndex * to.DimPoints
+	cov := covFilter.PositionCovariance()
+	return mat.DenseCopyOf(cov.Slice(start, start+to.DimPoints, start, start+to.DimPoints)), nil
+}
+
 // LivePoints returns a boolean mask of which points are currently live.
 func (to *TrackedObject) LivePoints() []bool {
 	livePoints := make([]bool, to.NumPoints)
@@ -426,19 +705,19 @@ func (to *TrackedObject) LivePoints() []bool {
 }
 
 // GetLivePoints returns a boolean mask of which points are currently live.
-// Alias for LivePoints() required by norfairgodraw.TrackedObjectLike interface.
+// Alias for LivePoints() required by the TrackedObjectLike interface.
 func (to *TrackedObject) GetLivePoints() []bool {
 	return to.LivePoints()
 }
 
 // GetID returns the object's permanent ID.
-// Required by norfairgodraw.TrackedObjectLike interface.
+// Required by the TrackedObjectLike interface.
 func (to *TrackedObject) GetID() *int {
 	return to.ID
 }
 
 // GetLabel returns the object's label.
-// Required by norfairgodraw.TrackedObjectLike interface.
+// Required by the TrackedObjectLike interface.
 func (to *TrackedObject) GetLabel() *string {
 	return to.Label
 }
@@ -457,6 +736,7 @@ func (to *TrackedObject) ReidHitCounterIsPositive() bool {
 func (to *TrackedObject) UpdateCoordinateTransformation(coordTransform CoordinateTransformation) {
 	if coordTransform != nil {
 		to.AbsToRel = coordTransform.AbsToRel
+		to.updateEstimate() // Refresh the cached estimate for the new frame
 
 		// Transform last detection if it exists
 		if to.LastDetection != nil {
@@ -476,6 +756,7 @@ func (to *TrackedObject) acquireIDs() {
 	id, globalID := to.objFactory.GetIDs()
 	to.ID = &id
 	to.GlobalID = &globalID
+	to.initializedAge = to.Age
 }
 
 // conditionallyAddToPastDetections manages the past detections storage.
@@ -492,7 +773,7 @@ func (to *TrackedObject) conditionallyAddToPastDetections(detection *Detection)
 	} else if len(to.PastDetections) > 0 {
 		// Check if we should replace the oldest detection
 		// This maintains uniform distribution: ages 0, N, 2N, 3N, ...
-		if to.Age >= to.PastDetections[0].Age*to.config.PastDetectionsLength {
+		if to.Age >= to.PastDetections[0].Age*int64(to.config.PastDetectionsLength) {
 			// Remove oldest
 			to.PastDetections = to.PastDetections[1:]
 			// Add new
@@ -1,8 +1,14 @@
 package norfairgo
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
 )
 
 // TrackerConfig contains all configuration parameters for a Tracker.
@@ -17,7 +23,8 @@ type TrackerConfig struct {
 	DistanceThreshold float64
 
 	// Maximum "hits" an object can accumulate before being clamped.
-	// Objects lose 1 hit per frame without detection, gain 2*period hits per match.
+	// Objects lose 1 hit per frame without detection, gain 2*period hits per match
+	// (so a matched object gains 1 hit per frame at any period).
 	// Default: 15
 	HitCounterMax int
 
@@ -37,6 +44,33 @@ type TrackerConfig struct {
 	// Default: 0.0
 	DetectionThreshold float64
 
+	// Per-point minimum confidence scores for keypoint detections, indexed by point.
+	// Overrides DetectionThreshold for every point it covers, so e.g. noisy wrist
+	// keypoints can be gated more strictly than torso keypoints.
+	// Default: nil (DetectionThreshold for every point)
+	PointDetectionThresholds []float64
+
+	// Maximum point-to-point distance for aligning detections whose point count
+	// differs from a track's (e.g. partial keypoint sets). When > 0, such detections
+	// are matched point by point to the track's estimate (see AlignDetectionPoints)
+	// and missing points are excluded from the Kalman update.
+	// Default: 0.0 (disabled; detections and tracks must have the same point count)
+	PointMatchingThreshold float64
+
+	// Expected shape of detection points: bounding boxes, keypoints, or any shape
+	// consistent per label (see PointsMode). Non-conforming detections are skipped
+	// by Update with a warning describing the mismatch.
+	// Default: PointsAuto
+	PointsMode PointsMode
+
+	// Maximum relative change of the aspect ratio (width / height) of predicted
+	// bounding boxes per frame, e.g. 0.1 for 10%. Predictions beyond it are resized
+	// around their center, keeping their area, which prevents the degenerate sliver
+	// boxes that build up over unmatched prediction-only frames. Only applies to
+	// 2-point boxes [[x1, y1], [x2, y2]].
+	// Default: 0.0 (unconstrained)
+	MaxAspectRatioChange float64
+
 	// Factory for creating Kalman filters for tracked objects.
 	// Default: OptimizedKalmanFilterFactory with default parameters
 	FilterFactory FilterFactory
@@ -61,6 +95,206 @@ type TrackerConfig struct {
 	// Set to nil or 0 to disable ReID.
 	// Default: nil (disabled)
 	ReidHitCounterMax *int
+
+	// Maximum estimated speed (per frame, largest over all points) for an object
+	// to count as stationary. See TrackedObject.IsStationary.
+	// Default: 0.0 (disabled)
+	StationaryVelocityThreshold float64
+
+	// Number of consecutive frames under StationaryVelocityThreshold before an
+	// object is flagged as stationary.
+	// Default: 10 (if 0 and StationaryVelocityThreshold > 0)
+	StationaryFrames int
+
+	// Zero the Kalman velocity of stationary objects every frame, so parked or
+	// queued objects do not drift. A new detection that moves the object breaks
+	// the stationary state again.
+	// Default: false
+	FreezeStationaryVelocity bool
+
+	// Weight in (0, 1] of the latest velocity in the exponential moving average
+	// behind TrackedObject.Heading. Lower values are smoother but lag turns.
+	// Default: 0.3 (if 0)
+	HeadingSmoothing float64
+
+	// Minimum smoothed speed (per frame) for TrackedObject.Heading to follow the
+	// direction of motion. Slower objects keep their last heading, so the noise of
+	// nearly stationary objects does not make it spin.
+	// Default: 1.0 (if 0)
+	HeadingMinSpeed float64
+
+	// Exclude objects that were not matched with a detection in the current frame
+	// from Update's return value. They are still tracked (see TrackedObject.IsMatched).
+	// Default: false
+	OutputOnlyMatched bool
+
+	// Exclude objects younger than OutputMinAge or older than OutputMaxAge frames
+	// (TrackedObject.Age) from Update's return value.
+	// Default: 0 (no age limit)
+	OutputMinAge int
+	OutputMaxAge int
+
+	// Maximum number of simultaneously tracked objects (including initializing
+	// and ReID-pending objects), bounding memory in detector noise storms.
+	// When exceeded after an update, objects are evicted in this order:
+	//   1. dead objects waiting for ReID
+	//   2. initializing objects
+	//   3. active objects
+	// Within each group the lowest last-detection score goes first, then the youngest.
+	// Frozen objects are never evicted, so they may keep the tracker above the limit.
+	// Default: 0 (unbounded)
+	MaxTrackedObjects int
+
+	// Multi-hypothesis mode for ambiguous detection matches, in (0, 1).
+	// A match is ambiguous when its distance is at least AmbiguityRatio times the
+	// distance of the runner-up (another detection for the same object, or another
+	// object for the same detection). Ambiguous objects are not updated for one frame:
+	// they coast on their prediction, keep the competing detections as Hypotheses
+	// (which do not start new objects) and are matched normally in the next frame,
+	// whose evidence resolves the ambiguity. An object is never deferred twice in a row.
+	// Default: 0.0 (disabled)
+	AmbiguityRatio float64
+
+	// Match initialized objects in a cascade (as in DeepSORT): objects are grouped by
+	// TrackedObject.FramesSinceHit and each group is matched against the detections
+	// left over by the more recently updated groups. This prevents long-lost objects,
+	// whose uncertain predictions may be closer to a detection, from stealing it from
+	// an actively tracked object.
+	// Default: false (all initialized objects are matched at once)
+	MatchingCascade bool
+
+	// Matching of initialized objects as a list of stages, each with its own
+	// distance function, threshold and detection/object filters (see MatchingStage).
+	// Stages run in order on what earlier stages left unmatched; with
+	// MatchingCascade, every stage matches in a cascade. Initializing objects and
+	// ReID keep using DistanceFunction/DistanceThreshold and the Reid* options.
+	// Default: nil (a single stage with DistanceFunction and DistanceThreshold)
+	MatchingStages []MatchingStage
+
+	// Record, per tracked object, which detection (frame number and index in the
+	// detections passed to Update) it was matched with. See TrackedObject.MatchHistory
+	// and Tracker.MatchHistory.
+	// Default: false
+	RecordMatchHistory bool
+
+	// Computes the ReID embedding of a detection from the frame (e.g. a model applied
+	// to GetCutout(detection.Points, frame)). Called by UpdateWithFrame for new and
+	// matched detections whose Embedding is nil, so ReID works without a separate
+	// crop-and-embed loop.
+	// Default: nil (embeddings are provided by the caller)
+	EmbeddingFn func(frame Frame, detection *Detection) []float32
+
+	// Minimum number of frames between two EmbeddingFn calls for the detections
+	// matched to the same object. Detections starting new objects are always embedded.
+	// Default: 1 (every frame)
+	EmbeddingInterval int
+
+	// Maximum number of embeddings kept per object in TrackedObject.EmbeddingGallery.
+	// When full, the most redundant embedding is dropped, so the gallery keeps the most
+	// diverse appearances. Use with GalleryDistance as ReidDistanceFunction.
+	// Default: 0 (no gallery)
+	EmbeddingGallerySize int
+
+	// Lens distortion correction applied to detection points at the start of Update,
+	// so tracking works in undistorted pixel coordinates on wide-angle and fisheye
+	// cameras. Estimates are then undistorted too; map them back with
+	// Undistorter.DistortPoints to draw on the original frame.
+	// Default: nil (no correction)
+	Undistorter *Undistorter
+
+	// Persists the ID counters and recent track fingerprints across process restarts
+	// (see FileIDStore and RedisIDStore). NewTracker restores them, so IDs continue
+	// where they stopped and, with ReID enabled, tracks still in view keep their IDs.
+	// Update saves in the background whenever a new ID is issued; call
+	// Tracker.SaveIDs on shutdown. Not supported by TiledTracker.
+	// Default: nil (IDs start at 1)
+	IDStore IDStore
+
+	// Records the inputs of every Update call, so they can be replayed into a new
+	// tracker with the same configuration (see Replay), e.g. for bug reports.
+	// Default: nil (no recording)
+	Recorder *UpdateRecorder
+
+	// Scale DistanceThreshold with the camera motion between consecutive frames, as
+	// measured from the coordinate transformations passed to Update (e.g. by a
+	// MotionEstimator): threshold * (1 + MotionThresholdScale * motion in pixels),
+	// so association stays stable during fast pans. See Tracker.CurrentDistanceThreshold.
+	// Default: 0.0 (fixed threshold)
+	MotionThresholdScale float64
+
+	// Upper bound of the motion scaling factor of the distance threshold.
+	// Default: 3.0 (if MotionThresholdScale > 0)
+	MaxMotionThresholdFactor float64
+
+	// Confidence level of the chi-square gate, in (0, 1). Before assignment, every
+	// detection whose squared Mahalanobis distance to an object's predicted position
+	// (see TrackedObject.MahalanobisDistance) exceeds the chi-square quantile at this
+	// level is excluded from matching that object, whatever the distance function
+	// says. Typical values are 0.95 or 0.99. Requires a filter implementing
+	// CovarianceFilter; other objects are not gated.
+	// Default: 0.0 (disabled)
+	GatingConfidence float64
+
+	// Maximum squared Mahalanobis distance of the innovation of a matched detection
+	// (see TrackedObject.MahalanobisDistance). When a match exceeds it, the detection
+	// is treated as a gross outlier: the object skips its correction step and coasts
+	// on its prediction for the frame, and the detection is left unmatched (it may
+	// start a new object). Unlike GatingConfidence, the assignment is not changed.
+	// Values are in chi-square units, e.g. ChiSquareGate(4, 0.9999) for boxes.
+	// Requires a filter implementing CovarianceFilter; other objects are always
+	// corrected.
+	// Default: 0.0 (disabled)
+	InnovationThreshold float64
+
+	// Match detections to objects on a sparse distance matrix (see SparseDistances)
+	// keeping, per detection, only the objects within the distance threshold and the
+	// chi-square gate. Large scenes, where most pairs are far apart, then need memory
+	// and matching time proportional to the matchable pairs. Distances implementing
+	// SparseDistance never build the dense matrix. Matches are the same as without
+	// it; frames needing point alignment (PointMatchingThreshold) and ReID matching
+	// stay dense.
+	// Default: false
+	SparseMatching bool
+
+	// Re-link new objects to recently lost ones by motion alone, covering short
+	// occlusions without ReID embeddings. Lost objects keep being predicted for
+	// RelinkMaxFrames frames; when an object finishes initializing within this
+	// mean point distance of a lost object's predicted position (and with a
+	// compatible velocity, see RelinkVelocityThreshold), the lost object takes it
+	// over and continues under its old ID.
+	// Default: 0.0 (disabled)
+	RelinkDistanceThreshold float64
+
+	// Number of frames a lost object can be re-linked for.
+	// Default: 30 (if 0 and RelinkDistanceThreshold > 0)
+	RelinkMaxFrames int
+
+	// Maximum mean point distance between the velocity estimates of a new and a
+	// lost object for them to be re-linked (in units per frame).
+	// Default: 0.0 (velocities are not compared)
+	RelinkVelocityThreshold float64
+
+	// Frame size in pixels, needed by ExitLookahead to know where the frame ends.
+	// When set, detections of frames of another size (see Detection.Coordinates)
+	// are skipped.
+	// Default: 0 (unknown)
+	FrameWidth  int
+	FrameHeight int
+
+	// Coordinate space of the detection points (see CoordinateSpace). Detections
+	// in another space (see Detection.Coordinates) are skipped with a warning, so
+	// e.g. normalized and pixel detections are never matched with each other.
+	// Default: SpaceUnspecified (the space of the first detection specifying one)
+	CoordinateSpace CoordinateSpace
+
+	// Terminate objects that were not matched in the current update and whose
+	// center, predicted ExitLookahead frames ahead with their velocity, lies outside
+	// the FrameWidth x FrameHeight frame. Objects leaving the frame are then removed
+	// right away (without ReID or re-linking) instead of lingering as ghosts at its
+	// edge for HitCounterMax frames, where they steal the detections of entering
+	// objects. Requires FrameWidth and FrameHeight.
+	// Default: 0 (disabled)
+	ExitLookahead int
 }
 
 // Tracker is the main object tracking class that manages the lifecycle of tracked objects.
@@ -71,6 +305,52 @@ type Tracker struct {
 	// State (mutable during tracking)
 	TrackedObjects []*TrackedObject
 	objFactory     *TrackedObjectFactory
+	evictions      int         // Objects evicted by MaxTrackedObjects
+	stats          *FrameStats // Statistics collected by UpdateWithStats (nil otherwise)
+
+	// Match history recording (RecordMatchHistory)
+	frameNumber      int64                    // Frames processed so far (sum of periods)
+	detectionIndices map[*Detection]int       // Index of each detection in the current frame
+	matchHistory     map[int][]DetectionMatch // Histories of removed objects, by ID
+
+	frame *Frame // Frame of the current UpdateWithFrame call (nil otherwise)
+
+	savedCount int      // ID counter at the last IDStore save
+	idSaver    *idSaver // Background saves of Update (IDStore)
+
+	// Motion-compensated distance threshold (MotionThresholdScale)
+	distanceThreshold  float64                  // Distance threshold of the current frame
+	lastTransformation CoordinateTransformation // Coordinate transformation of the previous update
+	cameraMotion       float64                  // Camera motion of the current frame in pixels
+
+	gatingThresholds map[int]float64 // Chi-square gates by degrees of freedom (GatingConfidence)
+
+	lostObjects []lostObject // Recently lost objects that can be re-linked (RelinkDistanceThreshold)
+
+	pointsMode    PointsMode // Configured or detected points mode (see PointsMode)
+	keypointShape [2]int     // Shape of the first detection with PointsKeypoints
+
+	coordinates CoordinateSystem // Enforced coordinate system (see Coordinates)
+}
+
+// FrameStats summarizes what a single Tracker update did (see UpdateWithStats).
+type FrameStats struct {
+	NumMatched        int     // Detections matched to existing objects
+	NumReidMatched    int     // Objects recovered by ReID matching
+	NumNew            int     // Objects created from unmatched detections
+	NumRelinked       int     // New objects handed over to recently lost objects (see RelinkDistanceThreshold)
+	NumDead           int     // Objects removed because their (ReID) hit counter expired
+	NumEvicted        int     // Objects evicted by MaxTrackedObjects
+	NumExited         int     // Objects terminated when leaving the frame (see ExitLookahead)
+	NumRejected       int     // Matches rejected as innovation outliers (see InnovationThreshold)
+	NumInitializing   int     // Objects still initializing after the update
+	NumActive         int     // Active objects after the update (before output filtering)
+	MeanMatchDistance float64 // Mean distance of the detection matches (0 if none)
+
+	DistanceTime   time.Duration // Time spent computing distance matrices
+	AssignmentTime time.Duration // Time spent solving the assignment problems
+
+	matchDistanceSum float64
 }
 
 // NewTracker creates a new Tracker from a configuration.
@@ -91,11 +371,45 @@ type Tracker struct {
 //   - InitializationDelay: hitCounterMax/2 (if -1)
 //   - PointwiseHitCounterMax: 4 (if 0)
 //   - DetectionThreshold: 0.0
+//   - PointDetectionThresholds: nil (DetectionThreshold for every point)
+//   - PointMatchingThreshold: 0.0 (disabled)
+//   - PointsMode: PointsAuto
+//   - MaxAspectRatioChange: 0.0 (unconstrained)
 //   - FilterFactory: OptimizedKalmanFilterFactory (if nil)
 //   - PastDetectionsLength: 4 (if 0)
 //   - ReidDistanceFunction: nil (disabled)
 //   - ReidDistanceThreshold: 0.0
 //   - ReidHitCounterMax: nil (disabled)
+//   - StationaryVelocityThreshold: 0.0 (disabled)
+//   - StationaryFrames: 10 (if 0 and stationary detection is enabled)
+//   - FreezeStationaryVelocity: false
+//   - HeadingSmoothing: 0.3 (if 0)
+//   - HeadingMinSpeed: 1.0 (if 0)
+//   - OutputOnlyMatched: false
+//   - OutputMinAge, OutputMaxAge: 0 (no age limit)
+//   - MaxTrackedObjects: 0 (unbounded)
+//   - AmbiguityRatio: 0.0 (disabled)
+//   - MatchingCascade: false
+//   - MatchingStages: nil (a single stage with DistanceFunction and DistanceThreshold)
+//   - RecordMatchHistory: false
+//   - EmbeddingFn: nil
+//   - EmbeddingInterval: 1 (if 0)
+//   - EmbeddingGallerySize: 0 (no gallery)
+//   - Undistorter: nil (no correction)
+//   - IDStore: nil (IDs start at 1)
+//   - Recorder: nil (no recording)
+//   - MotionThresholdScale: 0.0 (fixed threshold)
+//   - MaxMotionThresholdFactor: 3.0 (if 0 and MotionThresholdScale > 0)
+//   - GatingConfidence: 0.0 (disabled)
+//   - InnovationThreshold: 0.0 (disabled)
+//   - RelinkDistanceThreshold: 0.0 (disabled)
+//   - RelinkMaxFrames: 30 (if 0 and RelinkDistanceThreshold > 0)
+//   - RelinkVelocityThreshold: 0.0 (velocities are not compared)
+//   - FrameWidth, FrameHeight: 0 (unknown)
+//   - CoordinateSpace: SpaceUnspecified (learned from the detections)
+//   - ExitLookahead: 0 (disabled)
+//
+// Returns error if the configuration is invalid or the IDStore cannot be loaded.
 func NewTracker(config *TrackerConfig) (*Tracker, error) {
 	if config == nil {
 		return nil, fmt.Errorf("config cannot be nil")
@@ -141,6 +455,101 @@ func NewTracker(config *TrackerConfig) (*Tracker, error) {
 		config.PastDetectionsLength = 4
 	}
 
+	if config.EmbeddingInterval == 0 {
+		config.EmbeddingInterval = 1
+	}
+
+	if config.EmbeddingGallerySize < 0 {
+		return nil, fmt.Errorf("embedding_gallery_size must be >= 0, got %d", config.EmbeddingGallerySize)
+	}
+
+	if config.AmbiguityRatio < 0 || config.AmbiguityRatio >= 1 {
+		return nil, fmt.Errorf("ambiguity_ratio must be in [0, 1), got %f", config.AmbiguityRatio)
+	}
+
+	if config.MaxTrackedObjects < 0 {
+		return nil, fmt.Errorf("max_tracked_objects must be >= 0, got %d", config.MaxTrackedObjects)
+	}
+
+	if config.OutputMinAge < 0 || config.OutputMaxAge < 0 {
+		return nil, fmt.Errorf("output ages must be >= 0, got min %d and max %d", config.OutputMinAge, config.OutputMaxAge)
+	}
+
+	if config.MotionThresholdScale < 0 {
+		return nil, fmt.Errorf("motion_threshold_scale must be >= 0, got %f", config.MotionThresholdScale)
+	}
+
+	if config.MotionThresholdScale > 0 && config.MaxMotionThresholdFactor == 0 {
+		config.MaxMotionThresholdFactor = 3.0
+	}
+
+	if config.MaxMotionThresholdFactor < 0 || (config.MotionThresholdScale > 0 && config.MaxMotionThresholdFactor < 1) {
+		return nil, fmt.Errorf("max_motion_threshold_factor must be >= 1, got %f", config.MaxMotionThresholdFactor)
+	}
+
+	if config.GatingConfidence < 0 || config.GatingConfidence >= 1 {
+		return nil, fmt.Errorf("gating_confidence must be in [0, 1), got %f", config.GatingConfidence)
+	}
+
+	if config.InnovationThreshold < 0 {
+		return nil, fmt.Errorf("innovation_threshold must be >= 0, got %f", config.InnovationThreshold)
+	}
+
+	if config.MaxAspectRatioChange < 0 {
+		return nil, fmt.Errorf("max_aspect_ratio_change must be >= 0, got %f", config.MaxAspectRatioChange)
+	}
+
+	if config.PointsMode < PointsAuto || config.PointsMode > PointsKeypoints {
+		return nil, fmt.Errorf("points_mode must be PointsAuto, PointsBBox or PointsKeypoints, got %d", config.PointsMode)
+	}
+
+	for i, stage := range config.MatchingStages {
+		if stage.DistanceThreshold < 0 {
+			return nil, fmt.Errorf("matching stage %d (%q): distance_threshold must be >= 0, got %f", i, stage.Name, stage.DistanceThreshold)
+		}
+	}
+
+	if config.RelinkDistanceThreshold < 0 || config.RelinkMaxFrames < 0 || config.RelinkVelocityThreshold < 0 {
+		return nil, fmt.Errorf("relink options must be >= 0, got distance %f, max frames %d and velocity %f",
+			config.RelinkDistanceThreshold, config.RelinkMaxFrames, config.RelinkVelocityThreshold)
+	}
+
+	if config.ExitLookahead < 0 {
+		return nil, fmt.Errorf("exit_lookahead must be >= 0, got %d", config.ExitLookahead)
+	}
+	if config.ExitLookahead > 0 && (config.FrameWidth <= 0 || config.FrameHeight <= 0) {
+		return nil, fmt.Errorf("exit_lookahead requires a positive frame size, got %dx%d", config.FrameWidth, config.FrameHeight)
+	}
+
+	coordinates := CoordinateSystem{Space: config.CoordinateSpace}
+	if config.FrameWidth > 0 && config.FrameHeight > 0 {
+		coordinates.FrameWidth, coordinates.FrameHeight = config.FrameWidth, config.FrameHeight
+	}
+	if err := coordinates.Validate(); err != nil {
+		return nil, fmt.Errorf("coordinate_space: %w", err)
+	}
+
+	if config.RelinkDistanceThreshold > 0 && config.RelinkMaxFrames == 0 {
+		config.RelinkMaxFrames = 30
+	}
+
+	if config.StationaryVelocityThreshold > 0 && config.StationaryFrames == 0 {
+		config.StationaryFrames = 10
+	}
+
+	if config.HeadingSmoothing == 0 {
+		config.HeadingSmoothing = 0.3
+	}
+	if config.HeadingSmoothing < 0 || config.HeadingSmoothing > 1 {
+		return nil, fmt.Errorf("heading_smoothing must be in (0, 1], got %f", config.HeadingSmoothing)
+	}
+	if config.HeadingMinSpeed == 0 {
+		config.HeadingMinSpeed = 1.0
+	}
+	if config.HeadingMinSpeed < 0 {
+		return nil, fmt.Errorf("heading_min_speed must be >= 0, got %f", config.HeadingMinSpeed)
+	}
+
 	// Validate configuration
 	if config.PastDetectionsLength < 0 {
 		return nil, fmt.Errorf("past_detections_length must be >= 0, got %d", config.PastDetectionsLength)
@@ -155,11 +564,21 @@ func NewTracker(config *TrackerConfig) (*Tracker, error) {
 	}
 
 	// Create tracker with config and initial state
-	return &Tracker{
-		Config:         config,
-		TrackedObjects: []*TrackedObject{},
-		objFactory:     NewTrackedObjectFactory(),
-	}, nil
+	tracker := &Tracker{
+		Config:            config,
+		TrackedObjects:    []*TrackedObject{},
+		objFactory:        NewTrackedObjectFactory(),
+		distanceThreshold: config.DistanceThreshold,
+		pointsMode:        config.PointsMode,
+		coordinates:       coordinates,
+	}
+	if config.IDStore != nil {
+		tracker.idSaver = newIDSaver(config.IDStore)
+		if err := tracker.restoreIDs(); err != nil {
+			return nil, err
+		}
+	}
+	return tracker, nil
 }
 
 // Update processes detections for the current frame and returns active tracked objects.
@@ -176,21 +595,47 @@ func NewTracker(config *TrackerConfig) (*Tracker, error) {
 //
 // Parameters:
 //   - detections: List of detections for this frame (nil = no detections)
-//   - period: Time period since last update (default: 1)
+//   - period: Number of frames since the last update (default: 1, values < 1 are treated as 1)
 //   - coordTransformations: Coordinate transformation for camera motion (nil = no transformation)
+//
+// Period semantics: when only every n-th frame is processed, pass period=n. Every
+// frame-based quantity then keeps its per-frame meaning:
+//   - the filter predicts n steps, so velocities stay in units per frame
+//   - hit counters, ReID counters and pointwise hit counters decay by n, while a
+//     match still adds 2*n, so HitCounterMax, InitializationDelay and ReidHitCounterMax
+//     are measured in frames rather than updates
+//   - Age and StationaryCount advance by n
+//
+// With period=1 this is exactly norfair's behavior.
 func (t *Tracker) Update(
 	detections []*Detection,
 	period int,
 	coordTransformations CoordinateTransformation,
 ) []*TrackedObject {
+	t.recordUpdate(detections, period, coordTransformations)
+
 	// Handle nil detections
 	if detections == nil {
 		detections = []*Detection{}
 	}
+	if period < 1 {
+		period = 1
+	}
+	if t.Config.RecordMatchHistory {
+		t.indexDetections(detections) // Before skipped detections shift the indices
+	}
+	detections = t.normalizeDetections(detections)
+	t.updateDistanceThreshold(detections, coordTransformations)
+	t.frameNumber += int64(period)
 
 	// =========================================================================
 	// STAGE 1: Coordinate Transformation
 	// =========================================================================
+	if t.Config.Undistorter != nil {
+		for _, det := range detections {
+			t.Config.Undistorter.UndistortDetection(det)
+		}
+	}
 	if coordTransformations != nil {
 		for _, det := range detections {
 			det.UpdateCoordinateTransformation(coordTransformations)
@@ -202,6 +647,7 @@ func (t *Tracker) Update(
 	// =========================================================================
 	var aliveObjects []*TrackedObject
 	var deadObjects []*TrackedObject
+	objectsBefore := t.TrackedObjects
 
 	if t.Config.ReidHitCounterMax == nil {
 		// No ReID: Remove objects with hit_counter < 0
@@ -228,48 +674,50 @@ func (t *Tracker) Update(
 		}
 		t.TrackedObjects = newTrackedObjects
 	}
+	t.archiveRemovedMatchHistories(objectsBefore)
+	t.poolLostObjects(objectsBefore)
+	if t.stats != nil {
+		t.stats.NumDead = len(objectsBefore) - len(t.TrackedObjects)
+	}
 
 	// =========================================================================
 	// STAGE 3: State Prediction
 	// =========================================================================
 	for _, obj := range t.TrackedObjects {
-		obj.TrackerStep() // Decrements counters, increments age, calls filter.predict()
+		obj.trackerStepPeriod(period) // Decrements counters, increments age, calls filter.predict()
 		obj.UpdateCoordinateTransformation(coordTransformations)
 	}
+	t.predictLostObjects(period, coordTransformations)
 
 	// =========================================================================
 	// STAGE 4: Match Initialized Objects
 	// =========================================================================
-	// Filter to non-initializing objects
+	// Filter to non-initializing objects (frozen objects never match)
 	initializedObjects := []*TrackedObject{}
 	for _, obj := range aliveObjects {
-		if !obj.IsInitializing {
+		if !obj.IsInitializing && !obj.Frozen {
 			initializedObjects = append(initializedObjects, obj)
 		}
 	}
 
-	unmatchedDetections, _, unmatchedInitTrackers := t.updateObjectsInPlace(
-		t.Config.DistanceFunction,
-		t.Config.DistanceThreshold,
-		initializedObjects,
-		detections,
-		period,
-	)
+	var unmatchedDetections interface{}
+	var unmatchedInitTrackers []*TrackedObject
+	unmatchedDetections, unmatchedInitTrackers = t.matchStages(initializedObjects, detections, period)
 
 	// =========================================================================
 	// STAGE 5: Match Initializing Objects
 	// =========================================================================
-	// Filter to initializing objects
+	// Filter to initializing objects (frozen objects never match)
 	initializingObjects := []*TrackedObject{}
 	for _, obj := range aliveObjects {
-		if obj.IsInitializing {
+		if obj.IsInitializing && !obj.Frozen {
 			initializingObjects = append(initializingObjects, obj)
 		}
 	}
 
 	unmatchedDetections, matchedNotInitTrackers, _ := t.updateObjectsInPlace(
 		t.Config.DistanceFunction,
-		t.Config.DistanceThreshold,
+		t.distanceThreshold,
 		initializingObjects,
 		unmatchedDetections,
 		period,
@@ -279,8 +727,13 @@ func (t *Tracker) Update(
 	// STAGE 6: ReID Matching
 	// =========================================================================
 	if t.Config.ReidDistanceFunction != nil {
-		// Combine unmatched initialized objects with dead objects
-		reidCandidates := append(unmatchedInitTrackers, deadObjects...)
+		// Combine unmatched initialized objects with dead objects (frozen objects never match)
+		reidCandidates := append([]*TrackedObject{}, unmatchedInitTrackers...)
+		for _, obj := range deadObjects {
+			if !obj.Frozen {
+				reidCandidates = append(reidCandidates, obj)
+			}
+		}
 
 		t.updateObjectsInPlace(
 			t.Config.ReidDistanceFunction,
@@ -301,7 +754,22 @@ func (t *Tracker) Update(
 		unmatchedDets = []*Detection{}
 	}
 
+	// Objects that finished initializing in this update (and were not merged by ReID)
+	var newlyInitialized []*TrackedObject
+	if t.Config.RelinkDistanceThreshold > 0 {
+		tracked := make(map[*TrackedObject]bool, len(t.TrackedObjects))
+		for _, obj := range t.TrackedObjects {
+			tracked[obj] = true
+		}
+		for _, obj := range initializingObjects {
+			if !obj.IsInitializing && tracked[obj] {
+				newlyInitialized = append(newlyInitialized, obj)
+			}
+		}
+	}
+
 	for _, detection := range unmatchedDets {
+		t.embedDetection(detection, nil)
 		newObj, err := NewTrackedObject(
 			t.objFactory,
 			detection,
@@ -314,13 +782,49 @@ func (t *Tracker) Update(
 			fmt.Printf("Warning: failed to create tracked object: %v\n", err)
 			continue
 		}
+		if detection.Embedding != nil && t.frame != nil {
+			newObj.embeddingFrame = t.frameNumber
+		}
 		t.TrackedObjects = append(t.TrackedObjects, newObj)
+		t.recordMatch(newObj, detection)
+		if t.stats != nil {
+			t.stats.NumNew++
+		}
+		if newObj.ID != nil {
+			newlyInitialized = append(newlyInitialized, newObj)
+		}
+	}
+
+	// Motion-only re-linking of recently lost objects
+	if t.Config.RelinkDistanceThreshold > 0 {
+		t.relinkObjects(newlyInitialized)
 	}
 
+	// Terminate objects leaving the frame
+	if t.Config.ExitLookahead > 0 {
+		objectsBefore = t.TrackedObjects
+		t.terminateExitingObjects()
+		t.archiveRemovedMatchHistories(objectsBefore)
+		if t.stats != nil {
+			t.stats.NumExited = len(objectsBefore) - len(t.TrackedObjects)
+		}
+	}
+
+	// Bounded memory mode
+	evictionsBefore := t.evictions
+	objectsBefore = t.TrackedObjects
+	t.evictExcessObjects()
+	t.archiveRemovedMatchHistories(objectsBefore)
+	if t.stats != nil {
+		t.stats.NumEvicted = t.evictions - evictionsBefore
+	}
+
+	t.autoSaveIDs()
+
 	// =========================================================================
 	// STAGE 8: Return Active Objects
 	// =========================================================================
-	return t.GetActiveObjects()
+	return t.filterOutputObjects(t.GetActiveObjects())
 }
 
 // updateObjectsInPlace matches candidates to objects and updates them in place.
@@ -372,29 +876,50 @@ func (t *Tracker) updateObjectsInPlace(
 		return candidates, []*TrackedObject{}, objects
 	}
 
-	// Compute distance matrix
-	distanceMatrix := distanceFunction.GetDistances(objects, candList)
+	// Compute distance matrix (aligning point sets of mismatched pairs if enabled)
+	distanceStart := time.Now()
+	var distanceMatrix mat.Matrix
+	var denseDistances *mat.Dense
+	var sparseDistances *SparseDistances
+	var alignedDetections [][]*Detection
+	dets, isDetections := candList.([]*Detection)
+	switch {
+	case isDetections && t.Config.PointMatchingThreshold > 0 && hasPointCountMismatch(objects, dets):
+		denseDistances, alignedDetections = t.alignedDistances(distanceFunction, objects, dets)
+	case isDetections && t.Config.SparseMatching:
+		sparseDistances = getSparseDistances(distanceFunction, objects, dets, distanceThreshold)
+	default:
+		denseDistances = getDistances(distanceFunction, objects, candList)
+	}
+	if isDetections && t.Config.GatingConfidence > 0 {
+		if sparseDistances != nil {
+			t.applySparseGating(sparseDistances, objects, dets)
+		} else {
+			t.applyGating(denseDistances, objects, dets, alignedDetections)
+		}
+	}
+	if t.stats != nil {
+		t.stats.DistanceTime += time.Since(distanceStart)
+	}
 
 	// Validate for NaN
-	err := ValidateDistanceMatrix(distanceMatrix)
+	var err error
+	if sparseDistances != nil {
+		distanceMatrix = sparseDistances
+		err = sparseDistances.Validate()
+	} else {
+		distanceMatrix = denseDistances
+		err = ValidateDistanceMatrix(denseDistances)
+	}
 	if err != nil {
 		panic(fmt.Sprintf("distance function error: %v", err))
 	}
 
 	// Store minimum distances for debugging
-	rows, cols := distanceMatrix.Dims()
-	for i := 0; i < cols; i++ {
+	for i, minVal := range columnMinima(distanceMatrix) {
 		if i >= len(objects) {
 			break
 		}
-		// Find minimum in column i
-		minVal := math.Inf(1)
-		for j := 0; j < rows; j++ {
-			val := distanceMatrix.At(j, i)
-			if val < minVal {
-				minVal = val
-			}
-		}
 		if minVal < distanceThreshold {
 			objects[i].CurrentMinDistance = &minVal
 		} else {
@@ -403,7 +928,16 @@ func (t *Tracker) updateObjectsInPlace(
 	}
 
 	// Greedy matching
-	matchedCandIndices, matchedObjIndices := MatchDetectionsAndObjects(distanceMatrix, distanceThreshold)
+	assignmentStart := time.Now()
+	var matchedCandIndices, matchedObjIndices []int
+	if sparseDistances != nil {
+		matchedCandIndices, matchedObjIndices = MatchSparseDetectionsAndObjects(sparseDistances, distanceThreshold)
+	} else {
+		matchedCandIndices, matchedObjIndices = MatchDetectionsAndObjects(denseDistances, distanceThreshold)
+	}
+	if t.stats != nil {
+		t.stats.AssignmentTime += time.Since(assignmentStart)
+	}
 
 	// Process matches
 	if len(matchedCandIndices) > 0 {
@@ -415,6 +949,17 @@ func (t *Tracker) updateObjectsInPlace(
 			matchedObjSet[matchedObjIndices[i]] = true
 		}
 
+		// Defer ambiguous detection matches; their hypotheses don't start new objects
+		var deferredMatches map[int][]int
+		if _, ok := candList.([]*Detection); ok && t.Config.AmbiguityRatio > 0 {
+			deferredMatches = t.findAmbiguousMatches(distanceMatrix, distanceThreshold, objects, matchedCandIndices, matchedObjIndices)
+			for _, hypotheses := range deferredMatches {
+				for _, candIdx := range hypotheses {
+					matchedCandSet[candIdx] = true
+				}
+			}
+		}
+
 		// Separate unmatched candidates and objects
 		var unmatchedCandList []interface{}
 		var unmatchedObjList []*TrackedObject
@@ -455,11 +1000,43 @@ func (t *Tracker) updateObjectsInPlace(
 				// Check candidate type
 				switch cands := candList.(type) {
 				case []*Detection:
-					// Candidate is Detection - update object
+					if hypotheses, ok := deferredMatches[objIdx]; ok {
+						// Ambiguous - coast this frame and keep the hypotheses
+						matchedObject.Hypotheses = make([]*Detection, len(hypotheses))
+						for h, hypIdx := range hypotheses {
+							matchedObject.Hypotheses[h] = cands[hypIdx]
+						}
+						continue
+					}
+
 					matchedCandidate := cands[candIdx]
+					if alignedDetections != nil && alignedDetections[candIdx][objIdx] != nil {
+						matchedCandidate = alignedDetections[candIdx][objIdx]
+					}
+					if t.isInnovationOutlier(matchedObject, matchedCandidate) {
+						// Gross outlier - coast this frame instead of correcting
+						unmatchedCandidates = append(unmatchedCandidates.([]*Detection), cands[candIdx])
+						unmatchedObjList = append(unmatchedObjList, matchedObject)
+						if t.stats != nil {
+							t.stats.NumRejected++
+						}
+						continue
+					}
+
+					// Candidate is Detection - update object
+					t.embedDetection(cands[candIdx], matchedObject)
+					if matchedCandidate != cands[candIdx] {
+						matchedCandidate.Embedding = cands[candIdx].Embedding
+					}
 					matchedObject.Hit(matchedCandidate, period)
 					matchedObject.LastDistance = &distance
+					matchedObject.addMatchDistance(distance)
 					matchedObjList = append(matchedObjList, matchedObject)
+					t.recordMatch(matchedObject, cands[candIdx])
+					if t.stats != nil {
+						t.stats.NumMatched++
+						t.stats.matchDistanceSum += distance
+					}
 
 				case []*TrackedObject:
 					// Candidate is TrackedObject - merge (ReID case)
@@ -468,6 +1045,9 @@ func (t *Tracker) updateObjectsInPlace(
 
 					// Remove matched candidate from tracker's object list
 					t.removeTrackedObject(matchedCandidate)
+					if t.stats != nil {
+						t.stats.NumReidMatched++
+					}
 				}
 			} else {
 				// Distance >= threshold - add to unmatched
@@ -488,6 +1068,333 @@ func (t *Tracker) updateObjectsInPlace(
 	return candidates, []*TrackedObject{}, objects
 }
 
+// normalizeDetections silently drops ignored detections (see Detection.Ignore), and
+// drops detections that would otherwise panic deep inside the distance functions
+// or the filter, warning once per kind of problem:
+//   - invalid detections (see Detection.Validate)
+//   - detections not conforming to TrackerConfig.PointsMode
+//   - detections whose coordinate system is not compatible with the tracker's
+//     (see Tracker.Coordinates)
+//   - detections whose shape differs from the tracked objects, or the first
+//     detection, with the same label and class ID (only the point dimension
+//     must match when PointMatchingThreshold allows partial detections)
+//   - exact duplicates: the same detection passed twice, or a detection with
+//     the same label, class ID, points and scores as an earlier one
+//
+// Detections missing AbsolutePoints get a copy of their Points. The input slice
+// is never modified; a new slice is only allocated when a detection is dropped.
+func (t *Tracker) normalizeDetections(detections []*Detection) []*Detection {
+	shapes := make(map[labelKey][2]int)
+	for _, obj := range t.TrackedObjects {
+		shapes[newLabelKey(obj.Label, obj.ClassID)] = [2]int{obj.NumPoints, obj.DimPoints}
+	}
+	seen := make(map[*Detection]bool, len(detections))
+	firstPoints := make(map[[2]float64][]*Detection, len(detections))
+
+	var normalized []*Detection
+	skip := func(i int) {
+		if normalized == nil {
+			normalized = append(make([]*Detection, 0, len(detections)), detections[:i]...)
+		}
+	}
+	drop := func(i int, reason string) {
+		WarnOnce(fmt.Sprintf("Tracker.Update skipped detections: %s", reason))
+		skip(i)
+	}
+
+	for i, det := range detections {
+		if det != nil && det.Ignore {
+			skip(i) // Silently, the caller asked for it
+			continue
+		}
+		if err := det.Validate(); err != nil {
+			drop(i, err.Error())
+			continue
+		}
+		if seen[det] {
+			drop(i, "the same detection was passed more than once")
+			continue
+		}
+		if err := t.checkPointsMode(det); err != nil {
+			drop(i, err.Error())
+			continue
+		}
+		if err := t.checkCoordinates(det); err != nil {
+			drop(i, err.Error())
+			continue
+		}
+
+		rows, cols := det.Points.Dims()
+		key := newLabelKey(det.Label, det.ClassID)
+		if shape, ok := shapes[key]; !ok {
+			shapes[key] = [2]int{rows, cols}
+		} else if shape[1] != cols || (shape[0] != rows && t.Config.PointMatchingThreshold <= 0) {
+			drop(i, fmt.Sprintf("got %s, which does not match %s of other objects with the same label",
+				describeShape(rows, cols), describeShape(shape[0], shape[1])))
+			continue
+		}
+
+		first := [2]float64{det.Points.At(0, 0), det.Points.At(0, 1)}
+		if isDuplicateDetection(det, firstPoints[first]) {
+			drop(i, "exact duplicate detections")
+			continue
+		}
+
+		seen[det] = true
+		firstPoints[first] = append(firstPoints[first], det)
+		if det.AbsolutePoints == nil {
+			det.AbsolutePoints = mat.DenseCopyOf(det.Points)
+		}
+		if normalized != nil {
+			normalized = append(normalized, det)
+		}
+	}
+
+	if normalized == nil {
+		normalized = detections
+	}
+	if t.pointsMode == PointsAuto && len(normalized) > 0 {
+		t.pointsMode = detectPointsMode(normalized)
+	}
+	return normalized
+}
+
+// isDuplicateDetection reports whether det has the same label, class ID, points
+// and scores as one of the candidates.
+func isDuplicateDetection(det *Detection, candidates []*Detection) bool {
+	for _, other := range candidates {
+		if newLabelKey(det.Label, det.ClassID) != newLabelKey(other.Label, other.ClassID) {
+			continue
+		}
+		if !mat.Equal(det.Points, other.Points) || len(det.Scores) != len(other.Scores) {
+			continue
+		}
+		equal := true
+		for j := range det.Scores {
+			if det.Scores[j] != other.Scores[j] {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateWithFrame is Update with the current frame, used by TrackerConfig.EmbeddingFn
+// to compute the embeddings of new and matched detections.
+//
+// Parameters:
+//   - frame: Frame the detections were made on
+//   - detections, period, coordTransformations: Same as Update
+func (t *Tracker) UpdateWithFrame(
+	frame Frame,
+	detections []*Detection,
+	period int,
+	coordTransformations CoordinateTransformation,
+) []*TrackedObject {
+	t.frame = &frame
+	defer func() { t.frame = nil }()
+
+	return t.Update(detections, period, coordTransformations)
+}
+
+// embedDetection sets a detection's embedding with TrackerConfig.EmbeddingFn, unless
+// it has one, there is no frame, or obj (nil for new objects) was embedded recently.
+func (t *Tracker) embedDetection(detection *Detection, obj *TrackedObject) {
+	if t.Config.EmbeddingFn == nil || t.frame == nil || detection.Embedding != nil {
+		return
+	}
+	if obj != nil && obj.embeddingFrame > 0 && t.frameNumber-obj.embeddingFrame < int64(t.Config.EmbeddingInterval) {
+		return
+	}
+
+	embedding := t.Config.EmbeddingFn(*t.frame, detection)
+	if embedding == nil {
+		return
+	}
+	detection.Embedding = make([]float64, len(embedding))
+	for i, v := range embedding {
+		detection.Embedding[i] = float64(v)
+	}
+	if obj != nil {
+		obj.embeddingFrame = t.frameNumber
+	}
+}
+
+// UpdateWithStats is Update, additionally returning statistics about the update,
+// so applications and tests can inspect tracker behavior per frame.
+//
+// Returns:
+//   - activeObjects: Same as Update
+//   - stats: Counts, mean match distance and timings of this update
+func (t *Tracker) UpdateWithStats(
+	detections []*Detection,
+	period int,
+	coordTransformations CoordinateTransformation,
+) ([]*TrackedObject, FrameStats) {
+	t.stats = &FrameStats{}
+	defer func() { t.stats = nil }()
+
+	activeObjects := t.Update(detections, period, coordTransformations)
+
+	stats := t.stats
+	for _, obj := range t.TrackedObjects {
+		if obj.IsInitializing {
+			stats.NumInitializing++
+		}
+	}
+	stats.NumActive = len(t.GetActiveObjects())
+	if stats.NumMatched > 0 {
+		stats.MeanMatchDistance = stats.matchDistanceSum / float64(stats.NumMatched)
+	}
+	return activeObjects, *stats
+}
+
+// UpdateContext is Update, but returns ctx.Err() without updating the tracker if
+// the context is already cancelled, so service handlers can stop mid-sequence.
+func (t *Tracker) UpdateContext(
+	ctx context.Context,
+	detections []*Detection,
+	period int,
+	coordTransformations CoordinateTransformation,
+) ([]*TrackedObject, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return t.Update(detections, period, coordTransformations), nil
+}
+
+// Run updates the tracker with every frame of detections received from frames (e.g.
+// DetectionFileParser.Detections()) until the channel is closed, the context is
+// cancelled or onFrame returns an error, for cancellable batch evaluation.
+// Frames are numbered by counting; use RunFrames when frames may be skipped.
+//
+// Parameters:
+//   - ctx: Cancels the run between frames
+//   - frames: Detections of consecutive frames (period 1, no camera motion)
+//   - onFrame: Called with the 1-indexed frame number and the active objects after
+//     each update (nil = ignore results)
+//
+// Returns: ctx.Err() if cancelled, the error of onFrame, or nil once frames is closed
+func (t *Tracker) Run(
+	ctx context.Context,
+	frames <-chan []*Detection,
+	onFrame func(frameNumber int64, activeObjects []*TrackedObject) error,
+) error {
+	for frameNumber := int64(1); ; frameNumber++ {
+		var detections []*Detection
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case dets, ok := <-frames:
+			if !ok {
+				return nil
+			}
+			detections = dets
+		}
+
+		activeObjects, err := t.UpdateContext(ctx, detections, 1, nil)
+		if err != nil {
+			return err
+		}
+		if onFrame != nil {
+			if err := onFrame(frameNumber, activeObjects); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RunFrames is Run for numbered frames (e.g. DetectionFileParser.Frames()): the
+// period of each update is the difference to the previous frame number, so gaps in
+// the numbering are handled as skipped frames.
+//
+// Parameters:
+//   - ctx: Cancels the run between frames
+//   - frames: Numbered frames in increasing order (no camera motion)
+//   - onFrame: Called with the frame number and the active objects after each
+//     update (nil = ignore results)
+//
+// Returns: ctx.Err() if cancelled, the error of onFrame, or nil once frames is closed
+func (t *Tracker) RunFrames(
+	ctx context.Context,
+	frames <-chan DetectionFrame,
+	onFrame func(frameNumber int64, activeObjects []*TrackedObject) error,
+) error {
+	previous := int64(0)
+	for {
+		var frame DetectionFrame
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case f, ok := <-frames:
+			if !ok {
+				return nil
+			}
+			frame = f
+		}
+
+		period := 1
+		if previous > 0 && frame.Number-previous > 1 {
+			period = int(frame.Number - previous)
+		}
+		previous = frame.Number
+
+		activeObjects, err := t.UpdateContext(ctx, frame.Detections, period, nil)
+		if err != nil {
+			return err
+		}
+		if onFrame != nil {
+			if err := onFrame(frame.Number, activeObjects); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// matchCascade matches detections to objects level by level, the most recently hit
+// objects first (see TrackerConfig.MatchingCascade).
+//
+// Returns:
+//   - unmatchedDetections: Detections not matched at any level
+//   - unmatchedObjects: Objects not matched at their level
+func (t *Tracker) matchCascade(
+	distanceFunction Distance,
+	distanceThreshold float64,
+	objects []*TrackedObject,
+	detections []*Detection,
+	period int,
+) ([]*Detection, []*TrackedObject) {
+	levels := map[int64][]*TrackedObject{}
+	var levelKeys []int64
+	for _, obj := range objects {
+		if _, ok := levels[obj.FramesSinceHit]; !ok {
+			levelKeys = append(levelKeys, obj.FramesSinceHit)
+		}
+		levels[obj.FramesSinceHit] = append(levels[obj.FramesSinceHit], obj)
+	}
+	slices.Sort(levelKeys)
+
+	unmatchedDetections := detections
+	unmatchedObjects := []*TrackedObject{}
+	for _, key := range levelKeys {
+		remaining, _, unmatched := t.updateObjectsInPlace(
+			distanceFunction,
+			distanceThreshold,
+			levels[key],
+			unmatchedDetections,
+			period,
+		)
+		unmatchedDetections = remaining.([]*Detection)
+		unmatchedObjects = append(unmatchedObjects, unmatched...)
+	}
+
+	return unmatchedDetections, unmatchedObjects
+}
+
 // CurrentObjectCount returns the number of currently active objects.
 func (t *Tracker) CurrentObjectCount() int {
 	return len(t.GetActiveObjects())
@@ -498,6 +1405,11 @@ func (t *Tracker) TotalObjectCount() int {
 	return t.objFactory.Count()
 }
 
+// EvictionCount returns the total number of objects evicted by MaxTrackedObjects.
+func (t *Tracker) EvictionCount() int {
+	return t.evictions
+}
+
 // GetActiveObjects returns objects that are not initializing and have positive hit counter.
 func (t *Tracker) GetActiveObjects() []*TrackedObject {
 	activeObjects := []*TrackedObject{}
@@ -509,6 +1421,245 @@ func (t *Tracker) GetActiveObjects() []*TrackedObject {
 	return activeObjects
 }
 
+// AllObjects returns every object the tracker holds: active, initializing and dead
+// objects waiting for ReID. The returned slice is a new copy that later updates do
+// not change, but the objects themselves are live and keep being updated.
+func (t *Tracker) AllObjects() []*TrackedObject {
+	return append([]*TrackedObject{}, t.TrackedObjects...)
+}
+
+// AliveObjects returns objects with a positive hit counter, initializing or not,
+// including those not matched in the latest update (see AllObjects for snapshot
+// semantics). Unlike Update's return value it ignores the output filters.
+func (t *Tracker) AliveObjects() []*TrackedObject {
+	aliveObjects := []*TrackedObject{}
+	for _, obj := range t.TrackedObjects {
+		if obj.HitCounterIsPositive() {
+			aliveObjects = append(aliveObjects, obj)
+		}
+	}
+	return aliveObjects
+}
+
+// InitializingObjects returns alive objects that have not yet passed the
+// InitializationDelay and therefore have no ID (see AllObjects for snapshot semantics).
+func (t *Tracker) InitializingObjects() []*TrackedObject {
+	initializingObjects := []*TrackedObject{}
+	for _, obj := range t.TrackedObjects {
+		if obj.IsInitializing && obj.HitCounterIsPositive() {
+			initializingObjects = append(initializingObjects, obj)
+		}
+	}
+	return initializingObjects
+}
+
+// findAmbiguousMatches returns the matches to defer in multi-hypothesis mode, as a map
+// from object index to the candidate indices kept as hypotheses (matched one first).
+func (t *Tracker) findAmbiguousMatches(
+	distanceMatrix mat.Matrix,
+	distanceThreshold float64,
+	objects []*TrackedObject,
+	matchedCandIndices, matchedObjIndices []int,
+) map[int][]int {
+	rows, cols := distanceMatrix.Dims()
+	deferred := map[int][]int{}
+
+	for k := range matchedCandIndices {
+		candIdx, objIdx := matchedCandIndices[k], matchedObjIndices[k]
+		if objects[objIdx].deferred {
+			continue
+		}
+		distance := distanceMatrix.At(candIdx, objIdx)
+
+		// Runner-up detection for this object
+		runnerUpCand, runnerUpCandDistance := -1, math.Inf(1)
+		for c := 0; c < rows; c++ {
+			if d := distanceMatrix.At(c, objIdx); c != candIdx && d < distanceThreshold && d < runnerUpCandDistance {
+				runnerUpCand, runnerUpCandDistance = c, d
+			}
+		}
+		if runnerUpCand >= 0 && distance >= t.Config.AmbiguityRatio*runnerUpCandDistance {
+			deferred[objIdx] = []int{candIdx, runnerUpCand}
+			continue
+		}
+
+		// Runner-up object for this detection
+		for o := 0; o < cols; o++ {
+			if d := distanceMatrix.At(candIdx, o); o != objIdx && d < distanceThreshold && distance >= t.Config.AmbiguityRatio*d {
+				deferred[objIdx] = []int{candIdx}
+				break
+			}
+		}
+	}
+
+	return deferred
+}
+
+// evictExcessObjects drops objects beyond MaxTrackedObjects in eviction order
+// (see TrackerConfig.MaxTrackedObjects), preserving the order of the rest.
+func (t *Tracker) evictExcessObjects() {
+	excess := len(t.TrackedObjects) - t.Config.MaxTrackedObjects
+	if t.Config.MaxTrackedObjects <= 0 || excess <= 0 {
+		return
+	}
+
+	evictionGroup := func(obj *TrackedObject) int {
+		switch {
+		case !obj.HitCounterIsPositive():
+			return 0
+		case obj.IsInitializing:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	// Frozen objects never expire, so they are not evicted either
+	candidates := make([]*TrackedObject, 0, len(t.TrackedObjects))
+	for _, obj := range t.TrackedObjects {
+		if !obj.Frozen {
+			candidates = append(candidates, obj)
+		}
+	}
+	excess = min(excess, len(candidates))
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if groupA, groupB := evictionGroup(a), evictionGroup(b); groupA != groupB {
+			return groupA < groupB
+		}
+		if scoreA, scoreB := lastDetectionScore(a), lastDetectionScore(b); scoreA != scoreB {
+			return scoreA < scoreB
+		}
+		return a.Age < b.Age
+	})
+
+	evicted := make(map[*TrackedObject]bool, excess)
+	for _, obj := range candidates[:excess] {
+		evicted[obj] = true
+	}
+
+	kept := make([]*TrackedObject, 0, t.Config.MaxTrackedObjects)
+	for _, obj := range t.TrackedObjects {
+		if !evicted[obj] {
+			kept = append(kept, obj)
+		}
+	}
+	t.TrackedObjects = kept
+	t.evictions += excess
+}
+
+// lastDetectionScore returns the mean score of an object's last detection (1.0 if unscored).
+func lastDetectionScore(obj *TrackedObject) float64 {
+	return meanDetectionScore(obj.LastDetection)
+}
+
+// filterOutputObjects applies the Output* config options to the objects returned by Update.
+func (t *Tracker) filterOutputObjects(objects []*TrackedObject) []*TrackedObject {
+	if !t.Config.OutputOnlyMatched && t.Config.OutputMinAge <= 0 && t.Config.OutputMaxAge <= 0 {
+		return objects
+	}
+
+	filtered := []*TrackedObject{}
+	for _, obj := range objects {
+		if t.Config.OutputOnlyMatched && !obj.IsMatched() {
+			continue
+		}
+		if t.Config.OutputMinAge > 0 && obj.Age < int64(t.Config.OutputMinAge) {
+			continue
+		}
+		if t.Config.OutputMaxAge > 0 && obj.Age > int64(t.Config.OutputMaxAge) {
+			continue
+		}
+		filtered = append(filtered, obj)
+	}
+	return filtered
+}
+
+// =============================================================================
+// Manual Track Control
+// =============================================================================
+
+// RemoveObject deletes the tracked object with the given permanent ID.
+//
+// The ID is not reused; a new detection at the same location creates a new object.
+//
+// Returns: Error if no tracked object has that ID
+func (t *Tracker) RemoveObject(id int) error {
+	obj := t.findObjectByID(id)
+	if obj == nil {
+		return fmt.Errorf("tracked object with id %d not found", id)
+	}
+	t.removeTrackedObject(obj)
+	t.archiveRemovedMatchHistories([]*TrackedObject{obj})
+	return nil
+}
+
+// FreezeObject freezes the tracked object with the given permanent ID.
+//
+// A frozen object keeps predicting its position but is never matched to
+// detections (nor ReID-matched while dead), and its hit counters are held so it
+// does not expire. It is not evicted by MaxTrackedObjects either. Detections
+// it would have matched are free to match other objects or start new ones.
+//
+// Returns: Error if no tracked object has that ID
+func (t *Tracker) FreezeObject(id int) error {
+	obj := t.findObjectByID(id)
+	if obj == nil {
+		return fmt.Errorf("tracked object with id %d not found", id)
+	}
+	obj.Frozen = true
+	return nil
+}
+
+// UnfreezeObject resumes matching for a previously frozen object.
+//
+// Returns: Error if no tracked object has that ID
+func (t *Tracker) UnfreezeObject(id int) error {
+	obj := t.findObjectByID(id)
+	if obj == nil {
+		return fmt.Errorf("tracked object with id %d not found", id)
+	}
+	obj.Frozen = false
+	return nil
+}
+
+// SetLabel changes the label of the tracked object with the given permanent ID.
+//
+// The new label takes effect on the next Update, so label-aware distances only
+// match it against detections with the new label.
+//
+// Returns: Error if no tracked object has that ID
+func (t *Tracker) SetLabel(id int, label string) error {
+	obj := t.findObjectByID(id)
+	if obj == nil {
+		return fmt.Errorf("tracked object with id %d not found", id)
+	}
+	obj.Label = &label
+	return nil
+}
+
+// SetClassID changes the integer class ID of the tracked object with the given permanent ID.
+//
+// Returns: Error if no tracked object has that ID
+func (t *Tracker) SetClassID(id int, classID int) error {
+	obj := t.findObjectByID(id)
+	if obj == nil {
+		return fmt.Errorf("tracked object with id %d not found", id)
+	}
+	obj.ClassID = &classID
+	return nil
+}
+
+// findObjectByID returns the tracked object with the given permanent ID, or nil.
+func (t *Tracker) findObjectByID(id int) *TrackedObject {
+	for _, obj := range t.TrackedObjects {
+		if obj.ID != nil && *obj.ID == id {
+			return obj
+		}
+	}
+	return nil
+}
+
 // removeTrackedObject removes a tracked object from the tracker's list.
 // This is used during ReID merging.
 func (t *Tracker) removeTrackedObject(objToRemove *TrackedObject) {
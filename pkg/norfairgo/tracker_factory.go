@@ -80,6 +80,13 @@ func (f *TrackedObjectFactory) GetIDs() (id int, globalID int) {
 	return instanceID, gID
 }
 
+// restoreCount continues the instance-level IDs after count (see TrackerConfig.IDStore).
+func (f *TrackedObjectFactory) restoreCount(count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.count = max(f.count, count)
+}
+
 // Count returns the current instance-level counter value.
 // This represents the total number of objects that have been fully initialized
 // by this factory instance.
@@ -107,6 +114,14 @@ func GlobalCount() int {
 	return globalCount
 }
 
+// raiseGlobalCount continues the global IDs after count if they are not already
+// past it (see TrackerConfig.IDStore).
+func raiseGlobalCount(count int) {
+	globalCountMutex.Lock()
+	globalCount = max(globalCount, count)
+	globalCountMutex.Unlock()
+}
+
 // ResetGlobalCount resets the global counter to zero.
 // This should typically only be used in tests.
 func ResetGlobalCount() {
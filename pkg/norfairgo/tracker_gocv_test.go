@@ -0,0 +1,63 @@
+//go:build !nogocv
+
+package norfairgo
+
+import (
+	"testing"
+
+	"gocv.io/x/gocv"
+)
+
+func TestTracker_EmbeddingFn(t *testing.T) {
+	calls := 0
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20.0,
+		HitCounterMax:       10,
+		InitializationDelay: 0,
+		EmbeddingFn: func(frame gocv.Mat, detection *Detection) []float32 {
+			calls++
+			return []float32{float32(frame.Cols()), float32(detection.Points.At(0, 0))}
+		},
+		EmbeddingInterval: 3,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	frame := gocv.NewMatWithSize(10, 20, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	// New detection: embedded
+	first := newPointDetection(t, 0, 0)
+	tracker.UpdateWithFrame(frame, []*Detection{first}, 1, nil)
+	if calls != 1 || len(first.Embedding) != 2 || first.Embedding[0] != 20 {
+		t.Fatalf("Expected the new detection to be embedded, calls=%d embedding=%v", calls, first.Embedding)
+	}
+
+	// Matched detections: embedded every EmbeddingInterval frames only
+	var embedded []bool
+	for i := 1; i <= 6; i++ {
+		det := newPointDetection(t, float64(i), 0)
+		tracker.UpdateWithFrame(frame, []*Detection{det}, 1, nil)
+		embedded = append(embedded, det.Embedding != nil)
+	}
+	expected := []bool{false, false, true, false, false, true}
+	for i := range expected {
+		if embedded[i] != expected[i] {
+			t.Errorf("Frame %d: expected embedded=%v, got %v", i+2, expected[i], embedded[i])
+		}
+	}
+
+	// Provided embeddings and plain Update don't call the hook
+	callsBefore := calls
+	provided := newPointDetection(t, 7, 0)
+	provided.Embedding = []float64{1}
+	tracker.UpdateWithFrame(frame, []*Detection{provided, newPointDetection(t, 500, 500)}, 1, nil)
+	if calls != callsBefore+1 || provided.Embedding[0] != 1 {
+		t.Errorf("Expected only the new detection without embedding to be embedded")
+	}
+	tracker.Update([]*Detection{newPointDetection(t, 900, 900)}, 1, nil)
+	if calls != callsBefore+1 {
+		t.Error("Expected Update without a frame not to call EmbeddingFn")
+	}
+}
@@ -0,0 +1,28 @@
+//go:build nogocv
+
+package norfairgo
+
+import (
+	"image"
+	"testing"
+)
+
+func TestTracker_EmbeddingFnImage(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20.0,
+		InitializationDelay: 0,
+		EmbeddingFn: func(frame Frame, detection *Detection) []float32 {
+			return []float32{float32(frame.Bounds().Dx())}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	det := newPointDetection(t, 0, 0)
+	tracker.UpdateWithFrame(image.NewRGBA(image.Rect(0, 0, 20, 10)), []*Detection{det}, 1, nil)
+	if len(det.Embedding) != 1 || det.Embedding[0] != 20 {
+		t.Errorf("Expected the detection to be embedded from the image, got %v", det.Embedding)
+	}
+}
@@ -1,7 +1,11 @@
 package norfairgo
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
 	"testing"
 
 	"gonum.org/v1/gonum/mat"
@@ -515,3 +519,1022 @@ func TestTracker_CameraMotion(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+// =============================================================================
+// Manual Track Control Tests
+// =============================================================================
+
+// newManualControlTracker creates a tracker with one active object at (10, 20)
+func newManualControlTracker(t *testing.T) (*Tracker, int) {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   100.0,
+		HitCounterMax:       5,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	var active []*TrackedObject
+	for i := 0; i < 2; i++ {
+		active = tracker.Update([]*Detection{newPointDetection(t, 10, 20)}, 1, nil)
+	}
+	if len(active) != 1 || active[0].ID == nil {
+		t.Fatalf("Expected 1 active object, got %d", len(active))
+	}
+	return tracker, *active[0].ID
+}
+
+// mustNewTracker creates a tracker, failing the test if the config is invalid.
+func mustNewTracker(t *testing.T, config *TrackerConfig) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(config)
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	return tracker
+}
+
+// newPointDetection creates a single-point detection
+func newPointDetection(t *testing.T, x, y float64) *Detection {
+	t.Helper()
+	detection, err := NewDetection(mat.NewDense(1, 2, []float64{x, y}), nil)
+	if err != nil {
+		t.Fatalf("Failed to create detection: %v", err)
+	}
+	return detection
+}
+
+func TestTracker_RemoveObject(t *testing.T) {
+	tracker, id := newManualControlTracker(t)
+
+	if err := tracker.RemoveObject(id); err != nil {
+		t.Fatalf("RemoveObject failed: %v", err)
+	}
+	if len(tracker.TrackedObjects) != 0 {
+		t.Errorf("Expected no tracked objects after removal, got %d", len(tracker.TrackedObjects))
+	}
+	if err := tracker.RemoveObject(id); err == nil {
+		t.Error("Expected error when removing an unknown object")
+	}
+
+	// A new detection at the same place starts a new object with a new ID
+	var active []*TrackedObject
+	for i := 0; i < 2; i++ {
+		active = tracker.Update([]*Detection{newPointDetection(t, 10, 20)}, 1, nil)
+	}
+	if len(active) != 1 || *active[0].ID == id {
+		t.Errorf("Expected a new object with a new ID, got %d objects", len(active))
+	}
+}
+
+func TestTracker_FreezeObject(t *testing.T) {
+	tracker, id := newManualControlTracker(t)
+	frozen := tracker.findObjectByID(id)
+
+	if err := tracker.FreezeObject(id); err != nil {
+		t.Fatalf("FreezeObject failed: %v", err)
+	}
+	hitCounter := frozen.HitCounter
+
+	// The detection must not be matched to the frozen object, which also must not expire
+	for i := 0; i < 10; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 10, 20)}, 1, nil)
+	}
+	if frozen.HitCounter != hitCounter {
+		t.Errorf("Expected frozen hit counter %d to be held, got %d", hitCounter, frozen.HitCounter)
+	}
+	if tracker.findObjectByID(id) == nil {
+		t.Fatal("Frozen object should not expire")
+	}
+	if len(tracker.TrackedObjects) != 2 {
+		t.Errorf("Expected the detection to start a second object, got %d objects", len(tracker.TrackedObjects))
+	}
+
+	if err := tracker.UnfreezeObject(id); err != nil {
+		t.Fatalf("UnfreezeObject failed: %v", err)
+	}
+	if frozen.Frozen {
+		t.Error("Expected object to be unfrozen")
+	}
+	if err := tracker.FreezeObject(id + 100); err == nil {
+		t.Error("Expected error when freezing an unknown object")
+	}
+}
+
+func TestTracker_FreezeObjectSkipsReID(t *testing.T) {
+	reidHitCounterMax := 100
+	tracker := mustNewTracker(t, &TrackerConfig{
+		DistanceFunction:      DistanceByName("euclidean"),
+		DistanceThreshold:     10.0,
+		HitCounterMax:         2,
+		InitializationDelay:   1,
+		ReidDistanceFunction:  DistanceByName("euclidean"),
+		ReidDistanceThreshold: 10.0,
+		ReidHitCounterMax:     &reidHitCounterMax,
+	})
+
+	// Establish an object, let it die and freeze it while it waits for ReID
+	var active []*TrackedObject
+	for i := 0; i < 3; i++ {
+		active = tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+	}
+	if len(active) != 1 {
+		t.Fatalf("Expected 1 active object, got %d", len(active))
+	}
+	id := *active[0].ID
+	for i := 0; i < 5; i++ {
+		tracker.Update(nil, 1, nil)
+	}
+	dead := tracker.findObjectByID(id)
+	if dead == nil || dead.HitCounterIsPositive() {
+		t.Fatal("Expected a dead object waiting for ReID")
+	}
+	if err := tracker.FreezeObject(id); err != nil {
+		t.Fatalf("FreezeObject failed: %v", err)
+	}
+
+	// A new object at the same place must not be ReID-matched to the frozen one
+	for i := 0; i < 5; i++ {
+		active = tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+	}
+	if len(active) != 1 || *active[0].ID == id {
+		t.Errorf("Expected a new object instead of reviving the frozen one, got %d objects", len(active))
+	}
+	if dead.HitCounterIsPositive() || tracker.findObjectByID(id) == nil {
+		t.Error("Expected the frozen object to stay dead and tracked")
+	}
+}
+
+func TestTracker_SetLabel(t *testing.T) {
+	tracker, id := newManualControlTracker(t)
+
+	if err := tracker.SetLabel(id, "car"); err != nil {
+		t.Fatalf("SetLabel failed: %v", err)
+	}
+	label := tracker.findObjectByID(id).GetLabel()
+	if label == nil || *label != "car" {
+		t.Errorf("Expected label 'car', got %v", label)
+	}
+	if err := tracker.SetLabel(id+100, "car"); err == nil {
+		t.Error("Expected error when relabelling an unknown object")
+	}
+}
+
+func TestTracker_SetClassID(t *testing.T) {
+	tracker, id := newManualControlTracker(t)
+
+	if err := tracker.SetClassID(id, 2); err != nil {
+		t.Fatalf("SetClassID failed: %v", err)
+	}
+	classID := tracker.findObjectByID(id).ClassID
+	if classID == nil || *classID != 2 {
+		t.Errorf("Expected class ID 2, got %v", classID)
+	}
+	if err := tracker.SetClassID(id+100, 2); err == nil {
+		t.Error("Expected error when reclassing an unknown object")
+	}
+}
+
+func TestTrackedObject_PointDetectionThresholds(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:         DistanceByName("euclidean"),
+		DistanceThreshold:        100.0,
+		HitCounterMax:            10,
+		InitializationDelay:      0,
+		PointDetectionThresholds: []float64{0.5, 0.9},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	newKeypoints := func(x1 float64, scores []float64) *Detection {
+		det, err := NewDetection(mat.NewDense(2, 2, []float64{10, 10, x1, 10}), &DetectionConfig{Scores: scores})
+		if err != nil {
+			t.Fatalf("Failed to create detection: %v", err)
+		}
+		return det
+	}
+
+	tracker.Update([]*Detection{newKeypoints(50, []float64{0.8, 0.8})}, 1, nil)
+	if len(tracker.TrackedObjects) != 1 {
+		t.Fatalf("Expected 1 tracked object, got %d", len(tracker.TrackedObjects))
+	}
+	obj := tracker.TrackedObjects[0]
+	if obj.GatedPoints[0] || !obj.GatedPoints[1] {
+		t.Errorf("Expected only point 1 to be gated by its stricter threshold, got %v", obj.GatedPoints)
+	}
+
+	// A low-confidence jump of point 1 must not pull its estimate
+	tracker.Update([]*Detection{newKeypoints(90, []float64{0.8, 0.3})}, 1, nil)
+	if obj.GatedPoints[0] || !obj.GatedPoints[1] {
+		t.Errorf("Expected point 1 to stay gated, got %v", obj.GatedPoints)
+	}
+	if x := obj.Estimate.At(1, 0); math.Abs(x-50) > 1e-6 {
+		t.Errorf("Expected gated point to keep its estimate x=50, got %v", x)
+	}
+
+	// A confident detection of point 1 updates it again
+	tracker.Update([]*Detection{newKeypoints(90, []float64{0.8, 0.95})}, 1, nil)
+	if obj.GatedPoints[1] {
+		t.Errorf("Expected point 1 to be ungated, got %v", obj.GatedPoints)
+	}
+	if x := obj.Estimate.At(1, 0); x <= 50 {
+		t.Errorf("Expected point 1 estimate to move towards x=90, got %v", x)
+	}
+}
+
+func TestTrackedObject_IsStationary(t *testing.T) {
+	for _, freeze := range []bool{false, true} {
+		tracker, err := NewTracker(&TrackerConfig{
+			DistanceFunction:            DistanceByName("euclidean"),
+			DistanceThreshold:           50.0,
+			HitCounterMax:               10,
+			InitializationDelay:         0,
+			StationaryVelocityThreshold: 0.5,
+			StationaryFrames:            3,
+			FreezeStationaryVelocity:    freeze,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+
+		// Moving object
+		for frame := 0; frame < 10; frame++ {
+			tracker.Update([]*Detection{newPointDetection(t, 10*float64(frame), 0)}, 1, nil)
+		}
+		obj := tracker.TrackedObjects[0]
+		if obj.IsStationary() {
+			t.Errorf("freeze=%v: moving object should not be stationary", freeze)
+		}
+
+		// Object stops
+		for frame := 0; frame < 40; frame++ {
+			tracker.Update([]*Detection{newPointDetection(t, 90, 0)}, 1, nil)
+		}
+		if !obj.IsStationary() {
+			t.Errorf("freeze=%v: stopped object should be stationary (count %d)", freeze, obj.StationaryCount)
+		}
+		// Only the measurement update of this frame can add velocity back
+		velocity := obj.EstimateVelocity().At(0, 0)
+		if freeze && math.Abs(velocity) > 1e-6 {
+			t.Errorf("Expected frozen velocity to be ~0, got %g", velocity)
+		}
+
+		// Object moves off again
+		for frame := 1; frame <= 5; frame++ {
+			tracker.Update([]*Detection{newPointDetection(t, 90+10*float64(frame), 0)}, 1, nil)
+		}
+		if obj.IsStationary() {
+			t.Errorf("freeze=%v: object moving off should not be stationary", freeze)
+		}
+	}
+
+	// Disabled by default
+	tracker, id := newManualControlTracker(t)
+	if tracker.findObjectByID(id).IsStationary() {
+		t.Error("Stationary detection should be disabled by default")
+	}
+}
+
+func TestTrackedObject_MaxAspectRatioChange(t *testing.T) {
+	// A box narrowing by 2 and growing taller by 2 per frame, then unmatched
+	run := func(maxChange float64) (ratios []float64) {
+		tracker, err := NewTracker(&TrackerConfig{
+			DistanceFunction:     DistanceByName("euclidean"),
+			DistanceThreshold:    50.0,
+			HitCounterMax:        30,
+			InitializationDelay:  0,
+			MaxAspectRatioChange: maxChange,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+		for frame := 0; frame < 30; frame++ {
+			f := float64(frame)
+			det, _ := NewDetectionFromBox(f, 0, 100-f, 50+2*f, nil)
+			tracker.Update([]*Detection{det}, 1, nil)
+		}
+		for frame := 0; frame < 20; frame++ {
+			tracker.Update(nil, 1, nil)
+			estimate := tracker.TrackedObjects[0].Estimate
+			ratios = append(ratios, (estimate.At(1, 0)-estimate.At(0, 0))/(estimate.At(1, 1)-estimate.At(0, 1)))
+		}
+		return ratios
+	}
+
+	unconstrained := run(0)
+	if last := unconstrained[len(unconstrained)-1]; last > 0.1 {
+		t.Fatalf("Expected the unconstrained prediction to degenerate, got aspect ratio %v", last)
+	}
+
+	constrained := run(0.05)
+	for i := 1; i < len(constrained); i++ {
+		change := constrained[i-1] / constrained[i]
+		if constrained[i] <= 0 || change > 1.05+1e-9 || change < 1/1.05-1e-9 {
+			t.Fatalf("Frame %d: aspect ratio changed from %v to %v", i, constrained[i-1], constrained[i])
+		}
+	}
+
+	if _, err := NewTracker(&TrackerConfig{
+		DistanceFunction:     DistanceByName("euclidean"),
+		DistanceThreshold:    1,
+		MaxAspectRatioChange: -1,
+	}); err == nil {
+		t.Error("Expected error for negative max_aspect_ratio_change")
+	}
+}
+
+func TestTracker_OutputFiltering(t *testing.T) {
+	newTracker := func(config *TrackerConfig) *Tracker {
+		config.DistanceFunction = DistanceByName("euclidean")
+		config.DistanceThreshold = 10.0
+		config.HitCounterMax = 10
+		tracker, err := NewTracker(config)
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+		return tracker
+	}
+
+	// Only matched: an unmatched track is hidden but still tracked
+	tracker := newTracker(&TrackerConfig{OutputOnlyMatched: true})
+	for i := 0; i < 3; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 0, 0), newPointDetection(t, 100, 0)}, 1, nil)
+	}
+	active := tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+	if len(active) != 1 || !active[0].IsMatched() {
+		t.Errorf("Expected only the matched object, got %d objects", len(active))
+	}
+	if len(tracker.GetActiveObjects()) != 2 {
+		t.Errorf("Expected the unmatched object to still be active, got %d", len(tracker.GetActiveObjects()))
+	}
+
+	// Age limits
+	tracker = newTracker(&TrackerConfig{OutputMinAge: 2, OutputMaxAge: 4})
+	var counts []int
+	for i := 0; i < 7; i++ {
+		counts = append(counts, len(tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)))
+	}
+	expected := []int{0, 0, 1, 1, 1, 0, 0}
+	for i := range expected {
+		if counts[i] != expected[i] {
+			t.Errorf("Expected output counts %v, got %v", expected, counts)
+			break
+		}
+	}
+
+	if _, err := NewTracker(&TrackerConfig{OutputMinAge: -1}); err == nil {
+		t.Error("Expected error for negative output age")
+	}
+}
+
+// simulateLinearMotion runs a tracker over objects moving at constant velocity,
+// processing every step-th frame with period=step.
+func simulateLinearMotion(t *testing.T, step, numFrames int) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   30.0,
+		HitCounterMax:       10,
+		InitializationDelay: 3,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	starts := [][2]float64{{0, 0}, {0, 200}, {400, 100}}
+	velocities := [][2]float64{{5, 0}, {3, 2}, {-4, 1}}
+	for frame := 0; frame < numFrames; frame += step {
+		var detections []*Detection
+		for i := range starts {
+			x := starts[i][0] + velocities[i][0]*float64(frame)
+			y := starts[i][1] + velocities[i][1]*float64(frame)
+			detections = append(detections, newPointDetection(t, x, y))
+		}
+		tracker.Update(detections, step, nil)
+	}
+	return tracker
+}
+
+func TestTracker_PeriodMatchesFullRate(t *testing.T) {
+	const numFrames = 60
+	fullRate := simulateLinearMotion(t, 1, numFrames)
+	skipping := simulateLinearMotion(t, 2, numFrames)
+
+	fullObjects := fullRate.GetActiveObjects()
+	skipObjects := skipping.GetActiveObjects()
+	if len(fullObjects) != 3 || len(skipObjects) != 3 {
+		t.Fatalf("Expected 3 active objects at both rates, got %d and %d", len(fullObjects), len(skipObjects))
+	}
+	if fullRate.TotalObjectCount() != skipping.TotalObjectCount() {
+		t.Errorf("Expected no extra IDs when skipping frames, got %d vs %d",
+			fullRate.TotalObjectCount(), skipping.TotalObjectCount())
+	}
+
+	// Velocities stay per frame when skipping, and ages count frames
+	for i := range fullObjects {
+		fullVelocity := fullObjects[i].EstimateVelocity()
+		skipVelocity := skipObjects[i].EstimateVelocity()
+		for d := 0; d < 2; d++ {
+			if math.Abs(fullVelocity.At(0, d)-skipVelocity.At(0, d)) > 0.1 {
+				t.Errorf("Object %d: expected per-frame velocity %f, got %f at period 2",
+					i, fullVelocity.At(0, d), skipVelocity.At(0, d))
+			}
+		}
+		if diff := fullObjects[i].Age - skipObjects[i].Age; diff < 0 || diff > 1 {
+			t.Errorf("Object %d: expected ages in frames, got %d vs %d", i, fullObjects[i].Age, skipObjects[i].Age)
+		}
+	}
+}
+
+func TestTracker_PeriodHitCounterInFrames(t *testing.T) {
+	for _, step := range []int{1, 2, 5} {
+		tracker := simulateLinearMotion(t, step, 30)
+
+		// Without detections, objects must survive HitCounterMax frames, not updates
+		frames := 0
+		for len(tracker.GetActiveObjects()) > 0 && frames < 100 {
+			tracker.Update(nil, step, nil)
+			frames += step
+		}
+		if frames < 10 || frames > 10+step {
+			t.Errorf("period %d: expected objects to expire after ~10 frames, took %d", step, frames)
+		}
+	}
+
+	// Period < 1 is treated as 1
+	tracker, id := newManualControlTracker(t)
+	age := tracker.findObjectByID(id).Age
+	tracker.Update(nil, 0, nil)
+	if tracker.findObjectByID(id).Age != age+1 {
+		t.Errorf("Expected period 0 to advance age by 1, got %d", tracker.findObjectByID(id).Age-age)
+	}
+}
+
+func TestTracker_LargeFrameCounters(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   10,
+		InitializationDelay: 0,
+		RecordMatchHistory:  true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+
+	// Simulate a stream that has been running for more than 2^31 frames
+	start := int64(math.MaxInt32)
+	tracker.frameNumber = start
+	obj := tracker.TrackedObjects[0]
+	obj.Age = start
+	for i := 0; i < 3; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+	}
+
+	if tracker.frameNumber != start+3 {
+		t.Errorf("Expected frame number %d, got %d", start+3, tracker.frameNumber)
+	}
+	if obj.Age != start+3 {
+		t.Errorf("Expected age %d, got %d", start+3, obj.Age)
+	}
+	if last := obj.MatchHistory[len(obj.MatchHistory)-1]; last.Frame != start+3 {
+		t.Errorf("Expected last match at frame %d, got %d", start+3, last.Frame)
+	}
+	if len(tracker.GetActiveObjects()) != 1 {
+		t.Errorf("Expected the object to stay active, got %d objects", len(tracker.GetActiveObjects()))
+	}
+}
+
+func TestTracker_MaxTrackedObjects(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   10.0,
+		HitCounterMax:       10,
+		InitializationDelay: 3,
+		MaxTrackedObjects:   5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	// Establish one real object
+	for i := 0; i < 5; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+	}
+	active := tracker.GetActiveObjects()
+	if len(active) != 1 {
+		t.Fatalf("Expected 1 active object, got %d", len(active))
+	}
+	realID := *active[0].ID
+
+	// Noise storm: many scattered low-score detections per frame
+	for frame := 0; frame < 10; frame++ {
+		detections := []*Detection{newPointDetection(t, 0, 0)}
+		for i := 0; i < 20; i++ {
+			noise, _ := NewDetection(mat.NewDense(1, 2, []float64{float64(100 + 50*i), float64(100 + 50*frame)}),
+				&DetectionConfig{Scores: []float64{0.1 + 0.01*float64(i)}})
+			detections = append(detections, noise)
+		}
+		tracker.Update(detections, 1, nil)
+
+		if len(tracker.TrackedObjects) > 5 {
+			t.Fatalf("Frame %d: expected at most 5 tracked objects, got %d", frame, len(tracker.TrackedObjects))
+		}
+	}
+
+	active = tracker.GetActiveObjects()
+	if len(active) != 1 || *active[0].ID != realID {
+		t.Errorf("Expected the established object to survive the storm")
+	}
+	if tracker.EvictionCount() < 150 {
+		t.Errorf("Expected evictions to be counted, got %d", tracker.EvictionCount())
+	}
+
+	// Highest-score noise survives
+	for _, obj := range tracker.TrackedObjects {
+		if obj.IsInitializing && obj.LastDetection.Scores[0] < 0.25 {
+			t.Errorf("Expected low-score initializing objects to be evicted first, found score %f", obj.LastDetection.Scores[0])
+		}
+	}
+
+	if _, err := NewTracker(&TrackerConfig{MaxTrackedObjects: -1}); err == nil {
+		t.Error("Expected error for negative MaxTrackedObjects")
+	}
+}
+
+func TestTracker_MaxTrackedObjectsKeepsFrozen(t *testing.T) {
+	tracker := mustNewTracker(t, &TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   10.0,
+		HitCounterMax:       10,
+		InitializationDelay: 0,
+		MaxTrackedObjects:   2,
+	})
+
+	// Two frozen objects fill the limit, a low-score one would be evicted first
+	low, _ := NewDetection(mat.NewDense(1, 2, []float64{0, 0}), &DetectionConfig{Scores: []float64{0.01}})
+	tracker.Update([]*Detection{low, newPointDetection(t, 100, 0)}, 1, nil)
+	for _, obj := range tracker.TrackedObjects {
+		if err := tracker.FreezeObject(*obj.ID); err != nil {
+			t.Fatalf("FreezeObject failed: %v", err)
+		}
+	}
+
+	// New objects are evicted instead, even when the frozen ones exceed the limit
+	tracker.Update([]*Detection{newPointDetection(t, 200, 0), newPointDetection(t, 300, 0)}, 1, nil)
+	frozen := 0
+	for _, obj := range tracker.TrackedObjects {
+		if obj.Frozen {
+			frozen++
+		}
+	}
+	if frozen != 2 || len(tracker.TrackedObjects) != 2 {
+		t.Errorf("Expected only the 2 frozen objects to remain, got %d of %d", frozen, len(tracker.TrackedObjects))
+	}
+}
+
+// newAmbiguityTracker creates a tracker with one point object at (0, 0)
+func newAmbiguityTracker(t *testing.T, ratio float64) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20.0,
+		HitCounterMax:       5,
+		InitializationDelay: 0,
+		AmbiguityRatio:      ratio,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+	if len(tracker.TrackedObjects) != 1 {
+		t.Fatalf("Expected 1 tracked object, got %d", len(tracker.TrackedObjects))
+	}
+	return tracker
+}
+
+func TestTracker_AmbiguityDeferral(t *testing.T) {
+	tracker := newAmbiguityTracker(t, 0.9)
+	obj := tracker.TrackedObjects[0]
+	hitCounter := obj.HitCounter
+
+	// Two nearly equidistant detections: the match is deferred
+	left, right := newPointDetection(t, -5, 0), newPointDetection(t, 5.2, 0)
+	tracker.Update([]*Detection{left, right}, 1, nil)
+
+	if len(tracker.TrackedObjects) != 1 {
+		t.Fatalf("Hypotheses must not start new objects, got %d objects", len(tracker.TrackedObjects))
+	}
+	if obj.IsMatched() || obj.HitCounter >= hitCounter {
+		t.Errorf("Deferred object must not be hit (matched=%v, hit counter %d -> %d)", obj.IsMatched(), hitCounter, obj.HitCounter)
+	}
+	if len(obj.Hypotheses) != 2 || obj.Hypotheses[0] != left || obj.Hypotheses[1] != right {
+		t.Fatalf("Expected hypotheses [left, right], got %v", obj.Hypotheses)
+	}
+
+	// The next frame resolves the ambiguity, even if it is still ambiguous
+	tracker.Update([]*Detection{newPointDetection(t, -5, 0), newPointDetection(t, 5.2, 0)}, 1, nil)
+	if obj.Hypotheses != nil {
+		t.Errorf("Expected no hypotheses after resolution, got %v", obj.Hypotheses)
+	}
+	if !obj.IsMatched() || obj.Estimate.At(0, 0) >= 0 {
+		t.Errorf("Expected a normal match to the closest detection, estimate x = %v", obj.Estimate.At(0, 0))
+	}
+}
+
+func TestTracker_AmbiguityDisabled(t *testing.T) {
+	tracker := newAmbiguityTracker(t, 0)
+	obj := tracker.TrackedObjects[0]
+
+	tracker.Update([]*Detection{newPointDetection(t, -5, 0), newPointDetection(t, 5.2, 0)}, 1, nil)
+
+	if !obj.IsMatched() || obj.Hypotheses != nil {
+		t.Errorf("Expected a greedy match without hypotheses")
+	}
+	if len(tracker.TrackedObjects) != 2 {
+		t.Errorf("Expected the other detection to start an object, got %d objects", len(tracker.TrackedObjects))
+	}
+}
+
+func TestTracker_AmbiguityClearMatch(t *testing.T) {
+	tracker := newAmbiguityTracker(t, 0.9)
+	obj := tracker.TrackedObjects[0]
+
+	tracker.Update([]*Detection{newPointDetection(t, 1, 0), newPointDetection(t, 15, 0)}, 1, nil)
+
+	if !obj.IsMatched() || obj.Hypotheses != nil {
+		t.Errorf("Expected an unambiguous match to be applied")
+	}
+}
+
+func TestTracker_AmbiguityRatioValidation(t *testing.T) {
+	for _, ratio := range []float64{-0.1, 1.0} {
+		_, err := NewTracker(&TrackerConfig{
+			DistanceFunction:  DistanceByName("euclidean"),
+			DistanceThreshold: 20.0,
+			AmbiguityRatio:    ratio,
+		})
+		if err == nil {
+			t.Errorf("Expected error for ambiguity ratio %v", ratio)
+		}
+	}
+}
+
+// runCascadeScenario tracks a recently hit object A at x=0 and an object B at x=12
+// lost for 3 frames, then feeds one detection closer to B than to A.
+func runCascadeScenario(t *testing.T, cascade bool) (objA, objB *TrackedObject) {
+	t.Helper()
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20.0,
+		HitCounterMax:       10,
+		InitializationDelay: 0,
+		MatchingCascade:     cascade,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 0, 0), newPointDetection(t, 12, 0)}, 1, nil)
+	}
+	if len(tracker.TrackedObjects) != 2 {
+		t.Fatalf("Expected 2 tracked objects, got %d", len(tracker.TrackedObjects))
+	}
+	objA, objB = tracker.TrackedObjects[0], tracker.TrackedObjects[1]
+
+	for i := 0; i < 3; i++ {
+		tracker.Update([]*Detection{newPointDetection(t, 0, 0)}, 1, nil)
+	}
+	if objA.FramesSinceHit != 0 || objB.FramesSinceHit != 3 {
+		t.Fatalf("Expected FramesSinceHit 0 and 3, got %d and %d", objA.FramesSinceHit, objB.FramesSinceHit)
+	}
+
+	tracker.Update([]*Detection{newPointDetection(t, 7, 0)}, 1, nil)
+	return objA, objB
+}
+
+func TestTracker_MatchingCascade(t *testing.T) {
+	objA, objB := runCascadeScenario(t, true)
+	if !objA.IsMatched() || objB.IsMatched() {
+		t.Errorf("Expected the recently hit object to win the detection (A matched=%v, B matched=%v)",
+			objA.IsMatched(), objB.IsMatched())
+	}
+}
+
+func TestTracker_MatchingCascadeDisabled(t *testing.T) {
+	objA, objB := runCascadeScenario(t, false)
+	if objA.IsMatched() || !objB.IsMatched() {
+		t.Errorf("Expected the closest object to win the detection (A matched=%v, B matched=%v)",
+			objA.IsMatched(), objB.IsMatched())
+	}
+}
+
+func TestTracker_UpdateWithStats(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20.0,
+		HitCounterMax:       2,
+		InitializationDelay: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	// Frame 1: two new initializing objects
+	_, stats := tracker.UpdateWithStats([]*Detection{newPointDetection(t, 0, 0), newPointDetection(t, 100, 0)}, 1, nil)
+	if stats.NumNew != 2 || stats.NumMatched != 0 || stats.NumInitializing != 2 || stats.NumActive != 0 {
+		t.Errorf("Frame 1: unexpected stats %+v", stats)
+	}
+
+	// Frame 2: both matched (distances 1 and 3), both become active
+	_, stats = tracker.UpdateWithStats([]*Detection{newPointDetection(t, 1, 0), newPointDetection(t, 103, 0)}, 1, nil)
+	if stats.NumNew != 0 || stats.NumMatched != 2 || stats.NumInitializing != 0 || stats.NumActive != 2 {
+		t.Errorf("Frame 2: unexpected stats %+v", stats)
+	}
+	if stats.MeanMatchDistance <= 0 || stats.MeanMatchDistance >= 20 {
+		t.Errorf("Frame 2: expected a positive mean match distance under the threshold, got %v", stats.MeanMatchDistance)
+	}
+	if stats.DistanceTime <= 0 && stats.AssignmentTime <= 0 {
+		t.Errorf("Frame 2: expected timings to be recorded, got %+v", stats)
+	}
+
+	// No detections until both objects expire
+	deadTotal := 0
+	for i := 0; i < 10; i++ {
+		_, stats = tracker.UpdateWithStats(nil, 1, nil)
+		deadTotal += stats.NumDead
+		if stats.NumMatched != 0 || stats.MeanMatchDistance != 0 {
+			t.Errorf("Expected no matches without detections, got %+v", stats)
+		}
+	}
+	if deadTotal != 2 || len(tracker.TrackedObjects) != 0 {
+		t.Errorf("Expected 2 dead objects in total, got %d", deadTotal)
+	}
+
+	// Stats are only collected by UpdateWithStats
+	if tracker.stats != nil {
+		t.Error("Expected stats collection to stop after UpdateWithStats")
+	}
+}
+
+func TestTracker_UpdateDegenerateDetections(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   10,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	valid := newPointDetection(t, 0, 0)
+	noAbsolute := &Detection{Points: mat.NewDense(1, 2, []float64{100, 100})}
+	detections := []*Detection{
+		valid,
+		nil,
+		{},
+		{Points: &mat.Dense{}},
+		{Points: mat.NewDense(1, 3, []float64{50, 50, 0})}, // dimension differs from the first detection
+		{Points: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), Scores: []float64{1}},
+		valid,                      // same detection twice
+		newPointDetection(t, 0, 0), // exact duplicate
+		noAbsolute,
+	}
+
+	objects := tracker.Update(detections, 1, nil)
+	if len(objects) != 2 {
+		t.Fatalf("Expected only the 2 valid detections to be tracked, got %d", len(objects))
+	}
+	if detections[1] != nil || detections[6] != valid {
+		t.Error("Update should not modify the caller's slice")
+	}
+	if noAbsolute.AbsolutePoints == nil {
+		t.Error("Expected missing AbsolutePoints to be filled in")
+	}
+
+	// Detections must keep the shape of the tracked objects
+	tracker.Update([]*Detection{
+		{Points: mat.NewDense(1, 3, []float64{0, 0, 0})},
+		{Points: mat.NewDense(2, 2, []float64{100, 100, 101, 101})},
+	}, 1, nil)
+	if len(tracker.TrackedObjects) != 2 {
+		t.Errorf("Expected mismatched detections to be skipped, got %d objects", len(tracker.TrackedObjects))
+	}
+}
+
+func TestTracker_UpdateContext(t *testing.T) {
+	tracker, _ := newManualControlTracker(t)
+	ageBefore := tracker.TrackedObjects[0].Age
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := tracker.UpdateContext(ctx, nil, 1, nil); err != nil {
+		t.Fatalf("UpdateContext failed: %v", err)
+	}
+	cancel()
+	if _, err := tracker.UpdateContext(ctx, nil, 1, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if age := tracker.TrackedObjects[0].Age; age != ageBefore+1 {
+		t.Errorf("Expected only the first update to run, age %d -> %d", ageBefore, age)
+	}
+}
+
+func TestTracker_Run(t *testing.T) {
+	newFrames := func(n int) <-chan []*Detection {
+		frames := make(chan []*Detection, n)
+		for i := 0; i < n; i++ {
+			frames <- []*Detection{newPointDetection(t, float64(i), 0)}
+		}
+		close(frames)
+		return frames
+	}
+	newTracker := func() *Tracker {
+		tracker, err := NewTracker(&TrackerConfig{
+			DistanceFunction:    DistanceByName("euclidean"),
+			DistanceThreshold:   10,
+			InitializationDelay: 0,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+		return tracker
+	}
+
+	// Runs until the channel is closed
+	var frameNumbers []int64
+	err := newTracker().Run(context.Background(), newFrames(5), func(frameNumber int64, objects []*TrackedObject) error {
+		frameNumbers = append(frameNumbers, frameNumber)
+		return nil
+	})
+	if err != nil || len(frameNumbers) != 5 || frameNumbers[4] != 5 {
+		t.Errorf("Expected frames 1..5 and no error, got %v, %v", frameNumbers, err)
+	}
+
+	// Stops when onFrame fails
+	stop := errors.New("stop")
+	processed := 0
+	err = newTracker().Run(context.Background(), newFrames(5), func(frameNumber int64, objects []*TrackedObject) error {
+		processed++
+		if frameNumber == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || processed != 2 {
+		t.Errorf("Expected to stop after frame 2 with onFrame's error, got %d frames, %v", processed, err)
+	}
+
+	// Stops when cancelled, even if no frame arrives
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := newTracker().Run(ctx, make(chan []*Detection), nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTracker_RunFrames(t *testing.T) {
+	newTracker := func() *Tracker {
+		tracker, err := NewTracker(&TrackerConfig{
+			DistanceFunction:    DistanceByName("euclidean"),
+			DistanceThreshold:   10,
+			InitializationDelay: 0,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create tracker: %v", err)
+		}
+		return tracker
+	}
+
+	// Frames 3 and 4 are missing, so frame 5 is an update with period 3
+	numbers := []int64{1, 2, 5, 6}
+	frames := make(chan DetectionFrame, len(numbers))
+	for _, number := range numbers {
+		frames <- DetectionFrame{Number: number, Detections: []*Detection{newPointDetection(t, float64(number), 0)}}
+	}
+	close(frames)
+
+	tracker := newTracker()
+	var frameNumbers []int64
+	err := tracker.RunFrames(context.Background(), frames, func(frameNumber int64, objects []*TrackedObject) error {
+		frameNumbers = append(frameNumbers, frameNumber)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(frameNumbers, numbers) {
+		t.Errorf("Expected frames %v and no error, got %v, %v", numbers, frameNumbers, err)
+	}
+
+	expected := newTracker()
+	for i, period := range []int{1, 1, 3, 1} {
+		expected.Update([]*Detection{newPointDetection(t, float64(numbers[i]), 0)}, period, nil)
+	}
+	if got, want := tracker.TrackedObjects[0].Age, expected.TrackedObjects[0].Age; got != want {
+		t.Errorf("Expected age %d from the frame gaps, got %d", want, got)
+	}
+
+	// Stops when cancelled, even if no frame arrives
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := newTracker().RunFrames(ctx, make(chan DetectionFrame), nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTracker_ObjectAccessors(t *testing.T) {
+	reidHitCounterMax := 20
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:      DistanceByName("euclidean"),
+		DistanceThreshold:     50,
+		HitCounterMax:         4,
+		InitializationDelay:   2,
+		ReidDistanceFunction:  DistanceByName("euclidean"),
+		ReidDistanceThreshold: 50,
+		ReidHitCounterMax:     &reidHitCounterMax,
+		OutputOnlyMatched:     true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	for frame := 0; frame < 3; frame++ {
+		tracker.Update([]*Detection{newPointDetection(t, 10, 10)}, 1, nil)
+	}
+	first := tracker.TrackedObjects[0]
+
+	// A new object starts initializing while the first one is not matched
+	output := tracker.Update([]*Detection{newPointDetection(t, 500, 500)}, 1, nil)
+	if len(output) != 0 {
+		t.Errorf("Expected no matched output objects, got %d", len(output))
+	}
+	initializing := tracker.InitializingObjects()
+	if len(initializing) != 1 || initializing[0] == first {
+		t.Fatalf("Expected the new object to be initializing, got %d objects", len(initializing))
+	}
+	if alive := tracker.AliveObjects(); len(alive) != 2 {
+		t.Errorf("Expected 2 alive objects, got %d", len(alive))
+	}
+
+	// The first object dies and waits for ReID
+	all := tracker.AllObjects()
+	for frame := 0; frame < 8; frame++ {
+		tracker.Update([]*Detection{newPointDetection(t, 500, 500)}, 1, nil)
+	}
+	if len(tracker.InitializingObjects()) != 0 {
+		t.Errorf("Expected no initializing objects, got %d", len(tracker.InitializingObjects()))
+	}
+	alive := tracker.AliveObjects()
+	if len(alive) != 1 || alive[0] == first {
+		t.Errorf("Expected only the second object to be alive, got %d", len(alive))
+	}
+	if len(tracker.AllObjects()) != 2 || first.HitCounterIsPositive() {
+		t.Errorf("Expected the dead object to still be held for ReID, got %d objects", len(tracker.AllObjects()))
+	}
+
+	// Returned slices are snapshots
+	all[0] = nil
+	if tracker.TrackedObjects[0] == nil {
+		t.Error("Expected AllObjects to return a copy")
+	}
+}
+
+func TestTracker_IgnoredDetections(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   5.0,
+		HitCounterMax:       10,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	tracked := newPointDetection(t, 0, 0)
+	hidden := newPointDetection(t, 50, 0)
+	hidden.Ignore = true
+	detections := []*Detection{tracked, hidden}
+	tracker.Update(detections, 1, nil)
+	if len(tracker.TrackedObjects) != 1 {
+		t.Fatalf("Expected ignored detections not to start objects, got %d objects", len(tracker.TrackedObjects))
+	}
+	obj := tracker.TrackedObjects[0]
+
+	// Ignoring the tracked detection leaves the object unmatched
+	tracked.Ignore = true
+	tracker.Update(detections, 1, nil)
+	if obj.IsMatched() || len(tracker.TrackedObjects) != 1 {
+		t.Errorf("Expected the object to coast (matched=%v, objects=%d)", obj.IsMatched(), len(tracker.TrackedObjects))
+	}
+	if len(detections) != 2 {
+		t.Errorf("Expected the caller's slice to be unchanged, got %d detections", len(detections))
+	}
+
+	tracked.Ignore = false
+	tracker.Update(detections, 1, nil)
+	if !obj.IsMatched() {
+		t.Error("Expected the object to match once the detection is no longer ignored")
+	}
+}
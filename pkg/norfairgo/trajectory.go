@@ -0,0 +1,192 @@
+package norfairgo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// =============================================================================
+// Trajectories - Recording, Simplification and Export
+// =============================================================================
+
+// SimplifyPathIndices returns the indices of the points kept by the Douglas-Peucker
+// simplification of a path: every dropped point lies within tolerance of the
+// segment between the kept points around it. The first and last points are always
+// kept; a tolerance <= 0 keeps every point.
+func SimplifyPathIndices(points [][2]float64, tolerance float64) []int {
+	n := len(points)
+	if n <= 2 || tolerance <= 0 {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+	stack := [][2]int{{0, n - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		first, last := span[0], span[1]
+
+		farthest, maxDistance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := pointSegmentDistance(points[i], points[first], points[last]); d > maxDistance {
+				farthest, maxDistance = i, d
+			}
+		}
+		if farthest >= 0 {
+			keep[farthest] = true
+			stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+		}
+	}
+
+	var indices []int
+	for i, k := range keep {
+		if k {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// SimplifyPath returns the points kept by the Douglas-Peucker simplification of a
+// path (see SimplifyPathIndices).
+func SimplifyPath(points [][2]float64, tolerance float64) [][2]float64 {
+	indices := SimplifyPathIndices(points, tolerance)
+	simplified := make([][2]float64, len(indices))
+	for i, index := range indices {
+		simplified[i] = points[index]
+	}
+	return simplified
+}
+
+// pointSegmentDistance returns the distance from p to the segment from a to b.
+func pointSegmentDistance(p, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return math.Hypot(p[0]-a[0], p[1]-a[1])
+	}
+	t := math.Max(0, math.Min(1, ((p[0]-a[0])*dx+(p[1]-a[1])*dy)/lengthSquared))
+	return math.Hypot(p[0]-a[0]-t*dx, p[1]-a[1]-t*dy)
+}
+
+// TrajectoryRecorderConfig configures a TrajectoryRecorder.
+type TrajectoryRecorderConfig struct {
+	// Point of each object that is recorded.
+	// Default: ReferenceBottomCenter
+	Reference ReferencePointMode
+
+	// Record absolute (world) instead of relative (frame) coordinates, for moving
+	// cameras.
+	// Default: false
+	Absolute bool
+
+	// Douglas-Peucker tolerance applied by Trajectories and WriteCSV, in the units
+	// of the estimates. Long, nearly straight paths shrink to a few points.
+	// Default: 0 (every update is kept)
+	Tolerance float64
+}
+
+// TrajectoryPoint is the position of an object on one update.
+type TrajectoryPoint struct {
+	Frame int64 // Update number (1-indexed)
+	X, Y  float64
+}
+
+// Trajectory is the path of one object.
+type Trajectory struct {
+	ID     int
+	Points []TrajectoryPoint
+}
+
+// TrajectoryRecorder records the path of every object passed to Update, for export
+// and offline analysis.
+type TrajectoryRecorder struct {
+	config       TrajectoryRecorderConfig
+	frameNumber  int64
+	trajectories map[int][]TrajectoryPoint
+}
+
+// NewTrajectoryRecorder creates a new TrajectoryRecorder.
+//
+// Returns: Error if Tolerance is negative
+func NewTrajectoryRecorder(config TrajectoryRecorderConfig) (*TrajectoryRecorder, error) {
+	if config.Tolerance < 0 {
+		return nil, fmt.Errorf("tolerance must be >= 0, got %v", config.Tolerance)
+	}
+	return &TrajectoryRecorder{
+		config:       config,
+		trajectories: make(map[int][]TrajectoryPoint),
+	}, nil
+}
+
+// Update appends the current position of every object to its trajectory.
+func (r *TrajectoryRecorder) Update(objects []TrackedObjectLike) {
+	r.frameNumber++
+	for _, obj := range objects {
+		id := obj.GetID()
+		if id == nil {
+			continue
+		}
+		estimate, err := obj.GetEstimate(r.config.Absolute)
+		if err != nil {
+			continue
+		}
+		point := r.config.Reference.Extract(estimate)
+		r.trajectories[*id] = append(r.trajectories[*id], TrajectoryPoint{Frame: r.frameNumber, X: point[0], Y: point[1]})
+	}
+}
+
+// Trajectories returns the recorded trajectories ordered by ID, simplified with
+// the configured Tolerance.
+func (r *TrajectoryRecorder) Trajectories() []Trajectory {
+	ids := make([]int, 0, len(r.trajectories))
+	for id := range r.trajectories {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	trajectories := make([]Trajectory, len(ids))
+	for i, id := range ids {
+		recorded := r.trajectories[id]
+		path := make([][2]float64, len(recorded))
+		for j, p := range recorded {
+			path[j] = [2]float64{p.X, p.Y}
+		}
+		indices := SimplifyPathIndices(path, r.config.Tolerance)
+		points := make([]TrajectoryPoint, len(indices))
+		for j, index := range indices {
+			points[j] = recorded[index]
+		}
+		trajectories[i] = Trajectory{ID: id, Points: points}
+	}
+	return trajectories
+}
+
+// WriteCSV writes Trajectories as CSV, one point per row.
+//
+// Format: id,frame,x,y
+func (r *TrajectoryRecorder) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"id", "frame", "x", "y"}); err != nil {
+		return fmt.Errorf("failed to write trajectories header: %w", err)
+	}
+	for _, trajectory := range r.Trajectories() {
+		for _, p := range trajectory.Points {
+			record := []string{strconv.Itoa(trajectory.ID), strconv.FormatInt(p.Frame, 10), formatFloat(p.X), formatFloat(p.Y)}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write trajectories: %w", err)
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,65 @@
+package norfairgo
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestSimplifyPath(t *testing.T) {
+	// A noisy straight line with a single corner at index 5
+	path := [][2]float64{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 0.05}, {4, 0}, {5, 0}, {5.1, 1}, {4.9, 2}, {5, 3}}
+
+	if indices := SimplifyPathIndices(path, 0.5); !reflect.DeepEqual(indices, []int{0, 5, 8}) {
+		t.Errorf("Expected indices [0 5 8], got %v", indices)
+	}
+	if simplified := SimplifyPath(path, 0.5); !reflect.DeepEqual(simplified, [][2]float64{{0, 0}, {5, 0}, {5, 3}}) {
+		t.Errorf("Unexpected simplified path %v", simplified)
+	}
+	if simplified := SimplifyPath(path, 0); len(simplified) != len(path) {
+		t.Errorf("Expected tolerance 0 to keep all %d points, got %d", len(path), len(simplified))
+	}
+	if simplified := SimplifyPath(path, 1e-3); len(simplified) != len(path) {
+		t.Errorf("Expected a tiny tolerance to keep all noisy points, got %v", simplified)
+	}
+
+	// Points beyond the segment ends are measured to the ends, not the infinite line
+	if indices := SimplifyPathIndices([][2]float64{{0, 0}, {10, 0}, {5, 0}}, 1); !reflect.DeepEqual(indices, []int{0, 1, 2}) {
+		t.Errorf("Expected a path doubling back to be kept, got %v", indices)
+	}
+}
+
+func TestTrajectoryRecorder(t *testing.T) {
+	recorder, err := NewTrajectoryRecorder(TrajectoryRecorderConfig{Tolerance: 0.5})
+	if err != nil {
+		t.Fatalf("Failed to create trajectory recorder: %v", err)
+	}
+	for x := 0; x < 100; x++ {
+		objects := []TrackedObjectLike{pointTrack(2, float64(x), 10)}
+		if x < 2 {
+			objects = append(objects, pointTrack(1, 0, float64(x)))
+		}
+		recorder.Update(objects)
+	}
+
+	trajectories := recorder.Trajectories()
+	expected := []Trajectory{
+		{ID: 1, Points: []TrajectoryPoint{{Frame: 1, X: 0, Y: 0}, {Frame: 2, X: 0, Y: 1}}},
+		{ID: 2, Points: []TrajectoryPoint{{Frame: 1, X: 0, Y: 10}, {Frame: 100, X: 99, Y: 10}}},
+	}
+	if !reflect.DeepEqual(trajectories, expected) {
+		t.Errorf("Expected trajectories %+v, got %+v", expected, trajectories)
+	}
+
+	var buf bytes.Buffer
+	if err := recorder.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if csv := "id,frame,x,y\n1,1,0,0\n1,2,0,1\n2,1,0,10\n2,100,99,10\n"; buf.String() != csv {
+		t.Errorf("Expected CSV\n%s\ngot\n%s", csv, buf.String())
+	}
+
+	if _, err := NewTrajectoryRecorder(TrajectoryRecorderConfig{Tolerance: -1}); err == nil {
+		t.Error("Expected error for negative tolerance")
+	}
+}
@@ -0,0 +1,349 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// =============================================================================
+// Turning Movements - Entry/Exit Zone Pairs at Intersections
+// =============================================================================
+
+// Zone is a named polygon of (x, y) vertices in image coordinates, e.g. one
+// approach of an intersection.
+type Zone struct {
+	Name    string
+	Polygon [][2]float64
+}
+
+// TurningMovementConfig configures a TurningMovementCounter.
+type TurningMovementConfig struct {
+	// Entry and exit zones, e.g. one per intersection approach. An object is in the
+	// first zone containing its reference point.
+	// Required (unique names, at least 3 vertices each).
+	Zones []Zone
+
+	// Point of each object that is tested against the zones.
+	// Default: ReferenceBottomCenter
+	Reference ReferencePointMode
+
+	// Number of updates per counting window of Counts.
+	// Default: 0 (a single window)
+	WindowSize int64
+
+	// Largest heading change in degrees of a straight movement. Changes of more than
+	// 180 - StraightTolerance are U-turns, the ones in between left or right turns.
+	// Default: 30 (if 0)
+	StraightTolerance float64
+}
+
+// TurnDirection classifies a movement by the object's change of heading.
+type TurnDirection int
+
+const (
+	// TurnUnknown is used when the object had no heading in its entry or exit zone.
+	TurnUnknown TurnDirection = iota
+	// TurnStraight is a heading change of at most StraightTolerance.
+	TurnStraight
+	// TurnLeft is a counter-clockwise heading change in the image.
+	TurnLeft
+	// TurnRight is a clockwise heading change in the image.
+	TurnRight
+	// TurnUTurn is a heading change of more than 180 - StraightTolerance.
+	TurnUTurn
+)
+
+// String returns "unknown", "straight", "left", "right" or "u-turn".
+func (d TurnDirection) String() string {
+	switch d {
+	case TurnStraight:
+		return "straight"
+	case TurnLeft:
+		return "left"
+	case TurnRight:
+		return "right"
+	case TurnUTurn:
+		return "u-turn"
+	default:
+		return "unknown"
+	}
+}
+
+// TurningMovement is the completed movement of an object from an entry to an exit zone.
+type TurningMovement struct {
+	ID         int
+	Entry      string        // Name of the first zone the object was in
+	Exit       string        // Name of the last zone the object was in after leaving Entry
+	EntryFrame int64         // Update (1-indexed) the object was first seen in Entry
+	ExitFrame  int64         // Last update the object was seen in Exit
+	Turn       TurnDirection // Classification of TurnAngle
+	TurnAngle  float64       // Heading change in degrees in (-180, 180], positive = clockwise in the image
+}
+
+// MovementCount is the number of movements between a pair of zones in a window of
+// updates (by ExitFrame).
+type MovementCount struct {
+	WindowStart, WindowEnd int64 // First and last update of the window
+	Entry, Exit            string
+	Count                  int
+}
+
+// movementTrack is the zone history of one object.
+type movementTrack struct {
+	entry, exit               int // Zone indices, -1 if none yet
+	leftEntry                 bool
+	entryFrame, exitFrame     int64
+	entryHeading, exitHeading float64
+	hasEntryHeading           bool
+	hasExitHeading            bool
+}
+
+// TurningMovementCounter assigns each tracked object an (entry zone, exit zone) pair
+// and aggregates turning-movement counts over time windows, e.g. for traffic
+// analytics at intersections. Turns are classified from the objects' headings (see
+// TrackedObject.Heading) in the entry and exit zones, assuming a roughly top-down
+// view: a clockwise heading change in the image is a right turn.
+//
+// A movement is completed when its object is no longer passed to Update (or on
+// Flush), after it has been in an entry zone, left it, and been in an exit zone.
+type TurningMovementCounter struct {
+	config      TurningMovementConfig
+	zoneIndex   map[string]int
+	frameNumber int64
+	tracks      map[int]*movementTrack
+	movements   []TurningMovement
+}
+
+// NewTurningMovementCounter creates a new TurningMovementCounter.
+//
+// Returns: Error if there are no zones, a zone has fewer than 3 vertices or a
+// duplicate name, WindowSize is negative or StraightTolerance is not in [0, 90)
+func NewTurningMovementCounter(config TurningMovementConfig) (*TurningMovementCounter, error) {
+	if len(config.Zones) == 0 {
+		return nil, fmt.Errorf("turning movement counting requires at least one zone")
+	}
+	zoneIndex := make(map[string]int, len(config.Zones))
+	for i, zone := range config.Zones {
+		if len(zone.Polygon) < 3 {
+			return nil, fmt.Errorf("zone %q must have at least 3 vertices, got %d", zone.Name, len(zone.Polygon))
+		}
+		if _, exists := zoneIndex[zone.Name]; exists {
+			return nil, fmt.Errorf("duplicate zone name %q", zone.Name)
+		}
+		zoneIndex[zone.Name] = i
+	}
+	if config.WindowSize < 0 {
+		return nil, fmt.Errorf("window size must be >= 0, got %d", config.WindowSize)
+	}
+	if config.StraightTolerance == 0 {
+		config.StraightTolerance = 30
+	}
+	if config.StraightTolerance < 0 || config.StraightTolerance >= 90 {
+		return nil, fmt.Errorf("straight tolerance must be in [0, 90), got %v", config.StraightTolerance)
+	}
+
+	return &TurningMovementCounter{
+		config:    config,
+		zoneIndex: zoneIndex,
+		tracks:    make(map[int]*movementTrack),
+	}, nil
+}
+
+// Update records the zones of the current objects and returns the movements
+// completed on this update (objects no longer passed), ordered by ID.
+func (c *TurningMovementCounter) Update(objects []TrackedObjectLike) []TurningMovement {
+	c.frameNumber++
+
+	seen := make(map[int]bool, len(objects))
+	for _, obj := range objects {
+		id := obj.GetID()
+		if id == nil {
+			continue
+		}
+		estimate, err := obj.GetEstimate(false)
+		if err != nil {
+			continue
+		}
+		seen[*id] = true
+
+		track := c.tracks[*id]
+		if track == nil {
+			track = &movementTrack{entry: -1, exit: -1}
+			c.tracks[*id] = track
+		}
+		point := c.config.Reference.Extract(estimate)
+		heading, hasHeading := objectHeading(obj)
+
+		zone := c.zoneAt(point)
+		switch {
+		case zone < 0:
+			if track.entry >= 0 {
+				track.leftEntry = true
+			}
+		case track.entry < 0:
+			track.entry = zone
+			track.entryFrame = c.frameNumber
+			track.entryHeading, track.hasEntryHeading = heading, hasHeading
+		case zone == track.entry && !track.leftEntry:
+			// Keep the heading of the object as it leaves the entry zone
+			if hasHeading {
+				track.entryHeading, track.hasEntryHeading = heading, true
+			}
+		default:
+			track.leftEntry = true
+			if zone != track.exit {
+				track.hasExitHeading = false
+			}
+			track.exit = zone
+			track.exitFrame = c.frameNumber
+			if hasHeading {
+				track.exitHeading, track.hasExitHeading = heading, true
+			}
+		}
+	}
+
+	var completed []TurningMovement
+	for id, track := range c.tracks {
+		if seen[id] {
+			continue
+		}
+		if movement, ok := c.complete(id, track); ok {
+			completed = append(completed, movement)
+		}
+		delete(c.tracks, id)
+	}
+	sort.Slice(completed, func(i, j int) bool { return completed[i].ID < completed[j].ID })
+	c.movements = append(c.movements, completed...)
+	return completed
+}
+
+// Flush completes the movements of all objects still being tracked, e.g. at the end
+// of a video, and returns them ordered by ID.
+func (c *TurningMovementCounter) Flush() []TurningMovement {
+	var completed []TurningMovement
+	for id, track := range c.tracks {
+		if movement, ok := c.complete(id, track); ok {
+			completed = append(completed, movement)
+		}
+	}
+	clear(c.tracks)
+	sort.Slice(completed, func(i, j int) bool { return completed[i].ID < completed[j].ID })
+	c.movements = append(c.movements, completed...)
+	return completed
+}
+
+// Movements returns all completed movements in order of completion.
+func (c *TurningMovementCounter) Movements() []TurningMovement {
+	return append([]TurningMovement{}, c.movements...)
+}
+
+// Counts returns the number of completed movements per window and zone pair,
+// ordered by window and then by the order of the entry and exit zones.
+func (c *TurningMovementCounter) Counts() []MovementCount {
+	type key struct {
+		window      int64
+		entry, exit int
+	}
+	counts := make(map[key]int)
+	for _, movement := range c.movements {
+		window := int64(0)
+		if c.config.WindowSize > 0 {
+			window = (movement.ExitFrame - 1) / c.config.WindowSize
+		}
+		counts[key{window, c.zoneIndex[movement.Entry], c.zoneIndex[movement.Exit]}]++
+	}
+
+	keys := make([]key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].window != keys[j].window {
+			return keys[i].window < keys[j].window
+		}
+		if keys[i].entry != keys[j].entry {
+			return keys[i].entry < keys[j].entry
+		}
+		return keys[i].exit < keys[j].exit
+	})
+
+	result := make([]MovementCount, len(keys))
+	for i, k := range keys {
+		start, end := int64(1), c.frameNumber
+		if c.config.WindowSize > 0 {
+			start, end = k.window*c.config.WindowSize+1, (k.window+1)*c.config.WindowSize
+		}
+		result[i] = MovementCount{
+			WindowStart: start,
+			WindowEnd:   end,
+			Entry:       c.config.Zones[k.entry].Name,
+			Exit:        c.config.Zones[k.exit].Name,
+			Count:       counts[k],
+		}
+	}
+	return result
+}
+
+// zoneAt returns the index of the first zone containing point, or -1.
+func (c *TurningMovementCounter) zoneAt(point [2]float64) int {
+	for i, zone := range c.config.Zones {
+		if PointInPolygon(point[0], point[1], zone.Polygon) {
+			return i
+		}
+	}
+	return -1
+}
+
+// complete returns the movement of a track that has an entry and an exit zone.
+func (c *TurningMovementCounter) complete(id int, track *movementTrack) (TurningMovement, bool) {
+	if track.entry < 0 || track.exit < 0 {
+		return TurningMovement{}, false
+	}
+	movement := TurningMovement{
+		ID:         id,
+		Entry:      c.config.Zones[track.entry].Name,
+		Exit:       c.config.Zones[track.exit].Name,
+		EntryFrame: track.entryFrame,
+		ExitFrame:  track.exitFrame,
+	}
+	if track.hasEntryHeading && track.hasExitHeading {
+		movement.TurnAngle = headingChange(track.entryHeading, track.exitHeading)
+		movement.Turn = c.classifyTurn(movement.TurnAngle)
+	}
+	return movement, true
+}
+
+// classifyTurn classifies a heading change in degrees.
+func (c *TurningMovementCounter) classifyTurn(angle float64) TurnDirection {
+	tolerance := c.config.StraightTolerance
+	switch {
+	case math.Abs(angle) <= tolerance:
+		return TurnStraight
+	case math.Abs(angle) > 180-tolerance:
+		return TurnUTurn
+	case angle > 0:
+		return TurnRight
+	default:
+		return TurnLeft
+	}
+}
+
+// headingChange returns the signed change from heading a to b in (-180, 180].
+func headingChange(a, b float64) float64 {
+	change := math.Mod(b-a, 360)
+	if change > 180 {
+		change -= 360
+	} else if change <= -180 {
+		change += 360
+	}
+	return change
+}
+
+// objectHeading returns the heading of objects that provide one (see TrackedObject.Heading).
+func objectHeading(obj TrackedObjectLike) (float64, bool) {
+	if heading, ok := obj.(interface{ Heading() (float64, bool) }); ok {
+		return heading.Heading()
+	}
+	return 0, false
+}
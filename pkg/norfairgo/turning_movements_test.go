@@ -0,0 +1,176 @@
+package norfairgo
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// intersectionZones returns the four approaches of a 300x300 intersection.
+func intersectionZones() []Zone {
+	square := func(x0, y0 float64) [][2]float64 {
+		return [][2]float64{{x0, y0}, {x0 + 100, y0}, {x0 + 100, y0 + 100}, {x0, y0 + 100}}
+	}
+	return []Zone{
+		{Name: "north", Polygon: square(100, 0)},
+		{Name: "east", Polygon: square(200, 100)},
+		{Name: "south", Polygon: square(100, 200)},
+		{Name: "west", Polygon: square(0, 100)},
+	}
+}
+
+func TestTurningMovementCounter(t *testing.T) {
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   30,
+		HitCounterMax:       4,
+		InitializationDelay: 0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	counter, err := NewTurningMovementCounter(TurningMovementConfig{Zones: intersectionZones(), WindowSize: 100})
+	if err != nil {
+		t.Fatalf("Failed to create counter: %v", err)
+	}
+
+	var completed []TurningMovement
+	drive := func(path [][2]float64) {
+		for _, p := range path {
+			objects := tracker.Update([]*Detection{newPointDetection(t, p[0], p[1])}, 1, nil)
+			completed = append(completed, counter.Update(AsTrackedObjectLikes(objects))...)
+		}
+		// Let the object expire
+		for i := 0; i < 10; i++ {
+			completed = append(completed, counter.Update(AsTrackedObjectLikes(tracker.Update(nil, 1, nil)))...)
+		}
+	}
+	line := func(from, to [2]float64, steps int) [][2]float64 {
+		path := make([][2]float64, steps)
+		for i := range path {
+			a := float64(i+1) / float64(steps)
+			path[i] = [2]float64{from[0] + a*(to[0]-from[0]), from[1] + a*(to[1]-from[1])}
+		}
+		return path
+	}
+
+	// West to east, straight through
+	drive(line([2]float64{10, 150}, [2]float64{290, 150}, 56))
+	// South to east: up, then right
+	drive(append(line([2]float64{150, 295}, [2]float64{150, 150}, 29), line([2]float64{150, 150}, [2]float64{290, 150}, 28)...))
+	// West to north: right, then up
+	drive(append(line([2]float64{5, 150}, [2]float64{150, 150}, 29), line([2]float64{150, 150}, [2]float64{150, 5}, 29)...))
+	// Never leaves the west approach
+	drive(line([2]float64{10, 150}, [2]float64{60, 150}, 10))
+
+	expected := []struct {
+		entry, exit string
+		turn        TurnDirection
+	}{
+		{"west", "east", TurnStraight},
+		{"south", "east", TurnRight},
+		{"west", "north", TurnLeft},
+	}
+	if len(completed) != len(expected) {
+		t.Fatalf("Expected %d movements, got %+v", len(expected), completed)
+	}
+	for i, want := range expected {
+		got := completed[i]
+		if got.Entry != want.entry || got.Exit != want.exit || got.Turn != want.turn {
+			t.Errorf("Movement %d: expected %s -> %s (%s), got %s -> %s (%s, %.1f degrees)",
+				i, want.entry, want.exit, want.turn, got.Entry, got.Exit, got.Turn, got.TurnAngle)
+		}
+		if got.ExitFrame <= got.EntryFrame {
+			t.Errorf("Movement %d: expected exit after entry, got frames %d and %d", i, got.EntryFrame, got.ExitFrame)
+		}
+	}
+	if len(counter.Movements()) != 3 {
+		t.Errorf("Expected 3 recorded movements, got %d", len(counter.Movements()))
+	}
+
+	// Movements end on updates 56, 123 and 190, in windows of 100 updates
+	counts := counter.Counts()
+	expectedCounts := []MovementCount{
+		{WindowStart: 1, WindowEnd: 100, Entry: "west", Exit: "east", Count: 1},
+		{WindowStart: 101, WindowEnd: 200, Entry: "south", Exit: "east", Count: 1},
+		{WindowStart: 101, WindowEnd: 200, Entry: "west", Exit: "north", Count: 1},
+	}
+	if len(counts) != len(expectedCounts) {
+		t.Fatalf("Expected %d counts, got %+v", len(expectedCounts), counts)
+	}
+	for i, want := range expectedCounts {
+		if counts[i] != want {
+			t.Errorf("Count %d: expected %+v, got %+v", i, want, counts[i])
+		}
+	}
+}
+
+func TestTurningMovementCounter_FlushAndUnknownTurn(t *testing.T) {
+	counter, err := NewTurningMovementCounter(TurningMovementConfig{Zones: intersectionZones()})
+	if err != nil {
+		t.Fatalf("Failed to create counter: %v", err)
+	}
+
+	// Objects without a heading still get a zone pair
+	for _, x := range []float64{50, 150, 250, 260} {
+		box := &boxTrack{id: 7, box: mat.NewDense(2, 2, []float64{x - 5, 140, x + 5, 150})}
+		if completed := counter.Update([]TrackedObjectLike{box}); len(completed) != 0 {
+			t.Fatalf("Expected no completed movements while tracked, got %+v", completed)
+		}
+	}
+	flushed := counter.Flush()
+	if len(flushed) != 1 || flushed[0].Entry != "west" || flushed[0].Exit != "east" || flushed[0].Turn != TurnUnknown {
+		t.Fatalf("Expected a west -> east movement with unknown turn, got %+v", flushed)
+	}
+	if flushed[0].EntryFrame != 1 || flushed[0].ExitFrame != 4 {
+		t.Errorf("Expected frames 1 to 4, got %d to %d", flushed[0].EntryFrame, flushed[0].ExitFrame)
+	}
+
+	counts := counter.Counts()
+	if len(counts) != 1 || counts[0].WindowStart != 1 || counts[0].WindowEnd != 4 || counts[0].Count != 1 {
+		t.Errorf("Expected a single window with 1 movement, got %+v", counts)
+	}
+}
+
+func TestTurningMovementCounter_ClassifyTurn(t *testing.T) {
+	counter, err := NewTurningMovementCounter(TurningMovementConfig{Zones: intersectionZones()})
+	if err != nil {
+		t.Fatalf("Failed to create counter: %v", err)
+	}
+	testCases := []struct {
+		from, to float64
+		angle    float64
+		turn     TurnDirection
+	}{
+		{0, 10, 10, TurnStraight},
+		{350, 10, 20, TurnStraight},
+		{270, 0, 90, TurnRight},
+		{0, 270, -90, TurnLeft},
+		{90, 270, 180, TurnUTurn},
+		{10, 200, -170, TurnUTurn},
+	}
+	for _, tc := range testCases {
+		angle := headingChange(tc.from, tc.to)
+		if angle != tc.angle {
+			t.Errorf("%v -> %v: expected angle %v, got %v", tc.from, tc.to, tc.angle, angle)
+		}
+		if turn := counter.classifyTurn(angle); turn != tc.turn {
+			t.Errorf("%v -> %v: expected %s, got %s", tc.from, tc.to, tc.turn, turn)
+		}
+	}
+}
+
+func TestNewTurningMovementCounter_Validation(t *testing.T) {
+	triangle := [][2]float64{{0, 0}, {1, 0}, {0, 1}}
+	for name, config := range map[string]TurningMovementConfig{
+		"no zones":       {},
+		"degenerate":     {Zones: []Zone{{Name: "a", Polygon: triangle[:2]}}},
+		"duplicate name": {Zones: []Zone{{Name: "a", Polygon: triangle}, {Name: "a", Polygon: triangle}}},
+		"window":         {Zones: []Zone{{Name: "a", Polygon: triangle}}, WindowSize: -1},
+		"tolerance":      {Zones: []Zone{{Name: "a", Polygon: triangle}}, StraightTolerance: 90},
+	} {
+		if _, err := NewTurningMovementCounter(config); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
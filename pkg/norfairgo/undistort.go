@@ -0,0 +1,178 @@
+package norfairgo
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Undistortion - Lens Distortion Correction of Detection Points
+// =============================================================================
+
+// DistortionModel selects the lens distortion model of CameraIntrinsics.
+type DistortionModel int
+
+const (
+	// DistortionRadialTangential is OpenCV's standard (Brown-Conrady) model with
+	// coefficients [k1, k2, p1, p2] or [k1, k2, p1, p2, k3].
+	DistortionRadialTangential DistortionModel = iota
+
+	// DistortionFisheye is OpenCV's fisheye (Kannala-Brandt) model with
+	// coefficients [k1, k2, k3, k4].
+	DistortionFisheye
+)
+
+// CameraIntrinsics describes a calibrated camera (e.g. from OpenCV's calibrateCamera).
+type CameraIntrinsics struct {
+	Fx, Fy float64 // Focal lengths in pixels
+	Cx, Cy float64 // Principal point in pixels
+
+	// Distortion coefficients, in OpenCV order for the model.
+	Distortion []float64
+
+	// Distortion model of the coefficients.
+	// Default: DistortionRadialTangential
+	Model DistortionModel
+}
+
+// Undistorter maps pixel coordinates between the distorted image and an ideal
+// pinhole camera with the same camera matrix.
+//
+// Only points are transformed, not whole frames, so correcting detections costs a
+// few operations per point instead of resampling every frame. Use it as
+// TrackerConfig.Undistorter so tracking (and any world-coordinate analytics) works
+// in undistorted coordinates, and DistortPoints to draw estimates on the original frame.
+type Undistorter struct {
+	intrinsics CameraIntrinsics
+	k          [5]float64 // Coefficients padded with zeros
+
+	// Fixed-point iterations used to invert the distortion.
+	// Default: 20
+	Iterations int
+}
+
+// NewUndistorter creates an Undistorter from camera intrinsics.
+//
+// Returns: Error if the focal lengths are not positive or the number of
+// coefficients does not match the model
+func NewUndistorter(intrinsics CameraIntrinsics) (*Undistorter, error) {
+	if intrinsics.Fx <= 0 || intrinsics.Fy <= 0 {
+		return nil, fmt.Errorf("focal lengths must be positive, got fx=%g fy=%g", intrinsics.Fx, intrinsics.Fy)
+	}
+
+	n := len(intrinsics.Distortion)
+	switch intrinsics.Model {
+	case DistortionRadialTangential:
+		if n != 0 && n != 4 && n != 5 {
+			return nil, fmt.Errorf("radial-tangential model expects 4 or 5 distortion coefficients, got %d", n)
+		}
+	case DistortionFisheye:
+		if n != 0 && n != 4 {
+			return nil, fmt.Errorf("fisheye model expects 4 distortion coefficients, got %d", n)
+		}
+	default:
+		return nil, fmt.Errorf("unknown distortion model %d", intrinsics.Model)
+	}
+
+	u := &Undistorter{intrinsics: intrinsics, Iterations: 20}
+	copy(u.k[:], intrinsics.Distortion)
+	return u, nil
+}
+
+// UndistortPoints returns the undistorted pixel coordinates of (n_points, 2+) points.
+// Extra dimensions are copied unchanged.
+func (u *Undistorter) UndistortPoints(points *mat.Dense) *mat.Dense {
+	return u.mapPoints(points, u.undistortNormalized)
+}
+
+// DistortPoints is the inverse of UndistortPoints, mapping undistorted pixel
+// coordinates (e.g. tracked object estimates) back onto the original frame.
+func (u *Undistorter) DistortPoints(points *mat.Dense) *mat.Dense {
+	return u.mapPoints(points, u.distortNormalized)
+}
+
+// UndistortDetection replaces a detection's points with their undistorted
+// coordinates (absolute points are reset to a copy). Detections are only
+// undistorted once, so they may be passed to Tracker.Update repeatedly.
+func (u *Undistorter) UndistortDetection(detection *Detection) {
+	if detection.undistorted {
+		return
+	}
+	detection.Points = u.UndistortPoints(detection.Points)
+	detection.AbsolutePoints = mat.DenseCopyOf(detection.Points)
+	detection.undistorted = true
+}
+
+// mapPoints applies a mapping of normalized image coordinates to pixel points.
+func (u *Undistorter) mapPoints(points *mat.Dense, mapping func(x, y float64) (float64, float64)) *mat.Dense {
+	in := u.intrinsics
+	result := mat.DenseCopyOf(points)
+	rows, _ := points.Dims()
+	for i := 0; i < rows; i++ {
+		x := (points.At(i, 0) - in.Cx) / in.Fx
+		y := (points.At(i, 1) - in.Cy) / in.Fy
+		x, y = mapping(x, y)
+		result.Set(i, 0, x*in.Fx+in.Cx)
+		result.Set(i, 1, y*in.Fy+in.Cy)
+	}
+	return result
+}
+
+// distortNormalized applies the distortion model to normalized coordinates.
+func (u *Undistorter) distortNormalized(x, y float64) (float64, float64) {
+	k := u.k
+	if u.intrinsics.Model == DistortionFisheye {
+		r := math.Hypot(x, y)
+		if r < 1e-12 {
+			return x, y
+		}
+		theta := math.Atan(r)
+		theta2 := theta * theta
+		thetaD := theta * (1 + theta2*(k[0]+theta2*(k[1]+theta2*(k[2]+theta2*k[3]))))
+		return x * thetaD / r, y * thetaD / r
+	}
+
+	r2 := x*x + y*y
+	radial := 1 + r2*(k[0]+r2*(k[1]+r2*k[4]))
+	dx := 2*k[2]*x*y + k[3]*(r2+2*x*x)
+	dy := k[2]*(r2+2*y*y) + 2*k[3]*x*y
+	return x*radial + dx, y*radial + dy
+}
+
+// undistortNormalized inverts the distortion model, as OpenCV's undistortPoints does.
+func (u *Undistorter) undistortNormalized(xd, yd float64) (float64, float64) {
+	k := u.k
+	if u.intrinsics.Model == DistortionFisheye {
+		thetaD := math.Hypot(xd, yd)
+		if thetaD < 1e-12 {
+			return xd, yd
+		}
+		// Newton's method on theta * (1 + k1 theta^2 + ...) = thetaD
+		theta := thetaD
+		for i := 0; i < u.Iterations; i++ {
+			theta2 := theta * theta
+			f := theta*(1+theta2*(k[0]+theta2*(k[1]+theta2*(k[2]+theta2*k[3])))) - thetaD
+			df := 1 + theta2*(3*k[0]+theta2*(5*k[1]+theta2*(7*k[2]+theta2*9*k[3])))
+			step := f / df
+			theta -= step
+			if math.Abs(step) < 1e-12 {
+				break
+			}
+		}
+		scale := math.Tan(theta) / thetaD
+		return xd * scale, yd * scale
+	}
+
+	x, y := xd, yd
+	for i := 0; i < u.Iterations; i++ {
+		r2 := x*x + y*y
+		icdist := 1 / (1 + r2*(k[0]+r2*(k[1]+r2*k[4])))
+		dx := 2*k[2]*x*y + k[3]*(r2+2*x*x)
+		dy := k[2]*(r2+2*y*y) + 2*k[3]*x*y
+		x = (xd - dx) * icdist
+		y = (yd - dy) * icdist
+	}
+	return x, y
+}
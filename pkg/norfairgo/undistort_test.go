@@ -0,0 +1,115 @@
+package norfairgo
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func newTestUndistorter(t *testing.T, model DistortionModel, distortion []float64) *Undistorter {
+	t.Helper()
+	u, err := NewUndistorter(CameraIntrinsics{
+		Fx: 800, Fy: 780, Cx: 640, Cy: 360,
+		Distortion: distortion,
+		Model:      model,
+	})
+	if err != nil {
+		t.Fatalf("NewUndistorter failed: %v", err)
+	}
+	return u
+}
+
+func TestUndistorter_KnownValue(t *testing.T) {
+	u, err := NewUndistorter(CameraIntrinsics{Fx: 100, Fy: 100, Distortion: []float64{-0.1, 0, 0, 0}})
+	if err != nil {
+		t.Fatalf("NewUndistorter failed: %v", err)
+	}
+
+	// Normalized x=0.5: r^2=0.25, distorted by (1 - 0.1 * 0.25) = 0.975
+	distorted := u.DistortPoints(mat.NewDense(1, 2, []float64{50, 0}))
+	if math.Abs(distorted.At(0, 0)-48.75) > 1e-9 || distorted.At(0, 1) != 0 {
+		t.Errorf("Expected (48.75, 0), got (%v, %v)", distorted.At(0, 0), distorted.At(0, 1))
+	}
+
+	undistorted := u.UndistortPoints(distorted)
+	if math.Abs(undistorted.At(0, 0)-50) > 1e-6 {
+		t.Errorf("Expected x=50 after undistortion, got %v", undistorted.At(0, 0))
+	}
+}
+
+func TestUndistorter_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		model      DistortionModel
+		distortion []float64
+	}{
+		{"radial-tangential", DistortionRadialTangential, []float64{-0.28, 0.07, 0.001, -0.0005, 0}},
+		{"fisheye", DistortionFisheye, []float64{0.05, -0.01, 0.002, -0.0003}},
+	}
+
+	points := mat.NewDense(4, 3, []float64{
+		640, 360, 1,
+		100, 50, 2,
+		1200, 700, 3,
+		900, 200, 4,
+	})
+
+	for _, tc := range tests {
+		u := newTestUndistorter(t, tc.model, tc.distortion)
+		roundTrip := u.DistortPoints(u.UndistortPoints(points))
+		for i := 0; i < 4; i++ {
+			for d := 0; d < 3; d++ {
+				if diff := math.Abs(roundTrip.At(i, d) - points.At(i, d)); diff > 1e-4 {
+					t.Errorf("%s: point %d dim %d off by %v after round trip", tc.name, i, d, diff)
+				}
+			}
+		}
+		if center := u.UndistortPoints(points); center.At(0, 0) != 640 || center.At(0, 1) != 360 {
+			t.Errorf("%s: expected the principal point to be fixed", tc.name)
+		}
+	}
+}
+
+func TestNewUndistorter_Validation(t *testing.T) {
+	invalid := []CameraIntrinsics{
+		{Fx: 0, Fy: 100},
+		{Fx: 100, Fy: 100, Distortion: []float64{0.1, 0.2, 0.3}},
+		{Fx: 100, Fy: 100, Distortion: []float64{0.1, 0.2, 0.3, 0.4, 0.5}, Model: DistortionFisheye},
+		{Fx: 100, Fy: 100, Model: DistortionModel(7)},
+	}
+	for i, intrinsics := range invalid {
+		if _, err := NewUndistorter(intrinsics); err == nil {
+			t.Errorf("Case %d: expected error", i)
+		}
+	}
+}
+
+func TestTracker_Undistorter(t *testing.T) {
+	u := newTestUndistorter(t, DistortionRadialTangential, []float64{-0.28, 0.07, 0, 0})
+	tracker, err := NewTracker(&TrackerConfig{
+		DistanceFunction:    DistanceByName("euclidean"),
+		DistanceThreshold:   20.0,
+		HitCounterMax:       5,
+		InitializationDelay: 0,
+		Undistorter:         u,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	det := newPointDetection(t, 100, 50)
+	expected := u.UndistortPoints(det.Points)
+
+	// The same detection passed twice is only undistorted once
+	tracker.Update([]*Detection{det}, 1, nil)
+	tracker.Update([]*Detection{det}, 1, nil)
+
+	if det.Points.At(0, 0) != expected.At(0, 0) || det.AbsolutePoints.At(0, 1) != expected.At(0, 1) {
+		t.Errorf("Expected undistorted points %v, got %v", mat.Formatted(expected), mat.Formatted(det.Points))
+	}
+	estimate := tracker.TrackedObjects[0].Estimate
+	if math.Abs(estimate.At(0, 0)-expected.At(0, 0)) > 1e-6 {
+		t.Errorf("Expected the estimate in undistorted coordinates, got %v", estimate.At(0, 0))
+	}
+}
@@ -2,12 +2,10 @@ package norfairgo
 
 import (
 	"fmt"
-	"image"
 	"log"
 	"os"
 	"sync"
 
-	"gocv.io/x/gocv"
 	"golang.org/x/term"
 	"gonum.org/v1/gonum/mat"
 )
@@ -59,74 +57,6 @@ func GetTerminalSize(defaultCols, defaultLines int) (cols, lines int) {
 	return defaultCols, defaultLines
 }
 
-// GetCutout extracts a rectangular region from an image based on the bounding box of points.
-// The cutout is defined by the minimum and maximum x and y coordinates in the points array.
-func GetCutout(points *mat.Dense, img gocv.Mat) gocv.Mat {
-	rows, cols := points.Dims()
-	if rows == 0 || cols < 2 {
-		// Return empty mat for invalid points
-		return gocv.NewMat()
-	}
-
-	// Find bounding box
-	minX := points.At(0, 0)
-	maxX := points.At(0, 0)
-	minY := points.At(0, 1)
-	maxY := points.At(0, 1)
-
-	for i := 0; i < rows; i++ {
-		x := points.At(i, 0)
-		y := points.At(i, 1)
-
-		if x < minX {
-			minX = x
-		}
-		if x > maxX {
-			maxX = x
-		}
-		if y < minY {
-			minY = y
-		}
-		if y > maxY {
-			maxY = y
-		}
-	}
-
-	// Convert to integer coordinates
-	x1 := int(minX)
-	y1 := int(minY)
-	x2 := int(maxX) + 1 // +1 to include the max point
-	y2 := int(maxY) + 1
-
-	// Clamp to image bounds
-	imgHeight := img.Rows()
-	imgWidth := img.Cols()
-
-	if x1 < 0 {
-		x1 = 0
-	}
-	if y1 < 0 {
-		y1 = 0
-	}
-	if x2 > imgWidth {
-		x2 = imgWidth
-	}
-	if y2 > imgHeight {
-		y2 = imgHeight
-	}
-
-	// Check for valid region
-	if x1 >= x2 || y1 >= y2 {
-		// Return empty mat for invalid region
-		return gocv.NewMat()
-	}
-
-	// Extract region using gocv.Mat.Region()
-	rect := image.Rect(x1, y1, x2, y2)
-	region := img.Region(rect)
-	return region
-}
-
 // warnedMessages tracks which messages have been warned about (for warnOnce)
 var warnedMessages sync.Map
 
@@ -148,3 +78,19 @@ func AnyTrue(values []bool) bool {
 	}
 	return false
 }
+
+// PointInPolygon returns true if point (x, y) lies inside the polygon.
+// The polygon is a list of (x, y) vertices and is implicitly closed.
+// Uses the even-odd ray casting rule; points exactly on an edge may go either way.
+func PointInPolygon(x, y float64, polygon [][2]float64) bool {
+	inside := false
+	n := len(polygon)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		xi, yi := polygon[i][0], polygon[i][1]
+		xj, yj := polygon[j][0], polygon[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,146 @@
+//go:build !nogocv
+
+package norfairgo
+
+import (
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// GetCutout Tests
+// =============================================================================
+
+func TestGetCutout_CenterRegion(t *testing.T) {
+	// Create a 100x100 test image
+	img := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer img.Close()
+
+	// Set entire image to black
+	img.SetTo(gocv.NewScalar(0, 0, 0, 0))
+
+	// Define points in center region (20,20) to (80,80)
+	points := mat.NewDense(4, 2, []float64{
+		20.0, 20.0,
+		80.0, 20.0,
+		80.0, 80.0,
+		20.0, 80.0,
+	})
+
+	// Extract cutout
+	cutout := GetCutout(points, img)
+	defer cutout.Close()
+
+	// Verify cutout dimensions
+	// Region should be from (20,20) to (81,81) = 61x61
+	if cutout.Rows() != 61 || cutout.Cols() != 61 {
+		t.Errorf("Expected cutout size 61x61, got %dx%d", cutout.Rows(), cutout.Cols())
+	}
+}
+
+func TestGetCutout_CornerRegion(t *testing.T) {
+	// Create a 100x100 test image
+	img := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer img.Close()
+
+	// Define points in top-left corner (0,0) to (30,30)
+	points := mat.NewDense(2, 2, []float64{
+		0.0, 0.0,
+		30.0, 30.0,
+	})
+
+	// Extract cutout
+	cutout := GetCutout(points, img)
+	defer cutout.Close()
+
+	// Verify cutout dimensions
+	// Region should be from (0,0) to (31,31) = 31x31
+	if cutout.Rows() != 31 || cutout.Cols() != 31 {
+		t.Errorf("Expected cutout size 31x31, got %dx%d", cutout.Rows(), cutout.Cols())
+	}
+}
+
+func TestGetCutout_SinglePoint(t *testing.T) {
+	// Create a 100x100 test image
+	img := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer img.Close()
+
+	// Define single point at (50,50)
+	points := mat.NewDense(1, 2, []float64{50.0, 50.0})
+
+	// Extract cutout
+	cutout := GetCutout(points, img)
+	defer cutout.Close()
+
+	// Single point should create 1x1 region from (50,50) to (51,51)
+	if cutout.Rows() != 1 || cutout.Cols() != 1 {
+		t.Errorf("Expected cutout size 1x1 for single point, got %dx%d", cutout.Rows(), cutout.Cols())
+	}
+}
+
+func TestGetCutout_OutOfBounds(t *testing.T) {
+	// Create a 100x100 test image
+	img := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer img.Close()
+
+	// Define points that extend beyond image bounds
+	points := mat.NewDense(4, 2, []float64{
+		-10.0, -10.0, // Out of bounds (negative)
+		50.0, 50.0, // In bounds
+		150.0, 50.0, // Out of bounds (too large)
+		50.0, 150.0, // Out of bounds (too large)
+	})
+
+	// Extract cutout
+	cutout := GetCutout(points, img)
+	defer cutout.Close()
+
+	// Region should be clamped to (0,0) to (100,100) = full image
+	if cutout.Rows() != 100 || cutout.Cols() != 100 {
+		t.Errorf("Expected cutout size 100x100 (clamped), got %dx%d", cutout.Rows(), cutout.Cols())
+	}
+}
+
+func TestGetCutout_LargeRegion(t *testing.T) {
+	// Create a 200x200 test image
+	img := gocv.NewMatWithSize(200, 200, gocv.MatTypeCV8UC3)
+	defer img.Close()
+
+	// Define points covering most of the image
+	points := mat.NewDense(4, 2, []float64{
+		10.0, 10.0,
+		190.0, 10.0,
+		190.0, 190.0,
+		10.0, 190.0,
+	})
+
+	// Extract cutout
+	cutout := GetCutout(points, img)
+	defer cutout.Close()
+
+	// Verify large cutout dimensions
+	// Region should be from (10,10) to (191,191) = 181x181
+	if cutout.Rows() != 181 || cutout.Cols() != 181 {
+		t.Errorf("Expected cutout size 181x181, got %dx%d", cutout.Rows(), cutout.Cols())
+	}
+}
+
+func TestGetCutout_InvalidPoints(t *testing.T) {
+	// Create a 100x100 test image
+	img := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer img.Close()
+
+	// Points with only 1 column (invalid)
+	points := mat.NewDense(3, 1, []float64{10.0, 20.0, 30.0})
+
+	// Extract cutout
+	cutout := GetCutout(points, img)
+	defer cutout.Close()
+
+	// Should return empty mat
+	if !cutout.Empty() {
+		t.Error("Expected empty mat for invalid points")
+	}
+}
@@ -1,8 +1,11 @@
+//go:build !nogocv
+
 package norfairgo
 
 import (
 	"fmt"
 	"image"
+	"iter"
 	"os"
 	"path/filepath"
 	"strings"
@@ -126,11 +129,34 @@ func NewVideo(opts VideoOptions) (*Video, error) {
 
 // Frames returns a channel that yields video frames.
 // The channel is closed when all frames have been read or an error occurs.
+//
+// The consumer must read until the channel is closed, otherwise the reading
+// goroutine leaks; use All to stop early.
 func (v *Video) Frames() <-chan gocv.Mat {
 	frames := make(chan gocv.Mat)
 
 	go func() {
 		defer close(frames)
+		for _, frame := range v.All() {
+			frames <- frame
+		}
+	}()
+
+	return frames
+}
+
+// All returns an iterator over the 1-indexed frame numbers and frames of the video,
+// an alternative to Frames without a goroutine: breaking out of the loop stops
+// reading and releases the capture. The caller owns (and must Close) each frame.
+//
+// Example:
+//
+//	for i, frame := range video.All() {
+//	    ...
+//	    frame.Close()
+//	}
+func (v *Video) All() iter.Seq2[int, gocv.Mat] {
+	return func(yield func(int, gocv.Mat) bool) {
 		defer v.cleanup()
 
 		v.startTime = time.Now()
@@ -143,22 +169,22 @@ func (v *Video) Frames() <-chan gocv.Mat {
 			frame := gocv.NewMat()
 			if ok := v.videoCapture.Read(&frame); !ok {
 				frame.Close()
-				break
+				return
 			}
 
 			if frame.Empty() {
 				frame.Close()
-				break
+				return
 			}
 
 			v.frameCounter++
 			v.updateProgressBar()
 
-			frames <- frame
+			if !yield(v.frameCounter, frame) {
+				return
+			}
 		}
-	}()
-
-	return frames
+	}
 }
 
 // Write writes a frame to the output video.
@@ -424,12 +450,27 @@ func NewVideoFromFrames(inputPath, savePath string, makeVideo bool) (*VideoFromF
 }
 
 // Frames returns a channel that yields frames from the image sequence.
+//
+// The consumer must read until the channel is closed, otherwise the reading
+// goroutine leaks; use All to stop early.
 func (vff *VideoFromFrames) Frames() <-chan gocv.Mat {
 	frames := make(chan gocv.Mat)
 
 	go func() {
 		defer close(frames)
+		for _, frame := range vff.All() {
+			frames <- frame
+		}
+	}()
+
+	return frames
+}
 
+// All returns an iterator over the 1-indexed frame numbers and frames of the image
+// sequence, skipping unreadable images. Breaking out of the loop stops reading.
+// The caller owns (and must Close) each frame.
+func (vff *VideoFromFrames) All() iter.Seq2[int, gocv.Mat] {
+	return func(yield func(int, gocv.Mat) bool) {
 		for i := 1; i <= vff.length; i++ {
 			// Frame path: {inputPath}/{imDir}/{frame:06d}{imExt}
 			framePath := filepath.Join(vff.inputPath, vff.imDir, fmt.Sprintf("%06d%s", i, vff.imExt))
@@ -442,11 +483,11 @@ func (vff *VideoFromFrames) Frames() <-chan gocv.Mat {
 			}
 
 			vff.frameNumber = i
-			frames <- frame
+			if !yield(i, frame) {
+				return
+			}
 		}
-	}()
-
-	return frames
+	}
 }
 
 // Update writes a frame to the video if makeVideo is true.
@@ -0,0 +1,287 @@
+package norfairgo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// =============================================================================
+// Zone Occupancy - Dwell Times and Queue Lengths Per Zone
+// =============================================================================
+
+// ZoneOccupancyConfig configures a ZoneOccupancy.
+type ZoneOccupancyConfig struct {
+	// Zones to monitor, e.g. queues or shelves. Zones may overlap: an object counts
+	// in every zone containing its reference point.
+	// Required (unique names, at least 3 vertices each).
+	Zones []Zone
+
+	// Point of each object that is tested against the zones.
+	// Default: ReferenceBottomCenter
+	Reference ReferencePointMode
+
+	// Frame rate used to report times in seconds.
+	// Default: 0 (seconds are not reported)
+	FPS float64
+}
+
+// ZoneStats summarizes the occupancy of one zone.
+type ZoneStats struct {
+	Zone                string
+	Visits              int     // Completed visits (see ZoneOccupancy.Flush)
+	AverageDwell        float64 // Mean visit length in updates, 0 without visits
+	MaxDwell            int64   // Longest visit in updates
+	MaxConcurrency      int     // Most objects inside at once
+	MaxConcurrencyFrame int64   // First update with MaxConcurrency objects inside
+	AverageOccupancy    float64 // Mean number of objects inside per update
+}
+
+// ZoneOccupancyBin is the number of objects inside a zone over a bin of updates.
+type ZoneOccupancyBin struct {
+	Start, End int64 // First and last update of the bin
+	Zone       string
+	Mean       float64 // Mean number of objects inside per update
+	Max        int     // Most objects inside at once
+}
+
+// zoneVisit is an ongoing visit of an object to a zone.
+type zoneVisit struct {
+	start, last int64
+}
+
+// ZoneOccupancy aggregates, per zone and update, the number of tracked objects
+// inside, and the dwell time of each visit, e.g. for queue-length and retail
+// analytics. A visit starts on the first update an object is inside a zone and ends
+// when it is outside or no longer passed to Update; its dwell time is the number of
+// updates from its first to its last one inside.
+type ZoneOccupancy struct {
+	config      ZoneOccupancyConfig
+	frameNumber int64
+	counts      [][]int // Objects inside per update, then per zone
+	visits      []map[int]*zoneVisit
+	dwells      [][]int64 // Completed visit lengths per zone
+}
+
+// NewZoneOccupancy creates a new ZoneOccupancy.
+//
+// Returns: Error if there are no zones, a zone has fewer than 3 vertices or a
+// duplicate name, or FPS is negative
+func NewZoneOccupancy(config ZoneOccupancyConfig) (*ZoneOccupancy, error) {
+	if len(config.Zones) == 0 {
+		return nil, fmt.Errorf("zone occupancy requires at least one zone")
+	}
+	names := make(map[string]bool, len(config.Zones))
+	for _, zone := range config.Zones {
+		if len(zone.Polygon) < 3 {
+			return nil, fmt.Errorf("zone %q must have at least 3 vertices, got %d", zone.Name, len(zone.Polygon))
+		}
+		if names[zone.Name] {
+			return nil, fmt.Errorf("duplicate zone name %q", zone.Name)
+		}
+		names[zone.Name] = true
+	}
+	if config.FPS < 0 {
+		return nil, fmt.Errorf("fps must be >= 0, got %v", config.FPS)
+	}
+
+	visits := make([]map[int]*zoneVisit, len(config.Zones))
+	for i := range visits {
+		visits[i] = make(map[int]*zoneVisit)
+	}
+	return &ZoneOccupancy{
+		config: config,
+		visits: visits,
+		dwells: make([][]int64, len(config.Zones)),
+	}, nil
+}
+
+// Update records which zones the current objects are in and returns the number of
+// objects inside each zone, in the order of the zones.
+func (z *ZoneOccupancy) Update(objects []TrackedObjectLike) []int {
+	z.frameNumber++
+
+	counts := make([]int, len(z.config.Zones))
+	for _, obj := range objects {
+		id := obj.GetID()
+		if id == nil {
+			continue
+		}
+		estimate, err := obj.GetEstimate(false)
+		if err != nil {
+			continue
+		}
+		point := z.config.Reference.Extract(estimate)
+		for i, zone := range z.config.Zones {
+			if !PointInPolygon(point[0], point[1], zone.Polygon) {
+				continue
+			}
+			counts[i]++
+			if visit := z.visits[i][*id]; visit != nil {
+				visit.last = z.frameNumber
+			} else {
+				z.visits[i][*id] = &zoneVisit{start: z.frameNumber, last: z.frameNumber}
+			}
+		}
+	}
+
+	// Visits not refreshed on this update have ended
+	for i, visits := range z.visits {
+		for id, visit := range visits {
+			if visit.last != z.frameNumber {
+				z.dwells[i] = append(z.dwells[i], visit.last-visit.start+1)
+				delete(visits, id)
+			}
+		}
+	}
+
+	z.counts = append(z.counts, counts)
+	return append([]int{}, counts...)
+}
+
+// Flush ends all ongoing visits, e.g. at the end of a video, so that they are
+// included in Stats.
+func (z *ZoneOccupancy) Flush() {
+	for i, visits := range z.visits {
+		for _, visit := range visits {
+			z.dwells[i] = append(z.dwells[i], visit.last-visit.start+1)
+		}
+		clear(visits)
+	}
+}
+
+// Stats returns the occupancy summary of each zone, in the order of the zones.
+func (z *ZoneOccupancy) Stats() []ZoneStats {
+	stats := make([]ZoneStats, len(z.config.Zones))
+	for i, zone := range z.config.Zones {
+		s := ZoneStats{Zone: zone.Name, Visits: len(z.dwells[i])}
+		var totalDwell int64
+		for _, dwell := range z.dwells[i] {
+			totalDwell += dwell
+			if dwell > s.MaxDwell {
+				s.MaxDwell = dwell
+			}
+		}
+		if s.Visits > 0 {
+			s.AverageDwell = float64(totalDwell) / float64(s.Visits)
+		}
+
+		total := 0
+		for frame, counts := range z.counts {
+			total += counts[i]
+			if counts[i] > s.MaxConcurrency {
+				s.MaxConcurrency = counts[i]
+				s.MaxConcurrencyFrame = int64(frame) + 1
+			}
+		}
+		if len(z.counts) > 0 {
+			s.AverageOccupancy = float64(total) / float64(len(z.counts))
+		}
+		stats[i] = s
+	}
+	return stats
+}
+
+// Series returns the occupancy of every zone over consecutive bins of binSize
+// updates (1 for per-frame values, the frame rate for per-second values), ordered
+// by bin and then by zone. The last bin may be shorter.
+//
+// Returns: Error if binSize is not positive
+func (z *ZoneOccupancy) Series(binSize int64) ([]ZoneOccupancyBin, error) {
+	if binSize <= 0 {
+		return nil, fmt.Errorf("bin size must be > 0, got %d", binSize)
+	}
+	var series []ZoneOccupancyBin
+	numFrames := int64(len(z.counts))
+	for start := int64(0); start < numFrames; start += binSize {
+		end := start + binSize
+		if end > numFrames {
+			end = numFrames
+		}
+		for i, zone := range z.config.Zones {
+			bin := ZoneOccupancyBin{Start: start + 1, End: end, Zone: zone.Name}
+			total := 0
+			for _, counts := range z.counts[start:end] {
+				total += counts[i]
+				if counts[i] > bin.Max {
+					bin.Max = counts[i]
+				}
+			}
+			bin.Mean = float64(total) / float64(end-start)
+			series = append(series, bin)
+		}
+	}
+	return series, nil
+}
+
+// WriteSeriesCSV writes Series(binSize) as CSV, one bin and zone per row. With an
+// FPS, the start time of each bin in seconds (update 1 at 0) is added.
+//
+// Format: start_frame,end_frame[,start_seconds],zone,mean,max
+func (z *ZoneOccupancy) WriteSeriesCSV(w io.Writer, binSize int64) error {
+	series, err := z.Series(binSize)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	header := []string{"start_frame", "end_frame"}
+	if z.config.FPS > 0 {
+		header = append(header, "start_seconds")
+	}
+	if err := writer.Write(append(header, "zone", "mean", "max")); err != nil {
+		return fmt.Errorf("failed to write zone occupancy header: %w", err)
+	}
+	for _, bin := range series {
+		record := []string{strconv.FormatInt(bin.Start, 10), strconv.FormatInt(bin.End, 10)}
+		if z.config.FPS > 0 {
+			record = append(record, formatFloat(z.seconds(bin.Start-1)))
+		}
+		record = append(record, bin.Zone, formatFloat(bin.Mean), strconv.Itoa(bin.Max))
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write zone occupancy: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteStatsCSV writes Stats as CSV, one zone per row. With an FPS, dwell times in
+// seconds are added.
+//
+// Format: zone,visits,average_dwell_frames,max_dwell_frames[,average_dwell_seconds,max_dwell_seconds],max_concurrency,max_concurrency_frame,average_occupancy
+func (z *ZoneOccupancy) WriteStatsCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"zone", "visits", "average_dwell_frames", "max_dwell_frames"}
+	if z.config.FPS > 0 {
+		header = append(header, "average_dwell_seconds", "max_dwell_seconds")
+	}
+	header = append(header, "max_concurrency", "max_concurrency_frame", "average_occupancy")
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write zone stats header: %w", err)
+	}
+	for _, s := range z.Stats() {
+		record := []string{s.Zone, strconv.Itoa(s.Visits), formatFloat(s.AverageDwell), strconv.FormatInt(s.MaxDwell, 10)}
+		if z.config.FPS > 0 {
+			record = append(record, formatFloat(s.AverageDwell/z.config.FPS), formatFloat(z.seconds(s.MaxDwell)))
+		}
+		record = append(record, strconv.Itoa(s.MaxConcurrency), strconv.FormatInt(s.MaxConcurrencyFrame, 10), formatFloat(s.AverageOccupancy))
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write zone stats: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// seconds converts a number of updates to seconds.
+func (z *ZoneOccupancy) seconds(frames int64) float64 {
+	return float64(frames) / z.config.FPS
+}
+
+// formatFloat formats v with up to 6 decimals, trimming trailing zeros.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
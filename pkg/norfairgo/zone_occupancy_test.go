@@ -0,0 +1,122 @@
+package norfairgo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nmichlo/norfair-go/internal/testutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+// pointTrack returns a track of id whose box has its bottom center at (x, y).
+func pointTrack(id int, x, y float64) TrackedObjectLike {
+	return &boxTrack{id: id, box: mat.NewDense(2, 2, []float64{x - 5, y - 10, x + 5, y})}
+}
+
+func TestZoneOccupancy(t *testing.T) {
+	square := func(x0 float64) [][2]float64 {
+		return [][2]float64{{x0, 0}, {x0 + 100, 0}, {x0 + 100, 100}, {x0, 100}}
+	}
+	occupancy, err := NewZoneOccupancy(ZoneOccupancyConfig{
+		Zones: []Zone{{Name: "queue", Polygon: square(0)}, {Name: "counter", Polygon: square(200)}},
+		FPS:   2,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create zone occupancy: %v", err)
+	}
+
+	// Object 1 waits in the queue for 3 updates, then moves to the counter for 2.
+	// Object 2 joins the queue on update 2 and stays.
+	frames := [][]TrackedObjectLike{
+		{pointTrack(1, 50, 50)},
+		{pointTrack(1, 50, 50), pointTrack(2, 20, 50)},
+		{pointTrack(1, 60, 50), pointTrack(2, 20, 50)},
+		{pointTrack(1, 250, 50), pointTrack(2, 30, 50)},
+		{pointTrack(1, 250, 50), pointTrack(2, 30, 50)},
+		{pointTrack(2, 30, 50)},
+	}
+	expectedCounts := [][]int{{1, 0}, {2, 0}, {2, 0}, {1, 1}, {1, 1}, {1, 0}}
+	for i, objects := range frames {
+		counts := occupancy.Update(objects)
+		if counts[0] != expectedCounts[i][0] || counts[1] != expectedCounts[i][1] {
+			t.Errorf("Update %d: expected counts %v, got %v", i+1, expectedCounts[i], counts)
+		}
+	}
+
+	// Before flushing, only the visits of object 1 have ended
+	stats := occupancy.Stats()
+	if stats[0].Visits != 1 || stats[0].MaxDwell != 3 || stats[1].Visits != 1 || stats[1].MaxDwell != 2 {
+		t.Errorf("Expected one visit of 3 and one of 2 updates, got %+v", stats)
+	}
+	occupancy.Flush()
+
+	stats = occupancy.Stats()
+	queue, counter := stats[0], stats[1]
+	if queue.Zone != "queue" || queue.Visits != 2 || queue.MaxDwell != 5 {
+		t.Errorf("Expected 2 queue visits, the longest of 5 updates, got %+v", queue)
+	}
+	testutil.AssertAlmostEqual(t, queue.AverageDwell, 4.0, 1e-9, "queue average dwell")
+	testutil.AssertAlmostEqual(t, queue.AverageOccupancy, 8.0/6.0, 1e-9, "queue average occupancy")
+	if queue.MaxConcurrency != 2 || queue.MaxConcurrencyFrame != 2 {
+		t.Errorf("Expected max queue length 2 first on update 2, got %d on %d", queue.MaxConcurrency, queue.MaxConcurrencyFrame)
+	}
+	if counter.Visits != 1 || counter.MaxConcurrency != 1 || counter.MaxConcurrencyFrame != 4 {
+		t.Errorf("Expected 1 counter visit starting on update 4, got %+v", counter)
+	}
+
+	// Per-second series at 2 fps
+	series, err := occupancy.Series(2)
+	if err != nil {
+		t.Fatalf("Series failed: %v", err)
+	}
+	if len(series) != 6 {
+		t.Fatalf("Expected 3 bins of 2 zones, got %+v", series)
+	}
+	if bin := series[0]; bin.Start != 1 || bin.End != 2 || bin.Zone != "queue" || bin.Mean != 1.5 || bin.Max != 2 {
+		t.Errorf("Unexpected first bin %+v", bin)
+	}
+	if bin := series[5]; bin.Start != 5 || bin.End != 6 || bin.Zone != "counter" || bin.Mean != 0.5 || bin.Max != 1 {
+		t.Errorf("Unexpected last bin %+v", bin)
+	}
+	if _, err := occupancy.Series(0); err == nil {
+		t.Error("Expected error for bin size 0")
+	}
+
+	var buf bytes.Buffer
+	if err := occupancy.WriteSeriesCSV(&buf, 2); err != nil {
+		t.Fatalf("WriteSeriesCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "start_frame,end_frame,start_seconds,zone,mean,max" || lines[3] != "3,4,1,queue,1.5,2" || len(lines) != 7 {
+		t.Errorf("Unexpected series CSV:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := occupancy.WriteStatsCSV(&buf); err != nil {
+		t.Fatalf("WriteStatsCSV failed: %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		"zone,visits,average_dwell_frames,max_dwell_frames,average_dwell_seconds,max_dwell_seconds,max_concurrency,max_concurrency_frame,average_occupancy",
+		"queue,2,4,5,2,2.5,2,2,1.333333",
+		"counter,1,2,2,1,1,1,4,0.333333",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected stats CSV:\n%s", buf.String())
+	}
+}
+
+func TestNewZoneOccupancy_Validation(t *testing.T) {
+	triangle := [][2]float64{{0, 0}, {1, 0}, {0, 1}}
+	for name, config := range map[string]ZoneOccupancyConfig{
+		"no zones":       {},
+		"degenerate":     {Zones: []Zone{{Name: "a", Polygon: triangle[:2]}}},
+		"duplicate name": {Zones: []Zone{{Name: "a", Polygon: triangle}, {Name: "a", Polygon: triangle}}},
+		"fps":            {Zones: []Zone{{Name: "a", Polygon: triangle}}, FPS: -1},
+	} {
+		if _, err := NewZoneOccupancy(config); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
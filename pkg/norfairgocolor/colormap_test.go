@@ -0,0 +1,99 @@
+package norfairgocolor
+
+import (
+	"math"
+	"testing"
+)
+
+// ==============================================================================
+// Colormap Tests
+// ==============================================================================
+
+// TestColormap_Endpoints verifies values 0 and 1 map to the first and last stops
+func TestColormap_Endpoints(t *testing.T) {
+	testCases := []struct {
+		name string
+		cmap Colormap
+		low  Color
+		high Color
+	}{
+		{"jet", Jet, Color{B: 128, G: 0, R: 0}, Color{B: 0, G: 0, R: 128}},
+		{"viridis", Viridis, Color{B: 84, G: 1, R: 68}, Color{B: 37, G: 231, R: 253}},
+		{"magma", Magma, Color{B: 4, G: 0, R: 0}, Color{B: 191, G: 253, R: 252}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.cmap.At(0); got != tc.low {
+				t.Errorf("At(0) = %+v, want %+v", got, tc.low)
+			}
+			if got := tc.cmap.At(1); got != tc.high {
+				t.Errorf("At(1) = %+v, want %+v", got, tc.high)
+			}
+		})
+	}
+}
+
+// TestColormap_InterpolationAndClamping verifies linear interpolation and clamping
+func TestColormap_InterpolationAndClamping(t *testing.T) {
+	cmap, err := NewColormap("gray", []Color{Black, White})
+	if err != nil {
+		t.Fatalf("NewColormap failed: %v", err)
+	}
+
+	if got := cmap.At(0.5); got != (Color{B: 128, G: 128, R: 128}) {
+		t.Errorf("At(0.5) = %+v, want mid gray", got)
+	}
+	if got := cmap.At(-1); got != Black {
+		t.Errorf("At(-1) should clamp to Black, got %+v", got)
+	}
+	if got := cmap.At(2); got != White {
+		t.Errorf("At(2) should clamp to White, got %+v", got)
+	}
+	if got := cmap.At(math.NaN()); got != Black {
+		t.Errorf("At(NaN) should map to Black, got %+v", got)
+	}
+	if got := cmap.AtRange(15, 10, 20); got != cmap.At(0.5) {
+		t.Errorf("AtRange(15, 10, 20) = %+v, want %+v", got, cmap.At(0.5))
+	}
+
+	if _, err := NewColormap("bad", []Color{Black}); err == nil {
+		t.Error("Expected error for colormap with a single stop")
+	}
+}
+
+// TestColormap_WithGamma verifies gamma is applied without modifying the original
+func TestColormap_WithGamma(t *testing.T) {
+	gray, _ := NewColormap("gray", []Color{Black, White})
+	squared := gray.WithGamma(2)
+
+	if got := squared.At(0.5); got != gray.At(0.25) {
+		t.Errorf("gamma 2: At(0.5) = %+v, want %+v", got, gray.At(0.25))
+	}
+	if gray.Gamma() != 1 || squared.Gamma() != 2 {
+		t.Errorf("Expected gammas 1 and 2, got %v and %v", gray.Gamma(), squared.Gamma())
+	}
+	if gray.WithGamma(0).Gamma() != 1 {
+		t.Error("Non-positive gamma should be treated as 1")
+	}
+}
+
+// TestColormapByName verifies built-in colormap lookup
+func TestColormapByName(t *testing.T) {
+	cmap, err := ColormapByName("Viridis")
+	if err != nil {
+		t.Fatalf("ColormapByName(Viridis) failed: %v", err)
+	}
+	if cmap.Name() != "viridis" {
+		t.Errorf("Expected name viridis, got %s", cmap.Name())
+	}
+
+	if _, err := ColormapByName("unknown"); err == nil {
+		t.Error("Expected error for unknown colormap")
+	}
+
+	names := ColormapNames()
+	if len(names) != 3 || names[0] != "jet" || names[1] != "magma" || names[2] != "viridis" {
+		t.Errorf("Unexpected colormap names: %v", names)
+	}
+}
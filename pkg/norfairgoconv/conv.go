@@ -0,0 +1,157 @@
+//go:build !nogocv
+
+/*
+Package norfairgoconv converts between gonum matrices and gocv Mats.
+
+gonum matrices are single-channel, so multi-channel Mats are flattened: a Mat
+with R rows, C columns and K channels converts to an (R, C*K) matrix whose rows
+interleave the channels, e.g. a CV_8UC3 image row [b0, g0, r0, b1, g1, r1, ...].
+Point lists use the layout OpenCV functions such as FindHomography expect: an
+(N, 2) matrix converts to an N x 1 CV_32FC2 Mat.
+
+	import conv "github.com/nmichlo/norfair-go/pkg/norfairgoconv"
+
+	pts, err := conv.PointsToMat(points) // (N, 2) -> N x 1 CV_32FC2
+	defer pts.Close()
+	homography := gocv.FindHomography(pts, ...)
+	h, err := conv.MatToDense(homography) // 3x3 CV_64F -> (3, 3)
+
+Supported depths are CV_8U (values are rounded and clamped to [0, 255]),
+CV_32F and CV_64F.
+*/
+package norfairgoconv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+)
+
+// matDepthMask extracts the depth (CV_8U, CV_32F, ...) from a MatType.
+const matDepthMask = 7
+
+// DenseToMat converts m to a Mat of type mt. For a type with K channels, the
+// columns of m are interleaved channels and must be a multiple of K.
+// The caller must Close the returned Mat.
+func DenseToMat(m *mat.Dense, mt gocv.MatType) (gocv.Mat, error) {
+	rows, cols := m.Dims()
+	channels := matChannels(mt)
+	if cols%channels != 0 {
+		return gocv.NewMat(), fmt.Errorf("matrix columns (%d) must be a multiple of the %d channels of the Mat type", cols, channels)
+	}
+
+	data := make([]float64, 0, rows*cols)
+	for i := 0; i < rows; i++ {
+		data = append(data, m.RawRowView(i)...)
+	}
+
+	var bytes []byte
+	switch mt & matDepthMask {
+	case gocv.MatTypeCV8U:
+		bytes = make([]byte, len(data))
+		for i, v := range data {
+			bytes[i] = uint8(math.Max(0, math.Min(255, math.Round(v))))
+		}
+	case gocv.MatTypeCV32F:
+		float32s := make([]float32, len(data))
+		for i, v := range data {
+			float32s[i] = float32(v)
+		}
+		bytes = Float32sToBytes(float32s)
+	case gocv.MatTypeCV64F:
+		bytes = Float64sToBytes(data)
+	default:
+		return gocv.NewMat(), fmt.Errorf("unsupported Mat type %v, expected a CV_8U, CV_32F or CV_64F type", mt)
+	}
+	return gocv.NewMatFromBytes(rows, cols/channels, mt, bytes)
+}
+
+// MatToDense converts m to an (R, C*K) matrix for a Mat with R rows, C columns
+// and K channels. Non-continuous Mats (e.g. regions) are supported.
+func MatToDense(m gocv.Mat) (*mat.Dense, error) {
+	if m.Empty() {
+		return nil, fmt.Errorf("cannot convert an empty Mat")
+	}
+	if !m.IsContinuous() {
+		continuous := m.Clone()
+		defer continuous.Close()
+		m = continuous
+	}
+
+	rows, cols := m.Rows(), m.Cols()*m.Channels()
+	bytes := m.ToBytes()
+	data := make([]float64, rows*cols)
+	switch m.Type() & matDepthMask {
+	case gocv.MatTypeCV8U:
+		for i := range data {
+			data[i] = float64(bytes[i])
+		}
+	case gocv.MatTypeCV32F:
+		for i, v := range BytesToFloat32s(bytes[:4*len(data)]) {
+			data[i] = float64(v)
+		}
+	case gocv.MatTypeCV64F:
+		copy(data, BytesToFloat64s(bytes[:8*len(data)]))
+	default:
+		return nil, fmt.Errorf("unsupported Mat type %v, expected a CV_8U, CV_32F or CV_64F type", m.Type())
+	}
+	return mat.NewDense(rows, cols, data), nil
+}
+
+// PointsToMat converts (N, 2) points to an N x 1 CV_32FC2 Mat, the point layout
+// of OpenCV functions like FindHomography and CalcOpticalFlowPyrLK.
+// The caller must Close the returned Mat.
+func PointsToMat(points *mat.Dense) (gocv.Mat, error) {
+	if _, cols := points.Dims(); cols != 2 {
+		return gocv.NewMat(), fmt.Errorf("points must have shape (N, 2), got %d columns", cols)
+	}
+	return DenseToMat(points, gocv.MatTypeCV32FC2)
+}
+
+// Float32sToBytes encodes data as little-endian bytes, the layout of
+// gocv.NewMatFromBytes for CV_32F Mats.
+func Float32sToBytes(data []float32) []byte {
+	bytes := make([]byte, len(data)*4)
+	for i, v := range data {
+		binary.LittleEndian.PutUint32(bytes[i*4:], math.Float32bits(v))
+	}
+	return bytes
+}
+
+// Float64sToBytes encodes data as little-endian bytes, the layout of
+// gocv.NewMatFromBytes for CV_64F Mats.
+func Float64sToBytes(data []float64) []byte {
+	bytes := make([]byte, len(data)*8)
+	for i, v := range data {
+		binary.LittleEndian.PutUint64(bytes[i*8:], math.Float64bits(v))
+	}
+	return bytes
+}
+
+// BytesToFloat32s decodes little-endian bytes, e.g. from Mat.ToBytes of a CV_32F Mat.
+// Trailing bytes that do not form a whole float32 are ignored.
+func BytesToFloat32s(bytes []byte) []float32 {
+	data := make([]float32, len(bytes)/4)
+	for i := range data {
+		data[i] = math.Float32frombits(binary.LittleEndian.Uint32(bytes[i*4:]))
+	}
+	return data
+}
+
+// BytesToFloat64s decodes little-endian bytes, e.g. from Mat.ToBytes of a CV_64F Mat.
+// Trailing bytes that do not form a whole float64 are ignored.
+func BytesToFloat64s(bytes []byte) []float64 {
+	data := make([]float64, len(bytes)/8)
+	for i := range data {
+		data[i] = math.Float64frombits(binary.LittleEndian.Uint64(bytes[i*8:]))
+	}
+	return data
+}
+
+// matChannels returns the number of channels of a MatType.
+func matChannels(mt gocv.MatType) int {
+	return int(mt)>>3 + 1
+}
@@ -0,0 +1,140 @@
+//go:build !nogocv
+
+package norfairgoconv
+
+import (
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDenseToMat_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mt       gocv.MatType
+		rows     int
+		cols     int
+		channels int
+	}{
+		{"CV_8UC1", gocv.MatTypeCV8UC1, 2, 3, 1},
+		{"CV_8UC3", gocv.MatTypeCV8UC3, 2, 2, 3},
+		{"CV_32FC1", gocv.MatTypeCV32FC1, 3, 3, 1},
+		{"CV_32FC2", gocv.MatTypeCV32FC2, 4, 1, 2},
+		{"CV_32FC3", gocv.MatTypeCV32FC3, 1, 2, 3},
+		{"CV_64FC1", gocv.MatTypeCV64FC1, 3, 3, 1},
+		{"CV_64FC2", gocv.MatTypeCV64FC2, 2, 2, 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			width := tc.cols * tc.channels
+			data := make([]float64, tc.rows*width)
+			for i := range data {
+				data[i] = float64(i*7%200) + 0.25
+			}
+			dense := mat.NewDense(tc.rows, width, data)
+
+			m, err := DenseToMat(dense, tc.mt)
+			if err != nil {
+				t.Fatalf("DenseToMat failed: %v", err)
+			}
+			defer m.Close()
+			if m.Rows() != tc.rows || m.Cols() != tc.cols || m.Channels() != tc.channels || m.Type() != tc.mt {
+				t.Fatalf("Expected %dx%d Mat with %d channels of type %v, got %dx%d with %d channels of type %v",
+					tc.rows, tc.cols, tc.channels, tc.mt, m.Rows(), m.Cols(), m.Channels(), m.Type())
+			}
+
+			back, err := MatToDense(m)
+			if err != nil {
+				t.Fatalf("MatToDense failed: %v", err)
+			}
+			if r, c := back.Dims(); r != tc.rows || c != width {
+				t.Fatalf("Expected (%d, %d), got (%d, %d)", tc.rows, width, r, c)
+			}
+			for i := 0; i < tc.rows; i++ {
+				for j := 0; j < width; j++ {
+					expected := dense.At(i, j)
+					if tc.mt&matDepthMask == gocv.MatTypeCV8U {
+						expected = float64(int(expected)) // 0.25 rounds down
+					}
+					if back.At(i, j) != expected {
+						t.Errorf("At(%d, %d): expected %v, got %v", i, j, expected, back.At(i, j))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDenseToMat_Errors(t *testing.T) {
+	if _, err := DenseToMat(mat.NewDense(2, 3, nil), gocv.MatTypeCV32FC2); err == nil {
+		t.Error("Expected error for columns that are not a multiple of the channels")
+	}
+	if _, err := DenseToMat(mat.NewDense(2, 2, nil), gocv.MatTypeCV16S); err == nil {
+		t.Error("Expected error for an unsupported depth")
+	}
+	if _, err := MatToDense(gocv.NewMat()); err == nil {
+		t.Error("Expected error for an empty Mat")
+	}
+}
+
+func TestDenseToMat_ClampsUint8(t *testing.T) {
+	m, err := DenseToMat(mat.NewDense(1, 3, []float64{-5, 127.6, 300}), gocv.MatTypeCV8UC1)
+	if err != nil {
+		t.Fatalf("DenseToMat failed: %v", err)
+	}
+	defer m.Close()
+	for j, expected := range []uint8{0, 128, 255} {
+		if got := m.GetUCharAt(0, j); got != expected {
+			t.Errorf("Column %d: expected %d, got %d", j, expected, got)
+		}
+	}
+}
+
+func TestPointsToMat(t *testing.T) {
+	points := mat.NewDense(3, 2, []float64{1.5, 2.5, 10, 20, -3, 4})
+	m, err := PointsToMat(points)
+	if err != nil {
+		t.Fatalf("PointsToMat failed: %v", err)
+	}
+	defer m.Close()
+	if m.Rows() != 3 || m.Cols() != 1 || m.Type() != gocv.MatTypeCV32FC2 {
+		t.Fatalf("Expected a 3x1 CV_32FC2 Mat, got %dx%d of type %v", m.Rows(), m.Cols(), m.Type())
+	}
+	if vec := m.GetVecfAt(1, 0); vec[0] != 10 || vec[1] != 20 {
+		t.Errorf("Expected point (10, 20), got %v", vec)
+	}
+
+	back, err := MatToDense(m)
+	if err != nil {
+		t.Fatalf("MatToDense failed: %v", err)
+	}
+	if !mat.Equal(back, points) {
+		t.Errorf("Expected %v, got %v", mat.Formatted(points), mat.Formatted(back))
+	}
+
+	if _, err := PointsToMat(mat.NewDense(2, 3, nil)); err == nil {
+		t.Error("Expected error for points that are not (N, 2)")
+	}
+}
+
+func TestBytesConversions(t *testing.T) {
+	float32s := []float32{0, 1.5, -2.25, 3e10}
+	for i, v := range BytesToFloat32s(Float32sToBytes(float32s)) {
+		if v != float32s[i] {
+			t.Errorf("float32 %d: expected %v, got %v", i, float32s[i], v)
+		}
+	}
+	float64s := []float64{0, 1.5, -2.25, 3e100}
+	for i, v := range BytesToFloat64s(Float64sToBytes(float64s)) {
+		if v != float64s[i] {
+			t.Errorf("float64 %d: expected %v, got %v", i, float64s[i], v)
+		}
+	}
+	if got := Float32sToBytes([]float32{1}); got[3] != 0x3f || got[2] != 0x80 {
+		t.Errorf("Expected little-endian float32 bytes, got %v", got)
+	}
+	if got := BytesToFloat32s(make([]byte, 6)); len(got) != 1 {
+		t.Errorf("Expected trailing bytes to be ignored, got %d values", len(got))
+	}
+}
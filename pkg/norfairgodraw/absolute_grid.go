@@ -1,3 +1,5 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
@@ -211,6 +213,71 @@ func DrawAbsoluteGridWithDefaults(frame *gocv.Mat, coordTransform norfairgo.Coor
 	DrawAbsoluteGrid(frame, coordTransform, 20, 2, 1, &Color{B: 0, G: 0, R: 0}, false)
 }
 
+// DrawAbsoluteAxes draws the x (red) and y (green) axes of a fixed world frame.
+//
+// Complements DrawAbsoluteGrid: with a perfect motion estimate the axes stay
+// glued to the scene, so any slow rotation or sliding of the axes over a long
+// sequence shows homography drift.
+//
+// Parameters:
+//   - frame: The OpenCV frame to draw on
+//   - coordTransform: The coordinate transformation from MotionEstimator (can be nil)
+//   - origin: Axes origin in absolute coordinates (nil = center of the frame)
+//   - length: Axis length in absolute pixels (default: a quarter of the smaller frame side)
+//   - thickness: Line thickness (default 2)
+func DrawAbsoluteAxes(
+	frame *gocv.Mat,
+	coordTransform norfairgo.CoordinateTransformation,
+	origin *image.Point,
+	length int,
+	thickness int,
+) {
+	if frame == nil {
+		return
+	}
+
+	h := frame.Rows()
+	w := frame.Cols()
+
+	// Set defaults
+	if origin == nil {
+		origin = &image.Point{X: w / 2, Y: h / 2}
+	}
+	if length <= 0 {
+		length = minInt(w, h) / 4
+	}
+	if thickness <= 0 {
+		thickness = 2
+	}
+
+	o, xEnd, yEnd := absoluteAxesEndpoints(coordTransform, *origin, length)
+
+	drawer := NewDrawer()
+	drawer.Line(frame, o, xEnd, Color{B: 0, G: 0, R: 255}, thickness)
+	drawer.Line(frame, o, yEnd, Color{B: 0, G: 255, R: 0}, thickness)
+}
+
+// absoluteAxesEndpoints returns the origin and x/y axis end points in relative coordinates.
+func absoluteAxesEndpoints(
+	coordTransform norfairgo.CoordinateTransformation,
+	origin image.Point,
+	length int,
+) (image.Point, image.Point, image.Point) {
+	points := mat.NewDense(3, 2, []float64{
+		float64(origin.X), float64(origin.Y),
+		float64(origin.X + length), float64(origin.Y),
+		float64(origin.X), float64(origin.Y + length),
+	})
+	if coordTransform != nil {
+		points = coordTransform.AbsToRel(points)
+	}
+
+	toPoint := func(i int) image.Point {
+		return image.Point{X: int(math.Round(points.At(i, 0))), Y: int(math.Round(points.At(i, 1)))}
+	}
+	return toPoint(0), toPoint(1), toPoint(2)
+}
+
 // ClearGridCache clears the grid generation cache (useful for testing).
 func ClearGridCache() {
 	gridCacheMutex.Lock()
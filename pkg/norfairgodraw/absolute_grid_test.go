@@ -1,3 +1,5 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
@@ -357,3 +359,34 @@ func TestGetGrid_LargeSize(t *testing.T) {
 		t.Errorf("Expected at least 100 points for large grid, got %d", rows)
 	}
 }
+
+// Test 16: Absolute axes end points follow the transform
+func TestAbsoluteAxesEndpoints(t *testing.T) {
+	origin := image.Point{X: 100, Y: 50}
+
+	o, xEnd, yEnd := absoluteAxesEndpoints(nil, origin, 20)
+	if o != origin || xEnd != (image.Point{X: 120, Y: 50}) || yEnd != (image.Point{X: 100, Y: 70}) {
+		t.Errorf("Unexpected identity axes: origin=%v x=%v y=%v", o, xEnd, yEnd)
+	}
+
+	transform := &mockFixedCameraTransform{offset: image.Point{X: 10, Y: -5}}
+	o, xEnd, yEnd = absoluteAxesEndpoints(transform, origin, 20)
+	if o != (image.Point{X: 110, Y: 45}) || xEnd != (image.Point{X: 130, Y: 45}) || yEnd != (image.Point{X: 110, Y: 65}) {
+		t.Errorf("Unexpected transformed axes: origin=%v x=%v y=%v", o, xEnd, yEnd)
+	}
+}
+
+// Test 17: DrawAbsoluteAxes with defaults and nil frame
+func TestDrawAbsoluteAxes_Defaults(t *testing.T) {
+	DrawAbsoluteAxes(nil, nil, nil, 0, 0)
+
+	frame := gocv.NewMatWithSize(480, 640, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	transform := &mockFixedCameraTransform{offset: image.Point{X: 10, Y: 10}}
+	DrawAbsoluteAxes(&frame, transform, nil, 0, 0)
+
+	if frame.Empty() {
+		t.Error("Frame should not be empty after drawing")
+	}
+}
@@ -0,0 +1,209 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"fmt"
+
+	"gocv.io/x/gocv"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// =============================================================================
+// Annotator - Composable Frame Overlays
+// =============================================================================
+
+// Annotator draws an overlay for tracked objects onto a frame in place.
+type Annotator interface {
+	Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject)
+}
+
+// AnnotatorFunc adapts a plain function to the Annotator interface.
+type AnnotatorFunc func(frame *gocv.Mat, objects []*norfairgo.TrackedObject)
+
+// Annotate calls f(frame, objects).
+func (f AnnotatorFunc) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	f(frame, objects)
+}
+
+// BoxesAnnotator draws bounding boxes (see DrawBoxes).
+type BoxesAnnotator struct {
+	Color      interface{} // Color or strategy (nil = "by_id")
+	Thickness  *int        // Line thickness (nil = auto)
+	TextSize   *float64    // Text size (nil = auto)
+	DrawLabels bool        // Draw object labels
+	DrawIDs    bool        // Draw object IDs
+	DrawScores bool        // Draw detection scores
+}
+
+// Annotate draws the boxes onto the frame.
+func (a *BoxesAnnotator) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	DrawBoxes(frame, toDrawables(objects), a.Color, a.Thickness, a.DrawLabels, a.TextSize,
+		a.DrawIDs, nil, nil, true, a.DrawScores)
+}
+
+// PointsAnnotator draws tracked points (see DrawPoints).
+type PointsAnnotator struct {
+	Color          interface{} // Color or strategy (nil = "by_id")
+	Radius         *int        // Point radius (nil = auto)
+	HideDeadPoints bool        // Skip points that are not live
+}
+
+// Annotate draws the points onto the frame.
+func (a *PointsAnnotator) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	DrawPoints(frame, toDrawables(objects), a.Radius, nil, a.Color, false, nil, false, true,
+		nil, nil, a.HideDeadPoints, false)
+}
+
+// IDsAnnotator draws object IDs (and optionally labels) without points or boxes.
+type IDsAnnotator struct {
+	Color      interface{} // Text color or strategy (nil = "by_id")
+	TextSize   *float64    // Text size (nil = auto)
+	DrawLabels bool        // Also draw object labels
+}
+
+// Annotate draws the IDs onto the frame.
+func (a *IDsAnnotator) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	DrawPoints(frame, toDrawables(objects), nil, nil, a.Color, a.DrawLabels, a.TextSize, true, false,
+		nil, nil, false, false)
+}
+
+// Annotate draws the motion trails onto the frame (Paths implements Annotator).
+func (p *Paths) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	result := p.Draw(frame, objects)
+	result.CopyTo(frame)
+	result.Close()
+}
+
+// AbsolutePathsAnnotator adapts AbsolutePaths to the Annotator interface.
+//
+// CoordTransform must be updated every frame with the transformation returned
+// by the MotionEstimator before the pipeline runs.
+type AbsolutePathsAnnotator struct {
+	Paths          *AbsolutePaths
+	CoordTransform norfairgo.CoordinateTransformation
+}
+
+// Annotate draws the absolute motion trails onto the frame.
+func (a *AbsolutePathsAnnotator) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	if a.Paths == nil || a.CoordTransform == nil {
+		return
+	}
+	a.Paths.Draw(frame, objects, a.CoordTransform)
+}
+
+// toDrawables converts tracked objects to the []interface{} accepted by DrawBoxes/DrawPoints.
+func toDrawables(objects []*norfairgo.TrackedObject) []interface{} {
+	drawables := make([]interface{}, len(objects))
+	for i, obj := range objects {
+		drawables[i] = obj
+	}
+	return drawables
+}
+
+// =============================================================================
+// Pipeline - Ordered Annotator Stack
+// =============================================================================
+
+// pipelineStage is a named annotator with an enable flag.
+type pipelineStage struct {
+	name      string
+	annotator Annotator
+	enabled   bool
+}
+
+// Pipeline composes annotators that are applied in insertion order.
+//
+// Stages are addressed by name so overlays can be toggled at runtime:
+//
+//	pipeline := NewPipeline().
+//		Add("paths", NewPathsWithOptions()).
+//		Add("boxes", &BoxesAnnotator{DrawIDs: true})
+//	pipeline.SetEnabled("paths", false)
+//	pipeline.Annotate(&frame, trackedObjects)
+//
+// Pipeline itself implements Annotator, so pipelines can be nested.
+type Pipeline struct {
+	stages []*pipelineStage
+}
+
+// NewPipeline creates an empty annotator pipeline.
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// Add appends an enabled annotator under the given name.
+// Adding an existing name replaces that stage's annotator, keeping its position.
+//
+// Returns: The pipeline, for chaining
+func (p *Pipeline) Add(name string, annotator Annotator) *Pipeline {
+	if stage := p.stage(name); stage != nil {
+		stage.annotator = annotator
+		return p
+	}
+	p.stages = append(p.stages, &pipelineStage{name: name, annotator: annotator, enabled: true})
+	return p
+}
+
+// Remove deletes the stage with the given name.
+//
+// Returns: False if no stage has that name
+func (p *Pipeline) Remove(name string) bool {
+	for i, stage := range p.stages {
+		if stage.name == name {
+			p.stages = append(p.stages[:i], p.stages[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SetEnabled enables or disables the stage with the given name.
+//
+// Returns: Error if no stage has that name
+func (p *Pipeline) SetEnabled(name string, enabled bool) error {
+	stage := p.stage(name)
+	if stage == nil {
+		return fmt.Errorf("annotator %q not found in pipeline", name)
+	}
+	stage.enabled = enabled
+	return nil
+}
+
+// Enabled reports whether the stage with the given name exists and is enabled.
+func (p *Pipeline) Enabled(name string) bool {
+	stage := p.stage(name)
+	return stage != nil && stage.enabled
+}
+
+// Names returns the stage names in application order.
+func (p *Pipeline) Names() []string {
+	names := make([]string, len(p.stages))
+	for i, stage := range p.stages {
+		names[i] = stage.name
+	}
+	return names
+}
+
+// Annotate applies every enabled stage to the frame in order.
+func (p *Pipeline) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	if frame == nil {
+		return
+	}
+	for _, stage := range p.stages {
+		if stage.enabled && stage.annotator != nil {
+			stage.annotator.Annotate(frame, objects)
+		}
+	}
+}
+
+// stage returns the stage with the given name, or nil.
+func (p *Pipeline) stage(name string) *pipelineStage {
+	for _, stage := range p.stages {
+		if stage.name == name {
+			return stage
+		}
+	}
+	return nil
+}
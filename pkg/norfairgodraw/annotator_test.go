@@ -0,0 +1,103 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"reflect"
+	"testing"
+
+	"gocv.io/x/gocv"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// recordingAnnotator appends its name to a shared call log
+func recordingAnnotator(name string, calls *[]string) AnnotatorFunc {
+	return func(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+		*calls = append(*calls, name)
+	}
+}
+
+// TestPipeline_OrderAndToggle verifies stages run in order and respect enable flags
+func TestPipeline_OrderAndToggle(t *testing.T) {
+	var calls []string
+	pipeline := NewPipeline().
+		Add("boxes", recordingAnnotator("boxes", &calls)).
+		Add("ids", recordingAnnotator("ids", &calls)).
+		Add("paths", recordingAnnotator("paths", &calls))
+
+	frame := gocv.NewMatWithSize(10, 10, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	pipeline.Annotate(&frame, nil)
+	if !reflect.DeepEqual(calls, []string{"boxes", "ids", "paths"}) {
+		t.Errorf("Expected all stages in order, got %v", calls)
+	}
+
+	calls = nil
+	if err := pipeline.SetEnabled("ids", false); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	pipeline.Annotate(&frame, nil)
+	if !reflect.DeepEqual(calls, []string{"boxes", "paths"}) {
+		t.Errorf("Expected disabled stage to be skipped, got %v", calls)
+	}
+	if pipeline.Enabled("ids") || !pipeline.Enabled("boxes") || pipeline.Enabled("missing") {
+		t.Error("Unexpected Enabled results")
+	}
+
+	if err := pipeline.SetEnabled("missing", true); err == nil {
+		t.Error("Expected error for unknown stage")
+	}
+}
+
+// TestPipeline_ReplaceAndRemove verifies name-based replacement and removal
+func TestPipeline_ReplaceAndRemove(t *testing.T) {
+	var calls []string
+	pipeline := NewPipeline().
+		Add("a", recordingAnnotator("a", &calls)).
+		Add("b", recordingAnnotator("b", &calls)).
+		Add("a", recordingAnnotator("a2", &calls))
+
+	if !reflect.DeepEqual(pipeline.Names(), []string{"a", "b"}) {
+		t.Errorf("Expected replacement to keep position, got %v", pipeline.Names())
+	}
+
+	frame := gocv.NewMatWithSize(10, 10, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+	pipeline.Annotate(&frame, nil)
+	if !reflect.DeepEqual(calls, []string{"a2", "b"}) {
+		t.Errorf("Expected replaced annotator to run, got %v", calls)
+	}
+
+	if !pipeline.Remove("a") || pipeline.Remove("a") {
+		t.Error("Expected Remove to succeed once")
+	}
+	if !reflect.DeepEqual(pipeline.Names(), []string{"b"}) {
+		t.Errorf("Expected only b to remain, got %v", pipeline.Names())
+	}
+}
+
+// TestPipeline_BuiltinAnnotators verifies the built-in annotators run on empty input
+func TestPipeline_BuiltinAnnotators(t *testing.T) {
+	frame := gocv.NewMatWithSize(120, 160, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	paths := NewPathsWithOptions()
+	defer paths.Close()
+
+	inner := NewPipeline().Add("ids", &IDsAnnotator{})
+	pipeline := NewPipeline().
+		Add("paths", paths).
+		Add("absolute", &AbsolutePathsAnnotator{Paths: NewAbsolutePathsWithOptions()}).
+		Add("boxes", &BoxesAnnotator{DrawIDs: true}).
+		Add("points", &PointsAnnotator{}).
+		Add("nested", inner)
+
+	pipeline.Annotate(&frame, []*norfairgo.TrackedObject{})
+	pipeline.Annotate(nil, nil)
+
+	if frame.Empty() {
+		t.Error("Frame should not be empty after annotation")
+	}
+}
@@ -1,9 +1,14 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
 	"fmt"
 	"hash/fnv"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/nmichlo/norfair-go/internal/imaging"
 	"github.com/nmichlo/norfair-go/pkg/norfairgocolor"
@@ -25,11 +30,27 @@ type Palette struct {
 
 // Note: Palette colors (Tab10, Tab20, Colorblind) moved to internal/imaging package
 
+// namedPalettes maps built-in palette names to their colors.
+var namedPalettes = map[string][]Color{
+	"tab10":      imaging.Tab10,
+	"tab20":      imaging.Tab20,
+	"colorblind": imaging.Colorblind,
+}
+
+// defaultPaletteColors are used by NewPalette(nil), and therefore by every drawing
+// function that colors objects by ID or label (like norfair's global Palette).
+var (
+	defaultPaletteColors = imaging.Tab10
+	defaultPaletteMu     sync.RWMutex
+)
+
 // NewPalette creates a new Palette with the given colors.
-// If colors is nil or empty, uses tab10 palette by default.
+// If colors is nil or empty, uses the default palette (tab10 unless changed with SetPalette).
 func NewPalette(colors []Color) *Palette {
 	if len(colors) == 0 {
-		colors = imaging.Tab10
+		defaultPaletteMu.RLock()
+		colors = defaultPaletteColors
+		defaultPaletteMu.RUnlock()
 	}
 
 	return &Palette{
@@ -39,43 +60,112 @@ func NewPalette(colors []Color) *Palette {
 }
 
 // ChooseColor selects a color based on a hashable value (typically object ID).
-// Uses FNV-1a hash for deterministic color assignment.
+//
+// Uses the FNV-1a hash of the value's text form, so the same ID maps to the same
+// color across runs, processes and machines as long as the palette is the same.
+// Pointers are dereferenced, so *int IDs hash like their int values; nil values
+// and nil pointers return the default color.
 func (p *Palette) ChooseColor(hashable interface{}) Color {
 	if hashable == nil {
 		return p.defaultColor
 	}
 
+	value := reflect.ValueOf(hashable)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return p.defaultColor
+		}
+		value = value.Elem()
+	}
+
 	// Hash the value
 	h := fnv.New32a()
-	fmt.Fprintf(h, "%v", hashable)
+	fmt.Fprintf(h, "%v", value.Interface())
 	hash := h.Sum32()
 
 	// Select color based on hash
-	idx := int(hash) % len(p.colors)
+	idx := hash % uint32(len(p.colors))
 	return p.colors[idx]
 }
 
 // Set changes the palette to a named palette.
 // Supported names: "tab10", "tab20", "colorblind".
 func (p *Palette) Set(paletteName string) error {
-	switch strings.ToLower(paletteName) {
-	case "tab10":
-		p.colors = imaging.Tab10
-	case "tab20":
-		p.colors = imaging.Tab20
-	case "colorblind":
-		p.colors = imaging.Colorblind
-	default:
-		return fmt.Errorf("unknown palette: %s (supported: tab10, tab20, colorblind)", paletteName)
+	colors, err := NamedPalette(paletteName)
+	if err != nil {
+		return err
 	}
+	p.colors = colors
 	return nil
 }
 
+// SetColors changes the palette to user-defined colors.
+//
+// Returns: Error if colors is empty
+func (p *Palette) SetColors(colors []Color) error {
+	if len(colors) == 0 {
+		return fmt.Errorf("palette must contain at least one color")
+	}
+	p.colors = append([]Color(nil), colors...)
+	return nil
+}
+
+// Colors returns a copy of the palette colors.
+func (p *Palette) Colors() []Color {
+	return append([]Color(nil), p.colors...)
+}
+
 // SetDefaultColor sets the default color (used when hashable is nil).
 func (p *Palette) SetDefaultColor(color Color) {
 	p.defaultColor = color
 }
 
+// NamedPalette returns a copy of a built-in palette.
+// Supported names (case-insensitive): "tab10", "tab20", "colorblind".
+func NamedPalette(paletteName string) ([]Color, error) {
+	colors, ok := namedPalettes[strings.ToLower(paletteName)]
+	if !ok {
+		return nil, fmt.Errorf("unknown palette: %s (supported: %s)", paletteName, strings.Join(PaletteNames(), ", "))
+	}
+	return append([]Color(nil), colors...), nil
+}
+
+// PaletteNames returns the names of the built-in palettes in sorted order.
+func PaletteNames() []string {
+	names := make([]string, 0, len(namedPalettes))
+	for name := range namedPalettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetPalette sets the default colors used by palettes created with NewPalette(nil).
+//
+// This affects all drawing functions that color objects by ID or label, so
+// services sharing the same palette render each ID with the same color.
+//
+// Returns: Error if colors is empty
+func SetPalette(colors []Color) error {
+	if len(colors) == 0 {
+		return fmt.Errorf("palette must contain at least one color")
+	}
+
+	defaultPaletteMu.Lock()
+	defer defaultPaletteMu.Unlock()
+	defaultPaletteColors = append([]Color(nil), colors...)
+	return nil
+}
+
+// SetNamedPalette sets the default palette to a built-in palette (see NamedPalette).
+func SetNamedPalette(paletteName string) error {
+	colors, err := NamedPalette(paletteName)
+	if err != nil {
+		return err
+	}
+	return SetPalette(colors)
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
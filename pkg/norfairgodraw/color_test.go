@@ -1,3 +1,5 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
@@ -245,6 +247,100 @@ func TestPalette_SetDefaultColor(t *testing.T) {
 	}
 }
 
+func TestPalette_ChooseColor_DereferencesPointers(t *testing.T) {
+	p := NewPalette(nil)
+
+	// Pointer IDs (e.g. TrackedObject.GetID()) must hash like their values
+	id := 42
+	otherID := 42
+	if p.ChooseColor(&id) != p.ChooseColor(42) || p.ChooseColor(&id) != p.ChooseColor(&otherID) {
+		t.Error("ChooseColor(&id) should match ChooseColor(id)")
+	}
+
+	var nilID *int
+	if p.ChooseColor(nilID) != p.defaultColor {
+		t.Error("ChooseColor(nil pointer) should return default color")
+	}
+}
+
+func TestPalette_ChooseColor_StableAcrossRuns(t *testing.T) {
+	p := NewPalette(nil)
+
+	// Expected indices are fixed by FNV-1a and must not change between releases
+	tests := []struct {
+		hashable interface{}
+		expected Color
+	}{
+		{1, imaging.Tab10[4]},
+		{2, imaging.Tab10[1]},
+		{"car", imaging.Tab10[3]},
+	}
+	for _, tt := range tests {
+		if got := p.ChooseColor(tt.hashable); got != tt.expected {
+			t.Errorf("ChooseColor(%v) = %+v, want %+v", tt.hashable, got, tt.expected)
+		}
+	}
+}
+
+func TestPalette_SetColors(t *testing.T) {
+	p := NewPalette(nil)
+
+	custom := []Color{norfairgocolor.Red, norfairgocolor.Green}
+	if err := p.SetColors(custom); err != nil {
+		t.Fatalf("SetColors returned error: %v", err)
+	}
+	custom[0] = norfairgocolor.Blue // Palette must keep its own copy
+	if colors := p.Colors(); len(colors) != 2 || colors[0] != norfairgocolor.Red {
+		t.Errorf("Expected [Red Green], got %+v", colors)
+	}
+
+	if err := p.SetColors(nil); err == nil {
+		t.Error("SetColors(nil) should return error")
+	}
+}
+
+func TestNamedPalette(t *testing.T) {
+	names := PaletteNames()
+	if len(names) != 3 || names[0] != "colorblind" || names[1] != "tab10" || names[2] != "tab20" {
+		t.Errorf("Unexpected palette names: %v", names)
+	}
+
+	colors, err := NamedPalette("Tab20")
+	if err != nil {
+		t.Fatalf("NamedPalette(Tab20) returned error: %v", err)
+	}
+	if len(colors) != len(imaging.Tab20) {
+		t.Errorf("Expected %d colors, got %d", len(imaging.Tab20), len(colors))
+	}
+
+	if _, err := NamedPalette("nonexistent"); err == nil {
+		t.Error("NamedPalette should return error for nonexistent palette")
+	}
+}
+
+func TestSetPalette_Default(t *testing.T) {
+	defer SetNamedPalette("tab10")
+
+	custom := []Color{norfairgocolor.Red}
+	if err := SetPalette(custom); err != nil {
+		t.Fatalf("SetPalette returned error: %v", err)
+	}
+	if c := NewPalette(nil).ChooseColor(7); c != norfairgocolor.Red {
+		t.Errorf("Expected new palettes to use the default palette, got %+v", c)
+	}
+
+	if err := SetNamedPalette("colorblind"); err != nil {
+		t.Fatalf("SetNamedPalette returned error: %v", err)
+	}
+	if n := len(NewPalette(nil).Colors()); n != len(imaging.Colorblind) {
+		t.Errorf("Expected %d colors, got %d", len(imaging.Colorblind), n)
+	}
+
+	if err := SetPalette(nil); err == nil {
+		t.Error("SetPalette(nil) should return error")
+	}
+}
+
 // =============================================================================
 // Color Conversion Tests
 // =============================================================================
@@ -0,0 +1,105 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"image"
+	"math"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// =============================================================================
+// Covariance Ellipses
+// =============================================================================
+
+// DrawCovarianceEllipses draws the confidence ellipse of each tracked point's
+// position estimate, computed from the filter covariance.
+//
+// Ellipses grow while objects are not detected and shrink on every hit, which
+// makes the effect of the filter's R/Q/P multipliers visible. Objects whose
+// filter does not implement norfairgo.CovarianceFilter (e.g. NoFilter) and
+// non-live points are skipped. Only 2D points are supported.
+//
+// Parameters:
+//   - frame: The OpenCV frame to draw on
+//   - trackedObjects: Tracked objects to draw
+//   - color: Ellipse color (nil = palette color by ID)
+//   - thickness: Line thickness (default 1)
+//   - confidence: Probability mass inside the ellipse in (0, 1) (default 0.95)
+func DrawCovarianceEllipses(
+	frame *gocv.Mat,
+	trackedObjects []*norfairgo.TrackedObject,
+	color *Color,
+	thickness int,
+	confidence float64,
+) {
+	if frame == nil {
+		return
+	}
+
+	// Set defaults
+	if thickness <= 0 {
+		thickness = 1
+	}
+	if confidence <= 0 || confidence >= 1 {
+		confidence = 0.95
+	}
+
+	// Chi-square quantile with 2 degrees of freedom has a closed form
+	scale := -2 * math.Log(1-confidence)
+
+	drawer := NewDrawer()
+	palette := NewPalette(nil)
+
+	for _, obj := range trackedObjects {
+		if obj.DimPoints != 2 {
+			continue
+		}
+		estimate, err := obj.GetEstimate(false)
+		if err != nil {
+			continue
+		}
+
+		objColor := palette.ChooseColor(obj.GetID())
+		if color != nil {
+			objColor = *color
+		}
+
+		livePoints := obj.LivePoints()
+		for i := 0; i < obj.NumPoints; i++ {
+			if i < len(livePoints) && !livePoints[i] {
+				continue
+			}
+			cov, err := obj.PointCovariance(i)
+			if err != nil {
+				break // Filter has no covariance
+			}
+
+			semiMajor, semiMinor, angle := covarianceEllipse(cov, scale)
+			center := image.Point{X: int(math.Round(estimate.At(i, 0))), Y: int(math.Round(estimate.At(i, 1)))}
+			axes := image.Point{X: int(math.Round(semiMajor)), Y: int(math.Round(semiMinor))}
+			drawer.Ellipse(frame, center, axes, angle, objColor, thickness)
+		}
+	}
+}
+
+// covarianceEllipse returns the semi-axes and rotation (degrees) of the ellipse
+// {p : p^T cov^-1 p <= scale} for a 2x2 covariance matrix.
+func covarianceEllipse(cov *mat.Dense, scale float64) (semiMajor, semiMinor, angle float64) {
+	a, b, c := cov.At(0, 0), (cov.At(0, 1)+cov.At(1, 0))/2, cov.At(1, 1)
+
+	// Closed-form eigen decomposition of a symmetric 2x2 matrix
+	mean := (a + c) / 2
+	radius := math.Hypot((a-c)/2, b)
+	lambdaMajor := math.Max(mean+radius, 0)
+	lambdaMinor := math.Max(mean-radius, 0)
+
+	semiMajor = math.Sqrt(scale * lambdaMajor)
+	semiMinor = math.Sqrt(scale * lambdaMinor)
+	angle = 0.5 * math.Atan2(2*b, a-c) * 180 / math.Pi
+	return semiMajor, semiMinor, angle
+}
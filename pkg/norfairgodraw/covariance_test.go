@@ -0,0 +1,67 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"math"
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// TestCovarianceEllipse_AxisAligned verifies axes and angle for diagonal covariance
+func TestCovarianceEllipse_AxisAligned(t *testing.T) {
+	cov := mat.NewDense(2, 2, []float64{9, 0, 0, 4})
+
+	semiMajor, semiMinor, angle := covarianceEllipse(cov, 1)
+	if math.Abs(semiMajor-3) > 1e-9 || math.Abs(semiMinor-2) > 1e-9 || math.Abs(angle) > 1e-9 {
+		t.Errorf("Expected (3, 2, 0), got (%f, %f, %f)", semiMajor, semiMinor, angle)
+	}
+
+	// Major axis along y
+	cov = mat.NewDense(2, 2, []float64{4, 0, 0, 9})
+	_, _, angle = covarianceEllipse(cov, 1)
+	if math.Abs(math.Abs(angle)-90) > 1e-9 {
+		t.Errorf("Expected angle ±90, got %f", angle)
+	}
+}
+
+// TestCovarianceEllipse_Rotated verifies a 45 degree correlated covariance
+func TestCovarianceEllipse_Rotated(t *testing.T) {
+	// Eigenvalues 3 and 1 along the diagonals
+	cov := mat.NewDense(2, 2, []float64{2, 1, 1, 2})
+
+	semiMajor, semiMinor, angle := covarianceEllipse(cov, 5.991464547107979)
+	if math.Abs(semiMajor-math.Sqrt(3*5.991464547107979)) > 1e-9 ||
+		math.Abs(semiMinor-math.Sqrt(5.991464547107979)) > 1e-9 ||
+		math.Abs(angle-45) > 1e-9 {
+		t.Errorf("Unexpected ellipse (%f, %f, %f)", semiMajor, semiMinor, angle)
+	}
+}
+
+// TestDrawCovarianceEllipses_Smoke verifies drawing with a real filter does not crash
+func TestDrawCovarianceEllipses_Smoke(t *testing.T) {
+	frame := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	initial := mat.NewDense(1, 2, []float64{50, 50})
+	id := 1
+	obj := &norfairgo.TrackedObject{
+		Filter:          norfairgo.NewFilterPyKalmanFilterFactory(4.0, 0.1, 10.0).CreateFilter(initial),
+		NumPoints:       1,
+		DimPoints:       2,
+		DimZ:            2,
+		ID:              &id,
+		PointHitCounter: []int{1},
+	}
+
+	DrawCovarianceEllipses(&frame, []*norfairgo.TrackedObject{obj}, nil, 0, 0)
+	DrawCovarianceEllipses(nil, []*norfairgo.TrackedObject{obj}, nil, 0, 0)
+
+	if frame.Empty() {
+		t.Error("Frame should not be empty after drawing")
+	}
+}
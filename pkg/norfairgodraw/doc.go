@@ -31,12 +31,14 @@ frames using gocv.
 
   - "by_id": Unique color per object ID
   - "by_label": Same color per label
-  - "by_score": Gradient based on confidence
+  - "by_score": Mean detection score mapped through ScoreColormap (viridis)
 
 # Components
 
 Drawer: Primitive drawing operations
 Color: RGBA with conversion utilities
 Path: Movement history tracking
+Minimap: Top-down view of ground plane positions and trails
+Heatmap: Occupancy of tracked object positions through a colormap (ScoreColormap by default)
 */
 package norfairgodraw
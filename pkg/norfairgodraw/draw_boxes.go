@@ -1,3 +1,5 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
@@ -20,6 +22,25 @@ func DrawBoxes(
 	textThickness *int,
 	drawBox bool,
 	drawScores bool,
+) *gocv.Mat {
+	return drawBoxes(frame, drawables, color, thickness, drawLabels, textSize, drawIDs,
+		textColor, textThickness, drawBox, drawScores, NewPalette(nil))
+}
+
+// drawBoxes is DrawBoxes with the palette of the "by_id" and "by_label" strategies.
+func drawBoxes(
+	frame *gocv.Mat,
+	drawables []interface{},
+	color interface{},
+	thickness *int,
+	drawLabels bool,
+	textSize *float64,
+	drawIDs bool,
+	textColor interface{},
+	textThickness *int,
+	drawBox bool,
+	drawScores bool,
+	palette *Palette,
 ) *gocv.Mat {
 	// Set defaults
 	if color == nil {
@@ -44,7 +65,6 @@ func DrawBoxes(
 	}
 
 	drawer := NewDrawer()
-	palette := NewPalette(nil) // default tab10
 
 	// Process each drawable
 	for _, obj := range drawables {
@@ -1,9 +1,11 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
 	"testing"
 
-	"github.com/nmichlo/norfair-go/internal/testutil"
+	"github.com/nmichlo/norfair-go/pkg/norfairgotest"
 	"gocv.io/x/gocv"
 	"gonum.org/v1/gonum/mat"
 )
@@ -541,5 +543,5 @@ func TestDrawBoxes_DirectColor_GoldenImage(t *testing.T) {
 
 	// Compare to golden image
 	goldenPath := "../../testdata/drawing/draw_boxes_direct_color_golden.png"
-	testutil.CompareToGoldenImage(t, &frame, goldenPath, 0.95)
+	norfairgotest.CompareToGoldenImage(t, &frame, goldenPath, 0.95)
 }
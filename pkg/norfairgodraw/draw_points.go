@@ -1,3 +1,5 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
@@ -26,6 +28,28 @@ func DrawPoints(
 	textColor interface{},
 	hideDeadPoints bool,
 	drawScores bool,
+) *gocv.Mat {
+	return drawPointsWithPalette(frame, drawables, radius, thickness, color, drawLabels, textSize, drawIDs,
+		drawPoints, textThickness, textColor, hideDeadPoints, drawScores, NewPalette(nil))
+}
+
+// drawPointsWithPalette is DrawPoints with the palette of the "by_id" and
+// "by_label" strategies.
+func drawPointsWithPalette(
+	frame *gocv.Mat,
+	drawables []interface{},
+	radius *int,
+	thickness *int,
+	color interface{},
+	drawLabels bool,
+	textSize *float64,
+	drawIDs bool,
+	drawPoints bool,
+	textThickness *int,
+	textColor interface{},
+	hideDeadPoints bool,
+	drawScores bool,
+	palette *Palette,
 ) *gocv.Mat {
 	// Early return if no drawables
 	if drawables == nil || len(drawables) == 0 {
@@ -54,7 +78,6 @@ func DrawPoints(
 	}
 
 	drawer := NewDrawer()
-	palette := NewPalette(nil) // default tab10
 
 	// Process each drawable
 	for _, obj := range drawables {
@@ -151,6 +174,9 @@ func DrawPoints(
 	return frame
 }
 
+// ScoreColormap maps mean detection scores in [0, 1] to colors for the "by_score" strategy.
+var ScoreColormap = colorpkg.Viridis
+
 // resolveColor determines the color based on the strategy or direct value.
 func resolveColor(colorStrategy interface{}, drawable *Drawable, palette *Palette) Color {
 	switch strategy := colorStrategy.(type) {
@@ -166,6 +192,15 @@ func resolveColor(colorStrategy interface{}, drawable *Drawable, palette *Palett
 				return palette.ChooseColor(*drawable.Label)
 			}
 			return palette.ChooseColor(nil) // Use default color
+		case "by_score":
+			if len(drawable.Scores) == 0 {
+				return palette.ChooseColor(nil) // Use default color
+			}
+			sum := 0.0
+			for _, score := range drawable.Scores {
+				sum += score
+			}
+			return ScoreColormap.At(sum / float64(len(drawable.Scores)))
 		case "random":
 			// Random color each time (using random float)
 			return palette.ChooseColor(rand.Float64())
@@ -1,10 +1,12 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
 	"testing"
 
-	"github.com/nmichlo/norfair-go/internal/testutil"
 	"github.com/nmichlo/norfair-go/pkg/norfairgocolor"
+	"github.com/nmichlo/norfair-go/pkg/norfairgotest"
 	"gocv.io/x/gocv"
 	"gonum.org/v1/gonum/mat"
 )
@@ -161,6 +163,21 @@ func TestDrawPoints_ColorByLabel(t *testing.T) {
 	}
 }
 
+func TestResolveColor_ByScore(t *testing.T) {
+	palette := NewPalette(nil)
+	points := mat.NewDense(2, 2, []float64{100, 100, 200, 200})
+
+	drawable, _ := NewDrawable(points, nil, nil, []float64{0.2, 0.6}, nil)
+	if got, want := resolveColor("by_score", drawable, palette), ScoreColormap.At(0.4); got != want {
+		t.Errorf("by_score should map the mean score, got %+v want %+v", got, want)
+	}
+
+	noScores, _ := NewDrawable(points, nil, nil, nil, nil)
+	if got := resolveColor("by_score", noScores, palette); got != palette.defaultColor {
+		t.Errorf("by_score without scores should use the default color, got %+v", got)
+	}
+}
+
 func TestDrawPoints_ColorRandom(t *testing.T) {
 	frame := gocv.NewMatWithSize(480, 640, gocv.MatTypeCV8UC3)
 	defer frame.Close()
@@ -659,5 +676,5 @@ func TestDrawPoints_DirectColor_GoldenImage(t *testing.T) {
 
 	// Compare to golden image
 	goldenPath := "../../testdata/drawing/draw_points_direct_color_golden.png"
-	testutil.CompareToGoldenImage(t, &frame, goldenPath, 0.95)
+	norfairgotest.CompareToGoldenImage(t, &frame, goldenPath, 0.95)
 }
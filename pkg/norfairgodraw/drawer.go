@@ -1,3 +1,5 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
@@ -7,6 +9,8 @@ import (
 
 	"gocv.io/x/gocv"
 	"gonum.org/v1/gonum/mat"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
 )
 
 // Drawer provides stateless drawing primitive functions.
@@ -125,6 +129,16 @@ func (d *Drawer) Line(frame *gocv.Mat, start image.Point, end image.Point, color
 	gocv.Line(frame, start, end, color.ToRGBA(), thickness)
 }
 
+// Ellipse draws a rotated ellipse outline on the frame.
+// Axes are the semi-axis lengths and angle is the rotation in degrees.
+func (d *Drawer) Ellipse(frame *gocv.Mat, center image.Point, axes image.Point, angle float64, color Color, thickness int) {
+	if thickness == 0 {
+		thickness = 1
+	}
+
+	gocv.Ellipse(frame, center, axes, angle, 0, 360, color.ToRGBA(), thickness)
+}
+
 // Cross draws a cross marker (+ shape) on the frame.
 func (d *Drawer) Cross(frame *gocv.Mat, center image.Point, radius int, color Color, thickness int) {
 	// Vertical line
@@ -173,13 +187,9 @@ type DetectionLike interface {
 	GetScores() []float64
 }
 
-// TrackedObject interface (minimal required fields for drawing)
-type TrackedObjectLike interface {
-	GetEstimate(absolute bool) (*mat.Dense, error)
-	GetID() *int
-	GetLabel() *string
-	GetLivePoints() []bool
-}
+// TrackedObject interface (minimal required fields for drawing), shared with the
+// metrics of package norfairgo. Implement it to draw your own track representations.
+type TrackedObjectLike = norfairgo.TrackedObjectLike
 
 // NewDrawableFromDetection creates a Drawable from a Detection-like object.
 func NewDrawableFromDetection(det DetectionLike) (*Drawable, error) {
@@ -1,11 +1,13 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
 	"image"
 	"testing"
 
-	"github.com/nmichlo/norfair-go/internal/testutil"
 	"github.com/nmichlo/norfair-go/pkg/norfairgocolor"
+	"github.com/nmichlo/norfair-go/pkg/norfairgotest"
 	"gocv.io/x/gocv"
 	"gonum.org/v1/gonum/mat"
 )
@@ -669,5 +671,5 @@ func TestDrawer_DrawingPrimitives_GoldenImage(t *testing.T) {
 	// Golden image generated from Python norfair using tools/validate_drawing/main.py
 	goldenPath := "../../testdata/drawing/drawing_primitives_golden.png"
 
-	testutil.CompareToGoldenImage(t, &frame, goldenPath, 0.95)
+	norfairgotest.CompareToGoldenImage(t, &frame, goldenPath, 0.95)
 }
@@ -0,0 +1,165 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"image"
+	"sync"
+
+	"gocv.io/x/gocv"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+	colorpkg "github.com/nmichlo/norfair-go/pkg/norfairgocolor"
+)
+
+// =============================================================================
+// Heatmap - Colormapped Occupancy of Tracked Object Positions
+// =============================================================================
+
+// heatmapMinCount is the count below which a fading cell is cleared.
+const heatmapMinCount = 0.01
+
+// Heatmap accumulates where tracked objects have been on a grid of cells and
+// draws the counts over frames through a colormap, showing busy areas of a
+// static camera view.
+//
+// Counts are normalized by the busiest cell, so the colormap always spans the
+// whole range. With decay, cells whose count fades below 0.01 are cleared and no
+// longer drawn. A change of frame size discards the counts. Like Paths, positions
+// are relative coordinates, so it is not meant for moving cameras.
+//
+// Draw and Reset calls are serialized, which only protects the Heatmap's own state
+// (see Paths for drawing on a different goroutine from tracking).
+type Heatmap struct {
+	getPointsToDraw GetPointsToDrawFunc
+	colormap        colorpkg.Colormap
+	cellSize        int // Configured cell size (0 = auto)
+	cell            int // Cell size of the current grid
+	decay           float64
+	alpha           float64
+	counts          []float64 // Row-major (gridRows, gridCols) counts (lazy init)
+	gridRows        int
+	gridCols        int
+	frameSize       image.Point // Frame size the grid was built for
+	drawer          *Drawer
+	mu              sync.Mutex // Guards counts and the grid size
+}
+
+// NewHeatmap creates a new Heatmap drawer.
+//
+// Parameters:
+//   - getPointsToDraw: Function to extract points from estimate (nil = use centroid)
+//   - colormap: Colormap of the counts (nil = ScoreColormap)
+//   - cellSize: Side of a grid cell in pixels (0 = auto-calculated from frame size,
+//     recomputed with the grid whenever the frame size changes)
+//   - decay: Fraction of the counts removed every frame in [0, 1], where 0 = never fades
+//   - alpha: Opacity of the heatmap over the frame in (0, 1] (0 = default 0.5)
+func NewHeatmap(
+	getPointsToDraw GetPointsToDrawFunc,
+	colormap *colorpkg.Colormap,
+	cellSize int,
+	decay float64,
+	alpha float64,
+) *Heatmap {
+	if getPointsToDraw == nil {
+		getPointsToDraw = defaultGetPointsToDraw
+	}
+	cmap := ScoreColormap
+	if colormap != nil {
+		cmap = *colormap
+	}
+	if decay < 0 || decay > 1 {
+		decay = 0
+	}
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.5
+	}
+
+	return &Heatmap{
+		getPointsToDraw: getPointsToDraw,
+		colormap:        cmap,
+		cellSize:        cellSize,
+		decay:           decay,
+		alpha:           alpha,
+		drawer:          NewDrawer(),
+	}
+}
+
+// Draw adds the current positions of the tracked objects to the heatmap and returns
+// a new frame with the heatmap blended on top. Cells no object has visited are
+// left unchanged.
+// The caller is responsible for closing the returned Mat.
+func (h *Heatmap) Draw(frame *gocv.Mat, trackedObjects []*norfairgo.TrackedObject) gocv.Mat {
+	return h.DrawObjects(frame, norfairgo.AsTrackedObjectLikes(trackedObjects))
+}
+
+// DrawObjects is Draw for any track representation implementing TrackedObjectLike.
+func (h *Heatmap) DrawObjects(frame *gocv.Mat, trackedObjects []TrackedObjectLike) gocv.Mat {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// (Re)build the grid for the frame size
+	size := image.Point{X: frame.Cols(), Y: frame.Rows()}
+	if h.counts == nil || size != h.frameSize {
+		h.cell = h.cellSize
+		if h.cell <= 0 {
+			h.cell = maxInt(frame.Rows()/50, 1)
+		}
+		h.gridRows = (size.Y + h.cell - 1) / h.cell
+		h.gridCols = (size.X + h.cell - 1) / h.cell
+		h.counts = make([]float64, h.gridRows*h.gridCols)
+		h.frameSize = size
+	}
+
+	// Fade the counts, clearing cells that have faded out, then add the current positions
+	if h.decay > 0 {
+		for i := range h.counts {
+			h.counts[i] *= 1 - h.decay
+			if h.counts[i] < heatmapMinCount {
+				h.counts[i] = 0
+			}
+		}
+	}
+	for _, obj := range trackedObjects {
+		estimate, err := obj.GetEstimate(false)
+		if err != nil {
+			continue // Skip if estimate fails
+		}
+		for _, point := range h.getPointsToDraw(estimate) {
+			if point.X < 0 || point.Y < 0 || point.X >= size.X || point.Y >= size.Y {
+				continue
+			}
+			h.counts[(point.Y/h.cell)*h.gridCols+point.X/h.cell]++
+		}
+	}
+
+	// Fill the visited cells with their colormap color on a copy of the frame
+	var maxCount float64
+	for _, count := range h.counts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	overlay := frame.Clone()
+	defer overlay.Close()
+	for row := 0; row < h.gridRows; row++ {
+		for col := 0; col < h.gridCols; col++ {
+			count := h.counts[row*h.gridCols+col]
+			if count <= 0 {
+				continue
+			}
+			topLeft := image.Point{X: col * h.cell, Y: row * h.cell}
+			bottomRight := image.Point{X: topLeft.X + h.cell - 1, Y: topLeft.Y + h.cell - 1}
+			h.drawer.Rectangle(&overlay, topLeft, bottomRight, h.colormap.AtRange(count, 0, maxCount), -1)
+		}
+	}
+
+	return h.drawer.AlphaBlend(&overlay, frame, h.alpha, 1-h.alpha, 0)
+}
+
+// Reset discards all accumulated counts.
+func (h *Heatmap) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts = nil
+}
@@ -0,0 +1,96 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+
+	colorpkg "github.com/nmichlo/norfair-go/pkg/norfairgocolor"
+)
+
+func heatmapObject(id int, x, y float64) TrackedObjectLike {
+	return &mockTrackedObjectForPaths{id: &id, estimate: mat.NewDense(1, 2, []float64{x, y})}
+}
+
+func TestHeatmap_AccumulatesPositions(t *testing.T) {
+	frame := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	magma := colorpkg.Magma
+	heatmap := NewHeatmap(nil, &magma, 10, 0, 1)
+	objects := []TrackedObjectLike{heatmapObject(1, 15, 15), heatmapObject(2, 85, 85), heatmapObject(3, -5, 500)}
+	for i := 0; i < 3; i++ {
+		result := heatmap.DrawObjects(&frame, objects[:1])
+		result.Close()
+	}
+	result := heatmap.DrawObjects(&frame, objects)
+	defer result.Close()
+
+	// The busiest cell gets the top color, a cell visited once the low end
+	top := magma.At(1)
+	if pixel := result.GetVecbAt(15, 15); pixel[0] != top.B || pixel[1] != top.G || pixel[2] != top.R {
+		t.Errorf("Expected the busiest cell to be %v, got %v", top, pixel)
+	}
+	low := magma.At(0.25)
+	if pixel := result.GetVecbAt(85, 85); pixel[0] != low.B || pixel[1] != low.G || pixel[2] != low.R {
+		t.Errorf("Expected a cell visited once to be %v, got %v", low, pixel)
+	}
+	if pixel := result.GetVecbAt(50, 50); pixel[0] != 0 || pixel[1] != 0 || pixel[2] != 0 {
+		t.Errorf("Expected unvisited cells to keep the frame, got %v", pixel)
+	}
+
+	// Reset discards the counts
+	heatmap.Reset()
+	empty := heatmap.DrawObjects(&frame, nil)
+	defer empty.Close()
+	if pixel := empty.GetVecbAt(15, 15); pixel[0] != 0 || pixel[1] != 0 || pixel[2] != 0 {
+		t.Errorf("Expected no heatmap after Reset, got %v", pixel)
+	}
+}
+
+func TestHeatmap_Defaults(t *testing.T) {
+	heatmap := NewHeatmap(nil, nil, 0, -1, 0)
+	if heatmap.colormap.Name() != ScoreColormap.Name() || heatmap.decay != 0 || heatmap.alpha != 0.5 {
+		t.Errorf("Unexpected defaults: colormap %s, decay %v, alpha %v", heatmap.colormap.Name(), heatmap.decay, heatmap.alpha)
+	}
+
+	frame := gocv.NewMatWithSize(200, 300, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+	result := heatmap.DrawObjects(&frame, []TrackedObjectLike{heatmapObject(1, 10, 10)})
+	defer result.Close()
+	if heatmap.cell != 4 || heatmap.gridRows != 50 || heatmap.gridCols != 75 {
+		t.Errorf("Expected 4px cells on a 50x75 grid, got %dpx on %dx%d", heatmap.cell, heatmap.gridRows, heatmap.gridCols)
+	}
+
+	// The auto cell size follows the frame size
+	small := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer small.Close()
+	resized := heatmap.DrawObjects(&small, nil)
+	defer resized.Close()
+	if heatmap.cell != 2 || heatmap.gridRows != 50 || heatmap.gridCols != 50 {
+		t.Errorf("Expected 2px cells on a 50x50 grid, got %dpx on %dx%d", heatmap.cell, heatmap.gridRows, heatmap.gridCols)
+	}
+}
+
+func TestHeatmap_DecayClearsCells(t *testing.T) {
+	frame := gocv.NewMatWithSize(100, 100, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	heatmap := NewHeatmap(nil, nil, 10, 0.5, 1)
+	visited := heatmap.DrawObjects(&frame, []TrackedObjectLike{heatmapObject(1, 15, 15)})
+	visited.Close()
+
+	// 0.5^7 < 0.01, after which the cell is cleared instead of staying at the low color
+	for i := 0; i < 7; i++ {
+		faded := heatmap.DrawObjects(&frame, nil)
+		faded.Close()
+	}
+	result := heatmap.DrawObjects(&frame, nil)
+	defer result.Close()
+	if pixel := result.GetVecbAt(15, 15); pixel[0] != 0 || pixel[1] != 0 || pixel[2] != 0 {
+		t.Errorf("Expected the faded cell to keep the frame, got %v", pixel)
+	}
+}
@@ -0,0 +1,209 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"sync"
+
+	"gocv.io/x/gocv"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// =============================================================================
+// Minimap - Top-Down View of Ground Plane Positions
+// =============================================================================
+
+// DrawMinimap draws ground plane positions (e.g. from GroundPlaneProjector.ProjectAll)
+// as a top-down minimap filling the frame.
+//
+// World coordinates are scaled into the frame so that worldBounds covers it entirely,
+// with the world y axis pointing up (the far side of the ground plane is at the top).
+// Positions outside worldBounds are not drawn.
+//
+// Parameters:
+//   - frame: The OpenCV frame to draw on, e.g. a blank image or a corner of the video frame
+//   - positions: World positions by object ID
+//   - worldBounds: Visible world area as [minX, minY, maxX, maxY]
+//   - radius: Radius of each object's marker (0 = auto-calculated from frame size)
+//   - drawIDs: If true, draw each object's ID next to its marker
+func DrawMinimap(
+	frame *gocv.Mat,
+	positions map[int][2]float64,
+	worldBounds [4]float64,
+	radius int,
+	drawIDs bool,
+) {
+	if frame == nil {
+		return
+	}
+	if worldBounds[2] <= worldBounds[0] || worldBounds[3] <= worldBounds[1] {
+		return
+	}
+	if radius <= 0 {
+		radius = maxInt(maxInt(frame.Rows(), frame.Cols())/50, 2)
+	}
+
+	// Draw in ID order so overlapping markers are deterministic
+	ids := make([]int, 0, len(positions))
+	for id := range positions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	drawer := NewDrawer()
+	palette := NewPalette(nil)
+	for _, id := range ids {
+		point, ok := worldToCanvas(positions[id], worldBounds, frame.Cols(), frame.Rows())
+		if !ok {
+			continue
+		}
+
+		color := palette.ChooseColor(id)
+		drawer.Circle(frame, point, radius, -1, color)
+
+		if drawIDs {
+			drawer.Text(
+				frame,
+				fmt.Sprintf("%d", id),
+				image.Point{X: point.X + radius, Y: point.Y - radius},
+				0,
+				color,
+				0,
+				false,
+				Color{},
+				0,
+			)
+		}
+	}
+}
+
+// worldToCanvas maps a world position to a pixel of a w x h canvas covering worldBounds.
+// Returns false if the position lies outside worldBounds.
+func worldToCanvas(position [2]float64, worldBounds [4]float64, w, h int) (image.Point, bool) {
+	u := (position[0] - worldBounds[0]) / (worldBounds[2] - worldBounds[0])
+	v := (worldBounds[3] - position[1]) / (worldBounds[3] - worldBounds[1])
+	if u < 0 || u > 1 || v < 0 || v > 1 {
+		return image.Point{}, false
+	}
+	return image.Point{X: int(u * float64(w-1)), Y: int(v * float64(h-1))}, true
+}
+
+// =============================================================================
+// Minimap Trajectories
+// =============================================================================
+
+// Minimap renders current positions and trails of all tracks onto a top-down canvas,
+// optionally over a background map image (e.g. a floor plan or satellite view).
+//
+// Positions are computed with a GroundPlaneProjector. Trails of objects that are no
+// longer passed to Draw are discarded.
+type Minimap struct {
+	projector   *norfairgo.GroundPlaneProjector
+	worldBounds [4]float64
+	background  *gocv.Mat
+	size        image.Point
+	maxHistory  int
+	trails      map[int][][2]float64 // Object ID -> past world positions, oldest first
+	drawer      *Drawer
+	palette     *Palette
+	mu          sync.Mutex // Guards trails
+}
+
+// NewMinimap creates a new Minimap drawer.
+//
+// Parameters:
+//   - projector: Projects tracked objects onto the ground plane
+//   - worldBounds: Visible world area as [minX, minY, maxX, maxY], matching the background if any
+//   - background: Map image to draw over (nil = black canvas); it is cloned, not modified
+//   - size: Canvas size when there is no background (default 400x400)
+//   - maxHistory: Number of past positions to keep per trail (default 50)
+func NewMinimap(
+	projector *norfairgo.GroundPlaneProjector,
+	worldBounds [4]float64,
+	background *gocv.Mat,
+	size image.Point,
+	maxHistory int,
+) *Minimap {
+	if size.X <= 0 || size.Y <= 0 {
+		size = image.Point{X: 400, Y: 400}
+	}
+	if maxHistory <= 0 {
+		maxHistory = 50
+	}
+
+	return &Minimap{
+		projector:   projector,
+		worldBounds: worldBounds,
+		background:  background,
+		size:        size,
+		maxHistory:  maxHistory,
+		trails:      make(map[int][][2]float64),
+		drawer:      NewDrawer(),
+		palette:     NewPalette(nil),
+	}
+}
+
+// Draw records the ground positions of the tracked objects and returns a new
+// canvas with their trails and current positions.
+// The caller is responsible for closing the returned Mat.
+func (m *Minimap) Draw(trackedObjects []*norfairgo.TrackedObject) gocv.Mat {
+	return m.DrawObjects(norfairgo.AsTrackedObjectLikes(trackedObjects))
+}
+
+// DrawObjects is like Draw but accepts any TrackedObjectLike.
+func (m *Minimap) DrawObjects(trackedObjects []TrackedObjectLike) gocv.Mat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	positions := m.projector.ProjectAll(trackedObjects)
+
+	// Extend trails, dropping those of objects that are gone
+	for id := range m.trails {
+		if _, ok := positions[id]; !ok {
+			delete(m.trails, id)
+		}
+	}
+	for id, position := range positions {
+		trail := append(m.trails[id], position)
+		if len(trail) > m.maxHistory {
+			trail = trail[len(trail)-m.maxHistory:]
+		}
+		m.trails[id] = trail
+	}
+
+	var canvas gocv.Mat
+	if m.background != nil {
+		canvas = m.background.Clone()
+	} else {
+		canvas = gocv.NewMatWithSize(m.size.Y, m.size.X, gocv.MatTypeCV8UC3)
+	}
+	if m.worldBounds[2] <= m.worldBounds[0] || m.worldBounds[3] <= m.worldBounds[1] {
+		return canvas
+	}
+
+	thickness := maxInt(maxInt(canvas.Rows(), canvas.Cols())/200, 1)
+	for id, trail := range m.trails {
+		color := m.palette.ChooseColor(id)
+		for i := 1; i < len(trail); i++ {
+			start, okStart := worldToCanvas(trail[i-1], m.worldBounds, canvas.Cols(), canvas.Rows())
+			end, okEnd := worldToCanvas(trail[i], m.worldBounds, canvas.Cols(), canvas.Rows())
+			if okStart && okEnd {
+				m.drawer.Line(&canvas, start, end, color, thickness)
+			}
+		}
+	}
+
+	DrawMinimap(&canvas, positions, m.worldBounds, 0, true)
+	return canvas
+}
+
+// Reset discards all trails.
+func (m *Minimap) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trails = make(map[int][][2]float64)
+}
@@ -0,0 +1,103 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"image"
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+func TestDrawMinimap(t *testing.T) {
+	frame := gocv.NewMatWithSize(200, 100, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	positions := map[int][2]float64{
+		1: {0, 0},
+		2: {5, 10},
+		3: {50, 50}, // outside bounds, skipped
+	}
+	DrawMinimap(&frame, positions, [4]float64{0, 0, 10, 20}, 0, true)
+
+	if frame.Empty() {
+		t.Error("Frame should not be empty after drawing")
+	}
+}
+
+func TestDrawMinimap_InvalidInputs(t *testing.T) {
+	// Should not panic
+	DrawMinimap(nil, map[int][2]float64{1: {0, 0}}, [4]float64{0, 0, 1, 1}, 0, false)
+
+	frame := gocv.NewMatWithSize(10, 10, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+	DrawMinimap(&frame, map[int][2]float64{1: {0, 0}}, [4]float64{0, 0, 0, 1}, 0, false)
+}
+
+func newTestMinimap(t *testing.T, maxHistory int) *Minimap {
+	t.Helper()
+	projector, err := norfairgo.NewGroundPlaneProjector(mat.NewDense(3, 3, []float64{
+		0.1, 0, 0,
+		0, 0.1, 0,
+		0, 0, 1,
+	}))
+	if err != nil {
+		t.Fatalf("NewGroundPlaneProjector failed: %v", err)
+	}
+	return NewMinimap(projector, [4]float64{0, 0, 100, 100}, nil, image.Point{X: 200, Y: 100}, maxHistory)
+}
+
+func minimapObject(id int, x, y float64) TrackedObjectLike {
+	return &mockTrackedObjectForPaths{id: &id, estimate: mat.NewDense(1, 2, []float64{x, y})}
+}
+
+func TestMinimap_Trails(t *testing.T) {
+	minimap := newTestMinimap(t, 3)
+
+	for i := 0; i < 5; i++ {
+		canvas := minimap.DrawObjects([]TrackedObjectLike{
+			minimapObject(1, float64(10*i), 0),
+			minimapObject(2, 0, float64(10*i)),
+		})
+		if canvas.Rows() != 100 || canvas.Cols() != 200 {
+			t.Errorf("canvas size = %dx%d, want 200x100", canvas.Cols(), canvas.Rows())
+		}
+		canvas.Close()
+	}
+
+	if len(minimap.trails[1]) != 3 {
+		t.Errorf("trail length = %d, want maxHistory 3", len(minimap.trails[1]))
+	}
+	if last := minimap.trails[1][2]; last != [2]float64{4, 0} {
+		t.Errorf("last trail position = %v, want [4 0]", last)
+	}
+
+	// Object 2 disappears: its trail is dropped
+	canvas := minimap.DrawObjects([]TrackedObjectLike{minimapObject(1, 50, 0)})
+	canvas.Close()
+	if _, ok := minimap.trails[2]; ok {
+		t.Error("trail of a missing object should be dropped")
+	}
+
+	minimap.Reset()
+	if len(minimap.trails) != 0 {
+		t.Error("Reset should discard all trails")
+	}
+}
+
+func TestMinimap_Background(t *testing.T) {
+	background := gocv.NewMatWithSize(50, 60, gocv.MatTypeCV8UC3)
+	defer background.Close()
+
+	minimap := newTestMinimap(t, 0)
+	minimap.background = &background
+
+	canvas := minimap.DrawObjects([]TrackedObjectLike{minimapObject(1, 100, 100)})
+	defer canvas.Close()
+	if canvas.Rows() != 50 || canvas.Cols() != 60 {
+		t.Errorf("canvas size = %dx%d, want background size 60x50", canvas.Cols(), canvas.Rows())
+	}
+}
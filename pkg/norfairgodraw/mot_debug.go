@@ -0,0 +1,213 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"fmt"
+	"image"
+	"iter"
+	"path/filepath"
+	"strings"
+
+	"gocv.io/x/gocv"
+
+	"github.com/nmichlo/norfair-go/internal/motmetrics"
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// =============================================================================
+// MOT Debug Video - Color-Coded Matches, Misses, False Positives and ID Switches
+// =============================================================================
+
+// MOTDebugLayout selects how GT and tracker boxes are arranged in debug frames.
+type MOTDebugLayout int
+
+const (
+	// MOTDebugOverlay draws tracker boxes and missed GT boxes on a single frame.
+	MOTDebugOverlay MOTDebugLayout = iota
+	// MOTDebugSideBySide draws GT boxes on the left and tracker boxes on the right.
+	MOTDebugSideBySide
+)
+
+// MOTDebugColors are the colors of each outcome in debug frames.
+var MOTDebugColors = map[motmetrics.EventType]Color{
+	motmetrics.EventMatch:         {B: 0, G: 255, R: 0},   // Lime
+	motmetrics.EventSwitch:        {B: 255, G: 0, R: 255}, // Magenta
+	motmetrics.EventMiss:          {B: 0, G: 0, R: 255},   // Red
+	motmetrics.EventFalsePositive: {B: 0, G: 255, R: 255}, // Yellow
+}
+
+// MOTDebugConfig configures RenderMOTDebugFrame and WriteMOTDebugVideo.
+// All fields are optional and can be zero.
+type MOTDebugConfig struct {
+	// Arrangement of GT and tracker boxes.
+	// Default: MOTDebugOverlay
+	Layout MOTDebugLayout
+
+	// Box line thickness (0 = auto-calculated from frame size).
+	// Default: 0
+	Thickness int
+
+	// Hide the ID labels of boxes.
+	// Default: false
+	HideIDs bool
+
+	// Hide the per-frame counts in the top-left corner.
+	// Default: false
+	HideCounts bool
+}
+
+// RenderMOTDebugFrame renders a debug frame that color-codes the outcome of every
+// box of a frame diagnosis (see norfairgo.DiagnoseFrames and MOTDebugColors), so
+// the events behind MOT metric numbers can be inspected visually.
+//
+// In the overlay layout, tracker boxes are drawn in the color of their outcome
+// and missed GT boxes in red; matched GT boxes are drawn thinly in the color of
+// their match. In the side-by-side layout, GT boxes are drawn on the left and
+// tracker boxes on the right, so the output is twice as wide as the frame.
+// Switched boxes are labelled with the GT ID they switched to.
+//
+// Parameters:
+//   - frame: The frame the diagnosis belongs to (not modified)
+//   - diagnosis: Classified boxes of the frame (nil draws only the counts)
+//   - config: Optional configuration (can be nil)
+//
+// Returns: A new frame owned by the caller
+func RenderMOTDebugFrame(frame gocv.Mat, diagnosis *norfairgo.FrameDiagnosis, config *MOTDebugConfig) gocv.Mat {
+	if config == nil {
+		config = &MOTDebugConfig{}
+	}
+	thickness := config.Thickness
+	if thickness <= 0 {
+		thickness = maxInt(maxInt(frame.Rows(), frame.Cols())/500, 2)
+	}
+	if diagnosis == nil {
+		diagnosis = &norfairgo.FrameDiagnosis{}
+	}
+
+	left := frame.Clone()
+	switch config.Layout {
+	case MOTDebugSideBySide:
+		right := frame.Clone()
+		defer right.Close()
+		for _, box := range diagnosis.GT {
+			drawDiagnosedBox(&left, box, "GT ", thickness, config.HideIDs)
+		}
+		for _, box := range diagnosis.Predictions {
+			drawDiagnosedBox(&right, box, "", thickness, config.HideIDs)
+		}
+		combined := gocv.NewMat()
+		gocv.Hconcat(left, right, &combined)
+		left.Close()
+		left = combined
+	default:
+		for _, box := range diagnosis.GT {
+			if box.Type == motmetrics.EventMiss {
+				drawDiagnosedBox(&left, box, "GT ", thickness, config.HideIDs)
+			} else {
+				drawDiagnosedBox(&left, box, "", maxInt(thickness/2, 1), true)
+			}
+		}
+		for _, box := range diagnosis.Predictions {
+			drawDiagnosedBox(&left, box, "", thickness, config.HideIDs)
+		}
+	}
+
+	if !config.HideCounts {
+		matches, falsePositives, misses, switches := diagnosis.Counts()
+		NewDrawer().Text(
+			&left,
+			fmt.Sprintf("frame %d  TP %d  FP %d  FN %d  IDSW %d", diagnosis.FrameID, matches, falsePositives, misses, switches),
+			image.Point{X: thickness * 5, Y: thickness * 15},
+			0,
+			Color{B: 255, G: 255, R: 255},
+			0,
+			true,
+			Color{},
+			0,
+		)
+	}
+	return left
+}
+
+// drawDiagnosedBox draws a single classified box with an optional ID label.
+func drawDiagnosedBox(frame *gocv.Mat, box norfairgo.DiagnosedBox, prefix string, thickness int, hideID bool) {
+	if len(box.BBox) < 4 {
+		return
+	}
+	color := MOTDebugColors[box.Type]
+	topLeft := image.Point{X: int(box.BBox[0]), Y: int(box.BBox[1])}
+	drawer := NewDrawer()
+	drawer.Rectangle(frame, topLeft, image.Point{X: int(box.BBox[2]), Y: int(box.BBox[3])}, color, thickness)
+	if hideID {
+		return
+	}
+
+	label := fmt.Sprintf("%s%d", prefix, box.ID)
+	if box.Type == motmetrics.EventSwitch && prefix == "" {
+		label = fmt.Sprintf("%d->GT %d", box.ID, box.MatchedID)
+	}
+	drawer.Text(frame, label, image.Point{X: topLeft.X, Y: topLeft.Y - thickness}, 0, color, 0, false, Color{}, 0)
+}
+
+// WriteMOTDebugVideo renders every frame with RenderMOTDebugFrame and writes the
+// result to a video file. Frames are matched to diagnoses by frame number, so
+// pass frames numbered like the MOTChallenge files, e.g. VideoFromFrames.All.
+// The writer closes every frame it reads.
+//
+// Example:
+//
+//	diagnoses, _ := norfairgo.DiagnoseFrames(gt, predictions, 0.5, nil)
+//	sequence, _ := norfairgo.NewVideoFromFrames("MOT17-02", "", false)
+//	err := norfairgodraw.WriteMOTDebugVideo("debug.mp4", 30, sequence.All(), diagnoses, nil)
+//
+// Parameters:
+//   - outputPath: Path of the output video (.mp4 or .avi)
+//   - fps: Frame rate of the output video
+//   - frames: Frame numbers and frames of the sequence
+//   - diagnoses: Diagnosis per frame number from norfairgo.DiagnoseFrames
+//   - config: Optional configuration (can be nil)
+//
+// Returns: Error if the video writer cannot be created or a frame cannot be written
+func WriteMOTDebugVideo(
+	outputPath string,
+	fps float64,
+	frames iter.Seq2[int, gocv.Mat],
+	diagnoses map[int64]*norfairgo.FrameDiagnosis,
+	config *MOTDebugConfig,
+) error {
+	var writer *gocv.VideoWriter
+	defer func() {
+		if writer != nil {
+			writer.Close()
+		}
+	}()
+
+	for frameNumber, frame := range frames {
+		diagnosis := diagnoses[int64(frameNumber)]
+		if diagnosis == nil {
+			diagnosis = &norfairgo.FrameDiagnosis{FrameID: int64(frameNumber)}
+		}
+		rendered := RenderMOTDebugFrame(frame, diagnosis, config)
+		frame.Close()
+
+		if writer == nil {
+			codec := "mp4v"
+			if strings.ToLower(filepath.Ext(outputPath)) == ".avi" {
+				codec = "MJPG" // Like Video.Write
+			}
+			var err error
+			writer, err = gocv.VideoWriterFile(outputPath, codec, fps, rendered.Cols(), rendered.Rows(), true)
+			if err != nil {
+				rendered.Close()
+				return fmt.Errorf("failed to create video writer: %w", err)
+			}
+		}
+		err := writer.Write(rendered)
+		rendered.Close()
+		if err != nil {
+			return fmt.Errorf("failed to write frame %d: %w", frameNumber, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gocv.io/x/gocv"
+
+	"github.com/nmichlo/norfair-go/internal/motmetrics"
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// newDebugDiagnosis builds a diagnosis with one box of every outcome
+func newDebugDiagnosis() *norfairgo.FrameDiagnosis {
+	return &norfairgo.FrameDiagnosis{
+		FrameID: 1,
+		GT: []norfairgo.DiagnosedBox{
+			{BBox: []float64{10, 10, 30, 30}, ID: 1, MatchedID: 5, Type: motmetrics.EventMatch},
+			{BBox: []float64{50, 10, 70, 30}, ID: 2, MatchedID: -1, Type: motmetrics.EventMiss},
+			{BBox: []float64{10, 50, 30, 70}, ID: 3, MatchedID: 7, Type: motmetrics.EventSwitch},
+		},
+		Predictions: []norfairgo.DiagnosedBox{
+			{BBox: []float64{10, 10, 30, 30}, ID: 5, MatchedID: 1, Type: motmetrics.EventMatch},
+			{BBox: []float64{10, 50, 30, 70}, ID: 7, MatchedID: 3, Type: motmetrics.EventSwitch},
+			{BBox: []float64{50, 50, 70, 70}, ID: 9, MatchedID: -1, Type: motmetrics.EventFalsePositive},
+		},
+	}
+}
+
+func TestRenderMOTDebugFrame_Layouts(t *testing.T) {
+	frame := gocv.NewMatWithSize(100, 120, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+	diagnosis := newDebugDiagnosis()
+
+	overlay := RenderMOTDebugFrame(frame, diagnosis, nil)
+	defer overlay.Close()
+	if overlay.Rows() != 100 || overlay.Cols() != 120 {
+		t.Errorf("Expected overlay of 100x120, got %dx%d", overlay.Rows(), overlay.Cols())
+	}
+
+	sideBySide := RenderMOTDebugFrame(frame, diagnosis, &MOTDebugConfig{Layout: MOTDebugSideBySide, HideIDs: true})
+	defer sideBySide.Close()
+	if sideBySide.Rows() != 100 || sideBySide.Cols() != 240 {
+		t.Errorf("Expected side-by-side frame of 100x240, got %dx%d", sideBySide.Rows(), sideBySide.Cols())
+	}
+
+	// Nil diagnoses only draw the counts
+	empty := RenderMOTDebugFrame(frame, nil, &MOTDebugConfig{HideCounts: true})
+	defer empty.Close()
+	if empty.Rows() != 100 || empty.Cols() != 120 {
+		t.Errorf("Expected empty frame of 100x120, got %dx%d", empty.Rows(), empty.Cols())
+	}
+}
+
+func TestWriteMOTDebugVideo(t *testing.T) {
+	frames := func(yield func(int, gocv.Mat) bool) {
+		for i := 1; i <= 3; i++ {
+			if !yield(i, gocv.NewMatWithSize(100, 120, gocv.MatTypeCV8UC3)) {
+				return
+			}
+		}
+	}
+	diagnoses := map[int64]*norfairgo.FrameDiagnosis{1: newDebugDiagnosis()}
+
+	outputPath := filepath.Join(t.TempDir(), "debug.avi")
+	if err := WriteMOTDebugVideo(outputPath, 10, frames, diagnoses, &MOTDebugConfig{Layout: MOTDebugSideBySide}); err != nil {
+		t.Fatalf("WriteMOTDebugVideo failed: %v", err)
+	}
+	if info, err := os.Stat(outputPath); err != nil || info.Size() == 0 {
+		t.Errorf("Expected a non-empty debug video, got %v", err)
+	}
+}
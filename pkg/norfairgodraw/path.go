@@ -1,8 +1,11 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
 	"image"
 	"math"
+	"sync"
 
 	"gocv.io/x/gocv"
 	"gonum.org/v1/gonum/mat"
@@ -42,6 +45,78 @@ func defaultGetPointsToDraw(estimate *mat.Dense) []image.Point {
 
 // Note: linspace moved to internal/numpy package
 
+// =============================================================================
+// Path Options
+// =============================================================================
+
+// pathConfig collects the settings shared by Paths and AbsolutePaths constructors.
+type pathConfig struct {
+	getPointsToDraw GetPointsToDrawFunc
+	thickness       *int
+	color           *Color
+	radius          *int
+	attenuation     float64
+	maxHistory      int
+	simplify        float64
+}
+
+// PathOption configures NewPathsWithOptions and NewAbsolutePathsWithOptions.
+// Options that only apply to one of the drawers are ignored by the other.
+type PathOption func(*pathConfig)
+
+// WithPointExtractor sets the function used to extract points from an estimate (default: centroid).
+func WithPointExtractor(getPointsToDraw GetPointsToDrawFunc) PathOption {
+	return func(c *pathConfig) { c.getPointsToDraw = getPointsToDraw }
+}
+
+// WithThickness sets the circle/line thickness (default: auto-calculated from frame size).
+func WithThickness(thickness int) PathOption {
+	return func(c *pathConfig) { c.thickness = &thickness }
+}
+
+// WithColor sets a fixed color for all paths (default: palette color by ID).
+func WithColor(color Color) PathOption {
+	return func(c *pathConfig) { c.color = &color }
+}
+
+// WithRadius sets the circle radius (default: auto-calculated from frame size).
+func WithRadius(radius int) PathOption {
+	return func(c *pathConfig) { c.radius = &radius }
+}
+
+// WithAttenuation sets the fade rate in [0, 1] for Paths (default 0.01).
+// Ignored by AbsolutePaths.
+func WithAttenuation(attenuation float64) PathOption {
+	return func(c *pathConfig) { c.attenuation = attenuation }
+}
+
+// WithMaxHistory sets the number of past positions kept per object for AbsolutePaths (default 20).
+// Ignored by Paths.
+func WithMaxHistory(maxHistory int) PathOption {
+	return func(c *pathConfig) { c.maxHistory = maxHistory }
+}
+
+// WithSimplifyTolerance merges nearly collinear history segments of AbsolutePaths
+// with the Douglas-Peucker simplification (see norfairgo.SimplifyPath), drawing
+// far fewer blended segments for objects moving in straight lines (default 0, every
+// segment). The tolerance is in absolute coordinates and applies to the first point
+// of each position. Ignored by Paths, which keeps no history.
+func WithSimplifyTolerance(tolerance float64) PathOption {
+	return func(c *pathConfig) { c.simplify = tolerance }
+}
+
+// newPathConfig returns the default configuration with all options applied.
+func newPathConfig(opts []PathOption) *pathConfig {
+	config := &pathConfig{
+		attenuation: 0.01,
+		maxHistory:  20,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
 // =============================================================================
 // Paths (for static cameras)
 // =============================================================================
@@ -49,6 +124,10 @@ func defaultGetPointsToDraw(estimate *mat.Dense) []image.Point {
 // Paths draws motion trails for tracked objects using relative coordinates.
 // It accumulates circles on a mask that fades over time, creating a trail effect.
 // This is designed for static cameras only - it will warn if used with camera motion.
+//
+// Draw and Close calls are serialized, which only protects the Paths' own state:
+// the tracked objects passed to Draw are modified by Tracker.Update. To render on
+// a different goroutine from tracking, pass norfairgo.SnapshotObjects to DrawObjects.
 type Paths struct {
 	getPointsToDraw    GetPointsToDrawFunc
 	thickness          *int
@@ -60,6 +139,7 @@ type Paths struct {
 	drawer             *Drawer
 	palette            *Palette
 	warnedCameraMotion bool
+	mu                 sync.Mutex // Guards mask and lazily computed parameters
 }
 
 // NewPaths creates a new Paths drawer for motion trail visualization.
@@ -95,6 +175,16 @@ func NewPaths(
 	}
 }
 
+// NewPathsWithOptions creates a new Paths drawer configured with functional options.
+//
+// Example:
+//
+//	paths := NewPathsWithOptions(WithRadius(4), WithAttenuation(0.05))
+func NewPathsWithOptions(opts ...PathOption) *Paths {
+	config := newPathConfig(opts)
+	return NewPaths(config.getPointsToDraw, config.thickness, config.color, config.radius, config.attenuation)
+}
+
 // Draw updates the path visualization and returns a new frame.
 // The returned frame is the input frame with paths alpha-blended on top.
 //
@@ -104,6 +194,14 @@ func NewPaths(
 //
 // Returns: New Mat with paths drawn (caller must Close() when done)
 func (p *Paths) Draw(frame *gocv.Mat, trackedObjects []*norfairgo.TrackedObject) gocv.Mat {
+	return p.DrawObjects(frame, norfairgo.AsTrackedObjectLikes(trackedObjects))
+}
+
+// DrawObjects is Draw for any track representation implementing TrackedObjectLike.
+func (p *Paths) DrawObjects(frame *gocv.Mat, trackedObjects []TrackedObjectLike) gocv.Mat {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	// Lazy initialization of mask
 	if p.mask == nil {
 		// Calculate frame scale for auto-sizing
@@ -132,7 +230,7 @@ func (p *Paths) Draw(frame *gocv.Mat, trackedObjects []*norfairgo.TrackedObject)
 	// Draw current positions for each tracked object
 	for _, obj := range trackedObjects {
 		// Warn once if used with camera motion (incompatible)
-		if obj.AbsToRel != nil && !p.warnedCameraMotion {
+		if to, ok := obj.(*norfairgo.TrackedObject); ok && to.AbsToRel != nil && !p.warnedCameraMotion {
 			norfairgo.WarnOnce("Paths is not compatible with camera motion. Use AbsolutePaths instead.")
 			p.warnedCameraMotion = true
 		}
@@ -166,6 +264,9 @@ func (p *Paths) Draw(frame *gocv.Mat, trackedObjects []*norfairgo.TrackedObject)
 // Close releases the internal mask Mat.
 // This should be called when the Paths drawer is no longer needed.
 func (p *Paths) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.mask != nil {
 		p.mask.Close()
 		p.mask = nil
@@ -179,16 +280,21 @@ func (p *Paths) Close() {
 // AbsolutePaths draws motion trails for tracked objects using absolute world coordinates.
 // It stores historical positions and transforms them to the current camera frame.
 // This supports camera motion by using coordinate transformations.
+//
+// Draw calls are serialized, which only protects the AbsolutePaths' own state (see
+// Paths for drawing on a different goroutine from tracking).
 type AbsolutePaths struct {
 	getPointsToDraw GetPointsToDrawFunc
 	thickness       *int
 	color           *Color
 	radius          *int
 	maxHistory      int
+	simplify        float64                 // Douglas-Peucker tolerance of the history (0 = none)
 	pastPoints      map[int][][]image.Point // Object ID -> history of absolute positions
 	alphas          []float64               // Alpha values for each history step
 	drawer          *Drawer
 	palette         *Palette
+	mu              sync.Mutex // Guards pastPoints and lazily computed parameters
 }
 
 // NewAbsolutePaths creates a new AbsolutePaths drawer for motion trail visualization with camera motion.
@@ -232,6 +338,18 @@ func NewAbsolutePaths(
 	}
 }
 
+// NewAbsolutePathsWithOptions creates a new AbsolutePaths drawer configured with functional options.
+//
+// Example:
+//
+//	paths := NewAbsolutePathsWithOptions(WithMaxHistory(50), WithThickness(2))
+func NewAbsolutePathsWithOptions(opts ...PathOption) *AbsolutePaths {
+	config := newPathConfig(opts)
+	paths := NewAbsolutePaths(config.getPointsToDraw, config.thickness, config.color, config.radius, config.maxHistory)
+	paths.simplify = config.simplify
+	return paths
+}
+
 // Draw updates the absolute path visualization and returns a new frame.
 // The returned frame is the input frame with paths drawn on top.
 //
@@ -246,6 +364,18 @@ func (ap *AbsolutePaths) Draw(
 	trackedObjects []*norfairgo.TrackedObject,
 	coordTransform norfairgo.CoordinateTransformation,
 ) gocv.Mat {
+	return ap.DrawObjects(frame, norfairgo.AsTrackedObjectLikes(trackedObjects), coordTransform)
+}
+
+// DrawObjects is Draw for any track representation implementing TrackedObjectLike.
+func (ap *AbsolutePaths) DrawObjects(
+	frame *gocv.Mat,
+	trackedObjects []TrackedObjectLike,
+	coordTransform norfairgo.CoordinateTransformation,
+) gocv.Mat {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
 	// Auto-calculate parameters if not set
 	frameScale := float64(frame.Rows()) / 100.0
 
@@ -262,7 +392,7 @@ func (ap *AbsolutePaths) Draw(
 	// Process each tracked object
 	for _, obj := range trackedObjects {
 		// Skip objects with no live points
-		if !norfairgo.AnyTrue(obj.LivePoints()) {
+		if !norfairgo.AnyTrue(obj.GetLivePoints()) {
 			continue
 		}
 
@@ -294,12 +424,15 @@ func (ap *AbsolutePaths) Draw(
 		}
 		objIDVal := *objID
 		if history, exists := ap.pastPoints[objIDVal]; exists && len(history) > 0 {
-			lastAbsolute := absolutePoints
+			// Positions from the current to the oldest drawn one
+			positions := append([][]image.Point{absolutePoints}, history...)
+			if len(positions) > len(ap.alphas)+1 {
+				positions = positions[:len(ap.alphas)+1]
+			}
+			kept := ap.simplifiedIndices(positions)
 
-			for i, pastAbsolute := range history {
-				if i >= len(ap.alphas) {
-					break
-				}
+			for k := 1; k < len(kept); k++ {
+				lastAbsolute, pastAbsolute := positions[kept[k-1]], positions[kept[k]]
 
 				// Create overlay for this segment
 				overlay := frame.Clone()
@@ -315,17 +448,14 @@ func (ap *AbsolutePaths) Draw(
 					}
 				}
 
-				// Alpha blend overlay with frame
-				alpha := ap.alphas[i]
+				// Alpha blend overlay with frame, with the alpha of the newest merged segment
+				alpha := ap.alphas[kept[k-1]]
 				blended := ap.drawer.AlphaBlend(&overlay, frame, alpha, 1.0, 0.0)
 				overlay.Close()
 
 				// Replace frame with blended result
 				frame.Close()
 				*frame = blended
-
-				// Move to next segment
-				lastAbsolute = pastAbsolute
 			}
 		}
 
@@ -346,6 +476,20 @@ func (ap *AbsolutePaths) Draw(
 	return *frame
 }
 
+// simplifiedIndices returns the indices of the positions to draw segments between:
+// all of them, or those kept by the Douglas-Peucker simplification of their first
+// points with WithSimplifyTolerance.
+func (ap *AbsolutePaths) simplifiedIndices(positions [][]image.Point) []int {
+	path := make([][2]float64, len(positions))
+	for i, points := range positions {
+		if len(points) == 0 {
+			return norfairgo.SimplifyPathIndices(path, 0)
+		}
+		path[i] = [2]float64{float64(points[0].X), float64(points[0].Y)}
+	}
+	return norfairgo.SimplifyPathIndices(path, ap.simplify)
+}
+
 // transformPointsToRelative transforms a slice of absolute points to relative coordinates.
 func (ap *AbsolutePaths) transformPointsToRelative(
 	points []image.Point,
@@ -1,7 +1,11 @@
+//go:build !nogocv
+
 package norfairgodraw
 
 import (
 	"image"
+	"reflect"
+	"sync"
 	"testing"
 
 	"gocv.io/x/gocv"
@@ -40,14 +44,8 @@ func (m *mockTrackedObjectForPaths) LivePoints() []bool {
 	return m.livePoints
 }
 
-// Ensure mockTrackedObjectForPaths implements norfairgo.TrackedObject
-var _ interface {
-	GetEstimate(bool) (*mat.Dense, error)
-	GetID() *int
-	GetLabel() *string
-	GetLivePoints() []bool
-	LivePoints() []bool
-} = (*mockTrackedObjectForPaths)(nil)
+// Ensure mockTrackedObjectForPaths implements norfairgo.TrackedObjectLike
+var _ norfairgo.TrackedObjectLike = (*mockTrackedObjectForPaths)(nil)
 
 // TestPaths_LazyInit verifies that mask is created on first Draw() call
 func TestPaths_LazyInit(t *testing.T) {
@@ -198,6 +196,30 @@ func TestPaths_Accumulation(t *testing.T) {
 	}
 }
 
+// TestPaths_DrawObjects verifies that custom track representations are drawn
+func TestPaths_DrawObjects(t *testing.T) {
+	radius := 5
+	thickness := -1
+	paths := NewPaths(nil, &thickness, nil, &radius, 0.0)
+
+	frame := gocv.NewMatWithSize(480, 640, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	id := 1
+	obj := &mockTrackedObjectForPaths{
+		estimate:   mat.NewDense(1, 2, []float64{100, 100}),
+		id:         &id,
+		livePoints: []bool{true},
+	}
+
+	result := paths.DrawObjects(&frame, []TrackedObjectLike{obj})
+	defer result.Close()
+
+	if pixel := paths.mask.GetVecbAt(100, 100); pixel[0] == 0 && pixel[1] == 0 && pixel[2] == 0 {
+		t.Error("Expected the object position to be drawn in the mask")
+	}
+}
+
 // TestPaths_ColorByID verifies palette-based color selection
 func TestPaths_ColorByID(t *testing.T) {
 	paths := NewPaths(nil, nil, nil, nil, 0.01)
@@ -744,3 +766,166 @@ func TestAbsolutePaths_TransformPointsToRelative_Empty(t *testing.T) {
 
 // NOTE: Additional tests for AbsolutePaths.Draw() with live TrackedObjects
 // are covered by integration tests due to complexity of creating mock TrackedObject instances
+
+// =============================================================================
+// Path Options Tests
+// =============================================================================
+
+// TestNewPathsWithOptions_Defaults verifies options constructor defaults match NewPaths
+func TestNewPathsWithOptions_Defaults(t *testing.T) {
+	paths := NewPathsWithOptions()
+
+	if paths.attenuation != 0.01 {
+		t.Errorf("Expected default attenuation 0.01, got %f", paths.attenuation)
+	}
+	if paths.thickness != nil || paths.radius != nil || paths.color != nil {
+		t.Error("Expected thickness, radius and color to be auto by default")
+	}
+	if paths.getPointsToDraw == nil {
+		t.Error("Expected default getPointsToDraw to be set")
+	}
+}
+
+// TestNewPathsWithOptions_AllOptions verifies each option is applied to Paths
+func TestNewPathsWithOptions_AllOptions(t *testing.T) {
+	customColor := Color{B: 0, G: 255, R: 0}
+	called := false
+	extractor := func(*mat.Dense) []image.Point {
+		called = true
+		return nil
+	}
+
+	paths := NewPathsWithOptions(
+		WithRadius(7),
+		WithThickness(3),
+		WithColor(customColor),
+		WithAttenuation(0.2),
+		WithMaxHistory(99), // Ignored by Paths
+		WithPointExtractor(extractor),
+	)
+
+	if paths.radius == nil || *paths.radius != 7 {
+		t.Errorf("Expected radius 7, got %v", paths.radius)
+	}
+	if paths.thickness == nil || *paths.thickness != 3 {
+		t.Errorf("Expected thickness 3, got %v", paths.thickness)
+	}
+	if paths.color == nil || *paths.color != customColor {
+		t.Errorf("Expected color %v, got %v", customColor, paths.color)
+	}
+	if paths.attenuation != 0.2 || paths.attenuationFactor != 0.8 {
+		t.Errorf("Expected attenuation 0.2 (factor 0.8), got %f (factor %f)", paths.attenuation, paths.attenuationFactor)
+	}
+
+	paths.getPointsToDraw(mat.NewDense(1, 2, []float64{1, 2}))
+	if !called {
+		t.Error("Expected custom point extractor to be used")
+	}
+}
+
+// TestNewAbsolutePathsWithOptions verifies options are applied to AbsolutePaths
+func TestNewAbsolutePathsWithOptions(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		ap := NewAbsolutePathsWithOptions()
+
+		if ap.maxHistory != 20 || len(ap.alphas) != 20 {
+			t.Errorf("Expected default maxHistory 20, got %d (%d alphas)", ap.maxHistory, len(ap.alphas))
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		customColor := Color{B: 255, G: 0, R: 0}
+		ap := NewAbsolutePathsWithOptions(
+			WithMaxHistory(5),
+			WithRadius(2),
+			WithThickness(1),
+			WithColor(customColor),
+			WithAttenuation(0.5), // Ignored by AbsolutePaths
+		)
+
+		if ap.maxHistory != 5 || len(ap.alphas) != 5 {
+			t.Errorf("Expected maxHistory 5, got %d (%d alphas)", ap.maxHistory, len(ap.alphas))
+		}
+		if ap.radius == nil || *ap.radius != 2 {
+			t.Errorf("Expected radius 2, got %v", ap.radius)
+		}
+		if ap.thickness == nil || *ap.thickness != 1 {
+			t.Errorf("Expected thickness 1, got %v", ap.thickness)
+		}
+		if ap.color == nil || *ap.color != customColor {
+			t.Errorf("Expected color %v, got %v", customColor, ap.color)
+		}
+	})
+}
+
+func TestAbsolutePaths_SimplifyTolerance(t *testing.T) {
+	// Straight run from x=0 to x=40, then a turn
+	var positions [][]image.Point
+	for x := 0; x <= 40; x += 10 {
+		positions = append(positions, []image.Point{{X: x, Y: 0}})
+	}
+	positions = append(positions, []image.Point{{X: 40, Y: 10}})
+
+	if kept := NewAbsolutePathsWithOptions().simplifiedIndices(positions); len(kept) != len(positions) {
+		t.Errorf("Expected every position without a tolerance, got %v", kept)
+	}
+	ap := NewAbsolutePathsWithOptions(WithSimplifyTolerance(1))
+	if kept := ap.simplifiedIndices(positions); !reflect.DeepEqual(kept, []int{0, 4, 5}) {
+		t.Errorf("Expected the straight run to merge into one segment, got %v", kept)
+	}
+	if kept := ap.simplifiedIndices(append(positions, nil)); len(kept) != len(positions)+1 {
+		t.Errorf("Expected positions without points to disable simplification, got %v", kept)
+	}
+}
+
+// =============================================================================
+// Concurrency Tests
+// =============================================================================
+
+// TestPaths_ConcurrentDraw verifies Draw can be called from multiple goroutines.
+// Run with -race to detect unsynchronized access to the mask and lazy parameters.
+func TestPaths_ConcurrentDraw(t *testing.T) {
+	paths := NewPaths(nil, nil, nil, nil, 0.01)
+	defer paths.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			frame := gocv.NewMatWithSize(120, 160, gocv.MatTypeCV8UC3)
+			defer frame.Close()
+
+			result := paths.Draw(&frame, []*norfairgo.TrackedObject{})
+			result.Close()
+		}()
+	}
+	wg.Wait()
+
+	if paths.mask == nil || paths.radius == nil || paths.thickness == nil {
+		t.Error("Expected mask and parameters to be initialized")
+	}
+}
+
+// TestAbsolutePaths_ConcurrentDraw verifies Draw can be called from multiple goroutines.
+func TestAbsolutePaths_ConcurrentDraw(t *testing.T) {
+	ap := NewAbsolutePaths(nil, nil, nil, nil, 5)
+	coordTransform := &mockCoordinateTransformation{offset: image.Point{X: 0, Y: 0}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			frame := gocv.NewMatWithSize(120, 160, gocv.MatTypeCV8UC3)
+			defer frame.Close()
+			result := ap.Draw(&frame, []*norfairgo.TrackedObject{}, coordTransform)
+			result.Close()
+		}()
+	}
+	wg.Wait()
+
+	if ap.radius == nil || ap.thickness == nil {
+		t.Error("Expected parameters to be initialized")
+	}
+}
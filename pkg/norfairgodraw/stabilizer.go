@@ -0,0 +1,102 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// Stabilizer warps frames into the absolute coordinate system of the camera motion
+// estimation (the first reference frame), producing stabilized video: static
+// scenery stays at the same output pixels while the camera moves.
+//
+// Unlike FixedCamera, which only pastes translated frames onto a canvas, frames
+// are warped with gocv.WarpPerspective, so any CoordinateTransformation
+// (including HomographyTransformation) is supported.
+type Stabilizer struct {
+	// OutputSize is the (width, height) of the stabilized frames.
+	// Default: the size of each input frame (zero value)
+	OutputSize image.Point
+
+	// Offset is the output pixel of the absolute origin. Use a positive offset and
+	// a larger OutputSize to keep frames visible when the camera pans up or left.
+	// Default: (0, 0)
+	Offset image.Point
+
+	// BorderColor fills output pixels not covered by the frame.
+	// Default: black
+	BorderColor color.RGBA
+}
+
+// NewStabilizer creates a Stabilizer whose output is outputSize with the
+// absolute origin at offset.
+func NewStabilizer(outputSize, offset image.Point) *Stabilizer {
+	return &Stabilizer{OutputSize: outputSize, Offset: offset}
+}
+
+// Stabilize returns the frame warped into absolute coordinates. A nil
+// coordTransform (e.g. from MotionEstimator.Update on the first frame) is the
+// identity. The caller must Close the returned Mat.
+func (s *Stabilizer) Stabilize(frame *gocv.Mat, coordTransform norfairgo.CoordinateTransformation) (gocv.Mat, error) {
+	width, height := frame.Cols(), frame.Rows()
+	homography, err := StabilizationMatrix(coordTransform, width, height)
+	if err != nil {
+		return gocv.NewMat(), err
+	}
+	offset := mat.NewDense(3, 3, []float64{1, 0, float64(s.Offset.X), 0, 1, float64(s.Offset.Y), 0, 0, 1})
+	homography.Mul(offset, homography)
+
+	size := s.OutputSize
+	if size.X <= 0 || size.Y <= 0 {
+		size = image.Pt(width, height)
+	}
+	warp := gocv.NewMatWithSize(3, 3, gocv.MatTypeCV64F)
+	defer warp.Close()
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			warp.SetDoubleAt(i, j, homography.At(i, j))
+		}
+	}
+
+	stabilized := gocv.NewMat()
+	if err := gocv.WarpPerspectiveWithParams(*frame, &stabilized, warp, size, gocv.InterpolationLinear, gocv.BorderConstant, s.BorderColor); err != nil {
+		stabilized.Close()
+		return gocv.NewMat(), err
+	}
+	return stabilized, nil
+}
+
+// StabilizationMatrix returns the 3x3 homography mapping relative pixel
+// coordinates of a width x height frame to absolute coordinates, i.e. the matrix
+// form of coordTransform.RelToAbs. Translations and homographies are converted
+// exactly; other transformations are fitted to the mapped frame corners. A nil
+// coordTransform gives the identity.
+func StabilizationMatrix(coordTransform norfairgo.CoordinateTransformation, width, height int) (*mat.Dense, error) {
+	switch trans := coordTransform.(type) {
+	case nil, *norfairgo.NilCoordinateTransformation:
+		return mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}), nil
+	case *norfairgo.TranslationTransformation:
+		// RelToAbs subtracts the movement vector
+		dx, dy := trans.MovementVector[0], trans.MovementVector[1]
+		return mat.NewDense(3, 3, []float64{1, 0, -dx, 0, 1, -dy, 0, 0, 1}), nil
+	case *norfairgo.HomographyTransformation:
+		return mat.DenseCopyOf(trans.InverseHomographyMatrix), nil
+	}
+
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("frame size must be positive, got %dx%d", width, height)
+	}
+	corners := mat.NewDense(4, 2, []float64{0, 0, float64(width), 0, float64(width), float64(height), 0, float64(height)})
+	homography, _, err := norfairgo.FindHomographyRANSAC(corners, coordTransform.RelToAbs(corners), 1, 1, 0.995)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fit a homography to %T: %v", coordTransform, err)
+	}
+	return homography, nil
+}
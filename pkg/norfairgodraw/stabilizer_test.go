@@ -0,0 +1,83 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// assertMatchesRelToAbs checks that the stabilization matrix maps points like RelToAbs.
+func assertMatchesRelToAbs(t *testing.T, coordTransform norfairgo.CoordinateTransformation) {
+	t.Helper()
+	homography, err := StabilizationMatrix(coordTransform, 100, 80)
+	if err != nil {
+		t.Fatalf("StabilizationMatrix failed: %v", err)
+	}
+	points := mat.NewDense(3, 2, []float64{0, 0, 50, 20, 100, 80})
+	expected := coordTransform.RelToAbs(points)
+	for i := 0; i < 3; i++ {
+		x, y := points.At(i, 0), points.At(i, 1)
+		w := homography.At(2, 0)*x + homography.At(2, 1)*y + homography.At(2, 2)
+		gotX := (homography.At(0, 0)*x + homography.At(0, 1)*y + homography.At(0, 2)) / w
+		gotY := (homography.At(1, 0)*x + homography.At(1, 1)*y + homography.At(1, 2)) / w
+		if math.Abs(gotX-expected.At(i, 0)) > 1e-6 || math.Abs(gotY-expected.At(i, 1)) > 1e-6 {
+			t.Errorf("%T: point %d mapped to (%v, %v), expected (%v, %v)", coordTransform, i, gotX, gotY, expected.At(i, 0), expected.At(i, 1))
+		}
+	}
+}
+
+func TestStabilizationMatrix(t *testing.T) {
+	translation, _ := norfairgo.NewTranslationTransformation([]float64{10, -5})
+	assertMatchesRelToAbs(t, translation)
+
+	homography, _ := norfairgo.NewHomographyTransformation(mat.NewDense(3, 3, []float64{1.1, 0.05, 3, -0.02, 0.95, 7, 0.0001, 0, 1}))
+	assertMatchesRelToAbs(t, homography)
+
+	// Other transformations are fitted to the frame corners
+	assertMatchesRelToAbs(t, &mockFixedCameraTransform{offset: image.Point{X: 4, Y: -3}})
+
+	identity, err := StabilizationMatrix(nil, 100, 80)
+	if err != nil || !mat.Equal(identity, mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1})) {
+		t.Errorf("Expected the identity for a nil transformation, got %v (%v)", identity, err)
+	}
+}
+
+func TestStabilizer_Stabilize(t *testing.T) {
+	frame := gocv.NewMatWithSize(40, 60, gocv.MatTypeCV8UC1)
+	defer frame.Close()
+	frame.SetUCharAt(10, 20, 255)
+
+	// RelToAbs moves points right by 5 pixels
+	translation, _ := norfairgo.NewTranslationTransformation([]float64{-5, 0})
+	stabilizer := NewStabilizer(image.Pt(80, 50), image.Pt(2, 3))
+	stabilized, err := stabilizer.Stabilize(&frame, translation)
+	if err != nil {
+		t.Fatalf("Stabilize failed: %v", err)
+	}
+	defer stabilized.Close()
+
+	if stabilized.Cols() != 80 || stabilized.Rows() != 50 {
+		t.Fatalf("Expected 80x50 output, got %dx%d", stabilized.Cols(), stabilized.Rows())
+	}
+	// The pixel moves to its absolute position (25, 10) plus the offset
+	if got := stabilized.GetUCharAt(13, 27); got != 255 {
+		t.Errorf("Expected the stabilized pixel at (27, 13), got value %d", got)
+	}
+
+	// Without an output size, the frame size is kept
+	defaultSize, err := (&Stabilizer{}).Stabilize(&frame, nil)
+	if err != nil {
+		t.Fatalf("Stabilize failed: %v", err)
+	}
+	defer defaultSize.Close()
+	if defaultSize.Cols() != 60 || defaultSize.Rows() != 40 || defaultSize.GetUCharAt(10, 20) != 255 {
+		t.Error("Expected the identity warp at the frame size for a nil transformation")
+	}
+}
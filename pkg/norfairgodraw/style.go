@@ -0,0 +1,146 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"gocv.io/x/gocv"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+)
+
+// =============================================================================
+// Style - Per-Label Drawing Configuration
+// =============================================================================
+
+// RenderMode selects how DrawStyled renders an object.
+type RenderMode int
+
+const (
+	// RenderAuto draws 2-point objects as boxes and other objects as points.
+	RenderAuto RenderMode = iota
+
+	// RenderBoxes draws bounding boxes (objects without exactly 2 points are skipped).
+	RenderBoxes
+
+	// RenderPoints draws every point of the object.
+	RenderPoints
+
+	// RenderHidden does not draw the object.
+	RenderHidden
+)
+
+// LabelStyle is how DrawStyled draws the objects of one label.
+type LabelStyle struct {
+	Color      interface{} // Color, name, hex string or strategy (nil = "by_id")
+	Palette    []Color     // Colors of the "by_id" and "by_label" strategies (nil = default palette)
+	Thickness  *int        // Box line thickness, or point outline thickness with -1 filled (nil = auto)
+	Radius     *int        // Point radius (nil = auto)
+	TextSize   *float64    // Text size (nil = auto)
+	DrawIDs    bool        // Draw object IDs
+	DrawLabels bool        // Draw object labels
+	DrawScores bool        // Draw detection scores
+	Render     RenderMode  // Boxes, points or hidden (default RenderAuto)
+}
+
+// DrawStyle maps labels to drawing styles, so mixed-class scenes stay legible, e.g.
+// people as blue points with IDs and vehicles as red boxes without.
+type DrawStyle struct {
+	Default LabelStyle            // Style of unlabeled objects and of labels missing from Labels
+	Labels  map[string]LabelStyle // Style per label
+}
+
+// For returns the style of a label.
+func (s *DrawStyle) For(label *string) LabelStyle {
+	if label != nil {
+		if style, ok := s.Labels[*label]; ok {
+			return style
+		}
+	}
+	return s.Default
+}
+
+// DrawStyled draws Detections or TrackedObjects with the style of their label,
+// through DrawBoxes and DrawPoints. Labels are drawn in the order they first appear.
+// A nil style draws every object with the zero LabelStyle.
+func DrawStyled(frame *gocv.Mat, drawables []interface{}, style *DrawStyle) *gocv.Mat {
+	if len(drawables) == 0 {
+		return frame
+	}
+	if style == nil {
+		style = &DrawStyle{}
+	}
+
+	// Group by label, keeping the order of first appearance
+	type labelGroup struct {
+		style  LabelStyle
+		boxes  []interface{}
+		points []interface{}
+	}
+	var groups []*labelGroup
+	byLabel := make(map[string]*labelGroup)
+	var unlabeled *labelGroup
+	for _, obj := range drawables {
+		d, ok := obj.(*Drawable)
+		if !ok {
+			var err error
+			if d, err = createDrawableFromInterface(obj); err != nil {
+				continue // Skip invalid objects
+			}
+		}
+
+		var group *labelGroup
+		if d.Label == nil {
+			group = unlabeled
+		} else {
+			group = byLabel[*d.Label]
+		}
+		if group == nil {
+			group = &labelGroup{style: style.For(d.Label)}
+			if d.Label == nil {
+				unlabeled = group
+			} else {
+				byLabel[*d.Label] = group
+			}
+			groups = append(groups, group)
+		}
+
+		switch group.style.Render {
+		case RenderHidden:
+		case RenderBoxes:
+			group.boxes = append(group.boxes, d)
+		case RenderPoints:
+			group.points = append(group.points, d)
+		default:
+			if rows, _ := d.Points.Dims(); rows == 2 {
+				group.boxes = append(group.boxes, d)
+			} else {
+				group.points = append(group.points, d)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		s := group.style
+		palette := NewPalette(s.Palette)
+		if len(group.boxes) > 0 {
+			drawBoxes(frame, group.boxes, s.Color, s.Thickness, s.DrawLabels, s.TextSize, s.DrawIDs,
+				nil, nil, true, s.DrawScores, palette)
+		}
+		if len(group.points) > 0 {
+			drawPointsWithPalette(frame, group.points, s.Radius, s.Thickness, s.Color, s.DrawLabels, s.TextSize,
+				s.DrawIDs, true, nil, nil, false, s.DrawScores, palette)
+		}
+	}
+
+	return frame
+}
+
+// StyledAnnotator draws tracked objects with per-label styles (see DrawStyled).
+type StyledAnnotator struct {
+	Style *DrawStyle
+}
+
+// Annotate draws the objects onto the frame.
+func (a *StyledAnnotator) Annotate(frame *gocv.Mat, objects []*norfairgo.TrackedObject) {
+	DrawStyled(frame, toDrawables(objects), a.Style)
+}
@@ -0,0 +1,54 @@
+//go:build !nogocv
+
+package norfairgodraw
+
+import (
+	"testing"
+
+	"gocv.io/x/gocv"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDrawStyled_PerLabel(t *testing.T) {
+	frame := gocv.NewMatWithSize(200, 200, gocv.MatTypeCV8UC3)
+	defer frame.Close()
+
+	person, car, bike := "person", "car", "bike"
+	id := 1
+	personBox, _ := NewDrawable(mat.NewDense(2, 2, []float64{20, 20, 60, 60}), &id, &person, nil, nil)
+	carBox, _ := NewDrawable(mat.NewDense(2, 2, []float64{100, 100, 180, 180}), &id, &car, nil, nil)
+	bikeBox, _ := NewDrawable(mat.NewDense(2, 2, []float64{120, 20, 180, 60}), &id, &bike, nil, nil)
+
+	thickness := 2
+	radius := 3
+	style := &DrawStyle{
+		Default: LabelStyle{Render: RenderHidden},
+		Labels: map[string]LabelStyle{
+			"person": {Color: Color{B: 255}, Render: RenderPoints, Radius: &radius},
+			"car":    {Palette: []Color{{R: 255}}, Thickness: &thickness},
+		},
+	}
+	DrawStyled(&frame, []interface{}{personBox, carBox, bikeBox}, style)
+
+	// Person corners are drawn as blue points, not as a box
+	if pixel := frame.GetVecbAt(20, 20); pixel[0] != 255 || pixel[2] != 0 {
+		t.Errorf("Expected a blue point at the person corner, got %v", pixel)
+	}
+	if pixel := frame.GetVecbAt(20, 40); pixel[0] != 0 {
+		t.Errorf("Expected no box edge between the person corners, got %v", pixel)
+	}
+
+	// The car box uses its single-color palette
+	if pixel := frame.GetVecbAt(100, 140); pixel[2] != 255 || pixel[0] != 0 {
+		t.Errorf("Expected a red car box edge, got %v", pixel)
+	}
+
+	// Bikes have no style and the default hides them
+	if pixel := frame.GetVecbAt(20, 150); pixel[0] != 0 || pixel[1] != 0 || pixel[2] != 0 {
+		t.Errorf("Expected the bike to be hidden, got %v", pixel)
+	}
+
+	if DrawStyled(&frame, nil, nil) != &frame {
+		t.Error("DrawStyled should return the frame")
+	}
+}
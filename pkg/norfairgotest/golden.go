@@ -0,0 +1,314 @@
+//go:build !nogocv
+
+/*
+Package norfairgotest provides golden-image test helpers for code that renders
+overlays with gocv, e.g. applications drawing tracked objects with norfairgodraw.
+
+A rendered frame is compared to a reference PNG ("golden image") with a pixel
+ratio or SSIM metric. On failure the absolute difference is written next to the
+golden image for inspection. Golden images are (re)generated by running the tests
+with NORFAIRGO_UPDATE_GOLDEN=1 (or any other true value of strconv.ParseBool) in
+the environment:
+
+	func TestOverlay(t *testing.T) {
+		frame := gocv.NewMatWithSize(240, 320, gocv.MatTypeCV8UC3)
+		defer frame.Close()
+		drawing.DrawBoxes(&frame, objects)
+
+		norfairgotest.CompareToGoldenImage(t, &frame, "testdata/overlay.png", 0.95)
+
+		// or, tolerant to small shifts and blur
+		norfairgotest.Comparison{Metric: norfairgotest.SSIM, MinSimilarity: 0.9}.
+			CompareToGolden(t, &frame, "testdata/overlay.png")
+	}
+
+	NORFAIRGO_UPDATE_GOLDEN=1 go test ./...
+*/
+package norfairgotest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"gocv.io/x/gocv"
+)
+
+// UpdateEnv is the environment variable that enables regeneration of golden images.
+//
+// An environment variable is used instead of a flag so that importing the package
+// does not register flags on flag.CommandLine of the test binary.
+const UpdateEnv = "NORFAIRGO_UPDATE_GOLDEN"
+
+// UpdateGolden reports whether golden images should be rewritten instead of
+// compared, i.e. whether UpdateEnv parses as true with strconv.ParseBool. Values
+// like "0", "false" or an unparsable value leave regeneration disabled.
+func UpdateGolden() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return err == nil && enabled
+}
+
+// =============================================================================
+// Comparison
+// =============================================================================
+
+// Metric is an image similarity metric in [0, 1].
+type Metric int
+
+const (
+	// PixelRatio is the share of pixels whose channels all differ by at most
+	// Comparison.PixelTolerance (see ImageSimilarity).
+	PixelRatio Metric = iota
+	// SSIM is the mean structural similarity (see StructuralSimilarity), which is
+	// less sensitive to anti-aliasing and small shifts than PixelRatio.
+	SSIM
+)
+
+// String returns the name of the metric.
+func (m Metric) String() string {
+	switch m {
+	case PixelRatio:
+		return "pixel ratio"
+	case SSIM:
+		return "SSIM"
+	default:
+		return fmt.Sprintf("Metric(%d)", int(m))
+	}
+}
+
+// Comparison configures a golden-image comparison.
+type Comparison struct {
+	// Similarity metric.
+	// Default: PixelRatio
+	Metric Metric
+
+	// Minimum similarity for the comparison to pass.
+	// Default: 0.95 (if 0)
+	MinSimilarity float64
+
+	// Maximum per-channel difference of matching pixels for PixelRatio, absorbing
+	// anti-aliasing differences between OpenCV builds.
+	// Default: 5 (if 0, use a negative value for exact matches)
+	PixelTolerance int
+
+	// Path the absolute difference image is written to on failure.
+	// Default: goldenPath + ".diff.png"
+	DiffPath string
+}
+
+// CompareToGolden compares actual to the golden image at goldenPath and fails t if
+// they differ in size or the similarity is below MinSimilarity. With UpdateGolden
+// the golden image is rewritten with actual instead.
+func (c Comparison) CompareToGolden(t testing.TB, actual *gocv.Mat, goldenPath string) {
+	t.Helper()
+
+	if UpdateGolden() {
+		if err := SaveGoldenImage(goldenPath, actual); err != nil {
+			t.Fatalf("Failed to update golden image: %v", err)
+		}
+		t.Logf("Updated golden image: %s", goldenPath)
+		return
+	}
+
+	golden := gocv.IMRead(goldenPath, gocv.IMReadColor)
+	if golden.Empty() {
+		t.Fatalf("Failed to load golden image: %s (run with -update-golden to create it)", goldenPath)
+	}
+	defer golden.Close()
+
+	if actual.Rows() != golden.Rows() || actual.Cols() != golden.Cols() || actual.Channels() != golden.Channels() {
+		t.Errorf("Image size mismatch: got %dx%d with %d channels, want %dx%d with %d channels",
+			actual.Cols(), actual.Rows(), actual.Channels(), golden.Cols(), golden.Rows(), golden.Channels())
+		return
+	}
+
+	minSimilarity := c.MinSimilarity
+	if minSimilarity == 0 {
+		minSimilarity = 0.95
+	}
+	var similarity float64
+	switch c.Metric {
+	case SSIM:
+		similarity = StructuralSimilarity(actual, &golden)
+	default:
+		pixelTolerance := c.PixelTolerance
+		if pixelTolerance == 0 {
+			pixelTolerance = 5
+		}
+		similarity = ImageSimilarity(actual, &golden, max(pixelTolerance, 0))
+	}
+	if similarity >= minSimilarity {
+		return
+	}
+
+	t.Errorf("Image %s %.2f%% below threshold %.2f%%", c.Metric, similarity*100, minSimilarity*100)
+	diffPath := c.DiffPath
+	if diffPath == "" {
+		diffPath = goldenPath + ".diff.png"
+	}
+	diff := gocv.NewMat()
+	defer diff.Close()
+	if err := gocv.AbsDiff(*actual, golden, &diff); err != nil || !gocv.IMWrite(diffPath, diff) {
+		t.Logf("Failed to save diff to: %s", diffPath)
+		return
+	}
+	t.Logf("Saved diff to: %s", diffPath)
+}
+
+// CompareToGoldenImage compares actual to the golden image at goldenPath with the
+// PixelRatio metric, requiring at least similarity (0.0 to 1.0).
+func CompareToGoldenImage(t testing.TB, actual *gocv.Mat, goldenPath string, similarity float64) {
+	t.Helper()
+	Comparison{MinSimilarity: similarity}.CompareToGolden(t, actual, goldenPath)
+}
+
+// SaveGoldenImage saves an image as a golden reference, creating its directory.
+func SaveGoldenImage(path string, img *gocv.Mat) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create golden image directory: %w", err)
+	}
+	if !gocv.IMWrite(path, *img) {
+		return fmt.Errorf("failed to write image to %s", path)
+	}
+	return nil
+}
+
+// =============================================================================
+// Metrics
+// =============================================================================
+
+// ImageSimilarity compares two 8-bit images and returns the share of pixels whose
+// channels all differ by at most pixelTolerance (0.0 to 1.0), or 0 if their sizes differ.
+func ImageSimilarity(img1, img2 *gocv.Mat, pixelTolerance int) float64 {
+	if img1.Rows() != img2.Rows() || img1.Cols() != img2.Cols() || img1.Channels() != img2.Channels() {
+		return 0.0
+	}
+	if img1.Empty() {
+		return 1.0
+	}
+
+	matchingPixels := 0
+	for y := 0; y < img1.Rows(); y++ {
+		for x := 0; x < img1.Cols(); x++ {
+			pixel1 := img1.GetVecbAt(y, x)
+			pixel2 := img2.GetVecbAt(y, x)
+
+			matches := true
+			for c := range pixel1 {
+				diff := int(pixel1[c]) - int(pixel2[c])
+				if diff < -pixelTolerance || diff > pixelTolerance {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				matchingPixels++
+			}
+		}
+	}
+
+	return float64(matchingPixels) / float64(img1.Rows()*img1.Cols())
+}
+
+// ssimWindow is the side of the square windows of StructuralSimilarity.
+const ssimWindow = 7
+
+// StructuralSimilarity returns the mean structural similarity (SSIM) of two 8-bit
+// images, averaged over channels, or 0 if their sizes differ. Like scikit-image's
+// default it uses uniform 7x7 windows (smaller for smaller images) with sample
+// covariances, K1 = 0.01 and K2 = 0.03.
+func StructuralSimilarity(img1, img2 *gocv.Mat) float64 {
+	if img1.Rows() != img2.Rows() || img1.Cols() != img2.Cols() || img1.Channels() != img2.Channels() {
+		return 0.0
+	}
+	rows, cols, channels := img1.Rows(), img1.Cols(), img1.Channels()
+	window := min(ssimWindow, rows, cols)
+	if window < 2 {
+		// Too small for variances: identical or not
+		if ImageSimilarity(img1, img2, 0) == 1 {
+			return 1.0
+		}
+		return 0.0
+	}
+
+	x := make([][]float64, channels)
+	y := make([][]float64, channels)
+	for c := range x {
+		x[c] = make([]float64, rows*cols)
+		y[c] = make([]float64, rows*cols)
+	}
+	for r := 0; r < rows; r++ {
+		for col := 0; col < cols; col++ {
+			pixel1 := img1.GetVecbAt(r, col)
+			pixel2 := img2.GetVecbAt(r, col)
+			for c := range x {
+				x[c][r*cols+col] = float64(pixel1[c])
+				y[c][r*cols+col] = float64(pixel2[c])
+			}
+		}
+	}
+
+	total := 0.0
+	for c := range x {
+		total += channelSSIM(x[c], y[c], rows, cols, window)
+	}
+	return total / float64(channels)
+}
+
+// channelSSIM returns the mean SSIM of all window positions of one channel, using
+// summed-area tables for the window statistics.
+func channelSSIM(x, y []float64, rows, cols, window int) float64 {
+	const dynamicRange = 255.0
+	c1 := (0.01 * dynamicRange) * (0.01 * dynamicRange)
+	c2 := (0.03 * dynamicRange) * (0.03 * dynamicRange)
+
+	sumX := summedArea(rows, cols, func(i int) float64 { return x[i] })
+	sumY := summedArea(rows, cols, func(i int) float64 { return y[i] })
+	sumXX := summedArea(rows, cols, func(i int) float64 { return x[i] * x[i] })
+	sumYY := summedArea(rows, cols, func(i int) float64 { return y[i] * y[i] })
+	sumXY := summedArea(rows, cols, func(i int) float64 { return x[i] * y[i] })
+
+	n := float64(window * window)
+	covNorm := n / (n - 1)
+	total := 0.0
+	count := 0
+	for r := 0; r+window <= rows; r++ {
+		for col := 0; col+window <= cols; col++ {
+			box := func(sums []float64) float64 {
+				return windowSum(sums, cols, r, col, window) / n
+			}
+			meanX, meanY := box(sumX), box(sumY)
+			varX := covNorm * (box(sumXX) - meanX*meanX)
+			varY := covNorm * (box(sumYY) - meanY*meanY)
+			covXY := covNorm * (box(sumXY) - meanX*meanY)
+
+			total += ((2*meanX*meanY + c1) * (2*covXY + c2)) /
+				((meanX*meanX + meanY*meanY + c1) * (varX + varY + c2))
+			count++
+		}
+	}
+	return total / float64(count)
+}
+
+// summedArea returns the (rows+1) x (cols+1) summed-area table of f over the
+// row-major pixel indices of a rows x cols channel.
+func summedArea(rows, cols int, f func(i int) float64) []float64 {
+	sums := make([]float64, (rows+1)*(cols+1))
+	for r := 0; r < rows; r++ {
+		rowSum := 0.0
+		for col := 0; col < cols; col++ {
+			rowSum += f(r*cols + col)
+			sums[(r+1)*(cols+1)+col+1] = sums[r*(cols+1)+col+1] + rowSum
+		}
+	}
+	return sums
+}
+
+// windowSum returns the sum of the window x window block at (r, col) from a summed-area table.
+func windowSum(sums []float64, cols, r, col, window int) float64 {
+	stride := cols + 1
+	return sums[(r+window)*stride+col+window] - sums[r*stride+col+window] -
+		sums[(r+window)*stride+col] + sums[r*stride+col]
+}
@@ -0,0 +1,168 @@
+//go:build !nogocv
+
+package norfairgotest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gocv.io/x/gocv"
+)
+
+// recordingTB captures failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper()                         {}
+func (r *recordingTB) Logf(format string, args ...any) {}
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// gradientImage returns a BGR image with a horizontal gradient and a bright square.
+func gradientImage(rows, cols int) gocv.Mat {
+	data := make([]byte, rows*cols*3)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			v := byte(x * 255 / cols)
+			if x >= 10 && x < 20 && y >= 10 && y < 20 {
+				v = 250
+			}
+			for c := 0; c < 3; c++ {
+				data[(y*cols+x)*3+c] = v
+			}
+		}
+	}
+	img, _ := gocv.NewMatFromBytes(rows, cols, gocv.MatTypeCV8UC3, data)
+	return img
+}
+
+// shiftImage returns img with every channel value increased by delta (clamped).
+func shiftImage(img gocv.Mat, delta int) gocv.Mat {
+	data := img.ToBytes()
+	for i, v := range data {
+		data[i] = byte(min(max(int(v)+delta, 0), 255))
+	}
+	shifted, _ := gocv.NewMatFromBytes(img.Rows(), img.Cols(), img.Type(), data)
+	return shifted
+}
+
+func TestImageSimilarity(t *testing.T) {
+	img := gradientImage(30, 40)
+	defer img.Close()
+	slightly := shiftImage(img, 3)
+	defer slightly.Close()
+	other := gradientImage(30, 41)
+	defer other.Close()
+
+	if s := ImageSimilarity(&img, &img, 0); s != 1 {
+		t.Errorf("Expected identical images to have similarity 1, got %v", s)
+	}
+	if s := ImageSimilarity(&img, &slightly, 5); s != 1 {
+		t.Errorf("Expected differences within tolerance to match, got %v", s)
+	}
+	if s := ImageSimilarity(&img, &slightly, 2); s >= 0.1 {
+		t.Errorf("Expected differences above tolerance not to match, got %v", s)
+	}
+	if s := ImageSimilarity(&img, &other, 5); s != 0 {
+		t.Errorf("Expected size mismatch to have similarity 0, got %v", s)
+	}
+}
+
+func TestStructuralSimilarity(t *testing.T) {
+	img := gradientImage(30, 40)
+	defer img.Close()
+	brighter := shiftImage(img, 3)
+	defer brighter.Close()
+	inverted := gocv.NewMat()
+	defer inverted.Close()
+	white := shiftImage(img, 255)
+	defer white.Close()
+	if err := gocv.AbsDiff(white, img, &inverted); err != nil {
+		t.Fatalf("AbsDiff failed: %v", err)
+	}
+
+	if s := StructuralSimilarity(&img, &img); s < 0.9999 {
+		t.Errorf("Expected identical images to have SSIM 1, got %v", s)
+	}
+	if s := StructuralSimilarity(&img, &brighter); s < 0.95 || s >= 1 {
+		t.Errorf("Expected a small brightness shift to keep SSIM high, got %v", s)
+	}
+	if s := StructuralSimilarity(&img, &inverted); s > 0 {
+		t.Errorf("Expected an inverted image to have negative SSIM, got %v", s)
+	}
+
+	tiny := gradientImage(1, 1)
+	defer tiny.Close()
+	if s := StructuralSimilarity(&tiny, &tiny); s != 1 {
+		t.Errorf("Expected identical 1x1 images to have SSIM 1, got %v", s)
+	}
+}
+
+func TestUpdateGolden_NoGlobalFlag(t *testing.T) {
+	// Importing the package must not register flags on the test binary
+	if flag.Lookup("update-golden") != nil {
+		t.Error("Expected no update-golden flag on flag.CommandLine")
+	}
+
+	for value, expected := range map[string]bool{
+		"1": true, "true": true, "TRUE": true,
+		"": false, "0": false, "false": false, "no": false,
+	} {
+		t.Setenv(UpdateEnv, value)
+		if UpdateGolden() != expected {
+			t.Errorf("Expected %s=%q to give %v", UpdateEnv, value, expected)
+		}
+	}
+}
+
+func TestCompareToGolden(t *testing.T) {
+	goldenPath := filepath.Join(t.TempDir(), "golden", "overlay.png")
+	img := gradientImage(30, 40)
+	defer img.Close()
+
+	// Regeneration writes the golden image, creating its directory
+	t.Setenv(UpdateEnv, "1")
+	CompareToGoldenImage(t, &img, goldenPath, 0.95)
+	if _, err := os.Stat(goldenPath); err != nil {
+		t.Fatalf("Expected golden image to be written: %v", err)
+	}
+	t.Setenv(UpdateEnv, "")
+
+	for _, metric := range []Metric{PixelRatio, SSIM} {
+		comparison := Comparison{Metric: metric, MinSimilarity: 0.99}
+
+		recorder := &recordingTB{TB: t}
+		comparison.CompareToGolden(recorder, &img, goldenPath)
+		if len(recorder.errors) != 0 {
+			t.Errorf("%s: expected identical image to pass, got %v", metric, recorder.errors)
+		}
+
+		// A mismatch fails and writes the diff
+		changed := shiftImage(img, 40)
+		comparison.DiffPath = filepath.Join(t.TempDir(), "diff.png")
+		recorder = &recordingTB{TB: t}
+		comparison.CompareToGolden(recorder, &changed, goldenPath)
+		changed.Close()
+		if len(recorder.errors) != 1 {
+			t.Errorf("%s: expected 1 failure, got %v", metric, recorder.errors)
+		}
+		if _, err := os.Stat(comparison.DiffPath); err != nil {
+			t.Errorf("%s: expected diff image to be written: %v", metric, err)
+		}
+	}
+
+	// Size mismatches fail without comparing
+	smaller := gradientImage(20, 40)
+	defer smaller.Close()
+	recorder := &recordingTB{TB: t}
+	CompareToGoldenImage(recorder, &smaller, goldenPath, 0.5)
+	if len(recorder.errors) != 1 {
+		t.Errorf("Expected a size mismatch failure, got %v", recorder.errors)
+	}
+}
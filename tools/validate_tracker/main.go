@@ -0,0 +1,361 @@
+// Command validate_tracker cross-validates the Go tracker against Python norfair.
+//
+// It replays the detections of recorded Python fixtures (testdata/fixtures, as
+// produced by norfair's Tracker.update) through norfairgo, converts the tracked
+// objects of both implementations to MOTChallenge prediction rows, diffs them
+// frame by frame and reports divergence statistics per fixture.
+//
+// Usage:
+//
+//	go run ./tools/validate_tracker [-fixtures testdata/fixtures] [-tolerance 1e-6] [-out dir] [-v] [name ...]
+//
+// Names select fixtures by scenario (e.g. "small" for fixture_small.json); all
+// fixtures are validated by default. With -out, the Python and Go prediction
+// files are written as <name>_python.txt and <name>_go.txt for further
+// inspection. The exit status is 1 if any fixture diverges.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nmichlo/norfair-go/pkg/norfairgo"
+	"gonum.org/v1/gonum/mat"
+)
+
+// =============================================================================
+// Fixture Schema (see pkg/norfairgo/fixture_test.go)
+// =============================================================================
+
+type fixture struct {
+	TrackerConfig struct {
+		DistanceFunction      string   `json:"distance_function"`
+		DistanceThreshold     float64  `json:"distance_threshold"`
+		HitCounterMax         int      `json:"hit_counter_max"`
+		InitializationDelay   int      `json:"initialization_delay"`
+		ReidDistanceThreshold *float64 `json:"reid_distance_threshold,omitempty"`
+		ReidHitCounterMax     *int     `json:"reid_hit_counter_max,omitempty"`
+	} `json:"tracker_config"`
+	Steps []struct {
+		FrameID int `json:"frame_id"`
+		Inputs  struct {
+			Detections []struct {
+				Bbox []float64 `json:"bbox"`
+			} `json:"detections"`
+		} `json:"inputs"`
+		Outputs struct {
+			TrackedObjects []struct {
+				ID       *int        `json:"id"`
+				Estimate [][]float64 `json:"estimate"`
+			} `json:"tracked_objects"`
+		} `json:"outputs"`
+	} `json:"steps"`
+}
+
+func loadFixture(path string) (*fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// =============================================================================
+// Predictions
+// =============================================================================
+
+// prediction is a MOTChallenge prediction row: frame, id, x, y, w, h (1-based frame).
+type prediction struct {
+	Frame int
+	ID    int
+	Box   [4]float64
+}
+
+// predictionRow builds a prediction row from a 2-point [[x1, y1], [x2, y2]] estimate.
+func predictionRow(frame, id int, estimate [][]float64) prediction {
+	x1, y1 := estimate[0][0], estimate[0][1]
+	x2, y2 := estimate[1][0], estimate[1][1]
+	return prediction{Frame: frame, ID: id, Box: [4]float64{x1, y1, x2 - x1, y2 - y1}}
+}
+
+// pythonPredictions returns the prediction rows recorded in the fixture.
+func pythonPredictions(f *fixture) []prediction {
+	var rows []prediction
+	for _, step := range f.Steps {
+		for _, obj := range step.Outputs.TrackedObjects {
+			if obj.ID != nil {
+				rows = append(rows, predictionRow(step.FrameID+1, *obj.ID, obj.Estimate))
+			}
+		}
+	}
+	return rows
+}
+
+// goPredictions replays the fixture detections through norfairgo.Tracker, configured
+// like the Python tracker that recorded the fixture.
+func goPredictions(f *fixture) ([]prediction, error) {
+	config := f.TrackerConfig
+	trackerConfig := &norfairgo.TrackerConfig{
+		DistanceFunction:    norfairgo.DistanceByName(config.DistanceFunction),
+		DistanceThreshold:   config.DistanceThreshold,
+		HitCounterMax:       config.HitCounterMax,
+		InitializationDelay: config.InitializationDelay,
+		ReidHitCounterMax:   config.ReidHitCounterMax,
+	}
+	if config.ReidDistanceThreshold != nil {
+		// The fixtures record ReID callables by name only; they use the main distance
+		trackerConfig.ReidDistanceFunction = norfairgo.DistanceByName(config.DistanceFunction)
+		trackerConfig.ReidDistanceThreshold = *config.ReidDistanceThreshold
+	}
+
+	// IDs are global like in Python, restart them to match the fixture
+	norfairgo.ResetGlobalCount()
+	tracker, err := norfairgo.NewTracker(trackerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracker: %w", err)
+	}
+
+	var rows []prediction
+	for _, step := range f.Steps {
+		detections := make([]*norfairgo.Detection, 0, len(step.Inputs.Detections))
+		for _, det := range step.Inputs.Detections {
+			detection, err := norfairgo.NewDetectionFromBox(det.Bbox[0], det.Bbox[1], det.Bbox[2], det.Bbox[3], nil)
+			if err != nil {
+				return nil, fmt.Errorf("frame %d: %w", step.FrameID, err)
+			}
+			detections = append(detections, detection)
+		}
+		for _, obj := range tracker.Update(detections, 1, nil) {
+			if obj.ID != nil {
+				rows = append(rows, predictionRow(step.FrameID+1, *obj.ID, denseRows(obj.Estimate)))
+			}
+		}
+	}
+	return rows, nil
+}
+
+func denseRows(m *mat.Dense) [][]float64 {
+	rows, _ := m.Dims()
+	result := make([][]float64, rows)
+	for i := range result {
+		result[i] = m.RawRowView(i)
+	}
+	return result
+}
+
+// writePredictions writes prediction rows in the MOTChallenge format.
+func writePredictions(path string, rows []prediction) error {
+	var b strings.Builder
+	for _, row := range rows {
+		fmt.Fprintf(&b, "%d,%d,%.6f,%.6f,%.6f,%.6f,-1,-1,-1,-1\n",
+			row.Frame, row.ID, row.Box[0], row.Box[1], row.Box[2], row.Box[3])
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// =============================================================================
+// Divergence
+// =============================================================================
+
+// divergence summarizes the differences between Python and Go prediction rows.
+type divergence struct {
+	Frames      int     // Frames with at least one prediction
+	Python      int     // Python prediction rows
+	Go          int     // Go prediction rows
+	Matched     int     // Rows with the same frame and ID in both
+	MissingInGo int     // Python rows without a Go row
+	ExtraInGo   int     // Go rows without a Python row
+	BoxErrors   int     // Matched rows with a coordinate error above the tolerance
+	MaxError    float64 // Maximum absolute coordinate error of matched rows
+	MeanError   float64 // Mean absolute coordinate error of matched rows
+	FirstFrame  int     // First diverging frame (0 if none)
+	Details     []detail
+}
+
+// detail describes a single diverging prediction row.
+type detail struct {
+	Frame   int
+	Message string
+}
+
+func (d *divergence) diverged() bool {
+	return d.MissingInGo > 0 || d.ExtraInGo > 0 || d.BoxErrors > 0
+}
+
+func (d *divergence) record(frame int, format string, args ...any) {
+	if d.FirstFrame == 0 || frame < d.FirstFrame {
+		d.FirstFrame = frame
+	}
+	d.Details = append(d.Details, detail{Frame: frame, Message: fmt.Sprintf(format, args...)})
+}
+
+type predictionKey struct{ frame, id int }
+
+// diffPredictions matches rows by frame and ID and compares their boxes.
+func diffPredictions(python, goRows []prediction, tolerance float64) *divergence {
+	d := &divergence{Python: len(python), Go: len(goRows)}
+
+	goByKey := make(map[predictionKey]prediction, len(goRows))
+	frames := make(map[int]bool)
+	for _, row := range goRows {
+		goByKey[predictionKey{row.Frame, row.ID}] = row
+		frames[row.Frame] = true
+	}
+
+	var sumError float64
+	matched := make(map[predictionKey]bool, len(python))
+	for _, row := range python {
+		frames[row.Frame] = true
+		key := predictionKey{row.Frame, row.ID}
+		other, ok := goByKey[key]
+		if !ok {
+			d.MissingInGo++
+			d.record(row.Frame, "id %d missing in Go", row.ID)
+			continue
+		}
+		matched[key] = true
+		d.Matched++
+
+		var rowError float64
+		for i := range row.Box {
+			rowError = math.Max(rowError, math.Abs(row.Box[i]-other.Box[i]))
+			sumError += math.Abs(row.Box[i] - other.Box[i])
+		}
+		d.MaxError = math.Max(d.MaxError, rowError)
+		if rowError > tolerance {
+			d.BoxErrors++
+			d.record(row.Frame, "id %d box %v, expected %v (error %g)", row.ID, other.Box, row.Box, rowError)
+		}
+	}
+	for _, row := range goRows {
+		if !matched[predictionKey{row.Frame, row.ID}] {
+			d.ExtraInGo++
+			d.record(row.Frame, "id %d only in Go", row.ID)
+		}
+	}
+
+	d.Frames = len(frames)
+	if d.Matched > 0 {
+		d.MeanError = sumError / float64(4*d.Matched)
+	}
+	sort.SliceStable(d.Details, func(i, j int) bool {
+		return d.Details[i].Frame < d.Details[j].Frame
+	})
+	return d
+}
+
+// validateFixture replays a fixture and diffs the Python and Go predictions.
+// With a non-empty outDir, both prediction files are written there.
+func validateFixture(path, outDir string, tolerance float64) (*divergence, error) {
+	f, err := loadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	python := pythonPredictions(f)
+	goRows, err := goPredictions(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if outDir != "" {
+		name := fixtureName(path)
+		if err := writePredictions(filepath.Join(outDir, name+"_python.txt"), python); err != nil {
+			return nil, err
+		}
+		if err := writePredictions(filepath.Join(outDir, name+"_go.txt"), goRows); err != nil {
+			return nil, err
+		}
+	}
+	return diffPredictions(python, goRows, tolerance), nil
+}
+
+func fixtureName(path string) string {
+	return strings.TrimPrefix(strings.TrimSuffix(filepath.Base(path), ".json"), "fixture_")
+}
+
+// =============================================================================
+// Main
+// =============================================================================
+
+func main() {
+	fixturesDir := flag.String("fixtures", "testdata/fixtures", "directory of fixture_<name>.json files")
+	tolerance := flag.Float64("tolerance", 1e-6, "maximum absolute box coordinate error")
+	outDir := flag.String("out", "", "directory to write <name>_python.txt and <name>_go.txt predictions")
+	verbose := flag.Bool("v", false, "print every divergence")
+	flag.Parse()
+
+	paths, err := fixturePaths(*fixturesDir, flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *outDir != "" {
+		if err := os.MkdirAll(*outDir, 0755); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	failed := false
+	fmt.Printf("%-16s %6s %6s %6s %7s %7s %7s %6s %10s %10s %s\n",
+		"fixture", "frames", "python", "go", "matched", "missing", "extra", "boxerr", "max_err", "mean_err", "status")
+	for _, path := range paths {
+		d, err := validateFixture(path, *outDir, *tolerance)
+		if err != nil {
+			fmt.Printf("%-16s ERROR %v\n", fixtureName(path), err)
+			failed = true
+			continue
+		}
+		status := "OK"
+		if d.diverged() {
+			status = fmt.Sprintf("DIVERGED (first frame %d)", d.FirstFrame)
+			failed = true
+		}
+		fmt.Printf("%-16s %6d %6d %6d %7d %7d %7d %6d %10.3g %10.3g %s\n",
+			fixtureName(path), d.Frames, d.Python, d.Go, d.Matched, d.MissingInGo, d.ExtraInGo, d.BoxErrors, d.MaxError, d.MeanError, status)
+		if *verbose {
+			for _, detail := range d.Details {
+				fmt.Printf("    frame %d: %s\n", detail.Frame, detail.Message)
+			}
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// fixturePaths returns the fixture files for the given scenario names, or all
+// fixtures in the directory if no names are given.
+func fixturePaths(dir string, names []string) ([]string, error) {
+	if len(names) == 0 {
+		paths, err := filepath.Glob(filepath.Join(dir, "fixture_*.json"))
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no fixtures found in %s", dir)
+		}
+		sort.Strings(paths)
+		return paths, nil
+	}
+
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(dir, "fixture_"+name+".json")
+		if _, err := os.Stat(paths[i]); err != nil {
+			return nil, fmt.Errorf("unknown fixture %q: %w", name, err)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateFixtures(t *testing.T) {
+	paths, err := fixturePaths("../../testdata/fixtures", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	for _, path := range paths {
+		d, err := validateFixture(path, outDir, 1e-6)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if d.diverged() || d.Matched == 0 {
+			t.Errorf("%s diverged: %+v", fixtureName(path), d)
+		}
+		for _, suffix := range []string{"_python.txt", "_go.txt"} {
+			if _, err := os.Stat(filepath.Join(outDir, fixtureName(path)+suffix)); err != nil {
+				t.Errorf("Expected predictions file: %v", err)
+			}
+		}
+	}
+}
+
+func TestDiffPredictions(t *testing.T) {
+	python := []prediction{
+		{Frame: 1, ID: 1, Box: [4]float64{0, 0, 10, 10}},
+		{Frame: 2, ID: 1, Box: [4]float64{1, 0, 10, 10}},
+		{Frame: 2, ID: 2, Box: [4]float64{5, 5, 10, 10}},
+	}
+	goRows := []prediction{
+		{Frame: 1, ID: 1, Box: [4]float64{0, 0, 10, 10}},
+		{Frame: 2, ID: 1, Box: [4]float64{1.5, 0, 10, 10}},
+		{Frame: 3, ID: 3, Box: [4]float64{0, 0, 1, 1}},
+	}
+
+	d := diffPredictions(python, goRows, 1e-6)
+	if d.Frames != 3 || d.Matched != 2 || d.MissingInGo != 1 || d.ExtraInGo != 1 || d.BoxErrors != 1 {
+		t.Errorf("Unexpected counts: %+v", d)
+	}
+	if d.MaxError != 0.5 || d.MeanError != 0.5/8 {
+		t.Errorf("Expected max error 0.5 and mean error 0.0625, got %v and %v", d.MaxError, d.MeanError)
+	}
+	if !d.diverged() || d.FirstFrame != 2 {
+		t.Errorf("Expected divergence from frame 2, got %v (diverged=%v)", d.FirstFrame, d.diverged())
+	}
+}